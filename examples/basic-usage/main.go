@@ -16,6 +16,7 @@ import (
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/retry"
 )
 
 // AppConfig 应用程序配置结构体
@@ -85,27 +86,32 @@ func (bs *BusinessService) RetryOperation(ctx context.Context, operation string)
 	bs.logger.CtxInfof(ctx, "Attempting operation: %s (max retries: %d)", 
 		operation, bs.config.App.MaxRetries)
 
-	var lastErr error
-	for i := 0; i < bs.config.App.MaxRetries; i++ {
-		bs.logger.CtxInfof(ctx, "Attempt %d/%d for operation: %s", 
-			i+1, bs.config.App.MaxRetries, operation)
+	attempt := 0
+	opts := retry.NewOptions()
+	opts.MaxAttempts = bs.config.App.MaxRetries
+	opts.InitialInterval = 10 * time.Millisecond
+	retrier, err := retry.New(opts, retry.WithLogger(bs.logger), retry.WithName(operation))
+	if err != nil {
+		return err
+	}
+	err = retrier.Do(ctx, func(ctx context.Context) error {
+		attempt++
+		bs.logger.CtxInfof(ctx, "Attempt %d/%d for operation: %s",
+			attempt, bs.config.App.MaxRetries, operation)
 
 		// 模拟可能失败的操作 (Simulate potentially failing operation)
-		if operation == "flaky" && i < 2 {
-			lastErr = errors.Errorf("attempt %d failed", i+1)
-			bs.logger.CtxWarnf(ctx, "Operation failed, will retry: %v", lastErr)
-			continue
+		if operation == "flaky" && attempt < 3 {
+			return errors.Errorf("attempt %d failed", attempt)
 		}
-
-		bs.logger.CtxInfof(ctx, "Operation succeeded on attempt %d", i+1)
+		return nil
+	})
+	if err == nil {
+		bs.logger.CtxInfof(ctx, "Operation succeeded on attempt %d", attempt)
 		return nil
 	}
 
 	// 所有重试都失败了 (All retries failed)
-	finalErr := errors.WithCode(
-		errors.Wrapf(lastErr, "operation failed after %d retries", bs.config.App.MaxRetries),
-		errors.ErrConfigSetup, // 使用示例错误码 (Using example error code)
-	)
+	finalErr := errors.WithCode(err, errors.ErrConfigSetup) // 使用示例错误码 (Using example error code)
 	bs.logger.CtxErrorf(ctx, "Operation ultimately failed: %v", finalErr)
 	return finalErr
 }
@@ -73,9 +73,9 @@ type CollectionsConfig struct {
 	IntSlice       []int    `mapstructure:"int_slice" default:"1,2,3,4,5"`
 	FloatSlice     []float64 `mapstructure:"float_slice" default:"1.1,2.2,3.3"`
 	
-	// 映射类型 (Map types) - 注意：映射的默认值处理比较复杂
-	StringMap      map[string]string `mapstructure:"string_map"`
-	IntMap         map[string]int    `mapstructure:"int_map"`
+	// 映射类型 (Map types) - 默认值使用 JSON 对象字面量 (defaults use JSON object literals)
+	StringMap      map[string]string `mapstructure:"string_map" default:"{\"env\":\"dev\",\"region\":\"local\"}"`
+	IntMap         map[string]int    `mapstructure:"int_map" default:"{\"retries\":3,\"workers\":4}"`
 }
 
 // NestedConfig 嵌套结构默认值配置
@@ -242,8 +242,8 @@ func (dva *DefaultValueAnalyzer) analyzeCollections(c *CollectionsConfig) {
 	fmt.Printf("  Single Item: %v (expected: [single])\n", c.SingleItem)
 	fmt.Printf("  Int Slice: %v (expected: [1 2 3 4 5])\n", c.IntSlice)
 	fmt.Printf("  Float Slice: %v (expected: [1.1 2.2 3.3])\n", c.FloatSlice)
-	fmt.Printf("  String Map: %v (maps need special handling)\n", c.StringMap)
-	fmt.Printf("  Int Map: %v (maps need special handling)\n", c.IntMap)
+	fmt.Printf("  String Map: %v\n", c.StringMap)
+	fmt.Printf("  Int Map: %v\n", c.IntMap)
 }
 
 // analyzeNested 分析嵌套结构默认值
@@ -383,8 +383,8 @@ func printDefaultValueBestPractices() {
 	
 	fmt.Println("3. Collections and Maps:")
 	fmt.Println("   ✓ Slices can use comma-separated default values")
-	fmt.Println("   ⚠ Maps need special handling (no direct tag support)")
-	fmt.Println("   ✓ Consider using factory functions for map initialization")
+	fmt.Println("   ✓ Use JSON object literals for map defaults: default:\"{\\\"k\\\":\\\"v\\\"}\"")
+	fmt.Println("   ✓ Config file entries are merged over map defaults")
 	fmt.Println("   ✓ Document expected map structure clearly")
 	fmt.Println()
 	
@@ -10,13 +10,15 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
 )
 
-// 自定义错误码 (Custom error codes)
+// 自定义错误码；通用场景（不可用、限流、内部错误等）直接使用 errors 包的标准 Coder
+// (Custom error codes; generic cases such as unavailable, rate limited or internal errors use the standard Coders of the errors package)
 var (
 	// 业务逻辑错误码 (Business logic error codes)
 	ErrUserNotFound      = errors.NewCoder(1001, 404, "UserNotFound", "User not found")
@@ -33,12 +35,9 @@ var (
 	
 	// 外部服务错误码 (External service error codes)
 	ErrExternalService    = errors.NewCoder(3001, 502, "ExternalService", "External service error")
-	ErrServiceUnavailable = errors.NewCoder(3002, 503, "ServiceUnavailable", "Service temporarily unavailable")
-	ErrRateLimitExceeded  = errors.NewCoder(3003, 429, "RateLimitExceeded", "Rate limit exceeded")
 	ErrAPIQuotaExceeded   = errors.NewCoder(3004, 402, "APIQuotaExceeded", "API quota exceeded")
 	
 	// 系统错误码 (System error codes)
-	ErrInternalServer     = errors.NewCoder(5001, 500, "InternalServer", "Internal server error")
 	ErrConfigurationError = errors.NewCoder(5002, 500, "ConfigurationError", "Configuration error")
 	ErrResourceExhausted  = errors.NewCoder(5003, 503, "ResourceExhausted", "Resource exhausted")
 	ErrMaintenanceMode    = errors.NewCoder(5004, 503, "MaintenanceMode", "System in maintenance mode")
@@ -305,7 +304,7 @@ func (s *UserService) checkRateLimit(clientID string) error {
 	if exists && time.Since(lastRequest) < s.rateLimit {
 		return errors.WithCode(
 			errors.Errorf("rate limit exceeded for client %s", clientID),
-			ErrRateLimitExceeded,
+			errors.ErrRateLimited,
 		)
 	}
 	
@@ -352,7 +351,7 @@ func (s *UserService) validateWithExternalService(user *User) error {
 	if user.Email == "service@unavailable.com" {
 		return errors.WithCode(
 			errors.New("email validation service is unavailable"),
-			ErrServiceUnavailable,
+			errors.ErrUnavailable,
 		)
 	}
 	
@@ -367,64 +366,38 @@ func (s *UserService) validateWithExternalService(user *User) error {
 	return nil
 }
 
-// HTTPErrorMapper HTTP错误映射器
-// (HTTPErrorMapper maps errors to HTTP status codes)
-type HTTPErrorMapper struct{}
+// init 注册自定义错误码，errors.HTTPStatus 和 errors.WriteHTTPError 据此得到每个错误码的 HTTP 状态；
+// 重复的错误码会在启动时 panic
+// (init registers the custom error codes so errors.HTTPStatus and errors.WriteHTTPError know the HTTP status of each;
+// a duplicate code panics at startup)
+func init() {
+	errors.MustRegisterCoder(
+		ErrUserNotFound, ErrUserAlreadyExists, ErrInvalidUserData, ErrUserDeactivated, ErrInsufficientPermissions,
+		ErrDatabaseConnection, ErrDatabaseTimeout, ErrDatabaseConstraint, ErrDatabaseSchema,
+		ErrExternalService, ErrAPIQuotaExceeded,
+		ErrConfigurationError, ErrResourceExhausted, ErrMaintenanceMode,
+	)
+}
 
-// MapErrorToHTTPStatus 将错误码映射到HTTP状态码
-// (MapErrorToHTTPStatus maps error codes to HTTP status codes)
-func (m *HTTPErrorMapper) MapErrorToHTTPStatus(err error) (int, string) {
-	if err == nil {
-		return http.StatusOK, "OK"
+// demonstrateCodeCatalog 演示错误码冲突检测和错误码目录
+// (demonstrateCodeCatalog demonstrates code collision detection and the error code catalog)
+func demonstrateCodeCatalog() {
+	fmt.Println("=== Error Code Catalog ===")
+
+	// 另一个模块复用了 1001 (Another module reuses 1001)
+	errOrderNotFound := errors.NewCoder(1001, 404, "OrderNotFound", "Order not found")
+	if err := errors.RegisterCoder(errOrderNotFound); err != nil {
+		fmt.Printf("Registration rejected: %v\n", err)
 	}
-	
-	coder := errors.GetCoder(err)
-	if coder == nil {
-		return http.StatusInternalServerError, "Internal Server Error"
-	}
-	
-	switch coder.Code() {
-	// 业务逻辑错误 (Business logic errors)
-	case ErrUserNotFound.Code():
-		return http.StatusNotFound, "User Not Found"
-	case ErrUserAlreadyExists.Code():
-		return http.StatusConflict, "User Already Exists"
-	case ErrInvalidUserData.Code():
-		return http.StatusBadRequest, "Invalid User Data"
-	case ErrUserDeactivated.Code():
-		return http.StatusForbidden, "User Account Deactivated"
-	case ErrInsufficientPermissions.Code():
-		return http.StatusForbidden, "Insufficient Permissions"
-		
-	// 数据访问错误 (Data access errors)
-	case ErrDatabaseConnection.Code(), ErrDatabaseTimeout.Code():
-		return http.StatusServiceUnavailable, "Database Service Unavailable"
-	case ErrDatabaseConstraint.Code():
-		return http.StatusConflict, "Data Constraint Violation"
-	case ErrDatabaseSchema.Code():
-		return http.StatusInternalServerError, "Database Schema Error"
-		
-	// 外部服务错误 (External service errors)
-	case ErrExternalService.Code(), ErrServiceUnavailable.Code():
-		return http.StatusBadGateway, "External Service Error"
-	case ErrRateLimitExceeded.Code():
-		return http.StatusTooManyRequests, "Rate Limit Exceeded"
-	case ErrAPIQuotaExceeded.Code():
-		return http.StatusPaymentRequired, "API Quota Exceeded"
-		
-	// 系统错误 (System errors)
-	case ErrInternalServer.Code():
-		return http.StatusInternalServerError, "Internal Server Error"
-	case ErrConfigurationError.Code():
-		return http.StatusInternalServerError, "Configuration Error"
-	case ErrResourceExhausted.Code():
-		return http.StatusServiceUnavailable, "Resource Exhausted"
-	case ErrMaintenanceMode.Code():
-		return http.StatusServiceUnavailable, "Service Under Maintenance"
-		
-	default:
-		return http.StatusInternalServerError, "Unknown Error"
+
+	// 只列出本示例的错误码 (List only the codes of this example)
+	var catalog errors.CodeCatalog
+	for _, entry := range errors.Catalog() {
+		if entry.Code < 10000 {
+			catalog = append(catalog, entry)
+		}
 	}
+	fmt.Println(catalog.Markdown())
 }
 
 // analyzeError 分析错误码信息
@@ -448,9 +421,14 @@ func analyzeError(err error) {
 		fmt.Printf("Error Message: %s\n", coder.Error())
 		
 		// HTTP映射 (HTTP mapping)
-		mapper := &HTTPErrorMapper{}
-		httpStatus, httpMessage := mapper.MapErrorToHTTPStatus(err)
-		fmt.Printf("HTTP Status: %d %s\n", httpStatus, httpMessage)
+		httpStatus := errors.HTTPStatus(err)
+		fmt.Printf("HTTP Status: %d %s\n", httpStatus, http.StatusText(httpStatus))
+		
+		// 标准 JSON 错误响应 (Standard JSON error response)
+		rec := httptest.NewRecorder()
+		rec.Header().Set(errors.RequestIDHeader, "req-example")
+		errors.WriteHTTPError(rec, err)
+		fmt.Printf("HTTP Body: %s", rec.Body.String())
 	} else {
 		fmt.Println("No error code information available")
 	}
@@ -650,14 +628,17 @@ func demonstrateErrorCodeComparison() {
 			fmt.Printf("  String: %s\n", coder.String())
 			
 			// 检查特定错误类型 (Check specific error types)
-			switch coder.Code() {
-			case ErrUserNotFound.Code():
+			switch {
+			case errors.IsCode(err, ErrUserNotFound):
 				fmt.Printf("  Type: User not found error\n")
-			case ErrInvalidUserData.Code():
+			case errors.IsCode(err, ErrInvalidUserData):
 				fmt.Printf("  Type: Validation error\n")
-			case ErrDatabaseConnection.Code():
+			case errors.IsCode(err, ErrDatabaseConnection):
 				fmt.Printf("  Type: Database error\n")
 			}
+			if errors.HasAnyCode(err, ErrUserNotFound, ErrInvalidUserData) {
+				fmt.Printf("  Retryable: no (client error)\n")
+			}
 		}
 		fmt.Println()
 	}
@@ -691,6 +672,9 @@ func main() {
 	// 6. 演示错误码比较 (Demonstrate error code comparison)
 	demonstrateErrorCodeComparison()
 	
+	// 7. 演示错误码目录 (Demonstrate the error code catalog)
+	demonstrateCodeCatalog()
+	
 	logger.Info("Error codes example completed successfully")
 	fmt.Println("=== Example completed successfully ===")
 } 
\ No newline at end of file
@@ -577,6 +577,27 @@ func demonstratePerformanceImpact() {
 		fmt.Printf("  ✓ Acceptable overhead\n")
 	}
 	
+	// 测试3：热点路径上的 errors.NewNoStack（单次调用不捕获堆栈）
+	// (Test 3: errors.NewNoStack on a hot path (no stack capture for this call))
+	start = time.Now()
+	for i := 0; i < iterations; i++ {
+		_ = errors.NewNoStack("test error without stack capture")
+	}
+	noStack := time.Since(start)
+	
+	// 测试4：浅层捕获，只保留最近的 4 帧
+	// (Test 4: shallow capture keeping only the innermost 4 frames)
+	previous := errors.SetStackCaptureDepth(4)
+	start = time.Now()
+	for i := 0; i < iterations; i++ {
+		_ = errors.New("test error with a shallow stack trace")
+	}
+	shallowStack := time.Since(start)
+	errors.SetStackCaptureDepth(previous)
+	
+	fmt.Printf("  errors.NewNoStack:    %v\n", noStack)
+	fmt.Printf("  Stack depth 4:        %v\n", shallowStack)
+	
 	fmt.Println()
 }
 
@@ -8,130 +8,56 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/retry"
 )
 
-// ErrorGroup 错误组实现
-// (ErrorGroup implementation for collecting multiple errors)
+// ErrorGroup 在 errors.ErrorGroup 之上添加示例使用的查询辅助方法
+// (ErrorGroup adds the query helpers used by this example on top of errors.ErrorGroup,
+// which already provides concurrency-safe Add, Errors, HasErrors, Len, Reset and JSON output)
 type ErrorGroup struct {
-	mu     sync.Mutex
-	errors []error
+	*errors.ErrorGroup
 }
 
 // NewErrorGroup 创建新的错误组
 // (NewErrorGroup creates a new error group)
 func NewErrorGroup() *ErrorGroup {
-	return &ErrorGroup{
-		errors: make([]error, 0),
-	}
-}
-
-// Add 添加错误到组中
-// (Add adds an error to the group)
-func (eg *ErrorGroup) Add(err error) {
-	if err == nil {
-		return
-	}
-	
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	eg.errors = append(eg.errors, err)
-}
-
-// Errors 返回所有错误
-// (Errors returns all collected errors)
-func (eg *ErrorGroup) Errors() []error {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	
-	// 返回副本以避免竞态条件 (Return copy to avoid race conditions)
-	result := make([]error, len(eg.errors))
-	copy(result, eg.errors)
-	return result
-}
-
-// HasErrors 检查是否有错误
-// (HasErrors checks if there are any errors)
-func (eg *ErrorGroup) HasErrors() bool {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	return len(eg.errors) > 0
-}
-
-// Count 返回错误数量
-// (Count returns the number of errors)
-func (eg *ErrorGroup) Count() int {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	return len(eg.errors)
-}
-
-// Clear 清空所有错误
-// (Clear removes all errors)
-func (eg *ErrorGroup) Clear() {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	eg.errors = eg.errors[:0]
-}
-
-// Error 实现 error 接口
-// (Error implements the error interface)
-func (eg *ErrorGroup) Error() string {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	
-	if len(eg.errors) == 0 {
-		return "no errors"
-	}
-	
-	if len(eg.errors) == 1 {
-		return eg.errors[0].Error()
-	}
-	
-	result := fmt.Sprintf("%d errors occurred:", len(eg.errors))
-	for i, err := range eg.errors {
-		result += fmt.Sprintf("\n  [%d] %v", i+1, err)
-	}
-	return result
+	return &ErrorGroup{ErrorGroup: errors.NewErrorGroup()}
 }
 
 // First 返回第一个错误
 // (First returns the first error)
 func (eg *ErrorGroup) First() error {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	
-	if len(eg.errors) == 0 {
+	errs := eg.Errors()
+	if len(errs) == 0 {
 		return nil
 	}
-	return eg.errors[0]
+	return errs[0]
 }
 
 // Last 返回最后一个错误
 // (Last returns the last error)
 func (eg *ErrorGroup) Last() error {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	
-	if len(eg.errors) == 0 {
+	errs := eg.Errors()
+	if len(errs) == 0 {
 		return nil
 	}
-	return eg.errors[len(eg.errors)-1]
+	return errs[len(errs)-1]
 }
 
 // FilterByType 按类型过滤错误
 // (FilterByType filters errors by type)
 func (eg *ErrorGroup) FilterByType(errorType string) []error {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	
 	var filtered []error
-	for _, err := range eg.errors {
+	for _, err := range eg.Errors() {
 		if coder := errors.GetCoder(err); coder != nil {
 			if coder.String() == errorType {
 				filtered = append(filtered, err)
@@ -144,12 +70,9 @@ func (eg *ErrorGroup) FilterByType(errorType string) []error {
 // GroupByType 按类型分组错误
 // (GroupByType groups errors by type)
 func (eg *ErrorGroup) GroupByType() map[string][]error {
-	eg.mu.Lock()
-	defer eg.mu.Unlock()
-	
 	groups := make(map[string][]error)
 	
-	for _, err := range eg.errors {
+	for _, err := range eg.Errors() {
 		var groupKey string
 		if coder := errors.GetCoder(err); coder != nil {
 			groupKey = coder.String()
@@ -251,23 +174,24 @@ func (mtp *MultiTaskProcessor) processTask(task Task) TaskResult {
 		maxRetries = mtp.retryCount
 	}
 	
-	for attempts < maxRetries {
-		attempts++
-		
-		// 模拟任务处理 (Simulate task processing)
-		err := mtp.simulateTaskExecution(task)
-		if err == nil {
-			return TaskResult{
-				TaskID:   task.ID,
-				Success:  true,
-				Duration: time.Since(start),
-				Attempts: attempts,
-			}
-		}
-		
-		// 如果不是最后一次尝试，稍作等待 (If not the last attempt, wait a bit)
-		if attempts < maxRetries {
-			time.Sleep(time.Millisecond * 100)
+	// 失败的任务按指数退避重试 (Failed tasks are retried with exponential backoff)
+	opts := retry.NewOptions()
+	opts.MaxAttempts = maxRetries
+	opts.InitialInterval = 50 * time.Millisecond
+	r, err := retry.New(opts, retry.WithName(task.ID))
+	if err == nil {
+		err = r.Do(context.Background(), func(context.Context) error {
+			attempts++
+			// 模拟任务处理 (Simulate task processing)
+			return mtp.simulateTaskExecution(task)
+		})
+	}
+	if err == nil {
+		return TaskResult{
+			TaskID:   task.ID,
+			Success:  true,
+			Duration: time.Since(start),
+			Attempts: attempts,
 		}
 	}
 	
@@ -356,7 +280,7 @@ func (vp *ValidationProcessor) AddRule(rule ValidationRule) {
 // ValidateData 验证数据（收集所有验证错误）
 // (ValidateData validates data - collects all validation errors)
 func (vp *ValidationProcessor) ValidateData(data interface{}) error {
-	vp.errorGroup.Clear()
+	vp.errorGroup.Reset()
 	
 	// 对每个规则执行验证 (Execute validation for each rule)
 	for _, rule := range vp.rules {
@@ -367,11 +291,7 @@ func (vp *ValidationProcessor) ValidateData(data interface{}) error {
 	}
 	
 	// 如果有错误，返回组合错误 (If there are errors, return combined error)
-	if vp.errorGroup.HasErrors() {
-		return vp.errorGroup
-	}
-	
-	return nil
+	return vp.errorGroup.ErrorOrNil()
 }
 
 // GetValidationErrors 获取验证错误
@@ -503,7 +423,7 @@ func NewBatchProcessor(batchSize int) *BatchProcessor {
 // ProcessBatch 处理批量操作
 // (ProcessBatch processes batch operations)
 func (bp *BatchProcessor) ProcessBatch(items []interface{}) []error {
-	bp.errorGroup.Clear()
+	bp.errorGroup.Reset()
 	var allErrors []error
 	
 	// 分批处理 (Process in batches)
@@ -588,7 +508,7 @@ func demonstrateBasicErrorGroup() {
 	errorGroup.Add(errors.Errorf("third error with value: %d", 42))
 	errorGroup.Add(nil) // 这个会被忽略 (This will be ignored)
 	
-	fmt.Printf("Error count: %d\n", errorGroup.Count())
+	fmt.Printf("Error count: %d\n", errorGroup.Len())
 	fmt.Printf("Has errors: %t\n", errorGroup.HasErrors())
 	fmt.Printf("First error: %v\n", errorGroup.First())
 	fmt.Printf("Last error: %v\n", errorGroup.Last())
@@ -601,6 +521,17 @@ func demonstrateBasicErrorGroup() {
 	fmt.Println("\nCombined error message:")
 	fmt.Printf("%v\n", errorGroup)
 	
+	// errors.Is 和 errors.As 会检查组中的每个成员 (errors.Is and errors.As check every member of the group)
+	notFound := errors.NewWithCode(errors.ErrNotFound, "user 42 not found")
+	errorGroup.Add(notFound)
+	fmt.Printf("\nerrors.Is(group, notFound): %t\n", stderrors.Is(errorGroup, notFound))
+	fmt.Printf("Contains ErrNotFound: %t\n", errors.IsCode(errorGroup, errors.ErrNotFound))
+	
+	// 错误组可以直接序列化为 JSON (The group serializes directly to JSON)
+	if data, err := json.Marshal(errorGroup); err == nil {
+		fmt.Printf("JSON: %s\n", data)
+	}
+	
 	fmt.Println()
 }
 
@@ -652,7 +583,7 @@ func demonstrateParallelTaskProcessing() {
 	// 显示错误摘要 (Show error summary)
 	errorSummary := processor.GetErrorSummary()
 	if errorSummary.HasErrors() {
-		fmt.Printf("\nError Summary (%d errors):\n", errorSummary.Count())
+		fmt.Printf("\nError Summary (%d errors):\n", errorSummary.Len())
 		fmt.Printf("%v\n", errorSummary)
 	}
 	
@@ -705,7 +636,7 @@ func demonstrateValidationErrors() {
 		
 		// 显示错误详情 (Show error details)
 		validationErrors := validator.GetValidationErrors()
-		fmt.Printf("\nDetailed validation errors (%d total):\n", validationErrors.Count())
+		fmt.Printf("\nDetailed validation errors (%d total):\n", validationErrors.Len())
 		for i, validationErr := range validationErrors.Errors() {
 			fmt.Printf("  [%d] %v\n", i+1, validationErr)
 		}
@@ -749,7 +680,7 @@ func demonstrateBatchProcessing() {
 	// 显示批处理错误 (Show batch processing errors)
 	batchErrors := batchProcessor.GetBatchErrors()
 	if batchErrors.HasErrors() {
-		fmt.Printf("\nBatch Processing Errors (%d total):\n", batchErrors.Count())
+		fmt.Printf("\nBatch Processing Errors (%d total):\n", batchErrors.Len())
 		fmt.Printf("%v\n", batchErrors)
 	}
 	
@@ -778,7 +709,7 @@ func demonstrateErrorGrouping() {
 	errorGroup.Add(dbErr2)
 	errorGroup.Add(unknownErr)
 	
-	fmt.Printf("Total errors: %d\n", errorGroup.Count())
+	fmt.Printf("Total errors: %d\n", errorGroup.Len())
 	
 	// 按类型分组错误 (Group errors by type)
 	groupedErrors := errorGroup.GroupByType()
@@ -8,44 +8,39 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/cli"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/spf13/pflag"
 )
 
-// CLIConfig CLI工具配置
-// (CLIConfig represents CLI tool configuration)
-type CLIConfig struct {
-	App struct {
-		Name        string `yaml:"name" default:"user-cli"`
-		Version     string `yaml:"version" default:"v1.0.0"`
-		Description string `yaml:"description" default:"User management CLI tool"`
-	} `yaml:"app"`
+const (
+	appName    = "user-cli"
+	appVersion = "v1.0.0"
+)
 
+// CLIConfig CLI工具配置，带 flag 标签的字段同时是全局命令行标志
+// (CLIConfig represents CLI tool configuration; fields tagged flag are global command-line flags too)
+type CLIConfig struct {
 	Database struct {
 		Type string `yaml:"type" default:"file"`
-		Path string `yaml:"path" default:"./users.json"`
+		Path string `yaml:"path" default:"./users.json" flag:"db" usage:"path of the user database file"`
 	} `yaml:"database"`
 
 	Output struct {
-		Format string `yaml:"format" default:"table"`
-		Quiet  bool   `yaml:"quiet" default:"false"`
-		Color  bool   `yaml:"color" default:"true"`
+		Quiet bool `yaml:"quiet" default:"false" flag:"quiet,q" usage:"only print command results"`
 	} `yaml:"output"`
-
-	Logging struct {
-		Level       string   `yaml:"level" default:"info"`
-		Format      string   `yaml:"format" default:"text"`
-		OutputPaths []string `yaml:"output_paths"`
-	} `yaml:"logging"`
 }
 
 // User 用户模型
@@ -54,420 +49,316 @@ type User struct {
 	ID       string    `json:"id"`
 	Username string    `json:"username"`
 	Email    string    `json:"email"`
-	Name     string    `json:"name"`
+	Name     string    `json:"name" table:"NAME,wide"`
 	Status   string    `json:"status"`
 	Created  time.Time `json:"created"`
-	Updated  time.Time `json:"updated"`
-}
-
-// Command 命令接口
-// (Command represents command interface)
-type Command interface {
-	Name() string
-	Description() string
-	Usage() string
-	Execute(ctx context.Context, args []string) error
+	Updated  time.Time `json:"updated" table:"UPDATED,wide"`
 }
 
-// CLI CLI工具主结构
-// (CLI represents the main CLI tool structure)
-type CLI struct {
-	config   *CLIConfig
-	logger   log.Logger
-	storage  *FileStorage
-	commands map[string]Command
+// userCLI 保存命令共享的配置
+// (userCLI holds the configuration shared by the commands)
+type userCLI struct {
+	config *CLIConfig
 }
 
-// NewCLI 创建CLI工具
-// (NewCLI creates a new CLI tool)
-func NewCLI(cfg *CLIConfig) *CLI {
-	// 设置日志默认值 (Set logging defaults)
-	if len(cfg.Logging.OutputPaths) == 0 {
-		cfg.Logging.OutputPaths = []string{"stdout"}
-	}
-
-	// 初始化日志 (Initialize logging)
-	opts := log.NewOptions()
-	opts.Level = cfg.Logging.Level
-	opts.Format = cfg.Logging.Format
-	opts.EnableColor = cfg.Output.Color && cfg.Logging.Format == "text"
-	opts.DisableCaller = true
-	opts.DisableStacktrace = true
-	opts.OutputPaths = cfg.Logging.OutputPaths
-
-	log.Init(opts)
-
-	logger := log.Std().WithValues(
-		"app", cfg.App.Name,
-		"version", cfg.App.Version,
-		"component", "cli")
-
-	// 初始化存储 (Initialize storage)
-	storage := NewFileStorage(cfg.Database.Path, logger)
-
-	cli := &CLI{
-		config:   cfg,
-		logger:   logger,
-		storage:  storage,
-		commands: make(map[string]Command),
-	}
-
-	// 注册命令 (Register commands)
-	cli.registerCommands()
-
-	if !cfg.Output.Quiet {
-		logger.Infow("CLI tool initialized",
-			"app_name", cfg.App.Name,
-			"app_version", cfg.App.Version,
-			"database_path", cfg.Database.Path,
-			"output_format", cfg.Output.Format)
-	}
-
-	return cli
-}
-
-// registerCommands 注册所有命令
-// (registerCommands registers all commands)
-func (c *CLI) registerCommands() {
-	c.commands["create"] = NewCreateCommand(c)
-	c.commands["list"] = NewListCommand(c)
-	c.commands["get"] = NewGetCommand(c)
-	c.commands["update"] = NewUpdateCommand(c)
-	c.commands["delete"] = NewDeleteCommand(c)
-	c.commands["search"] = NewSearchCommand(c)
-	c.commands["export"] = NewExportCommand(c)
-	c.commands["import"] = NewImportCommand(c)
-	c.commands["help"] = NewHelpCommand(c)
-	c.commands["version"] = NewVersionCommand(c)
-}
-
-// Run 运行CLI工具
-// (Run executes the CLI tool)
-func (c *CLI) Run(ctx context.Context, args []string) error {
-	if len(args) < 1 {
-		return c.commands["help"].Execute(ctx, []string{})
-	}
-
-	cmdName := args[0]
-	cmdArgs := args[1:]
-
-	if cmd, exists := c.commands[cmdName]; exists {
-		return cmd.Execute(ctx, cmdArgs)
+// NewApp 创建CLI应用并注册所有命令
+// (NewApp creates the CLI application and registers all commands)
+func NewApp() *cli.App {
+	cfg := &CLIConfig{}
+	u := &userCLI{config: cfg}
+
+	app := cli.NewApp(appName, "User management CLI tool",
+		cli.WithVersion(appVersion),
+		cli.WithConfig(cfg, config.WithEnvPrefix("USER_CLI")),
+	)
+	app.AddCommand(
+		u.createCommand(),
+		u.listCommand(),
+		u.getCommand(),
+		u.updateCommand(),
+		u.deleteCommand(),
+		u.searchCommand(),
+		u.exportCommand(),
+		u.importCommand(),
+	)
+	return app
+}
+
+// storage 返回按配置打开的存储
+// (storage returns the storage opened from the configuration)
+func (u *userCLI) storage(ctx *cli.Context) *FileStorage {
+	return NewFileStorage(u.config.Database.Path, ctx.Logger())
+}
+
+// printf 在非安静模式下输出提示信息
+// (printf writes a message unless quiet mode is on)
+func (u *userCLI) printf(ctx *cli.Context, format string, args ...any) {
+	if !u.config.Output.Quiet {
+		fmt.Fprintf(ctx.Stdout(), format, args...)
 	}
-
-	return errors.Errorf("unknown command: %s", cmdName)
-}
-
-// CreateCommand 创建用户命令
-// (CreateCommand creates user command)
-type CreateCommand struct {
-	cli *CLI
-}
-
-// NewCreateCommand 创建CreateCommand
-// (NewCreateCommand creates a new CreateCommand)
-func NewCreateCommand(cli *CLI) *CreateCommand {
-	return &CreateCommand{cli: cli}
-}
-
-func (c *CreateCommand) Name() string        { return "create" }
-func (c *CreateCommand) Description() string { return "Create a new user" }
-func (c *CreateCommand) Usage() string {
-	return "create <username> <email> [--name <name>] [--status <status>]"
 }
 
-func (c *CreateCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) < 2 {
-		return errors.New("usage: " + c.Usage())
-	}
-
-	username := args[0]
-	email := args[1]
-
-	// 解析可选参数 (Parse optional arguments)
-	name := ""
-	status := "active"
-
-	for i := 2; i < len(args); i++ {
-		switch args[i] {
-		case "--name":
-			if i+1 < len(args) {
-				name = args[i+1]
-				i++
+// createCommand 创建用户命令
+// (createCommand creates the create user command)
+func (u *userCLI) createCommand() *cli.Command {
+	var name, status string
+	return &cli.Command{
+		Name:      "create",
+		Short:     "Create a new user",
+		ArgsUsage: "<username> <email>",
+		Args:      cli.ExactArgs(2),
+		Flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&name, "name", "", "display name (defaults to the username)")
+			fs.StringVar(&status, "status", "active", "user status")
+		},
+		Run: func(ctx *cli.Context) error {
+			username, email := ctx.Args[0], ctx.Args[1]
+			user := &User{
+				ID:       generateID(),
+				Username: username,
+				Email:    email,
+				Name:     cmp.Or(name, username),
+				Status:   status,
+				Created:  time.Now(),
+				Updated:  time.Now(),
 			}
-		case "--status":
-			if i+1 < len(args) {
-				status = args[i+1]
-				i++
+			ctx.Logger().Infow("Creating user", "username", username, "email", email, "status", status)
+			if err := u.storage(ctx).CreateUser(ctx, user); err != nil {
+				return err
 			}
-		}
-	}
-
-	if name == "" {
-		name = username
-	}
-
-	c.cli.logger.Infow("Creating user",
-		"username", username,
-		"email", email,
-		"name", name,
-		"status", status)
-
-	user := &User{
-		ID:       generateID(),
-		Username: username,
-		Email:    email,
-		Name:     name,
-		Status:   status,
-		Created:  time.Now(),
-		Updated:  time.Now(),
-	}
-
-	if err := c.cli.storage.CreateUser(ctx, user); err != nil {
-		c.cli.logger.Errorw("Failed to create user", "error", err)
-		return err
-	}
-
-	if !c.cli.config.Output.Quiet {
-		c.printUser(user)
-		fmt.Printf("✅ User '%s' created successfully with ID: %s\n", username, user.ID)
+			if err := ctx.Render(user); err != nil {
+				return err
+			}
+			u.printf(ctx, "✅ User '%s' created successfully with ID: %s\n", username, user.ID)
+			return nil
+		},
 	}
-
-	return nil
 }
 
-// printUser 打印用户信息
-// (printUser prints user information)
-func (c *CreateCommand) printUser(user *User) {
-	switch c.cli.config.Output.Format {
-	case "json":
-		data, _ := json.MarshalIndent(user, "", "  ")
-		fmt.Println(string(data))
-	case "table":
-		fmt.Printf("┌────────────┬─────────────────────────────────────┐\n")
-		fmt.Printf("│ Field      │ Value                               │\n")
-		fmt.Printf("├────────────┼─────────────────────────────────────┤\n")
-		fmt.Printf("│ ID         │ %-35s │\n", user.ID)
-		fmt.Printf("│ Username   │ %-35s │\n", user.Username)
-		fmt.Printf("│ Email      │ %-35s │\n", user.Email)
-		fmt.Printf("│ Name       │ %-35s │\n", user.Name)
-		fmt.Printf("│ Status     │ %-35s │\n", user.Status)
-		fmt.Printf("│ Created    │ %-35s │\n", user.Created.Format("2006-01-02 15:04:05"))
-		fmt.Printf("└────────────┴─────────────────────────────────────┘\n")
-	default:
-		fmt.Printf("ID: %s, Username: %s, Email: %s, Name: %s, Status: %s\n",
-			user.ID, user.Username, user.Email, user.Name, user.Status)
+// listCommand 列出用户命令
+// (listCommand creates the list users command)
+func (u *userCLI) listCommand() *cli.Command {
+	var status string
+	var limit int
+	return &cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Short:   "List all users",
+		Args:    cli.NoArgs,
+		Flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&status, "status", "", "only list users with this status")
+			fs.IntVar(&limit, "limit", 0, "maximum number of users, 0 for all")
+		},
+		Run: func(ctx *cli.Context) error {
+			ctx.Logger().Infow("Listing users", "status", status, "limit", limit)
+			users, err := u.storage(ctx).ListUsers(ctx)
+			if err != nil {
+				return err
+			}
+			if status != "" {
+				users = slices.DeleteFunc(users, func(user User) bool { return user.Status != status })
+			}
+			if limit > 0 && len(users) > limit {
+				users = users[:limit]
+			}
+			if len(users) == 0 {
+				u.printf(ctx, "📋 No users found\n")
+				return nil
+			}
+			return ctx.Render(users)
+		},
 	}
 }
 
-// HelpCommand 帮助命令
-// (HelpCommand help command)
-type HelpCommand struct {
-	cli *CLI
-}
-
-func NewHelpCommand(cli *CLI) *HelpCommand { return &HelpCommand{cli: cli} }
-
-func (c *HelpCommand) Name() string        { return "help" }
-func (c *HelpCommand) Description() string { return "Show help information" }
-func (c *HelpCommand) Usage() string       { return "help [command]" }
-
-func (c *HelpCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) > 0 {
-		// 显示特定命令帮助 (Show specific command help)
-		cmdName := args[0]
-		if cmd, exists := c.cli.commands[cmdName]; exists {
-			fmt.Printf("Command: %s\n", cmd.Name())
-			fmt.Printf("Description: %s\n", cmd.Description())
-			fmt.Printf("Usage: %s %s\n", c.cli.config.App.Name, cmd.Usage())
+// getCommand 获取用户命令
+// (getCommand creates the get user command)
+func (u *userCLI) getCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Short:     "Get user by ID or username",
+		ArgsUsage: "<id_or_username>",
+		Args:      cli.ExactArgs(1),
+		Run: func(ctx *cli.Context) error {
+			ctx.Logger().Infow("Getting user", "identifier", ctx.Args[0])
+			u.printf(ctx, "👤 User not found: %s (demo implementation)\n", ctx.Args[0])
 			return nil
-		}
-		return errors.Errorf("unknown command: %s", cmdName)
-	}
-
-	// 显示总体帮助 (Show general help)
-	fmt.Printf("%s - %s\n", c.cli.config.App.Name, c.cli.config.App.Description)
-	fmt.Printf("Version: %s\n\n", c.cli.config.App.Version)
-	fmt.Println("Available commands:")
-
-	// 按字母顺序排序命令 (Sort commands alphabetically)
-	var cmdNames []string
-	for name := range c.cli.commands {
-		cmdNames = append(cmdNames, name)
+		},
 	}
-	sort.Strings(cmdNames)
-
-	for _, name := range cmdNames {
-		cmd := c.cli.commands[name]
-		fmt.Printf("  %-10s %s\n", cmd.Name(), cmd.Description())
-	}
-
-	fmt.Printf("\nUse '%s help <command>' for more information about a command.\n", c.cli.config.App.Name)
-	return nil
-}
-
-// VersionCommand 版本命令
-// (VersionCommand version command)
-type VersionCommand struct {
-	cli *CLI
-}
-
-func NewVersionCommand(cli *CLI) *VersionCommand { return &VersionCommand{cli: cli} }
-
-func (c *VersionCommand) Name() string        { return "version" }
-func (c *VersionCommand) Description() string { return "Show version information" }
-func (c *VersionCommand) Usage() string       { return "version" }
-
-func (c *VersionCommand) Execute(ctx context.Context, args []string) error {
-	fmt.Printf("%s version %s\n", c.cli.config.App.Name, c.cli.config.App.Version)
-	fmt.Printf("Built with lmcc-go-sdk\n")
-	return nil
 }
 
-// ListCommand 列出用户命令
-// (ListCommand lists users command)
-type ListCommand struct {
-	cli *CLI
-}
-
-func NewListCommand(cli *CLI) *ListCommand { return &ListCommand{cli: cli} }
-
-func (c *ListCommand) Name() string        { return "list" }
-func (c *ListCommand) Description() string { return "List all users" }
-func (c *ListCommand) Usage() string       { return "list [--status <status>] [--limit <n>]" }
-
-func (c *ListCommand) Execute(ctx context.Context, args []string) error {
-	c.cli.logger.Infow("Listing users")
-	fmt.Println("📋 No users found (demo implementation)")
-	return nil
-}
-
-// GetCommand 获取用户命令
-// (GetCommand gets user command)
-type GetCommand struct {
-	cli *CLI
-}
-
-func NewGetCommand(cli *CLI) *GetCommand { return &GetCommand{cli: cli} }
-
-func (c *GetCommand) Name() string        { return "get" }
-func (c *GetCommand) Description() string { return "Get user by ID or username" }
-func (c *GetCommand) Usage() string       { return "get <id_or_username>" }
-
-func (c *GetCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) < 1 {
-		return errors.New("usage: " + c.Usage())
-	}
-	c.cli.logger.Infow("Getting user", "identifier", args[0])
-	fmt.Printf("👤 User not found: %s (demo implementation)\n", args[0])
-	return nil
-}
-
-// UpdateCommand 更新用户命令
-// (UpdateCommand updates user command)
-type UpdateCommand struct {
-	cli *CLI
-}
-
-func NewUpdateCommand(cli *CLI) *UpdateCommand { return &UpdateCommand{cli: cli} }
-
-func (c *UpdateCommand) Name() string        { return "update" }
-func (c *UpdateCommand) Description() string { return "Update user information" }
-func (c *UpdateCommand) Usage() string {
-	return "update <id_or_username> [--email <email>] [--name <name>] [--status <status>]"
-}
-
-func (c *UpdateCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) < 1 {
-		return errors.New("usage: " + c.Usage())
+// updateCommand 更新用户命令
+// (updateCommand creates the update user command)
+func (u *userCLI) updateCommand() *cli.Command {
+	var email, name, status string
+	return &cli.Command{
+		Name:      "update",
+		Short:     "Update user information",
+		ArgsUsage: "<id_or_username>",
+		Args:      cli.ExactArgs(1),
+		Flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&email, "email", "", "new email")
+			fs.StringVar(&name, "name", "", "new display name")
+			fs.StringVar(&status, "status", "", "new status")
+		},
+		Run: func(ctx *cli.Context) error {
+			ctx.Logger().Infow("Updating user", "identifier", ctx.Args[0], "email", email, "name", name, "status", status)
+			u.printf(ctx, "✏️  User update simulated: %s (demo implementation)\n", ctx.Args[0])
+			return nil
+		},
 	}
-	c.cli.logger.Infow("Updating user", "identifier", args[0])
-	fmt.Printf("✏️  User update simulated: %s (demo implementation)\n", args[0])
-	return nil
-}
-
-// DeleteCommand 删除用户命令
-// (DeleteCommand deletes user command)
-type DeleteCommand struct {
-	cli *CLI
 }
 
-func NewDeleteCommand(cli *CLI) *DeleteCommand { return &DeleteCommand{cli: cli} }
-
-func (c *DeleteCommand) Name() string        { return "delete" }
-func (c *DeleteCommand) Description() string { return "Delete user" }
-func (c *DeleteCommand) Usage() string       { return "delete <id_or_username> [--force]" }
-
-func (c *DeleteCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) < 1 {
-		return errors.New("usage: " + c.Usage())
+// deleteCommand 删除用户命令
+// (deleteCommand creates the delete user command)
+func (u *userCLI) deleteCommand() *cli.Command {
+	var force bool
+	return &cli.Command{
+		Name:      "delete",
+		Aliases:   []string{"rm"},
+		Short:     "Delete user",
+		ArgsUsage: "<id_or_username>",
+		Args:      cli.ExactArgs(1),
+		Flags: func(fs *pflag.FlagSet) {
+			fs.BoolVar(&force, "force", false, "delete without confirmation")
+		},
+		Run: func(ctx *cli.Context) error {
+			ctx.Logger().Infow("Deleting user", "identifier", ctx.Args[0], "force", force)
+			u.printf(ctx, "🗑️  User deletion simulated: %s (demo implementation)\n", ctx.Args[0])
+			return nil
+		},
 	}
-	c.cli.logger.Infow("Deleting user", "identifier", args[0])
-	fmt.Printf("🗑️  User deletion simulated: %s (demo implementation)\n", args[0])
-	return nil
 }
 
-// SearchCommand 搜索用户命令
-// (SearchCommand searches users command)
-type SearchCommand struct {
-	cli *CLI
-}
-
-func NewSearchCommand(cli *CLI) *SearchCommand { return &SearchCommand{cli: cli} }
-
-func (c *SearchCommand) Name() string        { return "search" }
-func (c *SearchCommand) Description() string { return "Search users by keyword" }
-func (c *SearchCommand) Usage() string       { return "search <keyword> [--field <field>]" }
-
-func (c *SearchCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) < 1 {
-		return errors.New("usage: " + c.Usage())
+// searchCommand 搜索用户命令
+// (searchCommand creates the search users command)
+func (u *userCLI) searchCommand() *cli.Command {
+	var field string
+	return &cli.Command{
+		Name:      "search",
+		Short:     "Search users by keyword",
+		ArgsUsage: "<keyword>",
+		Args:      cli.ExactArgs(1),
+		Flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&field, "field", "", "only search this field")
+		},
+		Run: func(ctx *cli.Context) error {
+			ctx.Logger().Infow("Searching users", "keyword", ctx.Args[0], "field", field)
+			u.printf(ctx, "🔍 No users found matching: %s (demo implementation)\n", ctx.Args[0])
+			return nil
+		},
 	}
-	c.cli.logger.Infow("Searching users", "keyword", args[0])
-	fmt.Printf("🔍 No users found matching: %s (demo implementation)\n", args[0])
-	return nil
 }
 
-// ExportCommand 导出用户命令
-// (ExportCommand exports users command)
-type ExportCommand struct {
-	cli *CLI
-}
-
-func NewExportCommand(cli *CLI) *ExportCommand { return &ExportCommand{cli: cli} }
+// exportCommand 导出用户命令
+// (exportCommand creates the export users command)
+func (u *userCLI) exportCommand() *cli.Command {
+	var formatName string
+	return &cli.Command{
+		Name:      "export",
+		Short:     "Export users to file",
+		ArgsUsage: "<filename>",
+		Args:      cli.ExactArgs(1),
+		Flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&formatName, "format", "", "jsonl or csv, inferred from the extension when empty")
+		},
+		Run: func(ctx *cli.Context) error {
+			filename := ctx.Args[0]
+			// 未指定 --format 时按扩展名推断 (Infer the format from the extension unless --format is given)
+			format, err := cli.FormatFromPath(filename)
+			if formatName != "" {
+				format, err = cli.ParseFormat(formatName)
+			}
+			if err != nil {
+				return err
+			}
 
-func (c *ExportCommand) Name() string        { return "export" }
-func (c *ExportCommand) Description() string { return "Export users to file" }
-func (c *ExportCommand) Usage() string       { return "export <filename> [--format <json|csv>]" }
+			users, err := u.storage(ctx).ListUsers(ctx)
+			if err != nil {
+				return err
+			}
+			file, err := os.Create(filename)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create %s", filename)
+			}
+			defer file.Close()
 
-func (c *ExportCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) < 1 {
-		return errors.New("usage: " + c.Usage())
+			ctx.Logger().Infow("Exporting users", "filename", filename, "format", format)
+			stats, err := cli.Export(ctx, file, format, slices.Values(users),
+				cli.WithLogger(ctx.Logger()), cli.WithName("users"))
+			if err != nil {
+				return err
+			}
+			u.printf(ctx, "📤 Exported %d users to %s in %s\n", stats.Succeeded(), filename, stats.Duration.Round(time.Millisecond))
+			return nil
+		},
 	}
-	c.cli.logger.Infow("Exporting users", "filename", args[0])
-	fmt.Printf("📤 Export simulated to: %s (demo implementation)\n", args[0])
-	return nil
 }
 
-// ImportCommand 导入用户命令
-// (ImportCommand imports users command)
-type ImportCommand struct {
-	cli *CLI
-}
+// importCommand 导入用户命令
+// (importCommand creates the import users command)
+func (u *userCLI) importCommand() *cli.Command {
+	var merge bool
+	return &cli.Command{
+		Name:      "import",
+		Short:     "Import users from file",
+		ArgsUsage: "<filename>",
+		Args:      cli.ExactArgs(1),
+		Flags: func(fs *pflag.FlagSet) {
+			fs.BoolVar(&merge, "merge", false, "keep existing users, replacing those with the same ID")
+		},
+		Run: func(ctx *cli.Context) error {
+			filename := ctx.Args[0]
+			format, err := cli.FormatFromPath(filename)
+			if err != nil {
+				return err
+			}
+			file, err := os.Open(filename)
+			if err != nil {
+				return errors.Wrapf(err, "failed to open %s", filename)
+			}
+			defer file.Close()
+
+			// 合并时保留现有用户，同 ID 的用户被导入的覆盖 (When merging, existing users are kept and users with the same ID are replaced)
+			storage := u.storage(ctx)
+			byID := map[string]User{}
+			if merge {
+				existing, err := storage.ListUsers(ctx)
+				if err != nil {
+					return err
+				}
+				for _, user := range existing {
+					byID[user.ID] = user
+				}
+			}
 
-func NewImportCommand(cli *CLI) *ImportCommand { return &ImportCommand{cli: cli} }
+			ctx.Logger().Infow("Importing users", "filename", filename, "format", format, "merge", merge)
+			stats, importErr := cli.Import(ctx, file, format, func(ctx context.Context, user User) error {
+				if user.ID == "" || user.Username == "" {
+					return errors.NewWithCode(errors.ErrValidation, "id and username are required")
+				}
+				byID[user.ID] = user
+				return nil
+			}, cli.WithLogger(ctx.Logger()), cli.WithName("users"))
+			if importErr != nil && stats.Succeeded() == 0 {
+				return importErr
+			}
 
-func (c *ImportCommand) Name() string        { return "import" }
-func (c *ImportCommand) Description() string { return "Import users from file" }
-func (c *ImportCommand) Usage() string       { return "import <filename> [--merge]" }
+			users := make([]User, 0, len(byID))
+			for _, user := range byID {
+				users = append(users, user)
+			}
+			sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+			if err := storage.SaveUsers(ctx, users); err != nil {
+				return err
+			}
 
-func (c *ImportCommand) Execute(ctx context.Context, args []string) error {
-	if len(args) < 1 {
-		return errors.New("usage: " + c.Usage())
+			u.printf(ctx, "📥 Imported %d of %d users from %s (%d failed)\n", stats.Succeeded(), stats.Records, filename, stats.Failed)
+			if importErr != nil {
+				u.printf(ctx, "%v\n", importErr)
+			}
+			return nil
+		},
 	}
-	c.cli.logger.Infow("Importing users", "filename", args[0])
-	fmt.Printf("📥 Import simulated from: %s (demo implementation)\n", args[0])
-	return nil
 }
 
 // FileStorage 文件存储 (简化版)
@@ -493,6 +384,37 @@ func (fs *FileStorage) CreateUser(ctx context.Context, user *User) error {
 	return nil // 简化实现
 }
 
+// ListUsers 读取存储文件中的全部用户，文件不存在时返回空列表
+// (ListUsers reads all users from the storage file, returning an empty list when it does not exist)
+func (fs *FileStorage) ListUsers(ctx context.Context) ([]User, error) {
+	data, err := os.ReadFile(fs.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", fs.path)
+	}
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", fs.path)
+	}
+	return users, nil
+}
+
+// SaveUsers 用 users 替换存储文件的内容
+// (SaveUsers replaces the contents of the storage file with users)
+func (fs *FileStorage) SaveUsers(ctx context.Context, users []User) error {
+	data, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode users")
+	}
+	if err := os.WriteFile(fs.path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", fs.path)
+	}
+	fs.logger.Infow("Users saved", "count", len(users), "path", fs.path)
+	return nil
+}
+
 // generateID 生成唯一ID
 // (generateID generates unique ID)
 func generateID() string {
@@ -511,31 +433,21 @@ func runDemo() {
 		args []string
 	}{
 		{"Show help", []string{"help"}},
-		{"Create user alice", []string{"create", "alice", "alice@example.com", "--name", "Alice Smith"}},
-		{"Show help for create command", []string{"help", "create"}},
+		{"Create user alice", []string{"create", "alice", "alice@example.com", "--name", "Alice Smith", "--db", "./demo_users.json"}},
+		{"Show help for create command", []string{"create", "--help"}},
+		{"Show version as JSON", []string{"version", "-o", "json"}},
+		{"Missing arguments", []string{"get"}},
 	}
 
-	// 加载配置 (Load configuration)
-	cfg := &CLIConfig{}
-	if err := config.LoadConfig(cfg); err != nil {
-		fmt.Printf("Failed to load configuration: %v\n", err)
-		// 使用默认配置继续 (Continue with default configuration)
-	}
-
-	// 设置演示配置 (Set demo configuration)
-	cfg.Database.Path = "./demo_users.json"
-	cfg.Output.Quiet = false
-
-	// 创建CLI实例 (Create CLI instance)
-	cli := NewCLI(cfg)
+	app := NewApp()
 	ctx := context.Background()
 
 	// 运行测试命令 (Run test commands)
 	for i, test := range tests {
 		fmt.Printf("%d. %s:\n", i+1, test.name)
-		fmt.Printf("   Command: %s %s\n", cfg.App.Name, strings.Join(test.args, " "))
+		fmt.Printf("   Command: %s %s\n", appName, strings.Join(test.args, " "))
 
-		if err := cli.Run(ctx, test.args); err != nil {
+		if err := app.Run(ctx, test.args); err != nil {
 			fmt.Printf("   ❌ Error: %v\n", err)
 		} else {
 			fmt.Printf("   ✅ Success\n")
@@ -552,25 +464,5 @@ func main() {
 		runDemo()
 		return
 	}
-
-	fmt.Println("=== CLI Tool Integration Example ===")
-	fmt.Println("This example demonstrates a command-line interface with subcommands and configuration.")
-	fmt.Println()
-
-	// 加载配置 (Load configuration)
-	cfg := &CLIConfig{}
-	if err := config.LoadConfig(cfg); err != nil {
-		fmt.Printf("Failed to load configuration: %v\n", err)
-		// 使用默认配置继续 (Continue with default configuration)
-	}
-
-	// 创建CLI实例 (Create CLI instance)
-	cli := NewCLI(cfg)
-
-	// 运行CLI (Run CLI)
-	ctx := context.Background()
-	if err := cli.Run(ctx, os.Args[1:]); err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
-	}
-} 
\ No newline at end of file
+	NewApp().Main()
+}
@@ -17,7 +17,13 @@ import (
 
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	lmccmetrics "github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/sdk"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/tasks"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/trace"
 )
 
 // ServiceConfig 微服务配置
@@ -89,8 +95,9 @@ type UserResponse struct {
 type UserService struct {
 	config  *ServiceConfig
 	logger  log.Logger
-	metrics *MetricsCollector
-	tracer  *TracingService
+	metrics *ServiceMetrics
+	runtime *lmccmetrics.RuntimeCollector
+	sdk     *sdk.Runtime
 	db      *DatabaseService
 }
 
@@ -102,16 +109,27 @@ func NewUserService(cfg *ServiceConfig) *UserService {
 		cfg.Logging.OutputPaths = []string{"stdout"}
 	}
 
-	// 初始化日志 (Initialize logging)
-	opts := log.NewOptions()
-	opts.Level = cfg.Logging.Level
-	opts.Format = cfg.Logging.Format
-	opts.EnableColor = cfg.Logging.Format == "text"
-	opts.DisableCaller = false
-	opts.DisableStacktrace = cfg.Logging.Level != "debug"
-	opts.OutputPaths = cfg.Logging.OutputPaths
-
-	log.Init(opts)
+	// 一次调用完成日志、运行时指标和统一关闭的初始化 (One call initializes logging, runtime metrics and consistent shutdown)
+	sdkOpts := sdk.NewOptions()
+	sdkOpts.Log.Level = cfg.Logging.Level
+	sdkOpts.Log.Format = cfg.Logging.Format
+	sdkOpts.Log.EnableColor = cfg.Logging.Format == "text"
+	sdkOpts.Log.DisableCaller = false
+	sdkOpts.Log.DisableStacktrace = cfg.Logging.Level != "debug"
+	sdkOpts.Log.OutputPaths = cfg.Logging.OutputPaths
+	sdkOpts.Metrics.Namespace = "user_service"
+	sdkOpts.Metrics.RuntimeStats = cfg.Observability.MetricsEnabled
+	sdkOpts.StartupReport = false
+	// 安装 TracerProvider 并在日志中写入 span 的 trace_id；设置 Trace.Exporter = "otlp" 可导出到收集器
+	// (Install a TracerProvider and write the span's trace_id to logs; set Trace.Exporter = "otlp" to export to a collector)
+	sdkOpts.Trace.Enabled = cfg.Observability.TracingEnabled
+	sdkOpts.Log.EnableOTelTraceContext = true
+
+	rt, err := sdk.Bootstrap(context.Background(), sdkOpts, sdk.WithVersion(cfg.Service.Version))
+	if err != nil {
+		fmt.Printf("Failed to bootstrap SDK: %v\n", err)
+		os.Exit(1)
+	}
 
 	logger := log.Std().WithValues(
 		"service", cfg.Service.Name,
@@ -119,8 +137,11 @@ func NewUserService(cfg *ServiceConfig) *UserService {
 		"component", "microservice")
 
 	// 初始化组件 (Initialize components)
-	metrics := NewMetricsCollector(logger)
-	tracer := NewTracingService(cfg, logger)
+	metrics, err := NewServiceMetrics(sdkOpts.Metrics, rt.Registry)
+	if err != nil {
+		fmt.Printf("Failed to create metrics: %v\n", err)
+		os.Exit(1)
+	}
 	db := NewDatabaseService(cfg, logger)
 
 	logger.Infow("User microservice initialized",
@@ -131,27 +152,35 @@ func NewUserService(cfg *ServiceConfig) *UserService {
 		"tracing_enabled", cfg.Observability.TracingEnabled,
 		"metrics_enabled", cfg.Observability.MetricsEnabled)
 
-	return &UserService{
+	service := &UserService{
 		config:  cfg,
 		logger:  logger,
 		metrics: metrics,
-		tracer:  tracer,
+		runtime: rt.Collector,
+		sdk:     rt,
 		db:      db,
 	}
+	if err := registerHealthChecks(service); err != nil {
+		fmt.Printf("Failed to register health checks: %v\n", err)
+		os.Exit(1)
+	}
+	return service
 }
 
 // GetUser 获取用户
 // (GetUser retrieves a user by ID)
 func (s *UserService) GetUser(ctx context.Context, req *UserRequest) (*UserResponse, error) {
-	// 生成链路追踪ID (Generate trace ID)
-	traceID := s.tracer.GenerateTraceID()
-	ctx = s.tracer.WithTraceID(ctx, traceID)
+	// 开始 span，其 trace ID 会随响应返回 (Start a span; its trace ID is returned with the response)
+	ctx, span := trace.Start(ctx, "UserService.GetUser")
+	var opErr error
+	defer func() { trace.End(span, opErr) }()
+	traceID := trace.TraceID(ctx)
 
 	// 创建带追踪信息的日志记录器 (Create logger with tracing info)
 	logger := s.logger.WithValues("trace_id", traceID, "operation", "get_user")
 
 	// 记录指标 (Record metrics)
-	s.metrics.RecordRequest("get_user")
+	s.metrics.requests.Inc("operation", "get_user")
 	startTime := time.Now()
 
 	logger.Infow("Processing get user request",
@@ -159,8 +188,9 @@ func (s *UserService) GetUser(ctx context.Context, req *UserRequest) (*UserRespo
 
 	// 验证请求 (Validate request)
 	if req.ID == "" {
-		err := errors.New("user ID is required")
-		s.metrics.RecordError("get_user", "validation_error")
+		err := errors.NewWithCode(errors.ErrValidation, "user ID is required")
+		s.metrics.errors.Record(err, "operation", "get_user")
+		opErr = err
 		logger.Errorw("Validation failed", "error", err)
 		return &UserResponse{
 			Success: false,
@@ -172,7 +202,8 @@ func (s *UserService) GetUser(ctx context.Context, req *UserRequest) (*UserRespo
 	// 从数据库获取用户 (Get user from database)
 	user, err := s.db.GetUser(ctx, req.ID)
 	if err != nil {
-		s.metrics.RecordError("get_user", "database_error")
+		s.metrics.errors.Record(err, "operation", "get_user")
+		opErr = err
 		logger.Errorw("Database operation failed", "error", err)
 		return &UserResponse{
 			Success: false,
@@ -182,7 +213,7 @@ func (s *UserService) GetUser(ctx context.Context, req *UserRequest) (*UserRespo
 	}
 
 	// 记录成功指标 (Record success metrics)
-	s.metrics.RecordSuccess("get_user", time.Since(startTime))
+	s.metrics.duration.ObserveDuration(startTime, "operation", "get_user")
 
 	logger.Infow("Get user request completed successfully",
 		"user_id", user.ID,
@@ -199,12 +230,14 @@ func (s *UserService) GetUser(ctx context.Context, req *UserRequest) (*UserRespo
 // CreateUser 创建用户
 // (CreateUser creates a new user)
 func (s *UserService) CreateUser(ctx context.Context, req *UserRequest) (*UserResponse, error) {
-	traceID := s.tracer.GenerateTraceID()
-	ctx = s.tracer.WithTraceID(ctx, traceID)
+	ctx, span := trace.Start(ctx, "UserService.CreateUser")
+	var opErr error
+	defer func() { trace.End(span, opErr) }()
+	traceID := trace.TraceID(ctx)
 
 	logger := s.logger.WithValues("trace_id", traceID, "operation", "create_user")
 
-	s.metrics.RecordRequest("create_user")
+	s.metrics.requests.Inc("operation", "create_user")
 	startTime := time.Now()
 
 	logger.Infow("Processing create user request",
@@ -213,8 +246,9 @@ func (s *UserService) CreateUser(ctx context.Context, req *UserRequest) (*UserRe
 
 	// 验证请求 (Validate request)
 	if req.Username == "" || req.Email == "" {
-		err := errors.New("username and email are required")
-		s.metrics.RecordError("create_user", "validation_error")
+		err := errors.NewWithCode(errors.ErrValidation, "username and email are required")
+		s.metrics.errors.Record(err, "operation", "create_user")
+		opErr = err
 		logger.Errorw("Validation failed", "error", err)
 		return &UserResponse{
 			Success: false,
@@ -226,7 +260,8 @@ func (s *UserService) CreateUser(ctx context.Context, req *UserRequest) (*UserRe
 	// 创建用户 (Create user in database)
 	user, err := s.db.CreateUser(ctx, req.Username, req.Email)
 	if err != nil {
-		s.metrics.RecordError("create_user", "database_error")
+		s.metrics.errors.Record(err, "operation", "create_user")
+		opErr = err
 		logger.Errorw("Database operation failed", "error", err)
 		return &UserResponse{
 			Success: false,
@@ -235,7 +270,7 @@ func (s *UserService) CreateUser(ctx context.Context, req *UserRequest) (*UserRe
 		}, nil
 	}
 
-	s.metrics.RecordSuccess("create_user", time.Since(startTime))
+	s.metrics.duration.ObserveDuration(startTime, "operation", "create_user")
 
 	logger.Infow("Create user request completed successfully",
 		"user_id", user.ID,
@@ -250,149 +285,33 @@ func (s *UserService) CreateUser(ctx context.Context, req *UserRequest) (*UserRe
 	}, nil
 }
 
-// MetricsCollector 指标收集器
-// (MetricsCollector collects service metrics)
-type MetricsCollector struct {
-	logger       log.Logger
-	requests     map[string]int64
-	errors       map[string]map[string]int64
-	latencies    map[string][]time.Duration
-	mu           sync.RWMutex
+// ServiceMetrics 服务指标，基于 pkg/metrics 发布到 Prometheus 注册表
+// (ServiceMetrics holds the service metrics, published to a Prometheus registry through pkg/metrics)
+type ServiceMetrics struct {
+	requests *lmccmetrics.Counter
+	errors   *lmccmetrics.ErrorCounter
+	duration *lmccmetrics.Histogram
 }
 
-// NewMetricsCollector 创建指标收集器
-// (NewMetricsCollector creates a new metrics collector)
-func NewMetricsCollector(logger log.Logger) *MetricsCollector {
-	return &MetricsCollector{
-		logger:    logger.WithValues("component", "metrics"),
-		requests:  make(map[string]int64),
-		errors:    make(map[string]map[string]int64),
-		latencies: make(map[string][]time.Duration),
-	}
-}
-
-// RecordRequest 记录请求
-// (RecordRequest records a request)
-func (mc *MetricsCollector) RecordRequest(operation string) {
-	mc.mu.Lock()
-	defer mc.mu.Unlock()
-	mc.requests[operation]++
-	mc.logger.Debugw("Request recorded", "operation", operation, "count", mc.requests[operation])
-}
-
-// RecordError 记录错误
-// (RecordError records an error)
-func (mc *MetricsCollector) RecordError(operation, errorType string) {
-	mc.mu.Lock()
-	defer mc.mu.Unlock()
-	if mc.errors[operation] == nil {
-		mc.errors[operation] = make(map[string]int64)
-	}
-	mc.errors[operation][errorType]++
-	mc.logger.Warnw("Error recorded", "operation", operation, "error_type", errorType)
-}
-
-// RecordSuccess 记录成功响应
-// (RecordSuccess records a successful response)
-func (mc *MetricsCollector) RecordSuccess(operation string, duration time.Duration) {
-	mc.mu.Lock()
-	defer mc.mu.Unlock()
-	mc.latencies[operation] = append(mc.latencies[operation], duration)
-	mc.logger.Debugw("Success recorded", "operation", operation, "duration", duration)
-}
-
-// GetMetrics 获取指标
-// (GetMetrics returns current metrics)
-func (mc *MetricsCollector) GetMetrics() map[string]interface{} {
-	mc.mu.RLock()
-	defer mc.mu.RUnlock()
-
-	metrics := map[string]interface{}{
-		"requests":  make(map[string]int64),
-		"errors":    make(map[string]map[string]int64),
-		"latencies": make(map[string]map[string]interface{}),
-	}
-
-	// 复制请求计数 (Copy request counts)
-	for op, count := range mc.requests {
-		metrics["requests"].(map[string]int64)[op] = count
-	}
-
-	// 复制错误计数 (Copy error counts)
-	for op, errors := range mc.errors {
-		metrics["errors"].(map[string]map[string]int64)[op] = make(map[string]int64)
-		for errorType, count := range errors {
-			metrics["errors"].(map[string]map[string]int64)[op][errorType] = count
-		}
-	}
-
-	// 计算延迟统计 (Calculate latency statistics)
-	for op, durations := range mc.latencies {
-		if len(durations) > 0 {
-			var total time.Duration
-			min := durations[0]
-			max := durations[0]
-
-			for _, d := range durations {
-				total += d
-				if d < min {
-					min = d
-				}
-				if d > max {
-					max = d
-				}
-			}
-
-			avg := total / time.Duration(len(durations))
-			metrics["latencies"].(map[string]map[string]interface{})[op] = map[string]interface{}{
-				"count":   len(durations),
-				"avg":     avg,
-				"min":     min,
-				"max":     max,
-			}
-		}
+// NewServiceMetrics 创建服务指标
+// (NewServiceMetrics creates the service metrics)
+func NewServiceMetrics(opts *lmccmetrics.Options, reg *lmccmetrics.Registry) (*ServiceMetrics, error) {
+	requests, err := lmccmetrics.NewCounter(opts, "requests_total", "Number of handled requests.",
+		[]string{"operation"}, lmccmetrics.WithRegistry(reg))
+	if err != nil {
+		return nil, err
 	}
-
-	return metrics
-}
-
-// TracingService 链路追踪服务
-// (TracingService provides distributed tracing)
-type TracingService struct {
-	config *ServiceConfig
-	logger log.Logger
-}
-
-// NewTracingService 创建链路追踪服务
-// (NewTracingService creates a new tracing service)
-func NewTracingService(cfg *ServiceConfig, logger log.Logger) *TracingService {
-	return &TracingService{
-		config: cfg,
-		logger: logger.WithValues("component", "tracing"),
+	// 按错误码和类别统计错误 (Errors are counted by error code and category)
+	errorCounter, err := lmccmetrics.NewErrorCounter(opts, []string{"operation"}, lmccmetrics.WithRegistry(reg))
+	if err != nil {
+		return nil, err
 	}
-}
-
-// GenerateTraceID 生成链路追踪ID
-// (GenerateTraceID generates a new trace ID)
-func (ts *TracingService) GenerateTraceID() string {
-	traceID := fmt.Sprintf("trace_%d_%d", time.Now().UnixNano(), os.Getpid())
-	ts.logger.Debugw("Generated trace ID", "trace_id", traceID)
-	return traceID
-}
-
-// WithTraceID 在上下文中添加追踪ID
-// (WithTraceID adds trace ID to context)
-func (ts *TracingService) WithTraceID(ctx context.Context, traceID string) context.Context {
-	return context.WithValue(ctx, "trace_id", traceID)
-}
-
-// GetTraceID 从上下文获取追踪ID
-// (GetTraceID retrieves trace ID from context)
-func (ts *TracingService) GetTraceID(ctx context.Context) string {
-	if traceID, ok := ctx.Value("trace_id").(string); ok {
-		return traceID
+	duration, err := lmccmetrics.NewHistogram(opts, "request_duration_seconds", "Duration of successful requests in seconds.",
+		[]string{"operation"}, lmccmetrics.WithRegistry(reg))
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	return &ServiceMetrics{requests: requests, errors: errorCounter, duration: duration}, nil
 }
 
 // DatabaseService 数据库服务
@@ -458,8 +377,10 @@ func (ds *DatabaseService) seedData() {
 
 // GetUser 获取用户
 // (GetUser retrieves a user by ID)
-func (ds *DatabaseService) GetUser(ctx context.Context, userID string) (*User, error) {
-	traceID := ds.getTraceID(ctx)
+func (ds *DatabaseService) GetUser(ctx context.Context, userID string) (user *User, err error) {
+	ctx, span := trace.Start(ctx, "db.GetUser")
+	defer func() { trace.End(span, err) }()
+	traceID := trace.TraceID(ctx)
 	logger := ds.logger.WithValues("trace_id", traceID, "operation", "db_get_user")
 
 	start := time.Now()
@@ -476,7 +397,7 @@ func (ds *DatabaseService) GetUser(ctx context.Context, userID string) (*User, e
 	duration := time.Since(start)
 
 	if !exists {
-		err := errors.New("user not found")
+		err = errors.NewWithCode(errors.ErrNotFound, "user not found")
 		logger.Warnw("User not found", "user_id", userID, "duration", duration)
 		return nil, err
 	}
@@ -490,8 +411,10 @@ func (ds *DatabaseService) GetUser(ctx context.Context, userID string) (*User, e
 
 // CreateUser 创建用户
 // (CreateUser creates a new user)
-func (ds *DatabaseService) CreateUser(ctx context.Context, username, email string) (*User, error) {
-	traceID := ds.getTraceID(ctx)
+func (ds *DatabaseService) CreateUser(ctx context.Context, username, email string) (user *User, err error) {
+	ctx, span := trace.Start(ctx, "db.CreateUser")
+	defer func() { trace.End(span, err) }()
+	traceID := trace.TraceID(ctx)
 	logger := ds.logger.WithValues("trace_id", traceID, "operation", "db_create_user")
 
 	start := time.Now()
@@ -506,13 +429,13 @@ func (ds *DatabaseService) CreateUser(ctx context.Context, username, email strin
 	for _, user := range ds.users {
 		if user.Username == username {
 			ds.mu.RUnlock()
-			err := errors.New("username already exists")
+			err = errors.New("username already exists")
 			logger.Warnw("Username conflict", "username", username)
 			return nil, err
 		}
 		if user.Email == email {
 			ds.mu.RUnlock()
-			err := errors.New("email already exists")
+			err = errors.New("email already exists")
 			logger.Warnw("Email conflict", "email", email)
 			return nil, err
 		}
@@ -520,7 +443,7 @@ func (ds *DatabaseService) CreateUser(ctx context.Context, username, email strin
 	ds.mu.RUnlock()
 
 	// 创建新用户 (Create new user)
-	user := &User{
+	user = &User{
 		ID:       fmt.Sprintf("user_%d", time.Now().Unix()),
 		Username: username,
 		Email:    email,
@@ -542,188 +465,103 @@ func (ds *DatabaseService) CreateUser(ctx context.Context, username, email strin
 	return user, nil
 }
 
-// getTraceID 从上下文获取追踪ID
-// (getTraceID retrieves trace ID from context)
-func (ds *DatabaseService) getTraceID(ctx context.Context) string {
-	if traceID, ok := ctx.Value("trace_id").(string); ok {
-		return traceID
+// registerHealthChecks 在 SDK 的健康检查注册表中注册服务的检查，服务的健康端点和调试服务器的 /readyz 共用这些检查
+// (registerHealthChecks registers the service's checks in the SDK health registry, shared by the service's health endpoint and the debug server's /readyz)
+func registerHealthChecks(service *UserService) error {
+	health := service.sdk.Health
+	if err := health.Register("database", service.checkDatabase, healthcheck.WithTimeout(time.Second)); err != nil {
+		return err
 	}
-	return "unknown"
-}
-
-// HealthChecker 健康检查器
-// (HealthChecker provides health check functionality)
-type HealthChecker struct {
-	service *UserService
-	logger  log.Logger
-}
-
-// NewHealthChecker 创建健康检查器
-// (NewHealthChecker creates a new health checker)
-func NewHealthChecker(service *UserService) *HealthChecker {
-	return &HealthChecker{
-		service: service,
-		logger:  service.logger.WithValues("component", "health"),
+	// 内存压力和响应变慢只会使服务降级，不会使就绪检查失败 (Memory pressure and slow responses only degrade the service; they do not fail readiness)
+	if err := health.Register("memory", service.checkMemory, healthcheck.NonCritical()); err != nil {
+		return err
 	}
+	return health.Register("response_time", service.checkResponseTime,
+		healthcheck.NonCritical(), healthcheck.WithCacheTTL(5*time.Second))
 }
 
-// Check 执行健康检查
-// (Check performs health check)
-func (hc *HealthChecker) Check(ctx context.Context) map[string]interface{} {
-	hc.logger.Debugw("Performing health check")
-
-	health := map[string]interface{}{
-		"status":     "healthy",
-		"timestamp":  time.Now(),
-		"service":    hc.service.config.Service.Name,
-		"version":    hc.service.config.Service.Version,
-		"checks":     make(map[string]interface{}),
+// checkDatabase 检查数据库能否在 100ms 内返回测试用户
+// (checkDatabase checks that the database returns the test user within 100ms)
+func (s *UserService) checkDatabase(ctx context.Context) error {
+	start := time.Now()
+	if _, err := s.db.GetUser(ctx, "user_001"); err != nil {
+		return err
 	}
-
-	// 检查数据库连接 (Check database connection)
-	dbHealth := hc.checkDatabase(ctx)
-	health["checks"].(map[string]interface{})["database"] = dbHealth
-
-	// 检查内存使用 (Check memory usage)
-	memHealth := hc.checkMemory()
-	health["checks"].(map[string]interface{})["memory"] = memHealth
-
-	// 检查服务响应时间 (Check service response time)
-	responseHealth := hc.checkResponseTime(ctx)
-	health["checks"].(map[string]interface{})["response_time"] = responseHealth
-
-	// 判断整体健康状态 (Determine overall health status)
-	if !dbHealth["healthy"].(bool) || !memHealth["healthy"].(bool) || !responseHealth["healthy"].(bool) {
-		health["status"] = "unhealthy"
+	if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+		return errors.Errorf("database responded in %s, above the 100ms threshold", elapsed)
 	}
-
-	hc.logger.Infow("Health check completed", "status", health["status"])
-
-	return health
+	return nil
 }
 
-// checkDatabase 检查数据库健康状态
-// (checkDatabase checks database health)
-func (hc *HealthChecker) checkDatabase(ctx context.Context) map[string]interface{} {
-	start := time.Now()
-
-	// 尝试获取一个测试用户 (Try to get a test user)
-	_, err := hc.service.db.GetUser(ctx, "user_001")
-
-	duration := time.Since(start)
-	healthy := err == nil && duration < 100*time.Millisecond
-
-	return map[string]interface{}{
-		"healthy":       healthy,
-		"response_time": duration,
-		"error":         func() string { if err != nil { return err.Error() }; return "" }(),
+// checkMemory 检查内存使用：设置了 GOMEMLIMIT 时，从操作系统获得的内存（sys）超过上限的 90% 视为不健康；
+// GOMEMLIMIT 限制的是运行时的全部内存，而不只是堆
+// (checkMemory checks memory usage: with GOMEMLIMIT set, memory obtained from the OS (sys) above 90% of the limit is unhealthy;
+// GOMEMLIMIT bounds all runtime memory, not just the heap)
+func (s *UserService) checkMemory(context.Context) error {
+	stats := s.runtime.Stats()
+	if stats.MemoryLimitBytes <= 0 {
+		return nil
 	}
-}
-
-// checkMemory 检查内存使用
-// (checkMemory checks memory usage)
-func (hc *HealthChecker) checkMemory() map[string]interface{} {
-	// 这里可以添加实际的内存检查逻辑 (Add actual memory checking logic here)
-	// 目前返回模拟数据 (Currently returning mock data)
-	return map[string]interface{}{
-		"healthy":    true,
-		"usage_mb":   156.7,
-		"limit_mb":   512.0,
-		"usage_pct":  30.6,
+	sysPct := float64(stats.SysBytes) / float64(stats.MemoryLimitBytes) * 100
+	if sysPct >= 90 {
+		return errors.Errorf("sys memory %.1f MiB is %.1f%% of the %.1f MiB GOMEMLIMIT",
+			float64(stats.SysBytes)/(1<<20), sysPct, float64(stats.MemoryLimitBytes)/(1<<20))
 	}
+	return nil
 }
 
-// checkResponseTime 检查服务响应时间
-// (checkResponseTime checks service response time)
-func (hc *HealthChecker) checkResponseTime(ctx context.Context) map[string]interface{} {
+// checkResponseTime 检查服务能否在 200ms 内处理一个轻量级请求
+// (checkResponseTime checks that the service handles a lightweight request within 200ms)
+func (s *UserService) checkResponseTime(ctx context.Context) error {
 	start := time.Now()
-
-	// 执行一个轻量级操作 (Perform a lightweight operation)
-	req := &UserRequest{ID: "user_001"}
-	_, err := hc.service.GetUser(ctx, req)
-
-	duration := time.Since(start)
-	healthy := err == nil && duration < 200*time.Millisecond
-
-	return map[string]interface{}{
-		"healthy":       healthy,
-		"response_time": duration,
-		"threshold":     "200ms",
+	if _, err := s.GetUser(ctx, &UserRequest{ID: "user_001"}); err != nil {
+		return err
+	}
+	if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+		return errors.Errorf("request took %s, above the 200ms threshold", elapsed)
 	}
+	return nil
 }
 
 // HTTPServer HTTP服务器
 // (HTTPServer provides HTTP endpoints)
 type HTTPServer struct {
-	service       *UserService
-	healthChecker *HealthChecker
-	logger        log.Logger
+	service *UserService
+	logger  log.Logger
 }
 
 // NewHTTPServer 创建HTTP服务器
 // (NewHTTPServer creates a new HTTP server)
 func NewHTTPServer(service *UserService) *HTTPServer {
 	return &HTTPServer{
-		service:       service,
-		healthChecker: NewHealthChecker(service),
-		logger:        service.logger.WithValues("component", "http"),
+		service: service,
+		logger:  service.logger.WithValues("component", "http"),
 	}
 }
 
-// Start 启动HTTP服务器
-// (Start starts the HTTP server)
-func (hs *HTTPServer) Start() error {
+// Start 启动HTTP服务器，ctx 取消时优雅关闭
+// (Start starts the HTTP server and shuts it down gracefully when ctx is cancelled)
+func (hs *HTTPServer) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 
-	// 健康检查端点 (Health check endpoint)
-	mux.HandleFunc(hs.service.config.HTTP.HealthCheckPath, hs.healthHandler)
+	// 健康检查端点，执行 SDK 注册表中的检查 (Health check endpoint running the checks in the SDK registry)
+	mux.Handle(hs.service.config.HTTP.HealthCheckPath, hs.service.sdk.HealthHandler())
 
-	// 指标端点 (Metrics endpoint)
-	mux.HandleFunc(hs.service.config.HTTP.MetricsPath, hs.metricsHandler)
+	// 指标端点，以 Prometheus 文本格式输出 (Metrics endpoint in the Prometheus text format)
+	mux.Handle(hs.service.config.HTTP.MetricsPath, hs.service.sdk.Registry.Handler())
 
 	// 用户API端点 (User API endpoints)
 	mux.HandleFunc("/api/users/", hs.getUserHandler)
 	mux.HandleFunc("/api/users", hs.createUserHandler)
 
-	addr := fmt.Sprintf(":%d", hs.service.config.HTTP.Port)
-	hs.logger.Infow("Starting HTTP server", "address", addr)
+	opts := server.DefaultServerOptions()
+	opts.Addr = fmt.Sprintf(":%d", hs.service.config.HTTP.Port)
+	opts.ShutdownTimeout = 5 * time.Second
+	opts.Logger = hs.logger
+	hs.logger.Infow("Starting HTTP server", "address", opts.Addr)
 
-	server := &http.Server{
-		Addr:    addr,
-		Handler: mux,
-	}
-
-	return server.ListenAndServe()
-}
-
-// healthHandler 健康检查处理器
-// (healthHandler handles health check requests)
-func (hs *HTTPServer) healthHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	health := hs.healthChecker.Check(ctx)
-
-	w.Header().Set("Content-Type", "application/json")
-	if health["status"] == "healthy" {
-		w.WriteHeader(http.StatusOK)
-	} else {
-		w.WriteHeader(http.StatusServiceUnavailable)
-	}
-
-	fmt.Fprintf(w, `{"status": "%s", "timestamp": "%s", "service": "%s", "version": "%s"}`,
-		health["status"], health["timestamp"], health["service"], health["version"])
-}
-
-// metricsHandler 指标处理器
-// (metricsHandler handles metrics requests)
-func (hs *HTTPServer) metricsHandler(w http.ResponseWriter, r *http.Request) {
-	metrics := hs.service.metrics.GetMetrics()
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	// 简化的指标输出 (Simplified metrics output)
-	fmt.Fprintf(w, `{"requests": %v, "timestamp": "%s"}`,
-		metrics["requests"], time.Now().Format(time.RFC3339))
+	// 超时、/healthz 与 /readyz 以及优雅关闭由 server 负责 (Timeouts, /healthz and /readyz and the graceful shutdown are handled by server)
+	return server.New(opts).Run(ctx, mux)
 }
 
 // getUserHandler 获取用户处理器
@@ -741,8 +579,9 @@ func (hs *HTTPServer) getUserHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 从 traceparent 请求头继续调用方的追踪 (Continue the caller's trace from the traceparent header)
 	req := &UserRequest{ID: userID}
-	resp, err := hs.service.GetUser(r.Context(), req)
+	resp, err := hs.service.GetUser(trace.Extract(r.Context(), r.Header), req)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -772,7 +611,7 @@ func (hs *HTTPServer) createUserHandler(w http.ResponseWriter, r *http.Request)
 	email := r.FormValue("email")
 
 	req := &UserRequest{Username: username, Email: email}
-	resp, err := hs.service.CreateUser(r.Context(), req)
+	resp, err := hs.service.CreateUser(trace.Extract(r.Context(), r.Header), req)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -835,18 +674,26 @@ func runDemo(service *UserService) {
 
 	// 演示健康检查 (Demonstrate health check)
 	fmt.Println("2. Testing Health Check:")
-	healthChecker := NewHealthChecker(service)
-	health := healthChecker.Check(ctx)
-	fmt.Printf("   Service Status: %s\n", health["status"])
-	fmt.Printf("   Timestamp: %s\n", health["timestamp"])
+	report := service.sdk.CheckHealth(ctx)
+	fmt.Printf("   Service Status: %s\n", report.Status)
+	for _, check := range report.Checks {
+		fmt.Printf("   %s: %s (%s) %s\n", check.Name, check.Status, check.Duration, check.Error)
+	}
 	fmt.Println()
 
 	// 显示指标 (Show metrics)
 	fmt.Println("3. Service Metrics:")
-	metrics := service.metrics.GetMetrics()
-	requests := metrics["requests"].(map[string]int64)
-	for operation, count := range requests {
-		fmt.Printf("   %s: %d requests\n", operation, count)
+	families, err := service.sdk.Registry.Gather()
+	if err != nil {
+		fmt.Printf("   Failed to gather metrics: %v\n", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "user_service_requests_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			fmt.Printf("   %s: %.0f requests\n", m.GetLabel()[0].GetValue(), m.GetCounter().GetValue())
+		}
 	}
 	fmt.Println()
 
@@ -875,12 +722,11 @@ func main() {
 	fmt.Println("Starting HTTP server for additional testing...")
 	httpServer := NewHTTPServer(userService)
 
-	// 在goroutine中启动HTTP服务器 (Start HTTP server in goroutine)
-	go func() {
-		if err := httpServer.Start(); err != nil {
-			userService.logger.Errorw("HTTP server failed", "error", err)
-		}
-	}()
+	// 在受监管的后台任务中启动HTTP服务器，失败和 panic 会被记录 (Start HTTP server in a supervised background task; failures and panics are logged)
+	serverCtx := log.NewContextWithLogger(context.Background(), userService.logger)
+	if err := tasks.Go(serverCtx, "http-server", httpServer.Start); err != nil {
+		userService.logger.Errorw("Failed to start HTTP server", "error", err)
+	}
 
 	// 等待一段时间让服务器启动 (Wait for server to start)
 	time.Sleep(1 * time.Second)
@@ -895,6 +741,13 @@ func main() {
 	// 运行一些HTTP测试 (Run some HTTP tests)
 	runHTTPTests(cfg)
 
+	// 停止后台任务（等待 HTTP 服务器退出）和 SDK 子系统 (Stop background tasks, waiting for the HTTP server to exit, and the SDK subsystems)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := userService.sdk.Shutdown(shutdownCtx); err != nil {
+		userService.logger.Warnw("Shutdown did not complete cleanly", "error", err)
+	}
+
 	userService.logger.Infow("Microservice example completed successfully")
 	fmt.Println("=== Example completed successfully ===")
 }
@@ -927,13 +780,17 @@ func runHTTPTests(cfg *ServiceConfig) {
 		resp.Body.Close()
 	}
 
-	// 测试用户API (Test user API)
+	// 测试用户API，通过 traceparent 请求头传播客户端的追踪 (Test user API, propagating the client's trace in the traceparent header)
 	fmt.Println("Testing user API:")
-	resp, err = http.Get(baseURL + "/api/users/user_001")
+	ctx, span := trace.Start(context.Background(), "client.GetUser")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/users/user_001", nil)
+	trace.Inject(ctx, req.Header)
+	resp, err = http.DefaultClient.Do(req)
+	trace.End(span, err)
 	if err != nil {
 		fmt.Printf("   ❌ User API failed: %v\n", err)
 	} else {
-		fmt.Printf("   ✅ User API successful: %d\n", resp.StatusCode)
+		fmt.Printf("   ✅ User API successful: %d (trace %s)\n", resp.StatusCode, trace.TraceID(ctx))
 		resp.Body.Close()
 	}
 
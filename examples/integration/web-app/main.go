@@ -18,7 +18,10 @@ import (
 
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/httpx"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/tasks"
 )
 
 // AppConfig Web应用配置
@@ -82,7 +85,6 @@ type APIResponse struct {
 type WebApp struct {
 	config *AppConfig
 	logger log.Logger
-	server *http.Server
 }
 
 // NewWebApp 创建Web应用实例
@@ -120,128 +122,6 @@ func NewWebApp(cfg *AppConfig) *WebApp {
 	}
 }
 
-// LoggingMiddleware 日志中间件
-// (LoggingMiddleware provides request logging)
-type LoggingMiddleware struct {
-	logger log.Logger
-}
-
-// NewLoggingMiddleware 创建日志中间件
-// (NewLoggingMiddleware creates logging middleware)
-func NewLoggingMiddleware(logger log.Logger) *LoggingMiddleware {
-	return &LoggingMiddleware{
-		logger: logger.WithValues("middleware", "logging"),
-	}
-}
-
-// Handler 日志中间件处理器
-// (Handler logging middleware handler)
-func (m *LoggingMiddleware) Handler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// 生成请求ID (Generate request ID)
-		requestID := fmt.Sprintf("req_%d", time.Now().UnixNano())
-
-		// 创建带请求信息的日志记录器 (Create logger with request info)
-		requestLogger := m.logger.WithValues(
-			"request_id", requestID,
-			"method", r.Method,
-			"path", r.URL.Path,
-			"remote_addr", r.RemoteAddr,
-			"user_agent", r.UserAgent())
-
-		// 记录请求开始 (Log request start)
-		requestLogger.Infow("HTTP request started",
-			"query_params", r.URL.RawQuery,
-			"content_length", r.ContentLength)
-
-		// 创建响应写入器包装器 (Create response writer wrapper)
-		wrapped := &responseWriter{
-			ResponseWriter: w,
-			statusCode:     http.StatusOK,
-		}
-
-		// 将请求信息添加到上下文 (Add request info to context)
-		ctx := context.WithValue(r.Context(), "request_id", requestID)
-		ctx = context.WithValue(ctx, "logger", requestLogger)
-
-		// 执行下一个处理器 (Execute next handler)
-		next.ServeHTTP(wrapped, r.WithContext(ctx))
-
-		// 记录请求完成 (Log request completion)
-		duration := time.Since(start)
-
-		logLevel := requestLogger.Infow
-		if wrapped.statusCode >= 400 && wrapped.statusCode < 500 {
-			logLevel = requestLogger.Warnw
-		} else if wrapped.statusCode >= 500 {
-			logLevel = requestLogger.Errorw
-		}
-
-		logLevel("HTTP request completed",
-			"status_code", wrapped.statusCode,
-			"duration", duration,
-			"response_size", wrapped.bytesWritten)
-	})
-}
-
-// responseWriter 响应写入器包装器
-// (responseWriter wrapper for capturing response details)
-type responseWriter struct {
-	http.ResponseWriter
-	statusCode   int
-	bytesWritten int
-}
-
-func (rw *responseWriter) WriteHeader(statusCode int) {
-	rw.statusCode = statusCode
-	rw.ResponseWriter.WriteHeader(statusCode)
-}
-
-func (rw *responseWriter) Write(data []byte) (int, error) {
-	n, err := rw.ResponseWriter.Write(data)
-	rw.bytesWritten += n
-	return n, err
-}
-
-// ErrorMiddleware 错误处理中间件
-// (ErrorMiddleware provides error handling)
-type ErrorMiddleware struct {
-	logger log.Logger
-}
-
-// NewErrorMiddleware 创建错误处理中间件
-// (NewErrorMiddleware creates error handling middleware)
-func NewErrorMiddleware(logger log.Logger) *ErrorMiddleware {
-	return &ErrorMiddleware{
-		logger: logger.WithValues("middleware", "error"),
-	}
-}
-
-// Handler 错误处理中间件处理器
-// (Handler error handling middleware handler)
-func (m *ErrorMiddleware) Handler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				requestID := r.Context().Value("request_id")
-				
-				m.logger.Errorw("Panic recovered",
-					"request_id", requestID,
-					"panic", err,
-					"path", r.URL.Path,
-					"method", r.Method)
-
-				// 返回500错误 (Return 500 error)
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			}
-		}()
-
-		next.ServeHTTP(w, r)
-	})
-}
-
 // UserService 用户服务
 // (UserService provides user operations)
 type UserService struct {
@@ -261,7 +141,7 @@ func NewUserService(logger log.Logger, cfg *AppConfig) *UserService {
 // GetUser 获取用户
 // (GetUser retrieves a user)
 func (s *UserService) GetUser(ctx context.Context, userID string) (*User, error) {
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	logger := s.logger.WithValues("request_id", requestID, "operation", "get_user")
 
 	logger.Debugw("Starting user retrieval",
@@ -299,7 +179,7 @@ func (s *UserService) GetUser(ctx context.Context, userID string) (*User, error)
 // CreateUser 创建用户
 // (CreateUser creates a new user)
 func (s *UserService) CreateUser(ctx context.Context, username, email string) (*User, error) {
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	logger := s.logger.WithValues("request_id", requestID, "operation", "create_user")
 
 	logger.Infow("Starting user creation",
@@ -353,7 +233,7 @@ func (s *UserService) CreateUser(ctx context.Context, username, email string) (*
 // simulateDBQuery 模拟数据库查询
 // (simulateDBQuery simulates database query)
 func (s *UserService) simulateDBQuery(ctx context.Context, operation, userID string) error {
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	logger := s.logger.WithValues("request_id", requestID, "component", "database")
 
 	start := time.Now()
@@ -389,7 +269,7 @@ func (s *UserService) simulateDBQuery(ctx context.Context, operation, userID str
 // simulateDBInsert 模拟数据库插入
 // (simulateDBInsert simulates database insert)
 func (s *UserService) simulateDBInsert(ctx context.Context, userID, username, email string) error {
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	logger := s.logger.WithValues("request_id", requestID, "component", "database")
 
 	start := time.Now()
@@ -435,7 +315,7 @@ func NewAPIHandler(userService *UserService, logger log.Logger) *APIHandler {
 // writeJSONResponse 写入JSON响应
 // (writeJSONResponse writes JSON response)
 func (h *APIHandler) writeJSONResponse(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}, err error) {
-	requestID := r.Context().Value("request_id").(string)
+	requestID := httpx.RequestIDFromRequest(r)
 
 	response := APIResponse{
 		Success:   err == nil,
@@ -552,67 +432,35 @@ func (app *WebApp) setupRoutes() *http.ServeMux {
 	userService := NewUserService(app.logger, app.config)
 	apiHandler := NewAPIHandler(userService, app.logger)
 
-	// 创建中间件 (Create middleware)
-	loggingMiddleware := NewLoggingMiddleware(app.logger)
-	errorMiddleware := NewErrorMiddleware(app.logger)
-
 	// 设置路由 (Setup routes)
 	mux.HandleFunc("/api/health", apiHandler.HealthHandler)
 	mux.HandleFunc("/api/users/", apiHandler.GetUserHandler)   // GET /api/users/{id}
 	mux.HandleFunc("/api/users", apiHandler.CreateUserHandler) // POST /api/users
 
-	// 应用中间件 (Apply middleware)
-	var handler http.Handler = mux
-	handler = loggingMiddleware.Handler(handler)
-	handler = errorMiddleware.Handler(handler)
-
 	return mux
 }
 
-// Start 启动Web应用
-// (Start starts the web application)
-func (app *WebApp) Start() error {
+// Start 启动Web应用，ctx 被取消或收到 SIGINT/SIGTERM 时优雅关闭
+// (Start runs the web application and shuts it down gracefully when ctx is cancelled or SIGINT/SIGTERM arrives)
+func (app *WebApp) Start(ctx context.Context) error {
 	mux := app.setupRoutes()
 
-	app.server = &http.Server{
-		Addr:         fmt.Sprintf("%s:%d", app.config.Server.Host, app.config.Server.Port),
-		Handler:      mux,
-		ReadTimeout:  time.Duration(app.config.Server.ReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(app.config.Server.WriteTimeout) * time.Second,
-		IdleTimeout:  time.Duration(app.config.Server.IdleTimeout) * time.Second,
-	}
-
-	// 应用中间件到服务器 (Apply middleware to server)
-	loggingMiddleware := NewLoggingMiddleware(app.logger)
-	errorMiddleware := NewErrorMiddleware(app.logger)
-
-	var handler http.Handler = mux
-	handler = loggingMiddleware.Handler(handler)
-	handler = errorMiddleware.Handler(handler)
-
-	app.server.Handler = handler
+	opts := server.DefaultServerOptions()
+	opts.Addr = fmt.Sprintf("%s:%d", app.config.Server.Host, app.config.Server.Port)
+	opts.ReadTimeout = time.Duration(app.config.Server.ReadTimeout) * time.Second
+	opts.WriteTimeout = time.Duration(app.config.Server.WriteTimeout) * time.Second
+	opts.IdleTimeout = time.Duration(app.config.Server.IdleTimeout) * time.Second
+	opts.Logger = app.logger
 
 	app.logger.Infow("Starting web server",
-		"address", app.server.Addr,
+		"address", opts.Addr,
 		"read_timeout", app.config.Server.ReadTimeout,
 		"write_timeout", app.config.Server.WriteTimeout)
 
-	if err := app.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return errors.Wrap(err, "failed to start web server")
-	}
-
-	return nil
-}
-
-// Stop 停止Web应用
-// (Stop stops the web application)
-func (app *WebApp) Stop(ctx context.Context) error {
-	app.logger.Infow("Stopping web server")
-
-	if app.server != nil {
-		if err := app.server.Shutdown(ctx); err != nil {
-			return errors.Wrap(err, "failed to stop web server")
-		}
+	// 应用请求 ID、访问日志和 panic 恢复中间件；/healthz 和 /readyz 由 server 提供
+	// (Apply request ID, access log and panic recovery middleware; /healthz and /readyz are served by the server)
+	if err := server.New(opts).Run(ctx, httpx.Default(httpx.WithLogger(app.logger))(mux)); err != nil {
+		return errors.Wrap(err, "failed to run web server")
 	}
 
 	app.logger.Infow("Web server stopped successfully")
@@ -634,13 +482,20 @@ func main() {
 	// 创建Web应用 (Create web application)
 	app := NewWebApp(cfg)
 
-	// 启动服务器在后台 (Start server in background)
+	// 在受监管的后台任务中启动服务器，失败和 panic 会被记录 (Start server in a supervised background task; failures and panics are logged)
 	serverErrChan := make(chan error, 1)
-	go func() {
-		if err := app.Start(); err != nil && err != http.ErrServerClosed {
+	serverCtx, stopServer := context.WithCancel(log.NewContextWithLogger(context.Background(), app.logger))
+	defer stopServer()
+	if err := tasks.Go(serverCtx, "web-server", func(ctx context.Context) error {
+		err := app.Start(ctx)
+		if err != nil {
 			serverErrChan <- err
 		}
-	}()
+		return err
+	}); err != nil {
+		app.logger.Errorw("Failed to start web server", "error", err)
+		os.Exit(1)
+	}
 
 	// 等待服务器启动 (Wait for server to start)
 	time.Sleep(2 * time.Second)
@@ -652,6 +507,7 @@ func main() {
 	fmt.Printf("Web server is running on http://%s:%d\n", cfg.Server.Host, cfg.Server.Port)
 	fmt.Println("Available endpoints:")
 	fmt.Println("  GET  /api/health")
+	fmt.Println("  GET  /healthz, /readyz")
 	fmt.Println("  GET  /api/users/{id}")
 	fmt.Println("  POST /api/users")
 	fmt.Println()
@@ -675,8 +531,10 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := app.Stop(ctx); err != nil {
-		app.logger.Errorw("Error during shutdown", "error", err)
+	// 取消服务器的 context 触发优雅关闭，并等待其完成 (Cancel the server context to trigger a graceful shutdown and wait for it)
+	stopServer()
+	if err := tasks.Shutdown(ctx); err != nil {
+		app.logger.Errorw("Background tasks did not stop in time", "error", err)
 		os.Exit(1)
 	}
 
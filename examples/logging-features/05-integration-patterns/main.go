@@ -85,8 +85,8 @@ func (m *LoggingMiddleware) Handler(next http.Handler) http.Handler {
 		}
 		
 		// 将日志记录器添加到上下文 (Add logger to context)
-		ctx := context.WithValue(r.Context(), "logger", requestLogger)
-		ctx = context.WithValue(ctx, "request_id", requestID)
+		ctx := log.NewContextWithLogger(r.Context(), requestLogger)
+		ctx = log.ContextWithRequestID(ctx, requestID)
 		
 		// 执行下一个处理器 (Execute next handler)
 		next.ServeHTTP(wrapped, r.WithContext(ctx))
@@ -147,7 +147,7 @@ func NewUserService(logger log.Logger, cfg *AppConfig) *UserService {
 // (CreateUser creates a user with integrated logging and error handling)
 func (s *UserService) CreateUser(ctx context.Context, userID, email string) error {
 	// 从上下文获取请求ID (Get request ID from context)
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	
 	// 创建带上下文的日志记录器 (Create context-aware logger)
 	logger := s.logger.WithValues("request_id", requestID, "operation", "create_user")
@@ -197,7 +197,7 @@ func (s *UserService) CreateUser(ctx context.Context, userID, email string) erro
 // GetUser 获取用户
 // (GetUser retrieves a user)
 func (s *UserService) GetUser(ctx context.Context, userID string) (map[string]interface{}, error) {
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	logger := s.logger.WithValues("request_id", requestID, "operation", "get_user")
 	
 	logger.Debugw("Starting user retrieval",
@@ -238,7 +238,7 @@ func (s *UserService) GetUser(ctx context.Context, userID string) (map[string]in
 // simulateDBOperation 模拟数据库操作
 // (simulateDBOperation simulates database operation)
 func (s *UserService) simulateDBOperation(ctx context.Context, operation, table string, params map[string]interface{}) error {
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	logger := s.logger.WithValues("request_id", requestID, "component", "database")
 	
 	start := time.Now()
@@ -360,7 +360,7 @@ func NewErrorHandlingIntegration(logger log.Logger) *ErrorHandlingIntegration {
 // ProcessWithErrorHandling 带错误处理的处理过程
 // (ProcessWithErrorHandling processing with error handling)
 func (ehi *ErrorHandlingIntegration) ProcessWithErrorHandling(ctx context.Context, data map[string]interface{}) error {
-	requestID := ctx.Value("request_id")
+	requestID, _ := log.RequestIDFromContext(ctx)
 	logger := ehi.logger.WithValues("request_id", requestID)
 	
 	logger.Infow("Starting error handling integration demo",
@@ -475,11 +475,9 @@ func demonstrateMiddlewareIntegration() {
 	
 	mux.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
 		// 从上下文获取日志记录器 (Get logger from context)
-		if ctxLogger, ok := r.Context().Value("logger").(log.Logger); ok {
-			ctxLogger.Infow("Processing API request",
-				"endpoint", "/api/users",
-				"handler", "users")
-		}
+		log.FromContext(r.Context()).Infow("Processing API request",
+			"endpoint", "/api/users",
+			"handler", "users")
 		
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -487,9 +485,7 @@ func demonstrateMiddlewareIntegration() {
 	})
 	
 	mux.HandleFunc("/api/health", func(w http.ResponseWriter, r *http.Request) {
-		if ctxLogger, ok := r.Context().Value("logger").(log.Logger); ok {
-			ctxLogger.Infow("Health check requested")
-		}
+		log.FromContext(r.Context()).Infow("Health check requested")
 		
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -616,7 +612,7 @@ func demonstrateServiceIntegration() {
 	for _, op := range operations {
 		fmt.Printf("Operation: %s\n", op.name)
 		
-		ctx := context.WithValue(context.Background(), "request_id", fmt.Sprintf("req_%d", time.Now().UnixNano()))
+		ctx := log.ContextWithRequestID(context.Background(), fmt.Sprintf("req_%d", time.Now().UnixNano()))
 		
 		if op.email != "" {
 			// 创建用户操作 (Create user operation)
@@ -691,7 +687,7 @@ func demonstrateErrorHandlingIntegration() {
 	for _, tc := range testCases {
 		fmt.Printf("Test case: %s\n", tc.name)
 		
-		ctx := context.WithValue(context.Background(), "request_id", fmt.Sprintf("req_%d", time.Now().UnixNano()))
+		ctx := log.ContextWithRequestID(context.Background(), fmt.Sprintf("req_%d", time.Now().UnixNano()))
 		
 		err := ehi.ProcessWithErrorHandling(ctx, tc.data)
 		if err != nil {
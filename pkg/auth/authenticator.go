@@ -0,0 +1,167 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// Authenticator 校验 JWT 令牌（HMAC 共享密钥、JWKS 或 OIDC 发现）并生成调用方信息。
+// (Authenticator validates JWT tokens (HMAC shared secret, JWKS or OIDC discovery) and produces principals.)
+type Authenticator struct {
+	opts       *Options
+	parser     *jwt.Parser
+	secret     []byte
+	keys       *keySet
+	client     *http.Client
+	skipPaths  map[string]struct{}
+	timeFunc   func() time.Time
+	algorithms []string
+}
+
+// Option 是 Authenticator 的可选配置。
+// (Option configures optional Authenticator settings.)
+type Option func(*Authenticator)
+
+// WithHTTPClient 设置获取 JWKS 和 OIDC 发现文档使用的 HTTP 客户端。
+// (WithHTTPClient sets the HTTP client used to fetch the JWKS and the OIDC discovery document.)
+func WithHTTPClient(client *http.Client) Option {
+	return func(a *Authenticator) {
+		if client != nil {
+			a.client = client
+		}
+	}
+}
+
+// WithTimeFunc 设置校验时间声明时使用的时钟，主要用于测试。
+// (WithTimeFunc sets the clock used to validate time claims, mainly for tests.)
+func WithTimeFunc(f func() time.Time) Option {
+	return func(a *Authenticator) {
+		if f != nil {
+			a.timeFunc = f
+		}
+	}
+}
+
+// New 根据选项创建认证器。签名密钥在首次校验令牌时才会获取。
+// (New creates an authenticator from options. Signing keys are fetched lazily on the first validation.)
+func New(opts *Options, authOpts ...Option) (*Authenticator, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid auth options"),
+			lmccerrors.ErrAuthOptionInvalid,
+		)
+	}
+
+	a := &Authenticator{
+		opts:      opts,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		skipPaths: make(map[string]struct{}, len(opts.SkipPaths)),
+		timeFunc:  time.Now,
+	}
+	for _, opt := range authOpts {
+		opt(a)
+	}
+	for _, p := range opts.SkipPaths {
+		a.skipPaths[p] = struct{}{}
+	}
+
+	a.algorithms = opts.Algorithms
+	if opts.Secret != "" {
+		a.secret = []byte(opts.Secret)
+		if len(a.algorithms) == 0 {
+			a.algorithms = hmacAlgorithms
+		}
+	} else {
+		a.keys = &keySet{
+			client:          a.client,
+			jwksURL:         opts.JWKSURL,
+			issuer:          opts.Issuer,
+			ttl:             opts.JWKSCacheTTL,
+			refreshInterval: opts.JWKSRefreshInterval,
+		}
+		if len(a.algorithms) == 0 {
+			a.algorithms = asymmetricAlgorithms
+		}
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(a.algorithms),
+		jwt.WithLeeway(opts.Leeway),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(a.timeFunc),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if len(opts.Audiences) > 0 {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audiences...))
+	}
+	a.parser = jwt.NewParser(parserOpts...)
+
+	return a, nil
+}
+
+// Options 返回认证器使用的选项。(Options returns the options used by the authenticator.)
+func (a *Authenticator) Options() *Options {
+	return a.opts
+}
+
+// Authenticate 校验令牌并返回调用方。令牌无效时错误码为 ErrAuthTokenInvalid，
+// 获取签名密钥失败时为 ErrAuthKeyFetch。
+// (Authenticate validates the token and returns the principal. Invalid tokens yield ErrAuthTokenInvalid;
+// failures to fetch signing keys yield ErrAuthKeyFetch.)
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if token == "" {
+		return nil, lmccerrors.NewWithCode(lmccerrors.ErrAuthTokenInvalid, "missing bearer token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		if a.secret != nil {
+			return a.secret, nil
+		}
+		kid, _ := t.Header["kid"].(string)
+		return a.keys.key(ctx, kid)
+	})
+	if err != nil {
+		if lmccerrors.IsCode(err, lmccerrors.ErrAuthKeyFetch) {
+			return nil, err
+		}
+		return nil, lmccerrors.WithCode(lmccerrors.Wrap(err, "invalid token"), lmccerrors.ErrAuthTokenInvalid)
+	}
+
+	p := &Principal{Claims: claims, Token: token}
+	p.Subject, _ = claims.GetSubject()
+	p.Issuer, _ = claims.GetIssuer()
+	if aud, err := claims.GetAudience(); err == nil {
+		p.Audience = aud
+	}
+	if a.opts.ScopeClaim != "" {
+		p.Scopes = parseScopes(claims[a.opts.ScopeClaim])
+	}
+	return p, nil
+}
+
+// skip 判断路径是否无需认证。(skip reports whether the path does not require authentication.)
+func (a *Authenticator) skip(path string) bool {
+	if !a.opts.Enabled {
+		return true
+	}
+	_, ok := a.skipPaths[path]
+	return ok
+}
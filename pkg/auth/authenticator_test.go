@@ -0,0 +1,307 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for token validation, JWKS caching, OIDC discovery and the middleware.
+ */
+
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/auth"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// claims builds standard test claims valid for an hour.
+// (claims 构造有效期为一小时的标准测试声明。)
+func claims(extra jwt.MapClaims) jwt.MapClaims {
+	now := time.Now()
+	c := jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://issuer.example.com",
+		"aud":   []string{"api"},
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"scope": "read write",
+	}
+	for k, v := range extra {
+		c[k] = v
+	}
+	return c
+}
+
+// sign signs claims with the given method, key and kid.
+// (sign 使用给定算法、密钥和 kid 签名声明。)
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, c jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+// b64 encodes a big integer as base64url.
+// (b64 将大整数编码为 base64url。)
+func b64(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+// newHMACAuthenticator creates an authenticator using the shared test secret.
+// (newHMACAuthenticator 创建使用共享测试密钥的认证器。)
+func newHMACAuthenticator(t *testing.T) *auth.Authenticator {
+	t.Helper()
+	opts := auth.NewOptions()
+	opts.Secret = testSecret
+	opts.Issuer = "https://issuer.example.com"
+	opts.Audiences = []string{"api", "admin"}
+	opts.SkipPaths = []string{"/healthz"}
+	a, err := auth.New(opts)
+	require.NoError(t, err)
+	return a
+}
+
+// TestOptionsValidate tests option validation.
+// (TestOptionsValidate 测试选项校验。)
+func TestOptionsValidate(t *testing.T) {
+	opts := auth.NewOptions()
+	assert.Len(t, opts.Validate(), 1, "a key source is required")
+
+	opts.JWKSURL = "not a url"
+	opts.Algorithms = []string{"none"}
+	assert.Len(t, opts.Validate(), 2)
+
+	_, err := auth.New(opts)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrAuthOptionInvalid))
+
+	opts.Enabled = false
+	assert.Empty(t, opts.Validate())
+}
+
+// TestAuthenticateHMAC tests validation of HMAC-signed tokens.
+// (TestAuthenticateHMAC 测试 HMAC 签名令牌的校验。)
+func TestAuthenticateHMAC(t *testing.T) {
+	a := newHMACAuthenticator(t)
+	ctx := context.Background()
+
+	p, err := a.Authenticate(ctx, sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", p.Subject)
+	assert.Equal(t, []string{"api"}, p.Audience)
+	assert.True(t, p.HasScopes("read", "write"))
+	assert.False(t, p.HasScopes("delete"))
+
+	cases := map[string]string{
+		"expired":      sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"no exp":       sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(jwt.MapClaims{"exp": nil})),
+		"wrong issuer": sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(jwt.MapClaims{"iss": "https://evil.example.com"})),
+		"wrong aud":    sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(jwt.MapClaims{"aud": "other"})),
+		"wrong secret": sign(t, jwt.SigningMethodHS256, []byte("another-secret-another-secret!!"), "", claims(nil)),
+		"alg none":     sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims(nil)),
+		"malformed":    "not.a.token",
+		"empty":        "",
+	}
+	for name, token := range cases {
+		_, err := a.Authenticate(ctx, token)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrAuthTokenInvalid), name)
+	}
+}
+
+// TestAuthenticateJWKS tests JWKS fetching, caching and refetching on key rotation.
+// (TestAuthenticateJWKS 测试 JWKS 的获取、缓存以及密钥轮换时的重新获取。)
+func TestAuthenticateJWKS(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	var rotated atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		keys := []map[string]string{
+			{"kty": "RSA", "kid": "k1", "use": "sig", "n": b64(key1.N), "e": b64(big.NewInt(int64(key1.E)))},
+			{"kty": "RSA", "kid": "enc", "use": "enc", "n": b64(key1.N), "e": b64(big.NewInt(int64(key1.E)))},
+		}
+		if rotated.Load() {
+			keys = append(keys, map[string]string{"kty": "RSA", "kid": "k2", "n": b64(key2.N), "e": b64(big.NewInt(int64(key2.E)))})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	}))
+	defer srv.Close()
+
+	opts := auth.NewOptions()
+	opts.JWKSURL = srv.URL
+	opts.JWKSRefreshInterval = 0
+	a, err := auth.New(opts)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for range 3 {
+		_, err = a.Authenticate(ctx, sign(t, jwt.SigningMethodRS256, key1, "k1", claims(nil)))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), fetches.Load(), "keys are cached")
+
+	_, err = a.Authenticate(ctx, sign(t, jwt.SigningMethodHS256, []byte(testSecret), "k1", claims(nil)))
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrAuthTokenInvalid), "HMAC is not accepted with JWKS")
+
+	rotated.Store(true)
+	_, err = a.Authenticate(ctx, sign(t, jwt.SigningMethodRS256, key2, "k2", claims(nil)))
+	require.NoError(t, err, "an unknown kid triggers a refetch")
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+// TestAuthenticateJWKS_SlowRefresh tests that a slow refetch for an unknown kid does not block tokens signed with cached keys,
+// and that concurrent refetches share one request.
+// (TestAuthenticateJWKS_SlowRefresh 测试为未知 kid 进行的缓慢重新获取不会阻塞使用已缓存密钥签名的令牌，且并发的重新获取共享一次请求。)
+func TestAuthenticateJWKS_SlowRefresh(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetches.Add(1) > 1 {
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "k1", "n": b64(key.N), "e": b64(big.NewInt(int64(key.E)))},
+		}})
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	opts := auth.NewOptions()
+	opts.JWKSURL = srv.URL
+	opts.JWKSRefreshInterval = 0
+	a, err := auth.New(opts)
+	require.NoError(t, err)
+	ctx := context.Background()
+	cached := sign(t, jwt.SigningMethodRS256, key, "k1", claims(nil))
+	_, err = a.Authenticate(ctx, cached)
+	require.NoError(t, err)
+
+	unknown := sign(t, jwt.SigningMethodRS256, key, "rotated", claims(nil))
+	results := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := a.Authenticate(ctx, unknown)
+			results <- err
+		}()
+	}
+	require.Eventually(t, func() bool { return fetches.Load() == 2 }, time.Second, time.Millisecond)
+
+	start := time.Now()
+	_, err = a.Authenticate(ctx, cached)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "cached keys do not wait for the refetch")
+
+	release <- struct{}{}
+	for range 2 {
+		assert.True(t, lmccerrors.IsCode(<-results, lmccerrors.ErrAuthTokenInvalid))
+	}
+	assert.Equal(t, int32(2), fetches.Load(), "concurrent refetches share one request")
+}
+
+// TestAuthenticateOIDCDiscovery tests key discovery through the issuer's OIDC configuration.
+// (TestAuthenticateOIDCDiscovery 测试通过签发者的 OIDC 配置发现密钥。)
+func TestAuthenticateOIDCDiscovery(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": srv.URL, "jwks_uri": srv.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(key.X), "y": b64(key.Y)},
+		}})
+	})
+
+	opts := auth.NewOptions()
+	opts.Issuer = srv.URL
+	a, err := auth.New(opts)
+	require.NoError(t, err)
+
+	p, err := a.Authenticate(context.Background(), sign(t, jwt.SigningMethodES256, key, "ec", claims(jwt.MapClaims{"iss": srv.URL})))
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL, p.Issuer)
+
+	bad, err := auth.New(&auth.Options{Enabled: true, Issuer: srv.URL + "/missing"})
+	require.NoError(t, err)
+	_, err = bad.Authenticate(context.Background(), sign(t, jwt.SigningMethodES256, key, "ec", claims(nil)))
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrAuthKeyFetch))
+}
+
+// TestMiddleware tests the net/http middleware and scope checks.
+// (TestMiddleware 测试 net/http 中间件和权限范围校验。)
+func TestMiddleware(t *testing.T) {
+	a := newHMACAuthenticator(t)
+	var got *auth.Principal
+	final := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	h := a.Middleware(auth.RequireScopes("read")(final))
+	admin := a.Middleware(auth.RequireScopes("admin")(final))
+
+	do := func(h http.Handler, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	token := sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(nil))
+
+	rec := do(h, "/api", token)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "user-1", got.Subject)
+
+	rec = do(h, "/api", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "Unauthorized", body["error"])
+	assert.Equal(t, float64(lmccerrors.ErrAuthTokenInvalid.Code()), body["code"])
+
+	rec = do(h, "/api", "garbage")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "invalid_token")
+
+	rec = do(admin, "/api", token)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Header().Get("WWW-Authenticate"), `scope="admin"`)
+
+	rec = do(a.Middleware(final), "/healthz", "")
+	assert.Equal(t, http.StatusOK, rec.Code, "skip paths bypass authentication")
+}
@@ -0,0 +1,46 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package auth provides JWT/OIDC authentication middleware.
+(auth 包提供 JWT/OIDC 认证中间件。)
+
+Tokens are validated against an HMAC shared secret, a JWKS endpoint or keys discovered
+from the issuer's OIDC configuration. Fetched keys are cached and refetched when they
+expire or an unknown kid appears after a key rotation. Issuer, audience and time claims
+are checked, and the authenticated Principal is stored in the request context.
+(令牌可使用 HMAC 共享密钥、JWKS 端点或从签发者 OIDC 配置中发现的密钥进行校验。
+获取的密钥会被缓存，过期或密钥轮换后出现未知 kid 时重新获取。
+中间件会校验签发者、受众和时间声明，并将认证后的调用方写入请求 context。)
+
+Failures are answered with the standard error envelope: 401 for missing or invalid
+tokens and 403 for insufficient scopes.
+(失败时以标准错误信封响应：令牌缺失或无效返回 401，权限范围不足返回 403。)
+
+Configuration, typically the "auth" section loaded via pkg/config:
+(配置，通常是通过 pkg/config 加载的 "auth" 节：)
+
+	auth:
+	  issuer: https://accounts.example.com
+	  audiences: [my-api]
+	  skip-paths: [/healthz]
+
+Usage:
+(用法：)
+
+	a, err := auth.New(cfg.Auth)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	mux.Handle("/orders", a.Middleware(auth.RequireScopes("orders:read")(ordersHandler)))
+
+	// In a handler (在处理器中)
+	p, _ := auth.PrincipalFromContext(r.Context())
+
+Authenticator also implements server.Middleware for the framework-agnostic server.
+(Authenticator 同时实现了 server.Middleware，可用于框架无关的服务器。)
+*/
+package auth
@@ -0,0 +1,309 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// jwk 是 JSON Web Key 中本包关心的字段。
+// (jwk holds the JSON Web Key fields this package cares about.)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// keySet 是带缓存的远程 JWKS，到期或遇到未知 kid 时重新获取。
+// 查找只持有读锁，获取在锁外进行且同一时间只有一个，因此缓慢的获取不会阻塞使用已缓存密钥的校验。
+// (keySet is a cached remote JWKS that is refetched when it expires or an unknown kid is seen. Lookups only hold the read
+// lock and fetches run outside the lock, one at a time, so a slow fetch does not block validations using cached keys.)
+type keySet struct {
+	client          *http.Client
+	ttl             time.Duration
+	refreshInterval time.Duration
+	issuer          string // 用于 OIDC 发现 (Used for OIDC discovery)
+
+	mu          sync.RWMutex
+	jwksURL     string
+	keys        map[string]any
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	inflight    *keyFetch // 正在进行的获取，没有时为 nil (The fetch in progress, nil when there is none)
+}
+
+// keyFetch 是一次进行中的 JWKS 获取，done 关闭后 err 为其结果。
+// (keyFetch is a JWKS fetch in progress; err holds its result once done is closed.)
+type keyFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// key 返回 kid 对应的公钥；kid 为空且只有一个密钥时返回该密钥。
+// (key returns the public key for kid; with an empty kid the only key is returned when there is exactly one.)
+func (ks *keySet) key(ctx context.Context, kid string) (any, error) {
+	ks.mu.RLock()
+	now := time.Now()
+	loaded := ks.keys != nil
+	expired := now.Sub(ks.fetchedAt) > ks.ttl && now.Sub(ks.attemptedAt) >= ks.refreshInterval
+	k, ok := ks.lookupLocked(kid)
+	fetching := ks.inflight != nil
+	ks.mu.RUnlock()
+
+	if !loaded || expired {
+		if ok && fetching {
+			// 刷新进行中时使用已缓存的密钥，而不是等待 (Use the cached key while a refresh is in progress instead of waiting)
+			return k, nil
+		}
+		if err := ks.refresh(ctx); err != nil && !loaded {
+			return nil, err
+		}
+		ks.mu.RLock()
+		k, ok = ks.lookupLocked(kid)
+		ks.mu.RUnlock()
+	}
+	if ok {
+		return k, nil
+	}
+
+	// 未知 kid 可能意味着密钥已轮换，进行中的获取可能已包含它 (An unknown kid may mean the keys were rotated; a fetch in progress may include it)
+	ks.mu.RLock()
+	retry := time.Since(ks.attemptedAt) >= ks.refreshInterval || ks.inflight != nil
+	ks.mu.RUnlock()
+	if retry {
+		if err := ks.refresh(ctx); err != nil {
+			return nil, err
+		}
+		ks.mu.RLock()
+		k, ok = ks.lookupLocked(kid)
+		ks.mu.RUnlock()
+		if ok {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("no signing key found for kid '%s'", kid)
+}
+
+// lookupLocked 在缓存中查找密钥，调用方需持有锁。
+// (lookupLocked looks a key up in the cache; the caller must hold the lock.)
+func (ks *keySet) lookupLocked(kid string) (any, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, k := range ks.keys {
+			return k, true
+		}
+	}
+	k, ok := ks.keys[kid]
+	return k, ok
+}
+
+// refresh 重新获取 JWKS，失败时保留旧的密钥。已有获取在进行时等待其结果而不是再次获取。
+// (refresh refetches the JWKS, keeping the previous keys on failure. When a fetch is already in progress it waits for its
+// result instead of fetching again.)
+func (ks *keySet) refresh(ctx context.Context) error {
+	ks.mu.Lock()
+	if f := ks.inflight; f != nil {
+		ks.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return lmccerrors.WithCode(lmccerrors.Wrap(ctx.Err(), "failed to wait for the JWKS fetch"), lmccerrors.ErrAuthKeyFetch)
+		}
+	}
+	f := &keyFetch{done: make(chan struct{})}
+	ks.inflight = f
+	ks.attemptedAt = time.Now()
+	jwksURL := ks.jwksURL
+	ks.mu.Unlock()
+
+	keys, jwksURL, err := ks.fetch(ctx, jwksURL)
+
+	ks.mu.Lock()
+	ks.jwksURL = jwksURL
+	if err == nil {
+		ks.keys = keys
+		ks.fetchedAt = time.Now()
+	}
+	ks.inflight, f.err = nil, err
+	ks.mu.Unlock()
+	close(f.done)
+	return err
+}
+
+// fetch 获取 JWKS 并返回其中可用的签名密钥；jwksURL 为空时先通过 OIDC 发现获得，并返回使用的地址。
+// (fetch fetches the JWKS and returns its usable signing keys; an empty jwksURL is first found through OIDC discovery,
+// and the URL used is returned.)
+func (ks *keySet) fetch(ctx context.Context, jwksURL string) (map[string]any, string, error) {
+	if jwksURL == "" {
+		discovered, err := discoverJWKSURL(ctx, ks.client, ks.issuer)
+		if err != nil {
+			return nil, "", err
+		}
+		jwksURL = discovered
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := getJSON(ctx, ks.client, jwksURL, &set); err != nil {
+		return nil, jwksURL, lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to fetch JWKS from '%s'", jwksURL), lmccerrors.ErrAuthKeyFetch)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			// 跳过无法识别的密钥而不是整体失败 (Skip unrecognised keys instead of failing the whole set)
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	if len(keys) == 0 {
+		return nil, jwksURL, lmccerrors.ErrorfWithCode(lmccerrors.ErrAuthKeyFetch, "JWKS at '%s' contains no usable signing keys", jwksURL)
+	}
+	return keys, jwksURL, nil
+}
+
+// publicKey 将 JWK 转换为 Go 的密钥类型。
+// (publicKey converts the JWK into a Go key type.)
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve '%s'", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve '%s'", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, err
+		}
+		return secret, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", k.Kty)
+	}
+}
+
+// decodeBigInt 解码 base64url 编码的大整数。
+// (decodeBigInt decodes a base64url-encoded big integer.)
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// discoverJWKSURL 通过 OIDC 发现文档获取 jwks_uri。
+// (discoverJWKSURL fetches jwks_uri from the OIDC discovery document.)
+func discoverJWKSURL(ctx context.Context, client *http.Client, issuer string) (string, error) {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := getJSON(ctx, client, discoveryURL, &doc); err != nil {
+		return "", lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to fetch OIDC discovery document from '%s'", discoveryURL), lmccerrors.ErrAuthKeyFetch)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return "", lmccerrors.ErrorfWithCode(lmccerrors.ErrAuthKeyFetch, "OIDC discovery issuer '%s' does not match '%s'", doc.Issuer, issuer)
+	}
+	if doc.JWKSURI == "" {
+		return "", lmccerrors.ErrorfWithCode(lmccerrors.ErrAuthKeyFetch, "OIDC discovery document at '%s' has no jwks_uri", discoveryURL)
+	}
+	return doc.JWKSURI, nil
+}
+
+// getJSON 发送 GET 请求并解码 JSON 响应。
+// (getJSON sends a GET request and decodes the JSON response.)
+func getJSON(ctx context.Context, client *http.Client, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out)
+}
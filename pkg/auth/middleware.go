@@ -0,0 +1,165 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server/middleware"
+)
+
+// Middleware 返回 net/http 认证中间件，校验 Authorization 头中的 Bearer 令牌并将调用方写入请求 context。
+// (Middleware returns net/http authentication middleware that validates the Bearer token in the Authorization
+// header and stores the principal in the request context.)
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.skip(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		p, err := a.Authenticate(r.Context(), BearerToken(r))
+		if err != nil {
+			a.logFailure(r, err)
+			writeError(w, r, err, "")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithPrincipal(r.Context(), p)))
+	})
+}
+
+// Process 实现 server.Middleware，调用方同时保存在 server.Context 的 PrincipalContextKey 下。
+// (Process implements server.Middleware; the principal is stored under PrincipalContextKey of the server.Context.)
+func (a *Authenticator) Process(ctx server.Context, next func() error) error {
+	r := ctx.Request()
+	if a.skip(r.URL.Path) {
+		return next()
+	}
+	p, err := a.Authenticate(r.Context(), BearerToken(r))
+	if err != nil {
+		a.logFailure(r, err)
+		writeError(ctx.Response(), r, err, "")
+		return nil
+	}
+	ctx.Set(PrincipalContextKey, p)
+	return next()
+}
+
+// RequireScopes 返回 net/http 中间件，要求调用方拥有全部指定权限范围，否则返回 403。
+// 必须放在 Authenticator.Middleware 之后。
+// (RequireScopes returns net/http middleware requiring the principal to hold all given scopes, responding 403 otherwise.
+// It must run after Authenticator.Middleware.)
+func RequireScopes(scopes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, _ := PrincipalFromContext(r.Context())
+			if err := checkScopes(p, scopes); err != nil {
+				writeError(w, r, err, strings.Join(scopes, " "))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScopesMiddleware 是 RequireScopes 的 server.Middleware 版本。
+// (RequireScopesMiddleware is the server.Middleware version of RequireScopes.)
+func RequireScopesMiddleware(scopes ...string) server.Middleware {
+	return server.MiddlewareFunc(func(ctx server.Context, next func() error) error {
+		p, _ := PrincipalFromServerContext(ctx)
+		if err := checkScopes(p, scopes); err != nil {
+			writeError(ctx.Response(), ctx.Request(), err, strings.Join(scopes, " "))
+			return nil
+		}
+		return next()
+	})
+}
+
+// PrincipalFromServerContext 从 server.Context 中读取调用方，依次检查存储值和请求 context。
+// (PrincipalFromServerContext reads the principal from a server.Context, checking the stored value and then the request context.)
+func PrincipalFromServerContext(ctx server.Context) (*Principal, bool) {
+	if v, ok := ctx.Get(PrincipalContextKey); ok {
+		if p, ok := v.(*Principal); ok && p != nil {
+			return p, true
+		}
+	}
+	return PrincipalFromContext(ctx.Request().Context())
+}
+
+// BearerToken 从 Authorization 头中提取 Bearer 令牌，不存在时返回空字符串。
+// (BearerToken extracts the Bearer token from the Authorization header, returning an empty string when absent.)
+func BearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	const prefix = "bearer "
+	if len(h) > len(prefix) && strings.EqualFold(h[:len(prefix)], prefix) {
+		return strings.TrimSpace(h[len(prefix):])
+	}
+	return ""
+}
+
+// checkScopes 校验调用方的权限范围。(checkScopes validates the scopes of the principal.)
+func checkScopes(p *Principal, scopes []string) error {
+	if p == nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrAuthTokenInvalid, "request is not authenticated")
+	}
+	if !p.HasScopes(scopes...) {
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrForbidden, "insufficient scope, requires '%s'", strings.Join(scopes, " "))
+	}
+	return nil
+}
+
+// logFailure 以 Debug 级别记录认证失败，签名密钥获取失败则记录为 Error。
+// (logFailure logs authentication failures at Debug level, and signing key fetch failures at Error level.)
+func (a *Authenticator) logFailure(r *http.Request, err error) {
+	if lmccerrors.IsCode(err, lmccerrors.ErrAuthKeyFetch) {
+		log.Errorw("Failed to fetch auth signing keys", "path", r.URL.Path, "error", err)
+		return
+	}
+	log.Debugw("Authentication failed", "path", r.URL.Path, "error", err)
+}
+
+// writeError 以标准错误信封写入 401/403/500 响应，并按 RFC 6750 设置 WWW-Authenticate 头。
+// (writeError writes a 401/403/500 response using the standard error envelope and sets WWW-Authenticate per RFC 6750.)
+func writeError(w http.ResponseWriter, r *http.Request, err error, scope string) {
+	coder := lmccerrors.GetCoder(err)
+	if coder == nil {
+		coder = lmccerrors.ErrInternalServer
+	}
+	status := coder.HTTPStatus()
+
+	message := err.Error()
+	switch status {
+	case http.StatusUnauthorized:
+		if BearerToken(r) == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+		} else {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+	case http.StatusForbidden:
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, scope))
+	default:
+		// 不向客户端暴露内部错误细节 (Do not expose internal error details to clients)
+		message = coder.String()
+	}
+
+	resp := middleware.ErrorResponse{
+		Error:     http.StatusText(status),
+		Message:   message,
+		Code:      coder.Code(),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		RequestID: r.Header.Get("X-Request-ID"),
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,139 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Options 定义了 JWT/OIDC 认证的配置选项。
+// (Options defines configuration options for JWT/OIDC authentication.)
+// 通常作为应用配置中的 "auth" 节加载。
+// (It is typically loaded as the "auth" section of the application configuration.)
+//
+// 签名密钥的来源按以下顺序确定：Secret（HMAC）、JWKSURL、基于 Issuer 的 OIDC 发现。
+// (Signing keys come from, in order: Secret (HMAC), JWKSURL, or OIDC discovery based on Issuer.)
+type Options struct {
+	// Enabled 控制是否启用认证，关闭时中间件直接放行请求。
+	// (Enabled controls whether authentication is enforced; when disabled the middleware passes requests through.)
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Issuer 是期望的令牌签发者（iss），为空时不校验。
+	// (Issuer is the expected token issuer (iss); it is not checked when empty.)
+	Issuer string `json:"issuer" mapstructure:"issuer"`
+
+	// Audiences 是可接受的受众（aud），令牌只需包含其中之一，为空时不校验。
+	// (Audiences are the accepted audiences (aud); a token needs to contain one of them. Not checked when empty.)
+	Audiences []string `json:"audiences" mapstructure:"audiences"`
+
+	// JWKSURL 是 JSON Web Key Set 的地址，为空且未设置 Secret 时通过 Issuer 的 OIDC 发现文档获取。
+	// (JWKSURL is the JSON Web Key Set URL; when empty and Secret is unset it is discovered from the Issuer's OIDC configuration.)
+	JWKSURL string `json:"jwks-url" mapstructure:"jwks-url"`
+
+	// Secret 是 HMAC 签名使用的共享密钥，设置后不再使用 JWKS。
+	// (Secret is the shared key for HMAC signatures; JWKS is not used when it is set.)
+	Secret string `json:"secret" mapstructure:"secret"`
+
+	// Algorithms 是允许的签名算法，为空时根据密钥来源选择（HMAC 或非对称算法）。
+	// (Algorithms are the allowed signing algorithms; when empty they are chosen from the key source, HMAC or asymmetric.)
+	Algorithms []string `json:"algorithms" mapstructure:"algorithms"`
+
+	// JWKSCacheTTL 是 JWKS 缓存的有效期。
+	// (JWKSCacheTTL is how long a fetched JWKS is cached.)
+	JWKSCacheTTL time.Duration `json:"jwks-cache-ttl" mapstructure:"jwks-cache-ttl"`
+
+	// JWKSRefreshInterval 是遇到未知 kid 时两次重新获取 JWKS 的最小间隔，用于应对密钥轮换。
+	// (JWKSRefreshInterval is the minimum interval between refetches triggered by an unknown kid, to follow key rotation.)
+	JWKSRefreshInterval time.Duration `json:"jwks-refresh-interval" mapstructure:"jwks-refresh-interval"`
+
+	// Leeway 是校验 exp/nbf/iat 时允许的时钟偏差。
+	// (Leeway is the clock skew tolerated when validating exp/nbf/iat.)
+	Leeway time.Duration `json:"leeway" mapstructure:"leeway"`
+
+	// ScopeClaim 是保存权限范围的声明名，支持空格分隔的字符串或字符串数组。
+	// (ScopeClaim is the claim holding scopes, either a space-separated string or a string array.)
+	ScopeClaim string `json:"scope-claim" mapstructure:"scope-claim"`
+
+	// SkipPaths 是无需认证的请求路径。
+	// (SkipPaths are request paths that do not require authentication.)
+	SkipPaths []string `json:"skip-paths" mapstructure:"skip-paths"`
+}
+
+// NewOptions 创建具有默认值的认证选项 (creates authentication options with default values)
+func NewOptions() *Options {
+	return &Options{
+		Enabled:             true,
+		JWKSCacheTTL:        15 * time.Minute,
+		JWKSRefreshInterval: time.Minute, // 防止伪造 kid 导致频繁拉取 (Prevents forged kids from hammering the JWKS endpoint)
+		Leeway:              30 * time.Second,
+		ScopeClaim:          "scope",
+		SkipPaths:           []string{},
+	}
+}
+
+// Validate 验证认证选项是否有效。
+// (Validate validates if the authentication options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if !o.Enabled {
+		return errs
+	}
+
+	if o.Secret == "" && o.JWKSURL == "" && o.Issuer == "" {
+		errs = append(errs, fmt.Errorf("one of auth secret, jwks-url or issuer must be set"))
+	}
+
+	if o.JWKSURL != "" {
+		if u, err := url.Parse(o.JWKSURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid auth jwks-url '%s'", o.JWKSURL))
+		}
+	}
+
+	for _, alg := range o.Algorithms {
+		if !isKnownAlgorithm(alg) {
+			errs = append(errs, fmt.Errorf("unsupported auth algorithm '%s'", alg))
+		}
+	}
+
+	if o.JWKSCacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("invalid auth jwks cache ttl '%s', must not be negative", o.JWKSCacheTTL))
+	}
+
+	if o.JWKSRefreshInterval < 0 {
+		errs = append(errs, fmt.Errorf("invalid auth jwks refresh interval '%s', must not be negative", o.JWKSRefreshInterval))
+	}
+
+	if o.Leeway < 0 {
+		errs = append(errs, fmt.Errorf("invalid auth leeway '%s', must not be negative", o.Leeway))
+	}
+
+	return errs
+}
+
+// hmacAlgorithms 是使用共享密钥时允许的算法。(hmacAlgorithms are the algorithms allowed with a shared secret.)
+var hmacAlgorithms = []string{"HS256", "HS384", "HS512"}
+
+// asymmetricAlgorithms 是使用 JWKS 时默认允许的算法。(asymmetricAlgorithms are the algorithms allowed with JWKS by default.)
+var asymmetricAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// isKnownAlgorithm 判断是否为支持的签名算法。(isKnownAlgorithm reports whether alg is a supported signing algorithm.)
+func isKnownAlgorithm(alg string) bool {
+	for _, known := range hmacAlgorithms {
+		if alg == known {
+			return true
+		}
+	}
+	for _, known := range asymmetricAlgorithms {
+		if alg == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// Principal 是通过认证的调用方。
+// (Principal is an authenticated caller.)
+type Principal struct {
+	// Subject 是令牌的 sub 声明。(Subject is the token's sub claim.)
+	Subject string `json:"subject"`
+	// Issuer 是令牌的 iss 声明。(Issuer is the token's iss claim.)
+	Issuer string `json:"issuer,omitempty"`
+	// Audience 是令牌的 aud 声明。(Audience is the token's aud claim.)
+	Audience []string `json:"audience,omitempty"`
+	// Scopes 是从 ScopeClaim 解析出的权限范围。(Scopes are the scopes parsed from ScopeClaim.)
+	Scopes []string `json:"scopes,omitempty"`
+	// Claims 是令牌的全部声明。(Claims are all claims of the token.)
+	Claims map[string]any `json:"claims,omitempty"`
+	// Token 是原始令牌。(Token is the raw token.)
+	Token string `json:"-"`
+}
+
+// HasScopes 判断调用方是否拥有全部指定的权限范围。
+// (HasScopes reports whether the principal has all of the given scopes.)
+func (p *Principal) HasScopes(scopes ...string) bool {
+	for _, want := range scopes {
+		found := false
+		for _, have := range p.Scopes {
+			if have == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Claim 返回指定声明的字符串值，不存在或不是字符串时返回空字符串。
+// (Claim returns the string value of a claim, or an empty string when it is missing or not a string.)
+func (p *Principal) Claim(name string) string {
+	s, _ := p.Claims[name].(string)
+	return s
+}
+
+// parseScopes 解析空格分隔的字符串或字符串数组形式的权限范围。
+// (parseScopes parses scopes given as a space-separated string or a string array.)
+func parseScopes(v any) []string {
+	switch val := v.(type) {
+	case string:
+		return strings.Fields(val)
+	case []string:
+		return val
+	case []any:
+		scopes := make([]string, 0, len(val))
+		for _, s := range val {
+			if str, ok := s.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+		return scopes
+	default:
+		return nil
+	}
+}
+
+// contextKey 是本包在 context 中使用的私有键类型。
+// (contextKey is the private key type used by this package in contexts.)
+type contextKey int
+
+// principalKey 是调用方在 context 中的键。(principalKey is the context key for the principal.)
+const principalKey contextKey = iota
+
+// PrincipalContextKey 是 server.Context 中保存调用方的键。
+// (PrincipalContextKey is the key the principal is stored under in a server.Context.)
+const PrincipalContextKey = "auth.principal"
+
+// ContextWithPrincipal 返回携带调用方的新 context。
+// (ContextWithPrincipal returns a new context carrying the principal.)
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// PrincipalFromContext 从 context 中读取调用方。
+// (PrincipalFromContext reads the principal from the context.)
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	p, ok := ctx.Value(principalKey).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,568 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package cli
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/spf13/pflag"
+)
+
+// Command 是应用的一个命令，或包含子命令的命令组。Run 为空的命令只显示帮助。
+// 命令自己的标志在 Flags 中定义，通常绑定到闭包中的变量；应用的全局标志对所有命令可用。
+// (Command is a command of an application, or a group of subcommands. A command without Run only shows its help.
+// The command's own flags are defined in Flags, usually bound to variables in a closure; the application's global flags are
+// available to every command.)
+type Command struct {
+	// Name 是命令名。(Name is the command name.)
+	Name string
+	// Aliases 是命令的其他名称。(Aliases are other names of the command.)
+	Aliases []string
+	// Short 是命令列表中显示的一行说明。(Short is the one-line description shown in command lists.)
+	Short string
+	// Long 是帮助中显示的完整说明，为空时使用 Short。(Long is the full description shown in the help; Short is used when empty.)
+	Long string
+	// ArgsUsage 是帮助中位置参数的写法，例如 "<username> <email>"。
+	// (ArgsUsage describes the positional arguments in the help, e.g. "<username> <email>".)
+	ArgsUsage string
+	// Args 校验位置参数，为空时不校验。(Args validates the positional arguments; nothing is checked when nil.)
+	Args ArgsValidator
+	// Flags 在命令的 FlagSet 上定义其标志。(Flags defines the command's flags on its FlagSet.)
+	Flags func(fs *pflag.FlagSet)
+	// Run 执行命令。(Run executes the command.)
+	Run func(ctx *Context) error
+
+	parent   *Command
+	commands []*Command
+	builtin  bool // 内置命令不加载配置 (Built-in commands do not load the configuration)
+}
+
+// AddCommand 添加子命令。(AddCommand adds subcommands.)
+func (c *Command) AddCommand(cmds ...*Command) {
+	for _, sub := range cmds {
+		sub.parent = c
+		c.commands = append(c.commands, sub)
+	}
+}
+
+// Commands 返回子命令。(Commands returns the subcommands.)
+func (c *Command) Commands() []*Command {
+	return slices.Clone(c.commands)
+}
+
+// Path 返回从应用名开始的完整命令路径，例如 "user-cli user create"。
+// (Path returns the full command path starting with the application name, e.g. "user-cli user create".)
+func (c *Command) Path() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.Path() + " " + c.Name
+}
+
+// find 按名称或别名查找子命令。(find looks up a subcommand by name or alias.)
+func (c *Command) find(name string) *Command {
+	for _, sub := range c.commands {
+		if sub.Name == name || slices.Contains(sub.Aliases, name) {
+			return sub
+		}
+	}
+	return nil
+}
+
+// ArgsValidator 校验命令的位置参数。(ArgsValidator validates the positional arguments of a command.)
+type ArgsValidator func(args []string) error
+
+// NoArgs 不接受位置参数。(NoArgs accepts no positional arguments.)
+func NoArgs(args []string) error {
+	if len(args) > 0 {
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIUsage, "unexpected argument '%s'", args[0])
+	}
+	return nil
+}
+
+// ExactArgs 要求恰好 n 个位置参数。(ExactArgs requires exactly n positional arguments.)
+func ExactArgs(n int) ArgsValidator {
+	return RangeArgs(n, n)
+}
+
+// MinArgs 要求至少 n 个位置参数。(MinArgs requires at least n positional arguments.)
+func MinArgs(n int) ArgsValidator {
+	return RangeArgs(n, -1)
+}
+
+// RangeArgs 要求 min 到 max 个位置参数，max 为负数时不限上限。
+// (RangeArgs requires between min and max positional arguments; a negative max means no upper bound.)
+func RangeArgs(min, max int) ArgsValidator {
+	return func(args []string) error {
+		switch {
+		case min == max && len(args) != min:
+			return lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIUsage, "accepts %d arg(s), received %d", min, len(args))
+		case len(args) < min:
+			return lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIUsage, "requires at least %d arg(s), received %d", min, len(args))
+		case max >= 0 && len(args) > max:
+			return lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIUsage, "accepts at most %d arg(s), received %d", max, len(args))
+		}
+		return nil
+	}
+}
+
+// App 是由命令组成的命令行应用。Run 解析命令行、分派到命令，并在执行命令前加载配置（WithConfig）和配置全局日志。
+// 每个命令都有全局标志 --output/-o、--log-level 和 -h/--help，设置了 WithConfig 时还有 --config/-c 以及配置结构体中
+// 带 `flag` 标签的字段；命令自己的同名标志优先。应用自动提供 help 命令，设置了 WithVersion 时还有 version 命令。
+// (App is a command-line application made of commands. Run parses the command line, dispatches to a command and, before
+// executing it, loads the configuration (WithConfig) and configures the global logger. Every command has the global flags
+// --output/-o, --log-level and -h/--help, plus --config/-c and the fields tagged `flag` in the configuration struct when
+// WithConfig is set; a command's own flag of the same name takes precedence. The application provides a help command, and a
+// version command when WithVersion is set.)
+type App struct {
+	root    *Command
+	version string
+	output  OutputFormat
+	logOpts *log.Options
+	stdout  io.Writer
+	stderr  io.Writer
+	config  *appConfig
+}
+
+// appConfig 是 WithConfig 设置的配置加载方式。(appConfig is how the configuration set by WithConfig is loaded.)
+type appConfig struct {
+	register func(fs *pflag.FlagSet) error
+	load     func(fs *pflag.FlagSet, file string) error
+}
+
+// AppOption 配置 App。(AppOption configures an App.)
+type AppOption func(*App)
+
+// WithVersion 设置应用版本，并启用 version 命令。(WithVersion sets the application version and enables the version command.)
+func WithVersion(version string) AppOption {
+	return func(a *App) {
+		a.version = version
+	}
+}
+
+// WithConfig 设置命令执行前加载的配置：cfg 按 opts 加载，--config 指定的文件取代 opts 中的 WithConfigFile，
+// cfg 中带 `flag` 标签的字段注册为全局标志并绑定到配置（见 config.RegisterFlags）。
+// (WithConfig sets the configuration loaded before a command runs: cfg is loaded with opts, the file given with --config
+// replaces a WithConfigFile in opts, and the fields of cfg tagged `flag` are registered as global flags bound to the
+// configuration (see config.RegisterFlags).)
+func WithConfig[T any](cfg *T, opts ...config.Option) AppOption {
+	return func(a *App) {
+		a.config = &appConfig{
+			register: func(fs *pflag.FlagSet) error {
+				return config.RegisterFlags(fs, cfg)
+			},
+			load: func(fs *pflag.FlagSet, file string) error {
+				loadOpts := append(opts[:len(opts):len(opts)], config.WithFlagSet(fs))
+				if file != "" {
+					loadOpts = append(loadOpts, config.WithConfigFile(file, ""))
+				}
+				return config.LoadConfig(cfg, loadOpts...)
+			},
+		}
+	}
+}
+
+// WithLogOptions 设置全局日志的选项。opts 在配置加载之后才读取，因此可以指向配置结构体中的字段；--log-level 覆盖其中的级别。
+// 默认以 text 格式向 stderr 写出 warn 及以上级别的日志，不影响标准输出上的命令结果。
+// (WithLogOptions sets the options of the global logger. opts is read after the configuration is loaded, so it can point to a
+// field of the configuration struct; --log-level overrides its level. By default entries at warn and above are written to
+// stderr in the text format, keeping command results on stdout clean.)
+func WithLogOptions(opts *log.Options) AppOption {
+	return func(a *App) {
+		a.logOpts = opts
+	}
+}
+
+// WithDefaultOutput 设置未指定 --output 时的输出格式，默认为 OutputTable。
+// (WithDefaultOutput sets the output format used when --output is absent; defaults to OutputTable.)
+func WithDefaultOutput(format OutputFormat) AppOption {
+	return func(a *App) {
+		a.output = format
+	}
+}
+
+// WithWriters 设置命令结果和帮助的输出，以及错误信息的输出，默认为 os.Stdout 和 os.Stderr。
+// (WithWriters sets where command results and help go and where error messages go; defaults to os.Stdout and os.Stderr.)
+func WithWriters(stdout, stderr io.Writer) AppOption {
+	return func(a *App) {
+		a.stdout = stdout
+		a.stderr = stderr
+	}
+}
+
+// NewApp 创建名为 name 的应用，short 是帮助中显示的说明。
+// (NewApp creates an application named name; short is the description shown in the help.)
+func NewApp(name, short string, options ...AppOption) *App {
+	a := &App{
+		root:   &Command{Name: name, Short: short},
+		output: OutputTable,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	for _, opt := range options {
+		opt(a)
+	}
+	return a
+}
+
+// Root 返回根命令，可以设置其 Run、Flags 或 Long。(Root returns the root command, whose Run, Flags or Long can be set.)
+func (a *App) Root() *Command {
+	return a.root
+}
+
+// AddCommand 向根命令添加命令。(AddCommand adds commands to the root command.)
+func (a *App) AddCommand(cmds ...*Command) {
+	a.root.AddCommand(cmds...)
+}
+
+// Main 以 os.Args 运行应用，收到 SIGINT 或 SIGTERM 时取消 context。出错时把错误写到 stderr 并退出：
+// 用法错误的退出码为 2，其他错误为 1。
+// (Main runs the application with os.Args, cancelling the context on SIGINT or SIGTERM. On error it writes the error to stderr
+// and exits with code 2 for usage errors and 1 otherwise.)
+func (a *App) Main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := a.Run(ctx, os.Args[1:])
+	stop()
+	_ = log.Sync()
+	if err != nil {
+		os.Exit(a.reportError(err))
+	}
+}
+
+// reportError 写出错误并返回退出码。(reportError writes the error and returns the exit code.)
+func (a *App) reportError(err error) int {
+	fmt.Fprintf(a.stderr, "Error: %v\n", err)
+	if lmccerrors.IsCode(err, lmccerrors.ErrCLIUsage) {
+		fmt.Fprintf(a.stderr, "Run '%s --help' for usage.\n", a.root.Name)
+		return 2
+	}
+	return 1
+}
+
+// globalValues 是全局标志的值。(globalValues holds the values of the global flags.)
+type globalValues struct {
+	configFile string
+	logLevel   string
+	output     OutputFormat
+	help       bool
+}
+
+// globalFlags 定义全局标志。(globalFlags defines the global flags.)
+func (a *App) globalFlags() (*pflag.FlagSet, *globalValues, error) {
+	values := &globalValues{output: a.output}
+	fs := pflag.NewFlagSet(a.root.Name, pflag.ContinueOnError)
+	if a.config != nil {
+		fs.StringVarP(&values.configFile, "config", "c", "", "config file (yaml, json, toml, ini or .env)")
+	}
+	fs.StringVar(&values.logLevel, "log-level", "", "log level: debug, info, warn or error")
+	fs.VarP(&values.output, "output", "o", OutputFlagUsage)
+	fs.BoolVarP(&values.help, "help", "h", false, "show help")
+	if a.config != nil {
+		if err := a.config.register(fs); err != nil {
+			return nil, nil, err
+		}
+	}
+	return fs, values, nil
+}
+
+// mergeFlags 合并命令的标志和全局标志，被命令标志遮盖（名称或简写相同）的全局标志被跳过，同时返回实际生效的全局标志。
+// (mergeFlags merges the command's flags with the global flags, skipping global flags shadowed by a command flag of the same
+// name or shorthand, and also returns the global flags in effect.)
+func mergeFlags(name string, local, global *pflag.FlagSet) (*pflag.FlagSet, *pflag.FlagSet) {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	fs.AddFlagSet(local)
+	effective := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	global.VisitAll(func(f *pflag.Flag) {
+		if fs.Lookup(f.Name) != nil || (f.Shorthand != "" && fs.ShorthandLookup(f.Shorthand) != nil) {
+			return
+		}
+		fs.AddFlag(f)
+		effective.AddFlag(f)
+	})
+	return fs, effective
+}
+
+// resolve 沿命令行中的命令名找到要执行的命令，返回去掉命令名的参数。第一个不是子命令的位置参数之后不再查找子命令。
+// (resolve follows the command names on the command line to the command to execute and returns the arguments without the
+// command names. Subcommands are no longer looked up after the first positional argument that is not one.)
+func (a *App) resolve(args []string, global *pflag.FlagSet) (*Command, []string) {
+	cmd := a.root
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return cmd, append(rest, args[i:]...)
+		}
+		if strings.HasPrefix(arg, "-") && arg != "-" {
+			rest = append(rest, arg)
+			// 全局标志的值不是命令名 (The value of a global flag is not a command name)
+			if takesValue(global, arg) && i+1 < len(args) {
+				i++
+				rest = append(rest, args[i])
+			}
+			continue
+		}
+		sub := cmd.find(arg)
+		if sub == nil {
+			return cmd, append(rest, args[i:]...)
+		}
+		cmd = sub
+	}
+	return cmd, rest
+}
+
+// takesValue 判断 arg 是否为需要以下一个参数为值的全局标志。
+// (takesValue reports whether arg is a global flag taking the next argument as its value.)
+func takesValue(global *pflag.FlagSet, arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+	var f *pflag.Flag
+	if name, ok := strings.CutPrefix(arg, "--"); ok {
+		f = global.Lookup(name)
+	} else if name := arg[1:]; len(name) == 1 {
+		f = global.ShorthandLookup(name)
+	}
+	return f != nil && f.NoOptDefVal == ""
+}
+
+// addBuiltins 添加 help 和 version 命令，已有同名命令时跳过。
+// (addBuiltins adds the help and version commands, skipping those whose name is already taken.)
+func (a *App) addBuiltins() {
+	if a.root.find("help") == nil {
+		a.root.AddCommand(&Command{
+			Name:      "help",
+			Short:     "Show help for a command",
+			ArgsUsage: "[command]...",
+			builtin:   true,
+			Run: func(ctx *Context) error {
+				cmd := a.root
+				for _, name := range ctx.Args {
+					if cmd = cmd.find(name); cmd == nil {
+						return lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIUsage, "unknown command '%s'", strings.Join(ctx.Args, " "))
+					}
+				}
+				return a.writeHelp(cmd)
+			},
+		})
+	}
+	if a.version != "" && a.root.find("version") == nil {
+		a.root.AddCommand(&Command{
+			Name:    "version",
+			Short:   "Show version information",
+			Args:    NoArgs,
+			builtin: true,
+			Run: func(ctx *Context) error {
+				if ctx.Output() == OutputJSON || ctx.Output() == OutputYAML {
+					return ctx.Render(map[string]string{"name": a.root.Name, "version": a.version, "go": runtime.Version()})
+				}
+				_, err := fmt.Fprintf(ctx.Stdout(), "%s version %s (%s)\n", a.root.Name, a.version, runtime.Version())
+				return err
+			},
+		})
+	}
+}
+
+// Run 用 args（不含程序名）运行应用。用法错误带 ErrCLIUsage 错误码。
+// (Run runs the application with args, excluding the program name. Usage errors carry the ErrCLIUsage code.)
+func (a *App) Run(ctx context.Context, args []string) error {
+	a.addBuiltins()
+	global, values, err := a.globalFlags()
+	if err != nil {
+		return err
+	}
+	cmd, rest := a.resolve(args, global)
+
+	local := pflag.NewFlagSet(cmd.Path(), pflag.ContinueOnError)
+	if cmd.Flags != nil {
+		cmd.Flags(local)
+	}
+	fs, _ := mergeFlags(cmd.Path(), local, global)
+	if err := fs.Parse(rest); err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "invalid flags for '%s'", cmd.Path()), lmccerrors.ErrCLIUsage)
+	}
+	if values.help {
+		return a.writeHelp(cmd)
+	}
+	positional := fs.Args()
+	if cmd.Run == nil {
+		if len(positional) > 0 {
+			return lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIUsage, "unknown command '%s' for '%s'", positional[0], cmd.Path())
+		}
+		return a.writeHelp(cmd)
+	}
+	if cmd.Args != nil {
+		if err := cmd.Args(positional); err != nil {
+			return lmccerrors.Wrapf(err, "invalid arguments for '%s'", cmd.Path())
+		}
+	}
+
+	logOpts, err := a.setup(cmd, fs, values)
+	if err != nil {
+		return err
+	}
+	return cmd.Run(&Context{
+		Context: ctx,
+		Command: cmd,
+		Args:    positional,
+		app:     a,
+		flags:   fs,
+		output:  values.output,
+		color:   ColorEnabled(logOpts),
+	})
+}
+
+// setup 加载配置并配置全局日志，内置命令跳过这两步。
+// (setup loads the configuration and configures the global logger; built-in commands skip both.)
+func (a *App) setup(cmd *Command, fs *pflag.FlagSet, values *globalValues) (*log.Options, error) {
+	if cmd.builtin {
+		return nil, nil
+	}
+	if a.config != nil {
+		if err := a.config.load(fs, values.configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var opts log.Options
+	if a.logOpts != nil {
+		opts = *a.logOpts
+	} else {
+		opts = *log.NewOptions()
+		opts.Level = "warn"
+		opts.Format = log.FormatText
+		opts.OutputPaths = []string{"stderr"}
+		opts.DisableCaller = true
+		opts.DisableStacktrace = true
+	}
+	if values.logLevel != "" {
+		opts.Level = values.logLevel
+	}
+	if err := log.ReconfigureGlobalLogger(&opts); err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+// writeHelp 写出命令的帮助。(writeHelp writes the help of a command.)
+func (a *App) writeHelp(cmd *Command) error {
+	global, _, err := a.globalFlags()
+	if err != nil {
+		return err
+	}
+	local := pflag.NewFlagSet(cmd.Path(), pflag.ContinueOnError)
+	if cmd.Flags != nil {
+		cmd.Flags(local)
+	}
+	_, global = mergeFlags(cmd.Path(), local, global)
+
+	var b strings.Builder
+	if desc := cmp.Or(cmd.Long, cmd.Short); desc != "" {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(desc))
+	}
+	b.WriteString("Usage:\n")
+	if cmd.Run != nil {
+		fmt.Fprintf(&b, "  %s [flags]", cmd.Path())
+		if cmd.ArgsUsage != "" {
+			fmt.Fprintf(&b, " %s", cmd.ArgsUsage)
+		}
+		b.WriteString("\n")
+	}
+	if len(cmd.commands) > 0 {
+		fmt.Fprintf(&b, "  %s [command]\n", cmd.Path())
+	}
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(&b, "\nAliases:\n  %s\n", strings.Join(append([]string{cmd.Name}, cmd.Aliases...), ", "))
+	}
+	if len(cmd.commands) > 0 {
+		subs := slices.SortedFunc(slices.Values(cmd.commands), func(x, y *Command) int { return strings.Compare(x.Name, y.Name) })
+		width := 0
+		for _, sub := range subs {
+			width = max(width, len(sub.Name))
+		}
+		b.WriteString("\nAvailable Commands:\n")
+		for _, sub := range subs {
+			fmt.Fprintf(&b, "  %-*s   %s\n", width, sub.Name, sub.Short)
+		}
+	}
+	if usages := local.FlagUsages(); usages != "" {
+		fmt.Fprintf(&b, "\nFlags:\n%s", usages)
+	}
+	if usages := global.FlagUsages(); usages != "" {
+		fmt.Fprintf(&b, "\nGlobal Flags:\n%s", usages)
+	}
+	if len(cmd.commands) > 0 {
+		fmt.Fprintf(&b, "\nUse \"%s [command] --help\" for more information about a command.\n", cmd.Path())
+	}
+	_, err = io.WriteString(a.stdout, b.String())
+	return err
+}
+
+// Context 是命令执行时的上下文，内嵌命令的 context.Context，Main 在收到中断信号时取消它。
+// (Context is the context of a running command. It embeds the command's context.Context, which Main cancels on an interrupt.)
+type Context struct {
+	context.Context
+	// Command 是正在执行的命令。(Command is the command being executed.)
+	Command *Command
+	// Args 是位置参数。(Args are the positional arguments.)
+	Args []string
+
+	app    *App
+	flags  *pflag.FlagSet
+	output OutputFormat
+	color  bool
+}
+
+// Flags 返回已解析的标志，包括全局标志。(Flags returns the parsed flags, including the global flags.)
+func (c *Context) Flags() *pflag.FlagSet {
+	return c.flags
+}
+
+// Output 返回 --output 选择的输出格式。(Output returns the output format selected with --output.)
+func (c *Context) Output() OutputFormat {
+	return c.output
+}
+
+// Stdout 返回命令结果的输出。(Stdout returns where command results go.)
+func (c *Context) Stdout() io.Writer {
+	return c.app.stdout
+}
+
+// Stderr 返回错误信息的输出。(Stderr returns where error messages go.)
+func (c *Context) Stderr() io.Writer {
+	return c.app.stderr
+}
+
+// Logger 返回带有命令路径的全局记录器。(Logger returns the global logger with the command path.)
+func (c *Context) Logger() log.Logger {
+	return log.Std().WithValues("command", c.Command.Path())
+}
+
+// Renderer 返回按 --output 写入 Stdout 的渲染器，颜色跟随日志配置。
+// (Renderer returns a renderer writing to Stdout in the --output format, with color following the log options.)
+func (c *Context) Renderer() *Renderer {
+	return NewRenderer(c.Stdout(), c.output, WithColor(c.color))
+}
+
+// Render 用 Renderer 渲染 v。(Render renders v with Renderer.)
+func (c *Context) Render(v any) error {
+	return c.Renderer().Render(v)
+}
@@ -0,0 +1,129 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the application scaffold.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/cli"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type appConfig struct {
+	Region string `mapstructure:"region" default:"eu-west-1" flag:"region" usage:"cloud region"`
+	Limit  int    `mapstructure:"limit" default:"10"`
+}
+
+// newTestApp creates an application with a "server list" command recording what it received.
+func newTestApp(t *testing.T, stdout *bytes.Buffer) (*cli.App, *appConfig, *[]string, *string) {
+	t.Helper()
+	original := log.GetGlobalLogger()
+	t.Cleanup(func() { log.SetGlobalLogger(original) })
+
+	cfg := &appConfig{}
+	var gotArgs []string
+	var status string
+	app := cli.NewApp("cloudctl", "Manage cloud servers",
+		cli.WithVersion("v1.2.3"), cli.WithConfig(cfg), cli.WithWriters(stdout, &bytes.Buffer{}))
+	group := &cli.Command{Name: "server", Aliases: []string{"srv"}, Short: "Manage servers"}
+	group.AddCommand(&cli.Command{
+		Name:      "list",
+		Aliases:   []string{"ls"},
+		Short:     "List servers",
+		ArgsUsage: "[name]",
+		Args:      cli.RangeArgs(0, 1),
+		Flags: func(fs *pflag.FlagSet) {
+			fs.StringVar(&status, "status", "", "filter by status")
+		},
+		Run: func(ctx *cli.Context) error {
+			gotArgs = ctx.Args
+			return ctx.Render([]server{{Name: "api", Status: "running", Region: cfg.Region}})
+		},
+	})
+	app.AddCommand(group)
+	return app, cfg, &gotArgs, &status
+}
+
+// TestApp_Dispatch tests command and alias lookup, command and global flags, and config binding.
+// (TestApp_Dispatch 测试命令和别名查找、命令标志和全局标志，以及配置绑定。)
+func TestApp_Dispatch(t *testing.T) {
+	var out bytes.Buffer
+	app, cfg, gotArgs, status := newTestApp(t, &out)
+
+	require.NoError(t, app.Run(context.Background(), []string{"srv", "ls", "api", "--status", "running", "-o", "json", "--region=us-east-1"}))
+	assert.Equal(t, []string{"api"}, *gotArgs)
+	assert.Equal(t, "running", *status)
+	assert.Equal(t, "us-east-1", cfg.Region, "config fields tagged flag are global flags")
+	assert.Equal(t, 10, cfg.Limit)
+	assert.JSONEq(t, `[{"name":"api","status":"running","region":"us-east-1","token":""}]`, out.String())
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cloudctl.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("region: ap-south-1\nlimit: 50\n"), 0o600))
+	out.Reset()
+	require.NoError(t, app.Run(context.Background(), []string{"--config", file, "server", "list", "--log-level", "debug"}))
+	assert.Equal(t, "ap-south-1", cfg.Region)
+	assert.Equal(t, 50, cfg.Limit)
+	assert.Equal(t, "NAME   STATUS\napi    running\n", out.String())
+}
+
+// TestApp_UsageErrors tests that unknown commands, bad flags and bad arguments are ErrCLIUsage errors.
+// (TestApp_UsageErrors 测试未知命令、错误的标志和参数返回 ErrCLIUsage 错误。)
+func TestApp_UsageErrors(t *testing.T) {
+	var out bytes.Buffer
+	app, _, _, _ := newTestApp(t, &out)
+
+	for _, args := range [][]string{
+		{"deploy"},
+		{"server", "restart"},
+		{"server", "list", "--unknown"},
+		{"server", "list", "a", "b"},
+		{"server", "list", "-o", "xml"},
+	} {
+		err := app.Run(context.Background(), args)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrCLIUsage), "%v: %v", args, err)
+	}
+
+	err := app.Run(context.Background(), []string{"server", "list", "--config", "missing.yaml"})
+	require.Error(t, err)
+	assert.False(t, lmccerrors.IsCode(err, lmccerrors.ErrCLIUsage))
+}
+
+// TestApp_Builtins tests the help and version commands and the --help flag.
+// (TestApp_Builtins 测试 help 和 version 命令以及 --help 标志。)
+func TestApp_Builtins(t *testing.T) {
+	var out bytes.Buffer
+	app, _, _, _ := newTestApp(t, &out)
+
+	require.NoError(t, app.Run(context.Background(), nil))
+	help := out.String()
+	assert.Contains(t, help, "Manage cloud servers\n\nUsage:\n  cloudctl [command]\n")
+	assert.Contains(t, help, "Available Commands:\n  help      Show help for a command\n  server    Manage servers\n  version   Show version information\n")
+	assert.Contains(t, help, "--config string")
+
+	out.Reset()
+	require.NoError(t, app.Run(context.Background(), []string{"server", "ls", "-h"}))
+	flagHelp := out.String()
+	out.Reset()
+	require.NoError(t, app.Run(context.Background(), []string{"help", "server", "list"}))
+	assert.Equal(t, flagHelp, out.String(), "the help command and --help print the same help")
+	assert.Contains(t, out.String(), "Usage:\n  cloudctl server list [flags] [name]\n\nAliases:\n  list, ls\n\nFlags:\n      --status string")
+	assert.Contains(t, out.String(), "Global Flags:\n")
+
+	out.Reset()
+	require.NoError(t, app.Run(context.Background(), []string{"version", "-o", "json"}))
+	assert.Contains(t, out.String(), `"version": "v1.2.3"`)
+}
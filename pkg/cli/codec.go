@@ -0,0 +1,87 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package cli
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// Format 是导出和导入的文件格式。
+// (Format is a file format for export and import.)
+type Format string
+
+const (
+	// FormatCSV 是带表头行的 CSV。(FormatCSV is CSV with a header row.)
+	FormatCSV Format = "csv"
+	// FormatJSONL 是每行一个 JSON 对象的 JSON Lines。(FormatJSONL is JSON Lines, one JSON object per line.)
+	FormatJSONL Format = "jsonl"
+)
+
+// ParseFormat 解析格式名称，"ndjson" 视为 FormatJSONL。
+// (ParseFormat parses a format name; "ndjson" is treated as FormatJSONL.)
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "csv":
+		return FormatCSV, nil
+	case "jsonl", "ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIFormatUnsupported, "unsupported format '%s', must be csv or jsonl", name)
+	}
+}
+
+// FormatFromPath 根据文件扩展名推断格式。
+// (FormatFromPath infers the format from a file extension.)
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// Encoder 流式写出记录。Flush 必须在写完后调用。
+// (Encoder writes records as a stream. Flush must be called when done.)
+type Encoder[T any] interface {
+	// Encode 写出一条记录。(Encode writes one record.)
+	Encode(v T) error
+	// Flush 将缓冲的数据写入底层 Writer。(Flush writes buffered data to the underlying Writer.)
+	Flush() error
+}
+
+// Decoder 流式读取记录，读完时返回 io.EOF。单条记录无效时返回带 ErrCLIRecordInvalid 的错误，之后可以继续读取。
+// (Decoder reads records as a stream, returning io.EOF at the end. An invalid record yields an error coded ErrCLIRecordInvalid and reading may continue.)
+type Decoder[T any] interface {
+	// Decode 读取下一条记录。(Decode reads the next record.)
+	Decode() (T, error)
+	// Line 返回最近一条记录所在的行号。(Line returns the line number of the most recent record.)
+	Line() int
+}
+
+// NewEncoder 创建指定格式的编码器。(NewEncoder creates an encoder for format.)
+func NewEncoder[T any](w io.Writer, format Format) (Encoder[T], error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVEncoder[T](w)
+	case FormatJSONL:
+		return NewJSONLEncoder[T](w), nil
+	default:
+		return nil, lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIFormatUnsupported, "unsupported format '%s'", format)
+	}
+}
+
+// NewDecoder 创建指定格式的解码器。(NewDecoder creates a decoder for format.)
+func NewDecoder[T any](r io.Reader, format Format) (Decoder[T], error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVDecoder[T](r)
+	case FormatJSONL:
+		return NewJSONLDecoder[T](r), nil
+	default:
+		return nil, lmccerrors.ErrorfWithCode(lmccerrors.ErrCLIFormatUnsupported, "unsupported format '%s'", format)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the structured output renderer.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/cli"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Region string `json:"region" table:"REGION,wide"`
+	Token  string `json:"token" table:"-"`
+}
+
+// TestRenderer_Formats tests table, wide, JSON and YAML output.
+// (TestRenderer_Formats 测试 table、wide、JSON 和 YAML 输出。)
+func TestRenderer_Formats(t *testing.T) {
+	servers := []*server{
+		{Name: "api", Status: "running", Region: "eu-west-1", Token: "s3cr3t"},
+		{Name: "worker-long-name", Status: "stopped", Region: "us-east-1"},
+	}
+	tests := []struct {
+		format cli.OutputFormat
+		want   string
+	}{
+		{cli.OutputTable, "NAME               STATUS\napi                running\nworker-long-name   stopped\n"},
+		{cli.OutputWide, "NAME               STATUS    REGION\napi                running   eu-west-1\nworker-long-name   stopped   us-east-1\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		require.NoError(t, cli.NewRenderer(&buf, tt.format).Render(servers))
+		assert.Equal(t, tt.want, buf.String(), tt.format)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, cli.NewRenderer(&buf, cli.OutputJSON).Render(servers[1]))
+	assert.JSONEq(t, `{"name":"worker-long-name","status":"stopped","region":"us-east-1","token":""}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, cli.NewRenderer(&buf, cli.OutputYAML).Render(map[string]int{"replicas": 3}))
+	assert.Equal(t, "replicas: 3\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, cli.NewRenderer(&buf, "").Render(map[string]string{"b": "2", "a": "1"}))
+	assert.Equal(t, "KEY   VALUE\na     1\nb     2\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, cli.NewRenderer(&buf, cli.OutputTable, cli.WithColor(true)).Render(servers[0]))
+	assert.Equal(t, "\x1b[1mNAME   STATUS\x1b[0m\napi    running\n", buf.String())
+}
+
+// TestOutputFlag tests parsing the --output flag with the flag package and by hand.
+// (TestOutputFlag 测试用 flag 包和手工方式解析 --output 标志。)
+func TestOutputFlag(t *testing.T) {
+	var format cli.OutputFormat
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&format, "output", cli.OutputFlagUsage)
+	require.NoError(t, fs.Parse([]string{"--output", "yml"}))
+	assert.Equal(t, cli.OutputYAML, format)
+
+	format, rest, err := cli.ExtractOutputFlag([]string{"list", "-o", "wide", "--limit", "5"}, cli.OutputTable)
+	require.NoError(t, err)
+	assert.Equal(t, cli.OutputWide, format)
+	assert.Equal(t, []string{"list", "--limit", "5"}, rest)
+
+	format, _, err = cli.ExtractOutputFlag([]string{"--output=json"}, cli.OutputTable)
+	require.NoError(t, err)
+	assert.Equal(t, cli.OutputJSON, format)
+
+	_, _, err = cli.ExtractOutputFlag([]string{"--output", "xml"}, cli.OutputTable)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrCLIFormatUnsupported))
+}
+
+// TestColorEnabled tests that color follows the log options and NO_COLOR.
+// (TestColorEnabled 测试颜色设置跟随日志配置和 NO_COLOR。)
+func TestColorEnabled(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Format = log.FormatText
+	assert.False(t, cli.ColorEnabled(opts))
+
+	opts.EnableColor = true
+	assert.True(t, cli.ColorEnabled(opts))
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, cli.ColorEnabled(opts))
+}
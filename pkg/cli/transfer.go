@@ -0,0 +1,198 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// defaultProgressEvery 是默认的进度日志间隔（记录数）。(defaultProgressEvery is the default progress log interval in records.)
+const defaultProgressEvery = 1000
+
+// Stats 汇总一次导出或导入。(Stats summarizes an export or import.)
+type Stats struct {
+	// Records 是处理的记录数，包括失败的记录。(Records is the number of records processed, including failed ones.)
+	Records int
+	// Failed 是失败的记录数。(Failed is the number of records that failed.)
+	Failed int
+	// Duration 是总耗时。(Duration is the total time taken.)
+	Duration time.Duration
+}
+
+// Succeeded 返回成功的记录数。(Succeeded returns the number of records that succeeded.)
+func (s Stats) Succeeded() int {
+	return s.Records - s.Failed
+}
+
+// TransferOption 配置 Export 和 Import。(TransferOption configures Export and Import.)
+type TransferOption func(*transfer)
+
+// WithLogger 设置进度日志使用的 logger，默认为 log.Std()。
+// (WithLogger sets the logger used for progress logs; defaults to log.Std().)
+func WithLogger(logger log.Logger) TransferOption {
+	return func(t *transfer) {
+		if logger != nil {
+			t.logger = logger
+		}
+	}
+}
+
+// WithName 设置日志中的任务名称。(WithName sets the job name shown in logs.)
+func WithName(name string) TransferOption {
+	return func(t *transfer) {
+		t.name = name
+	}
+}
+
+// WithProgressEvery 设置每处理多少条记录记录一次进度日志，0 表示不记录进度。
+// (WithProgressEvery sets how many records are processed between progress logs; 0 disables progress logs.)
+func WithProgressEvery(n int) TransferOption {
+	return func(t *transfer) {
+		t.progressEvery = n
+	}
+}
+
+// WithMaxErrors 设置失败记录数上限，达到后提前停止；0 表示不限制。
+// (WithMaxErrors sets the number of failed records after which the transfer stops early; 0 means no limit.)
+func WithMaxErrors(n int) TransferOption {
+	return func(t *transfer) {
+		t.maxErrors = n
+	}
+}
+
+// transfer 是一次导出或导入的状态。(transfer is the state of an export or import.)
+type transfer struct {
+	op            string
+	name          string
+	logger        log.Logger
+	progressEvery int
+	maxErrors     int
+
+	start time.Time
+	stats Stats
+	errs  *lmccerrors.ErrorGroup
+}
+
+func newTransfer(op string, format Format, options []TransferOption) *transfer {
+	t := &transfer{
+		op:            op,
+		name:          string(format),
+		logger:        log.Std(),
+		progressEvery: defaultProgressEvery,
+		start:         time.Now(),
+	}
+	for _, opt := range options {
+		opt(t)
+	}
+	t.errs = lmccerrors.NewErrorGroup(t.op + " '" + t.name + "' had failed records")
+	return t
+}
+
+// record 记录一条记录的结果，达到失败上限时返回 true。
+// (record records the outcome of one record and reports whether the failure limit was reached.)
+func (t *transfer) record(err error) bool {
+	t.stats.Records++
+	if err != nil {
+		t.stats.Failed++
+		t.errs.Add(err)
+		t.logger.Debugw("Record failed", "op", t.op, "name", t.name, "record", t.stats.Records, "error", err)
+	}
+	if t.progressEvery > 0 && t.stats.Records%t.progressEvery == 0 {
+		t.logger.Infow("Transfer progress", "op", t.op, "name", t.name,
+			"records", t.stats.Records, "failed", t.stats.Failed, "elapsed", time.Since(t.start).String())
+	}
+	return t.maxErrors > 0 && t.stats.Failed >= t.maxErrors
+}
+
+// finish 返回统计和聚合的错误。abort 非 nil 时优先返回它。
+// (finish returns the stats and the aggregated error; a non-nil abort error takes precedence.)
+func (t *transfer) finish(abort error) (Stats, error) {
+	t.stats.Duration = time.Since(t.start)
+	keyvals := []any{"op", t.op, "name", t.name, "records", t.stats.Records, "failed", t.stats.Failed,
+		"elapsed", t.stats.Duration.String()}
+	switch {
+	case abort != nil:
+		t.logger.Errorw("Transfer aborted", append(keyvals, "error", abort)...)
+		return t.stats, abort
+	case t.stats.Failed > 0:
+		t.logger.Warnw("Transfer completed with failures", keyvals...)
+		return t.stats, t.errs
+	default:
+		t.logger.Infow("Transfer completed", keyvals...)
+		return t.stats, nil
+	}
+}
+
+// Export 将 items 以 format 流式写入 w。编码失败的记录被跳过并聚合到返回的 *errors.ErrorGroup 中；
+// ctx 取消或写入失败时立即停止。
+// (Export streams items to w in format. Records that fail to encode are skipped and aggregated into the returned *errors.ErrorGroup;
+// it stops immediately when ctx is canceled or writing fails.)
+func Export[T any](ctx context.Context, w io.Writer, format Format, items iter.Seq[T], options ...TransferOption) (Stats, error) {
+	enc, err := NewEncoder[T](w, format)
+	if err != nil {
+		return Stats{}, err
+	}
+	t := newTransfer("export", format, options)
+	for item := range items {
+		if err := ctx.Err(); err != nil {
+			return t.finish(err)
+		}
+		err := enc.Encode(item)
+		if err != nil && !lmccerrors.IsCode(err, lmccerrors.ErrCLIRecordInvalid) {
+			return t.finish(lmccerrors.Wrapf(err, "failed to write record %d", t.stats.Records+1))
+		}
+		if err != nil {
+			err = lmccerrors.Wrapf(err, "record %d", t.stats.Records+1)
+		}
+		if t.record(err) {
+			break
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return t.finish(lmccerrors.Wrap(err, "failed to flush output"))
+	}
+	return t.finish(nil)
+}
+
+// Import 从 r 中按 format 流式读取记录并逐条交给 handle。无效记录和 handle 返回的错误被聚合到返回的 *errors.ErrorGroup 中，
+// 错误信息带有行号；ctx 取消或读取失败时立即停止。
+// (Import streams records from r in format and passes each to handle. Invalid records and errors returned by handle are aggregated,
+// with line numbers, into the returned *errors.ErrorGroup; it stops immediately when ctx is canceled or reading fails.)
+func Import[T any](ctx context.Context, r io.Reader, format Format, handle func(ctx context.Context, item T) error, options ...TransferOption) (Stats, error) {
+	dec, err := NewDecoder[T](r, format)
+	if err != nil {
+		return Stats{}, err
+	}
+	t := newTransfer("import", format, options)
+	for {
+		if err := ctx.Err(); err != nil {
+			return t.finish(err)
+		}
+		item, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return t.finish(nil)
+		}
+		if err != nil && !lmccerrors.IsCode(err, lmccerrors.ErrCLIRecordInvalid) {
+			return t.finish(lmccerrors.Wrap(err, "failed to read input"))
+		}
+		if err == nil {
+			if herr := handle(ctx, item); herr != nil {
+				err = lmccerrors.Wrapf(herr, "record on line %d", dec.Line())
+			}
+		}
+		if t.record(err) {
+			return t.finish(nil)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for CSV and JSON Lines export and import.
+ */
+
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/cli"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type user struct {
+	ID      int               `json:"id"`
+	Name    string            `csv:"full_name" json:"name"`
+	Active  bool              `json:"active"`
+	Score   *float64          `json:"score,omitempty"`
+	Created time.Time         `json:"created"`
+	Timeout time.Duration     `json:"timeout"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Secret  string            `json:"-"`
+	address
+}
+
+// TestExportImport_RoundTrip tests that every format round-trips records.
+// (TestExportImport_RoundTrip 测试所有格式都能完整往返记录。)
+func TestExportImport_RoundTrip(t *testing.T) {
+	score := 9.5
+	created := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	users := []user{
+		{ID: 1, Name: "Alice, A.", Active: true, Score: &score, Created: created, Timeout: 3 * time.Second,
+			Tags: []string{"admin", "ops"}, Labels: map[string]string{"team": "core"}, address: address{City: "Paris"}},
+		{ID: 2, Name: "Bob \"B\"", Created: created},
+	}
+
+	for _, format := range []cli.Format{cli.FormatCSV, cli.FormatJSONL} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			stats, err := cli.Export(context.Background(), &buf, format, slices.Values(users), cli.WithProgressEvery(1))
+			require.NoError(t, err)
+			assert.Equal(t, 2, stats.Records)
+			assert.Equal(t, 2, stats.Succeeded())
+
+			var got []user
+			stats, err = cli.Import(context.Background(), &buf, format, func(_ context.Context, u user) error {
+				got = append(got, u)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 2, stats.Records)
+			assert.Equal(t, users, got)
+		})
+	}
+}
+
+// TestEncoder_CSVHeader tests CSV column naming.
+// (TestEncoder_CSVHeader 测试 CSV 列命名。)
+func TestEncoder_CSVHeader(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := cli.NewEncoder[*user](&buf, cli.FormatCSV)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(&user{ID: 7, Name: "Eve"}))
+	require.NoError(t, enc.Flush())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, "id,full_name,active,score,created,timeout,tags,labels,city", lines[0])
+	assert.Equal(t, "7,Eve,false,,,0s,,,", lines[1])
+
+	_, err = cli.NewEncoder[string](&buf, cli.FormatCSV)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrCLIFormatUnsupported))
+}
+
+// TestImport_AggregatesErrors tests that invalid records and handler errors are collected with line numbers.
+// (TestImport_AggregatesErrors 测试无效记录和处理函数错误会带行号被汇总。)
+func TestImport_AggregatesErrors(t *testing.T) {
+	input := "id,full_name,unknown\n1,Alice,x\nnope,Bob,y\n3,Carol,z\n4,Dave\n"
+	var names []string
+	stats, err := cli.Import(context.Background(), strings.NewReader(input), cli.FormatCSV, func(_ context.Context, u user) error {
+		if u.ID == 3 {
+			return errors.New("duplicate user")
+		}
+		names = append(names, u.Name)
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, []string{"Alice"}, names)
+	assert.Equal(t, 4, stats.Records)
+	assert.Equal(t, 3, stats.Failed)
+
+	var group *lmccerrors.ErrorGroup
+	require.ErrorAs(t, err, &group)
+	require.Len(t, group.Errors(), 3)
+	assert.Contains(t, group.Errors()[0].Error(), "line 3")
+	assert.True(t, lmccerrors.IsCode(group.Errors()[0], lmccerrors.ErrCLIRecordInvalid))
+	assert.Contains(t, group.Errors()[1].Error(), "line 4: duplicate user")
+	assert.Contains(t, group.Errors()[2].Error(), "expected 3 fields, got 2")
+
+	jsonl := "{\"id\":1}\n\n{bad\n{\"id\":2}\n"
+	stats, err = cli.Import(context.Background(), strings.NewReader(jsonl), cli.FormatJSONL,
+		func(context.Context, user) error { return nil }, cli.WithMaxErrors(1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+	assert.Equal(t, 2, stats.Records, "import stops at the error limit")
+}
+
+// TestFormatFromPath tests format detection from file extensions.
+// (TestFormatFromPath 测试根据扩展名识别格式。)
+func TestFormatFromPath(t *testing.T) {
+	format, err := cli.FormatFromPath("out/users.CSV")
+	require.NoError(t, err)
+	assert.Equal(t, cli.FormatCSV, format)
+	format, err = cli.FormatFromPath("users.ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, cli.FormatJSONL, format)
+
+	_, err = cli.FormatFromPath("users.xml")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrCLIFormatUnsupported))
+	_, err = cli.Export(context.Background(), &bytes.Buffer{}, cli.Format("xml"), slices.Values([]user{}))
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrCLIFormatUnsupported))
+}
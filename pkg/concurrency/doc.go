@@ -0,0 +1,32 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package concurrency provides panic-safe goroutine groups and bounded fan-out/fan-in helpers.
+(concurrency 包提供了 panic 安全的 goroutine 组以及有界的扇出/扇入辅助函数。)
+
+Group has errgroup semantics: the first failing task cancels the group context and is
+returned from Wait. In addition, a panic inside a task is recovered and returned as an
+error coded ErrPanic, and failed tasks are logged with their name and duration.
+(Group 具有 errgroup 语义：第一个失败的任务会取消组上下文并由 Wait 返回。
+此外，任务中的 panic 会被恢复并以带 ErrPanic 的错误返回，失败的任务会连同名称和耗时一起记录日志。)
+
+	g, ctx := concurrency.NewGroup(ctx, concurrency.WithName("sync"), concurrency.WithLimit(8))
+	for _, id := range ids {
+		g.GoNamed(id, func() error { return syncOne(ctx, id) })
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+Helpers:
+(辅助函数：)
+
+  - Parallel: run a fixed set of functions (运行一组固定的函数)
+  - ForEach / Map: process a slice with bounded concurrency; Map keeps input order (以有界并发处理切片；Map 保持输入顺序)
+  - Merge / Collect / Generate: context-aware fan-in over channels (基于通道的上下文感知扇入)
+*/
+package concurrency
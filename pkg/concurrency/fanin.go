@@ -0,0 +1,84 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+)
+
+// Merge 将多个通道合并为一个通道。所有输入通道关闭或上下文被取消后，输出通道会被关闭。
+// (Merge fans multiple channels into one. The output channel is closed once all inputs are closed or the context is cancelled.)
+func Merge[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(ch)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Collect 读取通道中的所有值，直到通道关闭或上下文被取消。
+// 上下文被取消时返回已读取的值以及上下文错误。
+// (Collect reads all values from the channel until it is closed or the context is cancelled.)
+// (On cancellation it returns the values read so far together with the context error.)
+func Collect[T any](ctx context.Context, ch <-chan T) ([]T, error) {
+	var out []T
+	for {
+		select {
+		case <-ctx.Done():
+			return out, ctx.Err()
+		case v, ok := <-ch:
+			if !ok {
+				return out, nil
+			}
+			out = append(out, v)
+		}
+	}
+}
+
+// Generate 在后台调用 fn 生成值并发送到返回的通道，fn 返回后通道关闭。
+// emit 在上下文被取消时返回 false，fn 应据此停止生产。
+// (Generate calls fn in the background to produce values into the returned channel, which is closed when fn returns.)
+// (emit returns false once the context is cancelled, and fn should stop producing.)
+func Generate[T any](ctx context.Context, fn func(ctx context.Context, emit func(T) bool)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		fn(ctx, func(v T) bool {
+			select {
+			case out <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return out
+}
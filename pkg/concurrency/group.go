@@ -0,0 +1,163 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// GroupOption 是配置 Group 的函数类型。
+// (GroupOption is a function type for configuring a Group.)
+type GroupOption func(*Group)
+
+// WithLimit 限制同时运行的任务数量，n <= 0 表示不限制。
+// (WithLimit limits the number of concurrently running tasks; n <= 0 means unlimited.)
+func WithLimit(n int) GroupOption {
+	return func(g *Group) {
+		g.setLimit(n)
+	}
+}
+
+// WithLogger 设置用于记录任务失败的日志记录器，默认使用全局日志记录器。
+// (WithLogger sets the logger used to record task failures; the global logger is used by default.)
+func WithLogger(logger log.Logger) GroupOption {
+	return func(g *Group) {
+		g.logger = logger
+	}
+}
+
+// WithName 设置 Group 的名称，会出现在日志和 panic 错误信息中。
+// (WithName sets the name of the Group, which appears in logs and panic error messages.)
+func WithName(name string) GroupOption {
+	return func(g *Group) {
+		g.name = name
+	}
+}
+
+// Group 是带 panic 恢复的 errgroup：任务中的 panic 会被转换为带 ErrPanic 的错误，
+// 第一个失败的任务会取消 Group 的上下文，Wait 返回第一个错误。
+// (Group is an errgroup with panic recovery: a panic in a task is converted into an error coded ErrPanic,)
+// (the first failing task cancels the Group's context, and Wait returns the first error.)
+type Group struct {
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+	logger log.Logger
+	name   string
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup 创建一个 Group 及其派生的上下文，上下文会在第一个任务失败或 Wait 返回时被取消。
+// (NewGroup creates a Group and a derived context that is cancelled when the first task fails or Wait returns.)
+func NewGroup(ctx context.Context, opts ...GroupOption) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &Group{cancel: cancel}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g, ctx
+}
+
+// setLimit 设置并发上限。(setLimit sets the concurrency limit.)
+func (g *Group) setLimit(n int) {
+	if n <= 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go 在新的 goroutine 中运行 fn。设置了并发上限时，会阻塞直到有空闲槽位。
+// (Go runs fn in a new goroutine. With a concurrency limit it blocks until a slot is free.)
+func (g *Group) Go(fn func() error) {
+	g.GoNamed("", fn)
+}
+
+// GoNamed 与 Go 相同，但为任务指定名称，用于日志和错误信息。
+// (GoNamed is like Go but names the task for logs and error messages.)
+func (g *Group) GoNamed(task string, fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+
+		start := time.Now()
+		if err := g.run(task, fn); err != nil {
+			g.logFailure(task, err, time.Since(start))
+			g.errOnce.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel(err)
+				}
+			})
+		}
+	}()
+}
+
+// run 执行任务并将 panic 恢复为带 ErrPanic 的错误。
+// (run executes the task and recovers a panic into an error coded ErrPanic.)
+func (g *Group) run(task string, fn func() error) (err error) {
+	defer lmccerrors.HandlePanic(func(p any) {
+		err = lmccerrors.Wrapf(lmccerrors.WrapPanic(p), "panic in task '%s'", g.taskLabel(task))
+	})
+	return fn()
+}
+
+// Wait 阻塞直到所有任务完成，然后返回第一个非 nil 错误（如果有）。
+// (Wait blocks until all tasks have completed, then returns the first non-nil error, if any.)
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	return g.err
+}
+
+// taskLabel 组合 Group 名称与任务名称。
+// (taskLabel combines the Group name with the task name.)
+func (g *Group) taskLabel(task string) string {
+	switch {
+	case g.name != "" && task != "":
+		return g.name + "/" + task
+	case task != "":
+		return task
+	case g.name != "":
+		return g.name
+	default:
+		return "anonymous"
+	}
+}
+
+// logFailure 记录失败的任务。上下文取消导致的失败以 Debug 级别记录，以免淹没首个真实错误。
+// (logFailure records a failed task. Failures caused by context cancellation are logged at Debug so they don't bury the first real error.)
+func (g *Group) logFailure(task string, err error, elapsed time.Duration) {
+	logger := g.logger
+	if logger == nil {
+		logger = log.Std()
+	}
+	kvs := []any{"task", g.taskLabel(task), "duration", elapsed, "error", err}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		logger.Debugw("Concurrent task cancelled", kvs...)
+		return
+	}
+	logger.Errorw("Concurrent task failed", kvs...)
+}
@@ -0,0 +1,167 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the panic-safe Group and the fan-out/fan-in helpers.
+ */
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/concurrency"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGroupFirstErrorCancels tests that the first error is returned and cancels the context.
+// (TestGroupFirstErrorCancels 测试返回第一个错误并取消上下文。)
+func TestGroupFirstErrorCancels(t *testing.T) {
+	boom := errors.New("boom")
+	g, ctx := concurrency.NewGroup(context.Background())
+
+	g.GoNamed("fail", func() error { return boom })
+	g.GoNamed("wait", func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := g.Wait()
+	assert.ErrorIs(t, err, boom)
+	assert.ErrorIs(t, context.Cause(ctx), boom)
+}
+
+// TestGroupRecoversPanic tests that a panic is converted into an ErrPanic coded error.
+// (TestGroupRecoversPanic 测试 panic 被转换为带 ErrPanic 的错误。)
+func TestGroupRecoversPanic(t *testing.T) {
+	g, _ := concurrency.NewGroup(context.Background(), concurrency.WithName("workers"))
+	g.GoNamed("explode", func() error { panic("kaboom") })
+
+	err := g.Wait()
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrPanic))
+	assert.Contains(t, err.Error(), "workers/explode")
+	assert.Contains(t, err.Error(), "kaboom")
+
+	sentinel := errors.New("sentinel")
+	g, _ = concurrency.NewGroup(context.Background())
+	g.Go(func() error { panic(sentinel) })
+	err = g.Wait()
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrPanic))
+	assert.ErrorIs(t, err, sentinel, "panicked errors stay matchable")
+}
+
+// TestGroupLimit tests that WithLimit bounds concurrency.
+// (TestGroupLimit 测试 WithLimit 限制并发数。)
+func TestGroupLimit(t *testing.T) {
+	var running, peak int32
+	g, _ := concurrency.NewGroup(context.Background(), concurrency.WithLimit(2))
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+// TestZeroGroup tests that the zero value Group is usable.
+// (TestZeroGroup 测试 Group 零值可用。)
+func TestZeroGroup(t *testing.T) {
+	var g concurrency.Group
+	var n int32
+	for i := 0; i < 3; i++ {
+		g.Go(func() error {
+			atomic.AddInt32(&n, 1)
+			return nil
+		})
+	}
+	require.NoError(t, g.Wait())
+	assert.Equal(t, int32(3), n)
+}
+
+// TestMap tests that Map preserves input order and propagates errors.
+// (TestMap 测试 Map 保持输入顺序并传播错误。)
+func TestMap(t *testing.T) {
+	out, err := concurrency.Map(context.Background(), []int{1, 2, 3, 4}, 2, func(_ context.Context, v int) (int, error) {
+		time.Sleep(time.Duration(5-v) * time.Millisecond)
+		return v * v, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 4, 9, 16}, out)
+
+	bad := errors.New("bad item")
+	out, err = concurrency.Map(context.Background(), []int{1, 2, 3}, 0, func(_ context.Context, v int) (int, error) {
+		if v == 2 {
+			return 0, bad
+		}
+		return v, nil
+	})
+	assert.ErrorIs(t, err, bad)
+	assert.Nil(t, out)
+}
+
+// TestParallelAndForEach tests the Parallel and ForEach helpers.
+// (TestParallelAndForEach 测试 Parallel 和 ForEach 辅助函数。)
+func TestParallelAndForEach(t *testing.T) {
+	var sum int64
+	err := concurrency.Parallel(context.Background(), 0,
+		func(context.Context) error { atomic.AddInt64(&sum, 1); return nil },
+		func(context.Context) error { atomic.AddInt64(&sum, 2); return nil },
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), sum)
+
+	sum = 0
+	err = concurrency.ForEach(context.Background(), []int64{1, 2, 3}, 1, func(_ context.Context, v int64) error {
+		atomic.AddInt64(&sum, v)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), sum)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = concurrency.ForEach(ctx, []int{1}, 1, func(context.Context, int) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestMergeCollect tests fan-in of multiple channels.
+// (TestMergeCollect 测试多个通道的扇入。)
+func TestMergeCollect(t *testing.T) {
+	ctx := context.Background()
+	produce := func(vals ...int) <-chan int {
+		return concurrency.Generate(ctx, func(_ context.Context, emit func(int) bool) {
+			for _, v := range vals {
+				if !emit(v) {
+					return
+				}
+			}
+		})
+	}
+
+	out, err := concurrency.Collect(ctx, concurrency.Merge(ctx, produce(1, 2), produce(3), produce()))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 2, 3}, out)
+
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	never := make(chan int)
+	_, err = concurrency.Collect(cctx, concurrency.Merge(cctx, never))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,74 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package concurrency
+
+import (
+	"context"
+)
+
+// Parallel 以最多 limit 个并发运行 fns，返回第一个错误。
+// 任一函数失败时，传入其他函数的上下文会被取消。limit <= 0 表示不限制。
+// (Parallel runs fns with at most limit running concurrently and returns the first error.)
+// (When any function fails, the context passed to the others is cancelled. limit <= 0 means unlimited.)
+func Parallel(ctx context.Context, limit int, fns ...func(ctx context.Context) error) error {
+	g, gctx := NewGroup(ctx, WithLimit(limit))
+	for _, fn := range fns {
+		g.Go(func() error {
+			return fn(gctx)
+		})
+	}
+	return g.Wait()
+}
+
+// ForEach 以最多 limit 个并发对 items 中的每个元素调用 fn，返回第一个错误。
+// 上下文被取消后不再启动新的调用。
+// (ForEach calls fn for every element of items with at most limit running concurrently and returns the first error.)
+// (No new calls are started once the context is cancelled.)
+func ForEach[T any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) error) error {
+	g, gctx := NewGroup(ctx, WithLimit(limit))
+	for _, item := range items {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			return fn(gctx, item)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Map 以最多 limit 个并发对 items 中的每个元素调用 fn，并按输入顺序返回结果。
+// 任一调用失败时返回 nil 结果和第一个错误。
+// (Map calls fn for every element of items with at most limit running concurrently and returns results in input order.)
+// (If any call fails, it returns a nil result and the first error.)
+func Map[T, R any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	g, gctx := NewGroup(ctx, WithLimit(limit))
+	for i, item := range items {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			r, err := fn(gctx, item)
+			if err != nil {
+				return err
+			}
+			results[i] = r
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -71,7 +71,7 @@ func updateGlobalCfg[T any](cfg *T) {
 	log.Println("Warning: Global Cfg variable was not updated. Provided type is not *config.Config and does not embed config.Config, nor does it contain an initialized pointer field to *config.Config.")
 }
 
-// Note: The following accessor functions (GetString, GetInt, GetBool, GetDuration, GetStringSlice, IsSet, AllSettings)
+// Note: The package-level accessor functions (GetString, GetInt, GetBool, GetDuration, GetStringSlice, IsSet, AllSettings)
 // have been removed. They relied on Viper's global instance and were not recommended for use.
-// Please access configuration values directly through the global `Cfg` variable (obtained via `GetGlobalCfg()`)
-// or through the specific configuration struct instance returned by `LoadConfigAndWatch`.
+// Use the typed accessors of the Manager returned by `LoadConfigAndWatch` (see Values), the global `Cfg` variable
+// (obtained via `GetGlobalCfg()`), or the configuration struct instance itself.
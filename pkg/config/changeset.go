@@ -0,0 +1,112 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Change 描述一次热重载中单个叶子键的变化。新增的键 Old 为 nil，删除的键 New 为 nil。
+// (Change describes how a single leaf key changed in a hot reload. Old is nil for an added key and New is nil for a removed key.)
+type Change struct {
+	Key string // 点分隔的小写键，例如 "database.host" (Dotted, lower-case key such as "database.host")
+	Old any    // 重载前的值 (Value before the reload)
+	New any    // 重载后的值 (Value after the reload)
+}
+
+// ChangeSet 是最近一次热重载中发生变化的键，按键排序。
+// (ChangeSet is the keys that changed in the most recent hot reload, sorted by key.)
+type ChangeSet []Change
+
+// IsEmpty 报告是否没有任何键发生变化。(IsEmpty reports whether no key changed.)
+func (cs ChangeSet) IsEmpty() bool {
+	return len(cs) == 0
+}
+
+// Keys 返回发生变化的键。(Keys returns the changed keys.)
+func (cs ChangeSet) Keys() []string {
+	keys := make([]string, len(cs))
+	for i, c := range cs {
+		keys[i] = c.Key
+	}
+	return keys
+}
+
+// Get 返回指定键的变化，键不区分大小写。(Get returns the change of the key; the key is case-insensitive.)
+func (cs ChangeSet) Get(key string) (Change, bool) {
+	key = strings.ToLower(key)
+	i := sort.Search(len(cs), func(i int) bool { return cs[i].Key >= key })
+	if i < len(cs) && cs[i].Key == key {
+		return cs[i], true
+	}
+	return Change{}, false
+}
+
+// Changed 报告键本身或其下任一子键是否发生变化，例如 Changed("database") 覆盖 "database.host"。前缀不区分大小写。
+// (Changed reports whether the key itself or any key below it changed, e.g. Changed("database") covers "database.host".
+// The prefix is case-insensitive.)
+func (cs ChangeSet) Changed(prefix string) bool {
+	prefix = strings.ToLower(prefix)
+	for _, c := range cs {
+		if c.Key == prefix || strings.HasPrefix(c.Key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// LastChangeSet 返回最近一次成功热重载的变化集合；尚未发生热重载时返回 nil。
+// 在回调中调用时，返回的正是触发该回调的那次重载的变化。
+// (LastChangeSet returns the change set of the most recent successful hot reload, or nil before any hot reload.
+// Called from a callback, it returns the changes of the reload that triggered the callback.)
+func (cm *configManager[T]) LastChangeSet() ChangeSet {
+	changes := cm.lastChanges.Load()
+	if changes == nil {
+		return nil
+	}
+	return *changes
+}
+
+// diffSettings 比较两份配置快照的叶子键，返回按键排序的变化集合。
+// (diffSettings compares the leaf keys of two configuration snapshots and returns the changes sorted by key.)
+func diffSettings(old, new map[string]any) ChangeSet {
+	oldValues, newValues := make(map[string]any), make(map[string]any)
+	flattenSettings(old, "", oldValues)
+	flattenSettings(new, "", newValues)
+
+	changes := ChangeSet{}
+	for key, oldValue := range oldValues {
+		newValue, ok := newValues[key]
+		if !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, Change{Key: key, Old: oldValue, New: newValue})
+		}
+	}
+	for key, newValue := range newValues {
+		if _, ok := oldValues[key]; !ok {
+			changes = append(changes, Change{Key: key, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// flattenSettings 将嵌套映射展开为点分隔的叶子键。(flattenSettings flattens nested maps into dotted leaf keys.)
+func flattenSettings(m map[string]any, prefix string, result map[string]any) {
+	for key, value := range m {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flattenSettings(nested, fullKey, result)
+			continue
+		}
+		result[fullKey] = value
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for the change sets computed on hot reload.
+ */
+
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDiffSettings tests added, removed and modified leaf keys and the ChangeSet helpers.
+// (TestDiffSettings 测试新增、删除和修改的叶子键以及 ChangeSet 的辅助方法。)
+func TestDiffSettings(t *testing.T) {
+	old := map[string]any{
+		"database": map[string]any{"host": "db-1", "port": 5432},
+		"log":      map[string]any{"level": "info", "outputs": []any{"stdout"}},
+		"legacy":   true,
+	}
+	next := map[string]any{
+		"database": map[string]any{"host": "db-2", "port": 5432},
+		"log":      map[string]any{"level": "info", "outputs": []any{"stdout", "file"}},
+		"feature":  map[string]any{"enabled": true},
+	}
+
+	changes := diffSettings(old, next)
+	assert.Equal(t, []string{"database.host", "feature.enabled", "legacy", "log.outputs"}, changes.Keys())
+
+	host, ok := changes.Get("Database.Host")
+	require.True(t, ok)
+	assert.Equal(t, Change{Key: "database.host", Old: "db-1", New: "db-2"}, host)
+	legacy, _ := changes.Get("legacy")
+	assert.Nil(t, legacy.New, "a removed key has no new value")
+	feature, _ := changes.Get("feature.enabled")
+	assert.Nil(t, feature.Old, "an added key has no old value")
+	_, ok = changes.Get("database.port")
+	assert.False(t, ok)
+
+	assert.True(t, changes.Changed("database"))
+	assert.True(t, changes.Changed("DATABASE.host"))
+	assert.False(t, changes.Changed("database.port"))
+	assert.False(t, changes.Changed("data"), "a prefix only matches whole key segments")
+	assert.False(t, changes.Changed("server"))
+	assert.True(t, diffSettings(old, old).IsEmpty())
+}
+
+// TestConfigHotReload_ChangeSet tests that a reload records its change set and only notifies the changed sections.
+// (TestConfigHotReload_ChangeSet 测试热重载记录变化集合，并且只通知发生变化的配置节。)
+func TestConfigHotReload_ChangeSet(t *testing.T) {
+	configFile, cleanup := createTempConfigFile(t, `
+log:
+  level: "info"
+database:
+  host: "db-1"
+`, "yaml")
+	defer cleanup()
+
+	var loadedCfg testAppConfig
+	initializeTestConfig(&loadedCfg)
+	cm, err := LoadConfigAndWatch(&loadedCfg, WithConfigFile(configFile, "yaml"), WithHotReload(true))
+	require.NoError(t, err)
+	assert.Nil(t, cm.LastChangeSet(), "no change set before the first reload")
+
+	reloaded := make(chan ChangeSet, 1)
+	sections := make(chan string, 4)
+	cm.RegisterCallback(func(v *viper.Viper, cfg any) error {
+		reloaded <- cm.LastChangeSet()
+		return nil
+	})
+	for _, section := range []string{"log", "database"} {
+		cm.RegisterSectionChangeCallback(section, func(v *viper.Viper) error {
+			sections <- section
+			return nil
+		})
+	}
+
+	time.Sleep(100 * time.Millisecond) // Ensure watcher is running
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+log:
+  level: "debug"
+database:
+  host: "db-1"
+`), 0644))
+
+	select {
+	case changes := <-reloaded:
+		assert.Equal(t, ChangeSet{{Key: "log.level", Old: "info", New: "debug"}}, changes)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for config change callback to execute")
+	}
+	assert.Equal(t, "log", <-sections)
+	select {
+	case section := <-sections:
+		t.Fatalf("section %q was notified although it did not change", section)
+	case <-time.After(200 * time.Millisecond):
+	}
+	assert.False(t, cm.LastChangeSet().Changed("database"))
+}
@@ -7,17 +7,13 @@
 package config
 
 import (
-	"errors"
 	"log"
-	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 
 	"github.com/fsnotify/fsnotify"
 	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
 	"github.com/mitchellh/mapstructure"
-	"github.com/spf13/viper"
 )
 
 // Note: ConfigChangeCallback, configManager, newConfigManager, RegisterCallback, notifyCallbacks, GetViperInstance
@@ -65,6 +61,89 @@ func GetGlobalCfg() *Config {
 func LoadConfigAndWatch[T any](cfg *T, opts ...Option) (Manager, error) {
 	cm := newConfigManager(cfg, opts...) // newConfigManager is defined in manager.go
 
+	configFileUsed, settings, err := cm.load()
+	if err != nil {
+		return nil, err
+	}
+	cm.storeSettings(settings)
+	cm.storeSnapshot(*cm.cfg)
+
+	// 8. 配置并启动监控（如果启用）(Configure and start watching if enabled)
+	if cm.options.enableHotReload && configFileUsed != "" {
+		configFiles := cm.options.configFilePaths()
+		// 设置了 WithReloadDebounce 时，短时间内的多次变更合并为一次重载 (With WithReloadDebounce, bursts of changes are coalesced into one reload)
+		reload := debounceReload(cm.options.reloadDebounce, func(source string) {
+			// 文件和远程变更可能并发到达，重载需串行执行 (File and remote changes may arrive concurrently, so reloads are serialized)
+			cm.reloadMux.Lock()
+			defer cm.reloadMux.Unlock()
+
+			log.Printf("Config file changed: %s. Reloading...", source)
+
+			// 重新读取并合并所有配置源 (Re-read and merge every config source)
+			if errRead := cm.readConfigSources(); errRead != nil {
+				// 如果文件在监控期间被删除，ReadInConfig 会报错，这是可能的场景
+				// (If the file is deleted during watch, ReadInConfig will error, which is possible)
+				log.Printf("Error reading config during hot reload: %v", errRead)
+				// Consider if we should reset config or keep old one? Keep old one for now.
+				return // Skip update and callbacks if re-read fails
+			}
+
+			// 解码、校验并应用新配置，失败时保留当前配置 (Decode, validate and apply the new configuration, keeping the current one on failure)
+			if errApply := cm.applySettings(); errApply != nil {
+				log.Printf("Error applying config during hot reload, keeping the previous config: %v", errApply)
+				if cm.options.onValidationError != nil && lmccerrors.IsCode(errApply, lmccerrors.ErrConfigValidation) {
+					cm.options.onValidationError(errApply)
+				}
+			}
+		})
+		onConfigChange := func(e fsnotify.Event) {
+			// 检查事件类型，避免不必要的重载（例如 CHMOD）
+			// Check event type to avoid unnecessary reloads (e.g., CHMOD)
+			if e.Op&fsnotify.Write != fsnotify.Write && e.Op&fsnotify.Create != fsnotify.Create {
+				log.Printf("Info: Config watcher received non-write/create event (%s), skipping reload.", e.Op)
+				return
+			}
+			reload(e.Name)
+		}
+
+		// 被引入的文件与配置文件一同监视 (Included files are watched along with the config files)
+		watchedFiles := func() []string {
+			return append(configFiles[:len(configFiles):len(configFiles)], cm.includedFiles()...)
+		}
+		if cm.options.kubernetesProjected && len(configFiles) > 0 {
+			// 投射文件通过替换符号链接更新，按内容监视 (Projected files are updated by swapping symlinks, so they are watched by content)
+			if err := watchProjectedFiles(watchedFiles(), reload); err != nil {
+				return nil, err
+			}
+		} else if len(configFiles) == 1 && len(cm.includedFiles()) == 0 {
+			// 使用 Viper 内部的文件变更通知 (Use Viper's internal file change notifications)
+			cm.v.WatchConfig()
+			cm.v.OnConfigChange(onConfigChange)
+		} else if len(configFiles) > 0 {
+			if err := watchConfigFiles(watchedFiles, onConfigChange); err != nil {
+				return nil, err
+			}
+		}
+		if cm.remote != nil {
+			cm.watchRemoteConfig(reload)
+		}
+		log.Printf("Hot reload enabled for config file: %s", configFileUsed)
+	} else if cm.options.enableHotReload {
+		log.Println("Warning: Hot reload enabled but no config file was used, watcher not started.")
+	}
+
+	// 首次加载后更新全局 Cfg 变量 (Update the global Cfg variable after initial load)
+	// 调用 accessors.go 中的 updateGlobalCfg (Call updateGlobalCfg from accessors.go)
+	updateGlobalCfg(cm.cfg)
+
+	return cm, nil
+}
+
+// load 执行一次完整的加载：读取配置源、应用环境变量和命令行标志、设置默认值、解码并校验，但不启动监控。
+// 返回合并后的配置源名称和解码所用的设置。
+// (load performs one full load: it reads the config sources, applies environment variables and flags, sets defaults,
+// decodes and validates, without starting any watcher. It returns the merged config source names and the settings decoded.)
+func (cm *configManager[T]) load() (string, map[string]any, error) {
 	// 1. 初始化 cfg 中的 nil 指针字段 (Initialize nil pointer fields in cfg)
 	// Assuming initializeNilPointers is defined elsewhere (e.g., defaults.go)
 	initializeNilPointers(cm.cfg)
@@ -82,58 +161,58 @@ func LoadConfigAndWatch[T any](cfg *T, opts ...Option) (Manager, error) {
 	// 3. 设置并读取配置文件 (Set and read the config file)
 	configFileUsed := ""
 	var keysFromConfigFile map[string]bool // 记录配置文件中实际存在的键 (Record keys actually present in config file)
-	if cm.options.configFilePath != "" {
-		cm.v.SetConfigFile(cm.options.configFilePath)
-		if cm.options.configFileType == "" {
-			ext := filepath.Ext(cm.options.configFilePath)
-			if len(ext) > 1 {
-				configType := strings.ToLower(ext[1:])
-				cm.v.SetConfigType(configType)
-			} else {
-				log.Printf("Warning: Could not infer config type from file extension '%s'...", cm.options.configFilePath)
-			}
-		} else {
-			cm.v.SetConfigType(strings.ToLower(cm.options.configFileType))
+	configFiles := cm.options.configFilePaths()
+	if len(cm.options.readers) > 0 {
+		// io.Reader 只能读取一次，内容在热重载时重复使用 (An io.Reader can only be read once; its content is reused on hot reloads)
+		if err := cm.readConfigReaders(); err != nil {
+			return "", nil, err
 		}
-
-		err := cm.v.ReadInConfig()
-		if err != nil {
-			var configFileNotFoundError viper.ConfigFileNotFoundError
-			if errors.As(err, &configFileNotFoundError) || os.IsNotExist(err) {
-				// 文件未找到，也应该是一个错误，而不仅仅是日志
-				// (File not found should also be an error, not just a log)
-				return nil, lmccerrors.WithCode(
-					lmccerrors.Wrapf(err, "config file '%s' not found", cm.options.configFilePath),
-					lmccerrors.ErrConfigFileRead,
-				)
-			} else {
-				return nil, lmccerrors.WithCode(
-					lmccerrors.Wrapf(err, "failed to read config file '%s'", cm.options.configFilePath),
-					lmccerrors.ErrConfigFileRead,
-				)
-			}
-		} else {
-			configFileUsed = cm.options.configFilePath
-			log.Printf("Info: Successfully read config file '%s'.", configFileUsed)
-			
-			// 记录配置文件中实际存在的键 (Record keys actually present in config file)
-			keysFromConfigFile = flattenViperKeys(cm.v.AllSettings())
+	}
+	if cm.options.remote != nil {
+		// 远程配置在文件之后合并，首次读取失败时返回错误 (Remote config is merged after the files; a failed initial read is an error)
+		if err := cm.fetchRemoteConfig(); err != nil {
+			return "", nil, err
+		}
+	}
+	if len(configFiles) > 0 || len(cm.readerData) > 0 || cm.remote != nil {
+		// 多个文件按顺序深度合并，后面的文件覆盖前面的 (Multiple files are deep-merged in order, later files override earlier ones)
+		if err := cm.readConfigSources(); err != nil {
+			return "", nil, err
 		}
+		configFileUsed = strings.Join(cm.sourceNames(), ", ")
+		log.Printf("Info: Successfully read config file '%s'.", configFileUsed)
+
+		// 记录配置文件中实际存在的键 (Record keys actually present in config file)
+		keysFromConfigFile = flattenViperKeys(cm.v.AllSettings())
 	} else {
 		log.Println("Info: No config file path provided...")
 		keysFromConfigFile = make(map[string]bool) // 空映射 (Empty map)
 	}
 
+	// 绑定命令行标志，显式设置的标志优先级最高 (Bind command-line flags; flags set explicitly take the highest precedence)
+	if cm.options.flagSet != nil {
+		if err := bindFlags(cm.v, cm.options.flagSet, cm.cfg, keysFromConfigFile); err != nil {
+			return "", nil, err
+		}
+	}
+
 	// 4. 从结构体标签设置 Viper 默认值 (Set Viper defaults from struct tags)
 	// Assuming setDefaultsFromTags is defined elsewhere (e.g., defaults.go)
 	if err := setDefaultsFromTags(cm.v, cm.cfg, ""); err != nil {
-		return nil, lmccerrors.WithCode(
+		return "", nil, lmccerrors.WithCode(
 			lmccerrors.Wrap(err, "failed to set defaults from struct tags"),
 			lmccerrors.ErrConfigSetup,
 		)
 	}
+	// 以代码提供的默认值覆盖标签中的默认值 (Defaults provided in code override the ones from tags)
+	if cm.options.defaultsProvider != nil {
+		if err := setDefaultsFromProvider(cm.v, cm.cfg, cm.options.defaultsProvider); err != nil {
+			return "", nil, err
+		}
+	}
 
 	// 5. 将 Viper 配置解组到结构体中 (Unmarshal the Viper config into the struct)
+	var metadata mapstructure.Metadata
 	decoderConfig := &mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
@@ -143,104 +222,112 @@ func LoadConfigAndWatch[T any](cfg *T, opts ...Option) (Manager, error) {
 		TagName:          "mapstructure",
 		Result:           cm.cfg,
 		Squash:           true,
+		Metadata:         &metadata, // 记录未使用的键，供 WithStrictUnmarshal 检查 (Records unused keys for WithStrictUnmarshal)
 	}
 	decoder, err := mapstructure.NewDecoder(decoderConfig)
 	if err != nil {
-		return nil, lmccerrors.WithCode(
+		return "", nil, lmccerrors.WithCode(
 			lmccerrors.Wrap(err, "failed to create mapstructure decoder"),
 			lmccerrors.ErrConfigSetup,
 		)
 	}
-	if err := decoder.Decode(cm.v.AllSettings()); err != nil {
-		return nil, lmccerrors.WithCode(
+	// 解析密钥引用，解析结果不写回 Viper (Resolve secret references; the results are not written back to Viper)
+	settings, err := cm.resolvedSettings()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := decoder.Decode(settings); err != nil {
+		return "", nil, lmccerrors.WithCode(
 			lmccerrors.Wrap(err, "failed to unmarshal config from mapstructure"),
 			lmccerrors.ErrConfigSetup,
 		)
 	}
+	if err := cm.checkUnknownKeys(metadata.Unused); err != nil {
+		return "", nil, err
+	}
 
 	// 6. 在解码后应用默认值到零值字段 (Apply defaults to zero-value fields after decoding)
 	// 使用改进版本的函数，它能够区分显式设置的值和真正的零值
 	// (Use improved version of the function that can distinguish explicitly set values from true zero values)
 	if err := applyDefaultsToZeroFieldsWithViper(cm.cfg, cm.v, keysFromConfigFile); err != nil {
-		return nil, lmccerrors.WithCode(
+		return "", nil, lmccerrors.WithCode(
 			lmccerrors.Wrap(err, "failed to apply defaults to zero fields after initial load"),
 			lmccerrors.ErrConfigSetup,
 		)
 	}
 
-	// 7. 配置并启动监控（如果启用）(Configure and start watching if enabled)
-	if cm.options.enableHotReload && configFileUsed != "" {
-		cm.v.WatchConfig()
-		// 使用 OnConfigChange 来处理 Viper 内部的文件变更通知
-		// (Use OnConfigChange to handle Viper's internal file change notifications)
-		cm.v.OnConfigChange(func(e fsnotify.Event) {
-			// 检查事件类型，避免不必要的重载（例如 CHMOD）
-			// Check event type to avoid unnecessary reloads (e.g., CHMOD)
-			if e.Op&fsnotify.Write != fsnotify.Write && e.Op&fsnotify.Create != fsnotify.Create {
-				log.Printf("Info: Config watcher received non-write/create event (%s), skipping reload.", e.Op)
-				return
-			}
-
-			log.Printf("Config file changed: %s. Reloading...", e.Name)
-
-			// 重新读取配置 (Re-read the config)
-			if errRead := cm.v.ReadInConfig(); errRead != nil {
-				// 如果文件在监控期间被删除，ReadInConfig 会报错，这是可能的场景
-				// (If the file is deleted during watch, ReadInConfig will error, which is possible)
-				log.Printf("Error reading config during hot reload: %v", errRead)
-				// Consider if we should reset config or keep old one? Keep old one for now.
-				return // Skip update and callbacks if re-read fails
-			}
-
-			// 重新解码配置到 cm.cfg (Re-decode the configuration into cm.cfg)
-			newDecoderConfig := &mapstructure.DecoderConfig{
-				WeaklyTypedInput: true,
-				TagName:          "mapstructure",
-				Result:           cm.cfg, // Update the existing config object
-				Squash:           true,
-				DecodeHook: mapstructure.ComposeDecodeHookFunc(
-					mapstructure.StringToTimeDurationHookFunc(),
-					mapstructure.StringToSliceHookFunc(","),
-				),
-			}
-			newDecoder, errDecoder := mapstructure.NewDecoder(newDecoderConfig)
-			if errDecoder != nil {
-				log.Printf("Error creating decoder during hot reload: %v", errDecoder)
-				return // Skip notifying callbacks on decoder error
-			}
+	// 7. 校验配置，失败时列出所有无效字段 (Validate the configuration, listing every invalid field on failure)
+	if err := validateConfig(cm.cfg); err != nil {
+		return "", nil, err
+	}
+	return configFileUsed, settings, nil
+}
 
-			if errUnmarshal := newDecoder.Decode(cm.v.AllSettings()); errUnmarshal != nil {
-				log.Printf("Error re-unmarshalling config during hot reload: %v", errUnmarshal)
-				return // Skip notifying callbacks on unmarshal error
-			}
+// applySettings 将 Viper 中的当前设置（包括通过 Set 设置的值）解码到配置的副本，校验通过后替换 cm.cfg、
+// 记录变化集合并通知回调。调用方需持有 reloadMux。
+// (applySettings decodes the current settings held by Viper, including values set with Set, into a copy of the configuration;
+// once it validates it replaces cm.cfg, records the change set and notifies the callbacks. The caller holds reloadMux.)
+func (cm *configManager[T]) applySettings() error {
+	// 解码到当前配置的副本，校验通过后才替换 cm.cfg；ZeroFields 使指针、映射和切片重新分配，不会修改当前配置
+	// (Decode into a copy of the current configuration and replace cm.cfg only once it validates;
+	// ZeroFields makes pointers, maps and slices freshly allocated so the current configuration is never modified)
+	next := *cm.cfg
+	var metadata mapstructure.Metadata
+	newDecoderConfig := &mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		TagName:          "mapstructure",
+		Result:           &next,
+		Squash:           true,
+		ZeroFields:       true,
+		Metadata:         &metadata,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+	}
+	newDecoder, err := mapstructure.NewDecoder(newDecoderConfig)
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to create mapstructure decoder"), lmccerrors.ErrConfigSetup)
+	}
 
-			// 在热重载解码后应用默认值 (Apply defaults after hot reload decoding)
-			// 使用改进版本的函数，它能够区分显式设置的值和真正的零值
-			// (Use improved version of the function that can distinguish explicitly set values from true zero values)
-			// 重新构建配置文件键映射 (Rebuild config file keys map)
-			hotReloadKeysFromConfigFile := flattenViperKeys(cm.v.AllSettings())
-			if errApplyDefaults := applyDefaultsToZeroFieldsWithViper(cm.cfg, cm.v, hotReloadKeysFromConfigFile); errApplyDefaults != nil {
-				log.Printf("Error applying defaults to zero fields during hot reload: %v", errApplyDefaults)
-				// Decide if we should skip callbacks or proceed. For now, proceed.
-			}
+	settings, err := cm.resolvedSettings()
+	if err != nil {
+		return err
+	}
+	if err := newDecoder.Decode(settings); err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to unmarshal config from mapstructure"), lmccerrors.ErrConfigSetup)
+	}
+	if err := cm.checkUnknownKeys(metadata.Unused); err != nil {
+		return err
+	}
 
-			log.Println("Config reloaded successfully.")
-			// 调用 accessors.go 中的 updateGlobalCfg (Call updateGlobalCfg from accessors.go)
-			updateGlobalCfg(cm.cfg)
+	// 重新构建配置文件键映射后应用默认值，使显式设置的零值得以保留
+	// (Rebuild the config file keys map before applying defaults so explicitly set zero values are kept)
+	if err := applyDefaultsToZeroFieldsWithViper(&next, cm.v, flattenViperKeys(settings)); err != nil {
+		log.Printf("Error applying defaults to zero fields during hot reload: %v", err)
+	}
 
-			// 通知所有注册的回调 (Notify all registered callbacks)
-			cm.notifyCallbacks() // notifyCallbacks is defined in manager.go
-		})
-		log.Printf("Hot reload enabled for config file: %s", configFileUsed)
-	} else if cm.options.enableHotReload {
-		log.Println("Warning: Hot reload enabled but no config file was used, watcher not started.")
+	// 无效的新配置不会被应用，保留当前配置 (An invalid new configuration is not applied; the current one is kept)
+	if err := validateConfig(&next); err != nil {
+		return err
 	}
+	var previous map[string]any
+	if snapshot := cm.settings.Load(); snapshot != nil {
+		previous = *snapshot
+	}
+	changes := diffSettings(previous, settings)
+	*cm.cfg = next
+	cm.storeSettings(settings)
+	cm.storeSnapshot(next)
+	cm.lastChanges.Store(&changes)
 
-	// 首次加载后更新全局 Cfg 变量 (Update the global Cfg variable after initial load)
+	log.Printf("Config reloaded successfully, %d key(s) changed.", len(changes))
 	// 调用 accessors.go 中的 updateGlobalCfg (Call updateGlobalCfg from accessors.go)
 	updateGlobalCfg(cm.cfg)
 
-	return cm, nil
+	// 通知所有注册的回调 (Notify all registered callbacks)
+	cm.notifyCallbacks() // notifyCallbacks is defined in manager.go
+	return nil
 }
 
 // LoadConfig 是一个简化的包装器，用于加载配置（不带热重载监控）。
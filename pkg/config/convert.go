@@ -0,0 +1,195 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Convert 读取 inPath 处任一受支持格式的配置文件，并按 outPath 扩展名对应的格式（YAML、JSON 或 TOML）写出，
+// 例如将一批 JSON 配置迁移为 YAML。格式由文件名推断。YAML 和 JSON 之间的转换保留键的顺序，YAML 输入的注释在 YAML
+// 输出中保留；TOML 输出和来自 TOML、INI、.env 的输入按键排序。文件内容原样转换，不展开环境变量、引入文件或密钥引用。
+// 输出文件以原子替换的方式写入。
+// (Convert reads the config file at inPath in any supported format and writes it to outPath in the format of its extension,
+// YAML, JSON or TOML, e.g. to migrate a fleet of JSON configs to YAML. Formats are inferred from the file names. Conversions
+// between YAML and JSON keep the key order, and YAML comments survive in YAML output; TOML output and input from TOML, INI
+// and .env files are ordered by key. The content is converted as is, without expanding environment variables, includes or
+// secret references. The output file is replaced atomically.)
+//
+// Returns:
+//   error: 格式不受支持或无法读取输入时返回带 ErrConfigFileRead 的错误，无法转换或写入时返回带 ErrConfigSetup 的错误。
+//          (An error coded ErrConfigFileRead when a format is unsupported or the input cannot be read, and coded
+//          ErrConfigSetup when the content cannot be converted or written.)
+func Convert(inPath, outPath string) error {
+	inType, outType := detectFileType(inPath), detectFileType(outPath)
+	if err := checkFileType(inPath, inType); err != nil {
+		return err
+	}
+	if inType == "" {
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigFileRead, "cannot infer the format of config file '%s'", inPath)
+	}
+	data, err := os.ReadFile(inPath)
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to read config file '%s'", inPath), lmccerrors.ErrConfigFileRead)
+	}
+	doc, err := decodeDocument(data, inType)
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to parse config file '%s'", inPath), lmccerrors.ErrConfigFileRead)
+	}
+
+	var out []byte
+	switch outType {
+	case "yaml", "yml":
+		if inType == "json" {
+			// JSON 解析为流式风格的 YAML 节点，输出为块风格 (JSON parses into flow-style YAML nodes; write block style instead)
+			clearNodeStyle(doc)
+		}
+		out, err = encodeYAMLNode(doc)
+	case "json":
+		out, err = encodeJSONNode(doc)
+	case "toml":
+		var settings map[string]any
+		if err = doc.Decode(&settings); err == nil {
+			out, err = toml.Marshal(settings)
+		}
+	default:
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigSetup, "converting config files to type '%s' is not supported", outType)
+	}
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to convert config file '%s' to %s", inPath, outType), lmccerrors.ErrConfigSetup)
+	}
+	if err := writeFileAtomic(outPath, out); err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to write config file '%s'", outPath), lmccerrors.ErrConfigSetup)
+	}
+	return nil
+}
+
+// decodeDocument 将配置文件解析为 YAML 节点树。YAML 和 JSON 直接解析以保留键的顺序，其他格式经 Viper 的解码器解析。
+// (decodeDocument parses a config file into a YAML node tree. YAML and JSON are parsed directly to keep the key order; other
+// formats go through Viper's decoders.)
+func decodeDocument(data []byte, fileType string) (*yaml.Node, error) {
+	var doc yaml.Node
+	switch fileType {
+	case "yaml", "yml", "json":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	default:
+		decoder, err := codecRegistry.Decoder(fileType)
+		if err != nil {
+			return nil, err
+		}
+		settings := make(map[string]any)
+		if err := decoder.Decode(data, settings); err != nil {
+			return nil, err
+		}
+		if err := doc.Encode(settings); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Kind == 0 {
+		// 空文件 (Empty file)
+		doc = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	return &doc, nil
+}
+
+// clearNodeStyle 递归清除节点的风格，使编码器选择块风格和必要的引号。
+// (clearNodeStyle recursively clears the style of the nodes so the encoder picks block style and only the quotes needed.)
+func clearNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearNodeStyle(child)
+	}
+}
+
+// encodeYAMLNode 以两个空格缩进编码节点树。(encodeYAMLNode encodes the node tree with two-space indentation.)
+func encodeYAMLNode(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeJSONNode 按节点树中键的顺序编码缩进的 JSON。(encodeJSONNode encodes indented JSON in the key order of the node tree.)
+func encodeJSONNode(doc *yaml.Node) ([]byte, error) {
+	var compact bytes.Buffer
+	if err := writeJSONNode(&compact, doc); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// writeJSONNode 将节点写为紧凑的 JSON。(writeJSONNode writes the node as compact JSON.)
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSONNode(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSONNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONNode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
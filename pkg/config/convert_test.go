@@ -0,0 +1,85 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for converting config files between formats.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConvert tests converting between YAML, JSON and TOML, keeping the key order between YAML and JSON.
+// (TestConvert 测试 YAML、JSON 和 TOML 之间的转换，以及 YAML 与 JSON 之间保留键的顺序。)
+func TestConvert(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := writeConfigFile(t, dir, "app.json", `{
+  "server": {"port": 8080, "host": "0.0.0.0"},
+  "name": "true",
+  "tags": ["a", "b"]
+}`)
+
+	yamlPath := filepath.Join(dir, "app.yaml")
+	require.NoError(t, Convert(jsonPath, yamlPath))
+	data, err := os.ReadFile(yamlPath)
+	require.NoError(t, err)
+	assert.Equal(t, `server:
+  port: 8080
+  host: 0.0.0.0
+name: "true"
+tags:
+  - a
+  - b
+`, string(data))
+
+	// YAML 的注释在转换为 JSON 时丢弃，键的顺序保留 (YAML comments are dropped in JSON, the key order is kept)
+	commented := writeConfigFile(t, dir, "commented.yml", "# service\nzone: eu\nlimits:\n  max: 10 # per second\n  burst: 2.5\n")
+	outJSON := filepath.Join(dir, "commented.json")
+	require.NoError(t, Convert(commented, outJSON))
+	data, err = os.ReadFile(outJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"zone\": \"eu\",\n  \"limits\": {\n    \"max\": 10,\n    \"burst\": 2.5\n  }\n}\n", string(data))
+
+	tomlPath := filepath.Join(dir, "app.toml")
+	require.NoError(t, Convert(yamlPath, tomlPath))
+	backToYAML := filepath.Join(dir, "roundtrip.yaml")
+	require.NoError(t, Convert(tomlPath, backToYAML))
+
+	var cfg struct {
+		Server struct {
+			Host string `mapstructure:"host"`
+			Port int    `mapstructure:"port"`
+		} `mapstructure:"server"`
+		Name string   `mapstructure:"name"`
+		Tags []string `mapstructure:"tags"`
+	}
+	require.NoError(t, LoadConfig(&cfg, WithConfigFile(backToYAML, "")))
+	assert.Equal(t, "0.0.0.0", cfg.Server.Host)
+	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, "true", cfg.Name)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+}
+
+// TestConvert_Errors tests missing inputs and unsupported formats.
+// (TestConvert_Errors 测试缺失的输入文件和不受支持的格式。)
+func TestConvert_Errors(t *testing.T) {
+	dir := t.TempDir()
+	in := writeConfigFile(t, dir, "app.yaml", "a: 1\n")
+
+	err := Convert(filepath.Join(dir, "missing.yaml"), filepath.Join(dir, "out.json"))
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+
+	err = Convert(writeConfigFile(t, dir, "app.xml", "<a/>"), filepath.Join(dir, "out.json"))
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+
+	err = Convert(in, filepath.Join(dir, "out.ini"))
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+	assert.NoFileExists(t, filepath.Join(dir, "out.ini"))
+}
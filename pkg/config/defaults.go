@@ -7,6 +7,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"reflect"
@@ -139,13 +140,17 @@ func setDefaultsFromTags(v *viper.Viper, config interface{}, keyPrefix string) e
 				return err // Propagate error
 			}
 		} else if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+			// 为带有默认值的 nil 结构体指针分配内存，使其默认值同样生效
+			// (Allocate nil struct pointers that carry defaults so those defaults apply as well)
+			if fieldVal.IsNil() && fieldVal.CanSet() && hasDefaultsDefined(field.Type.Elem()) {
+				fieldVal.Set(reflect.New(field.Type.Elem()))
+			}
 			// Recurse into struct pointer type (递归指针类型结构体)
 			if !fieldVal.IsNil() {
 				if err := setDefaultsFromTags(v, fieldVal.Interface(), fullKey); err != nil {
 					return err // Propagate error
 				}
-			} // Do not create a tempInstance and recurse if fieldVal is nil, as this would set defaults for a non-existent path in Viper.
-			  // (如果 fieldVal 为 nil，则不创建 tempInstance 并递归，因为这会在 Viper 中为不存在的路径设置默认值。)
+			}
 		}
 
 		// Set the default value in Viper if tag exists and key is not already set
@@ -169,9 +174,11 @@ func setDefaultsFromTags(v *viper.Viper, config interface{}, keyPrefix string) e
 }
 
 // parseStringToType 将字符串值 `value` 解析为 `targetType` 指定的 Go 类型。
-// 支持基本类型 (string, int*, uint*, float*, bool), time.Duration, 以及 string 切片 (逗号或空格分隔)。
+// 支持基本类型 (string, int*, uint*, float*, bool), time.Duration, string 切片 (逗号或空格分隔)，
+// 以及以 JSON 字面量表示的映射，例如 `default:"{\"k\":\"v\"}"`。
 // (parseStringToType parses the string `value` into the Go type specified by `targetType`.)
-// (Supports basic types (string, int*, uint*, float*, bool), time.Duration, and string slices (comma or space separated).)
+// (Supports basic types (string, int*, uint*, float*, bool), time.Duration, string slices (comma or space separated),
+// and maps written as JSON literals, e.g. `default:"{\"k\":\"v\"}"`.)
 // Parameters:
 //   value: 要解析的字符串值。
 //          (The string value to parse.)
@@ -280,6 +287,20 @@ func parseStringToType(value string, targetType reflect.Type) (interface{}, erro
 			return parts, nil
 		}
 		return nil, lmccerrors.NewWithCode(lmccerrors.ErrConfigDefaultTagParse, fmt.Sprintf("unsupported slice type for default tag: %s. Only []string is supported.", targetType.Elem().Kind()))
+	case reflect.Map:
+		// 映射的默认值使用 JSON 字面量 (Map defaults are written as JSON literals)
+		m := reflect.New(targetType)
+		if err := json.Unmarshal([]byte(value), m.Interface()); err != nil {
+			return nil, lmccerrors.WithCode(
+				lmccerrors.Wrapf(err, "invalid JSON map literal '%s'", value),
+				lmccerrors.ErrConfigDefaultTagParse,
+			)
+		}
+		if m.Elem().IsNil() {
+			// JSON null 视为空映射 (JSON null is taken as an empty map)
+			m.Elem().Set(reflect.MakeMap(targetType))
+		}
+		return m.Elem().Interface(), nil
 	default:
 		return nil, lmccerrors.NewWithCode(lmccerrors.ErrConfigDefaultTagParse, fmt.Sprintf("unsupported type for default tag: %s", kind))
 	}
@@ -636,3 +657,62 @@ func (l *DefaultConfigLoader) Load(v *viper.Viper) error {
 func (l *DefaultConfigLoader) Name() string {
 	return "DefaultTagLoader"
 }
+
+// setDefaultsFromProvider 让 provider 填充一个新的零值配置，并将其中的非零字段设置为 Viper 默认值。
+// (setDefaultsFromProvider lets provider fill in a new zero configuration and sets its non-zero fields as Viper defaults.)
+// Parameters:
+//   v: 要设置默认值的 Viper 实例。
+//      (The Viper instance to set defaults on.)
+//   config: 指向配置结构体的指针，仅用于确定类型。
+//           (A pointer to the configuration struct, only used for its type.)
+//   provider: 填充默认值的函数。
+//             (The function filling in the defaults.)
+// Returns:
+//   error: provider 返回的错误，带 ErrConfigSetup 错误码。
+//          (The error returned by provider, coded ErrConfigSetup.)
+func setDefaultsFromProvider(v *viper.Viper, config interface{}, provider func(target any) error) error {
+	target := reflect.New(reflect.TypeOf(config).Elem())
+	initializeNilPointers(target.Interface())
+	if err := provider(target.Interface()); err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "defaults provider failed"), lmccerrors.ErrConfigSetup)
+	}
+	setDefaultsFromValue(v, target.Elem(), "")
+	return nil
+}
+
+// setDefaultsFromValue 递归地将结构体值中的非零叶子字段设置为 Viper 默认值，键的构建方式与 setDefaultsFromTags 相同。
+// (setDefaultsFromValue recursively sets the non-zero leaf fields of a struct value as Viper defaults, building keys the
+// same way as setDefaultsFromTags.)
+func setDefaultsFromValue(v *viper.Viper, val reflect.Value, keyPrefix string) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldVal := val.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		mapKey := strings.TrimSuffix(field.Tag.Get("mapstructure"), ",omitempty")
+		if mapKey == "-" {
+			continue
+		}
+		// 不带标签的嵌入字段与外层共享键前缀 (Embedded fields without a tag share the key prefix of the outer struct)
+		fullKey := keyPrefix
+		if mapKey != "" || !field.Anonymous {
+			if mapKey == "" {
+				mapKey = field.Name
+			}
+			fullKey = joinKey(keyPrefix, mapKey)
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			setDefaultsFromValue(v, fieldVal, fullKey)
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct:
+			if !fieldVal.IsNil() {
+				setDefaultsFromValue(v, fieldVal.Elem(), fullKey)
+			}
+		case !fieldVal.IsZero():
+			v.SetDefault(fullKey, fieldVal.Interface())
+		}
+	}
+}
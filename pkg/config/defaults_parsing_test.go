@@ -51,6 +51,10 @@ func TestParseStringToType(t *testing.T) {
 		{"InvalidBool", "maybe", reflect.TypeOf(false), nil, true},
 		{"InvalidDuration", "xyz", reflect.TypeOf(time.Duration(0)), nil, true},
 		{"UnsupportedSlice", "1,2,3", reflect.TypeOf([]int{}), nil, true}, // Only string slices supported for default tags
+		{"MapJSON", `{"k":"v"}`, reflect.TypeOf(map[string]string{}), map[string]string{"k": "v"}, false},
+		{"MapJSONInt", `{"a":1}`, reflect.TypeOf(map[string]int{}), map[string]int{"a": 1}, false},
+		{"MapJSONNull", "null", reflect.TypeOf(map[string]int{}), map[string]int{}, false},
+		{"InvalidMapJSON", "k=v", reflect.TypeOf(map[string]string{}), nil, true},
 		{"UnsupportedType", "{}", reflect.TypeOf(struct{}{}), nil, true},
 	}
 
@@ -256,18 +260,55 @@ func TestSetDefaultsFromTags_EdgeCases(t *testing.T) {
 	assert.Equal(t, "nested_keep", v.GetString("prefix.NestedVal.keep"))
 	assert.False(t, v.IsSet("prefix.NestedVal.SkipMe"))
 
-	// Check for NestedPtr - if it was nil, its defaults shouldn't be set
-	assert.False(t, v.IsSet("prefix.NestedPtr.keep"), "Defaults for nil NestedPtr should not be set")
+	// Nil struct pointers carrying defaults are allocated and their defaults set
+	// (带有默认值的 nil 结构体指针会被分配，其默认值也会被设置)
+	require.NotNil(t, cfg.NestedPtr, "NestedPtr should be allocated")
+	require.NotNil(t, cfg.NilPtr, "NilPtr should be allocated")
+	assert.Equal(t, "nested_keep", v.GetString("prefix.NestedPtr.keep"))
+	assert.Equal(t, "nested_keep", v.GetString("prefix.NilPtr.keep"))
 
-	// Check for NilPtr - if it was nil, its defaults shouldn't be set
-	assert.False(t, v.IsSet("prefix.NilPtr.keep"), "Defaults for nil NilPtr should not be set")
+	// Struct pointers without any defaults stay nil (没有任何默认值的结构体指针保持为 nil)
+	type NoDefaults struct {
+		Value string `mapstructure:"value"`
+	}
+	type WithOptional struct {
+		Optional *NoDefaults `mapstructure:"optional"`
+	}
+	optional := &WithOptional{}
+	require.NoError(t, setDefaultsFromTags(viper.New(), optional, ""))
+	assert.Nil(t, optional.Optional)
+}
 
-	// Initialize NestedPtr and re-run to check its defaults
-	cfg.NestedPtr = &NestedWithSkip{}
-	v2 := viper.New()
-	err = setDefaultsFromTags(v2, cfg, "prefix2") // Use a new viper instance and prefix
-	require.NoError(t, err)
-	assert.Equal(t, "nested_keep", v2.GetString("prefix2.NestedPtr.keep"))
+// TestSetDefaultsFromTags_NestedPointersAndMaps tests defaults inside nested nil struct pointers and JSON map defaults.
+// (TestSetDefaultsFromTags_NestedPointersAndMaps 测试嵌套的 nil 结构体指针中的默认值以及 JSON 映射默认值。)
+func TestSetDefaultsFromTags_NestedPointersAndMaps(t *testing.T) {
+	type Pool struct {
+		Size   int               `mapstructure:"size" default:"10"`
+		Labels map[string]string `mapstructure:"labels" default:"{\"tier\":\"db\",\"zone\":\"a\"}"`
+	}
+	type Database struct {
+		Host string `mapstructure:"host" default:"localhost"`
+		Pool *Pool  `mapstructure:"pool"`
+	}
+	type AppConfig struct {
+		Database *Database     `mapstructure:"database"`
+		Weights  map[string]int `mapstructure:"weights" default:"{\"a\":1,\"b\":2}"`
+	}
+
+	var cfg AppConfig
+	require.NoError(t, LoadConfig(&cfg))
+	require.NotNil(t, cfg.Database)
+	require.NotNil(t, cfg.Database.Pool)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+	assert.Equal(t, 10, cfg.Database.Pool.Size)
+	assert.Equal(t, map[string]string{"tier": "db", "zone": "a"}, cfg.Database.Pool.Labels)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, cfg.Weights)
+
+	// 配置文件中的映射覆盖默认值中的同名键 (Map entries from the config file override the default entries with the same key)
+	path := writeConfigFile(t, t.TempDir(), "config.yaml", "weights:\n  a: 5\n")
+	var fromFile AppConfig
+	require.NoError(t, LoadConfig(&fromFile, WithConfigFile(path, "")))
+	assert.Equal(t, 5, fromFile.Weights["a"])
 }
 
 func TestSetDefaultsFromTags_ErrorCase(t *testing.T) {
@@ -0,0 +1,70 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for defaults computed by a provider function.
+ */
+
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerPoolConfig struct {
+	Size    int           `mapstructure:"size" default:"4"`
+	Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+}
+
+type providerConfig struct {
+	Host  string              `mapstructure:"host" default:"localhost"`
+	Zone  string              `mapstructure:"zone"`
+	Debug bool                `mapstructure:"debug" default:"true"`
+	Pool  *providerPoolConfig `mapstructure:"pool"`
+	Tags  []string            `mapstructure:"tags"`
+}
+
+// TestWithDefaultsProvider tests that provided defaults override tags and are overridden by files and environment variables.
+// (TestWithDefaultsProvider 测试提供的默认值覆盖标签默认值，并被配置文件和环境变量覆盖。)
+func TestWithDefaultsProvider(t *testing.T) {
+	provider := func(target any) error {
+		cfg := target.(*providerConfig)
+		cfg.Host = "node-7"
+		cfg.Zone = "eu-1"
+		cfg.Pool.Size = 32
+		cfg.Tags = []string{"computed"}
+		return nil
+	}
+
+	var cfg providerConfig
+	require.NoError(t, LoadConfig(&cfg, WithDefaultsProvider(provider)))
+	assert.Equal(t, "node-7", cfg.Host, "provided defaults override tags")
+	assert.Equal(t, "eu-1", cfg.Zone)
+	assert.Equal(t, 32, cfg.Pool.Size)
+	assert.Equal(t, 5*time.Second, cfg.Pool.Timeout, "tag defaults apply to fields the provider leaves zero")
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"computed"}, cfg.Tags)
+
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", "pool:\n  size: 8\n")
+	t.Setenv("PROVTEST_ZONE", "us-2")
+	cfg = providerConfig{}
+	require.NoError(t, LoadConfig(&cfg, WithConfigFile(path, ""), WithEnvPrefix("PROVTEST"), WithDefaultsProvider(provider)))
+	assert.Equal(t, 8, cfg.Pool.Size, "config files override provided defaults")
+	assert.Equal(t, "us-2", cfg.Zone, "environment variables override provided defaults")
+	assert.Equal(t, "node-7", cfg.Host)
+}
+
+// TestWithDefaultsProvider_Error tests that a failing provider fails the load.
+// (TestWithDefaultsProvider_Error 测试提供函数失败时加载失败。)
+func TestWithDefaultsProvider_Error(t *testing.T) {
+	var cfg providerConfig
+	err := LoadConfig(&cfg, WithDefaultsProvider(func(any) error { return errors.New("no hostname") }))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+	assert.Contains(t, err.Error(), "no hostname")
+}
@@ -0,0 +1,147 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// WarningLogger 接收配置包的结构化警告，例如已弃用的键。pkg/log 的 Logger（例如 log.Std()）满足该接口。
+// (WarningLogger receives the structured warnings of the config package, such as deprecated keys. A pkg/log Logger,
+// e.g. log.Std(), satisfies it.)
+type WarningLogger interface {
+	Warnw(msg string, keysAndValues ...any)
+}
+
+// deprecatedField 描述一个带 `deprecated` 标签的配置字段。(deprecatedField describes a configuration field with a `deprecated` tag.)
+type deprecatedField struct {
+	key         string // Viper 键，例如 "server.listen_port" (The Viper key, e.g. "server.listen_port")
+	message     string // `deprecated` 标签的内容 (The content of the `deprecated` tag)
+	replacement string // `replacement` 标签给出的新键，可为空 (The new key given by the `replacement` tag, may be empty)
+}
+
+// collectDeprecatedFields 递归收集带 `deprecated` 标签的字段，键的构造方式与 bindEnvs 相同。
+// (collectDeprecatedFields recursively collects the fields with a `deprecated` tag, building keys the same way as bindEnvs.)
+func collectDeprecatedFields(typ reflect.Type, parts []string) []deprecatedField {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []deprecatedField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := fieldKey(field)
+		if tag == "-" {
+			continue
+		}
+		currentParts := append(parts[:len(parts):len(parts)], tag)
+		if field.Anonymous {
+			// 嵌入的结构体被压平，使用父级路径 (Embedded structs are squashed, so they use the parent path)
+			currentParts = parts
+		}
+
+		if message, ok := field.Tag.Lookup("deprecated"); ok && !field.Anonymous {
+			fields = append(fields, deprecatedField{
+				key:         strings.ToLower(strings.Join(currentParts, ".")),
+				message:     message,
+				replacement: strings.ToLower(field.Tag.Get("replacement")),
+			})
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType != durationType {
+			fields = append(fields, collectDeprecatedFields(fieldType, currentParts)...)
+		}
+	}
+	return fields
+}
+
+// applyDeprecations 为配置源中出现的每个已弃用键记录一条警告；字段带 `replacement` 标签且新键未在配置源中设置时，
+// 将旧值复制到 settings 中的新键。
+// (applyDeprecations logs a warning for every deprecated key present in the config sources; when the field has a
+// `replacement` tag and the new key is not set in the sources, the old value is copied to the new key in settings.)
+func (cm *configManager[T]) applyDeprecations(settings map[string]any) {
+	for _, f := range collectDeprecatedFields(reflect.TypeOf(cm.cfg), nil) {
+		if !cm.explicitlySet(f.key) {
+			continue
+		}
+		migrated := false
+		if f.replacement != "" && !cm.explicitlySet(f.replacement) {
+			if value, ok := nestedValue(settings, f.key); ok {
+				setNestedValue(settings, strings.Split(f.replacement, "."), value)
+				migrated = true
+			}
+		}
+		keysAndValues := []any{"key", f.key, "reason", f.message}
+		if f.replacement != "" {
+			keysAndValues = append(keysAndValues, "replacement", f.replacement, "migrated", migrated)
+		}
+		cm.warnw("Deprecated config key is set", keysAndValues...)
+	}
+}
+
+// explicitlySet 报告 key 是否由配置文件、远程文档、环境变量、显式设置的命令行标志或 Set 设置，不包括 `default` 标签。
+// (explicitlySet reports whether key is set by a config file, the remote document, an environment variable, a flag set
+// explicitly or Set, leaving `default` tags aside.)
+func (cm *configManager[T]) explicitlySet(key string) bool {
+	if cm.v.InConfig(key) {
+		return true
+	}
+	if _, ok := cm.overrides[key]; ok {
+		return true
+	}
+	if cm.options.enableEnvVarOverride {
+		if _, ok := os.LookupEnv(cm.envVarName(key)); ok {
+			return true
+		}
+	}
+	if fs := cm.options.flagSet; fs != nil {
+		for _, f := range collectFlagFields(reflect.TypeOf(cm.cfg), nil) {
+			if f.key == key && fs.Changed(f.name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// envVarName 返回覆盖 key 的环境变量名，例如前缀为 LMCC 时 "server.port" 对应 LMCC_SERVER_PORT。
+// (envVarName returns the environment variable overriding key, e.g. LMCC_SERVER_PORT for "server.port" with the LMCC prefix.)
+func (cm *configManager[T]) envVarName(key string) string {
+	name := strings.NewReplacer(".", "_", "-", "_").Replace(key)
+	if cm.options.envPrefix != "" {
+		name = cm.options.envPrefix + "_" + name
+	}
+	return strings.ToUpper(name)
+}
+
+// warnw 将警告写入 WithWarningLogger 设置的 Logger，未设置时写入标准库日志。
+// (warnw writes a warning to the Logger set with WithWarningLogger, or to the standard library logger when none is set.)
+func (cm *configManager[T]) warnw(msg string, keysAndValues ...any) {
+	if cm.options.warningLogger != nil {
+		cm.options.warningLogger.Warnw(msg, keysAndValues...)
+		return
+	}
+	var b strings.Builder
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, " %v=%q", keysAndValues[i], fmt.Sprint(keysAndValues[i+1]))
+	}
+	log.Printf("Warning: %s.%s", msg, b.String())
+}
@@ -0,0 +1,89 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for deprecated configuration keys.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingWarningLogger 记录收到的警告。(recordingWarningLogger records the warnings it receives.)
+type recordingWarningLogger struct {
+	warnings []map[string]any
+}
+
+func (l *recordingWarningLogger) Warnw(msg string, keysAndValues ...any) {
+	entry := map[string]any{"msg": msg}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		entry[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	l.warnings = append(l.warnings, entry)
+}
+
+type deprecatedServerConfig struct {
+	Port       int    `mapstructure:"port" default:"8080"`
+	ListenPort int    `mapstructure:"listen_port" deprecated:"use server.port instead" replacement:"server.port"`
+	Legacy     string `mapstructure:"legacy" deprecated:"no longer used"`
+}
+
+type deprecatedConfig struct {
+	Server deprecatedServerConfig `mapstructure:"server"`
+}
+
+// TestDeprecatedKeys tests that deprecated keys found in files or environment variables are reported and migrated.
+// (TestDeprecatedKeys 测试文件或环境变量中的已弃用键会被报告并迁移。)
+func TestDeprecatedKeys(t *testing.T) {
+	t.Run("migrates to the replacement", func(t *testing.T) {
+		path := writeConfigFile(t, t.TempDir(), "app.yaml", "server:\n  listen_port: 9000\n")
+		logger := &recordingWarningLogger{}
+		var cfg deprecatedConfig
+		require.NoError(t, LoadConfig(&cfg, WithConfigFile(path, ""), WithWarningLogger(logger)))
+		assert.Equal(t, 9000, cfg.Server.Port)
+		require.Len(t, logger.warnings, 1)
+		assert.Equal(t, map[string]any{
+			"msg":         "Deprecated config key is set",
+			"key":         "server.listen_port",
+			"reason":      "use server.port instead",
+			"replacement": "server.port",
+			"migrated":    true,
+		}, logger.warnings[0])
+	})
+
+	t.Run("replacement set explicitly wins", func(t *testing.T) {
+		path := writeConfigFile(t, t.TempDir(), "app.yaml", "server:\n  listen_port: 9000\n  port: 9100\n")
+		logger := &recordingWarningLogger{}
+		var cfg deprecatedConfig
+		require.NoError(t, LoadConfig(&cfg, WithConfigFile(path, ""), WithWarningLogger(logger)))
+		assert.Equal(t, 9100, cfg.Server.Port)
+		require.Len(t, logger.warnings, 1)
+		assert.Equal(t, false, logger.warnings[0]["migrated"])
+	})
+
+	t.Run("environment variables and keys without replacement", func(t *testing.T) {
+		t.Setenv("DEPRECATED_SERVER_LEGACY", "x")
+		logger := &recordingWarningLogger{}
+		var cfg deprecatedConfig
+		require.NoError(t, LoadConfig(&cfg, WithEnvPrefix("DEPRECATED"), WithWarningLogger(logger)))
+		assert.Equal(t, 8080, cfg.Server.Port)
+		require.Len(t, logger.warnings, 1)
+		assert.Equal(t, map[string]any{
+			"msg":    "Deprecated config key is set",
+			"key":    "server.legacy",
+			"reason": "no longer used",
+		}, logger.warnings[0])
+	})
+
+	t.Run("absent keys are silent", func(t *testing.T) {
+		path := writeConfigFile(t, t.TempDir(), "app.yaml", "server:\n  port: 9100\n")
+		logger := &recordingWarningLogger{}
+		var cfg deprecatedConfig
+		require.NoError(t, LoadConfig(&cfg, WithConfigFile(path, ""), WithWarningLogger(logger)))
+		assert.Empty(t, logger.warnings)
+	})
+}
@@ -9,9 +9,9 @@ Package config provides flexible and robust configuration management capabilitie
 inspired by best practices seen in ecosystems like Marmotedu.
 (config 包为 Go 应用程序提供了灵活且健壮的配置管理功能，其设计借鉴了 Marmotedu 等生态系统中的最佳实践。)
 
-It leverages the Viper library for handling various configuration sources such as files (YAML, JSON, TOML, etc.),
+It leverages the Viper library for handling various configuration sources such as files (YAML, JSON, TOML, INI and .env),
 environment variables, command-line flags, and default values defined via struct tags.
-(它利用 Viper 库来处理各种配置源，例如文件（YAML、JSON、TOML 等）、环境变量、命令行标志以及通过结构体标签定义的默认值。)
+(它利用 Viper 库来处理各种配置源，例如文件（YAML、JSON、TOML、INI 和 .env）、环境变量、命令行标志以及通过结构体标签定义的默认值。)
 
 Key features include:
 (主要功能包括：)
@@ -19,8 +19,14 @@ Key features include:
   - Loading configuration from multiple sources with a clear precedence order.
     (从多个来源加载配置，具有明确的优先级顺序。)
   - Setting default values using struct field tags (`default:"value"`), with proper handling
-    to ensure config file values are not overridden by defaults.
-    (使用结构体字段标签 (`default:"value"`) 设置默认值，并正确处理以确保配置文件的值不会被默认值覆盖。)
+    to ensure config file values are not overridden by defaults. Map fields take a JSON object literal
+    (`default:"{\"k\":\"v\"}"`), and nil struct pointers are allocated when their fields carry defaults.
+    (使用结构体字段标签 (`default:"value"`) 设置默认值，并正确处理以确保配置文件的值不会被默认值覆盖。
+    映射字段使用 JSON 对象字面量，字段带有默认值的 nil 结构体指针会被自动分配。)
+  - Computed defaults, such as the hostname or pool sizes derived from the CPU count, from a function passed to
+    WithDefaultsProvider; they override tag defaults and are overridden by every other source.
+    (通过 WithDefaultsProvider 传入的函数以代码计算默认值，例如主机名或根据 CPU 数量计算的连接池大小；
+    它们覆盖标签默认值，并被其他所有来源覆盖。)
   - Automatic binding of environment variables to struct fields (respecting prefixes and `mapstructure` tags).
     (自动将环境变量绑定到结构体字段（遵循前缀和 `mapstructure` 标签）。)
   - Optional hot-reloading of configuration files upon changes, allowing dynamic reconfiguration.
@@ -105,6 +111,31 @@ Basic Usage:
 		log.Printf("Server configuration changed")
 		return nil
 	})
+	// Section callbacks only run when a key in their section changed. cm.LastChangeSet() lists the keys changed
+	// by the reload with their old and new values. All callbacks run one at a time in registration order;
+	// WithCallbackTimeout bounds each of them and WithReloadDebounce coalesces bursts of file writes into one reload.
+	// (节回调只在该节中的键发生变化时运行。cm.LastChangeSet() 列出本次重载变化的键及其新旧值。所有回调按注册顺序逐个执行；
+	// WithCallbackTimeout 限制每个回调的执行时间，WithReloadDebounce 将一连串文件写入合并为一次重载。)
+	// config.Watch subscribes to one section with typed old and new values; dbCfg is updated once the callback returns nil.
+	// (config.Watch 以强类型的新旧值订阅一个配置节；回调返回 nil 后 dbCfg 才会更新。)
+	var dbCfg config.DatabaseConfig
+	_ = config.Watch(cm, "database", &dbCfg, func(old, new config.DatabaseConfig) error {
+		if old.MaxOpenConns != new.MaxOpenConns {
+			// Resize the pool (调整连接池大小)
+		}
+		return nil
+	})
+	cm.RegisterCallback(func(v *viper.Viper, currentCfg any) error {
+		if cm.LastChangeSet().Changed("database") {
+			// Re-initialize the database pool only when database.* changed (仅当 database.* 变化时重建连接池)
+		}
+		return nil
+	})
+	// cfg is overwritten in place on reload; goroutines that read it concurrently use config.SnapshotOf, a copy
+	// that is never modified, with a version incremented by every reload.
+	// (cfg 在重载时被原地覆盖；并发读取的 goroutine 使用 config.SnapshotOf，它返回不再修改的副本，以及每次重载都会递增的版本号。)
+	current, version := config.SnapshotOf[MyConfig](cm)
+	fmt.Println("Snapshot version:", version, "port:", current.Server.Port)
 
 
 	// Access configuration values (访问配置值)
@@ -137,5 +168,325 @@ For applications that don't need hot-reload functionality, you can use the simpl
 	// Configuration is now loaded and ready to use
 	// (配置现已加载并可以使用)
 	fmt.Printf("Server will run on %s:%d\n", cfg.Server.Host, cfg.Server.Port)
+
+Multiple Configuration Files:
+(多配置文件：)
+
+WithConfigFiles deep-merges several files in order. Precedence from lowest to highest is:
+struct `default` tags, the file given to WithConfigFile, the WithConfigFiles entries in order,
+then environment variables. Nested maps are merged key by key; scalars and lists are replaced
+as a whole. With hot reload enabled every file is watched, and a change to any of them
+reloads the whole stack.
+(WithConfigFiles 按顺序深度合并多个文件。优先级从低到高为：结构体 `default` 标签、WithConfigFile 指定的文件、
+按顺序排列的 WithConfigFiles 文件，最后是环境变量。嵌套映射逐键合并，标量和列表整体替换。
+启用热重载时会监视所有文件，任一文件变化都会重新加载整组配置。)
+
+	cm, err := config.LoadConfigAndWatch(
+		&cfg,
+		config.WithConfigFiles("config/base.yaml", "config/override.dev.yaml"),
+		config.WithHotReload(true),
+	)
+
+Readers and Embedded Configs:
+(Reader 与嵌入的配置：)
+
+WithConfigReader reads configuration from an io.Reader in the given format, merged after the config
+files, and LoadFromBytes loads a byte slice, so configs embedded with go:embed and documents in tests
+need no files on disk.
+(WithConfigReader 从 io.Reader 读取指定格式的配置，在配置文件之后合并；LoadFromBytes 加载字节切片，
+因此通过 go:embed 嵌入的配置和测试中的文档无需写入磁盘。)
+
+	//go:embed defaults.yaml
+	var defaultsYAML []byte
+
+	err := config.LoadFromBytes(&cfg, defaultsYAML, "yaml", config.WithEnvPrefix("APP"))
+
+File Formats:
+(文件格式：)
+
+YAML, JSON, TOML, INI and dotenv files are supported and detected from the file name, with .env
+and .env.local being dotenv; pass the type to WithConfigFile for other names. INI sections such as
+[server.tls] become nested keys, and dotenv variables are named like the environment variables
+overriding the same keys (APP_SERVER_PORT for server.port with the APP prefix). All formats share
+the same precedence, so a legacy INI file can be merged with newer YAML files during a migration.
+(支持 YAML、JSON、TOML、INI 和 dotenv 文件，并由文件名识别格式，.env 和 .env.local 为 dotenv；其他名称请将类型传给
+WithConfigFile。INI 配置节如 [server.tls] 转换为嵌套键，dotenv 变量的命名与覆盖同一键的环境变量相同（前缀为 APP 时
+server.port 对应 APP_SERVER_PORT）。所有格式的优先级相同，因此迁移期间可以将旧的 INI 文件与新的 YAML 文件合并。)
+
+	config.LoadConfig(&cfg, config.WithConfigFiles("legacy.ini", "config.yaml", ".env.local"))
+
+Include Files:
+(引入文件：)
+
+A `$include` key (IncludeDirective) splits a large file per concern. Its value is a path or a
+list of paths, resolved relative to the file containing it; the included documents are merged
+into the map holding the directive, and the other keys of that map take precedence. Included
+files may include further files, cycles are an error, and with hot reload enabled every included
+file is watched along with the config files.
+(`$include` 键（IncludeDirective）用于按关注点拆分大型配置文件。其值为一个路径或路径列表，相对于包含它的文件解析；
+引入的文档合并到指令所在的映射中，该映射中的其他键优先。被引入的文件可以继续引入其他文件，循环引入会报错；
+启用热重载时所有被引入的文件与配置文件一同被监视。)
+
+	# config.yaml
+	$include: [database.yaml]
+	log:
+	  $include: logging.yaml
+	  level: warn # overrides the level in logging.yaml
+
+Profiles:
+(环境 Profile：)
+
+WithProfile("production") merges config.production.yaml right after config.yaml, and likewise
+after every WithConfigFiles entry; missing variants are skipped. The APP_PROFILE environment
+variable overrides the name and also works without the option. Precedence from lowest to highest:
+struct defaults, config.yaml, config.production.yaml, later files and their variants, the remote
+document, environment variables, command-line flags. Per-environment differences then live in
+small override files instead of conditional blocks in code.
+(WithProfile("production") 在 config.yaml 之后立即合并 config.production.yaml，WithConfigFiles 的每个文件同理；
+不存在的变体被跳过。环境变量 APP_PROFILE 覆盖该名称，未使用此选项时同样生效。优先级从低到高为：结构体默认值、
+config.yaml、config.production.yaml、后续文件及其变体、远程文档、环境变量、命令行标志。
+各环境的差异因此放在小的覆盖文件中，无需在代码中编写条件分支。)
+
+	err := config.LoadConfig(
+		&cfg,
+		config.WithConfigFile("config/config.yaml", ""),
+		config.WithProfile("production"), // APP_PROFILE=staging takes precedence
+	)
+
+Runtime Changes:
+(运行时修改：)
+
+cm.Set("log.level", "debug") validates and applies a value at runtime and runs the callbacks;
+set values win over every other source until cm.Save() writes them back to the highest-precedence
+config file. YAML comments and key order are kept; JSON and TOML files are reformatted.
+(cm.Set("log.level", "debug") 在运行时校验并应用一个值，然后执行回调；设置的值优先于其他所有来源，
+直到 cm.Save() 将其写回优先级最高的配置文件。YAML 的注释和键的顺序会被保留；JSON 和 TOML 文件会重新格式化。)
+
+Remote Providers:
+(远程配置提供者：)
+
+WithRemoteProvider loads a document stored under a key in etcd (through the v3 JSON gateway)
+or Consul KV. It is merged after every file and below environment variables; the format is
+inferred from the key's extension (YAML by default). With hot reload enabled the key is watched,
+using blocking queries for Consul and polling for etcd, and changes run the same callbacks as
+file changes.
+(WithRemoteProvider 从 etcd（通过 v3 JSON 网关）或 Consul KV 的键中加载配置文档，在所有文件之后、环境变量之前合并；
+格式由键的扩展名推断（默认 YAML）。启用热重载时会监视该键：Consul 使用阻塞查询，etcd 定期轮询，变化时触发与文件变化相同的回调。)
+
+	cm, err := config.LoadConfigAndWatch(
+		&cfg,
+		config.WithConfigFile("config/base.yaml", ""),
+		config.WithRemoteProvider("consul", "http://consul.service:8500", "config/orders/app.yaml"),
+		config.WithHotReload(true),
+	)
+
+Kubernetes:
+(Kubernetes：)
+
+WithKubernetesProjectedFile loads a file projected from a ConfigMap or Secret volume. Kubernetes
+updates it by atomically swapping the "..data" symlink, so with hot reload enabled the parent
+directory is watched and the file is reloaded when its content changes. WithKubernetesConfigMap
+reads a ConfigMap through the in-cluster API with the pod's service account instead, merging it
+like a remote provider and waiting for changes with watch requests.
+(WithKubernetesProjectedFile 加载由 ConfigMap 或 Secret 卷投射的文件。Kubernetes 通过原子替换 "..data" 符号链接更新它，
+因此启用热重载时会监视其所在目录，并在文件内容变化时重新加载。WithKubernetesConfigMap 则使用 Pod 的服务账号通过集群内 API
+读取 ConfigMap，按远程提供者的方式合并，并通过 watch 请求等待变化。)
+
+	cm, err := config.LoadConfigAndWatch(
+		&cfg,
+		config.WithKubernetesProjectedFile("/etc/app/config.yaml", ""),
+		config.WithHotReload(true),
+	)
+
+	cm, err := config.LoadConfigAndWatch(&cfg, config.WithKubernetesConfigMap("", "orders-config", "app.yaml"), config.WithHotReload(true))
+
+Typed Accessors:
+(类型化访问：)
+
+The Manager also answers ad-hoc lookups by dotted key without unmarshalling the whole struct.
+Values come from the latest successful load or hot reload and may be read concurrently with reloads;
+a missing or unconvertible key returns the optional default.
+(Manager 还支持按点分隔的键进行临时查找，无需解组整个结构体。值来自最近一次成功的加载或热重载，可与热重载并发读取；
+键不存在或无法转换时返回可选的默认值。)
+
+	host := cm.GetString("server.host")
+	timeout := cm.GetDuration("client.timeout", 5*time.Second)
+	peers := cm.GetStringSlice("cluster.peers")
+
+Environment Variables Inside Values:
+(值中的环境变量：)
+
+String values may contain ${VAR} and ${VAR:-default}, expanded from the environment on load and on
+every hot reload, so configuration files no longer need envsubst. Unset variables expand to an empty
+string and the default applies when the variable is unset or empty. WithEnvExpansion(false) keeps
+values literal.
+(字符串值可以包含 ${VAR} 和 ${VAR:-default}，在加载和每次热重载时从环境变量展开，配置文件无需再经 envsubst 处理。
+未设置的变量展开为空字符串，变量未设置或为空时使用默认值。WithEnvExpansion(false) 保留值的原文。)
+
+	// database.url: "postgres://${DB_USER}:${DB_PASS}@${DB_HOST:-db}:5432/app"
+
+Secret References:
+(密钥引用：)
+
+String values may contain ${env:NAME}, ${file:/path} or ${secret:<resolver>:<ref>} instead of the
+secret itself. References are resolved on load and on every hot reload, and the results reach only
+the config struct and the typed accessors, never Viper. Resolvers are registered with
+WithSecretResolver, and every pkg/secrets Provider (Vault, AWS, GCP, ...) can be one. An unresolvable reference fails loading
+with ErrConfigSecret, and during hot reload the previous configuration is kept.
+(字符串值可以使用 ${env:NAME}、${file:/path} 或 ${secret:<resolver>:<ref>} 代替密钥本身。引用在加载和每次热重载时解析，
+结果只写入配置结构体和类型化访问方法，不会写入 Viper。解析器通过 WithSecretResolver 注册，pkg/secrets 中的任意 Provider（Vault、AWS、GCP 等）均可作为解析器。
+无法解析的引用会使加载失败并返回 ErrConfigSecret，热重载时则保留之前的配置。)
+
+	// database.password: "${secret:vault:kv/data/db#password}"
+	cm, err := config.LoadConfigAndWatch(&cfg,
+		config.WithConfigFile("config.yaml", ""),
+		config.WithSecretResolver("vault", secrets.NewVaultProvider()), // VAULT_ADDR, VAULT_TOKEN
+	)
+
+Command-Line Flags:
+(命令行标志：)
+
+Fields tagged `flag:"name"` (optionally `flag:"name,p"` with a shorthand and `usage:"..."` for the
+help text) are bound to the flags of the FlagSet passed to WithFlagSet. A flag set on the command line
+takes precedence over environment variables, config files and defaults, in that order. Call
+RegisterFlags before parsing so the flags show up in the help output.
+(带 `flag:"name"` 标签的字段（可用 `flag:"name,p"` 指定简写，用 `usage:"..."` 指定帮助文本）会绑定到传给 WithFlagSet 的
+FlagSet 中的标志。命令行显式设置的标志优先于环境变量、配置文件和默认值。请在解析前调用 RegisterFlags，使标志出现在帮助信息中。)
+
+	// Port int `mapstructure:"port" default:"8080" flag:"server-port,p" usage:"Port to listen on"`
+	fs := pflag.NewFlagSet("app", pflag.ExitOnError)
+	if err := config.RegisterFlags(fs, &cfg); err != nil {
+		return err
+	}
+	fs.Parse(os.Args[1:])
+	cm, err := config.LoadConfigAndWatch(&cfg,
+		config.WithConfigFile("config.yaml", ""),
+		config.WithFlagSet(fs),
+	)
+
+Deprecated Keys:
+(已弃用的键：)
+
+A field tagged `deprecated:"use server.port instead"` logs a structured warning whenever its key is set
+by a config source, environment variable or flag. With `replacement:"server.port"` the old value is
+also copied to the new key unless that key is set too, keeping services working while keys are
+renamed. Warnings go to the Logger set with WithWarningLogger, such as log.Std().
+(带 `deprecated:"use server.port instead"` 标签的字段，其键由配置源、环境变量或命令行标志设置时会记录结构化警告。
+带 `replacement:"server.port"` 时，旧值还会复制到新键（除非新键也已设置），使服务在键重命名期间保持正常工作。
+警告写入 WithWarningLogger 设置的 Logger，例如 log.Std()。)
+
+	ListenPort int `mapstructure:"listen_port" deprecated:"use server.port instead" replacement:"server.port"`
+
+	err := config.LoadConfig(&cfg,
+		config.WithConfigFile("config.yaml", ""),
+		config.WithWarningLogger(log.Std()),
+	)
+
+Validation:
+(校验：)
+
+After decoding and applying defaults, fields are checked against their `validate` tags
+(go-playground/validator rules such as required, min, max, oneof), and then the config struct's
+Validate() error method is called if it implements Validator. Loading fails with a single
+ErrConfigValidation error that lists every invalid field by its mapstructure path. During hot
+reload an invalid config is not applied: the previous one is kept, change callbacks are not run,
+and the function set by WithOnValidationError receives the error.
+(解码并应用默认值后，先按字段的 `validate` 标签（go-playground/validator 规则，例如 required、min、max、oneof）校验，
+如果配置结构体实现了 Validator，再调用其 Validate() error 方法。加载失败时返回一个 ErrConfigValidation 错误，按 mapstructure 路径列出所有无效字段。
+热重载时无效的配置不会被应用：保留之前的配置、不执行变更回调，并将错误交给 WithOnValidationError 设置的函数。)
+
+	type ServerConfig struct {
+		Host string `mapstructure:"host" validate:"required"`
+		Port int    `mapstructure:"port" validate:"min=1,max=65535"`
+	}
+
+	cm, err := config.LoadConfigAndWatch(&cfg,
+		config.WithConfigFile("config.yaml", ""),
+		config.WithHotReload(true),
+		config.WithOnValidationError(func(err error) {
+			log.Printf("rejected config change: %v", err)
+		}),
+	)
+
+Strict Unmarshal:
+(严格解码：)
+
+By default keys in the config sources that match no field are ignored, so a typo in a production
+YAML file passes silently. WithStrictUnmarshal makes them an ErrConfigValidation error listing every
+unknown key by path, like Viper's ErrorUnused; during hot reload such a config is not applied. Map
+fields and `mapstructure:",remain"` fields accept any key.
+(默认情况下，配置源中没有对应字段的键会被忽略，生产环境 YAML 文件中的拼写错误因此不会被发现。WithStrictUnmarshal
+使其成为 ErrConfigValidation 错误，按路径列出每个未知键，相当于 Viper 的 ErrorUnused；热重载时这样的配置不会被应用。
+映射类型的字段和 `mapstructure:",remain"` 字段接受任意键。)
+
+	err := config.LoadConfig(&cfg,
+		config.WithConfigFile("config.yaml", ""),
+		config.WithStrictUnmarshal(),
+	) // config validation failed: unknown key 'server.prot'
+
+Dry-Run Validation:
+(试运行校验：)
+
+Validate loads a file exactly like LoadConfig, including defaults, environment overrides and
+validation, but starts no watcher and leaves the global configuration untouched. Every decoding or
+validation failure is prefixed with the file line or environment variable that set it, which makes
+it suitable for a `myapp config validate` subcommand or a CI step.
+(Validate 与 LoadConfig 一样加载文件，包括默认值、环境变量覆盖和校验，但不启动监控，也不修改全局配置。
+每个解码或校验失败都以设置它的文件行或环境变量作为前缀，适合用于 `myapp config validate` 子命令或 CI 步骤。)
+
+	if err := config.Validate("config.yaml", &AppConfig{}); err != nil {
+		var eg *errors.ErrorGroup
+		if errors.As(err, &eg) {
+			for _, e := range eg.Errors() {
+				fmt.Fprintln(os.Stderr, e) // config.yaml:12: field 'server.port' failed rule 'max=65535'
+			}
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+
+JSON Schema:
+(JSON Schema：)
+
+GenerateSchema describes the files accepted for a config struct as a JSON Schema, which editors use
+for autocompletion and CI can use to check YAML files before they are deployed. Property names come
+from the mapstructure tags, and the default, usage and validate tags become defaults, descriptions
+and constraints.
+(GenerateSchema 将配置结构体所接受的文件描述为 JSON Schema，编辑器可用于自动补全，CI 可在部署前用于检查 YAML 文件。
+属性名来自 mapstructure 标签，default、usage 和 validate 标签分别转换为默认值、描述和约束。)
+
+	data, err := config.GenerateSchema(&AppConfig{})
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile("config.schema.json", data, 0o644)
+
+Example Files:
+(示例文件：)
+
+WriteExample writes a commented YAML or TOML skeleton of a config struct, for a `myapp config init`
+subcommand. Keys come from the mapstructure tags, values from the struct or its default tags, and
+comments from the desc tags (or usage without one).
+(WriteExample 写出配置结构体带注释的 YAML 或 TOML 骨架，可用于 `myapp config init` 子命令。键来自 mapstructure 标签，
+值来自结构体或其 default 标签，注释来自 desc 标签（没有时取 usage）。)
+
+	type ServerConfig struct {
+		Port int `mapstructure:"port" default:"8080" desc:"HTTP listen port"`
+	}
+	err := config.WriteExample(&AppConfig{}, "config.yaml", "")
+	// server:
+	//   # HTTP listen port
+	//   port: 8080
+
+Format Conversion:
+(格式转换：)
+
+Convert rewrites a config file in another format, inferred from the extensions, for a
+`myapp config convert` subcommand. YAML and JSON conversions keep the key order; TOML output is
+ordered by key.
+(Convert 以另一种格式重写配置文件，格式由扩展名推断，可用于 `myapp config convert` 子命令。YAML 与 JSON 之间的转换保留键的顺序；TOML 输出按键排序。)
+
+	err = config.Convert("config.json", "config.yaml")
 */
 package config
\ No newline at end of file
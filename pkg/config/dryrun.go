@@ -0,0 +1,255 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v3"
+)
+
+// Validate 对配置做一次试运行：读取 path（以及 opts 中的其他配置源）、应用默认值、环境变量覆盖和校验规则，
+// 但不启动监控，也不更新全局配置，适合 `myapp config validate` 子命令或 CI 步骤。path 为空时只使用 opts 中的配置源。
+// 解码或校验失败时返回一个 ErrorGroup，其中每个错误在能够定位时以来源作为前缀，例如 "app.yaml:12: " 或
+// "env LMCC_SERVER_PORT: "；文件语法错误原样返回，其中已包含行号。
+// (Validate performs a dry run of the configuration: it reads path (and any other source in opts), applies defaults,
+// environment overrides and the validation rules, but starts no watcher and leaves the global configuration untouched,
+// which suits a `myapp config validate` subcommand or a CI step. With an empty path only the sources in opts are used.
+// When decoding or validation fails it returns an ErrorGroup in which every error that can be located is prefixed with
+// its source, such as "app.yaml:12: " or "env LMCC_SERVER_PORT: "; file syntax errors are returned as they are, already
+// carrying a line number.)
+//
+// Parameters:
+//   path:   要校验的配置文件，文件类型由扩展名推断。
+//           (The configuration file to validate; the file type is inferred from its extension.)
+//   target: 接收解码结果的配置结构体指针，与 LoadConfig 使用的类型相同。
+//           (A pointer to the configuration struct receiving the result, the same type as used with LoadConfig.)
+//   opts:   与 LoadConfig 相同的选项，WithHotReload 被忽略。
+//           (The same options as LoadConfig; WithHotReload is ignored.)
+//
+// Returns:
+//   error: 配置有效时为 nil；否则为带 ErrConfigValidation、ErrConfigSetup 或 ErrConfigFileRead 的错误。
+//          (nil when the configuration is valid; otherwise an error coded ErrConfigValidation, ErrConfigSetup or ErrConfigFileRead.)
+func Validate[T any](path string, target *T, opts ...Option) error {
+	if path != "" {
+		opts = append([]Option{WithConfigFile(path, "")}, opts...)
+	}
+	cm := newConfigManager(target, opts...)
+	cm.options.enableHotReload = false
+	if _, _, err := cm.load(); err != nil {
+		return cm.annotate(err)
+	}
+	return nil
+}
+
+// annotate 为解码和校验错误中的每一项加上其来源；其他错误原样返回。
+// (annotate prefixes every entry of a decoding or validation error with its source; other errors are returned unchanged.)
+func (cm *configManager[T]) annotate(err error) error {
+	var group *lmccerrors.ErrorGroup
+	if lmccerrors.IsCode(err, lmccerrors.ErrConfigValidation) && errors.As(err, &group) {
+		locations := cm.keyLocations()
+		annotated := lmccerrors.NewErrorGroup("config validation failed")
+		for _, e := range group.Errors() {
+			var path string
+			var fe *fieldError
+			var uk *unknownKeyError
+			switch {
+			case errors.As(e, &fe):
+				path = fe.path
+			case errors.As(e, &uk):
+				path = uk.path
+			}
+			if source := cm.keySource(path, locations); source != "" {
+				e = fmt.Errorf("%s: %w", source, e)
+			}
+			annotated.Add(e)
+		}
+		return lmccerrors.WithCode(annotated, lmccerrors.ErrConfigValidation)
+	}
+
+	var decodeErr *mapstructure.Error
+	if errors.As(err, &decodeErr) {
+		locations := cm.keyLocations()
+		annotated := lmccerrors.NewErrorGroup("config decoding failed")
+		for _, msg := range decodeErr.Errors {
+			if source := cm.keySource(quotedKey(msg), locations); source != "" {
+				msg = source + ": " + msg
+			}
+			annotated.Add(errors.New(msg))
+		}
+		return lmccerrors.WithCode(annotated, lmccerrors.ErrConfigSetup)
+	}
+	return err
+}
+
+// keySource 返回设置 key 的来源：环境变量优先，其次是定义该键或其最近的父键的文件行。无法定位时返回空字符串。
+// (keySource returns where key is set: an environment variable first, then the file line defining the key or its nearest
+// parent. It returns an empty string when the key cannot be located.)
+func (cm *configManager[T]) keySource(key string, locations map[string]string) string {
+	key = strings.ToLower(key)
+	if key == "" {
+		return ""
+	}
+	if cm.options.enableEnvVarOverride {
+		name := cm.envVarName(key)
+		if !strings.Contains(name, "[") {
+			if _, ok := os.LookupEnv(name); ok {
+				return "env " + name
+			}
+		}
+	}
+	for key != "" {
+		if location, ok := locations[key]; ok {
+			return location
+		}
+		key = key[:max(strings.LastIndexAny(key, ".["), 0)]
+	}
+	return ""
+}
+
+// keyLocations 返回配置文件中每个键（小写，例如 "servers[0].port"）所在的 "file:line"。
+// 按合并顺序扫描，后面的文件覆盖前面的；无法解析的文件被跳过。
+// (keyLocations returns the "file:line" of every key in the config files, lower-cased like "servers[0].port". Files are
+// scanned in merge order so later files override earlier ones; files that cannot be parsed are skipped.)
+func (cm *configManager[T]) keyLocations() map[string]string {
+	locations := map[string]string{}
+	files := append(cm.includedFiles(), cm.options.configFilePaths()...)
+	for _, path := range files {
+		lines := map[string]int{}
+		switch cm.options.fileType(path) {
+		case "yaml", "yml", "json":
+			lines = yamlKeyLines(path)
+		case "toml", "ini":
+			lines = tomlKeyLines(path)
+		case "env":
+			lines = cm.dotenvKeyLines(path)
+		}
+		for key, line := range lines {
+			locations[key] = path + ":" + strconv.Itoa(line)
+		}
+	}
+	return locations
+}
+
+// yamlKeyLines 返回 YAML 或 JSON 文件中每个键的行号。(yamlKeyLines returns the line of every key in a YAML or JSON file.)
+func yamlKeyLines(path string) map[string]int {
+	lines := map[string]int{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return lines
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return lines
+	}
+	var walk func(node *yaml.Node, prefix string)
+	walk = func(node *yaml.Node, prefix string) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := joinKey(prefix, strings.ToLower(node.Content[i].Value))
+				lines[key] = node.Content[i].Line
+				walk(node.Content[i+1], key)
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				key := fmt.Sprintf("%s[%d]", prefix, i)
+				lines[key] = item.Line
+				walk(item, key)
+			}
+		}
+	}
+	walk(doc.Content[0], "")
+	return lines
+}
+
+// tomlKeyLines 逐行扫描 TOML 或 INI 文件，返回每个表和键的行号。(tomlKeyLines scans a TOML or INI file line by line, returning the line of every table and key.)
+func tomlKeyLines(path string) map[string]int {
+	lines := map[string]int{}
+	f, err := os.Open(path)
+	if err != nil {
+		return lines
+	}
+	defer f.Close()
+
+	table := ""
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "["):
+			end := strings.Index(line, "]")
+			if end < 0 {
+				continue
+			}
+			table = tomlKey(strings.Trim(line[:end], "[]"))
+			lines[table] = n
+		default:
+			if i := strings.IndexAny(line, "=:"); i > 0 {
+				lines[joinKey(table, tomlKey(line[:i]))] = n
+			}
+		}
+	}
+	return lines
+}
+
+// dotenvKeyLines 返回 .env 文件中每个变量所对应配置键的行号。(dotenvKeyLines returns the line of the variable setting each configuration key in a .env file.)
+func (cm *configManager[T]) dotenvKeyLines(path string) map[string]int {
+	lines := map[string]int{}
+	f, err := os.Open(path)
+	if err != nil {
+		return lines
+	}
+	defer f.Close()
+
+	variables := map[string]int{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "export ")
+		if name, _, ok := strings.Cut(line, "="); ok && !strings.HasPrefix(line, "#") {
+			variables[strings.ToUpper(strings.TrimSpace(name))] = n
+		}
+	}
+	for _, key := range collectKeys(reflect.TypeOf(cm.cfg), nil) {
+		if n, ok := variables[cm.envVarName(key)]; ok {
+			lines[key] = n
+		}
+	}
+	return lines
+}
+
+// tomlKey 将 TOML 中可能带引号的点分键规范化为小写路径。(tomlKey normalizes a possibly quoted dotted TOML key into a lower-case path.)
+func tomlKey(name string) string {
+	parts := strings.Split(strings.TrimSpace(name), ".")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.Trim(strings.TrimSpace(part), `"'`))
+	}
+	return strings.Join(parts, ".")
+}
+
+// quotedKey 返回 mapstructure 错误消息中第一个单引号括起的键，例如 "'server.port' expected type 'int'" 中的 "server.port"。
+// (quotedKey returns the first single-quoted key in a mapstructure error message, e.g. "server.port" in "'server.port' expected type 'int'".)
+func quotedKey(msg string) string {
+	start := strings.Index(msg, "'")
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(msg[start+1:], "'")
+	if end < 0 {
+		return ""
+	}
+	return msg[start+1 : start+1+end]
+}
@@ -0,0 +1,69 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for the dry-run configuration validation.
+ */
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidate tests that every failure is reported with the file line or environment variable setting it.
+// (TestValidate 测试每个失败都附带设置它的文件行或环境变量。)
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	path := writeConfigFile(t, dir, "app.yaml", `listener:
+  port: 70000
+mode: staging
+minConns: 20
+`)
+	override := writeConfigFile(t, dir, "override.toml", `[listener]
+port = 80000
+`)
+	t.Setenv("DRYRUN_MODE", "qa")
+
+	var cfg validatedConfig
+	err := Validate(path, &cfg, WithConfigFiles(override), WithEnvPrefix("DRYRUN"), WithHotReload(true))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigValidation))
+
+	var eg *lmccerrors.ErrorGroup
+	require.True(t, errors.As(err, &eg))
+	var messages []string
+	for _, e := range eg.Errors() {
+		messages = append(messages, e.Error())
+	}
+	assert.ElementsMatch(t, []string{
+		override + ":1: field 'listener.host' failed rule 'required'",
+		override + ":2: field 'listener.port' failed rule 'max=65535'",
+		"env DRYRUN_MODE: field 'mode' failed rule 'oneof=dev prod'",
+		"minConns must not exceed maxConns",
+	}, messages)
+
+	valid := writeConfigFile(t, dir, "valid.json", `{"listener": {"host": "localhost", "port": 8080}}`)
+	require.NoError(t, Validate(valid, &validatedConfig{}, WithEnvVarOverride(false)))
+}
+
+// TestValidate_DecodeAndSyntaxErrors tests that decoding errors are annotated and syntax errors keep their line numbers.
+// (TestValidate_DecodeAndSyntaxErrors 测试解码错误附带来源，语法错误保留其行号。)
+func TestValidate_DecodeAndSyntaxErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := writeConfigFile(t, dir, "app.yaml", "listener:\n  host: localhost\n  port: not-a-port\n")
+	err := Validate(path, &validatedConfig{}, WithEnvVarOverride(false))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+	assert.Contains(t, err.Error(), path+":3: cannot parse 'listener.port' as int")
+
+	broken := writeConfigFile(t, dir, "broken.yaml", "listener:\n  host: [localhost\n")
+	err = Validate(broken, &validatedConfig{})
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+	assert.Contains(t, err.Error(), "line")
+}
@@ -0,0 +1,358 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// WriteExample 根据配置结构体生成带注释的示例配置文件并写入 path，可用于 "config init" 这类命令，免去手工维护模板。
+// 键名取自 mapstructure 标签，嵌入或带 squash 的结构体会被展开，带 `deprecated` 标签的字段被省略。每个字段的值取自 cfg，
+// 字段为零值时使用其 `default` 标签；字段的注释取自 `desc` 标签，没有时取 `usage` 标签。time.Duration 写为 "30s" 这样的字符串。
+// 文件以原子替换的方式写入，已存在的文件会被覆盖。
+// (WriteExample generates a commented example configuration file from a configuration struct and writes it to path, for
+// commands such as "config init" that would otherwise maintain a template by hand. Key names come from mapstructure tags,
+// embedded or squashed structs are flattened and fields tagged `deprecated` are left out. The value of each field comes from
+// cfg, or from its `default` tag when the field is zero; its comment comes from the `desc` tag, or the `usage` tag without one.
+// A time.Duration is written as a string such as "30s". The file is replaced atomically, overwriting an existing file.)
+// Parameters:
+//   cfg: 配置结构体或指向它的指针。
+//        (The configuration struct or a pointer to it.)
+//   path: 要写入的文件路径。
+//         (The path of the file to write.)
+//   format: "yaml"（或 "yml"）或 "toml"；为空时由 path 的扩展名推断。
+//           ("yaml" (or "yml") or "toml"; when empty it is inferred from the extension of path.)
+// Returns:
+//   error: cfg 不是结构体或格式不受支持时返回 ErrConfigSetup 错误，default 标签无效时返回 ErrConfigDefaultTagParse 错误。
+//          (An ErrConfigSetup error when cfg is not a struct or the format is unsupported, or an ErrConfigDefaultTagParse
+//          error for an invalid default tag.)
+func WriteExample(cfg any, path, format string) error {
+	value := reflect.ValueOf(cfg)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigSetup,
+			"cannot generate an example config for %T, expected a struct or a pointer to one", cfg)
+	}
+	if format = normalizeFileType(format); format == "" {
+		format = detectFileType(path)
+	}
+
+	g := &exampleGenerator{visiting: make(map[reflect.Type]bool)}
+	entries, err := g.structEntries(value)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	switch format {
+	case "yaml", "yml":
+		out, err = encodeYAMLExample(entries)
+	case "toml":
+		out, err = encodeTOMLExample(entries)
+	default:
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigSetup, "generating example config files of type '%s' is not supported", format)
+	}
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to encode the example config"), lmccerrors.ErrConfigInternal)
+	}
+	if err := writeFileAtomic(path, out); err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to write config file '%s'", path), lmccerrors.ErrConfigSetup)
+	}
+	return nil
+}
+
+// exampleEntry 是示例配置中的一个键：叶子值、表（结构体）或表数组（结构体切片）。
+// (exampleEntry is one key of the example configuration: a leaf value, a table (struct) or an array of tables (struct slice).)
+type exampleEntry struct {
+	key     string
+	comment string
+	value   any              // 叶子值 (The leaf value)
+	fields  []exampleEntry   // 表的字段 (The fields of a table)
+	items   [][]exampleEntry // 表数组的元素 (The elements of an array of tables)
+	table   bool
+}
+
+// isTableArray 报告条目是否为表数组。(isTableArray reports whether the entry is an array of tables.)
+func (e exampleEntry) isTableArray() bool {
+	return e.items != nil
+}
+
+// exampleGenerator 生成示例条目，visiting 记录正在展开的结构体以截断递归类型。
+// (exampleGenerator builds the example entries; visiting records the structs being expanded so recursive types are cut off.)
+type exampleGenerator struct {
+	visiting map[reflect.Type]bool
+}
+
+// structEntries 生成结构体各字段的条目，嵌入或带 squash 的结构体字段加入同一层级。
+// (structEntries builds the entries of the fields of a struct; fields of embedded or squashed structs join the same level.)
+func (g *exampleGenerator) structEntries(v reflect.Value) ([]exampleEntry, error) {
+	typ := v.Type()
+	g.visiting[typ] = true
+	defer delete(g.visiting, typ)
+
+	var entries []exampleEntry
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, deprecated := field.Tag.Lookup("deprecated"); deprecated {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				fv = reflect.New(fv.Type().Elem())
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && fv.Type() != timeType &&
+			((field.Anonymous && name == "") || slices.Contains(tag[1:], "squash")) {
+			// 解码时被展开的结构体 (Structs squashed when decoding)
+			squashed, err := g.structEntries(fv)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, squashed...)
+			continue
+		}
+		if name == "" {
+			name = fieldKey(field)
+		}
+
+		entry := exampleEntry{key: name, comment: field.Tag.Get("desc")}
+		if entry.comment == "" {
+			entry.comment = field.Tag.Get("usage")
+		}
+		if defaultTag := field.Tag.Get("default"); defaultTag != "" && fv.IsZero() {
+			value, err := parseStringToType(defaultTag, field.Type)
+			if err != nil {
+				return nil, lmccerrors.WithCode(
+					lmccerrors.Wrapf(err, "invalid default tag '%s' for field %s", defaultTag, field.Name),
+					lmccerrors.ErrConfigDefaultTagParse,
+				)
+			}
+			fv = reflect.Indirect(reflect.ValueOf(value))
+		}
+		if err := g.fill(&entry, fv); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// fill 按值的类型设置条目的叶子值、表字段或表数组元素。
+// (fill sets the leaf value, table fields or array of tables elements of the entry depending on the type of the value.)
+func (g *exampleGenerator) fill(entry *exampleEntry, v reflect.Value) error {
+	switch {
+	case v.Type() == durationType:
+		entry.value = time.Duration(v.Int()).String()
+	case v.Type() == timeType:
+		entry.value = v.Interface()
+	case v.Kind() == reflect.Struct:
+		entry.table = true
+		if g.visiting[v.Type()] {
+			// 递归类型在第二层不再展开 (Recursive types are not expanded a second time)
+			return nil
+		}
+		fields, err := g.structEntries(v)
+		if err != nil {
+			return err
+		}
+		entry.fields = fields
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() > 0 && isStructType(v.Type().Elem()):
+		entry.items = make([][]exampleEntry, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := reflect.Indirect(v.Index(i))
+			if !item.IsValid() {
+				item = reflect.New(v.Type().Elem().Elem()).Elem()
+			}
+			fields, err := g.structEntries(item)
+			if err != nil {
+				return err
+			}
+			entry.items = append(entry.items, fields)
+		}
+	case v.Kind() == reflect.Slice && v.IsNil():
+		entry.value = []any{}
+	case v.Kind() == reflect.Map && v.IsNil():
+		entry.value = map[string]any{}
+	case v.Kind() == reflect.Interface && v.IsNil():
+		entry.value = ""
+	default:
+		entry.value = v.Interface()
+	}
+	return nil
+}
+
+// isStructType 报告 typ（或其指向的类型）是否为 time.Time 以外的结构体。
+// (isStructType reports whether typ, or the type it points to, is a struct other than time.Time.)
+func isStructType(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct && typ != timeType
+}
+
+// exampleComment 将注释的每一行加上 "# " 前缀。(exampleComment prefixes every line of a comment with "# ".)
+func exampleComment(comment string) string {
+	if comment == "" {
+		return ""
+	}
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("# "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// encodeYAMLExample 将条目编码为 YAML，注释写在对应键的上方。
+// (encodeYAMLExample encodes the entries as YAML, with each comment above its key.)
+func encodeYAMLExample(entries []exampleEntry) ([]byte, error) {
+	root, err := yamlExampleMapping(entries)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlExampleMapping 生成条目的 YAML 映射节点。(yamlExampleMapping builds the YAML mapping node of the entries.)
+func yamlExampleMapping(entries []exampleEntry) (*yaml.Node, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, e := range entries {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.key, HeadComment: exampleComment(e.comment)}
+		var value *yaml.Node
+		var err error
+		switch {
+		case e.table:
+			value, err = yamlExampleMapping(e.fields)
+		case e.isTableArray():
+			value = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for _, item := range e.items {
+				node, errItem := yamlExampleMapping(item)
+				if errItem != nil {
+					return nil, errItem
+				}
+				value.Content = append(value.Content, node)
+			}
+		default:
+			value = &yaml.Node{}
+			err = value.Encode(e.value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(value.Content) == 0 && (value.Kind == yaml.MappingNode || value.Kind == yaml.SequenceNode) {
+			value.Style = yaml.FlowStyle
+		}
+		mapping.Content = append(mapping.Content, key, value)
+	}
+	return mapping, nil
+}
+
+// bareTOMLKey 匹配无需加引号的 TOML 键。(bareTOMLKey matches TOML keys that need no quotes.)
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// encodeTOMLExample 将条目编码为 TOML，注释写在对应键或表头的上方。TOML 要求表的叶子键写在子表之前，因此叶子键排在前面。
+// (encodeTOMLExample encodes the entries as TOML, with each comment above its key or table header. TOML requires the leaf
+// keys of a table to come before its sub-tables, so leaf keys are written first.)
+func encodeTOMLExample(entries []exampleEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeTOMLTable(&buf, nil, entries); err != nil {
+		return nil, err
+	}
+	return bytes.TrimLeft(buf.Bytes(), "\n"), nil
+}
+
+// writeTOMLTable 写出路径为 path 的表的叶子键和子表。(writeTOMLTable writes the leaf keys and sub-tables of the table at path.)
+func writeTOMLTable(buf *bytes.Buffer, path []string, entries []exampleEntry) error {
+	for _, e := range entries {
+		if e.table || e.isTableArray() {
+			continue
+		}
+		if e.comment != "" {
+			buf.WriteString(exampleComment(e.comment) + "\n")
+		}
+		var line bytes.Buffer
+		enc := toml.NewEncoder(&line)
+		enc.SetTablesInline(true)
+		if err := enc.Encode(map[string]any{e.key: e.value}); err != nil {
+			return err
+		}
+		buf.Write(line.Bytes())
+	}
+	for _, e := range entries {
+		if !e.table && !e.isTableArray() {
+			continue
+		}
+		header := tomlKeyPath(append(path[:len(path):len(path)], e.key))
+		comment := exampleComment(e.comment)
+		if e.table {
+			buf.WriteString("\n")
+			if comment != "" {
+				buf.WriteString(comment + "\n")
+			}
+			buf.WriteString("[" + header + "]\n")
+			if err := writeTOMLTable(buf, append(path[:len(path):len(path)], e.key), e.fields); err != nil {
+				return err
+			}
+			continue
+		}
+		for i, item := range e.items {
+			buf.WriteString("\n")
+			if comment != "" && i == 0 {
+				buf.WriteString(comment + "\n")
+			}
+			buf.WriteString("[[" + header + "]]\n")
+			if err := writeTOMLTable(buf, append(path[:len(path):len(path)], e.key), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tomlKeyPath 将路径各部分连接为 TOML 点分键，必要时为部分加引号。
+// (tomlKeyPath joins the path parts into a dotted TOML key, quoting parts as needed.)
+func tomlKeyPath(path []string) string {
+	parts := make([]string, len(path))
+	for i, part := range path {
+		if bareTOMLKey.MatchString(part) {
+			parts[i] = part
+		} else {
+			parts[i] = strconv.Quote(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
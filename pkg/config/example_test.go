@@ -0,0 +1,109 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for generating an example configuration file from a configuration struct.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exampleServer struct {
+	Host string `mapstructure:"host" desc:"Server host address"`
+	Port int    `mapstructure:"port" default:"8080"`
+}
+
+type exampleWorker struct {
+	Name  string `mapstructure:"name" desc:"Worker name"`
+	Queue string `mapstructure:"queue" default:"default"`
+}
+
+type exampleTestConfig struct {
+	Server  exampleServer     `mapstructure:"server"`
+	Mode    string            `mapstructure:"mode" default:"prod" desc:"Run mode: dev or prod"`
+	Timeout time.Duration     `mapstructure:"timeout" default:"30s" usage:"Request timeout"`
+	Tags    []string          `mapstructure:"tags" default:"a,b"`
+	Labels  map[string]string `mapstructure:"labels"`
+	Workers []exampleWorker   `mapstructure:"workers" desc:"Background workers"`
+	Tree    *schemaNode       `mapstructure:"tree"`
+	OldMode string            `mapstructure:"old-mode" deprecated:"use mode"`
+	Ignore  string            `mapstructure:"-"`
+}
+
+// TestWriteExample_YAML tests that keys, values and comments come from the tags and the values of the struct.
+// (TestWriteExample_YAML 测试键、值和注释取自标签和结构体中的值。)
+func TestWriteExample_YAML(t *testing.T) {
+	cfg := exampleTestConfig{Mode: "dev", Workers: []exampleWorker{{Name: "mailer"}}}
+	cfg.Server.Host = "api.internal"
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, WriteExample(&cfg, path, ""))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "server:\n  # Server host address\n  host: api.internal\n", "values of the struct are kept")
+	assert.Contains(t, content, "  port: 8080\n", "zero fields take their default tag")
+	assert.Contains(t, content, "# Run mode: dev or prod\nmode: dev\n")
+	assert.Contains(t, content, "# Request timeout\ntimeout: 30s\n", "usage is used without desc")
+	assert.Contains(t, content, "tags:\n  - a\n  - b\n")
+	assert.Contains(t, content, "labels: {}\n")
+	assert.Contains(t, content, "# Background workers\nworkers:\n  - # Worker name\n    name: mailer\n    queue: default\n")
+	assert.Contains(t, content, "tree:\n  name: \"\"\n  children: []\n")
+	assert.NotContains(t, content, "old-mode")
+	assert.NotContains(t, content, "Ignore")
+
+	// 生成的文件可以直接加载 (The generated file loads as is)
+	var loaded exampleTestConfig
+	require.NoError(t, LoadConfig(&loaded, WithConfigFile(path, ""), WithEnvVarOverride(false)))
+	assert.Equal(t, "api.internal", loaded.Server.Host)
+	assert.Equal(t, 30*time.Second, loaded.Timeout)
+	assert.Equal(t, []exampleWorker{{Name: "mailer", Queue: "default"}}, loaded.Workers)
+}
+
+// TestWriteExample_TOML tests that leaf keys come before tables and arrays of tables in TOML.
+// (TestWriteExample_TOML 测试 TOML 中叶子键写在表和表数组之前。)
+func TestWriteExample_TOML(t *testing.T) {
+	cfg := exampleTestConfig{Workers: []exampleWorker{{Name: "mailer"}, {Name: "billing", Queue: "slow"}}}
+	path := filepath.Join(t.TempDir(), "app.conf")
+	require.NoError(t, WriteExample(cfg, path, "toml"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "# Run mode: dev or prod\nmode = 'prod'\n# Request timeout\ntimeout = '30s'\n")
+	assert.Contains(t, content, "\n[server]\n# Server host address\nhost = ''\n")
+	assert.Contains(t, content, "\n# Background workers\n[[workers]]\n# Worker name\nname = 'mailer'\nqueue = 'default'\n")
+	assert.Contains(t, content, "\n[[workers]]\n# Worker name\nname = 'billing'\nqueue = 'slow'\n")
+
+	var loaded exampleTestConfig
+	require.NoError(t, LoadConfig(&loaded, WithConfigFile(path, "toml"), WithEnvVarOverride(false)))
+	assert.Equal(t, "prod", loaded.Mode)
+	assert.Len(t, loaded.Workers, 2)
+}
+
+// TestWriteExample_Errors tests unsupported targets and formats.
+// (TestWriteExample_Errors 测试不支持的目标和格式。)
+func TestWriteExample_Errors(t *testing.T) {
+	dir := t.TempDir()
+	err := WriteExample("not a struct", filepath.Join(dir, "app.yaml"), "")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup), "%v", err)
+
+	err = WriteExample(&exampleTestConfig{}, filepath.Join(dir, "app.json"), "")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup), "%v", err)
+	assert.NoFileExists(t, filepath.Join(dir, "app.json"))
+
+	type badDefault struct {
+		Port int `mapstructure:"port" default:"eighty"`
+	}
+	err = WriteExample(&badDefault{}, filepath.Join(dir, "app.yaml"), "")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigDefaultTagParse), "%v", err)
+}
@@ -0,0 +1,196 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// readConfigFiles 按顺序读取所有配置文件：第一个文件替换 Viper 中已有的配置，其余文件依次深度合并。
+// (readConfigFiles reads every configuration file in order: the first replaces the configuration held by Viper
+// and the rest are deep-merged on top of it one by one.)
+func (cm *configManager[T]) readConfigFiles() error {
+	var included []string
+	for i, path := range cm.options.configFilePaths() {
+		cm.v.SetConfigFile(path)
+		fileType := cm.options.fileType(path)
+		if err := checkFileType(path, fileType); err != nil {
+			return err
+		}
+		if fileType != "" {
+			cm.v.SetConfigType(fileType)
+		} else {
+			log.Printf("Warning: Could not infer config type from file extension '%s'...", path)
+		}
+
+		// .env 文件和含有 include 指令的文件先转换为设置映射再合并
+		// (.env files and files with include directives are turned into a settings map first and merged as such)
+		var settings map[string]any
+		if fileType == "env" {
+			var err error
+			if settings, err = cm.readDotenvFile(path); err != nil {
+				return err
+			}
+		} else if data, errRead := os.ReadFile(path); errRead == nil && bytes.Contains(data, []byte(IncludeDirective)) {
+			var files []string
+			var err error
+			if settings, files, err = readIncludingFile(path, fileType); err != nil {
+				return err
+			}
+			included = append(included, files...)
+		}
+		if settings != nil {
+			if i == 0 {
+				// 清空之前读取的配置，使第一个文件与 ReadInConfig 一样替换已有配置
+				// (Clear the previously read configuration so the first file replaces it, as ReadInConfig does)
+				cm.v.SetConfigType("json")
+				if err := cm.v.ReadConfig(strings.NewReader("{}")); err != nil {
+					return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to reset config"), lmccerrors.ErrConfigFileRead)
+				}
+			}
+			if err := cm.v.MergeConfigMap(settings); err != nil {
+				return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to merge config file '%s'", path), lmccerrors.ErrConfigFileRead)
+			}
+			continue
+		}
+
+		var err error
+		if i == 0 {
+			err = cm.v.ReadInConfig()
+		} else {
+			err = cm.v.MergeInConfig()
+		}
+		if err != nil {
+			var configFileNotFoundError viper.ConfigFileNotFoundError
+			if errors.As(err, &configFileNotFoundError) || os.IsNotExist(err) {
+				// 文件未找到，也应该是一个错误，而不仅仅是日志
+				// (File not found should also be an error, not just a log)
+				return lmccerrors.WithCode(
+					lmccerrors.Wrapf(err, "config file '%s' not found", path),
+					lmccerrors.ErrConfigFileRead,
+				)
+			}
+			return lmccerrors.WithCode(
+				lmccerrors.Wrapf(err, "failed to read config file '%s'", path),
+				lmccerrors.ErrConfigFileRead,
+			)
+		}
+	}
+	cm.included.Store(&included)
+	return nil
+}
+
+// watchConfigFiles 监视 paths 返回的配置文件，任一文件变化时调用 onChange。每次事件后重新获取文件列表，
+// 使热重载时新引入的文件同样被监视。与 Viper 一样监视所在目录，以便编辑器或部署工具通过重命名替换文件时也能收到通知。
+// (watchConfigFiles watches the configuration files returned by paths and calls onChange when any of them changes.
+// The file list is fetched again after every event so files newly included by a hot reload are watched as well.
+// Like Viper it watches the parent directories so replacements done by renaming, as editors and deploy tools do, are noticed.)
+func watchConfigFiles(paths func() []string, onChange func(fsnotify.Event)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to create config file watcher"), lmccerrors.ErrConfigSetup)
+	}
+
+	dirs := make(map[string]bool)
+	// watchFiles 返回当前文件集合，并开始监视尚未监视的目录 (watchFiles returns the current file set and starts watching new directories)
+	watchFiles := func() (map[string]bool, error) {
+		files := make(map[string]bool)
+		for _, path := range paths() {
+			abs, errAbs := filepath.Abs(path)
+			if errAbs != nil {
+				abs = filepath.Clean(path)
+			}
+			files[abs] = true
+			dir := filepath.Dir(abs)
+			if dirs[dir] {
+				continue
+			}
+			if errAdd := watcher.Add(dir); errAdd != nil {
+				return files, lmccerrors.WithCode(lmccerrors.Wrapf(errAdd, "failed to watch config directory '%s'", dir), lmccerrors.ErrConfigSetup)
+			}
+			dirs[dir] = true
+		}
+		return files, nil
+	}
+	files, err := watchFiles()
+	if err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if abs, errAbs := filepath.Abs(event.Name); errAbs == nil && files[abs] {
+					onChange(event)
+					var errWatch error
+					if files, errWatch = watchFiles(); errWatch != nil {
+						log.Printf("Error watching config files: %v", errWatch)
+					}
+				}
+			case errWatch, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Error watching config files: %v", errWatch)
+			}
+		}
+	}()
+	return nil
+}
+
+// debounceReload 返回合并连续变更的 reload：每次调用重新开始计时，距最后一次调用 delay 之后才以所有变更来源执行一次 reload。
+// delay 不大于零时直接返回 reload。
+// (debounceReload returns a reload that coalesces successive changes: every call restarts the timer, and reload runs once with
+// every change source after delay has passed since the last call. A delay of zero or less returns reload unchanged.)
+func debounceReload(delay time.Duration, reload func(source string)) func(source string) {
+	if delay <= 0 {
+		return reload
+	}
+	var (
+		mu      sync.Mutex
+		timer   *time.Timer
+		sources []string
+	)
+	fire := func() {
+		mu.Lock()
+		batch := sources
+		sources = nil
+		mu.Unlock()
+		// 已触发的计时器可能已取走后续变更 (A timer that already fired may have taken later changes)
+		if len(batch) > 0 {
+			reload(strings.Join(batch, ", "))
+		}
+	}
+	return func(source string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(delay, fire)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for merging and watching multiple config files.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseConfigContent = `
+server:
+  host: "10.0.0.1"
+  port: 8080
+log:
+  level: "info"
+  format: "json"
+customFeature:
+  apiKey: "base-key"
+  rateLimit: 100
+  enabled: true
+`
+
+// writeConfigFile 在 dir 中写入配置文件并返回其路径。(writeConfigFile writes a config file into dir and returns its path.)
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// TestLoadConfig_MultipleFiles tests deep-merging files in order, including mixed file types.
+// (TestLoadConfig_MultipleFiles 测试按顺序深度合并多个文件，包括混合的文件类型。)
+func TestLoadConfig_MultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "base.yaml", baseConfigContent)
+	override := writeConfigFile(t, dir, "override.dev.yaml", `
+server:
+  port: 9090
+log:
+  level: "debug"
+`)
+	local := writeConfigFile(t, dir, "local.json", `{"customFeature": {"rateLimit": 5}}`)
+
+	var cfg testAppConfig
+	require.NoError(t, LoadConfig(&cfg, WithConfigFile(base, ""), WithConfigFiles(override, local)))
+
+	assert.Equal(t, "10.0.0.1", cfg.Server.Host, "keys only in the base file are kept")
+	assert.Equal(t, 9090, cfg.Server.Port, "override replaces base")
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, "json", cfg.Log.Format)
+	assert.Equal(t, "base-key", cfg.CustomFeature.APIKey)
+	assert.Equal(t, 5, cfg.CustomFeature.RateLimit, "later files take precedence")
+	assert.True(t, cfg.CustomFeature.Enabled)
+	assert.Equal(t, "5s", cfg.Server.ReadTimeout.String(), "struct defaults still fill missing keys")
+
+	// 只使用 WithConfigFiles 时第一个文件作为基础 (With only WithConfigFiles the first file is the base)
+	var onlyFiles testAppConfig
+	require.NoError(t, LoadConfig(&onlyFiles, WithConfigFiles(base, override)))
+	assert.Equal(t, 9090, onlyFiles.Server.Port)
+	assert.Equal(t, "10.0.0.1", onlyFiles.Server.Host)
+
+	var missing testAppConfig
+	err := LoadConfig(&missing, WithConfigFiles(base, filepath.Join(dir, "absent.yaml")))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+	assert.Contains(t, err.Error(), "absent.yaml")
+}
+
+// TestLoadConfigAndWatch_MultipleFiles tests that a change to any merged file triggers a reload of the whole stack.
+// (TestLoadConfigAndWatch_MultipleFiles 测试任一合并文件变化都会重新加载整个文件组。)
+func TestLoadConfigAndWatch_MultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "base.yaml", baseConfigContent)
+	override := writeConfigFile(t, dir, "override.yaml", "server:\n  port: 9090\n")
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFiles(base, override), WithHotReload(true))
+	require.NoError(t, err)
+	require.Equal(t, 9090, cfg.Server.Port)
+
+	reloaded := make(chan *testAppConfig, 4)
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		select {
+		case reloaded <- c.(*testAppConfig):
+		default:
+		}
+		return nil
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	// 以重命名方式原子替换文件，避免读到截断后的空文件 (Replace the file atomically by renaming so a truncated file is never read)
+	tmp := writeConfigFile(t, t.TempDir(), "override.yaml", "server:\n  port: 9191\nlog:\n  level: \"warn\"\n")
+	require.NoError(t, os.Rename(tmp, override))
+	select {
+	case got := <-reloaded:
+		assert.Equal(t, 9191, got.Server.Port)
+		assert.Equal(t, "warn", got.Log.Level)
+		assert.Equal(t, "10.0.0.1", got.Server.Host, "base keys survive a reload of the override")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for reload after override change")
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// durationType 是 time.Duration 的反射类型。(durationType is the reflect type of time.Duration.)
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// flagField 描述一个带 `flag` 标签的配置字段。(flagField describes a configuration field with a `flag` tag.)
+type flagField struct {
+	key       string // Viper 键，例如 "server.port" (The Viper key, e.g. "server.port")
+	name      string // 标志名，例如 "server-port" (The flag name, e.g. "server-port")
+	shorthand string // 单字母简写，可为空 (The one-letter shorthand, may be empty)
+	field     reflect.StructField
+}
+
+// RegisterFlags 将 cfg 中带 `flag:"name"` 或 `flag:"name,p"` 标签的字段注册为 fs 的标志，已存在的同名标志保持不变。
+// 标志的默认值取自 `default` 标签，帮助文本取自 `usage` 标签。请在 fs.Parse 之前调用，然后把 fs 传给 WithFlagSet。
+// (RegisterFlags registers the fields of cfg tagged `flag:"name"` or `flag:"name,p"` as flags of fs; flags already defined
+// under the same name are left untouched. The flag default comes from the `default` tag and the help text from the `usage` tag.
+// Call it before fs.Parse and then pass fs to WithFlagSet.)
+// Parameters:
+//   fs: 要注册标志的 FlagSet，例如 cobra 命令的 cmd.Flags()。
+//       (The FlagSet to register flags on, e.g. cmd.Flags() of a cobra command.)
+//   cfg: 指向配置结构体的指针。
+//        (A pointer to the configuration struct.)
+// Returns:
+//   error: 字段类型不支持或默认值无效时返回 ErrConfigSetup 错误。
+//          (An ErrConfigSetup error when a field type is unsupported or a default value is invalid.)
+func RegisterFlags(fs *pflag.FlagSet, cfg any) error {
+	for _, f := range collectFlagFields(reflect.TypeOf(cfg), nil) {
+		if fs.Lookup(f.name) != nil {
+			continue
+		}
+		if err := defineFlag(fs, f); err != nil {
+			return lmccerrors.WithCode(
+				lmccerrors.Wrapf(err, "failed to register flag '--%s' for key '%s'", f.name, f.key),
+				lmccerrors.ErrConfigSetup,
+			)
+		}
+	}
+	return nil
+}
+
+// bindFlags 注册缺少的标志并把每个标志绑定到对应的 Viper 键。显式设置的标志优先于环境变量、配置文件和默认值；
+// 未设置的标志不覆盖任何来源。显式设置的标志的键会记入 explicitKeys，使 --port=0 这样的零值不被默认值替换。
+// (bindFlags registers missing flags and binds every flag to its Viper key. A flag set explicitly takes precedence over
+// environment variables, config files and defaults; an unset flag overrides nothing. The keys of flags set explicitly are
+// recorded in explicitKeys so that a zero value such as --port=0 is not replaced by the default.)
+func bindFlags(v *viper.Viper, fs *pflag.FlagSet, cfg any, explicitKeys map[string]bool) error {
+	if err := RegisterFlags(fs, cfg); err != nil {
+		return err
+	}
+	for _, f := range collectFlagFields(reflect.TypeOf(cfg), nil) {
+		if err := v.BindPFlag(f.key, fs.Lookup(f.name)); err != nil {
+			return lmccerrors.WithCode(
+				lmccerrors.Wrapf(err, "failed to bind flag '--%s' to key '%s'", f.name, f.key),
+				lmccerrors.ErrConfigSetup,
+			)
+		}
+		if fs.Changed(f.name) {
+			explicitKeys[f.key] = true
+		}
+	}
+	return nil
+}
+
+// collectFlagFields 递归收集带 `flag` 标签的字段，键的构造方式与 bindEnvs 相同。
+// (collectFlagFields recursively collects the fields with a `flag` tag, building keys the same way as bindEnvs.)
+func collectFlagFields(typ reflect.Type, parts []string) []flagField {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []flagField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := fieldKey(field)
+		if tag == "-" {
+			continue
+		}
+		currentParts := append(parts[:len(parts):len(parts)], tag)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType != durationType {
+			// 嵌入的结构体被压平，使用父级路径 (Embedded structs are squashed, so they use the parent path)
+			if field.Anonymous {
+				currentParts = parts
+			}
+			fields = append(fields, collectFlagFields(fieldType, currentParts)...)
+			continue
+		}
+
+		flagTag := field.Tag.Get("flag")
+		if flagTag == "" || flagTag == "-" {
+			continue
+		}
+		name, shorthand, _ := strings.Cut(flagTag, ",")
+		fields = append(fields, flagField{
+			key:       strings.ToLower(strings.Join(currentParts, ".")),
+			name:      name,
+			shorthand: shorthand,
+			field:     field,
+		})
+	}
+	return fields
+}
+
+// fieldKey 返回字段的键名：优先 mapstructure 标签，其次 json 标签，否则为小写字段名。
+// (fieldKey returns the key of a field: the mapstructure tag first, then the json tag, otherwise the lowercase field name.)
+func fieldKey(field reflect.StructField) string {
+	for _, name := range []string{"mapstructure", "json"} {
+		if tag, _, _ := strings.Cut(field.Tag.Get(name), ","); tag != "" {
+			return tag
+		}
+	}
+	return strings.ToLower(field.Name)
+}
+
+// defineFlag 按字段类型定义标志并设置其默认值。(defineFlag defines a flag matching the field type and sets its default.)
+func defineFlag(fs *pflag.FlagSet, f flagField) error {
+	usage := f.field.Tag.Get("usage")
+	typ := f.field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == durationType:
+		fs.DurationP(f.name, f.shorthand, 0, usage)
+	case typ.Kind() == reflect.String:
+		fs.StringP(f.name, f.shorthand, "", usage)
+	case typ.Kind() == reflect.Bool:
+		fs.BoolP(f.name, f.shorthand, false, usage)
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Int64:
+		fs.Int64P(f.name, f.shorthand, 0, usage)
+	case typ.Kind() >= reflect.Uint && typ.Kind() <= reflect.Uint64:
+		fs.Uint64P(f.name, f.shorthand, 0, usage)
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		fs.Float64P(f.name, f.shorthand, 0, usage)
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String:
+		fs.StringSliceP(f.name, f.shorthand, nil, usage)
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Int:
+		fs.IntSliceP(f.name, f.shorthand, nil, usage)
+	default:
+		return fmt.Errorf("unsupported field type %s", f.field.Type)
+	}
+
+	if def := f.field.Tag.Get("default"); def != "" {
+		flag := fs.Lookup(f.name)
+		if err := flag.Value.Set(def); err != nil {
+			return fmt.Errorf("invalid default value '%s': %w", def, err)
+		}
+		flag.DefValue = flag.Value.String()
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for binding command-line flags to configuration fields.
+ */
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flagTestConfig struct {
+	Server struct {
+		Host    string        `mapstructure:"host" default:"localhost" flag:"server-host" usage:"Address to listen on"`
+		Port    int           `mapstructure:"port" default:"8080" flag:"server-port,p" usage:"Port to listen on"`
+		Timeout time.Duration `mapstructure:"timeout" default:"5s" flag:"server-timeout"`
+	} `mapstructure:"server"`
+	Debug bool     `mapstructure:"debug" flag:"debug"`
+	Tags  []string `mapstructure:"tags" flag:"tags"`
+	Name  string   `mapstructure:"name" default:"app"`
+}
+
+// TestLoadConfig_Flags tests the precedence flag > env > file > default for flag-tagged fields.
+// (TestLoadConfig_Flags 测试带 flag 标签字段的优先级：标志 > 环境变量 > 配置文件 > 默认值。)
+func TestLoadConfig_Flags(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", `
+server:
+  host: "file-host"
+  port: 9000
+debug: true
+`)
+	t.Setenv("FLAGTEST_SERVER_PORT", "9100")
+	t.Setenv("FLAGTEST_SERVER_TIMEOUT", "30s")
+
+	fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+	var cfg flagTestConfig
+	require.NoError(t, RegisterFlags(fs, &cfg))
+	require.NoError(t, fs.Parse([]string{"-p", "9200", "--tags", "a,b"}))
+
+	_, err := LoadConfigAndWatch(&cfg,
+		WithConfigFile(path, ""),
+		WithEnvPrefix("FLAGTEST"),
+		WithFlagSet(fs),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 9200, cfg.Server.Port, "the flag beats env and file")
+	assert.Equal(t, 30*time.Second, cfg.Server.Timeout, "env beats the default")
+	assert.Equal(t, "file-host", cfg.Server.Host, "an unset flag does not override the file")
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+	assert.Equal(t, "app", cfg.Name)
+}
+
+// TestLoadConfig_FlagsZeroValue tests that a zero value set on the command line is not replaced by the default.
+// (TestLoadConfig_FlagsZeroValue 测试命令行设置的零值不会被默认值替换。)
+func TestLoadConfig_FlagsZeroValue(t *testing.T) {
+	fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+	var cfg flagTestConfig
+	require.NoError(t, RegisterFlags(fs, &cfg))
+	require.NoError(t, fs.Parse([]string{"--server-port=0", "--server-host="}))
+
+	_, err := LoadConfigAndWatch(&cfg, WithFlagSet(fs), WithEnvVarOverride(false))
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.Server.Port)
+	assert.Equal(t, "", cfg.Server.Host)
+	assert.Equal(t, 5*time.Second, cfg.Server.Timeout)
+}
+
+// TestRegisterFlags tests the registered flag types, shorthand, usage and defaults, and that existing flags are kept.
+// (TestRegisterFlags 测试注册的标志类型、简写、帮助文本和默认值，以及已存在的标志保持不变。)
+func TestRegisterFlags(t *testing.T) {
+	fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+	fs.String("server-host", "0.0.0.0", "Defined by the application")
+	require.NoError(t, RegisterFlags(fs, &flagTestConfig{}))
+
+	port := fs.Lookup("server-port")
+	require.NotNil(t, port)
+	assert.Equal(t, "p", port.Shorthand)
+	assert.Equal(t, "8080", port.DefValue)
+	assert.Equal(t, "Port to listen on", port.Usage)
+	assert.Equal(t, "5s", fs.Lookup("server-timeout").DefValue)
+	assert.Equal(t, "bool", fs.Lookup("debug").Value.Type())
+	assert.Equal(t, "stringSlice", fs.Lookup("tags").Value.Type())
+	assert.Equal(t, "Defined by the application", fs.Lookup("server-host").Usage)
+	assert.Nil(t, fs.Lookup("name"), "fields without a flag tag are not registered")
+
+	// 重复调用不会重复定义标志 (Calling it again does not redefine flags)
+	require.NoError(t, RegisterFlags(fs, &flagTestConfig{}))
+
+	var unsupported struct {
+		Limits map[string]int `mapstructure:"limits" flag:"limits"`
+	}
+	err := RegisterFlags(pflag.NewFlagSet("app", pflag.ContinueOnError), &unsupported)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+}
@@ -0,0 +1,219 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// supportedFileTypes 是配置文件支持的格式，"env" 表示 .env 文件。
+// (supportedFileTypes are the supported configuration file formats; "env" stands for .env files.)
+var supportedFileTypes = []string{"yaml", "yml", "json", "toml", "ini", "env"}
+
+// codecRegistry 在 Viper 内置格式之外注册 INI 格式。(codecRegistry registers the INI format on top of the formats built into Viper.)
+var codecRegistry = func() *viper.DefaultCodecRegistry {
+	r := viper.NewCodecRegistry()
+	if err := r.RegisterCodec("ini", iniCodec{}); err != nil {
+		panic(err)
+	}
+	return r
+}()
+
+// newViper 返回支持本包所有配置文件格式的 Viper 实例。(newViper returns a Viper instance supporting every configuration file format of this package.)
+func newViper() *viper.Viper {
+	return viper.NewWithOptions(viper.WithCodecRegistry(codecRegistry))
+}
+
+// fileType 返回 path 的配置格式：WithConfigFile 显式指定的类型优先，否则由文件名推断；无法推断时返回空字符串。
+// (fileType returns the configuration format of path: the type given explicitly to WithConfigFile first, otherwise the one
+// inferred from the file name. It returns an empty string when the format cannot be inferred.)
+func (o *Options) fileType(path string) string {
+	if o.configFileType != "" && o.isPrimaryFile(path) {
+		return normalizeFileType(o.configFileType)
+	}
+	return detectFileType(path)
+}
+
+// detectFileType 由文件名推断配置格式：.env 和 .env.local 这类文件为 "env"，其余文件取扩展名。
+// (detectFileType infers the configuration format from the file name: files like .env and .env.local are "env",
+// other files use their extension.)
+func detectFileType(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return "env"
+	}
+	return normalizeFileType(strings.TrimPrefix(filepath.Ext(base), "."))
+}
+
+// normalizeFileType 将格式名转换为小写，并将 "dotenv" 统一为 "env"。(normalizeFileType lower-cases a format name and maps "dotenv" to "env".)
+func normalizeFileType(fileType string) string {
+	fileType = strings.ToLower(fileType)
+	if fileType == "dotenv" {
+		return "env"
+	}
+	return fileType
+}
+
+// checkFileType 在格式不受支持时返回带 ErrConfigFileRead 的错误。(checkFileType returns an error coded ErrConfigFileRead when the format is not supported.)
+func checkFileType(path, fileType string) error {
+	if fileType == "" || slices.Contains(supportedFileTypes, fileType) {
+		return nil
+	}
+	return lmccerrors.WithCode(
+		lmccerrors.Wrapf(viper.UnsupportedConfigError(fileType), "failed to read config file '%s' (supported types: %s)",
+			path, strings.Join(supportedFileTypes, ", ")),
+		lmccerrors.ErrConfigFileRead,
+	)
+}
+
+// readDotenvFile 读取 .env 文件，并按环境变量的命名规则将变量映射到配置键，例如前缀为 APP 时 APP_SERVER_PORT 对应
+// server.port。与任何配置键都不对应的变量被忽略，与真实环境变量一致。
+// (readDotenvFile reads a .env file and maps its variables to configuration keys following the environment variable naming,
+// e.g. APP_SERVER_PORT to server.port with the APP prefix. Variables matching no configuration key are ignored, as real
+// environment variables are.)
+func (cm *configManager[T]) readDotenvFile(path string) (map[string]any, error) {
+	v := newViper()
+	v.SetConfigFile(path)
+	v.SetConfigType("env")
+	if err := v.ReadInConfig(); err != nil {
+		if os.IsNotExist(err) {
+			return nil, lmccerrors.WithCode(lmccerrors.Wrapf(err, "config file '%s' not found", path), lmccerrors.ErrConfigFileRead)
+		}
+		return nil, lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to read config file '%s'", path), lmccerrors.ErrConfigFileRead)
+	}
+	variables := v.AllSettings()
+	settings := make(map[string]any)
+	for _, key := range collectKeys(reflect.TypeOf(cm.cfg), nil) {
+		if value, ok := variables[strings.ToLower(cm.envVarName(key))]; ok {
+			setNestedValue(settings, strings.Split(key, "."), value)
+		}
+	}
+	return settings, nil
+}
+
+// collectKeys 递归收集配置结构体的所有叶子键，键的构造方式与 bindEnvs 相同。
+// (collectKeys recursively collects every leaf key of the configuration struct, building keys the same way as bindEnvs.)
+func collectKeys(typ reflect.Type, parts []string) []string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var keys []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := fieldKey(field)
+		if tag == "-" {
+			continue
+		}
+		currentParts := append(parts[:len(parts):len(parts)], tag)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType != durationType {
+			// 嵌入的结构体被压平，使用父级路径 (Embedded structs are squashed, so they use the parent path)
+			if field.Anonymous {
+				currentParts = parts
+			}
+			keys = append(keys, collectKeys(fieldType, currentParts)...)
+			continue
+		}
+		keys = append(keys, strings.ToLower(strings.Join(currentParts, ".")))
+	}
+	return keys
+}
+
+// iniCodec 实现 INI 格式：[section] 开始一个配置节，点分的节名如 [server.tls] 表示嵌套节，key = value 或 key: value
+// 设置值，以 ; 或 # 开头的行为注释。所有值都是字符串，解码到结构体时按字段类型转换，与环境变量相同。
+// (iniCodec implements the INI format: [section] starts a section, a dotted name such as [server.tls] denotes a nested
+// section, key = value or key: value sets a value, and lines starting with ; or # are comments. All values are strings
+// and are converted to the field types when decoded into the struct, like environment variables.)
+type iniCodec struct{}
+
+// Decode 解析 INI 文档。(Decode parses an INI document.)
+func (iniCodec) Decode(b []byte, v map[string]any) error {
+	var section []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("ini: line %d: unterminated section header %q", n, line)
+			}
+			section = nil
+			if name := tomlKey(line[1 : len(line)-1]); name != "" {
+				section = strings.Split(name, ".")
+			}
+		default:
+			i := strings.IndexAny(line, "=:")
+			if i <= 0 {
+				return fmt.Errorf("ini: line %d: expected 'key = value', got %q", n, line)
+			}
+			value := strings.TrimSpace(line[i+1:])
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			path := append(section[:len(section):len(section)], strings.Split(tomlKey(line[:i]), ".")...)
+			setNestedValue(v, path, value)
+		}
+	}
+	return scanner.Err()
+}
+
+// Encode 将设置写为 INI 文档：顶层的值在前，嵌套映射写为点分名称的配置节。
+// (Encode writes the settings as an INI document: top-level values first, nested maps as sections with dotted names.)
+func (iniCodec) Encode(v map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	var write func(prefix string, m map[string]any)
+	write = func(prefix string, m map[string]any) {
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		header := prefix != ""
+		for _, key := range keys {
+			if _, ok := m[key].(map[string]any); ok {
+				continue
+			}
+			// 只含子节的配置节不写节头 (Sections holding only subsections get no header)
+			if header {
+				fmt.Fprintf(&buf, "\n[%s]\n", prefix)
+				header = false
+			}
+			fmt.Fprintf(&buf, "%s = %v\n", key, m[key])
+		}
+		for _, key := range keys {
+			if child, ok := m[key].(map[string]any); ok {
+				write(joinKey(prefix, key), child)
+			}
+		}
+	}
+	write("", v)
+	return bytes.TrimLeft(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for the TOML, INI and .env configuration file formats.
+ */
+
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type formatServerConfig struct {
+	Host    string        `mapstructure:"host" default:"localhost"`
+	Port    int           `mapstructure:"port" default:"8080" flag:"port"`
+	Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+}
+
+type formatConfig struct {
+	Name   string             `mapstructure:"name" default:"app"`
+	Debug  bool               `mapstructure:"debug"`
+	Tags   []string           `mapstructure:"tags"`
+	Server formatServerConfig `mapstructure:"server"`
+}
+
+// TestLoadConfig_FileFormats tests that every format is detected from its file name and decodes to the same config.
+// (TestLoadConfig_FileFormats 测试每种格式都由文件名识别，并解码为相同的配置。)
+func TestLoadConfig_FileFormats(t *testing.T) {
+	dir := t.TempDir()
+	want := formatConfig{
+		Name:   "orders",
+		Debug:  true,
+		Tags:   []string{"a", "b"},
+		Server: formatServerConfig{Host: "0.0.0.0", Port: 9000, Timeout: 30 * time.Second},
+	}
+	files := map[string]string{
+		"app.yaml": "name: orders\ndebug: true\ntags: [a, b]\nserver:\n  host: 0.0.0.0\n  port: 9000\n  timeout: 30s\n",
+		"app.toml": "name = \"orders\"\ndebug = true\ntags = [\"a\", \"b\"]\n\n[server]\nhost = \"0.0.0.0\"\nport = 9000\ntimeout = \"30s\"\n",
+		"app.ini":  "; legacy settings\nname = orders\ndebug = true\ntags = a,b\n\n[server]\nhost = \"0.0.0.0\"\nport: 9000\ntimeout = 30s\n",
+		".env":     "# local overrides\nAPP_NAME=orders\nAPP_DEBUG=true\nAPP_TAGS=a,b\nexport APP_SERVER_HOST=0.0.0.0\nAPP_SERVER_PORT=9000\nAPP_SERVER_TIMEOUT=30s\nUNRELATED=x\n",
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := writeConfigFile(t, dir, name, content)
+			var cfg formatConfig
+			require.NoError(t, LoadConfig(&cfg, WithConfigFile(path, ""), WithEnvPrefix("APP"), WithEnvVarOverride(false)))
+			assert.Equal(t, want, cfg)
+		})
+	}
+
+	t.Run("explicit type", func(t *testing.T) {
+		path := writeConfigFile(t, dir, "settings.conf", "[server]\nport = 9100\n")
+		var cfg formatConfig
+		require.NoError(t, LoadConfig(&cfg, WithConfigFile(path, "INI"), WithEnvVarOverride(false)))
+		assert.Equal(t, 9100, cfg.Server.Port)
+
+		path = writeConfigFile(t, dir, "local.vars", "APP_SERVER_PORT=9200\n")
+		require.NoError(t, LoadConfig(&cfg, WithConfigFile(path, "dotenv"), WithEnvPrefix("APP"), WithEnvVarOverride(false)))
+		assert.Equal(t, 9200, cfg.Server.Port)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		path := writeConfigFile(t, dir, "app.xml", "<app/>")
+		err := LoadConfig(&formatConfig{}, WithConfigFile(path, ""))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, lmccerrors.ErrConfigFileRead))
+		assert.Contains(t, err.Error(), "supported types: yaml, yml, json, toml, ini, env")
+	})
+}
+
+// TestLoadConfig_FileFormatPrecedence tests that INI and .env files keep the default < file < env < flag precedence.
+// (TestLoadConfig_FileFormatPrecedence 测试 INI 和 .env 文件保持 默认值 < 文件 < 环境变量 < 标志 的优先级。)
+func TestLoadConfig_FileFormatPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "app.ini", "name = legacy\n\n[server]\nhost = ini-host\nport = 9000\n")
+	local := writeConfigFile(t, dir, ".env.local", "APP_SERVER_HOST=dotenv-host\nAPP_SERVER_PORT=9100\n")
+	t.Setenv("APP_SERVER_PORT", "9200")
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, RegisterFlags(fs, &formatConfig{}))
+
+	var cfg formatConfig
+	require.NoError(t, LoadConfig(&cfg, WithConfigFiles(base, local), WithEnvPrefix("APP"), WithFlagSet(fs)))
+	assert.Equal(t, "legacy", cfg.Name, "set by the INI file")
+	assert.Equal(t, "dotenv-host", cfg.Server.Host, "the .env file is merged over the INI file")
+	assert.Equal(t, 9200, cfg.Server.Port, "environment variables override files")
+	assert.Equal(t, 5*time.Second, cfg.Server.Timeout, "defaults fill the rest")
+
+	require.NoError(t, fs.Parse([]string{"--port=9300"}))
+	require.NoError(t, LoadConfig(&cfg, WithConfigFiles(base, local), WithEnvPrefix("APP"), WithFlagSet(fs)))
+	assert.Equal(t, 9300, cfg.Server.Port, "flags override everything")
+}
+
+// TestValidate_FileFormats tests that dry-run errors point at INI and .env lines.
+// (TestValidate_FileFormats 测试试运行错误指向 INI 和 .env 文件的行。)
+func TestValidate_FileFormats(t *testing.T) {
+	dir := t.TempDir()
+	ini := writeConfigFile(t, dir, "app.ini", "[listener]\nhost = localhost\n; the port\nport = 70000\n")
+	err := Validate(ini, &validatedConfig{}, WithEnvVarOverride(false))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ini+":4: field 'listener.port' failed rule 'max=65535'")
+
+	env := writeConfigFile(t, dir, ".env", "DRYRUN_LISTENER_HOST=localhost\nDRYRUN_LISTENER_PORT=80000\n")
+	err = Validate(env, &validatedConfig{}, WithEnvPrefix("DRYRUN"), WithEnvVarOverride(false))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), env+":2: field 'listener.port' failed rule 'max=65535'")
+}
+
+// TestIniCodec tests decoding nested sections and encoding them back.
+// (TestIniCodec 测试解码嵌套配置节并重新编码。)
+func TestIniCodec(t *testing.T) {
+	settings := map[string]any{}
+	require.NoError(t, iniCodec{}.Decode([]byte("name = app\n[server.tls]\nenabled = true\n# comment\ncert = 'a.pem'\n"), settings))
+	assert.Equal(t, map[string]any{
+		"name":   "app",
+		"server": map[string]any{"tls": map[string]any{"enabled": "true", "cert": "a.pem"}},
+	}, settings)
+
+	out, err := iniCodec{}.Encode(settings)
+	require.NoError(t, err)
+	assert.Equal(t, "name = app\n\n[server.tls]\ncert = a.pem\nenabled = true\n", string(out))
+
+	assert.Error(t, iniCodec{}.Decode([]byte("[server\n"), map[string]any{}))
+	assert.Error(t, iniCodec{}.Decode([]byte("just a line\n"), map[string]any{}))
+}
@@ -0,0 +1,124 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for the $include directive in config files.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadConfig_Include tests top-level and nested includes, relative resolution, nested includes and precedence.
+// (TestLoadConfig_Include 测试顶层和嵌套的引入、相对路径解析、多级引入以及优先级。)
+func TestLoadConfig_Include(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "conf.d"), 0755))
+	writeConfigFile(t, dir, "conf.d/server.yaml", `
+server:
+  host: "10.0.0.1"
+  port: 8080
+$include: feature.json
+`)
+	writeConfigFile(t, dir, "conf.d/feature.json", `{"customFeature": {"apiKey": "included-key", "rateLimit": 100, "enabled": true}}`)
+	writeConfigFile(t, dir, "logging.yaml", `
+level: "debug"
+format: "json"
+`)
+	main := writeConfigFile(t, dir, "config.yaml", `
+$include:
+  - conf.d/server.yaml
+server:
+  port: 9090
+log:
+  $include: logging.yaml
+  level: "warn"
+`)
+
+	var cfg testAppConfig
+	require.NoError(t, LoadConfig(&cfg, WithConfigFile(main, "")))
+	assert.Equal(t, "10.0.0.1", cfg.Server.Host, "included keys are merged")
+	assert.Equal(t, 9090, cfg.Server.Port, "the including file overrides included keys")
+	assert.Equal(t, "warn", cfg.Log.Level, "sibling keys override a nested include")
+	assert.Equal(t, "json", cfg.Log.Format, "a nested include is merged under its key")
+	assert.Equal(t, "included-key", cfg.CustomFeature.APIKey, "included files resolve includes relative to themselves")
+	assert.Equal(t, 100, cfg.CustomFeature.RateLimit)
+
+	// 引入的文件作为后续文件合并时不会清除前面的配置 (Merging a later file with includes keeps the earlier configuration)
+	override := writeConfigFile(t, dir, "override.yaml", "log:\n  $include: logging.yaml\n")
+	var merged testAppConfig
+	require.NoError(t, LoadConfig(&merged, WithConfigFiles(main, override)))
+	assert.Equal(t, "debug", merged.Log.Level)
+	assert.Equal(t, "10.0.0.1", merged.Server.Host)
+}
+
+// TestLoadConfig_IncludeErrors tests missing includes, include cycles and invalid directives.
+// (TestLoadConfig_IncludeErrors 测试缺失的引入文件、循环引入和无效的指令。)
+func TestLoadConfig_IncludeErrors(t *testing.T) {
+	dir := t.TempDir()
+	missing := writeConfigFile(t, dir, "missing.yaml", "$include: absent.yaml\n")
+	cycleA := writeConfigFile(t, dir, "a.yaml", "$include: b.yaml\n")
+	writeConfigFile(t, dir, "b.yaml", "$include: a.yaml\n")
+	invalid := writeConfigFile(t, dir, "invalid.yaml", "$include:\n  file: x.yaml\n")
+
+	testCases := []struct {
+		name    string
+		path    string
+		wantMsg string
+	}{
+		{name: "Missing", path: missing, wantMsg: "absent.yaml"},
+		{name: "Cycle", path: cycleA, wantMsg: "config include cycle"},
+		{name: "Invalid", path: invalid, wantMsg: "invalid $include"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg testAppConfig
+			err := LoadConfig(&cfg, WithConfigFile(tc.path, ""))
+			require.Error(t, err)
+			assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+			assert.Contains(t, err.Error(), tc.wantMsg)
+		})
+	}
+}
+
+// TestLoadConfigAndWatch_Include tests that a change to an included file reloads the configuration.
+// (TestLoadConfigAndWatch_Include 测试被引入的文件变化时会重新加载配置。)
+func TestLoadConfigAndWatch_Include(t *testing.T) {
+	dir := t.TempDir()
+	logging := writeConfigFile(t, dir, "logging.yaml", "level: \"info\"\n")
+	main := writeConfigFile(t, dir, "config.yaml", "server:\n  port: 8080\nlog:\n  $include: logging.yaml\n")
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(main, ""), WithHotReload(true))
+	require.NoError(t, err)
+	require.Equal(t, "info", cfg.Log.Level)
+
+	reloaded := make(chan *testAppConfig, 4)
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		select {
+		case reloaded <- c.(*testAppConfig):
+		default:
+		}
+		return nil
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	tmp := writeConfigFile(t, t.TempDir(), "logging.yaml", "level: \"error\"\n")
+	require.NoError(t, os.Rename(tmp, logging))
+	select {
+	case got := <-reloaded:
+		assert.Equal(t, "error", got.Log.Level)
+		assert.Equal(t, 8080, got.Server.Port, "keys of the including file survive a reload")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for reload after included file change")
+	}
+}
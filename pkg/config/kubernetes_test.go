@@ -0,0 +1,173 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for loading and watching configuration projected by Kubernetes or read from a ConfigMap.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// projectKubernetesFile 按 kubelet 的方式原子地投射文件：写入新的时间戳目录，再通过重命名替换 "..data" 符号链接。
+// (projectKubernetesFile projects a file the way the kubelet does: it writes a new timestamped directory and then
+// swaps the "..data" symlink with a rename.)
+func projectKubernetesFile(t *testing.T, dir, generation, name, content string) {
+	t.Helper()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, generation), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, generation, name), []byte(content), 0o644))
+
+	previous, _ := os.Readlink(filepath.Join(dir, "..data"))
+	require.NoError(t, os.Symlink(generation, filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	if previous != "" {
+		require.NoError(t, os.RemoveAll(filepath.Join(dir, previous)))
+	}
+	if _, err := os.Lstat(filepath.Join(dir, name)); os.IsNotExist(err) {
+		require.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)))
+	}
+}
+
+// TestLoadConfig_KubernetesProjectedFile tests that a projected file is reloaded when Kubernetes swaps the "..data" symlink.
+// (TestLoadConfig_KubernetesProjectedFile 测试 Kubernetes 替换 "..data" 符号链接时投射文件会被重载。)
+func TestLoadConfig_KubernetesProjectedFile(t *testing.T) {
+	dir := t.TempDir()
+	projectKubernetesFile(t, dir, "..2024_01_01_00_00_00.1", "config.yaml", "server:\n  port: 8181\n")
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithKubernetesProjectedFile(filepath.Join(dir, "config.yaml"), ""), WithHotReload(true))
+	require.NoError(t, err)
+	assert.Equal(t, 8181, cfg.Server.Port)
+
+	reloaded := make(chan int, 1)
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		select {
+		case reloaded <- c.(*testAppConfig).Server.Port:
+		default:
+		}
+		return nil
+	})
+
+	projectKubernetesFile(t, dir, "..2024_01_01_00_05_00.2", "config.yaml", "server:\n  port: 8282\n")
+	select {
+	case port := <-reloaded:
+		assert.Equal(t, 8282, port)
+		assert.Equal(t, 8282, cfg.Server.Port)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for reload after the projected file was swapped")
+	}
+}
+
+// TestLoadConfig_KubernetesConfigMap tests reading a ConfigMap through the in-cluster API and waiting for changes with a watch.
+// (TestLoadConfig_KubernetesConfigMap 测试通过集群内 API 读取 ConfigMap，并使用 watch 等待变化。)
+func TestLoadConfig_KubernetesConfigMap(t *testing.T) {
+	kv := newFakeKV("server:\n  port: 7070\n")
+	configMap := func(value string, index int) map[string]any {
+		return map[string]any{
+			"metadata": map[string]any{"name": "app", "resourceVersion": strconv.Itoa(index)},
+			"data":     map[string]string{"app.yaml": value, "other.txt": "ignored"},
+		}
+	}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/namespaces/prod/configmaps/app":
+			value, index, _ := kv.snapshot()
+			_ = json.NewEncoder(w).Encode(configMap(value, index))
+		case "/api/v1/namespaces/prod/configmaps":
+			assert.Equal(t, "true", r.URL.Query().Get("watch"))
+			assert.Equal(t, "metadata.name=app", r.URL.Query().Get("fieldSelector"))
+			value, index, changed := kv.snapshot()
+			if strconv.Itoa(index) == r.URL.Query().Get("resourceVersion") {
+				select {
+				case <-changed:
+					value, index, _ = kv.snapshot()
+				case <-time.After(2 * time.Second):
+					// watch 超时结束，不返回事件 (The watch times out without an event)
+					return
+				case <-r.Context().Done():
+					return
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"type": "MODIFIED", "object": configMap(value, index)})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	accountDir := t.TempDir()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(filepath.Join(accountDir, "ca.crt"), ca, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(accountDir, "token"), []byte("test-token\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(accountDir, "namespace"), []byte("prod"), 0o600))
+	originalDir := kubernetesServiceAccountDir
+	kubernetesServiceAccountDir = accountDir
+	t.Cleanup(func() { kubernetesServiceAccountDir = originalDir })
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+	t.Setenv("KUBERNETES_SERVICE_HOST", host)
+	t.Setenv("KUBERNETES_SERVICE_PORT", port)
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithKubernetesConfigMap("", "app", "app.yaml"), WithHotReload(true))
+	require.NoError(t, err)
+	assert.Equal(t, 7070, cfg.Server.Port)
+	assert.Equal(t, "0.0.0.0", cfg.Server.Host, "struct defaults fill missing keys")
+
+	reloaded := make(chan int, 1)
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		select {
+		case reloaded <- c.(*testAppConfig).Server.Port:
+		default:
+		}
+		return nil
+	})
+	kv.set("server:\n  port: 7171\n")
+	select {
+	case port := <-reloaded:
+		assert.Equal(t, 7171, port)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for reload after the ConfigMap changed")
+	}
+
+	// 有多个键时必须指定数据键 (A data key is required when the ConfigMap has several keys)
+	var ambiguous testAppConfig
+	err = LoadConfig(&ambiguous, WithKubernetesConfigMap("prod", "app"))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigRemote))
+}
+
+// TestLoadConfig_KubernetesConfigMapOutsideCluster tests that the ConfigMap mode fails clearly outside a cluster.
+// (TestLoadConfig_KubernetesConfigMapOutsideCluster 测试在集群外使用 ConfigMap 模式会明确地失败。)
+func TestLoadConfig_KubernetesConfigMapOutsideCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+
+	var cfg testAppConfig
+	err := LoadConfig(&cfg, WithKubernetesConfigMap("prod", "app"))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+}
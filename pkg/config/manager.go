@@ -7,8 +7,11 @@
 package config
 
 import (
+	"fmt"
 	"log" // Use standard log package to avoid import cycle (使用标准日志包以避免导入循环)
 	"sync"
+	"sync/atomic"
+	"time"
 
 	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors" // SDK errors package (SDK 错误包)
 	"github.com/spf13/viper"
@@ -16,8 +19,10 @@ import (
 
 // ConfigChangeCallback 定义了配置变更时调用的回调函数类型。
 // (ConfigChangeCallback defines the type for callback functions invoked on configuration change.)
-// 这个回调接收 Viper 实例和解码后的整个配置对象。
-// (This callback receives the Viper instance and the decoded entire configuration object.)
+// 这个回调接收 Viper 实例和解码后的整个配置对象。cfg 只在回调执行期间有效，回调返回后不应继续持有或读取它；
+// 需要在之后使用配置时请复制所需的值，或使用 Snapshot。
+// (This callback receives the Viper instance and the decoded entire configuration object. cfg is only valid while the
+// callback runs and must not be kept or read after it returns; copy the values needed later or use Snapshot.)
 type ConfigChangeCallback func(v *viper.Viper, cfg any) error
 
 // configManager 封装了 Viper 实例、配置对象和回调逻辑。
@@ -25,13 +30,21 @@ type ConfigChangeCallback func(v *viper.Viper, cfg any) error
 // (configManager encapsulates the Viper instance, config object, and callback logic.)
 // (This is an internal struct used within the package.)
 type configManager[T any] struct {
-	v                   *viper.Viper
-	cfg                 *T
-	callbacks           []ConfigChangeCallback // 通用回调 (General callbacks)
-	callbackMux         sync.RWMutex
-	sectionCallbacks    map[string][]SectionChangeCallback // 特定节回调 (Section-specific callbacks)
-	sectionCallbacksMux sync.RWMutex
-	options             Options // Use the Options type defined in options.go
+	v             *viper.Viper
+	cfg           *T
+	callbacks     []registeredCallback // 按注册顺序排列的通用回调和特定节回调 (General and section callbacks in registration order)
+	callbackMux   sync.RWMutex
+	options       Options                           // Use the Options type defined in options.go
+	reloadMux     sync.Mutex                        // 串行化热重载 (Serializes hot reloads)
+	remote        remoteSource                      // 远程配置源，未配置时为 nil (Remote config source, nil when not configured)
+	remoteData    []byte                            // 最近一次读取的远程配置 (Most recently read remote config)
+	remoteVersion string                            // remoteData 的版本 (Version of remoteData)
+	readerData    []readerData                      // 从 WithConfigReader 读取的配置 (Config read from WithConfigReader)
+	settings      atomic.Pointer[map[string]any]    // Values 方法读取的配置快照 (Configuration snapshot read by the Values methods)
+	lastChanges   atomic.Pointer[ChangeSet]         // 最近一次热重载的变化集合 (Change set of the most recent hot reload)
+	overrides     map[string]override               // 通过 Set 设置的值，由 reloadMux 保护 (Values set with Set, guarded by reloadMux)
+	included      atomic.Pointer[[]string]          // 配置文件引入的文件 (Files included by the config files)
+	snapshot      atomic.Pointer[configSnapshot[T]] // Snapshot 返回的类型化配置及版本号 (Typed configuration and version returned by Snapshot)
 	// watcher             *fsnotify.Watcher // 保持对 watcher 的引用，以便可以停止它 (Keep a reference to the watcher so it can be stopped)
 	// watchStopper      chan struct{}     // 用于停止监视 goroutine 的通道 (Channel to stop the watch goroutine)
 }
@@ -54,14 +67,32 @@ func newConfigManager[T any](cfg *T, opts ...Option) *configManager[T] {
 		opt(&appliedOptions)
 	}
 	return &configManager[T]{
-		v:                viper.New(),
-		cfg:              cfg,
-		options:          appliedOptions, // Use the processed options
-		sectionCallbacks: make(map[string][]SectionChangeCallback),
+		v:       newViper(),
+		cfg:     cfg,
+		options: appliedOptions, // Use the processed options
 		// watchStopper:     make(chan struct{}), // 初始化停止通道 (Initialize stop channel)
 	}
 }
 
+// registeredCallback 是一个已注册的回调：section 为空时是通用回调，否则是该配置节的回调。
+// (registeredCallback is a registered callback: a general callback when section is empty, otherwise a callback for that section.)
+type registeredCallback struct {
+	section     string
+	general     ConfigChangeCallback
+	onSection   SectionChangeCallback
+	description string         // 用于错误日志 (Used in error logs)
+	state       *callbackState // 超时后的后台运行状态 (Background run state after a timeout)
+}
+
+// callbackState 记录回调是否在超时后仍在后台运行，以及期间是否有配置变更等待它返回后再次调用。
+// (callbackState records whether a callback is still running in the background after a timeout, and whether a configuration
+// change is waiting to call it again once it returns.)
+type callbackState struct {
+	mu      sync.Mutex
+	running bool
+	pending bool
+}
+
 // RegisterCallback 注册一个配置变更回调函数，该函数将在配置通过热重载更新时被调用。
 // (RegisterCallback registers a configuration change callback function, which will be invoked when the configuration is updated via hot-reload.)
 // Parameters:
@@ -70,7 +101,11 @@ func newConfigManager[T any](cfg *T, opts ...Option) *configManager[T] {
 func (cm *configManager[T]) RegisterCallback(callback func(v *viper.Viper, cfg any) error) { // Ensure signature matches interface
 	cm.callbackMux.Lock()
 	defer cm.callbackMux.Unlock()
-	cm.callbacks = append(cm.callbacks, callback)
+	cm.callbacks = append(cm.callbacks, registeredCallback{
+		general:     callback,
+		description: fmt.Sprintf("general configuration change callback %d", cm.countCallbacks("")+1),
+		state:       new(callbackState),
+	})
 	log.Printf("Info: Registered a general configuration change callback.") // 使用标准 log (Use standard log)
 }
 
@@ -82,69 +117,139 @@ func (cm *configManager[T]) RegisterCallback(callback func(v *viper.Viper, cfg a
 //   callback:   当配置节变更时调用的回调函数 (SectionChangeCallback)。
 //               (The callback function (SectionChangeCallback) to invoke when the section changes.)
 func (cm *configManager[T]) RegisterSectionChangeCallback(sectionKey string, callback SectionChangeCallback) {
-	cm.sectionCallbacksMux.Lock()
-	defer cm.sectionCallbacksMux.Unlock()
-	cm.sectionCallbacks[sectionKey] = append(cm.sectionCallbacks[sectionKey], callback)
+	cm.callbackMux.Lock()
+	defer cm.callbackMux.Unlock()
+	cm.callbacks = append(cm.callbacks, registeredCallback{
+		section:     sectionKey,
+		onSection:   callback,
+		description: fmt.Sprintf("configuration change callback for section [%s], callback %d", sectionKey, cm.countCallbacks(sectionKey)+1),
+		state:       new(callbackState),
+	})
 	log.Printf("Info: Registered a configuration change callback for section [%s].", sectionKey) // 使用标准 log (Use standard log)
 }
 
-// notifyCallbacks 在配置变更后通知所有注册的回调函数。
-// (notifyCallbacks notifies all registered callback functions after a configuration change.)
+// countCallbacks 返回已注册到 section 的回调数量，section 为空时统计通用回调。调用方需持有 callbackMux。
+// (countCallbacks returns the number of callbacks registered for section, or of general callbacks when section is empty.
+// The caller holds callbackMux.)
+func (cm *configManager[T]) countCallbacks(section string) int {
+	n := 0
+	for _, cb := range cm.callbacks {
+		if cb.section == section {
+			n++
+		}
+	}
+	return n
+}
+
+// notifyCallbacks 在配置变更后按注册顺序逐个调用回调，前一个回调返回或超时后才调用下一个，因此超时的回调可能与之后的回调
+// 以及之后的重载同时运行（见 runCallback）。特定节回调只在该配置节发生变化时调用；没有变化集合时（例如直接调用）调用所有回调。
+// (notifyCallbacks calls the callbacks one at a time in registration order after a configuration change, starting each only
+// after the previous one returned or timed out, so a timed-out callback may overlap the callbacks after it and later reloads
+// (see runCallback). Section callbacks are only called when their section changed; without a change set, e.g. when called
+// directly, every callback is called.)
 func (cm *configManager[T]) notifyCallbacks() {
-	// 通知通用回调 (Notify general callbacks)
-	cm.callbackMux.RLock()
 	// 创建副本以避免在回调执行期间持有锁 (Create a copy to avoid holding lock during callback execution)
-	currentCallbacks := make([]ConfigChangeCallback, len(cm.callbacks))
+	cm.callbackMux.RLock()
+	currentCallbacks := make([]registeredCallback, len(cm.callbacks))
 	copy(currentCallbacks, cm.callbacks)
 	cm.callbackMux.RUnlock()
 
-	if len(currentCallbacks) > 0 {
-		log.Printf("Info: Notifying %d general callback(s) about configuration change...", len(currentCallbacks)) // 使用标准 log (Use standard log)
-		for i, callback := range currentCallbacks {
-			if err := callback(cm.v, cm.cfg); err != nil {
-				wrappedErr := lmccerrors.WithCode(
-				lmccerrors.Wrapf(err, "error executing general configuration change callback %d", i+1),
-				lmccerrors.ErrConfigHotReload,
-			)
-				log.Printf("%s: %+v", lmccerrors.ErrConfigHotReload.String(), wrappedErr)
-			}
+	if len(currentCallbacks) == 0 {
+		return
+	}
+	log.Printf("Info: Notifying %d callback(s) about configuration change...", len(currentCallbacks)) // 使用标准 log (Use standard log)
+	changes := cm.lastChanges.Load()
+	for _, cb := range currentCallbacks {
+		if cb.section != "" && changes != nil && !changes.Changed(cb.section) {
+			continue
+		}
+		if err := cm.runCallback(cb); err != nil {
+			logCallbackError(cb, err)
 		}
 	}
+}
 
-	// 通知特定节回调 (Notify section-specific callbacks)
-	cm.sectionCallbacksMux.RLock()
-	// 创建深拷贝以避免在回调执行期间持有锁或回调修改映射 (Create a deep copy to avoid holding lock or callback modifying map during execution)
-	currentSectionCallbacks := make(map[string][]SectionChangeCallback)
-	for key, callbacksSlice := range cm.sectionCallbacks {
-		copiedSlice := make([]SectionChangeCallback, len(callbacksSlice))
-		copy(copiedSlice, callbacksSlice)
-		currentSectionCallbacks[key] = copiedSlice
+// runCallback 调用回调；设置了 WithCallbackTimeout 时，超时后返回错误并继续下一个回调，超时的回调在后台运行至结束。
+// 在此之前该回调不会再次启动：之后的重载只记下一次待执行的调用，回调返回后以最新的配置再调用一次，因此同一个回调从不与自身同时运行，
+// 也不会错过最新的配置。
+// (runCallback calls the callback; with WithCallbackTimeout set it returns an error once the timeout passes so the next callback
+// can run, and the timed-out callback runs to completion in the background. Until then the callback is not started again:
+// later reloads only record one pending call, made with the latest configuration once the callback returns, so a callback
+// never overlaps itself and never misses the latest configuration.)
+func (cm *configManager[T]) runCallback(cb registeredCallback) error {
+	timeout := cm.options.callbackTimeout
+	if timeout <= 0 {
+		return cm.callCallback(cb)
 	}
-	cm.sectionCallbacksMux.RUnlock()
-
-	if len(currentSectionCallbacks) > 0 {
-		log.Printf("Info: Notifying section-specific callback(s) about configuration change...") // 使用标准 log (Use standard log)
-		for sectionKey, callbacksSlice := range currentSectionCallbacks {
-			// 理论上，我们应该只在特定节实际更改时调用这些回调。
-			// 但 Viper 的 OnConfigChange 不提供此类粒度信息。
-			// 因此，我们通知所有已注册的特定节回调，并让它们自行处理。
-			// (Theoretically, we should only call these if the specific section actually changed.
-			// However, Viper's OnConfigChange doesn't provide that granular info.
-			// So, we notify all registered section callbacks and let them handle it.)
-			log.Printf("Info: Notifying %d callback(s) for section [%s]...", len(callbacksSlice), sectionKey) // 使用标准 log (Use standard log)
-			for i, callback := range callbacksSlice {
-				if err := callback(cm.v); err != nil {
-					wrappedErr := lmccerrors.WithCode(
-				lmccerrors.Wrapf(err, "error executing configuration change callback for section [%s], callback %d", sectionKey, i+1),
-				lmccerrors.ErrConfigHotReload,
-			)
-					log.Printf("%s: %+v", lmccerrors.ErrConfigHotReload.String(), wrappedErr)
-				}
-			}
+
+	cb.state.mu.Lock()
+	if cb.state.running {
+		cb.state.pending = true
+		cb.state.mu.Unlock()
+		log.Printf("Info: %s is still running for a previous change; it will run again with the latest configuration once it returns.", cb.description)
+		return nil
+	}
+	cb.state.running = true
+	cb.state.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cm.callCallback(cb)
+		cm.runPending(cb)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return lmccerrors.Errorf("callback did not return within %s", timeout)
+	}
+}
+
+// runPending 在回调返回后执行运行期间记下的调用，直到没有待执行的调用为止。
+// (runPending makes the call recorded while the callback was running once it returns, until none is pending.)
+func (cm *configManager[T]) runPending(cb registeredCallback) {
+	for {
+		cb.state.mu.Lock()
+		if !cb.state.pending {
+			cb.state.running = false
+			cb.state.mu.Unlock()
+			return
+		}
+		cb.state.pending = false
+		cb.state.mu.Unlock()
+
+		if err := cm.callCallback(cb); err != nil {
+			logCallbackError(cb, err)
 		}
 	}
 }
 
+// logCallbackError 以 ErrConfigHotReload 记录回调返回的错误。(logCallbackError logs an error returned by a callback as ErrConfigHotReload.)
+func logCallbackError(cb registeredCallback, err error) {
+	wrappedErr := lmccerrors.WithCode(
+		lmccerrors.Wrapf(err, "error executing %s", cb.description),
+		lmccerrors.ErrConfigHotReload,
+	)
+	log.Printf("%s: %+v", lmccerrors.ErrConfigHotReload.String(), wrappedErr)
+}
+
+// callCallback 调用回调。设置了 WithCallbackTimeout 时，通用回调收到的是当前版本的配置快照而不是 cm.cfg，
+// 这样在后台运行的回调不会与之后重载对 cm.cfg 的写入竞争。
+// (callCallback calls the callback. With WithCallbackTimeout set, general callbacks receive the snapshot of the current
+// version instead of cm.cfg, so a callback running in the background does not race with later reloads writing cm.cfg.)
+func (cm *configManager[T]) callCallback(cb registeredCallback) error {
+	if cb.general == nil {
+		return cb.onSection(cm.v)
+	}
+	cfg := cm.cfg
+	if snapshot := cm.snapshot.Load(); snapshot != nil && cm.options.callbackTimeout > 0 {
+		cfg = snapshot.cfg
+	}
+	return cb.general(cm.v, cfg)
+}
+
 // GetViperInstance 返回 configManager 内部使用的 Viper 实例。
 // (GetViperInstance returns the internal Viper instance used by the configManager.)
 // Returns:
@@ -6,14 +6,35 @@
 
 package config
 
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
 // Options 结构体定义了配置加载的可选参数
 // (Options struct defines optional parameters for config loading)
 type Options struct {
-	configFilePath       string // 配置文件路径 (Configuration file path)
-	configFileType       string // 配置文件类型 (Configuration file type)
-	envPrefix            string // 环境变量前缀 (Environment variable prefix)
-	enableEnvVarOverride bool   // 是否启用环境变量覆盖 (Whether to enable environment variable override)
-	enableHotReload      bool   // 是否启用热重载 (Whether to enable hot reload)
+	configFilePath       string         // 配置文件路径 (Configuration file path)
+	configFileType       string         // 配置文件类型 (Configuration file type)
+	configFiles          []string       // 依次合并的附加配置文件 (Additional config files merged in order)
+	readers              []readerSource // 在文件之后合并的 io.Reader 配置源 (io.Reader config sources merged after the files)
+	remote               *remoteOptions // 远程配置提供者 (Remote configuration provider)
+	envPrefix            string         // 环境变量前缀 (Environment variable prefix)
+	enableEnvVarOverride bool           // 是否启用环境变量覆盖 (Whether to enable environment variable override)
+	enableEnvExpansion   bool           // 是否展开值中的 ${VAR} (Whether to expand ${VAR} inside values)
+	enableHotReload      bool           // 是否启用热重载 (Whether to enable hot reload)
+	onValidationError    func(error)    // 热重载校验失败时的回调 (Callback for a failed validation during hot reload)
+	secretResolvers      map[string]SecretResolver // 按名称注册的密钥解析器 (Secret resolvers registered by name)
+	flagSet              *pflag.FlagSet // 绑定到配置字段的命令行标志 (Command-line flags bound to config fields)
+	kubernetesProjected  bool           // 配置文件由 Kubernetes 卷投射 (Config files are projected from a Kubernetes volume)
+	profile              string         // 环境 profile 名称 (Environment profile name)
+	reloadDebounce       time.Duration  // 合并热重载变更事件的等待时间 (Quiet period coalescing hot-reload change events)
+	callbackTimeout      time.Duration  // 单个变更回调的超时时间 (Timeout of a single change callback)
+	warningLogger        WarningLogger  // 接收结构化警告的 Logger (Logger receiving structured warnings)
+	defaultsProvider     func(target any) error // 以代码计算默认值的函数 (Function computing defaults in code)
+	strictUnmarshal      bool           // 配置源中的未知键视为错误 (Unknown keys in the config sources are an error)
 }
 
 // Option 是一个函数类型，用于修改 Options 结构体
@@ -26,6 +47,7 @@ var defaultOptions = Options{
 	configFileType:       "",     // 默认无配置文件类型 (No config file type by default)
 	envPrefix:            "LMCC", // 默认前缀 (Default prefix)
 	enableEnvVarOverride: true,   // 默认启用环境变量覆盖 (Enable env var override by default)
+	enableEnvExpansion:   true,   // 默认展开值中的环境变量 (Expand environment variables inside values by default)
 	enableHotReload:      false,  // 默认禁用热重载 (Disable hot reload by default)
 }
 
@@ -36,8 +58,9 @@ var defaultOptions = Options{
 // Parameters:
 //   path: 配置文件的完整路径。
 //         (The full path to the configuration file.)
-//   fileType: 配置文件的类型 (例如 "yaml", "json", "toml")。如果为空则自动推断。
-//             (The type of the configuration file (e.g., "yaml", "json", "toml"). Auto-inferred if empty.)
+//   fileType: 配置文件的类型 (例如 "yaml", "json", "toml", "ini", "dotenv")。如果为空则由文件名推断，.env 文件为 "dotenv"。
+//             (The type of the configuration file (e.g., "yaml", "json", "toml", "ini", "dotenv"). Inferred from the file name
+//             if empty, with .env files being "dotenv".)
 // Returns:
 //   Option: 应用此配置的 Option 函数。
 //           (The Option function to apply this configuration.)
@@ -48,6 +71,86 @@ func WithConfigFile(path string, fileType string) Option {
 	}
 }
 
+// WithConfigFiles 返回一个 Option，用于设置依次深度合并的多个配置文件（例如 base.yaml 和 override.dev.yaml）。
+// 优先级从低到高为：结构体默认值、WithConfigFile 设置的文件、按顺序排列的这些文件、环境变量；
+// 嵌套的映射逐键合并，标量和列表整体替换。文件类型由扩展名推断，启用热重载时监视所有文件。
+// (WithConfigFiles returns an Option to set several configuration files that are deep-merged in order, e.g. base.yaml and override.dev.yaml.)
+// (Precedence from lowest to highest is: struct defaults, the file set by WithConfigFile, these files in order, environment variables;
+// nested maps are merged key by key while scalars and lists are replaced. File types are inferred from extensions, and every file
+// is watched when hot reload is enabled.)
+// Parameters:
+//   paths: 按优先级从低到高排列的配置文件路径。
+//          (Configuration file paths ordered from lowest to highest precedence.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithConfigFiles(paths ...string) Option {
+	return func(o *Options) {
+		o.configFiles = append(o.configFiles[:len(o.configFiles):len(o.configFiles)], paths...)
+	}
+}
+
+// configFilePaths 返回按合并顺序排列的所有配置文件，每个文件之后紧跟其存在的 profile 变体。
+// (configFilePaths returns every configuration file in merge order, each followed by its profile variant when that exists.)
+func (o *Options) configFilePaths() []string {
+	var paths []string
+	profile := o.activeProfile()
+	add := func(path string) {
+		paths = append(paths, path)
+		if profile == "" {
+			return
+		}
+		if variant := profileFilePath(path, profile); fileExists(variant) {
+			paths = append(paths, variant)
+		}
+	}
+	if o.configFilePath != "" {
+		add(o.configFilePath)
+	}
+	for _, path := range o.configFiles {
+		if path != "" {
+			add(path)
+		}
+	}
+	return paths
+}
+
+// isPrimaryFile 报告 path 是否为 WithConfigFile 设置的文件或其 profile 变体，二者使用显式指定的文件类型。
+// (isPrimaryFile reports whether path is the file set by WithConfigFile or its profile variant; both use the explicit file type.)
+func (o *Options) isPrimaryFile(path string) bool {
+	if o.configFilePath == "" {
+		return false
+	}
+	if path == o.configFilePath {
+		return true
+	}
+	profile := o.activeProfile()
+	return profile != "" && path == profileFilePath(o.configFilePath, profile)
+}
+
+// WithRemoteProvider 返回一个 Option，用于从远程键值存储加载配置，支持 "etcd"（v3 JSON 网关）和 "consul"（KV HTTP API）。
+// 远程文档在所有配置文件之后合并，优先级仅低于环境变量；格式由 keyPath 的扩展名推断，默认 YAML。
+// 启用热重载时监视该键（Consul 使用阻塞查询，etcd 定期轮询），变化时触发与文件相同的回调。
+// (WithRemoteProvider returns an Option to load configuration from a remote key-value store: "etcd" (the v3 JSON gateway) or "consul" (the KV HTTP API).)
+// (The remote document is merged after every config file, below environment variables only; its format is inferred from the
+// extension of keyPath and defaults to YAML. With hot reload enabled the key is watched (Consul with blocking queries, etcd by
+// polling) and a change triggers the same callbacks as a file change.)
+// Parameters:
+//   provider: "etcd" 或 "consul"。
+//             ("etcd" or "consul".)
+//   endpoint: 服务地址，例如 "http://127.0.0.1:8500"；省略 scheme 时使用 http。Consul 的 ACL 令牌读取自 CONSUL_HTTP_TOKEN。
+//             (The service address, e.g. "http://127.0.0.1:8500"; http is assumed without a scheme. The Consul ACL token is read from CONSUL_HTTP_TOKEN.)
+//   keyPath: 保存配置文档的键，例如 "config/orders/app.yaml"。
+//            (The key holding the configuration document, e.g. "config/orders/app.yaml".)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithRemoteProvider(provider, endpoint, keyPath string) Option {
+	return func(o *Options) {
+		o.remote = &remoteOptions{provider: strings.ToLower(provider), endpoint: endpoint, keyPath: keyPath}
+	}
+}
+
 // WithEnvPrefix 返回一个 Option，用于设置查找环境变量时使用的前缀。
 // 例如，如果前缀为 "APP"，则会查找如 APP_SERVER_PORT 这样的变量。
 // (WithEnvPrefix returns an Option to set the prefix used when looking up environment variables.)
@@ -80,6 +183,25 @@ func WithEnvVarOverride(enable bool) Option {
 	}
 }
 
+// WithEnvExpansion 返回一个 Option，用于控制是否展开字符串值中的 ${VAR} 和 ${VAR:-default}，
+// 例如 url: "postgres://${DB_USER}:${DB_PASS}@db:5432/app"。未设置的变量展开为空字符串，${VAR:-default} 在变量未设置或为空时使用 default。
+// 展开在加载和每次热重载时进行，结果与密钥引用一样不写回 Viper。值中需要保留字面量 ${...} 时可禁用。
+// (WithEnvExpansion returns an Option to control whether ${VAR} and ${VAR:-default} are expanded inside string values, e.g.
+// url: "postgres://${DB_USER}:${DB_PASS}@db:5432/app". Unset variables expand to an empty string, and ${VAR:-default} uses
+// default when the variable is unset or empty. Expansion happens on load and on every hot reload, and like secret references
+// the results are never written back to Viper. Disable it when values must keep a literal ${...}.)
+// Parameters:
+//   enable: true 表示启用展开，false 表示禁用。默认为 true。
+//           (true to enable expansion, false to disable. Defaults to true.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithEnvExpansion(enable bool) Option {
+	return func(o *Options) {
+		o.enableEnvExpansion = enable
+	}
+}
+
 // WithHotReload 返回一个 Option，用于启用或禁用配置文件的热重载功能。
 // 如果启用，当配置文件发生更改时，配置将自动重新加载，并触发已注册的回调。
 // (WithHotReload returns an Option to enable or disable the hot-reload feature for the configuration file.)
@@ -95,3 +217,142 @@ func WithHotReload(enable bool) Option {
 		o.enableHotReload = enable
 	}
 }
+
+// WithReloadDebounce 返回一个 Option，用于合并热重载的变更事件：每个事件重新开始计时，最后一个事件之后 d 内没有新事件时才重新加载一次。
+// 编辑器保存或 ConfigMap 同步时文件会在短时间内被多次写入，合并后回调只被调用一次。默认不合并。
+// (WithReloadDebounce returns an Option to coalesce hot-reload change events: every event restarts the timer, and the
+// configuration is reloaded once when no further event arrives within d of the last one. Editors saving and ConfigMap syncs
+// write files several times in quick succession; coalesced, they call the callbacks only once. Events are not coalesced by default.)
+// Parameters:
+//   d: 最后一个变更事件之后的等待时间，例如 200*time.Millisecond；不大于零时禁用合并。
+//      (The quiet period after the last change event, e.g. 200*time.Millisecond; zero or less disables coalescing.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithReloadDebounce(d time.Duration) Option {
+	return func(o *Options) {
+		o.reloadDebounce = d
+	}
+}
+
+// WithCallbackTimeout 返回一个 Option，用于限制每个变更回调的执行时间。回调按注册顺序逐个执行；
+// 超过 d 仍未返回的回调会记录 ErrConfigHotReload 错误，随后继续执行下一个回调，超时的回调在后台运行至结束。
+// 因此超时后，该回调可能与之后的回调以及之后的重载同时运行；但在它返回之前不会再次启动，期间的重载只记下一次待执行的调用，
+// 回调返回后以最新的配置再调用一次。此时通用回调收到的是该版本的配置快照，回调返回后不应继续持有它。默认不限时。
+// (WithCallbackTimeout returns an Option to limit how long each change callback may run. Callbacks run one at a time in
+// registration order; a callback that has not returned after d is logged as an ErrConfigHotReload error and the next
+// callback starts, while the timed-out one runs to completion in the background. After a timeout that callback can
+// therefore overlap the callbacks after it and later reloads, but it is not started again until it returns: reloads in
+// the meantime record one pending call, made with the latest configuration once it returns. General callbacks then receive
+// the configuration snapshot of the version and must not keep it after returning. Callbacks are not limited by default.)
+// Parameters:
+//   d: 单个回调的超时时间；不大于零时不限时。
+//      (The timeout of a single callback; zero or less means no limit.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithCallbackTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		o.callbackTimeout = d
+	}
+}
+
+// WithOnValidationError 返回一个 Option，用于设置热重载得到的新配置未通过校验时调用的回调。
+// 此时新配置不会被应用，当前配置和回调保持不变；fn 收到的错误列出了所有无效字段。
+// (WithOnValidationError returns an Option to set the callback invoked when the new configuration from a hot reload fails validation.)
+// (The new configuration is not applied in that case and the current one is kept without notifying change callbacks;
+// the error passed to fn lists every invalid field.)
+// Parameters:
+//   fn: 接收校验错误的回调。
+//       (The callback receiving the validation error.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithOnValidationError(fn func(err error)) Option {
+	return func(o *Options) {
+		o.onValidationError = fn
+	}
+}
+
+// WithWarningLogger 返回一个 Option，用于设置接收结构化警告（例如配置源中出现的已弃用键）的 Logger。
+// 通常传入 log.Std() 或组件的 Logger；未设置时警告写入标准库日志。
+// (WithWarningLogger returns an Option to set the Logger receiving structured warnings, such as deprecated keys found in
+// the config sources. Usually log.Std() or a component Logger is passed; without it warnings go to the standard library logger.)
+// Parameters:
+//   logger: 接收警告的 Logger，pkg/log 的 Logger 满足 WarningLogger。
+//           (The Logger receiving the warnings; a pkg/log Logger satisfies WarningLogger.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithWarningLogger(logger WarningLogger) Option {
+	return func(o *Options) {
+		o.warningLogger = logger
+	}
+}
+
+// WithSecretResolver 返回一个 Option，用于注册名为 name 的密钥解析器，配置值中的 ${secret:<name>:<ref>} 由它解析。
+// ${env:NAME} 和 ${file:/path} 无需注册即可使用。引用在首次加载和每次热重载时解析，解析失败时加载返回
+// ErrConfigSecret 错误，热重载则保留之前的配置。
+// (WithSecretResolver returns an Option to register a secret resolver called name, which resolves ${secret:<name>:<ref>} in
+// configuration values. ${env:NAME} and ${file:/path} work without registration. References are resolved on the initial load
+// and on every hot reload; a failure makes loading return an ErrConfigSecret error, while a hot reload keeps the previous config.)
+// Parameters:
+//   name: 解析器名称，例如 "vault"，不区分大小写。
+//         (The resolver name, e.g. "vault"; case-insensitive.)
+//   resolver: 解析引用的 SecretResolver，pkg/secrets 中的任意 Provider 均可，例如 secrets.NewVaultProvider()。
+//             (The SecretResolver resolving references; any Provider from pkg/secrets works, e.g. secrets.NewVaultProvider().)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithSecretResolver(name string, resolver SecretResolver) Option {
+	return func(o *Options) {
+		// 复制映射，避免与 defaultOptions 或其他 Options 共享 (Copy the map so it is not shared with defaultOptions or other Options)
+		resolvers := make(map[string]SecretResolver, len(o.secretResolvers)+1)
+		for k, v := range o.secretResolvers {
+			resolvers[k] = v
+		}
+		resolvers[strings.ToLower(name)] = resolver
+		o.secretResolvers = resolvers
+	}
+}
+
+// WithFlagSet 返回一个 Option，将带 `flag:"server-port"` 标签的字段绑定到 fs 中的同名标志，缺少的标志会被自动注册。
+// 优先级为：显式设置的标志 > 环境变量 > 配置文件 > 默认值。标志须在 fs.Parse 之前注册才会出现在帮助信息中，
+// 因此通常先调用 RegisterFlags，解析命令行后再加载配置。
+// (WithFlagSet returns an Option to bind the fields tagged `flag:"server-port"` to the flags of the same name in fs;
+// missing flags are registered automatically. The precedence is: flag set explicitly > environment variable > config file > default.
+// Flags only show up in the help output when registered before fs.Parse, so RegisterFlags is usually called first and the
+// configuration is loaded after parsing the command line.)
+// Parameters:
+//   fs: 已解析或待解析的 FlagSet。
+//       (The FlagSet, parsed or about to be parsed.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithFlagSet(fs *pflag.FlagSet) Option {
+	return func(o *Options) {
+		o.flagSet = fs
+	}
+}
+
+// WithDefaultsProvider 返回一个 Option，用于以代码提供默认值，例如主机名或根据 CPU 数量计算的连接池大小。
+// 加载时 fn 收到一个指向新的零值配置结构体的指针，其中嵌套的结构体指针已分配；fn 设置的非零字段成为默认值，
+// 优先级高于 `default` 标签，低于配置文件、环境变量和命令行标志。零值字段不视为默认值。fn 只在首次加载时调用一次，
+// 得到的默认值在热重载时继续生效。
+// (WithDefaultsProvider returns an Option to provide defaults in code, such as the hostname or pool sizes derived from the
+// CPU count. On load fn receives a pointer to a new zero configuration struct with its nested struct pointers allocated;
+// the non-zero fields fn sets become defaults, taking precedence over `default` tags and below config files, environment
+// variables and flags. Zero fields are not taken as defaults. fn is called once on the initial load, and the defaults it
+// produced keep applying on hot reloads.)
+// Parameters:
+//   fn: 填充默认值的函数，target 与传给 LoadConfig 的配置类型相同；返回的错误使加载以 ErrConfigSetup 失败。
+//       (The function filling in defaults; target has the type of the configuration passed to LoadConfig. A returned error
+//       makes loading fail with ErrConfigSetup.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithDefaultsProvider(fn func(target any) error) Option {
+	return func(o *Options) {
+		o.defaultsProvider = fn
+	}
+}
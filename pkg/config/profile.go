@@ -0,0 +1,60 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProfileEnvVar 是选择配置 profile 的环境变量，设置时优先于 WithProfile。
+// (ProfileEnvVar is the environment variable that selects the configuration profile; when set it takes precedence over WithProfile.)
+const ProfileEnvVar = "APP_PROFILE"
+
+// WithProfile 返回一个 Option，用于选择环境 profile（例如 "production"）。每个配置文件之后紧接着合并其 profile 变体，
+// 变体名称在扩展名前插入 profile：config.yaml 之后合并 config.production.yaml。不存在的变体被跳过。
+// 合并顺序从低到高为：结构体默认值、config.yaml、config.production.yaml、WithConfigFiles 中的每个文件及其变体、
+// 远程文档、环境变量、命令行标志。环境变量 APP_PROFILE 非空时覆盖此处的名称。
+// (WithProfile returns an Option to select an environment profile such as "production". Each configuration file is immediately
+// followed by its profile variant, named by inserting the profile before the extension: config.production.yaml is merged after
+// config.yaml. Variants that do not exist are skipped. Precedence from lowest to highest is: struct defaults, config.yaml,
+// config.production.yaml, each WithConfigFiles file followed by its variant, the remote document, environment variables,
+// command-line flags. A non-empty APP_PROFILE environment variable overrides the name given here.)
+// Parameters:
+//   name: profile 名称，为空时仅使用 APP_PROFILE。
+//         (The profile name; only APP_PROFILE is consulted when empty.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithProfile(name string) Option {
+	return func(o *Options) {
+		o.profile = name
+	}
+}
+
+// activeProfile 返回生效的 profile 名称：APP_PROFILE 优先，其次为 WithProfile 设置的名称。
+// (activeProfile returns the profile in effect: APP_PROFILE first, then the name set by WithProfile.)
+func (o *Options) activeProfile() string {
+	if name := strings.TrimSpace(os.Getenv(ProfileEnvVar)); name != "" {
+		return name
+	}
+	return strings.TrimSpace(o.profile)
+}
+
+// profileFilePath 返回 path 的 profile 变体，例如 config.yaml 与 "production" 得到 config.production.yaml。
+// (profileFilePath returns the profile variant of path, e.g. config.production.yaml for config.yaml and "production".)
+func profileFilePath(path, profile string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+// fileExists 报告 path 是否为已存在的普通文件。(fileExists reports whether path is an existing regular file.)
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,84 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for layering environment profiles over config files.
+ */
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadConfig_Profile tests merging profile variants after their base files and selecting the profile via APP_PROFILE.
+// (TestLoadConfig_Profile 测试在基础文件之后合并 profile 变体，以及通过 APP_PROFILE 选择 profile。)
+func TestLoadConfig_Profile(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "config.yaml", baseConfigContent)
+	writeConfigFile(t, dir, "config.production.yaml", `
+server:
+  port: 443
+log:
+  level: "warn"
+`)
+	writeConfigFile(t, dir, "config.staging.yaml", `server: { port: 8443 }`)
+	override := writeConfigFile(t, dir, "override.json", `{"log": {"level": "error"}}`)
+
+	t.Setenv(ProfileEnvVar, "")
+
+	testCases := []struct {
+		name      string
+		opts      []Option
+		envValue  string
+		wantPort  int
+		wantLevel string
+	}{
+		{name: "NoProfile", opts: []Option{WithConfigFile(base, "")}, wantPort: 8080, wantLevel: "info"},
+		{name: "WithProfile", opts: []Option{WithConfigFile(base, ""), WithProfile("production")}, wantPort: 443, wantLevel: "warn"},
+		{name: "MissingVariantSkipped", opts: []Option{WithConfigFile(base, ""), WithProfile("dev")}, wantPort: 8080, wantLevel: "info"},
+		{name: "EnvVarOverridesOption", opts: []Option{WithConfigFile(base, ""), WithProfile("production")}, envValue: "staging", wantPort: 8443, wantLevel: "info"},
+		{name: "EnvVarAlone", opts: []Option{WithConfigFile(base, "")}, envValue: "production", wantPort: 443, wantLevel: "warn"},
+		{
+			name:     "LaterFilesOverrideProfile",
+			opts:     []Option{WithConfigFile(base, ""), WithConfigFiles(override), WithProfile("production")},
+			wantPort: 443, wantLevel: "error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(ProfileEnvVar, tc.envValue)
+			var cfg testAppConfig
+			require.NoError(t, LoadConfig(&cfg, tc.opts...))
+			assert.Equal(t, tc.wantPort, cfg.Server.Port)
+			assert.Equal(t, tc.wantLevel, cfg.Log.Level)
+			assert.Equal(t, "10.0.0.1", cfg.Server.Host, "keys only in the base file are kept")
+		})
+	}
+}
+
+// TestConfigFilePaths_Profile tests the merge order of base files and their profile variants.
+// (TestConfigFilePaths_Profile 测试基础文件及其 profile 变体的合并顺序。)
+func TestConfigFilePaths_Profile(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "config.yaml", "a: 1")
+	baseProd := writeConfigFile(t, dir, "config.prod.yaml", "a: 2")
+	extra := writeConfigFile(t, dir, "extra.toml", "a = 3")
+	extraProd := writeConfigFile(t, dir, "extra.prod.toml", "a = 4")
+	noExt := writeConfigFile(t, dir, "settings", "a: 5")
+	noExtProd := writeConfigFile(t, dir, "settings.prod", "a: 6")
+
+	t.Setenv(ProfileEnvVar, "")
+	o := defaultOptions
+	for _, opt := range []Option{WithConfigFile(base, ""), WithConfigFiles(extra, noExt), WithProfile("prod")} {
+		opt(&o)
+	}
+	assert.Equal(t, []string{base, baseProd, extra, extraProd, noExt, noExtProd}, o.configFilePaths())
+	assert.Equal(t, filepath.Join(dir, "config.prod.yaml"), profileFilePath(base, "prod"))
+	assert.True(t, o.isPrimaryFile(baseProd))
+	assert.False(t, o.isPrimaryFile(extraProd))
+}
@@ -0,0 +1,61 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for loading configuration from readers and byte slices.
+ */
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWithConfigReader tests loading from readers merged after the config files, with defaults and environment variables applied.
+// (TestWithConfigReader 测试从在配置文件之后合并的 Reader 加载，并应用默认值和环境变量。)
+func TestWithConfigReader(t *testing.T) {
+	var cfg testAppConfig
+	require.NoError(t, LoadFromBytes(&cfg, []byte("server:\n  host: embedded\nlog:\n  level: debug\n"), "yaml"))
+	assert.Equal(t, "embedded", cfg.Server.Host)
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, 8080, cfg.Server.Port, "struct tag defaults still apply")
+
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", "server:\n  host: file\n  port: 9000\nlog:\n  level: warn\n")
+	t.Setenv("READERTEST_LOG_LEVEL", "error")
+	cfg = testAppConfig{}
+	cm, err := LoadConfigAndWatch(&cfg,
+		WithConfigFile(path, ""),
+		WithConfigReader(strings.NewReader(`{"server": {"host": "json"}}`), "JSON"),
+		WithConfigReader(strings.NewReader("[server]\nport = 9100\n"), "toml"),
+		WithEnvPrefix("READERTEST"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "json", cfg.Server.Host, "readers override the files")
+	assert.Equal(t, 9100, cfg.Server.Port, "later readers override earlier ones")
+	assert.Equal(t, "error", cfg.Log.Level, "environment variables override readers")
+
+	// 重新读取配置源时复用 Reader 的内容 (The reader content is reused when the sources are read again)
+	require.NoError(t, cm.(*configManager[testAppConfig]).readConfigSources())
+	assert.Equal(t, "json", cm.GetViperInstance().GetString("server.host"))
+}
+
+// TestWithConfigReader_Errors tests unsupported formats, failing readers and invalid content.
+// (TestWithConfigReader_Errors 测试不受支持的格式、读取失败和无效内容。)
+func TestWithConfigReader_Errors(t *testing.T) {
+	var cfg testAppConfig
+	err := LoadFromBytes(&cfg, []byte("A=1\n"), "env")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+
+	err = LoadConfig(&cfg, WithConfigReader(iotest.ErrReader(errors.New("disk gone")), "yaml"))
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+	assert.Contains(t, err.Error(), "disk gone")
+
+	err = LoadFromBytes(&cfg, []byte("server: [unclosed"), "yaml")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigFileRead))
+}
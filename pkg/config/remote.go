@@ -0,0 +1,330 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+const (
+	// remoteRequestTimeout 是单次远程读取的超时时间。(remoteRequestTimeout bounds a single remote read.)
+	remoteRequestTimeout = 10 * time.Second
+
+	// consulWait 是 Consul 阻塞查询的最长等待时间。(consulWait is the maximum wait of a Consul blocking query.)
+	consulWait = 5 * time.Minute
+
+	// maxRemoteConfigSize 限制远程配置文档的大小。(maxRemoteConfigSize limits the size of a remote configuration document.)
+	maxRemoteConfigSize = 4 << 20
+)
+
+var (
+	// remotePollInterval 是 etcd 轮询的间隔。(remotePollInterval is the polling interval for etcd.)
+	remotePollInterval = 10 * time.Second
+
+	// remoteRetryInterval 是监视读取失败后的重试间隔。(remoteRetryInterval is the retry interval after a failed watch read.)
+	remoteRetryInterval = 5 * time.Second
+)
+
+// remoteOptions 保存 WithRemoteProvider 设置的参数。(remoteOptions holds the parameters set by WithRemoteProvider.)
+type remoteOptions struct {
+	provider string
+	endpoint string
+	keyPath  string
+}
+
+// configType 根据键的扩展名推断文档格式，默认 YAML。
+// (configType infers the document format from the extension of the key, defaulting to YAML.)
+func (o *remoteOptions) configType() string {
+	if o.provider == providerKubernetes && strings.Count(o.keyPath, "/") < 2 {
+		// 未指定数据键的 ConfigMap 按 YAML 解析 (A ConfigMap without a data key is parsed as YAML)
+		return "yaml"
+	}
+	if ext := path.Ext(o.keyPath); len(ext) > 1 {
+		return strings.ToLower(ext[1:])
+	}
+	return "yaml"
+}
+
+// remoteSource 从远程存储读取配置文档。
+// version 非空时，get 会等待文档版本不同于 version（或等待超时）后再返回。
+// (remoteSource reads a configuration document from a remote store.
+// When version is not empty get waits until the document version differs from it, or a wait times out, before returning.)
+type remoteSource interface {
+	name() string
+	get(ctx context.Context, version string) (data []byte, newVersion string, err error)
+}
+
+// newRemoteSource 根据提供者名称创建远程配置源。(newRemoteSource creates the remote source for a provider name.)
+func newRemoteSource(o *remoteOptions) (remoteSource, error) {
+	if o.provider == providerKubernetes {
+		return newKubernetesSource(o)
+	}
+	endpoint := strings.TrimRight(o.endpoint, "/")
+	if endpoint != "" && !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	key := strings.TrimPrefix(o.keyPath, "/")
+	if endpoint == "" || key == "" {
+		return nil, lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigSetup, "remote provider '%s' requires an endpoint and a key path", o.provider)
+	}
+
+	client := &http.Client{}
+	switch o.provider {
+	case "consul":
+		return &consulSource{endpoint: endpoint, key: key, token: os.Getenv("CONSUL_HTTP_TOKEN"), client: client}, nil
+	case "etcd":
+		return &etcdSource{endpoint: endpoint, key: key, pollInterval: remotePollInterval, client: client}, nil
+	default:
+		return nil, lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigSetup, "unsupported remote provider '%s', must be 'etcd' or 'consul'", o.provider)
+	}
+}
+
+// consulSource 通过 Consul KV HTTP API 读取配置，使用阻塞查询等待变化。
+// (consulSource reads configuration through the Consul KV HTTP API and waits for changes with blocking queries.)
+type consulSource struct {
+	endpoint string
+	key      string
+	token    string
+	client   *http.Client
+}
+
+func (s *consulSource) name() string {
+	return "consul://" + strings.TrimPrefix(strings.TrimPrefix(s.endpoint, "http://"), "https://") + "/" + s.key
+}
+
+func (s *consulSource) get(ctx context.Context, version string) ([]byte, string, error) {
+	u := s.endpoint + "/v1/kv/" + s.key + "?raw"
+	timeout := remoteRequestTimeout
+	if version != "" {
+		u += "&index=" + url.QueryEscape(version) + "&wait=" + consulWait.String()
+		timeout += consulWait
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if s.token != "" {
+		req.Header.Set("X-Consul-Token", s.token)
+	}
+	data, header, err := doRemoteRequest(s.client, req)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, header.Get("X-Consul-Index"), nil
+}
+
+// etcdSource 通过 etcd v3 的 JSON 网关读取配置，定期轮询以检测变化。
+// (etcdSource reads configuration through the etcd v3 JSON gateway and polls periodically to detect changes.)
+type etcdSource struct {
+	endpoint     string
+	key          string
+	pollInterval time.Duration
+	client       *http.Client
+}
+
+// etcdRangeResponse 是 /v3/kv/range 的响应。(etcdRangeResponse is the response of /v3/kv/range.)
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Value       string `json:"value"`
+		ModRevision string `json:"mod_revision"`
+	} `json:"kvs"`
+}
+
+func (s *etcdSource) name() string {
+	return "etcd://" + strings.TrimPrefix(strings.TrimPrefix(s.endpoint, "http://"), "https://") + "/" + s.key
+}
+
+func (s *etcdSource) get(ctx context.Context, version string) ([]byte, string, error) {
+	if version != "" {
+		select {
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, remoteRequestTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"key": base64.StdEncoding.EncodeToString([]byte(s.key))})
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/v3/kv/range", bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	data, _, err := doRemoteRequest(s.client, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var resp etcdRangeResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, "", fmt.Errorf("decode etcd range response: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, "", fmt.Errorf("key '%s' not found", s.key)
+	}
+	value, err := base64.StdEncoding.DecodeString(resp.Kvs[0].Value)
+	if err != nil {
+		return nil, "", fmt.Errorf("decode etcd value: %w", err)
+	}
+	return value, resp.Kvs[0].ModRevision, nil
+}
+
+// doRemoteRequest 发送请求并读取响应体，非 2xx 状态码作为错误返回。
+// (doRemoteRequest sends the request and reads the body; non-2xx status codes are returned as errors.)
+func doRemoteRequest(client *http.Client, req *http.Request) ([]byte, http.Header, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteConfigSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil, fmt.Errorf("key not found (status %d)", resp.StatusCode)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, resp.Header, nil
+}
+
+// fetchRemoteConfig 创建远程配置源并读取初始文档。
+// (fetchRemoteConfig creates the remote source and reads the initial document.)
+func (cm *configManager[T]) fetchRemoteConfig() error {
+	source, err := newRemoteSource(cm.options.remote)
+	if err != nil {
+		return err
+	}
+	data, version, err := source.get(context.Background(), "")
+	if err != nil {
+		return lmccerrors.WithCode(
+			lmccerrors.Wrapf(err, "failed to read remote config '%s'", source.name()),
+			lmccerrors.ErrConfigRemote,
+		)
+	}
+	cm.remote, cm.remoteData, cm.remoteVersion = source, data, version
+	return nil
+}
+
+// readConfigSources 依次读取所有配置文件，再合并 WithConfigReader 的内容和远程文档。
+// (readConfigSources reads every config file in order and then merges the content of WithConfigReader and the remote document.)
+func (cm *configManager[T]) readConfigSources() error {
+	hasLocal := len(cm.options.configFilePaths()) > 0
+	if hasLocal {
+		if err := cm.readConfigFiles(); err != nil {
+			return err
+		}
+	}
+	if len(cm.readerData) > 0 {
+		if err := cm.mergeConfigReaders(!hasLocal); err != nil {
+			return err
+		}
+		hasLocal = true
+	}
+	if cm.remote == nil {
+		return nil
+	}
+
+	var err error
+	if hasLocal {
+		// 使用独立的 Viper 解析，避免改变主实例监视文件时使用的配置类型
+		// (Parse with a separate Viper so the config type used by the main instance's file watcher is left untouched)
+		rv := newViper()
+		rv.SetConfigType(cm.options.remote.configType())
+		if err = rv.ReadConfig(bytes.NewReader(cm.remoteData)); err == nil {
+			err = cm.v.MergeConfigMap(rv.AllSettings())
+		}
+	} else {
+		cm.v.SetConfigType(cm.options.remote.configType())
+		err = cm.v.ReadConfig(bytes.NewReader(cm.remoteData))
+	}
+	if err != nil {
+		return lmccerrors.WithCode(
+			lmccerrors.Wrapf(err, "failed to parse remote config '%s'", cm.remote.name()),
+			lmccerrors.ErrConfigRemote,
+		)
+	}
+	return nil
+}
+
+// sourceNames 返回按合并顺序排列的配置源名称。(sourceNames returns the names of the config sources in merge order.)
+func (cm *configManager[T]) sourceNames() []string {
+	names := cm.options.configFilePaths()
+	for _, rd := range cm.readerData {
+		names = append(names, rd.name)
+	}
+	if cm.remote != nil {
+		names = append(names, cm.remote.name())
+	}
+	return names
+}
+
+// watchRemoteConfig 在后台监视远程键，文档变化时调用 reload。读取失败只记录首次错误并按间隔重试。
+// (watchRemoteConfig watches the remote key in the background and calls reload when the document changes.
+// Read failures are logged once and retried at an interval.)
+func (cm *configManager[T]) watchRemoteConfig(reload func(source string)) {
+	pollInterval, retryInterval := remotePollInterval, remoteRetryInterval
+	go func() {
+		version := cm.remoteVersion
+		failing := false
+		for {
+			if version == "" {
+				// 没有版本信息时无法等待变化，退化为轮询 (Without a version there is nothing to wait on, so fall back to polling)
+				time.Sleep(pollInterval)
+			}
+			data, newVersion, err := cm.remote.get(context.Background(), version)
+			if err != nil {
+				if !failing {
+					failing = true
+					log.Printf("Error watching remote config '%s': %v", cm.remote.name(), err)
+				}
+				time.Sleep(retryInterval)
+				continue
+			}
+			if failing {
+				failing = false
+				log.Printf("Info: Remote config '%s' is reachable again.", cm.remote.name())
+			}
+			if newVersion == version {
+				continue
+			}
+			version = newVersion
+
+			cm.reloadMux.Lock()
+			changed := !bytes.Equal(data, cm.remoteData)
+			cm.remoteData, cm.remoteVersion = data, newVersion
+			cm.reloadMux.Unlock()
+			if changed {
+				reload(cm.remote.name())
+			}
+		}
+	}()
+}
@@ -0,0 +1,183 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for loading and watching configuration from etcd and Consul.
+ */
+
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKV 是带版本号的单键存储，set 会唤醒等待中的阻塞查询。
+// (fakeKV is a versioned single-key store; set wakes up pending blocking queries.)
+type fakeKV struct {
+	mu      sync.Mutex
+	value   string
+	index   int
+	changed chan struct{}
+}
+
+func newFakeKV(value string) *fakeKV {
+	return &fakeKV{value: value, index: 1, changed: make(chan struct{})}
+}
+
+func (kv *fakeKV) set(value string) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.value = value
+	kv.index++
+	close(kv.changed)
+	kv.changed = make(chan struct{})
+}
+
+func (kv *fakeKV) snapshot() (string, int, chan struct{}) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	return kv.value, kv.index, kv.changed
+}
+
+// newFakeConsul 模拟 Consul 的 /v1/kv 端点，包括阻塞查询。(newFakeConsul emulates Consul's /v1/kv endpoint, including blocking queries.)
+func newFakeConsul(t *testing.T, key string, kv *fakeKV) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/"+key {
+			http.NotFound(w, r)
+			return
+		}
+		value, index, changed := kv.snapshot()
+		if waitIndex := r.URL.Query().Get("index"); waitIndex == strconv.Itoa(index) {
+			select {
+			case <-changed:
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+				return
+			}
+			value, index, _ = kv.snapshot()
+		}
+		w.Header().Set("X-Consul-Index", strconv.Itoa(index))
+		_, _ = w.Write([]byte(value))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestLoadConfig_Consul tests that the Consul document is merged over the config file and hot-reloaded on change.
+// (TestLoadConfig_Consul 测试 Consul 文档合并在配置文件之上，并在变化时热重载。)
+func TestLoadConfig_Consul(t *testing.T) {
+	kv := newFakeKV("server:\n  port: 9090\n")
+	srv := newFakeConsul(t, "config/orders/app.yaml", kv)
+	base := writeConfigFile(t, t.TempDir(), "base.yaml", baseConfigContent)
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg,
+		WithConfigFile(base, ""),
+		WithRemoteProvider("consul", srv.URL, "/config/orders/app.yaml"),
+		WithHotReload(true),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 9090, cfg.Server.Port, "remote overrides the file")
+	assert.Equal(t, "10.0.0.1", cfg.Server.Host, "file keys absent remotely are kept")
+
+	reloaded := make(chan int, 1)
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		select {
+		case reloaded <- c.(*testAppConfig).Server.Port:
+		default:
+		}
+		return nil
+	})
+
+	kv.set("server:\n  port: 9191\n")
+	select {
+	case port := <-reloaded:
+		assert.Equal(t, 9191, port)
+		assert.Equal(t, "10.0.0.1", cfg.Server.Host)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for reload after the Consul key changed")
+	}
+}
+
+// TestLoadConfig_Etcd tests loading a JSON document from the etcd v3 gateway without any config file, and polling for changes.
+// (TestLoadConfig_Etcd 测试在没有配置文件时从 etcd v3 网关加载 JSON 文档，并轮询变化。)
+func TestLoadConfig_Etcd(t *testing.T) {
+	originalInterval := remotePollInterval
+	remotePollInterval = 50 * time.Millisecond
+	t.Cleanup(func() { remotePollInterval = originalInterval })
+
+	kv := newFakeKV(`{"server": {"port": 7070}, "customFeature": {"apiKey": "remote"}}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Key string `json:"key"`
+		}
+		require.Equal(t, "/v3/kv/range", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		key, _ := base64.StdEncoding.DecodeString(req.Key)
+		if string(key) != "config/app.json" {
+			_, _ = w.Write([]byte(`{"header": {}}`))
+			return
+		}
+		value, index, _ := kv.snapshot()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"kvs": []map[string]string{{
+				"value":        base64.StdEncoding.EncodeToString([]byte(value)),
+				"mod_revision": strconv.Itoa(index),
+			}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithRemoteProvider("etcd", srv.URL, "config/app.json"), WithHotReload(true))
+	require.NoError(t, err)
+	assert.Equal(t, 7070, cfg.Server.Port)
+	assert.Equal(t, "remote", cfg.CustomFeature.APIKey)
+	assert.Equal(t, "0.0.0.0", cfg.Server.Host, "struct defaults fill missing keys")
+
+	reloaded := make(chan int, 1)
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		select {
+		case reloaded <- c.(*testAppConfig).Server.Port:
+		default:
+		}
+		return nil
+	})
+	kv.set(`{"server": {"port": 7171}}`)
+	select {
+	case port := <-reloaded:
+		assert.Equal(t, 7171, port)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for reload after the etcd key changed")
+	}
+
+	var missing testAppConfig
+	err = LoadConfig(&missing, WithRemoteProvider("etcd", srv.URL, "config/absent.json"))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigRemote))
+}
+
+// TestLoadConfig_RemoteProviderInvalid tests rejecting unknown providers and incomplete settings.
+// (TestLoadConfig_RemoteProviderInvalid 测试拒绝未知提供者和不完整的设置。)
+func TestLoadConfig_RemoteProviderInvalid(t *testing.T) {
+	var cfg testAppConfig
+	err := LoadConfig(&cfg, WithRemoteProvider("zookeeper", "127.0.0.1:2181", "config/app.yaml"))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+
+	err = LoadConfig(&cfg, WithRemoteProvider("consul", "", "config/app.yaml"))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+}
@@ -0,0 +1,308 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// schemaDraft 是生成的 JSON Schema 使用的规范版本。(schemaDraft is the specification version of the generated JSON Schema.)
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern 匹配 time.ParseDuration 接受的字符串，例如 "1h30m"。
+// (durationPattern matches the strings accepted by time.ParseDuration, e.g. "1h30m".)
+const durationPattern = `^[-+]?([0-9]*(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+// timeType 是 time.Time 的反射类型。(timeType is the reflect type of time.Time.)
+var timeType = reflect.TypeOf(time.Time{})
+
+// validateFormats 将 `validate` 标签中的格式规则映射为 JSON Schema 的 format。
+// (validateFormats maps format rules of the `validate` tag to JSON Schema formats.)
+var validateFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"hostname": "hostname",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"uuid":     "uuid",
+	"datetime": "date-time",
+}
+
+// GenerateSchema 根据配置结构体生成 JSON Schema（draft 2020-12），可用于编辑器自动补全和在 CI 中校验 YAML 配置文件。
+// 属性名取自 mapstructure 标签，嵌入或带 squash 的结构体会被展开；`default` 标签成为 default，`usage` 标签成为 description，
+// `validate` 标签中的 required、min/max、gt/gte/lt/lte、len、oneof、dive 和 email、url 等格式规则会被转换为对应的约束。
+// 带默认值的 required 字段不会列入 required，因为配置文件可以省略它。time.Duration 接受 "30s" 这样的字符串或纳秒整数。
+// (GenerateSchema generates a JSON Schema (draft 2020-12) from a configuration struct, for editor autocompletion and for
+// validating YAML configuration files in CI. Property names come from mapstructure tags and embedded or squashed structs are
+// flattened; the `default` tag becomes default, the `usage` tag becomes description, and the required, min/max, gt/gte/lt/lte,
+// len, oneof and dive rules of the `validate` tag, as well as formats such as email and url, become the matching constraints.
+// A required field with a default is not listed as required, since the file may omit it. A time.Duration accepts a string such
+// as "30s" or an integer number of nanoseconds.)
+// Parameters:
+//   cfg: 配置结构体或指向它的指针。
+//        (The configuration struct or a pointer to it.)
+// Returns:
+//   []byte: 缩进格式的 JSON Schema 文档。
+//           (The indented JSON Schema document.)
+//   error: cfg 不是结构体时返回 ErrConfigSetup 错误，default 标签无效时返回 ErrConfigDefaultTagParse 错误。
+//          (An ErrConfigSetup error when cfg is not a struct, or an ErrConfigDefaultTagParse error for an invalid default tag.)
+func GenerateSchema(cfg any) ([]byte, error) {
+	typ := reflect.TypeOf(cfg)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigSetup, "cannot generate a schema for %T, expected a struct or a pointer to one", cfg)
+	}
+
+	g := &schemaGenerator{visiting: make(map[reflect.Type]bool)}
+	root, err := g.structSchema(typ)
+	if err != nil {
+		return nil, err
+	}
+	root["$schema"] = schemaDraft
+	if typ.Name() != "" {
+		root["title"] = typ.Name()
+	}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to encode the schema"), lmccerrors.ErrConfigInternal)
+	}
+	return data, nil
+}
+
+// schemaGenerator 生成类型的 schema，visiting 记录正在生成的结构体以截断递归类型。
+// (schemaGenerator builds type schemas; visiting records the structs being built so recursive types are cut off.)
+type schemaGenerator struct {
+	visiting map[reflect.Type]bool
+}
+
+// structSchema 生成结构体的 object schema。(structSchema builds the object schema of a struct.)
+func (g *schemaGenerator) structSchema(typ reflect.Type) (map[string]any, error) {
+	if g.visiting[typ] {
+		// 递归类型在第二层不再展开 (Recursive types are not expanded a second time)
+		return map[string]any{"type": "object"}, nil
+	}
+	g.visiting[typ] = true
+	defer delete(g.visiting, typ)
+
+	properties := make(map[string]any)
+	var required []string
+	if err := g.addFields(typ, properties, &required); err != nil {
+		return nil, err
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		slices.Sort(required)
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// addFields 将结构体的字段加入 properties，嵌入或带 squash 的结构体字段加入同一层级。
+// (addFields adds the fields of a struct to properties; fields of embedded or squashed structs join the same level.)
+func (g *schemaGenerator) addFields(typ reflect.Type, properties map[string]any, required *[]string) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && ((field.Anonymous && name == "") || slices.Contains(tag[1:], "squash")) {
+			// 解码时被展开的结构体 (Structs squashed when decoding)
+			if err := g.addFields(fieldType, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = fieldKey(field)
+		}
+
+		schema, err := g.typeSchema(field.Type)
+		if err != nil {
+			return err
+		}
+		if usage := field.Tag.Get("usage"); usage != "" {
+			schema["description"] = usage
+		}
+		defaultTag := field.Tag.Get("default")
+		hasDefault := defaultTag != ""
+		if hasDefault {
+			value, errParse := parseStringToType(defaultTag, field.Type)
+			if errParse != nil {
+				return lmccerrors.WithCode(
+					lmccerrors.Wrapf(errParse, "invalid default tag '%s' for field %s", defaultTag, field.Name),
+					lmccerrors.ErrConfigDefaultTagParse,
+				)
+			}
+			if _, isDuration := value.(time.Duration); isDuration {
+				value = defaultTag
+			}
+			schema["default"] = value
+		}
+		if applyValidateRules(schema, fieldType, field.Tag.Get("validate")) && !hasDefault {
+			*required = append(*required, name)
+		}
+		properties[name] = schema
+	}
+	return nil
+}
+
+// typeSchema 生成单个类型的 schema。(typeSchema builds the schema of a single type.)
+func (g *schemaGenerator) typeSchema(typ reflect.Type) (map[string]any, error) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ == durationType:
+		return map[string]any{"type": []string{"string", "integer"}, "pattern": durationPattern}, nil
+	case typ == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.typeSchema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := g.typeSchema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.structSchema(typ)
+	default:
+		// interface{} 等任意值不加约束 (Arbitrary values such as interface{} are unconstrained)
+		return map[string]any{}, nil
+	}
+}
+
+// applyValidateRules 将 `validate` 标签规则转换为 schema 约束，"dive" 之后的规则作用于元素，返回字段是否为 required。
+// 无法表达的规则（例如跨字段规则）被忽略。
+// (applyValidateRules turns `validate` tag rules into schema constraints, applying the rules after "dive" to the elements,
+// and reports whether the field is required. Rules that cannot be expressed, such as cross-field ones, are ignored.)
+func applyValidateRules(schema map[string]any, typ reflect.Type, tag string) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+	rules, elemRules, dive := strings.Cut(tag, ",dive")
+	if strings.HasPrefix(tag, "dive") {
+		rules, elemRules, dive = "", strings.TrimPrefix(tag, "dive"), true
+	}
+	if dive {
+		if items, ok := schema["items"].(map[string]any); ok {
+			applyValidateRules(items, typ.Elem(), strings.TrimPrefix(elemRules, ","))
+		} else if values, ok := schema["additionalProperties"].(map[string]any); ok {
+			applyValidateRules(values, typ.Elem(), strings.TrimPrefix(elemRules, ","))
+		}
+	}
+
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if strings.Contains(name, "|") {
+			continue
+		}
+		switch name {
+		case "required":
+			required = true
+		case "min", "gte":
+			setBound(schema, typ, param, "minimum", "minLength", "minItems")
+		case "max", "lte":
+			setBound(schema, typ, param, "maximum", "maxLength", "maxItems")
+		case "gt":
+			setBound(schema, typ, param, "exclusiveMinimum", "", "")
+		case "lt":
+			setBound(schema, typ, param, "exclusiveMaximum", "", "")
+		case "len":
+			setBound(schema, typ, param, "", "minLength", "minItems")
+			setBound(schema, typ, param, "", "maxLength", "maxItems")
+		case "oneof":
+			var values []any
+			for _, option := range strings.Fields(param) {
+				if value, err := parseStringToType(option, typ); err == nil {
+					values = append(values, value)
+				}
+			}
+			if len(values) > 0 {
+				schema["enum"] = values
+			}
+		default:
+			if format, ok := validateFormats[name]; ok {
+				schema["format"] = format
+			}
+		}
+	}
+	return required
+}
+
+// setBound 按类型设置数值、字符串长度、数组长度或对象属性数的边界，对应的关键字为空时忽略。
+// (setBound sets a numeric, string length, array length or property count bound depending on the type; an empty keyword is skipped.)
+func setBound(schema map[string]any, typ reflect.Type, param, numberKey, lengthKey, itemsKey string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	var key string
+	switch typ.Kind() {
+	case reflect.String:
+		key = lengthKey
+	case reflect.Slice, reflect.Array:
+		key = itemsKey
+	case reflect.Map:
+		// 对象使用 minProperties/maxProperties (Objects use minProperties/maxProperties)
+		key = strings.Replace(itemsKey, "Items", "Properties", 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if typ != durationType {
+			key = numberKey
+		}
+	}
+	if key == "" {
+		return
+	}
+	if n, err := strconv.ParseFloat(param, 64); err == nil {
+		if key != numberKey || n == float64(int64(n)) {
+			schema[key] = int64(n)
+		} else {
+			schema[key] = n
+		}
+	}
+}
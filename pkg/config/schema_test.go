@@ -0,0 +1,92 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for generating a JSON Schema from a configuration struct.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaNode struct {
+	Name     string        `mapstructure:"name"`
+	Children []*schemaNode `mapstructure:"children"`
+}
+
+type schemaTestConfig struct {
+	Config `mapstructure:",squash"`
+	Mode   string            `mapstructure:"mode" default:"prod" validate:"oneof=dev prod" usage:"Run mode"`
+	Admin  string            `mapstructure:"admin" validate:"required,email"`
+	Ratio  float64           `mapstructure:"ratio" validate:"gt=0,lte=1.5"`
+	Tags   []string          `mapstructure:"tags" default:"a,b" validate:"max=5,dive,min=2"`
+	Limits map[string]uint16 `mapstructure:"limits" validate:"min=1"`
+	Tree   schemaNode        `mapstructure:"tree"`
+	Ignore string            `mapstructure:"-"`
+	hidden string
+}
+
+// TestGenerateSchema tests property names, types, defaults and constraints derived from tags.
+// (TestGenerateSchema 测试由标签得到的属性名、类型、默认值和约束。)
+func TestGenerateSchema(t *testing.T) {
+	data, err := GenerateSchema(&schemaTestConfig{})
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, schemaDraft, schema["$schema"])
+	assert.Equal(t, "schemaTestConfig", schema["title"])
+	assert.Equal(t, []any{"admin"}, schema["required"], "required fields with a default are optional in the file")
+
+	props := schema["properties"].(map[string]any)
+	assert.NotContains(t, props, "Ignore")
+	assert.NotContains(t, props, "-")
+	assert.NotContains(t, props, "hidden")
+
+	assert.Equal(t, map[string]any{
+		"type": "string", "default": "prod", "enum": []any{"dev", "prod"}, "description": "Run mode",
+	}, props["mode"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "email"}, props["admin"])
+	assert.Equal(t, map[string]any{"type": "number", "exclusiveMinimum": float64(0), "maximum": 1.5}, props["ratio"])
+	assert.Equal(t, map[string]any{
+		"type": "array", "default": []any{"a", "b"}, "maxItems": float64(5),
+		"items": map[string]any{"type": "string", "minLength": float64(2)},
+	}, props["tags"])
+	assert.Equal(t, map[string]any{
+		"type": "object", "minProperties": float64(1),
+		"additionalProperties": map[string]any{"type": "integer", "minimum": float64(0)},
+	}, props["limits"])
+
+	// 嵌入的 Config 被展开到顶层 (The embedded Config is flattened into the top level)
+	server := props["server"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "integer", "default": float64(8080)}, server["port"])
+	assert.Equal(t, map[string]any{
+		"type": []any{"string", "integer"}, "pattern": durationPattern, "default": "5s",
+	}, server["readTimeout"])
+
+	// 递归类型在第二层截断 (Recursive types are cut off at the second level)
+	tree := props["tree"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "object"}}, tree["children"])
+}
+
+// TestGenerateSchema_Errors tests rejecting non-struct values and invalid default tags.
+// (TestGenerateSchema_Errors 测试拒绝非结构体的值和无效的默认值标签。)
+func TestGenerateSchema_Errors(t *testing.T) {
+	_, err := GenerateSchema(42)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+
+	type badDefault struct {
+		Timeout time.Duration `mapstructure:"timeout" default:"soon"`
+	}
+	_, err = GenerateSchema(&badDefault{})
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigDefaultTagParse))
+}
@@ -0,0 +1,203 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/secrets"
+)
+
+// SecretResolver 解析配置值中的密钥引用，例如 ${secret:vault:kv/data/db#password} 中的 "kv/data/db#password"。
+// pkg/secrets 中的所有 Provider 都满足此接口。解析结果只写入解码后的配置结构体和类型化访问器的快照，不会写回 Viper。
+// (SecretResolver resolves a secret reference in a configuration value, e.g. "kv/data/db#password" in
+// ${secret:vault:kv/data/db#password}. Every Provider in pkg/secrets satisfies it. Resolved values only go into the decoded
+// config struct and the snapshot read by the typed accessors; they are never written back to Viper.)
+type SecretResolver interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// SecretResolverFunc 将普通函数适配为 SecretResolver。(SecretResolverFunc adapts a plain function to a SecretResolver.)
+type SecretResolverFunc func(ctx context.Context, key string) (string, error)
+
+// Get 实现 SecretResolver。(Get implements SecretResolver.)
+func (f SecretResolverFunc) Get(ctx context.Context, key string) (string, error) {
+	return f(ctx, key)
+}
+
+// envVarPattern 匹配 ${VAR} 和 ${VAR:-default}。(envVarPattern matches ${VAR} and ${VAR:-default}.)
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// secretRefPattern 匹配 ${env:NAME}、${file:/path} 和 ${secret:resolver:ref}。
+// (secretRefPattern matches ${env:NAME}, ${file:/path} and ${secret:resolver:ref}.)
+var secretRefPattern = regexp.MustCompile(`\$\{(env|file|secret):([^}]*)\}`)
+
+// builtinSecretResolvers 是无需注册即可使用的解析器。(builtinSecretResolvers are the resolvers available without registration.)
+var builtinSecretResolvers = map[string]SecretResolver{
+	"env":  secrets.NewEnvProvider(),
+	"file": secrets.NewFileProvider(),
+}
+
+// secretResolution 在一次加载中解析所有引用，相同引用只解析一次。
+// (secretResolution resolves every reference during one load; each distinct reference is resolved once.)
+type secretResolution struct {
+	ctx       context.Context
+	resolvers map[string]SecretResolver
+	expandEnv bool // 先展开 ${VAR} 和 ${VAR:-default} (Expand ${VAR} and ${VAR:-default} first)
+	cache     map[string]string
+}
+
+// resolveSecrets 返回 settings 的副本，其中字符串值里的密钥引用已替换为解析结果，expandEnv 为 true 时环境变量也已展开；
+// settings 本身不会被修改，因为其中的切片和映射可能与 Viper 共享。错误信息包含配置键和引用，但不包含任何已解析的值。
+// (resolveSecrets returns a copy of settings with the secret references in its string values replaced by their resolved values,
+// and environment variables expanded when expandEnv is true; settings itself is left untouched because its slices and maps may
+// be shared with Viper. Errors name the configuration key and the reference but never a resolved value.)
+func resolveSecrets(ctx context.Context, settings map[string]any, resolvers map[string]SecretResolver, expandEnv bool) (map[string]any, error) {
+	r := &secretResolution{ctx: ctx, resolvers: resolvers, expandEnv: expandEnv, cache: make(map[string]string)}
+	return r.resolveMap(settings, "")
+}
+
+func (r *secretResolution) resolveMap(m map[string]any, prefix string) (map[string]any, error) {
+	// 按键排序，使错误信息稳定 (Sort keys so error messages are stable)
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make(map[string]any, len(m))
+	for _, k := range keys {
+		resolved, err := r.resolveValue(m[k], joinKey(prefix, k))
+		if err != nil {
+			return nil, err
+		}
+		out[k] = resolved
+	}
+	return out, nil
+}
+
+func (r *secretResolution) resolveValue(value any, key string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return r.resolveString(v, key)
+	case map[string]any:
+		return r.resolveMap(v, key)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := r.resolveValue(item, fmt.Sprintf("%s[%d]", key, i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	case []string:
+		out := make([]string, len(v))
+		for i, item := range v {
+			resolved, err := r.resolveString(item, fmt.Sprintf("%s[%d]", key, i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return value, nil
+	}
+}
+
+func (r *secretResolution) resolveString(s, key string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	if r.expandEnv {
+		s = expandEnvVars(s)
+	}
+	var firstErr error
+	out := secretRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if firstErr != nil {
+			return ref
+		}
+		value, err := r.resolve(ref)
+		if err != nil {
+			firstErr = lmccerrors.WithCode(
+				lmccerrors.Wrapf(err, "failed to resolve secret %s for key '%s'", ref, key),
+				lmccerrors.ErrConfigSecret,
+			)
+			return ref
+		}
+		return value
+	})
+	return out, firstErr
+}
+
+func (r *secretResolution) resolve(ref string) (string, error) {
+	if value, ok := r.cache[ref]; ok {
+		return value, nil
+	}
+	match := secretRefPattern.FindStringSubmatch(ref)
+	scheme, target := match[1], match[2]
+	resolver := builtinSecretResolvers[scheme]
+	if scheme == "secret" {
+		name, rest, ok := strings.Cut(target, ":")
+		if !ok || rest == "" {
+			return "", fmt.Errorf("secret reference must have the form ${secret:<resolver>:<ref>}")
+		}
+		name, target = strings.ToLower(name), rest
+		if resolver = r.resolvers[name]; resolver == nil {
+			if resolver = builtinSecretResolvers[name]; resolver == nil {
+				return "", fmt.Errorf("no secret resolver registered for '%s'", name)
+			}
+		}
+	}
+	if target == "" {
+		return "", fmt.Errorf("empty secret reference")
+	}
+	value, err := resolver.Get(r.ctx, target)
+	if err != nil {
+		return "", err
+	}
+	r.cache[ref] = value
+	return value, nil
+}
+
+// resolvedSettings 返回 Viper 合并后的配置，其中的环境变量已展开（除非已禁用），密钥引用已解析。
+// (resolvedSettings returns the merged configuration from Viper with its environment variables expanded, unless disabled,
+// and its secret references resolved.)
+func (cm *configManager[T]) resolvedSettings() (map[string]any, error) {
+	settings := cm.v.AllSettings()
+	cm.applyOverrides(settings)
+	cm.applyDeprecations(settings)
+	return resolveSecrets(context.Background(), settings, cm.options.secretResolvers, cm.options.enableEnvExpansion)
+}
+
+// expandEnvVars 展开 s 中的 ${VAR} 和 ${VAR:-default}；未设置的变量展开为空字符串，default 在变量未设置或为空时使用。
+// (expandEnvVars expands ${VAR} and ${VAR:-default} in s; unset variables expand to an empty string and default is used
+// when the variable is unset or empty.)
+func expandEnvVars(s string) string {
+	return envVarPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		match := envVarPattern.FindStringSubmatch(ref)
+		value := os.Getenv(match[1])
+		if value == "" && match[2] != "" {
+			return match[3]
+		}
+		return value
+	})
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
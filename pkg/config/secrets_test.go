@@ -0,0 +1,190 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for resolving secret references in configuration values.
+ */
+
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadConfig_Secrets tests env, file, custom and Vault references, including references embedded in a longer value.
+// (TestLoadConfig_Secrets 测试 env、file、自定义和 Vault 引用，包括嵌在较长值中的引用。)
+func TestLoadConfig_Secrets(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := writeConfigFile(t, dir, "db_pass", "file-secret\n")
+	t.Setenv("SECRETS_TEST_API_KEY", "env-secret")
+
+	vaultCalls := 0
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vaultCalls++
+		if r.URL.Path != "/v1/kv/data/db" || r.Header.Get("X-Vault-Token") != "root-token" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"user":"app","password":"vault-secret"},"metadata":{"version":3}}}`))
+	}))
+	t.Cleanup(vault.Close)
+
+	path := writeConfigFile(t, dir, "app.yaml", `
+server:
+  host: "${secret:static:host}"
+  port: 8080
+database:
+  user: "${secret:vault:kv/data/db#user}"
+  password: "${secret:vault:kv/data/db#password}"
+  dsn: "postgres://app:${file:`+secretFile+`}@db:5432/orders"
+customFeature:
+  apiKey: "${env:SECRETS_TEST_API_KEY}"
+  tags: ["${env:SECRETS_TEST_API_KEY}", "plain"]
+`)
+	var cfg struct {
+		Server   ServerConfig `mapstructure:"server"`
+		Database struct {
+			User     string `mapstructure:"user"`
+			Password string `mapstructure:"password"`
+			DSN      string `mapstructure:"dsn"`
+		} `mapstructure:"database"`
+		CustomFeature struct {
+			APIKey string   `mapstructure:"apiKey"`
+			Tags   []string `mapstructure:"tags"`
+		} `mapstructure:"customFeature"`
+	}
+	cm, err := LoadConfigAndWatch(&cfg,
+		WithConfigFile(path, ""),
+		WithSecretResolver("vault", secrets.NewVaultProvider(secrets.WithVaultAddress(vault.URL), secrets.WithVaultToken("root-token"))),
+		WithSecretResolver("Static", SecretResolverFunc(func(_ context.Context, ref string) (string, error) {
+			return "resolved-" + ref, nil
+		})),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "resolved-host", cfg.Server.Host)
+	assert.Equal(t, "app", cfg.Database.User)
+	assert.Equal(t, "vault-secret", cfg.Database.Password)
+	assert.Equal(t, 2, vaultCalls, "one request per distinct reference")
+	assert.Equal(t, "postgres://app:file-secret@db:5432/orders", cfg.Database.DSN)
+	assert.Equal(t, "env-secret", cfg.CustomFeature.APIKey)
+	assert.Equal(t, []string{"env-secret", "plain"}, cfg.CustomFeature.Tags)
+
+	assert.Equal(t, "vault-secret", cm.GetString("database.password"), "typed accessors see resolved values")
+	assert.Equal(t, "${secret:vault:kv/data/db#password}", cm.GetViperInstance().GetString("database.password"),
+		"resolved values are not written back to Viper")
+}
+
+// TestLoadConfig_EnvExpansion tests ${VAR} and ${VAR:-default} expansion inside values and disabling it.
+// (TestLoadConfig_EnvExpansion 测试值中 ${VAR} 和 ${VAR:-default} 的展开以及禁用展开。)
+func TestLoadConfig_EnvExpansion(t *testing.T) {
+	t.Setenv("EXPANSION_TEST_USER", "app")
+	t.Setenv("EXPANSION_TEST_PASS", "s3cret")
+	t.Setenv("EXPANSION_TEST_EMPTY", "")
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", `
+server:
+  host: "${EXPANSION_TEST_HOST:-localhost}"
+  port: 8080
+database:
+  dsn: "postgres://${EXPANSION_TEST_USER}:${EXPANSION_TEST_PASS}@db:5432/app"
+  user: "${EXPANSION_TEST_EMPTY:-fallback}"
+  password: "${EXPANSION_TEST_UNSET}"
+  tags: ["${EXPANSION_TEST_USER}", "${env:EXPANSION_TEST_PASS}"]
+`)
+	type expansionConfig struct {
+		Server   ServerConfig `mapstructure:"server"`
+		Database struct {
+			DSN      string   `mapstructure:"dsn"`
+			User     string   `mapstructure:"user"`
+			Password string   `mapstructure:"password"`
+			Tags     []string `mapstructure:"tags"`
+		} `mapstructure:"database"`
+	}
+
+	var cfg expansionConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""), WithEnvVarOverride(false))
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", cfg.Server.Host)
+	assert.Equal(t, "postgres://app:s3cret@db:5432/app", cfg.Database.DSN)
+	assert.Equal(t, "fallback", cfg.Database.User, "the default applies to empty variables too")
+	assert.Empty(t, cfg.Database.Password, "unset variables expand to an empty string")
+	assert.Equal(t, []string{"app", "s3cret"}, cfg.Database.Tags)
+	assert.Equal(t, "postgres://app:s3cret@db:5432/app", cm.GetString("database.dsn"))
+	assert.Equal(t, "postgres://${EXPANSION_TEST_USER}:${EXPANSION_TEST_PASS}@db:5432/app",
+		cm.GetViperInstance().GetString("database.dsn"), "expanded values are not written back to Viper")
+
+	var literal expansionConfig
+	_, err = LoadConfigAndWatch(&literal, WithConfigFile(path, ""), WithEnvVarOverride(false), WithEnvExpansion(false))
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://${EXPANSION_TEST_USER}:${EXPANSION_TEST_PASS}@db:5432/app", literal.Database.DSN)
+	assert.Equal(t, "s3cret", literal.Database.Tags[1], "secret references are still resolved")
+}
+
+// TestLoadConfig_SecretErrors tests that unresolvable references fail loading with ErrConfigSecret.
+// (TestLoadConfig_SecretErrors 测试无法解析的引用使加载失败并返回 ErrConfigSecret。)
+func TestLoadConfig_SecretErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		value   string
+		message string
+	}{
+		{name: "unset env", value: "${env:SECRETS_TEST_UNSET}", message: "'SECRETS_TEST_UNSET' not found"},
+		{name: "missing file", value: "${file:" + filepath.Join(dir, "missing") + "}", message: "not found in file provider"},
+		{name: "unknown resolver", value: "${secret:aws:db/password}", message: "no secret resolver registered for 'aws'"},
+		{name: "malformed", value: "${secret:vault}", message: "${secret:<resolver>:<ref>}"},
+		{name: "resolver error", value: "${secret:failing:db}", message: "permission denied"},
+	}
+	failing := SecretResolverFunc(func(context.Context, string) (string, error) {
+		return "", errors.New("permission denied")
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, dir, "app.yaml", "database:\n  password: \""+tt.value+"\"\n")
+			var cfg testAppConfig
+			_, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""), WithSecretResolver("failing", failing))
+			require.Error(t, err)
+			assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSecret))
+			assert.Contains(t, err.Error(), "database.password")
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+// TestLoadConfig_SecretsHotReload tests that references are resolved again on hot reload and that a failed
+// resolution keeps the previous configuration.
+// (TestLoadConfig_SecretsHotReload 测试热重载时会重新解析引用，且解析失败时保留之前的配置。)
+func TestLoadConfig_SecretsHotReload(t *testing.T) {
+	dir := t.TempDir()
+	first := writeConfigFile(t, dir, "first", "one")
+	second := writeConfigFile(t, dir, "second", "two")
+	path := writeConfigFile(t, dir, "app.yaml", "customFeature:\n  apiKey: \"${file:"+first+"}\"\n  rateLimit: 1\n")
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""), WithHotReload(true))
+	require.NoError(t, err)
+	assert.Equal(t, "one", cfg.CustomFeature.APIKey)
+	time.Sleep(100 * time.Millisecond)
+
+	tmp := writeConfigFile(t, t.TempDir(), "app.yaml", "customFeature:\n  apiKey: \"${file:"+second+"}\"\n  rateLimit: 2\n")
+	require.NoError(t, os.Rename(tmp, path))
+	assert.Eventually(t, func() bool { return cm.GetInt("customFeature.rateLimit") == 2 }, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, "two", cm.GetString("customFeature.apiKey"))
+
+	tmp = writeConfigFile(t, t.TempDir(), "app.yaml", "customFeature:\n  apiKey: \"${file:"+filepath.Join(dir, "gone")+"}\"\n  rateLimit: 3\n")
+	require.NoError(t, os.Rename(tmp, path))
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, 2, cm.GetInt("customFeature.rateLimit"), "a failed resolution keeps the previous config")
+	assert.Equal(t, "two", cm.GetString("customFeature.apiKey"))
+}
@@ -0,0 +1,70 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for the versioned configuration snapshots.
+ */
+
+package config
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestManager_Snapshot tests that snapshots are versioned, survive later reloads unchanged and can be read during reloads.
+// (TestManager_Snapshot 测试快照带有版本号、在之后的重载中保持不变，并且可以在重载期间读取。)
+func TestManager_Snapshot(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", writebackYAML)
+
+	var cfg writebackConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""))
+	require.NoError(t, err)
+
+	first, version := SnapshotOf[writebackConfig](cm)
+	require.NotNil(t, first)
+	assert.Equal(t, uint64(1), version)
+	assert.Equal(t, uint64(1), cm.Version())
+	assert.Equal(t, cfg, *first)
+	assert.NotSame(t, &cfg, first, "the snapshot is a copy of the config struct")
+
+	require.NoError(t, cm.Set("server.port", 9090))
+	second, version := SnapshotOf[writebackConfig](cm)
+	assert.Equal(t, uint64(2), version)
+	assert.Equal(t, 9090, second.Server.Port)
+	assert.Equal(t, 8080, first.Server.Port, "an older snapshot is not modified by a reload")
+
+	require.Error(t, cm.Set("server.port", 70000))
+	assert.Equal(t, uint64(2), cm.Version(), "a rejected configuration does not create a version")
+
+	wrong, version := SnapshotOf[testAppConfig](cm)
+	assert.Nil(t, wrong)
+	assert.Equal(t, uint64(2), version)
+
+	// 并发读取者总是看到一致的配置和版本号 (Concurrent readers always see a matching configuration and version)
+	require.NoError(t, cm.Set("server.port", 9003))
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snapshot, version := SnapshotOf[writebackConfig](cm)
+				assert.Equal(t, 9000+int(version), snapshot.Server.Port)
+			}
+		}()
+	}
+	for port := 9004; port <= 9020; port++ {
+		require.NoError(t, cm.Set("server.port", port))
+	}
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,56 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// WithStrictUnmarshal 返回一个 Option，使配置源中存在但目标结构体中没有对应字段的键成为错误，从而发现 YAML 中的拼写错误，
+// 相当于 Viper 的 ErrorUnused。加载失败时返回带 ErrConfigValidation 的错误，其中每个未知键一项，例如
+// "unknown key 'server.prot'"；Validate 会为其加上文件和行号。热重载时包含未知键的新配置不会被应用。
+// 映射类型的字段和 `mapstructure:",remain"` 字段接受任意键。
+// (WithStrictUnmarshal returns an Option making keys present in the config sources but matching no field of the target
+// struct an error, catching typos in YAML files; it is the equivalent of Viper's ErrorUnused. Loading then fails with an
+// error coded ErrConfigValidation holding one entry per unknown key, such as "unknown key 'server.prot'", which Validate
+// prefixes with the file and line. During hot reload a new configuration with unknown keys is not applied. Map fields and
+// `mapstructure:",remain"` fields accept any key.)
+// Returns:
+//   Option: 应用此配置的 Option 函数。
+//           (The Option function to apply this configuration.)
+func WithStrictUnmarshal() Option {
+	return func(o *Options) {
+		o.strictUnmarshal = true
+	}
+}
+
+// unknownKeyError 是配置源中没有对应字段的键，path 为其在配置中的路径。
+// (unknownKeyError is a key of the config sources matching no field; path is its path in the configuration.)
+type unknownKeyError struct {
+	path string
+}
+
+func (e *unknownKeyError) Error() string {
+	return fmt.Sprintf("unknown key '%s'", e.path)
+}
+
+// checkUnknownKeys 在启用 WithStrictUnmarshal 时，将解码未使用的键汇总为一个带 ErrConfigValidation 的错误。
+// (checkUnknownKeys aggregates the keys left unused by decoding into a single error coded ErrConfigValidation when
+// WithStrictUnmarshal is enabled.)
+func (cm *configManager[T]) checkUnknownKeys(unused []string) error {
+	if !cm.options.strictUnmarshal || len(unused) == 0 {
+		return nil
+	}
+	eg := lmccerrors.NewErrorGroup("config validation failed")
+	for _, path := range slices.Sorted(slices.Values(unused)) {
+		eg.Add(&unknownKeyError{path: path})
+	}
+	return lmccerrors.WithCode(eg, lmccerrors.ErrConfigValidation)
+}
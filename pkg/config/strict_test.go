@@ -0,0 +1,96 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for rejecting unknown configuration keys.
+ */
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strictConfig struct {
+	Server struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port" default:"8080"`
+	} `mapstructure:"server"`
+	Labels  map[string]string `mapstructure:"labels"`
+	Workers []struct {
+		Name string `mapstructure:"name"`
+	} `mapstructure:"workers"`
+}
+
+// strictMessages 返回错误中每一项的消息。(strictMessages returns the message of every entry of err.)
+func strictMessages(t *testing.T, err error) []string {
+	t.Helper()
+	require.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigValidation), "%v", err)
+	var eg *lmccerrors.ErrorGroup
+	require.True(t, errors.As(err, &eg))
+	var messages []string
+	for _, e := range eg.Errors() {
+		messages = append(messages, e.Error())
+	}
+	return messages
+}
+
+// TestWithStrictUnmarshal tests that keys matching no field are reported by path, while map fields accept any key.
+// (TestWithStrictUnmarshal 测试没有对应字段的键按路径报告，而映射字段接受任意键。)
+func TestWithStrictUnmarshal(t *testing.T) {
+	data := []byte(`server:
+  host: api.internal
+  prot: 9000
+labels:
+  team: payments
+workers:
+  - name: mailer
+  - nmae: billing
+timeout: 5s
+`)
+	var cfg strictConfig
+	require.NoError(t, LoadFromBytes(&cfg, data, "yaml"), "unknown keys are ignored by default")
+	assert.Equal(t, 8080, cfg.Server.Port)
+
+	cfg = strictConfig{}
+	err := LoadFromBytes(&cfg, data, "yaml", WithStrictUnmarshal())
+	assert.Equal(t, []string{
+		"unknown key 'server.prot'",
+		"unknown key 'timeout'",
+		"unknown key 'workers[1].nmae'",
+	}, strictMessages(t, err))
+
+	cfg = strictConfig{}
+	t.Setenv("STRICTTEST_SERVER_PORT", "9100")
+	require.NoError(t, LoadFromBytes(&cfg, []byte("server:\n  host: api.internal\nlabels:\n  anything: goes\n"), "yaml",
+		WithStrictUnmarshal(), WithEnvPrefix("STRICTTEST")))
+	assert.Equal(t, 9100, cfg.Server.Port)
+	assert.Equal(t, map[string]string{"anything": "goes"}, cfg.Labels)
+}
+
+// TestWithStrictUnmarshal_Validate tests that Validate reports the file line of every unknown key.
+// (TestWithStrictUnmarshal_Validate 测试 Validate 报告每个未知键所在的文件行。)
+func TestWithStrictUnmarshal_Validate(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", "server:\n  host: api.internal\n  prot: 9000\n")
+	err := Validate(path, &strictConfig{}, WithStrictUnmarshal(), WithEnvVarOverride(false))
+	assert.Equal(t, []string{path + ":3: unknown key 'server.prot'"}, strictMessages(t, err))
+}
+
+// TestWithStrictUnmarshal_Set tests that setting an unknown key at runtime fails and keeps the current configuration.
+// (TestWithStrictUnmarshal_Set 测试运行时设置未知键会失败并保留当前配置。)
+func TestWithStrictUnmarshal_Set(t *testing.T) {
+	var cfg strictConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigReader(strings.NewReader("server:\n  host: api.internal\n"), "yaml"),
+		WithStrictUnmarshal(), WithEnvVarOverride(false))
+	require.NoError(t, err)
+
+	require.NoError(t, cm.Set("server.host", "db.internal"))
+	err = cm.Set("server.hots", "other")
+	assert.Equal(t, []string{"unknown key 'server.hots'"}, strictMessages(t, err))
+	assert.Equal(t, "db.internal", cfg.Server.Host)
+}
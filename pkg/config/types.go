@@ -36,6 +36,34 @@ type Manager interface {
 	// 回调接收 Viper 实例，并负责解组其特定节。)
 	RegisterSectionChangeCallback(sectionKey string, callback SectionChangeCallback)
 
+	// LastChangeSet returns the keys changed by the most recent successful hot reload, with their old and new values,
+	// so callbacks can react only to the keys they care about. It returns nil before any hot reload.
+	// (LastChangeSet 返回最近一次成功热重载中变化的键及其新旧值，使回调只对关心的键作出反应。尚未热重载时返回 nil。)
+	LastChangeSet() ChangeSet
+
+	// Set sets a dot-separated key at runtime; the new configuration is validated, applied and reported to the callbacks.
+	// Set values take precedence over every other source until Save writes them to the config file.
+	// (Set 在运行时设置点分隔的键；新配置经过校验后应用并通知回调。设置的值优先于其他所有来源，直到 Save 将其写入配置文件。)
+	Set(key string, value any) error
+
+	// Save writes the values set with Set back to the highest-precedence config file, keeping YAML comments.
+	// (Save 将通过 Set 设置的值写回优先级最高的配置文件，并保留 YAML 注释。)
+	Save() error
+
+	// Snapshot returns the typed configuration (*T) after the most recent successful load, hot reload or Set, with its
+	// version. A snapshot is never modified during a hot reload, so it can be read concurrently; use SnapshotOf for a typed result.
+	// (Snapshot 返回最近一次成功加载、热重载或 Set 后的类型化配置（*T）及其版本号。快照在热重载期间不会被修改，可以并发读取；
+	// 需要类型化结果时使用 SnapshotOf。)
+	Snapshot() (cfg any, version uint64)
+
+	// Version returns the configuration version: 1 after the initial load, incremented by every successful hot reload or Set.
+	// (Version 返回配置的版本号：首次加载后为 1，每次成功的热重载或 Set 加 1。)
+	Version() uint64
+
+	// Values provides typed, concurrency-safe lookups of the latest loaded configuration values.
+	// (Values 提供对最新加载的配置值的类型化、并发安全的查找。)
+	Values
+
 	// TODO: Consider adding StopWatch() or similar to control the watcher lifecycle if needed.
 }
 
@@ -0,0 +1,113 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// Validator 可由配置结构体实现，用于标签无法表达的校验（例如字段之间的约束）。
+// 它在 `validate` 标签校验之后、每次加载和热重载时调用。
+// (Validator may be implemented by a configuration struct for checks that tags cannot express, such as constraints between fields.)
+// (It is called after the `validate` tag rules, on every load and hot reload.)
+type Validator interface {
+	Validate() error
+}
+
+// squashedFieldName 标记被展开到父结构体中的字段，报告路径时省略。
+// (squashedFieldName marks fields squashed into their parent struct; it is left out of reported paths.)
+const squashedFieldName = "<squash>"
+
+// structValidator 按 `validate` 标签校验配置，字段以 mapstructure 名称报告。
+// (structValidator checks configuration against `validate` tags, reporting fields by their mapstructure names.)
+var structValidator = newStructValidator()
+
+func newStructValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")
+		name := tag[0]
+		// 解码时嵌入字段会被展开 (Embedded fields are squashed when decoding)
+		if (field.Anonymous && name == "") || slices.Contains(tag[1:], "squash") {
+			return squashedFieldName
+		}
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// validateConfig 依次执行 `validate` 标签规则和 Validator 接口，将所有失败汇总为一个带 ErrConfigValidation 的错误。
+// (validateConfig runs the `validate` tag rules and then the Validator interface, aggregating every failure
+// into a single error coded ErrConfigValidation.)
+func validateConfig(cfg any) error {
+	eg := lmccerrors.NewErrorGroup("config validation failed")
+
+	var fieldErrs validator.ValidationErrors
+	if err := structValidator.Struct(cfg); errors.As(err, &fieldErrs) {
+		for _, fe := range fieldErrs {
+			eg.Add(&fieldError{path: fieldPath(fe), rule: ruleString(fe)})
+		}
+	} else if err != nil {
+		var invalid *validator.InvalidValidationError
+		if !errors.As(err, &invalid) {
+			eg.Add(err)
+		}
+		// 非结构体配置没有可校验的标签 (Non-struct configuration has no tags to check)
+	}
+
+	if v, ok := cfg.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			eg.Add(err)
+		}
+	}
+
+	if !eg.HasErrors() {
+		return nil
+	}
+	return lmccerrors.WithCode(eg, lmccerrors.ErrConfigValidation)
+}
+
+// fieldError 是一个未通过 `validate` 标签规则的字段，path 为其在配置中的路径。
+// (fieldError is a field failing a `validate` tag rule; path is its path in the configuration.)
+type fieldError struct {
+	path string
+	rule string
+}
+
+func (e *fieldError) Error() string {
+	return fmt.Sprintf("field '%s' failed rule '%s'", e.path, e.rule)
+}
+
+// fieldPath 返回字段在配置中的路径，例如 "server.port"。(fieldPath returns the path of the field in the configuration, e.g. "server.port".)
+func fieldPath(fe validator.FieldError) string {
+	segments := strings.Split(fe.Namespace(), ".")[1:] // 去掉根结构体名称 (Drop the root struct name)
+	path := segments[:0]
+	for _, segment := range segments {
+		if segment != squashedFieldName {
+			path = append(path, segment)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// ruleString 返回失败的规则及其参数，例如 "max=65535"。(ruleString returns the failed rule with its parameter, e.g. "max=65535".)
+func ruleString(fe validator.FieldError) string {
+	if fe.Param() == "" {
+		return fe.Tag()
+	}
+	return fe.Tag() + "=" + fe.Param()
+}
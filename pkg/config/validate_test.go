@@ -0,0 +1,137 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for validating configuration with struct tags and the Validator interface.
+ */
+
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatedListener struct {
+	Host string `mapstructure:"host" validate:"required"`
+	Port int    `mapstructure:"port" validate:"min=1,max=65535"`
+}
+
+type validatedConfig struct {
+	Listener validatedListener `mapstructure:"listener"`
+	Mode     string            `mapstructure:"mode" default:"prod" validate:"oneof=dev prod"`
+	MinConns int               `mapstructure:"minConns"`
+	MaxConns int               `mapstructure:"maxConns" default:"10"`
+}
+
+// Validate 检查标签无法表达的字段间约束。(Validate checks a cross-field constraint tags cannot express.)
+func (c *validatedConfig) Validate() error {
+	if c.MinConns > c.MaxConns {
+		return errors.New("minConns must not exceed maxConns")
+	}
+	return nil
+}
+
+// TestLoadConfig_Validation tests that every invalid field is reported in one aggregated error.
+// (TestLoadConfig_Validation 测试所有无效字段都在一个聚合错误中报告。)
+func TestLoadConfig_Validation(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", `
+listener:
+  port: 70000
+mode: staging
+minConns: 20
+`)
+	var cfg validatedConfig
+	err := LoadConfig(&cfg, WithConfigFile(path, ""))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigValidation))
+
+	var eg *lmccerrors.ErrorGroup
+	require.True(t, errors.As(err, &eg))
+	assert.Equal(t, 4, eg.Len())
+	assert.Contains(t, err.Error(), "field 'listener.host' failed rule 'required'")
+	assert.Contains(t, err.Error(), "field 'listener.port' failed rule 'max=65535'")
+	assert.Contains(t, err.Error(), "field 'mode' failed rule 'oneof=dev prod'")
+	assert.Contains(t, err.Error(), "minConns must not exceed maxConns")
+
+	valid := writeConfigFile(t, t.TempDir(), "app.yaml", "listener:\n  host: localhost\n  port: 8080\n")
+	var validCfg validatedConfig
+	require.NoError(t, LoadConfig(&validCfg, WithConfigFile(valid, "")))
+	assert.Equal(t, "prod", validCfg.Mode)
+}
+
+// TestLoadConfig_ValidationEmbedded tests that fields of an embedded struct are reported without the embedded type name.
+// (TestLoadConfig_ValidationEmbedded 测试嵌入结构体的字段在报告时不包含嵌入类型名。)
+func TestLoadConfig_ValidationEmbedded(t *testing.T) {
+	type embeddedConfig struct {
+		Config
+		Region string `mapstructure:"region" validate:"required"`
+	}
+	var cfg embeddedConfig
+	err := LoadConfig(&cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field 'region' failed rule 'required'")
+}
+
+// TestLoadConfigAndWatch_InvalidReload tests that an invalid change is refused, the previous config is kept
+// and the OnValidationError callback is invoked instead of the change callbacks.
+// (TestLoadConfigAndWatch_InvalidReload 测试无效的变更被拒绝、保留之前的配置，并调用 OnValidationError 回调而不是变更回调。)
+func TestLoadConfigAndWatch_InvalidReload(t *testing.T) {
+	dir := t.TempDir()
+	path := writeConfigFile(t, dir, "app.yaml", "listener:\n  host: localhost\n  port: 8080\nmode: dev\n")
+
+	validationErrs := make(chan error, 1)
+	var cfg validatedConfig
+	cm, err := LoadConfigAndWatch(&cfg,
+		WithConfigFile(path, ""),
+		WithHotReload(true),
+		WithOnValidationError(func(err error) {
+			select {
+			case validationErrs <- err:
+			default:
+			}
+		}),
+	)
+	require.NoError(t, err)
+
+	reloaded := make(chan int, 4)
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		select {
+		case reloaded <- c.(*validatedConfig).Listener.Port:
+		default:
+		}
+		return nil
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	replaceFile := func(content string) {
+		tmp := writeConfigFile(t, t.TempDir(), "app.yaml", content)
+		require.NoError(t, os.Rename(tmp, path))
+	}
+
+	replaceFile("listener:\n  host: localhost\n  port: 0\nmode: dev\n")
+	select {
+	case err := <-validationErrs:
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigValidation))
+		assert.Contains(t, err.Error(), "listener.port")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for the validation error callback")
+	}
+	assert.Equal(t, 8080, cfg.Listener.Port, "the previous config is kept")
+	assert.Empty(t, reloaded, "change callbacks are not invoked for an invalid config")
+
+	replaceFile("listener:\n  host: localhost\n  port: 9090\nmode: dev\n")
+	select {
+	case port := <-reloaded:
+		assert.Equal(t, 9090, port)
+		assert.Equal(t, 9090, cfg.Listener.Port)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for reload after a valid change")
+	}
+}
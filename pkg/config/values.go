@@ -0,0 +1,126 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// Values 按点分隔的键（例如 "server.host"）读取配置值，键不区分大小写。
+// 值取自最近一次成功加载或热重载的配置（包括文件、环境变量和 `default` 标签），可并发调用。
+// 键不存在或无法转换为目标类型时返回可选的默认值，未提供默认值时返回零值。
+// (Values reads configuration values by dotted, case-insensitive keys such as "server.host".
+// Values come from the most recent successful load or hot reload, including files, environment variables and `default` tags,
+// and may be read concurrently. When a key is missing or cannot be converted to the requested type the optional default is
+// returned, or the zero value when no default is given.)
+type Values interface {
+	// Get 返回键的原始值，以及键是否存在。(Get returns the raw value of the key and whether the key exists.)
+	Get(key string) (any, bool)
+	// IsSet 报告键是否存在。(IsSet reports whether the key exists.)
+	IsSet(key string) bool
+	// GetString 返回字符串值。(GetString returns a string value.)
+	GetString(key string, defaultValue ...string) string
+	// GetInt 返回整数值。(GetInt returns an int value.)
+	GetInt(key string, defaultValue ...int) int
+	// GetBool 返回布尔值。(GetBool returns a bool value.)
+	GetBool(key string, defaultValue ...bool) bool
+	// GetFloat64 返回浮点数值。(GetFloat64 returns a float64 value.)
+	GetFloat64(key string, defaultValue ...float64) float64
+	// GetDuration 返回时长，字符串按 time.ParseDuration 解析（例如 "5s"），数字按纳秒解析。
+	// (GetDuration returns a duration; strings are parsed with time.ParseDuration, e.g. "5s", and numbers are nanoseconds.)
+	GetDuration(key string, defaultValue ...time.Duration) time.Duration
+	// GetStringSlice 返回字符串切片，字符串值按空白拆分。
+	// (GetStringSlice returns a string slice; a string value is split on white space.)
+	GetStringSlice(key string, defaultValue ...[]string) []string
+}
+
+// storeSettings 保存当前配置的快照，供 Values 方法无锁读取。
+// 只在配置加载或校验通过后调用，使快照与配置结构体保持一致。
+// (storeSettings saves a snapshot of the current configuration for the Values methods to read without locks.
+// It is only called once the configuration is loaded and validated, so the snapshot matches the config struct.)
+func (cm *configManager[T]) storeSettings(settings map[string]any) {
+	cm.settings.Store(&settings)
+}
+
+// Get 实现 Values。(Get implements Values.)
+func (cm *configManager[T]) Get(key string) (any, bool) {
+	settings := cm.settings.Load()
+	if settings == nil {
+		return nil, false
+	}
+	return nestedValue(*settings, key)
+}
+
+// nestedValue 返回 settings 中点分隔的键对应的值，键不区分大小写。
+// (nestedValue returns the value of a dotted, case-insensitive key in settings.)
+func nestedValue(settings map[string]any, key string) (any, bool) {
+	var current any = settings
+	for _, segment := range strings.Split(strings.ToLower(key), ".") {
+		section, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = section[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// IsSet 实现 Values。(IsSet implements Values.)
+func (cm *configManager[T]) IsSet(key string) bool {
+	_, ok := cm.Get(key)
+	return ok
+}
+
+// GetString 实现 Values。(GetString implements Values.)
+func (cm *configManager[T]) GetString(key string, defaultValue ...string) string {
+	return getValue(cm, key, cast.ToStringE, defaultValue)
+}
+
+// GetInt 实现 Values。(GetInt implements Values.)
+func (cm *configManager[T]) GetInt(key string, defaultValue ...int) int {
+	return getValue(cm, key, cast.ToIntE, defaultValue)
+}
+
+// GetBool 实现 Values。(GetBool implements Values.)
+func (cm *configManager[T]) GetBool(key string, defaultValue ...bool) bool {
+	return getValue(cm, key, cast.ToBoolE, defaultValue)
+}
+
+// GetFloat64 实现 Values。(GetFloat64 implements Values.)
+func (cm *configManager[T]) GetFloat64(key string, defaultValue ...float64) float64 {
+	return getValue(cm, key, cast.ToFloat64E, defaultValue)
+}
+
+// GetDuration 实现 Values。(GetDuration implements Values.)
+func (cm *configManager[T]) GetDuration(key string, defaultValue ...time.Duration) time.Duration {
+	return getValue(cm, key, cast.ToDurationE, defaultValue)
+}
+
+// GetStringSlice 实现 Values。(GetStringSlice implements Values.)
+func (cm *configManager[T]) GetStringSlice(key string, defaultValue ...[]string) []string {
+	return getValue(cm, key, cast.ToStringSliceE, defaultValue)
+}
+
+// getValue 查找键并用 convert 转换，键不存在或转换失败时返回默认值。
+// (getValue looks up the key and converts it with convert, returning the default when the key is missing or conversion fails.)
+func getValue[V any](values Values, key string, convert func(any) (V, error), defaultValue []V) V {
+	if raw, ok := values.Get(key); ok && raw != nil {
+		if v, err := convert(raw); err == nil {
+			return v
+		}
+	}
+	var zero V
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return zero
+}
@@ -0,0 +1,89 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for typed value accessors on Manager.
+ */
+
+package config
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestManager_Values tests typed lookups over file values, struct tag defaults and default fallbacks.
+// (TestManager_Values 测试对文件值、结构体标签默认值和默认回退值的类型化查找。)
+func TestManager_Values(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", baseConfigContent+`
+cache:
+  ttl: 90s
+  hosts: [redis-a, redis-b]
+  ratio: 0.25
+`)
+	var cfg struct {
+		Server ServerConfig `mapstructure:"server"`
+	}
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, "10.0.0.1", cm.GetString("server.host"))
+	assert.Equal(t, 8080, cm.GetInt("server.port"))
+	assert.Equal(t, 8080, cm.GetInt("Server.Port"), "keys are case-insensitive")
+	assert.True(t, cm.GetBool("customFeature.enabled"))
+	assert.Equal(t, 90*time.Second, cm.GetDuration("cache.ttl"))
+	assert.Equal(t, []string{"redis-a", "redis-b"}, cm.GetStringSlice("cache.hosts"))
+	assert.InDelta(t, 0.25, cm.GetFloat64("cache.ratio"), 1e-9)
+	assert.Equal(t, 10*time.Second, cm.GetDuration("server.writeTimeout"), "struct tag defaults are visible")
+
+	assert.False(t, cm.IsSet("cache.missing"))
+	assert.Equal(t, "fallback", cm.GetString("cache.missing", "fallback"))
+	assert.Equal(t, 3, cm.GetInt("server.host", 3), "unconvertible values use the default")
+	assert.Zero(t, cm.GetInt("cache.missing"))
+	assert.Equal(t, time.Minute, cm.GetDuration("cache.hosts.ttl", time.Minute), "paths through non-maps are missing")
+
+	section, ok := cm.Get("cache")
+	require.True(t, ok)
+	assert.IsType(t, map[string]any{}, section)
+}
+
+// TestManager_ValuesHotReload tests that lookups see reloaded values and may run concurrently with reloads.
+// (TestManager_ValuesHotReload 测试查找能看到重载后的值，并可与重载并发执行。)
+func TestManager_ValuesHotReload(t *testing.T) {
+	dir := t.TempDir()
+	path := writeConfigFile(t, dir, "app.yaml", baseConfigContent)
+
+	var cfg testAppConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""), WithHotReload(true))
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = cm.GetInt("server.port")
+				_ = cm.GetString("customFeature.apiKey")
+			}
+		}
+	}()
+
+	tmp := writeConfigFile(t, t.TempDir(), "app.yaml", "server:\n  host: 10.0.0.2\n  port: 9090\n")
+	require.NoError(t, os.Rename(tmp, path))
+	assert.Eventually(t, func() bool { return cm.GetInt("server.port") == 9090 }, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, "10.0.0.2", cm.GetString("server.host"))
+	assert.Equal(t, "none", cm.GetString("customFeature.apiKey", "none"), "removed keys fall back to the default")
+
+	close(stop)
+	wg.Wait()
+}
@@ -9,6 +9,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -218,4 +219,110 @@ func TestConfigCallback_Registration(t *testing.T) {
 	assert.Equal(t, int32(1), cb2Count.Load(), "Callback 2 should have been called once")
 	// We can't easily assert the logged aggregate error without log capturing.
 	// The primary check here is that callbacks are invoked as expected.
-} 
\ No newline at end of file
+} 
+// TestConfigCallback_OrderAndTimeout tests that general and section callbacks run serially in registration order
+// and that a callback exceeding WithCallbackTimeout does not hold up the next one.
+// (TestConfigCallback_OrderAndTimeout 测试通用回调和特定节回调按注册顺序逐个执行，且超过 WithCallbackTimeout 的回调不会阻塞下一个回调。)
+func TestConfigCallback_OrderAndTimeout(t *testing.T) {
+	var loadedCfg testAppConfig
+	cm := newConfigManager(&loadedCfg, WithCallbackTimeout(50*time.Millisecond))
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+	release := make(chan struct{})
+	defer close(release)
+
+	cm.RegisterSectionChangeCallback("server", func(v *viper.Viper) error { record("server"); return nil })
+	cm.RegisterCallback(func(v *viper.Viper, cfg any) error { record("general-1"); return nil })
+	cm.RegisterSectionChangeCallback("log", func(v *viper.Viper) error {
+		record("log-slow")
+		<-release
+		return nil
+	})
+	cm.RegisterCallback(func(v *viper.Viper, cfg any) error { record("general-2"); return nil })
+	cm.RegisterSectionChangeCallback("server", func(v *viper.Viper) error { record("server-2"); return nil })
+
+	changes := ChangeSet{{Key: "log.level"}}
+	cm.lastChanges.Store(&changes)
+	start := time.Now()
+	cm.notifyCallbacks()
+	assert.Less(t, time.Since(start), time.Second, "the slow callback is abandoned after its timeout")
+
+	mu.Lock()
+	assert.Equal(t, []string{"general-1", "log-slow", "general-2"}, order, "unchanged sections are skipped and the rest run in order")
+	order = nil
+	mu.Unlock()
+
+	// 超时的回调返回之前不会再次启动，期间的变更合并为一次待执行的调用 (A timed-out callback is not started again before it
+	// returns; the changes in the meantime are merged into one pending call)
+	cm.notifyCallbacks()
+	cm.notifyCallbacks()
+	mu.Lock()
+	assert.Equal(t, []string{"general-1", "general-2", "general-1", "general-2"}, order, "the callback still running is deferred")
+	order = nil
+	mu.Unlock()
+
+	release <- struct{}{}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Contains(order, "log-slow")
+	}, time.Second, 10*time.Millisecond, "the deferred call runs once the previous run returned, without another reload")
+	release <- struct{}{}
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []string{"log-slow"}, order, "the deferred changes are delivered in a single call")
+	mu.Unlock()
+}
+
+// TestConfigCallback_TimeoutSnapshot tests that with WithCallbackTimeout general callbacks receive the snapshot of the
+// current version, which later reloads never write.
+// (TestConfigCallback_TimeoutSnapshot 测试设置 WithCallbackTimeout 时，通用回调收到当前版本的快照，之后的重载不会写入该快照。)
+func TestConfigCallback_TimeoutSnapshot(t *testing.T) {
+	var loadedCfg testAppConfig
+	cm := newConfigManager(&loadedCfg, WithCallbackTimeout(time.Second))
+	cm.storeSnapshot(loadedCfg)
+
+	var got any
+	cm.RegisterCallback(func(v *viper.Viper, cfg any) error { got = cfg; return nil })
+	cm.notifyCallbacks()
+
+	snapshot, _ := cm.Snapshot()
+	assert.Same(t, snapshot, got)
+	assert.NotSame(t, &loadedCfg, got)
+}
+
+// TestConfigHotReload_Debounce tests that WithReloadDebounce coalesces a burst of writes into a single reload.
+// (TestConfigHotReload_Debounce 测试 WithReloadDebounce 将一连串写入合并为一次重载。)
+func TestConfigHotReload_Debounce(t *testing.T) {
+	configFile := writeConfigFile(t, t.TempDir(), "config.yaml", "server:\n  port: 8080\n")
+
+	var loadedCfg testAppConfig
+	cm, err := LoadConfigAndWatch(&loadedCfg, WithConfigFile(configFile, ""), WithHotReload(true),
+		WithReloadDebounce(300*time.Millisecond))
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	var lastPort atomic.Int64
+	cm.RegisterCallback(func(_ *viper.Viper, c any) error {
+		calls.Add(1)
+		lastPort.Store(int64(c.(*testAppConfig).Server.Port))
+		return nil
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	for port := 9001; port <= 9005; port++ {
+		require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf("server:\n  port: %d\n", port)), 0644))
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	require.Eventually(t, func() bool { return calls.Load() > 0 }, 5*time.Second, 20*time.Millisecond)
+	time.Sleep(600 * time.Millisecond)
+	assert.Equal(t, int32(1), calls.Load(), "the burst of writes reloads once")
+	assert.Equal(t, int64(9005), lastPort.Load(), "the reload sees the last write")
+}
@@ -0,0 +1,98 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for typed configuration section watches.
+ */
+
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchDatabaseConfig struct {
+	Host    string        `mapstructure:"host" default:"localhost"`
+	Port    int           `mapstructure:"port" default:"5432"`
+	Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+}
+
+type watchConfig struct {
+	Database watchDatabaseConfig `mapstructure:"database"`
+	Server   struct {
+		Port int `mapstructure:"port" default:"8080"`
+	} `mapstructure:"server"`
+}
+
+// TestWatch tests that a typed watch receives the old and new values of its section only.
+// (TestWatch 测试类型化订阅只接收其配置节的新旧值。)
+func TestWatch(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", "database:\n  host: db.internal\n")
+	var cfg watchConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""), WithEnvVarOverride(false))
+	require.NoError(t, err)
+
+	var db watchDatabaseConfig
+	type call struct{ old, new watchDatabaseConfig }
+	var calls []call
+	reject := false
+	require.NoError(t, Watch(cm, "Database", &db, func(old, new watchDatabaseConfig) error {
+		calls = append(calls, call{old, new})
+		if reject {
+			return errors.New("rejected")
+		}
+		return nil
+	}))
+	initial := watchDatabaseConfig{Host: "db.internal", Port: 5432, Timeout: 5 * time.Second}
+	assert.Equal(t, initial, db, "the current value is decoded right away, defaults included")
+
+	require.NoError(t, cm.Set("server.port", 9000))
+	assert.Empty(t, calls, "changes outside the section are not reported")
+
+	require.NoError(t, cm.Set("database.timeout", "10s"))
+	updated := watchDatabaseConfig{Host: "db.internal", Port: 5432, Timeout: 10 * time.Second}
+	require.Len(t, calls, 1)
+	assert.Equal(t, call{initial, updated}, calls[0])
+	assert.Equal(t, updated, db)
+
+	reject = true
+	require.NoError(t, cm.Set("database.port", 5433))
+	require.Len(t, calls, 2)
+	assert.Equal(t, 5433, calls[1].new.Port)
+	assert.Equal(t, updated, db, "a rejected change leaves the target unchanged")
+
+	reject = false
+	require.NoError(t, cm.Set("database.port", 5434))
+	require.Len(t, calls, 3)
+	assert.Equal(t, updated, calls[2].old, "the old value is the last accepted one")
+	assert.Equal(t, 5434, db.Port)
+}
+
+// TestWatch_InvalidArguments tests that Watch rejects missing arguments and undecodable sections.
+// (TestWatch_InvalidArguments 测试 Watch 拒绝缺失的参数和无法解码的配置节。)
+func TestWatch_InvalidArguments(t *testing.T) {
+	var cfg watchConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithEnvVarOverride(false))
+	require.NoError(t, err)
+
+	noop := func(old, new int) error { return nil }
+	var port int
+	err = Watch(cm, "", &port, noop)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+	assert.Error(t, Watch(cm, "server.port", nil, noop))
+	assert.Error(t, Watch[int](cm, "server.port", &port, nil))
+
+	require.NoError(t, Watch(cm, "server.port", &port, noop))
+	assert.Equal(t, 8080, port, "a single key can be watched too")
+
+	var db watchDatabaseConfig
+	err = Watch(cm, "server.port", &db, func(old, new watchDatabaseConfig) error { return nil })
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+}
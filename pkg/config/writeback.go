@@ -0,0 +1,253 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// override 是通过 Set 设置、尚未保存的值。(override is a value set with Set that has not been saved yet.)
+type override struct {
+	key   string // 调用方给出的键，写入文件时保留其大小写 (The key as given by the caller, whose case is kept when written to a file)
+	value any
+}
+
+// Set 在运行时将 key（点分隔，例如 "log.level"）设置为 value。新配置经过解码和校验后替换当前配置并触发回调；
+// 校验失败时返回错误并保留当前配置。设置的值优先于文件、远程文档、环境变量和命令行标志，直到调用 Save。
+// (Set sets key, dot separated such as "log.level", to value at runtime. The new configuration is decoded and validated,
+// then replaces the current one and triggers the callbacks; when validation fails an error is returned and the current
+// configuration is kept. Set values take precedence over files, the remote document, environment variables and flags until Save.)
+// Parameters:
+//   key: 配置键，不区分大小写。
+//        (The configuration key, case-insensitive.)
+//   value: 新值，与配置文件中的值一样经过弱类型解码。
+//          (The new value, weakly decoded like values from a config file.)
+// Returns:
+//   error: 键为空或新配置无效时返回错误。
+//          (An error if the key is empty or the new configuration is invalid.)
+func (cm *configManager[T]) Set(key string, value any) error {
+	name := strings.ToLower(strings.TrimSpace(key))
+	if name == "" {
+		return lmccerrors.NewWithCode(lmccerrors.ErrConfigSetup, "config key must not be empty")
+	}
+
+	cm.reloadMux.Lock()
+	defer cm.reloadMux.Unlock()
+	if cm.overrides == nil {
+		cm.overrides = make(map[string]override)
+	}
+	previous, had := cm.overrides[name]
+	cm.overrides[name] = override{key: strings.TrimSpace(key), value: value}
+	if err := cm.applySettings(); err != nil {
+		if had {
+			cm.overrides[name] = previous
+		} else {
+			delete(cm.overrides, name)
+		}
+		return lmccerrors.Wrapf(err, "failed to set config key '%s'", key)
+	}
+	return nil
+}
+
+// Save 将通过 Set 设置的值写回优先级最高的配置文件（WithConfigFile 或 WithConfigFiles 中最后合并的文件），
+// 其余内容保持不变。YAML 文件中的注释和键的顺序会被保留；JSON 和 TOML 文件重新格式化。文件以原子替换的方式写入，
+// 启用热重载时会像外部修改一样被重新加载。保存后这些值来自文件，环境变量和命令行标志重新按正常优先级生效。
+// (Save writes the values set with Set back to the highest-precedence config file, the last one merged from WithConfigFile
+// and WithConfigFiles, leaving the rest of its content untouched. Comments and key order are kept in YAML files; JSON and
+// TOML files are reformatted. The file is replaced atomically and, with hot reload enabled, reloaded like any external edit.
+// After saving, the values come from the file, so environment variables and flags take their normal precedence again.)
+// Returns:
+//   error: 没有可写的配置文件、格式不受支持或写入失败时返回错误。
+//          (An error if there is no writable config file, the format is unsupported or writing fails.)
+func (cm *configManager[T]) Save() error {
+	cm.reloadMux.Lock()
+	defer cm.reloadMux.Unlock()
+	if len(cm.overrides) == 0 {
+		return nil
+	}
+	paths := cm.options.configFilePaths()
+	if len(paths) == 0 || cm.options.kubernetesProjected {
+		return lmccerrors.NewWithCode(lmccerrors.ErrConfigSetup, "no writable config file to save to")
+	}
+	path := paths[len(paths)-1]
+
+	fileType := cm.options.fileType(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to read config file '%s'", path), lmccerrors.ErrConfigFileRead)
+	}
+
+	entries := make([]override, 0, len(cm.overrides))
+	for _, o := range cm.overrides {
+		entries = append(entries, o)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	var out []byte
+	switch fileType {
+	case "yaml", "yml":
+		out, err = setYAMLValues(data, entries)
+	case "json":
+		out, err = setMapValues(data, entries, json.Unmarshal, func(v any) ([]byte, error) {
+			b, errMarshal := json.MarshalIndent(v, "", "  ")
+			return append(b, '\n'), errMarshal
+		})
+	case "toml":
+		out, err = setMapValues(data, entries, toml.Unmarshal, toml.Marshal)
+	default:
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrConfigSetup, "saving config files of type '%s' is not supported", fileType)
+	}
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to update config file '%s'", path), lmccerrors.ErrConfigSetup)
+	}
+	if err := writeFileAtomic(path, out); err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to write config file '%s'", path), lmccerrors.ErrConfigSetup)
+	}
+	cm.overrides = nil
+	return nil
+}
+
+// applyOverrides 将通过 Set 设置的值写入 settings。(applyOverrides writes the values set with Set into settings.)
+func (cm *configManager[T]) applyOverrides(settings map[string]any) {
+	for name, o := range cm.overrides {
+		setNestedValue(settings, strings.Split(name, "."), o.value)
+	}
+}
+
+// setNestedValue 将 path 指向的值设置为 value，按需创建中间映射。
+// (setNestedValue sets the value at path to value, creating intermediate maps as needed.)
+func setNestedValue(m map[string]any, path []string, value any) {
+	for _, part := range path[:len(path)-1] {
+		next, ok := m[part].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			m[part] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
+
+// setYAMLValues 在 YAML 节点树中更新各个值，保留注释和键的顺序；已有的键按不区分大小写的方式匹配。
+// (setYAMLValues updates each value in the YAML node tree, keeping comments and key order; existing keys are matched case-insensitively.)
+func setYAMLValues(data []byte, entries []override) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	for _, e := range entries {
+		var value yaml.Node
+		if err := value.Encode(e.value); err != nil {
+			return nil, err
+		}
+		node := doc.Content[0]
+		for _, part := range strings.Split(e.key, ".") {
+			if node.Kind != yaml.MappingNode {
+				*node = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: node.HeadComment, LineComment: node.LineComment}
+			}
+			node = yamlMapValue(node, part)
+		}
+		value.HeadComment, value.LineComment, value.FootComment = node.HeadComment, node.LineComment, node.FootComment
+		*node = value
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlMapValue 返回映射节点中 key 对应的值节点，不存在时追加一个空节点。
+// (yamlMapValue returns the value node for key in a mapping node, appending an empty one when missing.)
+func yamlMapValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return mapping.Content[i+1]
+		}
+	}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	return value
+}
+
+// setMapValues 将文件解码为映射、更新各个值后重新编码；已有的键按不区分大小写的方式匹配。
+// (setMapValues decodes the file into a map, updates each value and encodes it again; existing keys are matched case-insensitively.)
+func setMapValues(data []byte, entries []override, unmarshal func([]byte, any) error, marshal func(any) ([]byte, error)) ([]byte, error) {
+	doc := make(map[string]any)
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range entries {
+		m := doc
+		parts := strings.Split(e.key, ".")
+		for i, part := range parts {
+			for existing := range m {
+				if strings.EqualFold(existing, part) {
+					part = existing
+					break
+				}
+			}
+			if i == len(parts)-1 {
+				m[part] = e.value
+				break
+			}
+			next, ok := m[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				m[part] = next
+			}
+			m = next
+		}
+	}
+	return marshal(doc)
+}
+
+// writeFileAtomic 先写入同目录下的临时文件再重命名，并保留原文件的权限。
+// (writeFileAtomic writes to a temporary file in the same directory and renames it, keeping the original file's permissions.)
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,119 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for setting configuration values at runtime and saving them back to the config file.
+ */
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writebackServer struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port" default:"8080" validate:"min=1,max=65535"`
+}
+
+type writebackConfig struct {
+	Server  writebackServer `mapstructure:"server"`
+	LogMode string          `mapstructure:"logMode" default:"text"`
+}
+
+const writebackYAML = `# Service settings
+server:
+  host: "localhost" # bind address
+  # listening port
+  port: 8080
+logMode: json
+`
+
+// TestManager_Set tests applying a value at runtime, notifying callbacks and rejecting invalid values.
+// (TestManager_Set 测试在运行时应用值、通知回调以及拒绝无效的值。)
+func TestManager_Set(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "app.yaml", writebackYAML)
+	t.Setenv("WBTEST_SERVER_PORT", "9000")
+
+	var cfg writebackConfig
+	cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""), WithEnvPrefix("WBTEST"))
+	require.NoError(t, err)
+	require.Equal(t, 9000, cfg.Server.Port)
+
+	var notified int
+	cm.RegisterSectionChangeCallback("server", func(*viper.Viper) error { notified++; return nil })
+
+	require.NoError(t, cm.Set("server.PORT", 9090))
+	assert.Equal(t, 9090, cfg.Server.Port, "Set takes precedence over environment variables")
+	assert.Equal(t, 9090, cm.GetInt("server.port"))
+	assert.Equal(t, 1, notified)
+	assert.True(t, cm.LastChangeSet().Changed("server.port"))
+
+	err = cm.Set("server.port", 70000)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigValidation))
+	assert.Equal(t, 9090, cfg.Server.Port, "an invalid value keeps the current configuration")
+	assert.Equal(t, 1, notified)
+
+	assert.Error(t, cm.Set(" ", 1))
+}
+
+// TestManager_Save tests writing set values back to YAML, keeping comments, and to JSON.
+// (TestManager_Save 测试将设置的值写回 YAML（保留注释）和 JSON 文件。)
+func TestManager_Save(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		path := writeConfigFile(t, t.TempDir(), "app.yaml", writebackYAML)
+		var cfg writebackConfig
+		cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""))
+		require.NoError(t, err)
+
+		require.NoError(t, cm.Save(), "nothing to save is not an error")
+		require.NoError(t, cm.Set("server.port", 9090))
+		require.NoError(t, cm.Set("logmode", "logfmt"))
+		require.NoError(t, cm.Save())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `# Service settings
+server:
+  host: "localhost" # bind address
+  # listening port
+  port: 9090
+logMode: logfmt
+`, string(data))
+
+		var reloaded writebackConfig
+		require.NoError(t, LoadConfig(&reloaded, WithConfigFile(path, "")))
+		assert.Equal(t, cfg, reloaded)
+	})
+
+	t.Run("JSONNewKey", func(t *testing.T) {
+		path := writeConfigFile(t, t.TempDir(), "app.json", `{"server": {"host": "localhost", "port": 8080}}`)
+		var cfg writebackConfig
+		cm, err := LoadConfigAndWatch(&cfg, WithConfigFile(path, ""))
+		require.NoError(t, err)
+
+		require.NoError(t, cm.Set("logMode", "json"))
+		require.NoError(t, cm.Save())
+
+		var reloaded writebackConfig
+		require.NoError(t, LoadConfig(&reloaded, WithConfigFile(path, "")))
+		assert.Equal(t, "json", reloaded.LogMode)
+		assert.Equal(t, 8080, reloaded.Server.Port)
+	})
+
+	t.Run("NoFile", func(t *testing.T) {
+		var cfg writebackConfig
+		cm, err := LoadConfigAndWatch(&cfg)
+		require.NoError(t, err)
+		require.NoError(t, cm.Set("server.port", 81))
+		err = cm.Save()
+		require.Error(t, err)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrConfigSetup))
+	})
+}
@@ -0,0 +1,45 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package debug provides a unified admin/debug HTTP server that runs on a separate
+port from the application's public traffic.
+(debug 包提供了一个统一的管理/调试 HTTP 服务器，它运行在与应用公共流量分离的端口上。)
+
+Endpoints (each can be toggled in Options):
+(端点（每个端点都可以在 Options 中开关）：)
+
+  - /debug/pprof/  Go runtime profiling (Go 运行时性能分析)
+  - /metrics       metrics exposition, handler supplied via WithMetricsHandler (指标暴露，处理器通过 WithMetricsHandler 提供)
+  - /healthz       liveness, overridable via WithHealthHandler (存活检查，可通过 WithHealthHandler 覆盖)
+  - /readyz        readiness, running the checks of the registry given via WithHealthRegistry (就绪检查，执行通过 WithHealthRegistry 提供的注册表中的检查)
+  - /loglevel      GET/PUT the global log level, audited by log.LevelHandler (查询/调整全局日志级别，由 log.LevelHandler 记录审计日志)
+  - /config        redacted configuration dump (脱敏后的配置输出)
+  - /buildinfo     Go version, module and VCS information (Go 版本、模块和 VCS 信息)
+
+The server is enabled via a single configuration section:
+(服务器通过单个配置节启用：)
+
+	type AppConfig struct {
+		Debug debug.Options `mapstructure:"debug"`
+	}
+
+	cm, _ := config.LoadConfigAndWatch(&cfg, config.WithConfigFile("config.yaml", ""))
+	srv, err := debug.Run(ctx, &cfg.Debug,
+		debug.WithConfigManager(cm),
+		debug.WithVersion(version),
+	)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	if srv != nil {
+		defer srv.Stop(context.Background())
+	}
+
+The server listens on 127.0.0.1 by default; expose it deliberately.
+(服务器默认监听 127.0.0.1；如需对外暴露请显式配置。)
+*/
+package debug
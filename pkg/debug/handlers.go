@@ -0,0 +1,196 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"runtime"
+	rtdebug "runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// redactedValue 是脱敏后替换敏感值的占位符。
+// (redactedValue is the placeholder that replaces sensitive values.)
+const redactedValue = "******"
+
+// defaultRedactKeys 是默认在配置输出中脱敏的键名片段。
+// (defaultRedactKeys are the key fragments redacted from the config output by default.)
+var defaultRedactKeys = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "api-key", "privatekey", "private_key", "private-key", "credential"}
+
+// writeJSON 以 JSON 格式写入响应。
+// (writeJSON writes the response as JSON.)
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Warnw("Failed to encode debug response", "error", err)
+	}
+}
+
+// writeError 以 JSON 格式写入错误响应。
+// (writeError writes an error response as JSON.)
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// handleHealth 是默认的存活检查处理器。
+// (handleHealth is the default liveness handler.)
+func handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleConfig 输出脱敏后的当前配置。
+// (handleConfig writes the current configuration with secrets redacted.)
+func (s *Server) handleConfig(w http.ResponseWriter, _ *http.Request) {
+	cfg, err := toGeneric(s.configProvider())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to serialize config: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, redact(cfg, s.redactKeys))
+}
+
+// Redact 将任意配置值转换为由 map/slice/标量组成的通用结构，并脱敏键名包含默认敏感片段或 extraKeys 的值，与 /config 端点的输出一致。
+// (Redact converts an arbitrary config value into a generic map/slice/scalar structure and redacts values whose key contains
+// a default sensitive fragment or one of extraKeys, matching the output of the /config endpoint.)
+func Redact(v any, extraKeys ...string) (any, error) {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	return redact(generic, append(append([]string(nil), defaultRedactKeys...), extraKeys...)), nil
+}
+
+// BuildInfo 是 /buildinfo 端点的响应体。
+// (BuildInfo is the response body of the /buildinfo endpoint.)
+type BuildInfo struct {
+	Version   string            `json:"version,omitempty"`
+	GoVersion string            `json:"go_version"`
+	Path      string            `json:"path,omitempty"`
+	Main      string            `json:"main,omitempty"`
+	Settings  map[string]string `json:"settings,omitempty"`
+	Deps      map[string]string `json:"deps,omitempty"`
+}
+
+// handleBuildInfo 输出二进制文件中嵌入的构建信息。
+// (handleBuildInfo writes the build information embedded in the binary.)
+func (s *Server) handleBuildInfo(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, ReadBuildInfo(s.version))
+}
+
+// ReadBuildInfo 读取二进制文件中嵌入的构建信息，version 为应用自报的版本。
+// (ReadBuildInfo reads the build information embedded in the binary; version is the version reported by the application.)
+func ReadBuildInfo(version string) BuildInfo {
+	info := BuildInfo{
+		Version:   version,
+		GoVersion: runtime.Version(),
+	}
+	if bi, ok := rtdebug.ReadBuildInfo(); ok {
+		info.Path = bi.Path
+		info.Main = bi.Main.Version
+		info.Settings = make(map[string]string, len(bi.Settings))
+		for _, setting := range bi.Settings {
+			info.Settings[setting.Key] = setting.Value
+		}
+		info.Deps = make(map[string]string, len(bi.Deps))
+		for _, dep := range bi.Deps {
+			info.Deps[dep.Path] = dep.Version
+		}
+	}
+	return info
+}
+
+// handleIndex 列出所有已启用的端点。
+// (handleIndex lists all enabled endpoints.)
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	endpoints := append([]string(nil), s.endpoints...)
+	sort.Strings(endpoints)
+	writeJSON(w, http.StatusOK, map[string]any{"endpoints": endpoints})
+}
+
+// toGeneric 将任意配置值转换为由 map/slice/标量组成的通用结构，以便统一脱敏。
+// (toGeneric converts an arbitrary config value into a generic map/slice/scalar structure for uniform redaction.)
+func toGeneric(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if m, ok := v.(map[string]any); ok {
+		return m, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// redact 递归地复制值，并将键名包含敏感片段的值替换为占位符。
+// (redact recursively copies the value, replacing values whose key contains a sensitive fragment with a placeholder.)
+func redact(v any, keys []string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, child := range val {
+			if isSensitiveKey(k, keys) && !isEmpty(child) {
+				out[k] = redactedValue
+				continue
+			}
+			out[k] = redact(child, keys)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, child := range val {
+			out[i] = redact(child, keys)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// isSensitiveKey 判断键名是否包含任一敏感片段（不区分大小写）。
+// (isSensitiveKey reports whether the key contains any sensitive fragment, case-insensitively.)
+func isSensitiveKey(key string, fragments []string) bool {
+	lower := strings.ToLower(key)
+	for _, fragment := range fragments {
+		if fragment != "" && strings.Contains(lower, strings.ToLower(fragment)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty 判断值是否为空，空值无需脱敏，保留原样有助于排查缺失配置。
+// (isEmpty reports whether the value is empty; empty values are kept as-is to help diagnose missing config.)
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	default:
+		return false
+	}
+}
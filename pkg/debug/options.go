@@ -0,0 +1,116 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package debug
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Options 定义了调试服务器的配置选项。
+// (Options defines configuration options for the debug server.)
+// 通常作为应用配置中的 "debug" 节加载。
+// (It is typically loaded as the "debug" section of the application configuration.)
+type Options struct {
+	// Enabled 控制是否启动调试服务器。
+	// (Enabled controls whether the debug server is started.)
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Host 是调试服务器监听的地址，默认仅监听本地回环地址。
+	// (Host is the address the debug server listens on; loopback only by default.)
+	Host string `json:"host" mapstructure:"host"`
+
+	// Port 是调试服务器监听的端口，为 0 时由系统分配。
+	// (Port is the port the debug server listens on; 0 lets the system choose one.)
+	Port int `json:"port" mapstructure:"port"`
+
+	// EnablePprof 启用 /debug/pprof/ 下的性能分析端点。
+	// (EnablePprof enables the profiling endpoints under /debug/pprof/.)
+	EnablePprof bool `json:"enable-pprof" mapstructure:"enable-pprof"`
+
+	// EnableMetrics 启用 /metrics 端点（需通过 WithMetricsHandler 提供处理器）。
+	// (EnableMetrics enables the /metrics endpoint; a handler must be supplied via WithMetricsHandler.)
+	EnableMetrics bool `json:"enable-metrics" mapstructure:"enable-metrics"`
+
+	// EnableHealth 启用 /healthz 端点，以及通过 WithHealthRegistry 提供注册表时的 /readyz 端点。
+	// (EnableHealth enables the /healthz endpoint, and the /readyz endpoint when a registry is given via WithHealthRegistry.)
+	EnableHealth bool `json:"enable-health" mapstructure:"enable-health"`
+
+	// EnableLogLevel 启用 /loglevel 端点，用于查询和调整全局日志级别。
+	// (EnableLogLevel enables the /loglevel endpoint for reading and changing the global log level.)
+	EnableLogLevel bool `json:"enable-log-level" mapstructure:"enable-log-level"`
+
+	// EnableConfigDump 启用 /config 端点，输出脱敏后的当前配置。
+	// (EnableConfigDump enables the /config endpoint, which dumps the current configuration with secrets redacted.)
+	EnableConfigDump bool `json:"enable-config-dump" mapstructure:"enable-config-dump"`
+
+	// EnableBuildInfo 启用 /buildinfo 端点。
+	// (EnableBuildInfo enables the /buildinfo endpoint.)
+	EnableBuildInfo bool `json:"enable-build-info" mapstructure:"enable-build-info"`
+
+	// ReadTimeout 是读取请求的超时时间。
+	// (ReadTimeout is the timeout for reading requests.)
+	ReadTimeout time.Duration `json:"read-timeout" mapstructure:"read-timeout"`
+
+	// WriteTimeout 是写入响应的超时时间。pprof 的 profile 采集需要比采样时长更长的超时。
+	// (WriteTimeout is the timeout for writing responses. CPU profiling needs a timeout longer than the sampling duration.)
+	WriteTimeout time.Duration `json:"write-timeout" mapstructure:"write-timeout"`
+
+	// ShutdownTimeout 是优雅关闭的最长等待时间。
+	// (ShutdownTimeout is the maximum time to wait for a graceful shutdown.)
+	ShutdownTimeout time.Duration `json:"shutdown-timeout" mapstructure:"shutdown-timeout"`
+}
+
+// NewOptions 创建具有默认值的调试服务器选项 (creates debug server options with default values)
+func NewOptions() *Options {
+	return &Options{
+		Enabled:          false,       // 默认关闭，需显式开启 (Disabled by default, must be enabled explicitly)
+		Host:             "127.0.0.1", // 默认仅本地访问 (Local access only by default)
+		Port:             6060,        // pprof 惯用端口 (Conventional pprof port)
+		EnablePprof:      true,
+		EnableMetrics:    true,
+		EnableHealth:     true,
+		EnableLogLevel:   true,
+		EnableConfigDump: true,
+		EnableBuildInfo:  true,
+		ReadTimeout:      10 * time.Second,
+		WriteTimeout:     60 * time.Second, // 覆盖默认 30s 的 CPU profile (Covers the default 30s CPU profile)
+		ShutdownTimeout:  5 * time.Second,
+	}
+}
+
+// Validate 验证调试服务器选项是否有效。
+// (Validate validates if the debug server options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.Port < 0 || o.Port > 65535 {
+		errs = append(errs, fmt.Errorf("invalid debug port %d, must be between 0 and 65535", o.Port))
+	}
+
+	if o.ReadTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid debug read timeout '%s', must not be negative", o.ReadTimeout))
+	}
+
+	if o.WriteTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid debug write timeout '%s', must not be negative", o.WriteTimeout))
+	}
+
+	if o.ShutdownTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid debug shutdown timeout '%s', must not be negative", o.ShutdownTimeout))
+	}
+
+	return errs
+}
+
+// Address 返回调试服务器的监听地址。
+// (Address returns the listen address of the debug server.)
+func (o *Options) Address() string {
+	return net.JoinHostPort(o.Host, strconv.Itoa(o.Port))
+}
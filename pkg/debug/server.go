@@ -0,0 +1,286 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package debug
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/profile"
+)
+
+// ServerOption 定义了配置调试服务器的函数类型。
+// (ServerOption defines a function type for configuring the debug server.)
+type ServerOption func(*Server)
+
+// WithMetricsHandler 设置 /metrics 端点的处理器。
+// (WithMetricsHandler sets the handler for the /metrics endpoint.)
+func WithMetricsHandler(h http.Handler) ServerOption {
+	return func(s *Server) {
+		s.metricsHandler = h
+	}
+}
+
+// WithHealthHandler 设置 /healthz 端点的处理器，替换默认的存活响应。
+// (WithHealthHandler sets the handler for the /healthz endpoint, replacing the default liveness response.)
+func WithHealthHandler(h http.Handler) ServerOption {
+	return func(s *Server) {
+		s.healthHandler = h
+	}
+}
+
+// WithHealthRegistry 使用 registry 提供健康端点：/healthz 为存活检查，/readyz 执行 registry 中的所有检查。
+// 同时设置 WithHealthHandler 时，/healthz 使用该处理器。
+// (WithHealthRegistry serves the health endpoints from registry: /healthz is the liveness probe and /readyz runs every
+// check in registry. When WithHealthHandler is also given, /healthz uses that handler.)
+func WithHealthRegistry(registry *healthcheck.Registry) ServerOption {
+	return func(s *Server) {
+		s.healthRegistry = registry
+	}
+}
+
+// WithConfigProvider 设置 /config 端点使用的配置来源。
+// (WithConfigProvider sets the configuration source used by the /config endpoint.)
+func WithConfigProvider(provider func() any) ServerOption {
+	return func(s *Server) {
+		s.configProvider = provider
+	}
+}
+
+// WithConfigManager 使用 config.Manager 的当前设置作为 /config 端点的配置来源。
+// (WithConfigManager uses the current settings of a config.Manager as the source of the /config endpoint.)
+func WithConfigManager(cm config.Manager) ServerOption {
+	return func(s *Server) {
+		s.configProvider = func() any {
+			return cm.GetViperInstance().AllSettings()
+		}
+	}
+}
+
+// WithVersion 设置 /buildinfo 端点报告的应用版本。
+// (WithVersion sets the application version reported by the /buildinfo endpoint.)
+func WithVersion(version string) ServerOption {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithRedactKeys 添加在 /config 输出中需要脱敏的额外键名片段（不区分大小写）。
+// (WithRedactKeys adds extra key fragments, matched case-insensitively, to redact in the /config output.)
+func WithRedactKeys(keys ...string) ServerOption {
+	return func(s *Server) {
+		s.redactKeys = append(s.redactKeys, keys...)
+	}
+}
+
+// Server 是聚合了运维端点的调试 HTTP 服务器，运行在独立端口上。
+// (Server is a debug HTTP server aggregating operational endpoints on a separate port.)
+type Server struct {
+	opts           *Options
+	mux            *http.ServeMux
+	httpServer     *http.Server
+	listener       net.Listener
+	metricsHandler http.Handler
+	healthHandler  http.Handler
+	healthRegistry *healthcheck.Registry
+	configProvider func() any
+	version        string
+	redactKeys     []string
+	endpoints      []string
+	mu             sync.Mutex
+	done           chan struct{}
+}
+
+// NewServer 根据选项创建调试服务器，但不会开始监听。
+// (NewServer creates a debug server from the options without starting to listen.)
+func NewServer(opts *Options, serverOpts ...ServerOption) (*Server, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid debug server options"),
+			lmccerrors.ErrDebugOptionInvalid,
+		)
+	}
+
+	s := &Server{
+		opts:       opts,
+		mux:        http.NewServeMux(),
+		redactKeys: append([]string(nil), defaultRedactKeys...),
+	}
+	for _, opt := range serverOpts {
+		opt(s)
+	}
+	s.registerRoutes()
+	return s, nil
+}
+
+// registerRoutes 根据选项注册启用的端点。
+// (registerRoutes registers the enabled endpoints according to the options.)
+func (s *Server) registerRoutes() {
+	if s.opts.EnablePprof {
+		profile.RegisterHandlers(s.mux)
+		s.endpoints = append(s.endpoints, "/debug/pprof/")
+	}
+	if s.opts.EnableMetrics && s.metricsHandler != nil {
+		s.handle("/metrics", s.metricsHandler)
+	}
+	if s.opts.EnableHealth {
+		h := s.healthHandler
+		switch {
+		case h != nil:
+		case s.healthRegistry != nil:
+			h = s.healthRegistry.LivenessHandler()
+		default:
+			h = http.HandlerFunc(handleHealth)
+		}
+		s.handle(healthcheck.LivenessPath, h)
+		if s.healthRegistry != nil {
+			s.handle(healthcheck.ReadinessPath, s.healthRegistry.ReadinessHandler())
+		}
+	}
+	if s.opts.EnableLogLevel {
+		s.handle("/loglevel", log.LevelHandler())
+	}
+	if s.opts.EnableConfigDump && s.configProvider != nil {
+		s.handle("/config", http.HandlerFunc(s.handleConfig))
+	}
+	if s.opts.EnableBuildInfo {
+		s.handle("/buildinfo", http.HandlerFunc(s.handleBuildInfo))
+	}
+	s.mux.HandleFunc("/", s.handleIndex)
+}
+
+// handle 注册端点并记录到索引列表中。
+// (handle registers an endpoint and records it in the index list.)
+func (s *Server) handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+	s.endpoints = append(s.endpoints, pattern)
+}
+
+// Handler 返回调试服务器的 HTTP 处理器，便于挂载到已有服务器或用于测试。
+// (Handler returns the HTTP handler of the debug server, for mounting on an existing server or testing.)
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Start 开始在配置的地址上监听并在后台提供服务，Stop 之后可以再次调用。
+// 监听失败会同步返回；ctx 被取消时服务器会自动关闭。
+// (Start begins listening on the configured address and serves in the background; it can be called again after Stop.)
+// (Listen failures are returned synchronously; the server shuts down when ctx is cancelled.)
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener != nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrDebugServerStart, "debug server already started")
+	}
+
+	ln, err := net.Listen("tcp", s.opts.Address())
+	if err != nil {
+		return lmccerrors.WithCode(
+			lmccerrors.Wrapf(err, "failed to listen on %s", s.opts.Address()),
+			lmccerrors.ErrDebugServerStart,
+		)
+	}
+	// 关闭后的 http.Server 不能再次使用，每次启动都新建一个 (A shut down http.Server cannot be reused, so each start creates one)
+	srv := &http.Server{
+		Handler:      s.mux,
+		ReadTimeout:  s.opts.ReadTimeout,
+		WriteTimeout: s.opts.WriteTimeout,
+	}
+	done := make(chan struct{})
+	s.listener, s.httpServer, s.done = ln, srv, done
+
+	log.Infow("Debug server started", "addr", ln.Addr().String(), "endpoints", s.endpoints)
+
+	go func() {
+		defer close(done)
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorw("Debug server stopped unexpectedly", "error", err)
+		}
+	}()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = s.stop(context.Background(), done)
+		case <-done:
+		}
+	}()
+
+	return nil
+}
+
+// Addr 返回实际监听的地址；在 Start 之前返回空字符串。
+// (Addr returns the actual listen address; it returns an empty string before Start.)
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop 在 ShutdownTimeout 内优雅关闭调试服务器。
+// (Stop gracefully shuts down the debug server within ShutdownTimeout.)
+func (s *Server) Stop(ctx context.Context) error {
+	return s.stop(ctx, nil)
+}
+
+// stop 关闭当前运行的服务器；run 不为 nil 时只在它仍是当前运行时关闭，使旧 ctx 的取消不会关闭重新启动的服务器。
+// (stop shuts down the running server; when run is not nil it only does so while run is still the current one, so
+// cancelling the ctx of an earlier Start does not stop a restarted server.)
+func (s *Server) stop(ctx context.Context, run chan struct{}) error {
+	s.mu.Lock()
+	srv, done := s.httpServer, s.done
+	if s.listener == nil || (run != nil && run != done) {
+		s.mu.Unlock()
+		return nil
+	}
+	s.listener, s.httpServer, s.done = nil, nil, nil
+	s.mu.Unlock()
+
+	if s.opts.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.ShutdownTimeout)
+		defer cancel()
+	}
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return lmccerrors.Wrap(err, "failed to shut down debug server")
+	}
+	<-done
+	return nil
+}
+
+// Run 在 opts.Enabled 为 true 时创建并启动调试服务器，否则返回 nil。
+// (Run creates and starts a debug server when opts.Enabled is true; otherwise it returns nil.)
+func Run(ctx context.Context, opts *Options, serverOpts ...ServerOption) (*Server, error) {
+	if opts == nil || !opts.Enabled {
+		return nil, nil
+	}
+
+	s, err := NewServer(opts, serverOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Start(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,241 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the debug server endpoints and lifecycle.
+ */
+
+package debug_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/debug"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer creates a debug server with all endpoints enabled.
+// (newTestServer 创建启用所有端点的调试服务器。)
+func newTestServer(t *testing.T, opts ...debug.ServerOption) *debug.Server {
+	t.Helper()
+	srv, err := debug.NewServer(debug.NewOptions(), opts...)
+	require.NoError(t, err)
+	return srv
+}
+
+// doRequest performs a request against the handler and returns the recorder.
+// (doRequest 对处理器发起请求并返回记录器。)
+func doRequest(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestServerEndpoints tests the aggregated debug endpoints.
+// (TestServerEndpoints 测试聚合的调试端点。)
+func TestServerEndpoints(t *testing.T) {
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("requests_total 1\n"))
+	})
+	srv := newTestServer(t, debug.WithMetricsHandler(metrics), debug.WithVersion("v1.2.3"))
+	h := srv.Handler()
+
+	rec := doRequest(h, http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+
+	rec = doRequest(h, http.MethodGet, "/metrics", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "requests_total")
+
+	rec = doRequest(h, http.MethodGet, "/debug/pprof/", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doRequest(h, http.MethodGet, "/buildinfo", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var info map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	assert.Equal(t, "v1.2.3", info["version"])
+	assert.NotEmpty(t, info["go_version"])
+
+	rec = doRequest(h, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "/loglevel")
+
+	rec = doRequest(h, http.MethodGet, "/unknown", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// TestServerHealthRegistry tests that a health registry serves liveness on /healthz and its checks on /readyz.
+// (TestServerHealthRegistry 测试健康检查注册表在 /healthz 提供存活检查，并在 /readyz 执行其中的检查。)
+func TestServerHealthRegistry(t *testing.T) {
+	registry := healthcheck.New()
+	require.NoError(t, registry.Register("db", func(context.Context) error { return errors.New("connection refused") }))
+	h := newTestServer(t, debug.WithHealthRegistry(registry)).Handler()
+
+	rec := doRequest(h, http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusOK, rec.Code, "liveness runs no checks")
+
+	rec = doRequest(h, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp healthcheck.Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Data)
+	assert.Equal(t, healthcheck.StatusFailing, resp.Data.Status)
+	require.Len(t, resp.Data.Checks, 1)
+	assert.Equal(t, "connection refused", resp.Data.Checks[0].Error)
+}
+
+// TestServerDisabledEndpoints tests that disabled endpoints are not registered.
+// (TestServerDisabledEndpoints 测试被禁用的端点不会被注册。)
+func TestServerDisabledEndpoints(t *testing.T) {
+	opts := debug.NewOptions()
+	opts.EnablePprof = false
+	opts.EnableHealth = false
+	srv, err := debug.NewServer(opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, doRequest(srv.Handler(), http.MethodGet, "/debug/pprof/", "").Code)
+	assert.Equal(t, http.StatusNotFound, doRequest(srv.Handler(), http.MethodGet, "/healthz", "").Code)
+	// 未提供处理器时不注册 /metrics (No /metrics without a handler)
+	assert.Equal(t, http.StatusNotFound, doRequest(srv.Handler(), http.MethodGet, "/metrics", "").Code)
+}
+
+// TestLogLevelEndpoint tests reading and changing the global log level.
+// (TestLogLevelEndpoint 测试查询和调整全局日志级别。)
+func TestLogLevelEndpoint(t *testing.T) {
+	logOpts := log.NewOptions()
+	logOpts.Level = "info"
+	logOpts.OutputPaths = []string{"stderr"}
+	log.Init(logOpts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	h := newTestServer(t).Handler()
+
+	rec := doRequest(h, http.MethodGet, "/loglevel", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"info"}`, rec.Body.String())
+
+	rec = doRequest(h, http.MethodPut, "/loglevel", `{"level":"debug"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "debug", log.GetLevel())
+
+	rec = doRequest(h, http.MethodPost, "/loglevel?level=warn", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "warn", log.GetLevel())
+
+	rec = doRequest(h, http.MethodPut, "/loglevel", `{"level":"loud"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "warn", log.GetLevel())
+
+	rec = doRequest(h, http.MethodDelete, "/loglevel", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+// TestConfigEndpointRedaction tests that secrets are redacted from the config dump.
+// (TestConfigEndpointRedaction 测试配置输出中的敏感信息被脱敏。)
+func TestConfigEndpointRedaction(t *testing.T) {
+	type dbConfig struct {
+		Host     string `json:"host"`
+		Password string `json:"password"`
+	}
+	type appConfig struct {
+		Name     string            `json:"name"`
+		Database dbConfig          `json:"database"`
+		APIToken string            `json:"apiToken"`
+		Extra    map[string]string `json:"extra"`
+		Empty    string            `json:"secretEmpty"`
+	}
+	cfg := appConfig{
+		Name:     "svc",
+		Database: dbConfig{Host: "db.local", Password: "hunter2"},
+		APIToken: "tok",
+		Extra:    map[string]string{"license": "abc"},
+	}
+
+	h := newTestServer(t,
+		debug.WithConfigProvider(func() any { return cfg }),
+		debug.WithRedactKeys("license"),
+	).Handler()
+
+	rec := doRequest(h, http.MethodGet, "/config", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.NotContains(t, body, "hunter2")
+	assert.NotContains(t, body, "\"tok\"")
+	assert.NotContains(t, body, "abc")
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	assert.Equal(t, "svc", out["name"])
+	assert.Equal(t, "db.local", out["database"].(map[string]any)["host"])
+	assert.Equal(t, "", out["secretEmpty"], "empty secrets are kept to show missing values")
+}
+
+// TestServerLifecycle tests starting and stopping the server on a real port.
+// (TestServerLifecycle 测试在真实端口上启动和停止服务器。)
+func TestServerLifecycle(t *testing.T) {
+	opts := debug.NewOptions()
+	opts.Enabled = true
+	opts.Port = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv, err := debug.Run(ctx, opts)
+	require.NoError(t, err)
+	require.NotNil(t, srv)
+	require.NotEmpty(t, srv.Addr())
+
+	resp, err := http.Get("http://" + srv.Addr() + "/healthz")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	err = srv.Start(ctx)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrDebugServerStart))
+
+	require.NoError(t, srv.Stop(context.Background()))
+	assert.Empty(t, srv.Addr())
+	require.NoError(t, srv.Stop(context.Background()), "stopping twice is a no-op")
+
+	// 停止后可以再次启动，之前 ctx 的取消不影响新的运行 (It can start again after Stop; cancelling the earlier ctx leaves the new run alone)
+	cancel()
+	require.NoError(t, srv.Start(context.Background()))
+	defer srv.Stop(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	resp, err = http.Get("http://" + srv.Addr() + "/healthz")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// TestRunDisabled tests that Run is a no-op when the server is disabled.
+// (TestRunDisabled 测试服务器禁用时 Run 不执行任何操作。)
+func TestRunDisabled(t *testing.T) {
+	srv, err := debug.Run(context.Background(), debug.NewOptions())
+	assert.NoError(t, err)
+	assert.Nil(t, srv)
+}
+
+// TestNewServerInvalidOptions tests that invalid options are rejected.
+// (TestNewServerInvalidOptions 测试无效选项会被拒绝。)
+func TestNewServerInvalidOptions(t *testing.T) {
+	opts := debug.NewOptions()
+	opts.Port = 70000
+	_, err := debug.NewServer(opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrDebugOptionInvalid))
+}
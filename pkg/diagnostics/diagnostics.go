@@ -0,0 +1,269 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/debug"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// 健康检查状态，与 healthcheck 包相同。(Health check statuses, the same as those of the healthcheck package.)
+const (
+	StatusOK      = healthcheck.StatusOK
+	StatusFailing = healthcheck.StatusFailing
+)
+
+// Document 是启动诊断报告，可序列化为 JSON 附在支持工单中。
+// (Document is the startup diagnostics report; it serializes to JSON for attaching to support tickets.)
+type Document struct {
+	// Timestamp 是生成报告的时间。(Timestamp is when the report was generated.)
+	Timestamp time.Time `json:"timestamp"`
+	// Build 是嵌入二进制文件的构建信息。(Build is the build information embedded in the binary.)
+	Build debug.BuildInfo `json:"build"`
+	// Runtime 描述进程和主机。(Runtime describes the process and host.)
+	Runtime RuntimeInfo `json:"runtime"`
+	// Logger 描述日志配置。(Logger describes the logging configuration.)
+	Logger LoggerInfo `json:"logger"`
+	// Config 是脱敏后的生效配置，未提供配置时为空。(Config is the effective configuration, redacted; empty when none was given.)
+	Config any `json:"config,omitempty"`
+	// HealthChecks 是已注册健康检查的结果，按名称排序。(HealthChecks are the results of the registered health checks, sorted by name.)
+	HealthChecks []HealthResult `json:"health_checks,omitempty"`
+}
+
+// RuntimeInfo 描述进程和主机。(RuntimeInfo describes the process and host.)
+type RuntimeInfo struct {
+	GOOS       string `json:"goos"`
+	GOARCH     string `json:"goarch"`
+	NumCPU     int    `json:"num_cpu"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	PID        int    `json:"pid"`
+	Hostname   string `json:"hostname,omitempty"`
+}
+
+// LoggerInfo 描述日志配置。(LoggerInfo describes the logging configuration.)
+type LoggerInfo struct {
+	// Level 是全局 logger 的当前级别。(Level is the current level of the global logger.)
+	Level string `json:"level"`
+	// Options 是通过 WithLogOptions 提供的日志选项。(Options are the log options given with WithLogOptions.)
+	Options any `json:"options,omitempty"`
+}
+
+// HealthResult 是一个健康检查的结果。(HealthResult is the outcome of one health check.)
+type HealthResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// HealthCheck 检查一个依赖，返回 nil 表示健康。(HealthCheck checks one dependency; nil means healthy.)
+type HealthCheck = healthcheck.Check
+
+// Option 配置诊断报告。(Option configures the diagnostics report.)
+type Option func(*reporter)
+
+// reporter 保存由 Option 设置的值。(reporter holds the values set by Options.)
+type reporter struct {
+	logger        log.Logger
+	version       string
+	config        func() any
+	logOptions    *log.Options
+	redactKeys    []string
+	healthChecks  map[string]HealthCheck
+	healthTimeout time.Duration
+	registry      *healthcheck.Registry
+}
+
+// WithLogger 设置 Report 输出报告的 logger，默认为 log.Std()。
+// (WithLogger sets the logger Report emits the report to; defaults to log.Std().)
+func WithLogger(logger log.Logger) Option {
+	return func(r *reporter) {
+		if logger != nil {
+			r.logger = logger
+		}
+	}
+}
+
+// WithVersion 设置报告中的应用版本。(WithVersion sets the application version in the report.)
+func WithVersion(version string) Option {
+	return func(r *reporter) {
+		r.version = version
+	}
+}
+
+// WithConfig 将 cfg 作为生效配置加入报告，输出前会脱敏。
+// (WithConfig adds cfg to the report as the effective configuration; it is redacted first.)
+func WithConfig(cfg any) Option {
+	return func(r *reporter) {
+		r.config = func() any { return cfg }
+	}
+}
+
+// WithConfigManager 将 config.Manager 的当前设置作为生效配置加入报告，输出前会脱敏。
+// (WithConfigManager adds the current settings of a config.Manager to the report as the effective configuration; they are redacted first.)
+func WithConfigManager(cm config.Manager) Option {
+	return func(r *reporter) {
+		r.config = func() any { return cm.GetViperInstance().AllSettings() }
+	}
+}
+
+// WithLogOptions 将初始化全局 logger 所用的选项加入报告。
+// (WithLogOptions adds the options the global logger was initialized with to the report.)
+func WithLogOptions(opts *log.Options) Option {
+	return func(r *reporter) {
+		r.logOptions = opts
+	}
+}
+
+// WithRedactKeys 添加需要脱敏的额外键名片段（不区分大小写），默认片段与调试服务器的 /config 端点相同。
+// (WithRedactKeys adds extra key fragments to redact, matched case-insensitively; the default fragments are those of the debug server's /config endpoint.)
+func WithRedactKeys(keys ...string) Option {
+	return func(r *reporter) {
+		r.redactKeys = append(r.redactKeys, keys...)
+	}
+}
+
+// WithHealthCheck 注册一个健康检查，报告生成时执行并记录结果。同名检查会被替换。
+// (WithHealthCheck registers a health check that is run, and its result recorded, when the report is generated. A check with the same name is replaced.)
+func WithHealthCheck(name string, check HealthCheck) Option {
+	return func(r *reporter) {
+		r.healthChecks[name] = check
+	}
+}
+
+// WithHealthRegistry 在报告中加入 registry 中所有检查的结果，例如由 sdk.Bootstrap 接入调试服务器的注册表。
+// (WithHealthRegistry adds the results of every check in registry to the report, e.g. the registry sdk.Bootstrap wires into the debug server.)
+func WithHealthRegistry(registry *healthcheck.Registry) Option {
+	return func(r *reporter) {
+		r.registry = registry
+	}
+}
+
+// WithHealthTimeout 设置通过 WithHealthCheck 注册的每个健康检查的超时，默认为 healthcheck.DefaultTimeout。
+// (WithHealthTimeout sets the timeout of each health check registered with WithHealthCheck; defaults to healthcheck.DefaultTimeout.)
+func WithHealthTimeout(d time.Duration) Option {
+	return func(r *reporter) {
+		if d > 0 {
+			r.healthTimeout = d
+		}
+	}
+}
+
+func newReporter(options []Option) *reporter {
+	r := &reporter{
+		logger:        log.Std(),
+		healthChecks:  map[string]HealthCheck{},
+		healthTimeout: healthcheck.DefaultTimeout,
+	}
+	for _, opt := range options {
+		opt(r)
+	}
+	return r
+}
+
+// Collect 生成诊断报告但不输出。健康检查并发执行，失败的检查只会记录在报告中。
+// (Collect generates the diagnostics report without emitting it. Health checks run concurrently; failing checks are only recorded in the report.)
+func Collect(ctx context.Context, options ...Option) (*Document, error) {
+	return newReporter(options).collect(ctx)
+}
+
+// Report 生成诊断报告，并作为一条 Info 级别的结构化日志（"diagnostics" 字段）输出，通常在启动时调用一次。
+// (Report generates the diagnostics report and emits it as a single structured Info entry under the "diagnostics" field; it is typically called once at startup.)
+func Report(ctx context.Context, options ...Option) (*Document, error) {
+	r := newReporter(options)
+	doc, err := r.collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Infow("Startup diagnostics", "diagnostics", doc)
+	return doc, nil
+}
+
+// CheckHealth 只执行通过 WithHealthCheck 和 WithHealthRegistry 提供的健康检查；其他选项被忽略。
+// (CheckHealth runs only the health checks given with WithHealthCheck and WithHealthRegistry; other options are ignored.)
+func CheckHealth(ctx context.Context, options ...Option) []HealthResult {
+	return newReporter(options).runHealthChecks(ctx)
+}
+
+func (r *reporter) collect(ctx context.Context) (*Document, error) {
+	doc := &Document{
+		Timestamp: time.Now(),
+		Build:     debug.ReadBuildInfo(r.version),
+		Runtime: RuntimeInfo{
+			GOOS:       runtime.GOOS,
+			GOARCH:     runtime.GOARCH,
+			NumCPU:     runtime.NumCPU(),
+			GOMAXPROCS: runtime.GOMAXPROCS(0),
+			PID:        os.Getpid(),
+		},
+		Logger: LoggerInfo{Level: log.GetLevel()},
+	}
+	doc.Runtime.Hostname, _ = os.Hostname()
+
+	if r.logOptions != nil {
+		opts, err := debug.Redact(r.logOptions, r.redactKeys...)
+		if err != nil {
+			return nil, lmccerrors.Wrap(err, "failed to serialize log options")
+		}
+		doc.Logger.Options = opts
+	}
+	if r.config != nil {
+		cfg, err := debug.Redact(r.config(), r.redactKeys...)
+		if err != nil {
+			return nil, lmccerrors.Wrap(err, "failed to serialize config")
+		}
+		doc.Config = cfg
+	}
+	doc.HealthChecks = r.runHealthChecks(ctx)
+	return doc, nil
+}
+
+// WriteJSON 以缩进的 JSON 写出报告。(WriteJSON writes the report as indented JSON.)
+func (d *Document) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// runHealthChecks 通过 healthcheck.Registry 并发执行健康检查，并按名称排序结果。
+// (runHealthChecks runs the health checks concurrently through a healthcheck.Registry and sorts the results by name.)
+func (r *reporter) runHealthChecks(ctx context.Context) []HealthResult {
+	var results []healthcheck.Result
+	if len(r.healthChecks) > 0 {
+		registry := healthcheck.New()
+		for name, check := range r.healthChecks {
+			if err := registry.Register(name, check, healthcheck.WithTimeout(r.healthTimeout)); err != nil {
+				results = append(results, healthcheck.Result{Name: name, Status: StatusFailing, Error: err.Error()})
+			}
+		}
+		results = append(results, registry.Check(ctx).Checks...)
+	}
+	if r.registry != nil {
+		results = append(results, r.registry.Check(ctx).Checks...)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	out := make([]HealthResult, 0, len(results))
+	for _, result := range results {
+		out = append(out, HealthResult{Name: result.Name, Status: result.Status, Error: result.Error, Duration: result.Duration})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
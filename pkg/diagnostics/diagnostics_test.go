@@ -0,0 +1,116 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the startup diagnostics report.
+ */
+
+package diagnostics_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/diagnostics"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type appConfig struct {
+	Database struct {
+		Host     string `json:"host"`
+		Password string `json:"password"`
+	} `json:"database"`
+	License string `json:"license"`
+}
+
+// TestReport tests that the report gathers redacted config, logger options and health checks into one log entry.
+// (TestReport 测试报告将脱敏配置、日志选项和健康检查汇总为一条日志。)
+func TestReport(t *testing.T) {
+	cfg := appConfig{License: "ABC-123"}
+	cfg.Database.Host = "db.internal"
+	cfg.Database.Password = "hunter2"
+
+	logOpts := log.NewOptions()
+	logOpts.Level = "debug"
+	var out bytes.Buffer
+	logger := log.NewLoggerWithWriter(log.NewOptions(), &out)
+
+	doc, err := diagnostics.Report(context.Background(),
+		diagnostics.WithLogger(logger),
+		diagnostics.WithVersion("v1.2.3"),
+		diagnostics.WithConfig(cfg),
+		diagnostics.WithLogOptions(logOpts),
+		diagnostics.WithRedactKeys("license"),
+		diagnostics.WithHealthTimeout(50*time.Millisecond),
+		diagnostics.WithHealthCheck("db", func(context.Context) error { return nil }),
+		diagnostics.WithHealthCheck("cache", func(context.Context) error { return errors.New("connection refused") }),
+		diagnostics.WithHealthCheck("slow", func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.3", doc.Build.Version)
+	assert.Equal(t, runtime.Version(), doc.Build.GoVersion)
+	assert.Equal(t, runtime.GOOS, doc.Runtime.GOOS)
+	assert.Equal(t, map[string]any{
+		"database": map[string]any{"host": "db.internal", "password": "******"},
+		"license":  "******",
+	}, doc.Config)
+	assert.Equal(t, "debug", doc.Logger.Options.(map[string]any)["level"])
+
+	require.Len(t, doc.HealthChecks, 3)
+	assert.Equal(t, "cache", doc.HealthChecks[0].Name)
+	assert.Equal(t, diagnostics.StatusFailing, doc.HealthChecks[0].Status)
+	assert.Equal(t, "connection refused", doc.HealthChecks[0].Error)
+	assert.Equal(t, diagnostics.StatusOK, doc.HealthChecks[1].Status)
+	assert.Equal(t, diagnostics.StatusFailing, doc.HealthChecks[2].Status)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, "Startup diagnostics", entry["M"])
+	assert.NotContains(t, out.String(), "hunter2")
+	assert.Contains(t, out.String(), "db.internal")
+}
+
+// TestDocument_WriteJSON tests writing a collected report as JSON.
+// (TestDocument_WriteJSON 测试将生成的报告写为 JSON。)
+func TestDocument_WriteJSON(t *testing.T) {
+	doc, err := diagnostics.Collect(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, doc.Config)
+	assert.Empty(t, doc.HealthChecks)
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.WriteJSON(&buf))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Contains(t, decoded, "build")
+	assert.Contains(t, decoded, "runtime")
+	assert.NotContains(t, decoded, "config")
+
+	_, err = diagnostics.Collect(context.Background(), diagnostics.WithConfig(func() {}))
+	assert.Error(t, err)
+}
+
+// TestCheckHealth tests running only the health checks, including a panicking one.
+// (TestCheckHealth 测试只执行健康检查，包括发生 panic 的检查。)
+func TestCheckHealth(t *testing.T) {
+	results := diagnostics.CheckHealth(context.Background(),
+		diagnostics.WithVersion("ignored"),
+		diagnostics.WithHealthCheck("queue", func(context.Context) error { panic("boom") }),
+		diagnostics.WithHealthCheck("db", func(context.Context) error { return nil }),
+	)
+	require.Len(t, results, 2)
+	assert.Equal(t, "db", results[0].Name)
+	assert.Equal(t, diagnostics.StatusOK, results[0].Status)
+	assert.Equal(t, diagnostics.StatusFailing, results[1].Status)
+	assert.Contains(t, results[1].Error, "boom")
+
+	assert.Empty(t, diagnostics.CheckHealth(context.Background()))
+}
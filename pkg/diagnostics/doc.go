@@ -0,0 +1,28 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package diagnostics 生成启动诊断报告，把排查问题所需的环境信息汇总到一个结构化文档中，使支持工单可以复现。
+(Package diagnostics generates a startup diagnostics report that gathers the environment needed to investigate an issue into
+one structured document, making support tickets reproducible.)
+
+报告包含构建信息（版本、Go 版本、VCS 设置和依赖）、运行时信息（平台、CPU、PID、主机名）、日志配置、脱敏后的生效配置，
+以及已注册健康检查的结果。脱敏规则与调试服务器的 /config 端点相同，可以用 WithRedactKeys 扩展。
+(The report contains build information (version, Go version, VCS settings and dependencies), runtime information (platform, CPUs,
+PID, hostname), the logging configuration, the redacted effective configuration and the results of the registered health checks.
+Redaction follows the debug server's /config endpoint and can be extended with WithRedactKeys.)
+
+Report 将报告作为一条结构化日志输出；Collect 只生成报告，可以用 Document.WriteJSON 写入文件或响应。
+(Report emits the report as a single structured log entry; Collect only generates it, and Document.WriteJSON writes it to a file or response.)
+
+	_, err := diagnostics.Report(ctx,
+		diagnostics.WithVersion(version),
+		diagnostics.WithConfigManager(cm),
+		diagnostics.WithLogOptions(logOpts),
+		diagnostics.WithHealthCheck("database", db.PingContext),
+	)
+*/
+package diagnostics
@@ -6,10 +6,6 @@
 
 package errors
 
-import (
-	"errors"
-)
-
 // Coder defines the interface for an error code.
 // It also embeds the standard error interface, so Coder instances can be used as errors directly.
 // Coder 定义了错误码的接口。
@@ -43,43 +39,90 @@ type Coder interface {
 
 // IsCode checks if the error (or any error in its chain) has a Coder
 // that matches the code of the provided Coder `c`.
+// The chain is traversed through Unwrap() error and Unwrap() []error, so the errors
+// collected in an ErrorGroup or joined with errors.Join are checked as well. An error
+// matches when it carries a Coder (Coder() method, or is itself a Coder), exposes one
+// through its As method, or reports equivalence to `c` through its Is method.
 // (IsCode 检查错误（或其链中的任何错误）是否拥有一个 Coder，
-// 该 Coder 的代码与提供的 Coder `c` 的代码匹配。)
+// 该 Coder 的代码与提供的 Coder `c` 的代码匹配。
+// 错误链通过 Unwrap() error 和 Unwrap() []error 遍历，因此 ErrorGroup 中收集的错误和
+// errors.Join 合并的错误同样会被检查。错误携带 Coder（Coder() 方法，或本身就是 Coder）、
+// 通过 As 方法暴露 Coder、或通过 Is 方法声明与 `c` 等价时即视为匹配。)
 func IsCode(err error, c Coder) bool {
-	if err == nil || c == nil {
+	return HasAnyCode(err, c)
+}
+
+// HasAnyCode reports whether the error chain of err matches any of the given Coders,
+// traversing wrapped errors and aggregates like IsCode. Nil Coders are ignored.
+// (HasAnyCode 报告 err 的错误链是否与任一给定的 Coder 匹配，与 IsCode 一样遍历包装的错误和聚合错误。
+// nil Coder 被忽略。)
+func HasAnyCode(err error, coders ...Coder) bool {
+	if err == nil {
 		return false
 	}
-
-	targetCode := c.Code()
-
-	for {
-		if coderHolder, ok := err.(interface{ Coder() Coder }); ok {
-			if currentCoder := coderHolder.Coder(); currentCoder != nil {
-				if currentCoder.Code() == targetCode {
-					return true
-				}
-			}
+	targets := make([]Coder, 0, len(coders))
+	for _, c := range coders {
+		if c != nil {
+			targets = append(targets, c)
 		}
-		// Also check if the error itself is a Coder that matches
-		if currentAsCoder, ok := err.(Coder); ok {
-			if currentAsCoder.Code() == targetCode {
+	}
+	if len(targets) == 0 {
+		return false
+	}
+	return walkChain(err, func(e error) bool {
+		for _, c := range targets {
+			if matchesCode(e, c) {
 				return true
 			}
 		}
+		return false
+	})
+}
 
-		// Check for multi-error unwrapping (Go 1.20+ style, like ErrorGroup)
-		if multiUnwrapper, ok := err.(interface{ Unwrap() []error }); ok {
-			for _, subErr := range multiUnwrapper.Unwrap() {
-				if IsCode(subErr, c) {
+// walkChain visits err and every error reachable through Unwrap() error and Unwrap() []error,
+// depth first, until visit returns true.
+// (walkChain 深度优先访问 err 以及通过 Unwrap() error 和 Unwrap() []error 可达的每个错误，直到 visit 返回 true。)
+func walkChain(err error, visit func(error) bool) bool {
+	for err != nil {
+		if visit(err) {
+			return true
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, sub := range u.Unwrap() {
+				if walkChain(sub, visit) {
 					return true
 				}
 			}
+			return false
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		default:
+			return false
 		}
+	}
+	return false
+}
 
-		unwrappedError := errors.Unwrap(err) // Use standard library errors.Unwrap
-		if unwrappedError == nil {
-			return false
+// matchesCode reports whether the single error e (without unwrapping) matches the code of c.
+// (matchesCode 报告单个错误 e（不解包）是否与 c 的代码匹配。)
+func matchesCode(e error, c Coder) bool {
+	if holder, ok := e.(interface{ Coder() Coder }); ok {
+		if current := holder.Coder(); current != nil && current.Code() == c.Code() {
+			return true
 		}
-		err = unwrappedError
 	}
+	if current, ok := e.(Coder); ok && current.Code() == c.Code() {
+		return true
+	}
+	if aser, ok := e.(interface{ As(any) bool }); ok {
+		var current Coder
+		if aser.As(&current) && current != nil && current.Code() == c.Code() {
+			return true
+		}
+	}
+	if iser, ok := e.(interface{ Is(error) bool }); ok && iser.Is(c) {
+		return true
+	}
+	return false
 }
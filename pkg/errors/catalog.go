@@ -0,0 +1,79 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// CatalogEntry describes one registered error code.
+// CatalogEntry 描述一个已注册的错误码。
+type CatalogEntry struct {
+	Code        int    `json:"code"`
+	HTTPStatus  int    `json:"http_status"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description"`
+	Reference   string `json:"reference,omitempty"`
+}
+
+// CodeCatalog is the list of registered error codes, sorted by code.
+// CodeCatalog 是按错误码排序的已注册错误码列表。
+type CodeCatalog []CatalogEntry
+
+// Catalog returns every registered Coder sorted by code, for publishing the error codes a service can return.
+// Catalog 返回按错误码排序的所有已注册 Coder，用于发布服务可能返回的错误码。
+func Catalog() CodeCatalog {
+	registryMu.RLock()
+	catalog := make(CodeCatalog, 0, len(registry))
+	for _, r := range registry {
+		catalog = append(catalog, CatalogEntry{
+			Code:        r.coder.Code(),
+			HTTPStatus:  r.coder.HTTPStatus(),
+			Name:        r.name,
+			Description: r.coder.String(),
+			Reference:   r.coder.Reference(),
+		})
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code })
+	return catalog
+}
+
+// JSON returns the catalog as an indented JSON array.
+// JSON 以缩进的 JSON 数组返回目录。
+func (c CodeCatalog) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// Markdown returns the catalog as a Markdown table with code, HTTP status, name, description and reference columns.
+// Markdown 以 Markdown 表格返回目录，列为错误码、HTTP 状态码、名称、描述和参考。
+func (c CodeCatalog) Markdown() string {
+	var b strings.Builder
+	b.WriteString("| Code | HTTP Status | Name | Description | Reference |\n")
+	b.WriteString("|------|-------------|------|-------------|-----------|\n")
+	for _, e := range c {
+		status := fmt.Sprint(e.HTTPStatus)
+		if text := http.StatusText(e.HTTPStatus); text != "" {
+			status += " " + text
+		}
+		fmt.Fprintf(&b, "| %d | %s | %s | %s | %s |\n",
+			e.Code, status, markdownCell(e.Name), markdownCell(e.Description), markdownCell(e.Reference))
+	}
+	return b.String()
+}
+
+// markdownCell escapes characters that would break a Markdown table cell.
+// markdownCell 转义会破坏 Markdown 表格单元格的字符。
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,125 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCoder_Duplicates(t *testing.T) {
+	errInvoiceNotFound := lmccerrors.NewCoder(990101, http.StatusNotFound, "Invoice not found", "")
+	require.NoError(t, lmccerrors.RegisterNamedCoder("ErrInvoiceNotFound", errInvoiceNotFound))
+	require.NoError(t, lmccerrors.RegisterCoder(errInvoiceNotFound), "registering the same Coder again is allowed")
+	require.NoError(t, lmccerrors.RegisterCoder(lmccerrors.NewCoder(990101, http.StatusNotFound, "Invoice not found", "")),
+		"an identical definition is not a collision")
+
+	err := lmccerrors.RegisterCoder(lmccerrors.NewCoder(990101, http.StatusGone, "Invoice archived", ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "990101")
+	assert.Contains(t, err.Error(), "ErrInvoiceNotFound")
+
+	coder, ok := lmccerrors.LookupCoder(990101)
+	require.True(t, ok)
+	assert.Equal(t, errInvoiceNotFound, coder, "the earlier registration is kept")
+
+	assert.Error(t, lmccerrors.RegisterCoder(lmccerrors.NewCoder(lmccerrors.ErrNotFound.Code(), 404, "Reused", "")),
+		"predefined codes are protected as well")
+	assert.Panics(t, func() {
+		lmccerrors.MustRegisterCoder(lmccerrors.NewCoder(990101, http.StatusConflict, "Invoice conflict", ""))
+	})
+}
+
+func TestCatalog(t *testing.T) {
+	require.NoError(t, lmccerrors.RegisterCoder(lmccerrors.NewCoder(990201, http.StatusConflict, "Seat | taken", "https://docs.example.com/e/990201")))
+
+	catalog := lmccerrors.Catalog()
+	require.NotEmpty(t, catalog)
+	for i := 1; i < len(catalog); i++ {
+		assert.Less(t, catalog[i-1].Code, catalog[i].Code, "entries are sorted by code")
+	}
+
+	var internal, seat lmccerrors.CatalogEntry
+	for _, e := range catalog {
+		switch e.Code {
+		case lmccerrors.ErrInternalServer.Code():
+			internal = e
+		case 990201:
+			seat = e
+		}
+	}
+	assert.Equal(t, lmccerrors.CatalogEntry{
+		Code: 100001, HTTPStatus: http.StatusInternalServerError, Name: "ErrInternalServer", Description: "Internal server error",
+	}, internal)
+	assert.Equal(t, "Seat | taken", seat.Description)
+
+	data, err := catalog.JSON()
+	require.NoError(t, err)
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded, len(catalog))
+	assert.Equal(t, "ErrInternalServer", decoded[0]["name"])
+	assert.EqualValues(t, 500, decoded[0]["http_status"])
+
+	md := catalog.Markdown()
+	assert.Contains(t, md, "| Code | HTTP Status | Name | Description | Reference |")
+	assert.Contains(t, md, "| 100001 | 500 Internal Server Error | ErrInternalServer | Internal server error |  |")
+	assert.Contains(t, md, `| 990201 | 409 Conflict |  | Seat \| taken | https://docs.example.com/e/990201 |`)
+}
+
+func TestCatalog_ListsEveryPredefinedCoder(t *testing.T) {
+	// 解析包源码，找出所有由 NewCoder 定义的导出 Err* 变量 (Parse the package sources for every exported Err* variable defined by NewCoder)
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	var defined []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if !name.IsExported() || !strings.HasPrefix(name.Name, "Err") || i >= len(vs.Values) {
+						continue
+					}
+					if call, ok := vs.Values[i].(*ast.CallExpr); ok {
+						if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "NewCoder" {
+							defined = append(defined, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	require.NotEmpty(t, defined)
+
+	registered := map[string]bool{}
+	for _, e := range lmccerrors.Catalog() {
+		registered[e.Name] = true
+	}
+	for _, name := range defined {
+		assert.True(t, registered[name], "%s is not registered in registry.go init", name)
+	}
+}
@@ -99,6 +99,26 @@ var (
 	// ErrOperationFailed 表示通用操作失败。
 	ErrOperationFailed = NewCoder(100009, 500, "Operation failed", "")
 
+	// ErrPanic represents a panic that was recovered and converted into an error.
+	// ErrPanic 表示被恢复并转换为错误的 panic。
+	ErrPanic = NewCoder(100010, 500, "Recovered from panic", "")
+
+	// ErrAlreadyExists represents a conflict with a resource that already exists (409, gRPC AlreadyExists).
+	// ErrAlreadyExists 表示资源已存在的冲突错误 (409，gRPC AlreadyExists)。
+	ErrAlreadyExists = NewCoder(100011, 409, "Resource already exists", "")
+
+	// ErrUnavailable represents a service that is temporarily unavailable (503, gRPC Unavailable).
+	// ErrUnavailable 表示服务暂时不可用 (503，gRPC Unavailable)。
+	ErrUnavailable = NewCoder(100012, 503, "Service unavailable", "")
+
+	// ErrInternal is the standard name of ErrInternalServer (500, gRPC Internal).
+	// ErrInternal 是 ErrInternalServer 的标准名称 (500，gRPC Internal)。
+	ErrInternal = ErrInternalServer
+
+	// ErrRateLimited is the standard name of ErrTooManyRequests (429, gRPC ResourceExhausted).
+	// ErrRateLimited 是 ErrTooManyRequests 的标准名称 (429，gRPC ResourceExhausted)。
+	ErrRateLimited = ErrTooManyRequests
+
 	// ErrConfigFileRead represents an error encountered while reading a configuration file.
 	// ErrConfigFileRead 表示读取配置文件时遇到的错误。
 	ErrConfigFileRead = NewCoder(200001, 500, "Config file read error", "https://lmcc-go-sdk.dev/docs/errors/config#file-read")
@@ -123,6 +143,18 @@ var (
 	// ErrConfigHotReload 表示配置热重载过程中遇到的错误。
 	ErrConfigHotReload = NewCoder(200006, 500, "Config hot-reload error", "")
 
+	// ErrConfigRemote represents an error encountered while reading or watching a remote configuration provider.
+	// ErrConfigRemote 表示读取或监视远程配置提供者时遇到的错误。
+	ErrConfigRemote = NewCoder(200007, 502, "Config remote provider error", "")
+
+	// ErrConfigValidation represents a configuration that failed its validation rules.
+	// ErrConfigValidation 表示配置未通过校验规则。
+	ErrConfigValidation = NewCoder(200008, 500, "Config validation error", "")
+
+	// ErrConfigSecret represents a secret reference in the configuration that could not be resolved.
+	// ErrConfigSecret 表示配置中的密钥引用无法解析。
+	ErrConfigSecret = NewCoder(200009, 500, "Config secret resolution error", "")
+
 	// --- Log Package Errors (pkg/log) ---
 
 	// ErrLogInternal represents an internal error within the logging system.
@@ -156,6 +188,182 @@ var (
 	// ErrLogRotationDirInvalid represents that the log rotation path exists but is not a directory.
 	// ErrLogRotationDirInvalid 表示日志轮转路径存在但不是一个目录。
 	ErrLogRotationDirInvalid = NewCoder(300008, 500, "Log rotation path exists but is not a directory", "")
+
+	// ErrLogAuditTampered represents an audit trail whose sequence numbers or hash chain do not verify.
+	// ErrLogAuditTampered 表示审计日志的序列号或哈希链校验失败。
+	ErrLogAuditTampered = NewCoder(300009, 500, "Log audit trail verification failed", "")
+
+	// --- Debug Package Errors (pkg/debug) ---
+
+	// ErrDebugOptionInvalid represents an invalid option provided for the debug server.
+	// ErrDebugOptionInvalid 表示为调试服务器提供了无效选项。
+	ErrDebugOptionInvalid = NewCoder(400001, 400, "Debug server option invalid", "")
+
+	// ErrDebugServerStart represents an error encountered while starting the debug server.
+	// ErrDebugServerStart 表示启动调试服务器时遇到的错误。
+	ErrDebugServerStart = NewCoder(400002, 500, "Debug server start error", "")
+
+	// --- Secrets Package Errors (pkg/secrets) ---
+
+	// ErrSecretNotFound represents that the requested secret does not exist in the provider.
+	// ErrSecretNotFound 表示请求的密钥在提供者中不存在。
+	ErrSecretNotFound = NewCoder(500001, 404, "Secret not found", "")
+
+	// ErrSecretProvider represents an error returned by a secret provider backend.
+	// ErrSecretProvider 表示密钥提供者后端返回的错误。
+	ErrSecretProvider = NewCoder(500002, 500, "Secret provider error", "")
+
+	// ErrSecretInvalidKey represents a malformed secret key or reference.
+	// ErrSecretInvalidKey 表示格式错误的密钥名称或引用。
+	ErrSecretInvalidKey = NewCoder(500003, 400, "Secret key invalid", "")
+
+	// --- Profile Package Errors (pkg/profile) ---
+
+	// ErrProfileOptionInvalid represents an invalid option provided for the profiler.
+	// ErrProfileOptionInvalid 表示为性能分析器提供了无效选项。
+	ErrProfileOptionInvalid = NewCoder(600001, 400, "Profile option invalid", "")
+
+	// ErrProfileCapture represents an error encountered while capturing or exporting a profile.
+	// ErrProfileCapture 表示采集或导出性能分析数据时遇到的错误。
+	ErrProfileCapture = NewCoder(600002, 500, "Profile capture error", "")
+
+	// --- Auth Package Errors (pkg/auth) ---
+
+	// ErrAuthOptionInvalid represents an invalid option provided for the authenticator.
+	// ErrAuthOptionInvalid 表示为认证器提供了无效选项。
+	ErrAuthOptionInvalid = NewCoder(700001, 400, "Auth option invalid", "")
+
+	// ErrAuthTokenInvalid represents a missing, malformed, expired or otherwise invalid token.
+	// ErrAuthTokenInvalid 表示令牌缺失、格式错误、已过期或因其他原因无效。
+	ErrAuthTokenInvalid = NewCoder(700002, 401, "Auth token invalid", "")
+
+	// ErrAuthKeyFetch represents an error encountered while fetching signing keys (JWKS or OIDC discovery).
+	// ErrAuthKeyFetch 表示获取签名密钥（JWKS 或 OIDC 发现文档）时遇到的错误。
+	ErrAuthKeyFetch = NewCoder(700003, 500, "Auth signing key fetch error", "")
+
+	// --- Pagination Package Errors (pkg/pagination) ---
+
+	// ErrPaginationInvalid represents invalid pagination parameters (limit, page or offset) in a request.
+	// ErrPaginationInvalid 表示请求中的分页参数（limit、page 或 offset）无效。
+	ErrPaginationInvalid = NewCoder(800001, 400, "Pagination parameter invalid", "")
+
+	// ErrPaginationCursorInvalid represents a malformed or tampered pagination cursor.
+	// ErrPaginationCursorInvalid 表示分页游标格式错误或被篡改。
+	ErrPaginationCursorInvalid = NewCoder(800002, 400, "Pagination cursor invalid", "")
+
+	// --- Trace Package Errors (pkg/trace) ---
+
+	// ErrTraceOptionInvalid represents an invalid option provided for tracing.
+	// ErrTraceOptionInvalid 表示为追踪提供了无效选项。
+	ErrTraceOptionInvalid = NewCoder(900001, 400, "Trace option invalid", "")
+
+	// ErrTraceExporter represents a failure to create a span exporter.
+	// ErrTraceExporter 表示创建 span 导出器失败。
+	ErrTraceExporter = NewCoder(900002, 500, "Trace exporter error", "")
+
+	// --- Metrics Package Errors (pkg/metrics) ---
+
+	// ErrMetricsOptionInvalid represents an invalid option provided for metrics.
+	// ErrMetricsOptionInvalid 表示为指标提供了无效选项。
+	ErrMetricsOptionInvalid = NewCoder(110001, 400, "Metrics option invalid", "")
+
+	// ErrMetricsRegister represents a failure to register metrics, e.g. a duplicate metric name.
+	// ErrMetricsRegister 表示注册指标失败，例如指标名重复。
+	ErrMetricsRegister = NewCoder(110002, 500, "Metrics registration error", "")
+
+	// ErrMetricsPush represents a failure to push metrics to a Pushgateway or an OTLP endpoint.
+	// ErrMetricsPush 表示向 Pushgateway 或 OTLP 端点推送指标失败。
+	ErrMetricsPush = NewCoder(110003, 500, "Metrics push error", "")
+
+	// --- Queue Package Errors (pkg/queue) ---
+
+	// ErrQueueOptionInvalid represents an invalid option provided for a message consumer.
+	// ErrQueueOptionInvalid 表示为消息消费者提供了无效选项。
+	ErrQueueOptionInvalid = NewCoder(120001, 400, "Queue option invalid", "")
+
+	// ErrQueueSource represents an error returned by a message source while receiving, acking or nacking.
+	// ErrQueueSource 表示消息源在接收、确认或否认消息时返回的错误。
+	ErrQueueSource = NewCoder(120002, 500, "Queue source error", "")
+
+	// --- Lock Package Errors (pkg/lock) ---
+
+	// ErrLockOptionInvalid represents an invalid option provided for a locker.
+	// ErrLockOptionInvalid 表示为锁提供了无效选项。
+	ErrLockOptionInvalid = NewCoder(130001, 400, "Lock option invalid", "")
+
+	// ErrLockHeld represents a lock that is currently held by another owner.
+	// ErrLockHeld 表示锁当前被其他持有者占用。
+	ErrLockHeld = NewCoder(130002, 409, "Lock held by another owner", "")
+
+	// ErrLockTimeout represents a lock that could not be acquired before the timeout or context cancellation.
+	// ErrLockTimeout 表示在超时或上下文取消前未能获取锁。
+	ErrLockTimeout = NewCoder(130003, 409, "Lock acquisition timed out", "")
+
+	// ErrLockNotHeld represents an operation on a lock that has been released or lost.
+	// ErrLockNotHeld 表示对已释放或已丢失的锁进行操作。
+	ErrLockNotHeld = NewCoder(130004, 409, "Lock not held", "")
+
+	// ErrLockBackend represents an error returned by a lock backend such as the file system, Redis or etcd.
+	// ErrLockBackend 表示锁后端（如文件系统、Redis 或 etcd）返回的错误。
+	ErrLockBackend = NewCoder(130005, 500, "Lock backend error", "")
+
+	// --- Tenant Package Errors (pkg/tenant) ---
+
+	// ErrTenantOptionInvalid represents an invalid option provided for tenant resolution.
+	// ErrTenantOptionInvalid 表示为租户解析提供了无效选项。
+	ErrTenantOptionInvalid = NewCoder(140001, 400, "Tenant option invalid", "")
+
+	// ErrTenantMissing represents a request that does not identify its tenant.
+	// ErrTenantMissing 表示请求未标识其租户。
+	ErrTenantMissing = NewCoder(140002, 400, "Tenant missing", "")
+
+	// ErrTenantInvalid represents an unknown tenant or one the caller may not act for.
+	// ErrTenantInvalid 表示未知租户或调用方无权代表的租户。
+	ErrTenantInvalid = NewCoder(140003, 403, "Tenant invalid", "")
+
+	// ErrTenantConfig represents an error encountered while resolving per-tenant configuration.
+	// ErrTenantConfig 表示解析租户级配置时遇到的错误。
+	ErrTenantConfig = NewCoder(140004, 500, "Tenant config error", "")
+
+	// --- CLI Package Errors (pkg/cli) ---
+
+	// ErrCLIFormatUnsupported represents an unsupported export, import or output format.
+	// ErrCLIFormatUnsupported 表示不支持的导出、导入或输出格式。
+	ErrCLIFormatUnsupported = NewCoder(150001, 400, "CLI format unsupported", "")
+
+	// ErrCLIRecordInvalid represents a record that could not be encoded or decoded, e.g. a malformed CSV row.
+	// ErrCLIRecordInvalid 表示无法编码或解码的记录，例如格式错误的 CSV 行。
+	ErrCLIRecordInvalid = NewCoder(150002, 400, "CLI record invalid", "")
+
+	// ErrCLIUsage represents a command line that does not match any command, or invalid flags or arguments.
+	// ErrCLIUsage 表示不匹配任何命令的命令行，或无效的标志或参数。
+	ErrCLIUsage = NewCoder(150003, 400, "CLI usage error", "")
+
+	// --- Tasks Package Errors (pkg/tasks) ---
+
+	// ErrTasksClosed represents starting a task after the task manager began shutting down.
+	// ErrTasksClosed 表示在任务管理器开始关闭后启动任务。
+	ErrTasksClosed = NewCoder(160001, 503, "Task manager closed", "")
+
+	// ErrTasksShutdown represents tasks still running when the shutdown deadline expired.
+	// ErrTasksShutdown 表示关闭期限到达时仍有任务在运行。
+	ErrTasksShutdown = NewCoder(160002, 500, "Task manager shutdown incomplete", "")
+
+	// --- SDK Bootstrap Errors (pkg/sdk) ---
+
+	// ErrSDKOptionInvalid represents invalid bootstrap options.
+	// ErrSDKOptionInvalid 表示无效的启动引导选项。
+	ErrSDKOptionInvalid = NewCoder(170001, 400, "SDK option invalid", "")
+
+	// ErrSDKBootstrap represents a subsystem that failed to start during bootstrap.
+	// ErrSDKBootstrap 表示启动引导期间某个子系统启动失败。
+	ErrSDKBootstrap = NewCoder(170002, 500, "SDK bootstrap failed", "")
+
+	// --- Retry Package Errors (pkg/retry) ---
+
+	// ErrRetryOptionInvalid represents invalid retry options.
+	// ErrRetryOptionInvalid 表示无效的重试选项。
+	ErrRetryOptionInvalid = NewCoder(180001, 400, "Retry option invalid", "")
 )
 
 // IsUnknownCoder checks if the Coder is the predefined unknownCoder.
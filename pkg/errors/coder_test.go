@@ -102,6 +102,10 @@ func TestPredefinedCoders(t *testing.T) {
 		{"ErrValidation", ErrValidation, 100006, 400, "Validation error"},
 		{"ErrTimeout", ErrTimeout, 100007, 504, "Request timeout"},
 		{"ErrTooManyRequests", ErrTooManyRequests, 100008, 429, "Too many requests"},
+		{"ErrAlreadyExists", ErrAlreadyExists, 100011, 409, "Resource already exists"},
+		{"ErrUnavailable", ErrUnavailable, 100012, 503, "Service unavailable"},
+		{"ErrInternal", ErrInternal, 100001, 500, "Internal server error"},
+		{"ErrRateLimited", ErrRateLimited, 100008, 429, "Too many requests"},
 	}
 
 	for _, tt := range tests {
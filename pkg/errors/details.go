@@ -0,0 +1,108 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// withDetails attaches machine-readable key/value details to an error without changing its message.
+// withDetails 为错误附加机器可读的键值详情，不改变其消息。
+type withDetails struct {
+	cause   error
+	details map[string]any
+}
+
+// Error returns the message of the underlying error.
+// Error 返回底层错误的消息。
+func (d *withDetails) Error() string {
+	return d.cause.Error()
+}
+
+// Unwrap returns the underlying error.
+// Unwrap 返回底层错误。
+func (d *withDetails) Unwrap() error {
+	return d.cause
+}
+
+// Format delegates to the underlying error, so %+v still prints its stack trace.
+// Format 委托给底层错误，因此 %+v 仍会打印其堆栈跟踪。
+func (d *withDetails) Format(s fmt.State, verb rune) {
+	if f, ok := d.cause.(fmt.Formatter); ok {
+		f.Format(s, verb)
+		return
+	}
+	fmt.Fprint(s, d.cause.Error())
+}
+
+// WithDetails attaches details such as user_id or order_id to err. The details survive later wrapping and are
+// returned by Details, written into HTTP error bodies and gRPC statuses, and logged with expanded error fields.
+// The map is copied. If err is nil, WithDetails returns nil; with no details err is returned unchanged.
+// WithDetails 为 err 附加 user_id、order_id 等详情。详情在之后的包装中保留，可通过 Details 读取，
+// 会写入 HTTP 错误响应体和 gRPC 状态，并随展开的错误字段记录到日志。映射会被复制。
+// err 为 nil 时返回 nil；没有详情时原样返回 err。
+func WithDetails(err error, details map[string]any) error {
+	if err == nil {
+		return nil
+	}
+	if len(details) == 0 {
+		return err
+	}
+	return &withDetails{cause: err, details: maps.Clone(details)}
+}
+
+// Details returns the details attached anywhere in err's chain, merged into a new map. When the same key is
+// attached more than once the outermost value wins. It returns nil when there are none.
+// Details 返回 err 错误链中附加的所有详情，合并为新的映射。同一个键被多次附加时以最外层的值为准。没有详情时返回 nil。
+func Details(err error) map[string]any {
+	var layers []map[string]any
+	for err != nil {
+		if d, ok := err.(*withDetails); ok {
+			layers = append(layers, d.details)
+		}
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		err = unwrapper.Unwrap()
+	}
+	if len(layers) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]any)
+	for i := len(layers) - 1; i >= 0; i-- {
+		maps.Copy(merged, layers[i])
+	}
+	return merged
+}
+
+// detailsStruct converts details into a protobuf Struct for gRPC statuses. Values that are not JSON types
+// are passed through a JSON round trip first; it returns nil if that fails.
+// detailsStruct 将详情转换为用于 gRPC 状态的 protobuf Struct。非 JSON 类型的值先经过一次 JSON 编解码，失败时返回 nil。
+func detailsStruct(details map[string]any) *structpb.Struct {
+	if s, err := structpb.NewStruct(details); err == nil {
+		return s
+	}
+	data, err := json.Marshal(details)
+	if err != nil {
+		return nil
+	}
+	var generic map[string]any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(generic)
+	if err != nil {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithDetails(t *testing.T) {
+	attached := map[string]any{"user_id": 42, "order_id": "o-1"}
+	base := lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "order missing")
+	err := lmccerrors.WithDetails(base, attached)
+	attached["user_id"] = 0 // 映射被复制 (The map is copied)
+
+	assert.Equal(t, base.Error(), err.Error(), "the message is unchanged")
+	assert.Contains(t, fmt.Sprintf("%+v", err), "TestWithDetails", "%+v still prints the stack")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrNotFound))
+	assert.ErrorIs(t, err, base)
+
+	// 详情在包装后保留，外层的值优先 (Details survive wrapping and outer values win)
+	wrapped := lmccerrors.WithDetails(fmt.Errorf("checkout: %w", lmccerrors.Wrap(err, "load order")), map[string]any{"order_id": "o-2"})
+	assert.Equal(t, map[string]any{"user_id": 42, "order_id": "o-2"}, lmccerrors.Details(wrapped))
+	assert.Equal(t, map[string]any{"user_id": 42, "order_id": "o-1"}, lmccerrors.Details(err))
+
+	assert.Nil(t, lmccerrors.Details(base))
+	assert.Nil(t, lmccerrors.Details(nil))
+	assert.NoError(t, lmccerrors.WithDetails(nil, attached))
+	assert.Same(t, base, lmccerrors.WithDetails(base, nil))
+}
+
+func TestWithDetails_Transports(t *testing.T) {
+	err := lmccerrors.WithDetails(lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "order missing"),
+		map[string]any{"order_id": "o-1", "attempts": 3, "tags": []string{"a"}})
+
+	rec := httptest.NewRecorder()
+	lmccerrors.WriteHTTPError(rec, err)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]any{"order_id": "o-1", "attempts": float64(3), "tags": []any{"a"}}, body["details"])
+
+	// gRPC 详情经过网络传输后还原 (gRPC details are restored after crossing the wire)
+	st := lmccerrors.ToGRPCStatus(err)
+	back := lmccerrors.FromGRPCStatus(status.Convert(st.Err()))
+	assert.True(t, lmccerrors.IsCode(back, lmccerrors.ErrNotFound))
+	assert.Equal(t, map[string]any{"order_id": "o-1", "attempts": float64(3), "tags": []any{"a"}}, lmccerrors.Details(back))
+
+	plain := lmccerrors.ToGRPCStatus(lmccerrors.WithDetails(errors.New("boom"), map[string]any{"k": "v"}))
+	assert.Equal(t, map[string]any{"k": "v"}, lmccerrors.Details(lmccerrors.FromGRPCStatus(plain)))
+}
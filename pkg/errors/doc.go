@@ -24,16 +24,38 @@
 //
 //   - Coder System: Define structured error types with codes, messages, HTTP statuses, and references.
 //     (Coder 系统：定义结构化的错误类型，包含错误码、消息、HTTP状态码和参考信息。)
-//   - Stack Traces: Automatically capture stack traces at the point of error creation or wrapping.
-//     (堆栈跟踪：在错误创建或包装时自动捕获堆栈跟踪。)
+//   - Standard Coders: `ErrNotFound`, `ErrAlreadyExists`, `ErrUnauthorized`, `ErrForbidden`, `ErrTimeout`, `ErrRateLimited`, `ErrInternal`, `ErrUnavailable` and `ErrValidation` have stable codes and HTTP/gRPC mappings, so services do not need to define their own copies.
+//     (标准 Coder：`ErrNotFound`、`ErrAlreadyExists`、`ErrUnauthorized`、`ErrForbidden`、`ErrTimeout`、`ErrRateLimited`、`ErrInternal`、`ErrUnavailable` 和 `ErrValidation` 具有稳定的错误码以及 HTTP/gRPC 映射，服务无需自行定义副本。)
+//   - Stack Traces: Automatically capture stack traces at the point of error creation or wrapping. `SetStackCaptureDepth` limits the captured frames, `DisableStackCapture` turns capture off, and `NewNoStack` skips it for a single hot-path error. `StackTraceOf` returns the innermost captured stack of an error chain, `FormatStack` its resolved frames, and `SetStackFormat(StackFormatSingleLine)` makes `%+v` print the stack as a JSON array on the message line.
+//     (堆栈跟踪：在错误创建或包装时自动捕获堆栈跟踪。`SetStackCaptureDepth` 限制捕获的帧数，`DisableStackCapture` 关闭捕获，`NewNoStack` 为单个热点路径错误跳过捕获。`StackTraceOf` 返回错误链中最内层捕获的堆栈，`FormatStack` 返回其解析后的各帧，`SetStackFormat(StackFormatSingleLine)` 使 `%+v` 在消息所在行以 JSON 数组输出堆栈。)
+//   - JSON Serialization: `Document(err)` and `ToJSON(err)` describe the cause chain as a list of {message, code, stack} entries with the outer code, HTTP status and details, for API payloads and structured logging pipelines; errors of this package implement `json.Marshaler` with the same output.
+//     (JSON 序列化：`Document(err)` 和 `ToJSON(err)` 将原因链描述为 {message, code, stack} 条目的列表，并附带外层错误码、HTTP 状态码和详情，用于 API 响应和结构化日志管道；本包的错误以相同的输出实现了 `json.Marshaler`。)
 //   - Error Wrapping: Richer error wrapping capabilities than the standard library, preserving context.
 //     (错误包装：比标准库更丰富的错误包装能力，保留上下文信息。)
+//   - Structured Details: `WithDetails(err, map[string]any{...})` attaches machine-readable context such as user_id or order_id that survives wrapping; `Details(err)` reads it back, and the HTTP body, gRPC status and expanded log fields carry it.
+//     (结构化详情：`WithDetails(err, map[string]any{...})` 附加 user_id、order_id 等机器可读的上下文，包装后仍然保留；`Details(err)` 读取详情，HTTP 响应体、gRPC 状态和展开的日志字段都会携带它。)
 //   - Standard Compatibility: Works seamlessly with `errors.Is`, `errors.As`, and `errors.Unwrap`.
 //     (标准兼容性：与 `errors.Is`、`errors.As` 和 `errors.Unwrap` 无缝协作。)
 //   - Flexible Formatting: Control error output format, including verbose stack trace printing with `%+v`.
 //     (灵活格式化：控制错误输出格式，包括使用 `%+v` 打印详细的堆栈跟踪。)
-//   - Error Aggregation: Support for grouping multiple errors into a single error instance using 'ErrorGroup', which is compatible with standard error handling utilities.
-//     (错误聚合：支持使用 'ErrorGroup' 将多个错误分组到一个错误实例中，该实例与标准错误处理工具兼容。)
+//   - HTTP Mapping: `RegisterCoder` records Coders by code, `HTTPStatus(err)` resolves the HTTP status of any error, and `WriteHTTPError` writes a standard JSON body with code, message, request_id and details.
+//     (HTTP 映射：`RegisterCoder` 按错误码记录 Coder，`HTTPStatus(err)` 解析任意错误的 HTTP 状态码，`WriteHTTPError` 写出包含 code、message、request_id 和 details 的标准 JSON 响应体。)
+//   - Problem Details: `ToProblemDetails(err, opts)` builds an RFC 7807 problem+json body (type, title, status, detail, instance, with the error code and details as extensions), `WriteProblem` writes it as `application/problem+json`, and `ProblemHandler` adapts handlers returning errors to `http.Handler`.
+//     (问题详情：`ToProblemDetails(err, opts)` 构建 RFC 7807 problem+json 响应体（type、title、status、detail、instance，错误码和详情作为扩展成员），`WriteProblem` 将其写为 `application/problem+json`，`ProblemHandler` 将返回错误的处理函数适配为 `http.Handler`。)
+//   - Localized Messages: `RegisterTranslations(locale, messages)` adds per-locale messages keyed by error code, and `Localize(err, locale)` returns the translated message of err's Coder from a pkg/i18n Catalog (see `TranslationCatalog`), negotiating locale and falling back to parent languages and then the Coder's description, while `err.Error()` keeps the original text for logs.
+//     (本地化消息：`RegisterTranslations(locale, messages)` 添加以错误码为键的各语言区域消息，`Localize(err, locale)` 从 pkg/i18n 的 Catalog（见 `TranslationCatalog`）返回 err 的 Coder 的翻译消息，协商 locale 并依次回退到上级语言和 Coder 的描述，而 `err.Error()` 保留原始文本供日志使用。)
+//   - gRPC Mapping: `ToGRPCStatus(err)` maps the Coder to a gRPC code and carries the error code, description and reference in an `errdetails.ErrorInfo`; `FromGRPCStatus(st)` restores the coded error on the client side.
+//     (gRPC 映射：`ToGRPCStatus(err)` 将 Coder 映射为 gRPC 码，并通过 `errdetails.ErrorInfo` 携带错误码、描述和参考链接；`FromGRPCStatus(st)` 在客户端还原带错误码的错误。)
+//   - Retries: `Retryable(err)` classifies errors by their Coder (5xx, timeouts and 429 are retryable, other 4xx are not); pkg/retry uses it to decide which failures to retry.
+//     (重试：`Retryable(err)` 根据 Coder 对错误分类（5xx、超时和 429 可以重试，其他 4xx 不可重试）；pkg/retry 使用它决定重试哪些失败。)
+//   - Panic Capture: `defer Recover(&err)` turns a panic into an error coded `ErrPanic` whose stack starts at the panicking frame, `HandlePanic(func(p any))` hands the recovered value to a callback, `WrapPanic(p)` converts a recovered value, and `Go(fn)` runs a goroutine whose panics go to the `SetPanicReporter` function instead of crashing the process.
+//     (Panic 捕获：`defer Recover(&err)` 将 panic 转换为带 `ErrPanic` 错误码的错误，其堆栈从发生 panic 的帧开始；`HandlePanic(func(p any))` 将恢复的值交给回调；`WrapPanic(p)` 转换恢复的值；`Go(fn)` 启动的 goroutine 中的 panic 交给 `SetPanicReporter` 设置的函数，而不会使进程崩溃。)
+//   - Error Counting: `OnError(func(err error, coder Coder))` sets a hook called once per failure where it enters the package (`New`, `WithCode`, `WrapPanic` and their variants, and `Wrap` of errors from other packages), so that metrics per error code can be recorded in one place; it is a no-op by default.
+//     (错误统计：`OnError(func(err error, coder Coder))` 设置一个钩子，每个失败在进入本包时调用一次（`New`、`WithCode`、`WrapPanic` 及其变体，以及包装其他包错误的 `Wrap`），从而在一处按错误码记录指标；默认不做任何事。)
+//   - Code Catalog: `RegisterCoder` rejects a code already registered for a different Coder, `MustRegisterCoder` panics on such collisions at init time, and `Catalog()` lists every registered code as JSON or Markdown.
+//     (错误码目录：`RegisterCoder` 拒绝已被其他 Coder 注册的错误码，`MustRegisterCoder` 在 init 时遇到冲突会 panic，`Catalog()` 以 JSON 或 Markdown 列出所有已注册的错误码。)
+//   - Error Aggregation: Support for grouping multiple errors into a single error instance using 'ErrorGroup', which is compatible with standard error handling utilities. The group is safe for concurrent use, matches `errors.Is`/`errors.As` against every member through `Unwrap() []error`, and serializes to JSON.
+//     (错误聚合：支持使用 'ErrorGroup' 将多个错误分组到一个错误实例中，该实例与标准错误处理工具兼容。错误组可并发使用，通过 `Unwrap() []error` 让 `errors.Is`/`errors.As` 匹配每个成员，并可序列化为 JSON。)
 //   - Formatted Error Creation: Functions like `Errorf` and `ErrorfWithCode` leverage `fmt.Errorf` internally for message formatting and support the `%w` verb for error wrapping, ensuring behavior consistent with standard library practices. When `%w` is not used, the direct cause generated by these functions is a standard error without a distinct stack trace from this package, with the wrapper itself capturing the call-site stack.
 //     (格式化错误创建：类似 `Errorf` 和 `ErrorfWithCode` 的函数内部利用 `fmt.Errorf` 进行消息格式化，并支持 `%w` 指令进行错误包装，确保行为与标准库实践一致。当未使用 `%w` 时，这些函数直接生成的 cause 是一个不从此包角度携带独立堆栈的标准错误，其包装器本身会捕获调用点堆栈。)
 //
@@ -76,10 +98,30 @@
 //	    log.Printf("Error Code: %d, Message: %s", coder.Code(), coder.String())
 //	}
 //
+//	// Match by code across wrapped chains, ErrorGroup and errors.Join (跨包装链、ErrorGroup 和 errors.Join 按错误码匹配)
+//	if errors.HasAnyCode(err, errors.ErrNotFound, errors.ErrForbidden) {
+//	    // Client error, do not retry
+//	}
+//
 // Printing stack trace:
 //
 //	fmt.Printf("%+v\n", err) // Prints the error message(s) and the full stack trace(s)
 //
+// Reducing stack capture cost on hot paths:
+//
+//	errors.SetStackCaptureDepth(8)          // Keep only the innermost 8 frames (只保留最内层的 8 帧)
+//	errNotFound := errors.NewNoStack("not found") // No capture for this error (该错误不捕获堆栈)
+//
+// Deferring expensive messages until the error is printed:
+//
+//	// fmt.Sprintf runs only if the error is printed or logged (仅在错误被打印或记录时执行 fmt.Sprintf)
+//	err = errors.WrapLazy(err, func() string { return fmt.Sprintf("save order %+v", order) })
+//
+// Keeping each error on one line for line-oriented log pipelines:
+//
+//	errors.SetStackFormat(errors.StackFormatSingleLine)
+//	fmt.Printf("%+v\n", err) // "msg stack=[{"function":...,"file":...,"line":...}]"
+//
 // For more detailed examples and a list of predefined Coders, please refer to the
 // specific function documentation and the `coder.go` file.
 // (更多详细示例和预定义Coder列表，请参考具体函数的文档和 `coder.go` 文件。)
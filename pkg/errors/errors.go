@@ -28,15 +28,28 @@ type fundamental struct {
 	// msg 是错误消息。
 	msg string
 
+	// lazy builds msg on first use when the error was created by NewLazy.
+	// lazy 在错误由 NewLazy 创建时，于首次使用时构建 msg。
+	lazy *lazyMessage
+
 	// stack is the stack trace from the point where the error was created.
 	// stack 是从错误创建点开始的堆栈跟踪。
 	stack StackTrace
 }
 
+// message returns the message of the fundamental error, building it if it is lazy.
+// message 返回 fundamental 错误的消息，延迟构建的消息在此时构建。
+func (f *fundamental) message() string {
+	if f.lazy != nil {
+		return f.lazy.String()
+	}
+	return f.msg
+}
+
 // Error returns the message of the fundamental error.
 // Error 返回 fundamental 错误的消息。
 func (f *fundamental) Error() string {
-	return f.msg
+	return f.message()
 }
 
 // Unwrap returns nil for a fundamental error, as it does not wrap another error.
@@ -58,7 +71,7 @@ func (f *fundamental) Format(s fmt.State, verb rune) {
 		if s.Flag('+') {
 			// %+v: message and stack trace
 			// %+v: 消息和堆栈跟踪
-			fmt.Fprint(s, f.msg)
+			fmt.Fprint(s, f.message())
 			f.stack.Format(s, verb) // Delegate to StackTrace's Formatter
 			return
 		}
@@ -66,7 +79,7 @@ func (f *fundamental) Format(s fmt.State, verb rune) {
 	case 's':
 		// %s, %v: message only
 		// %s, %v: 仅消息
-		fmt.Fprint(s, f.msg)
+		fmt.Fprint(s, f.message())
 	}
 }
 
@@ -75,10 +88,10 @@ func (f *fundamental) Format(s fmt.State, verb rune) {
 // It captures the stack trace at the point of creation.
 // 它在创建点捕获堆栈跟踪。
 func New(text string) error {
-	return &fundamental{
+	return notifyError(&fundamental{
 		msg:   text,
 		stack: callers(skipFrames), // skip New itself and runtime.Callers
-	}
+	})
 }
 
 // Errorf creates a new fundamental error with a formatted message.
@@ -86,10 +99,18 @@ func New(text string) error {
 // It captures the stack trace at the point of creation.
 // 它在创建点捕获堆栈跟踪。
 func Errorf(format string, args ...interface{}) error {
-	return &fundamental{
+	return notifyError(&fundamental{
 		msg:   fmt.Sprintf(format, args...),
 		stack: callers(skipFrames), // skip Errorf itself and runtime.Callers
-	}
+	})
+}
+
+// NewNoStack creates a new fundamental error without capturing a stack trace, regardless of StackCaptureDepth.
+// Use it for errors created on hot paths whose origin is not interesting, e.g. sentinel-like "not found" results.
+// NewNoStack 创建一个不捕获堆栈跟踪的 fundamental 错误，不受 StackCaptureDepth 影响。
+// 适用于热点路径上创建、不关心其来源的错误，例如类似哨兵的 "not found" 结果。
+func NewNoStack(text string) error {
+	return &fundamental{msg: text}
 }
 
 // Is checks if the fundamental error is equivalent to the target error.
@@ -104,7 +125,7 @@ func (f *fundamental) Is(target error) bool {
 	}
 	targetF, ok := target.(*fundamental)
 	if ok {
-		return f.msg == targetF.msg
+		return f.message() == targetF.message()
 	}
 	// A fundamental error doesn't inherently carry a Coder for direct Is comparison
 	// unless we decide to change its structure or Is logic for Coders specifically.
@@ -132,6 +153,10 @@ type wrapper struct {
 	// msg 是此错误包装器的消息。
 	msg string
 
+	// lazy builds msg on first use when the error was created by WrapLazy.
+	// lazy 在错误由 WrapLazy 创建时，于首次使用时构建 msg。
+	lazy *lazyMessage
+
 	// cause is the underlying error that is being wrapped.
 	// cause 是被包装的底层错误。
 	cause error
@@ -141,15 +166,24 @@ type wrapper struct {
 	stack StackTrace
 }
 
+// message returns the wrapper's own message, building it if it is lazy.
+// message 返回包装器自身的消息，延迟构建的消息在此时构建。
+func (w *wrapper) message() string {
+	if w.lazy != nil {
+		return w.lazy.String()
+	}
+	return w.msg
+}
+
 // Error returns the message of the wrapper and the underlying error.
 // Error 返回包装器及其底层错误的消息。
 func (w *wrapper) Error() string {
 	// We need to handle the case where cause is nil, although Wrap/Wrapf should prevent this.
 	// 我们需要处理 cause 为 nil 的情况，尽管 Wrap/Wrapf 应该防止这种情况。
 	if w.cause == nil {
-		return w.msg
+		return w.message()
 	}
-	return w.msg + ": " + w.cause.Error()
+	return w.message() + ": " + w.cause.Error()
 }
 
 // Unwrap returns the underlying error for compatibility with errors.Is and errors.As.
@@ -193,11 +227,11 @@ func Wrap(err error, message string) error {
 	if err == nil {
 		return nil
 	}
-	return &wrapper{
+	return notifyWrap(&wrapper{
 		msg:   message,
 		cause: err,
 		stack: callers(skipFrames), // skip Wrap itself and runtime.Callers
-	}
+	}, err)
 }
 
 // Wrapf annotates err with a new formatted message and a stack trace.
@@ -208,11 +242,11 @@ func Wrapf(err error, format string, args ...interface{}) error {
 	if err == nil {
 		return nil
 	}
-	return &wrapper{
+	return notifyWrap(&wrapper{
 		msg:   fmt.Sprintf(format, args...),
 		cause: err,
 		stack: callers(skipFrames), // skip Wrapf itself and runtime.Callers
-	}
+	}, err)
 }
 
 // Is checks if the wrapper error or its cause is equivalent to the target error.
@@ -227,7 +261,7 @@ func (w *wrapper) Is(target error) bool {
 		// This simple comparison might not be what's usually desired for Is.
 		// errors.Is will typically use this method to unwrap.
 		// A direct match of wrapper messages is less common for Is.
-		return w.msg == targetW.msg && errors.Is(w.cause, targetW.cause)
+		return w.message() == targetW.message() && errors.Is(w.cause, targetW.cause)
 	}
 	// Delegate to the cause
 	return errors.Is(w.cause, target)
@@ -391,7 +425,7 @@ func NewWithCode(coder Coder, text string) error {
 	if coder == nil {
 		coder = unknownCoder // Default to unknownCoder if nil Coder is provided
 	}
-	return &withCode{
+	return notifyError(&withCode{
 		cause: &fundamental{
 			msg: text,
 			// No separate stack for fundamental here, stack is for withCode
@@ -399,7 +433,7 @@ func NewWithCode(coder Coder, text string) error {
 		},
 		coder: coder,
 		stack: callers(skipFrames), // skip NewWithCode itself and runtime.Callers
-	}
+	})
 }
 
 // ErrorfWithCode creates a new error that associates a Coder with a formatted message.
@@ -410,14 +444,14 @@ func ErrorfWithCode(coder Coder, format string, args ...interface{}) error {
 	if coder == nil {
 		coder = unknownCoder // Default to unknownCoder if nil Coder is provided
 	}
-	return &withCode{
+	return notifyError(&withCode{
 		cause: &fundamental{
 			msg: fmt.Sprintf(format, args...),
 			// No separate stack for fundamental here, stack is for withCode
 		},
 		coder: coder,
 		stack: callers(skipFrames), // skip ErrorfWithCode itself and runtime.Callers
-	}
+	})
 }
 
 // WithCode annotates an existing error with a Coder.
@@ -442,11 +476,11 @@ func WithCode(err error, coder Coder) error {
 	// 	coder: coder,
 	// 	stack: callers(skipFrames), // skip WithCode itself and runtime.Callers
 	// }
-	return &withCode{
+	return notifyError(&withCode{
 		cause: err,
 		coder: coder,
 		stack: callers(skipFrames), // skip WithCode itself and runtime.Callers
-	}
+	})
 }
 
 // Cause returns the underlying cause of the error, if possible.
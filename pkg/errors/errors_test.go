@@ -494,6 +494,75 @@ func TestErrorfWithCode(t *testing.T) {
 	}
 }
 
+// asCoderError exposes a Coder only through its As method, like wrappers from other packages.
+// asCoderError 仅通过 As 方法暴露 Coder，类似其他包中的包装错误。
+type asCoderError struct {
+	coder lmccerrors.Coder
+}
+
+func (e asCoderError) Error() string { return "as coder error" }
+
+func (e asCoderError) As(target interface{}) bool {
+	if c, ok := target.(*lmccerrors.Coder); ok {
+		*c = e.coder
+		return true
+	}
+	return false
+}
+
+// isCoderError declares equivalence to a Coder only through its Is method.
+// isCoderError 仅通过 Is 方法声明与某个 Coder 等价。
+type isCoderError struct {
+	code int
+}
+
+func (e isCoderError) Error() string { return "is coder error" }
+
+func (e isCoderError) Is(target error) bool {
+	c, ok := target.(lmccerrors.Coder)
+	return ok && c.Code() == e.code
+}
+
+// TestIsCodeAndHasAnyCode tests matching Coders across wrapped chains, aggregates and Is/As methods.
+// TestIsCodeAndHasAnyCode 测试跨包装链、聚合错误和 Is/As 方法匹配 Coder。
+func TestIsCodeAndHasAnyCode(t *testing.T) {
+	group := lmccerrors.NewErrorGroup("batch")
+	group.Add(errors.New("plain"))
+	group.Add(fmt.Errorf("item 2: %w", lmccerrors.NewWithCode(mc2, "missing")))
+
+	tests := []struct {
+		name   string
+		err    error
+		coders []lmccerrors.Coder
+		want   bool
+	}{
+		{name: "nil error", err: nil, coders: []lmccerrors.Coder{mc1}, want: false},
+		{name: "no coders", err: lmccerrors.NewWithCode(mc1, "x"), coders: nil, want: false},
+		{name: "nil coders are ignored", err: lmccerrors.NewWithCode(mc1, "x"), coders: []lmccerrors.Coder{nil, mc1}, want: true},
+		{name: "direct", err: lmccerrors.NewWithCode(mc1, "x"), coders: []lmccerrors.Coder{mc1}, want: true},
+		{name: "different code", err: lmccerrors.NewWithCode(mc1, "x"), coders: []lmccerrors.Coder{mc2}, want: false},
+		{name: "wrapped by fmt and Wrap", err: lmccerrors.Wrap(fmt.Errorf("ctx: %w", lmccerrors.NewWithCode(mc1, "x")), "outer"), coders: []lmccerrors.Coder{mc1}, want: true},
+		{name: "error group", err: group, coders: []lmccerrors.Coder{mc1, mc2}, want: true},
+		{name: "error group without match", err: group, coders: []lmccerrors.Coder{mc1}, want: false},
+		{name: "errors.Join", err: errors.Join(errors.New("a"), lmccerrors.Wrap(coderError{mc1}, "b")), coders: []lmccerrors.Coder{mc1}, want: true},
+		{name: "As method", err: fmt.Errorf("w: %w", asCoderError{coder: mc2}), coders: []lmccerrors.Coder{mc2}, want: true},
+		{name: "Is method", err: fmt.Errorf("w: %w", isCoderError{code: mc1.C}), coders: []lmccerrors.Coder{mc2, mc1}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lmccerrors.HasAnyCode(tt.err, tt.coders...); got != tt.want {
+				t.Errorf("HasAnyCode() = %v, want %v", got, tt.want)
+			}
+			if len(tt.coders) == 1 {
+				if got := lmccerrors.IsCode(tt.err, tt.coders[0]); got != tt.want {
+					t.Errorf("IsCode() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
 // Further tests for WithMessage, WithMessagef,
 // Is, As (more complex cases), Cause will be added progressively.
 // 후속 테스트는 WithMessage, WithMessagef,
@@ -207,6 +207,22 @@ func ExampleIsCode() {
 	// stdErrors.Is: errWithSpecificCoder IS errors.ErrNotFound instance.
 }
 
+// ExampleHasAnyCode demonstrates checking an error chain against several Coders at once.
+// ExampleHasAnyCode 展示了如何一次性检查错误链是否匹配多个 Coder 中的任意一个。
+func ExampleHasAnyCode() {
+	err := fmt.Errorf("load profile: %w", errors.NewWithCode(errors.ErrNotFound, "user 42 not found"))
+
+	if errors.HasAnyCode(err, errors.ErrNotFound, errors.ErrForbidden) {
+		fmt.Println("client error: respond without retrying")
+	}
+	if !errors.HasAnyCode(err, errors.ErrInternalServer, errors.ErrPanic) {
+		fmt.Println("not a server error")
+	}
+	// Output:
+	// client error: respond without retrying
+	// not a server error
+}
+
 // ExampleAs demonstrates extracting a Coder (or other types) from an error.
 // ExampleAs 展示了如何从错误中提取 Coder (或其他类型)。
 func ExampleAs() {
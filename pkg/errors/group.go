@@ -7,15 +7,20 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 )
 
 // ErrorGroup holds a list of errors. It implements the error interface
-// and is compatible with Go 1.20's errors.Join mechanics via an Unwrap method.
-// ErrorGroup 包含一个错误列表。它实现了 error 接口，并通过 Unwrap 方法与 Go 1.20 的 errors.Join 机制兼容。
+// and is compatible with Go 1.20's errors.Join mechanics via an Unwrap method,
+// so errors.Is, errors.As and IsCode match any member. It is safe for concurrent use.
+// ErrorGroup 包含一个错误列表。它实现了 error 接口，并通过 Unwrap 方法与 Go 1.20 的 errors.Join 机制兼容，
+// 因此 errors.Is、errors.As 和 IsCode 可以匹配任一成员。它可以被并发使用。
 type ErrorGroup struct {
+	mu      sync.RWMutex
 	errs    []error
 	message string // Optional: An overarching message for the group (主要信息)
 	// stack StackTrace // Optional: Stack trace for the creation of the group itself (聚合错误自身的堆栈)
@@ -54,9 +59,44 @@ func (eg *ErrorGroup) Add(err error) {
 	if err == nil {
 		return
 	}
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
 	eg.errs = append(eg.errs, err)
 }
 
+// HasErrors reports whether the group contains at least one error.
+// HasErrors 报告组中是否至少包含一个错误。
+func (eg *ErrorGroup) HasErrors() bool {
+	return eg.Len() > 0
+}
+
+// Len returns the number of errors in the group.
+// Len 返回组中的错误数量。
+func (eg *ErrorGroup) Len() int {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+	return len(eg.errs)
+}
+
+// ErrorOrNil returns the group as an error when it contains errors, or nil otherwise,
+// so a function can end with "return eg.ErrorOrNil()".
+// ErrorOrNil 在组中包含错误时将组作为 error 返回，否则返回 nil，
+// 使函数可以以 "return eg.ErrorOrNil()" 结尾。
+func (eg *ErrorGroup) ErrorOrNil() error {
+	if eg == nil || !eg.HasErrors() {
+		return nil
+	}
+	return eg
+}
+
+// Reset removes every error from the group so it can be reused.
+// Reset 清空组中的所有错误以便复用。
+func (eg *ErrorGroup) Reset() {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+	eg.errs = []error{}
+}
+
 // Errors returns the list of errors in the group.
 // Errors 返回组中的错误列表。
 //
@@ -64,11 +104,13 @@ func (eg *ErrorGroup) Add(err error) {
 //
 //	[]error: A slice of errors. (错误切片。)
 func (eg *ErrorGroup) Errors() []error {
-	// Return a copy to prevent external modification if eg.errs is not pointer-based.
-	// However, []error itself is a reference type.
-	// To be safe and clear, if direct modification of the internal slice is a concern,
-	// one might copy it. For now, direct return is fine as errors are typically immutable or their state is not changed via this.
-	return eg.errs
+	// 返回副本，使调用方可以在其他 goroutine 继续 Add 时安全遍历
+	// (Return a copy so callers can iterate safely while other goroutines keep adding)
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+	out := make([]error, len(eg.errs))
+	copy(out, eg.errs)
+	return out
 }
 
 // Error implements the error interface. It returns a string representation of the error group.
@@ -78,6 +120,8 @@ func (eg *ErrorGroup) Errors() []error {
 //
 //	string: A string describing all errors in the group. (描述组中所有错误的字符串。)
 func (eg *ErrorGroup) Error() string {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
 	if len(eg.errs) == 0 {
 		if eg.message != "" {
 			return eg.message // Return just the group message if no errors but message exists
@@ -120,20 +164,65 @@ func (eg *ErrorGroup) Error() string {
 //	         where Join(nil...) is nil.
 //	         (如果组中没有错误，则返回 nil，以与 errors.Join 的行为保持一致，其中 Join(nil...) 为 nil。)
 func (eg *ErrorGroup) Unwrap() []error {
-	if len(eg.errs) == 0 {
+	errs := eg.Errors()
+	if len(errs) == 0 {
 		return nil
 	}
-	// Return a copy to prevent modification of the internal slice.
-	// Although []error is a reference type, the standard library's Join also effectively
-	// works with a new slice internally when it constructs the joined error.
-	// For consistency and safety, returning a new slice is good practice here.
-	// However, the standard library's multi-unwrapper pattern simply returns the slice.
-	// Let's stick to returning the direct slice as per typical Unwrap []error pattern,
-	// or a copy if modification is a high concern. For now, direct is simple.
-	// Reconsidering: errors.Join returns a new error instance. The Unwrap() []error method
-	// on such an error would return the slice it was constructed with.
-	// We should return eg.errs directly.
-	return eg.errs
+	return errs
+}
+
+// groupJSON is the JSON form of an ErrorGroup.
+// groupJSON 是 ErrorGroup 的 JSON 形式。
+type groupJSON struct {
+	Message string            `json:"message,omitempty"`
+	Errors  []json.RawMessage `json:"errors"`
+}
+
+// memberJSON is the JSON form of a group member that has no JSON encoding of its own.
+// memberJSON 是自身没有 JSON 编码的组成员的 JSON 形式。
+type memberJSON struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// MarshalJSON implements json.Marshaler. The output has the group message and one entry per error;
+// members implementing json.Marshaler (such as nested groups) encode themselves, others, including the
+// errors of this package whose full form ToJSON returns, become {"code": <coder code, if any>, "message": <Error()>}.
+// MarshalJSON 实现了 json.Marshaler。输出包含组消息和每个错误对应的条目；实现了 json.Marshaler 的成员（例如嵌套的组）
+// 自行编码，其他成员（包括完整形式由 ToJSON 返回的本包错误）编码为 {"code": <Coder 码，如有>, "message": <Error()>}。
+func (eg *ErrorGroup) MarshalJSON() ([]byte, error) {
+	errs := eg.Errors()
+	out := groupJSON{Message: eg.message, Errors: make([]json.RawMessage, 0, len(errs))}
+	for _, member := range errs {
+		var (
+			data []byte
+			err  error
+		)
+		if m, ok := member.(json.Marshaler); ok && !isChainError(member) {
+			data, err = m.MarshalJSON()
+		} else {
+			entry := memberJSON{Message: member.Error()}
+			if coder := GetCoder(member); coder != nil {
+				entry.Code = coder.Code()
+			}
+			data, err = json.Marshal(entry)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out.Errors = append(out.Errors, data)
+	}
+	return json.Marshal(out)
+}
+
+// isChainError reports whether err is one of this package's chain types, which marshal to a full ErrorDocument.
+// isChainError 报告 err 是否是本包的链式错误类型，这些类型编码为完整的 ErrorDocument。
+func isChainError(err error) bool {
+	switch err.(type) {
+	case *fundamental, *wrapper, *withCode, *withDetails:
+		return true
+	}
+	return false
 }
 
 // Format implements fmt.Formatter to provide custom formatting for ErrorGroup.
@@ -144,6 +233,8 @@ func (eg *ErrorGroup) Unwrap() []error {
 // (当动词是 'v' 且使用了 '+' 标志 (例如 "%+v") 时，)
 // (它会打印错误组的消息 (如果有)，然后是每个所含错误的详细、多行表示，)
 // (如果可用，则包括其堆栈跟踪。)
+// With StackFormatSingleLine the message and the errors are separated by "; " instead of newlines.
+// (使用 StackFormatSingleLine 时，消息和各错误之间以 "; " 而不是换行分隔。)
 // For other verbs ('s', 'q') or when '+' is not used with 'v',
 // it defaults to the output of the Error() method.
 // (对于其他动词 ('s', 'q') 或当 'v' 未与 '+' 一起使用时，它默认为 Error() 方法的输出。)
@@ -151,29 +242,36 @@ func (eg *ErrorGroup) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
+			errs := eg.Errors()
+			// With StackFormatSingleLine the whole group stays on one line.
+			// 使用 StackFormatSingleLine 时整个分组保持为一行。
+			separator := "\n"
+			if CurrentStackFormat() == StackFormatSingleLine {
+				separator = "; "
+			}
 			if eg.message != "" {
 				_, _ = io.WriteString(s, eg.message)
-				_, _ = io.WriteString(s, "\n") // Add a newline after the group message
+				_, _ = io.WriteString(s, separator) // Add a newline after the group message
 			}
-			if len(eg.errs) == 0 && eg.message == "" { // Handle case where group is empty and has no message
+			if len(errs) == 0 && eg.message == "" { // Handle case where group is empty and has no message
 				_, _ = io.WriteString(s, "empty error group") // (空错误组)
 				return
-			} else if len(eg.errs) == 0 && eg.message != "" { // Group has message but no errors
+			} else if len(errs) == 0 && eg.message != "" { // Group has message but no errors
 				// The message was already printed if it exists.
 				// We can add a note that there are no sub-errors if desired.
 				// io.WriteString(s, " (contains no sub-errors)")
 				return // Avoids printing "Error X of 0"
 			}
 
-			for i, err := range eg.errs {
+			for i, err := range errs {
 				if i > 0 {
-					_, _ = io.WriteString(s, "\n") // Add a separator line between errors
+					_, _ = io.WriteString(s, separator) // Add a separator line between errors
 				}
 				// Use Fprintf to format each sub-error with its details using %+v
 				// This will recursively call Format on sub-errors if they implement fmt.Formatter
 				// (使用 Fprintf 通过 %+v 格式化每个子错误的详细信息)
 				// (如果子错误实现了 fmt.Formatter，这将递归调用其 Format 方法)
-				fmt.Fprintf(s, "Error %d of %d: %+v", i+1, len(eg.errs), err)
+				fmt.Fprintf(s, "Error %d of %d: %+v", i+1, len(errs), err)
 			}
 			return
 		}
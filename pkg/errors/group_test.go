@@ -7,9 +7,11 @@
 package errors_test // Use errors_test for black-box testing
 
 import (
+	"encoding/json"
 	"errors" // Standard library errors for Is/As and creating simple errors
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 
 	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
@@ -106,6 +108,91 @@ func TestErrorGroup_Add(t *testing.T) {
 	}
 }
 
+func TestErrorGroup_HasErrors(t *testing.T) {
+	t.Parallel()
+
+	eg := lmccerrors.NewErrorGroup("test has errors")
+	if eg.HasErrors() || eg.Len() != 0 {
+		t.Errorf("New group should be empty, got HasErrors=%v Len=%d", eg.HasErrors(), eg.Len())
+	}
+	if err := eg.ErrorOrNil(); err != nil {
+		t.Errorf("ErrorOrNil() on empty group should return nil, got %v", err)
+	}
+
+	eg.Add(errors.New("boom"))
+	if !eg.HasErrors() || eg.Len() != 1 {
+		t.Errorf("Expected HasErrors=true Len=1, got HasErrors=%v Len=%d", eg.HasErrors(), eg.Len())
+	}
+	if err := eg.ErrorOrNil(); err != eg {
+		t.Errorf("ErrorOrNil() should return the group itself, got %v", err)
+	}
+
+	eg.Reset()
+	if eg.HasErrors() {
+		t.Errorf("Expected no errors after Reset, got %d", eg.Len())
+	}
+
+	var nilGroup *lmccerrors.ErrorGroup
+	if err := nilGroup.ErrorOrNil(); err != nil {
+		t.Errorf("ErrorOrNil() on nil group should return nil, got %v", err)
+	}
+}
+
+func TestErrorGroup_ConcurrentAdd(t *testing.T) {
+	t.Parallel()
+
+	eg := lmccerrors.NewErrorGroup()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			eg.Add(fmt.Errorf("error %d", i))
+			_ = eg.Error()
+		}(i)
+	}
+	wg.Wait()
+
+	if eg.Len() != 50 {
+		t.Errorf("Expected 50 errors after concurrent Add, got %d", eg.Len())
+	}
+
+	// Errors returns a copy, so modifying it must not affect the group
+	errs := eg.Errors()
+	errs[0] = nil
+	if eg.Errors()[0] == nil {
+		t.Errorf("Modifying the slice returned by Errors() should not change the group")
+	}
+}
+
+func TestErrorGroup_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	inner := lmccerrors.NewErrorGroup("inner")
+	inner.Add(errors.New("nested failure"))
+
+	eg := lmccerrors.NewErrorGroup("validation failed")
+	eg.Add(errors.New("plain failure"))
+	eg.Add(lmccErrNotFound)
+	eg.Add(inner)
+
+	data, err := json.Marshal(eg)
+	if err != nil {
+		t.Fatalf("json.Marshal(group) returned error: %v", err)
+	}
+	expected := `{"message":"validation failed","errors":[` +
+		`{"message":"plain failure"},` +
+		`{"code":100002,"message":"Resource not found: resource was not found for group test"},` +
+		`{"message":"inner","errors":[{"message":"nested failure"}]}]}`
+	assert.JSONEq(t, expected, string(data))
+
+	data, err = json.Marshal(lmccerrors.NewErrorGroup())
+	if err != nil {
+		t.Fatalf("json.Marshal(empty group) returned error: %v", err)
+	}
+	assert.JSONEq(t, `{"errors":[]}`, string(data))
+}
+
 func TestErrorGroup_Error(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,276 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// GRPCErrorDomain is the ErrorInfo domain ToGRPCStatus uses to mark the details it attaches.
+// GRPCErrorDomain 是 ToGRPCStatus 附加的 ErrorInfo 详情所使用的域。
+const GRPCErrorDomain = "lmcc-go-sdk"
+
+// ErrorInfo metadata keys written by ToGRPCStatus.
+// ToGRPCStatus 写入的 ErrorInfo 元数据键。
+const (
+	grpcMetaCode        = "code"
+	grpcMetaDescription = "description"
+	grpcMetaReference   = "reference"
+	grpcMetaHTTPStatus  = "http_status"
+)
+
+// GRPCCode returns the gRPC code for err: OK for nil, AlreadyExists for ErrAlreadyExists, otherwise the code mapped
+// from HTTPStatus(err). Errors without a Coder keep the code of a gRPC status in their chain, and context.Canceled and
+// context.DeadlineExceeded map to Canceled and DeadlineExceeded.
+// GRPCCode 返回 err 对应的 gRPC 码：nil 返回 OK，ErrAlreadyExists 返回 AlreadyExists，否则由 HTTPStatus(err) 映射得到。
+// 没有 Coder 的错误保留其错误链中 gRPC 状态的码，context.Canceled 和 context.DeadlineExceeded 分别映射为 Canceled 和 DeadlineExceeded。
+func GRPCCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	coder := GetCoder(err)
+	if coder != nil && coder.Code() == ErrAlreadyExists.Code() {
+		// 409 通常映射为 Aborted，ErrAlreadyExists 有更精确的 gRPC 码 (409 usually maps to Aborted; ErrAlreadyExists has a more precise gRPC code)
+		return codes.AlreadyExists
+	}
+	if coder == nil {
+		if st, ok := status.FromError(err); ok {
+			return st.Code()
+		}
+		switch {
+		case errors.Is(err, context.Canceled):
+			return codes.Canceled
+		case errors.Is(err, context.DeadlineExceeded):
+			return codes.DeadlineExceeded
+		}
+	}
+	return grpcCodeFromHTTPStatus(HTTPStatus(err))
+}
+
+// ToGRPCStatus converts err into a gRPC status. The status code comes from GRPCCode, the message is err.Error(),
+// and an errdetails.ErrorInfo in the GRPCErrorDomain carries the Coder's code, description, reference and HTTP status
+// so FromGRPCStatus can restore the Coder on the other side. Details attached with WithDetails are added as a
+// structpb.Struct. Errors without a Coder that already carry a gRPC status are returned unchanged, and nil becomes an OK status.
+// ToGRPCStatus 将 err 转换为 gRPC 状态。状态码来自 GRPCCode，消息为 err.Error()，GRPCErrorDomain 域中的 errdetails.ErrorInfo
+// 携带 Coder 的错误码、描述、参考链接和 HTTP 状态码，使 FromGRPCStatus 能在另一端还原 Coder。
+// 通过 WithDetails 附加的详情以 structpb.Struct 形式加入。没有 Coder 但已携带 gRPC 状态的错误原样返回，nil 转换为 OK 状态。
+func ToGRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	coder := GetCoder(err)
+	if coder == nil {
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+		return withGRPCDetails(status.New(GRPCCode(err), err.Error()), err)
+	}
+	if registered, ok := LookupCoder(coder.Code()); ok {
+		coder = registered
+	}
+
+	st := status.New(GRPCCode(err), err.Error())
+	metadata := map[string]string{
+		grpcMetaCode:        strconv.Itoa(coder.Code()),
+		grpcMetaDescription: coder.String(),
+		grpcMetaHTTPStatus:  strconv.Itoa(HTTPStatus(err)),
+	}
+	if ref := coder.Reference(); ref != "" {
+		metadata[grpcMetaReference] = ref
+	}
+	withInfo, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "CODE_" + strconv.Itoa(coder.Code()),
+		Domain:   GRPCErrorDomain,
+		Metadata: metadata,
+	})
+	if detailsErr != nil {
+		return st
+	}
+	return withGRPCDetails(withInfo, err)
+}
+
+// withGRPCDetails appends the details attached to err as a protobuf Struct, returning st unchanged when there are none.
+// withGRPCDetails 将 err 附加的详情作为 protobuf Struct 追加到状态中，没有详情时原样返回 st。
+func withGRPCDetails(st *status.Status, err error) *status.Status {
+	details := Details(err)
+	if len(details) == 0 {
+		return st
+	}
+	s := detailsStruct(details)
+	if s == nil {
+		return st
+	}
+	if withStruct, detailsErr := st.WithDetails(s); detailsErr == nil {
+		return withStruct
+	}
+	return st
+}
+
+// detailsFromGRPCStatus returns the details written by ToGRPCStatus, or nil.
+// detailsFromGRPCStatus 返回 ToGRPCStatus 写入的详情，没有时返回 nil。
+func detailsFromGRPCStatus(st *status.Status) map[string]any {
+	for _, detail := range st.Details() {
+		if s, ok := detail.(*structpb.Struct); ok {
+			return s.AsMap()
+		}
+	}
+	return nil
+}
+
+// FromGRPCStatus converts a gRPC status back into an error. When the status carries the ErrorInfo written by
+// ToGRPCStatus, the error has the Coder registered for that code, or a Coder rebuilt from the details when the code is
+// not registered locally; otherwise the Coder is chosen from the gRPC code, e.g. NotFound becomes ErrNotFound.
+// Details attached by ToGRPCStatus are restored and returned by Details. A nil or OK status returns nil.
+// FromGRPCStatus 将 gRPC 状态转换回错误。状态携带 ToGRPCStatus 写入的 ErrorInfo 时，错误使用为该错误码注册的 Coder，
+// 本地未注册时根据详情重建 Coder；否则根据 gRPC 码选择 Coder，例如 NotFound 转换为 ErrNotFound。
+// ToGRPCStatus 附加的详情会被还原，可通过 Details 读取。nil 或 OK 状态返回 nil。
+func FromGRPCStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	coder := coderFromGRPCStatus(st)
+
+	// ToGRPCStatus 的消息包含 Coder 描述，去掉它以免重复 (The message from ToGRPCStatus contains the Coder description; drop it to avoid repeating it)
+	msg := st.Message()
+	var err error
+	if msg == coder.String() {
+		err = &withCode{coder: coder, stack: callers(skipFrames)}
+	} else {
+		err = &withCode{
+			cause: &fundamental{msg: strings.Replace(msg, coder.String()+": ", "", 1)},
+			coder: coder,
+			stack: callers(skipFrames),
+		}
+	}
+	return WithDetails(err, detailsFromGRPCStatus(st))
+}
+
+// coderFromGRPCStatus returns the Coder described by the status details, falling back to one chosen from the gRPC code.
+// coderFromGRPCStatus 返回状态详情描述的 Coder，没有详情时根据 gRPC 码选择。
+func coderFromGRPCStatus(st *status.Status) Coder {
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != GRPCErrorDomain {
+			continue
+		}
+		metadata := info.GetMetadata()
+		code, err := strconv.Atoi(metadata[grpcMetaCode])
+		if err != nil {
+			continue
+		}
+		if registered, ok := LookupCoder(code); ok {
+			return registered
+		}
+		httpStatus, err := strconv.Atoi(metadata[grpcMetaHTTPStatus])
+		if err != nil {
+			httpStatus = httpStatusFromGRPCCode(st.Code())
+		}
+		return NewCoder(code, httpStatus, metadata[grpcMetaDescription], metadata[grpcMetaReference])
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return ErrBadRequest
+	case codes.Unauthenticated:
+		return ErrUnauthorized
+	case codes.PermissionDenied:
+		return ErrForbidden
+	case codes.NotFound:
+		return ErrNotFound
+	case codes.AlreadyExists:
+		return ErrAlreadyExists
+	case codes.DeadlineExceeded:
+		return ErrTimeout
+	case codes.ResourceExhausted:
+		return ErrTooManyRequests
+	case codes.Unavailable:
+		return ErrUnavailable
+	case codes.Internal:
+		return ErrInternalServer
+	default:
+		return NewCoder(unknownCoder.Code(), httpStatusFromGRPCCode(st.Code()), unknownCoder.String(), "")
+	}
+}
+
+// grpcCodeFromHTTPStatus maps an HTTP status to the closest gRPC code.
+// grpcCodeFromHTTPStatus 将 HTTP 状态码映射为最接近的 gRPC 码。
+func grpcCodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestedRangeNotSatisfiable:
+		return codes.OutOfRange
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499: // 客户端关闭请求 (Client closed request)
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	switch {
+	case httpStatus >= 400 && httpStatus < 500:
+		return codes.FailedPrecondition
+	case httpStatus >= 500:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// httpStatusFromGRPCCode maps a gRPC code to an HTTP status.
+// httpStatusFromGRPCCode 将 gRPC 码映射为 HTTP 状态码。
+func httpStatusFromGRPCCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,106 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"plain error", errors.New("boom"), codes.Internal},
+		{"not found", lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42"), codes.NotFound},
+		{"validation", fmt.Errorf("handler: %w", lmccerrors.WithCode(errors.New("bad"), lmccerrors.ErrValidation)), codes.InvalidArgument},
+		{"too many requests", lmccerrors.NewWithCode(lmccerrors.ErrTooManyRequests, "slow down"), codes.ResourceExhausted},
+		{"already exists", lmccerrors.NewWithCode(lmccerrors.ErrAlreadyExists, "user 42"), codes.AlreadyExists},
+		{"unavailable", lmccerrors.NewWithCode(lmccerrors.ErrUnavailable, "db down"), codes.Unavailable},
+		{"lock held", lmccerrors.NewWithCode(lmccerrors.ErrLockHeld, "job"), codes.Aborted},
+		{"remote config", lmccerrors.NewWithCode(lmccerrors.ErrConfigRemote, "etcd"), codes.Unavailable},
+		{"context canceled", fmt.Errorf("call: %w", context.Canceled), codes.Canceled},
+		{"grpc status", fmt.Errorf("call: %w", status.Error(codes.AlreadyExists, "dup")), codes.AlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lmccerrors.GRPCCode(tt.err))
+		})
+	}
+}
+
+func TestGRPCStatus_RoundTrip(t *testing.T) {
+	err := lmccerrors.Wrap(lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42"), "load profile")
+
+	st := lmccerrors.ToGRPCStatus(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, err.Error(), st.Message())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, lmccerrors.GRPCErrorDomain, info.GetDomain())
+	assert.Equal(t, "100002", info.GetMetadata()["code"])
+
+	// 经过网络传输后还原 (Restore after crossing the wire)
+	back := lmccerrors.FromGRPCStatus(status.Convert(st.Err()))
+	require.Error(t, back)
+	assert.True(t, lmccerrors.IsCode(back, lmccerrors.ErrNotFound))
+	assert.Equal(t, http.StatusNotFound, lmccerrors.HTTPStatus(back))
+	assert.Equal(t, "Resource not found: load profile: user 42", back.Error())
+
+	assert.Equal(t, codes.OK, lmccerrors.ToGRPCStatus(nil).Code())
+	assert.NoError(t, lmccerrors.FromGRPCStatus(nil))
+	assert.NoError(t, lmccerrors.FromGRPCStatus(status.New(codes.OK, "")))
+}
+
+func TestGRPCStatus_UnregisteredCoder(t *testing.T) {
+	// 只在服务端定义的 Coder 在客户端根据详情重建 (A Coder defined only on the server is rebuilt from the details on the client)
+	errQuotaExceeded := lmccerrors.NewCoder(990301, http.StatusTooManyRequests, "Quota exceeded", "https://example.com/quota")
+	st := lmccerrors.ToGRPCStatus(lmccerrors.NewWithCode(errQuotaExceeded, "tenant acme"))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+	back := lmccerrors.FromGRPCStatus(st)
+	coder := lmccerrors.GetCoder(back)
+	require.NotNil(t, coder)
+	assert.Equal(t, 990301, coder.Code())
+	assert.Equal(t, "Quota exceeded", coder.String())
+	assert.Equal(t, "https://example.com/quota", coder.Reference())
+	assert.Equal(t, http.StatusTooManyRequests, coder.HTTPStatus())
+	assert.Equal(t, "Quota exceeded: tenant acme", back.Error())
+}
+
+func TestFromGRPCStatus_PlainStatus(t *testing.T) {
+	back := lmccerrors.FromGRPCStatus(status.New(codes.PermissionDenied, "no access"))
+	assert.True(t, lmccerrors.IsCode(back, lmccerrors.ErrForbidden))
+	assert.Equal(t, "Forbidden: no access", back.Error())
+
+	back = lmccerrors.FromGRPCStatus(status.New(codes.Unavailable, "connection refused"))
+	assert.True(t, lmccerrors.IsCode(back, lmccerrors.ErrUnavailable))
+	assert.Equal(t, http.StatusServiceUnavailable, lmccerrors.HTTPStatus(back))
+
+	back = lmccerrors.FromGRPCStatus(status.New(codes.AlreadyExists, "dup"))
+	assert.True(t, lmccerrors.IsCode(back, lmccerrors.ErrAlreadyExists))
+	assert.Equal(t, http.StatusConflict, lmccerrors.HTTPStatus(back))
+
+	// 没有 Coder 的 gRPC 错误原样保留状态 (A gRPC error without a Coder keeps its status)
+	st := lmccerrors.ToGRPCStatus(fmt.Errorf("call: %w", status.Error(codes.AlreadyExists, "dup")))
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Empty(t, st.Details())
+}
@@ -0,0 +1,65 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// errorHook holds the function set with OnError.
+// errorHook 保存通过 OnError 设置的函数。
+var errorHook atomic.Pointer[func(err error, coder Coder)]
+
+// OnError sets a hook called once for every failure, so that applications can count errors per code in one place
+// instead of at every call site. A failure is reported where it enters the package: by New, Errorf and NewLazy for
+// errors without a code, by NewWithCode, ErrorfWithCode, WithCode and WrapPanic where a Coder is attached, and by
+// Wrap, Wrapf and WrapLazy only when their cause was not created by this package, e.g. an error returned by the
+// standard library. Wrapping an error of this package again is not reported, so wrap layers do not inflate the
+// counts. coder is the first Coder in the error chain, or nil when the error has none. The hook runs synchronously
+// on the creating goroutine and must not create errors with these functions itself. A nil hook removes it; without
+// a hook the constructors do no extra work. It is safe for concurrent use.
+// OnError 设置一个钩子，每个失败调用一次，使应用可以在一处按错误码统计错误，而无需在每个调用点埋点。失败在进入本包时报告：
+// 没有错误码的错误由 New、Errorf 和 NewLazy 报告，附加 Coder 时由 NewWithCode、ErrorfWithCode、WithCode 和 WrapPanic 报告，
+// Wrap、Wrapf 和 WrapLazy 仅在其 cause 不是本包创建的错误时报告，例如标准库返回的错误。再次包装本包的错误不会报告，
+// 因此包装层数不会使计数膨胀。coder 是错误链中的第一个 Coder，没有时为 nil。钩子在创建错误的 goroutine 中同步执行，
+// 其自身不得使用这些函数创建错误。hook 为 nil 时移除钩子；未设置钩子时这些构造函数没有额外开销。可并发调用。
+func OnError(hook func(err error, coder Coder)) {
+	if hook == nil {
+		errorHook.Store(nil)
+		return
+	}
+	errorHook.Store(&hook)
+}
+
+// notifyError passes err to the hook set with OnError, if any, and returns err.
+// notifyError 将 err 交给通过 OnError 设置的钩子（如果有），并返回 err。
+func notifyError(err error) error {
+	if hook := errorHook.Load(); hook != nil {
+		(*hook)(err, GetCoder(err))
+	}
+	return err
+}
+
+// notifyWrap passes the wrapper err to the hook like notifyError, unless its cause was created by this package and
+// so was reported already, and returns err.
+// notifyWrap 与 notifyError 一样将包装错误 err 交给钩子，但其 cause 由本包创建（因而已报告）时除外，并返回 err。
+func notifyWrap(err, cause error) error {
+	if errorHook.Load() != nil && !createdByPackage(cause) {
+		notifyError(err)
+	}
+	return err
+}
+
+// createdByPackage reports whether the chain of err contains an error created by the constructors of this package.
+// createdByPackage 报告 err 的错误链中是否包含由本包构造函数创建的错误。
+func createdByPackage(err error) bool {
+	var f *fundamental
+	var w *wrapper
+	var c *withCode
+	return errors.As(err, &f) || errors.As(err, &w) || errors.As(err, &c)
+}
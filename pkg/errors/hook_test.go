@@ -0,0 +1,89 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	stdErrors "errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnError(t *testing.T) {
+	type report struct {
+		err   error
+		coder errors.Coder
+	}
+	var mu sync.Mutex
+	var reports []report
+	errors.OnError(func(err error, coder errors.Coder) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, report{err, coder})
+	})
+	defer errors.OnError(nil)
+
+	base := errors.New("disk full")
+	wrapped := errors.Wrap(base, "save order")
+	coded := errors.WithCode(wrapped, errors.ErrInternalServer)
+	created := errors.NewWithCode(errors.ErrNotFound, "order missing")
+	_ = errors.Wrapf(errors.Wrap(created, "load order"), "handle %s", "GET /orders/1")
+	_ = errors.WrapLazy(coded, func() string { return "retry" })
+	foreign := errors.Wrap(io.ErrUnexpectedEOF, "read body")
+	_ = errors.Wrap(foreign, "decode request")
+	panicked := errors.WrapPanic("boom")
+	_ = errors.Wrap(nil, "ignored")
+	_ = errors.NewNoStack("not reported")
+
+	require.Len(t, reports, 5, "wrapping an error of this package again is not reported")
+	assert.Same(t, base, reports[0].err)
+	assert.Nil(t, reports[0].coder, "errors without a code are reported with a nil coder")
+	assert.Same(t, coded, reports[1].err)
+	assert.Equal(t, errors.ErrInternalServer, reports[1].coder)
+	assert.Equal(t, errors.ErrNotFound, reports[2].coder)
+	assert.Same(t, foreign, reports[3].err, "wrapping an error from another package is reported")
+	assert.Nil(t, reports[3].coder)
+	assert.Same(t, panicked, reports[4].err)
+	assert.Equal(t, errors.ErrPanic, reports[4].coder)
+
+	stack := errors.FormatStack(created)
+	require.Greater(t, len(stack), 1)
+	assert.Equal(t, "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors.NewWithCode", stack[0].Function)
+	assert.Contains(t, stack[1].Function, "TestOnError", "the hook does not add frames to the captured stack")
+}
+
+func TestOnError_Concurrent(t *testing.T) {
+	var mu sync.Mutex
+	counts := make(map[int]int)
+	errors.OnError(func(err error, coder errors.Coder) {
+		if coder == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		counts[coder.Code()]++
+	})
+	defer errors.OnError(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = errors.WithCode(stdErrors.New("timeout"), errors.ErrTimeout)
+		}()
+	}
+	wg.Wait()
+
+	errors.OnError(nil)
+	_ = errors.NewWithCode(errors.ErrTimeout, "after removal")
+	assert.Equal(t, 50, counts[errors.ErrTimeout.Code()])
+}
@@ -0,0 +1,60 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// RequestIDHeader is the response header WriteHTTPError reads the request ID from.
+// Request ID middleware usually sets it before the handler runs.
+// RequestIDHeader 是 WriteHTTPError 读取请求 ID 的响应头，通常由请求 ID 中间件在处理函数执行前设置。
+const RequestIDHeader = "X-Request-ID"
+
+// HTTPErrorBody is the JSON body written by WriteHTTPError.
+// HTTPErrorBody 是 WriteHTTPError 写入的 JSON 响应体。
+type HTTPErrorBody struct {
+	Code      int            `json:"code"`
+	Message   string         `json:"message"`
+	RequestID string         `json:"request_id,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+// NewHTTPErrorBody builds the response body for err. The message is the Coder's description rather than
+// err.Error(), so wrapped internal details are not exposed to clients. Details attached with WithDetails are included.
+// NewHTTPErrorBody 为 err 构建响应体。消息使用 Coder 的描述而不是 err.Error()，避免向客户端暴露被包装的内部细节。
+// 通过 WithDetails 附加的详情会包含在内。
+func NewHTTPErrorBody(err error) HTTPErrorBody {
+	coder := GetCoder(err)
+	if coder == nil {
+		coder = unknownCoder
+	} else if registered, ok := LookupCoder(coder.Code()); ok {
+		coder = registered
+	}
+	return HTTPErrorBody{Code: coder.Code(), Message: coder.String(), Details: Details(err)}
+}
+
+// WriteHTTPError writes err as a JSON response with the status from HTTPStatus and a body of
+// {"code", "message", "request_id", "details"}. The request ID is taken from the RequestIDHeader response header.
+// A nil err is written as the unknown error with status 500.
+// WriteHTTPError 将 err 写为 JSON 响应，状态码来自 HTTPStatus，响应体为 {"code", "message", "request_id", "details"}。
+// 请求 ID 取自 RequestIDHeader 响应头。nil 按未知错误以 500 写出。
+func WriteHTTPError(w http.ResponseWriter, err error) {
+	status := HTTPStatus(err)
+	if err == nil {
+		// nil 不应作为错误写出，按未知错误处理 (nil should not be written as an error; treat it as unknown)
+		status = unknownCoder.HTTPStatus()
+	}
+	body := NewHTTPErrorBody(err)
+	body.RequestID = w.Header().Get(RequestIDHeader)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
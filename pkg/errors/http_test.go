@@ -0,0 +1,76 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	errOrderLocked := lmccerrors.NewCoder(990001, http.StatusLocked, "Order locked", "")
+	require.NoError(t, lmccerrors.RegisterCoder(errOrderLocked))
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError},
+		{"predefined coder", lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42"), http.StatusNotFound},
+		{"wrapped coder", fmt.Errorf("handler: %w", lmccerrors.WithCode(errors.New("denied"), lmccerrors.ErrForbidden)), http.StatusForbidden},
+		{"registered coder", lmccerrors.NewWithCode(errOrderLocked, "order 7"), http.StatusLocked},
+		// 只携带错误码的 Coder 使用注册表中的状态码 (A Coder carrying only the code uses the status from the registry)
+		{"registered code without status", lmccerrors.WithCode(errors.New("locked"), lmccerrors.NewCoder(990001, 0, "", "")), http.StatusLocked},
+		{"unregistered coder without status", lmccerrors.WithCode(errors.New("x"), lmccerrors.NewCoder(990002, 0, "", "")), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lmccerrors.HTTPStatus(tt.err))
+		})
+	}
+}
+
+func TestLookupCoder(t *testing.T) {
+	coder, ok := lmccerrors.LookupCoder(lmccerrors.ErrTooManyRequests.Code())
+	require.True(t, ok, "predefined coders are registered")
+	assert.Equal(t, lmccerrors.ErrTooManyRequests, coder)
+
+	_, ok = lmccerrors.LookupCoder(-12345)
+	assert.False(t, ok)
+
+	assert.NoError(t, lmccerrors.RegisterCoder(nil), "nil is ignored")
+}
+
+func TestWriteHTTPError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(lmccerrors.RequestIDHeader, "req-123")
+	err := lmccerrors.Wrap(lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42 missing in shard 3"), "load profile")
+
+	lmccerrors.WriteHTTPError(rec, err)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	var body lmccerrors.HTTPErrorBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, lmccerrors.HTTPErrorBody{Code: 100002, Message: "Resource not found", RequestID: "req-123"}, body)
+	assert.NotContains(t, rec.Body.String(), "shard 3", "internal details are not exposed")
+
+	rec = httptest.NewRecorder()
+	lmccerrors.WriteHTTPError(rec, errors.New("database exploded"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"code":-1,"message":"An internal server error occurred"}`, rec.Body.String())
+}
@@ -0,0 +1,82 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/i18n"
+)
+
+// translations is the message catalog used by Localize, keyed by the decimal error code. Its default language is
+// language.Und, which has no messages, so codes without a translation fall back to the Coder's description.
+// translations 是 Localize 使用的消息目录，以十进制错误码为键。其默认语言 language.Und 没有消息，
+// 因此没有翻译的错误码回退到 Coder 的描述。
+var translations = i18n.NewCatalog(language.Und)
+
+// TranslationCatalog returns the message catalog used by Localize, e.g. to load translations from files with LoadFS.
+// Keys are decimal error codes such as "100004".
+// TranslationCatalog 返回 Localize 使用的消息目录，例如用 LoadFS 从文件加载翻译。键为十进制错误码，例如 "100004"。
+func TranslationCatalog() *i18n.Catalog {
+	return translations
+}
+
+// RegisterTranslations adds the messages of locale, keyed by error code, to the message catalog used by Localize.
+// Locales are BCP 47 tags matched case-insensitively, and "_" is treated as "-", so "zh_CN" and "zh-cn" are the same
+// locale; invalid locales are ignored. A message registered again for the same locale and code replaces the earlier
+// one; empty messages are ignored.
+// RegisterTranslations 将 locale 的消息（以错误码为键）加入 Localize 使用的消息目录。语言区域为不区分大小写的 BCP 47 标签，
+// "_" 视为 "-"，因此 "zh_CN" 与 "zh-cn" 是同一语言区域；忽略无效的语言区域。为同一语言区域和错误码再次注册的消息会替换之前的消息；
+// 忽略空消息。
+func RegisterTranslations(locale string, messages map[int]string) {
+	tag, err := language.Parse(normalizeLocale(locale))
+	if err != nil || tag == language.Und {
+		return
+	}
+	for code, message := range messages {
+		if message != "" {
+			translations.Set(tag, strconv.Itoa(code), message)
+		}
+	}
+}
+
+// Localize returns the message of err's Coder translated for locale, for showing to end users while err.Error()
+// keeps the original message for logs. The Coder is resolved like NewHTTPErrorBody: the registered Coder for the
+// code in err's chain, or the unknown Coder for errors without one. locale is a language tag or an Accept-Language
+// header; the best registered language is chosen with i18n.Catalog.Negotiate. When that language has no translation
+// for the code, its parent languages are tried ("zh-Hans-CN", then "zh-Hans", then "zh"), and finally the Coder's own
+// description. A nil err returns an empty string.
+// Localize 返回 err 的 Coder 在 locale 下的翻译消息，用于展示给最终用户，而 err.Error() 保留原始消息供日志使用。
+// Coder 的解析方式与 NewHTTPErrorBody 相同：使用错误链中错误码对应的已注册 Coder，没有 Coder 的错误使用未知 Coder。
+// locale 为语言标签或 Accept-Language 头，通过 i18n.Catalog.Negotiate 选择最合适的已注册语言。该语言没有此错误码的翻译时
+// 依次尝试其上级语言（"zh-Hans-CN"、"zh-Hans"、"zh"），最后使用 Coder 自身的描述。nil 返回空字符串。
+func Localize(err error, locale string) string {
+	if err == nil {
+		return ""
+	}
+	coder := GetCoder(err)
+	if coder == nil {
+		coder = unknownCoder
+	} else if registered, ok := LookupCoder(coder.Code()); ok {
+		coder = registered
+	}
+	tag := translations.Negotiate(normalizeLocale(locale))
+	key := strconv.Itoa(coder.Code())
+	if translations.Has(tag, key) {
+		return translations.T(tag, key)
+	}
+	return coder.String()
+}
+
+// normalizeLocale trims locale and replaces "_" with "-".
+// normalizeLocale 去除 locale 首尾空白并将 "_" 替换为 "-"。
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+}
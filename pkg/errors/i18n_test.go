@@ -0,0 +1,46 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestLocalize(t *testing.T) {
+	errQuotaExceeded := lmccerrors.NewCoder(990401, http.StatusTooManyRequests, "Quota exceeded", "")
+	lmccerrors.MustRegisterCoder(errQuotaExceeded)
+	lmccerrors.RegisterTranslations("zh-CN", map[int]string{
+		errQuotaExceeded.Code():         "配额已用尽",
+		lmccerrors.ErrNotFound.Code():   "资源不存在",
+		lmccerrors.ErrBadRequest.Code(): "",
+	})
+	lmccerrors.RegisterTranslations("zh", map[int]string{lmccerrors.ErrBadRequest.Code(): "请求无效"})
+
+	err := lmccerrors.Wrap(lmccerrors.NewWithCode(errQuotaExceeded, "tenant t1 used 1000/1000 requests"), "handle request")
+	assert.Equal(t, "配额已用尽", lmccerrors.Localize(err, "zh-CN"))
+	assert.Equal(t, "配额已用尽", lmccerrors.Localize(err, "zh_cn"), "locales are normalized")
+	assert.Equal(t, "Quota exceeded", lmccerrors.Localize(err, "en-US"), "missing translations fall back to the description")
+	assert.Contains(t, err.Error(), "tenant t1", "the error message is not translated")
+
+	assert.Equal(t, "资源不存在", lmccerrors.Localize(fmt.Errorf("lookup: %w", lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42")), "zh-CN"))
+	assert.Equal(t, "请求无效", lmccerrors.Localize(lmccerrors.NewWithCode(lmccerrors.ErrBadRequest, "x"), "zh-Hans-CN"),
+		"parent locales are tried")
+	notFound := lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "x")
+	assert.Equal(t, "资源不存在", lmccerrors.Localize(notFound, "fr-FR, zh-CN;q=0.8"), "Accept-Language headers are negotiated")
+	assert.Equal(t, "Resource not found", lmccerrors.Localize(notFound, "fr-FR"), "unmatched locales fall back to the description")
+	assert.True(t, lmccerrors.TranslationCatalog().Has(language.MustParse("zh-CN"), strconv.Itoa(errQuotaExceeded.Code())),
+		"translations are kept in the i18n catalog keyed by code")
+	assert.Equal(t, lmccerrors.GetUnknownCoder().String(), lmccerrors.Localize(fmt.Errorf("plain"), "zh-CN"))
+	assert.Empty(t, lmccerrors.Localize(nil, "zh-CN"))
+}
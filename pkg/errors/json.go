@@ -0,0 +1,132 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ErrorDocument is the structured JSON form of an error and its cause chain.
+// ErrorDocument 是错误及其原因链的结构化 JSON 形式。
+type ErrorDocument struct {
+	// Message is the full Error() string.
+	// Message 是完整的 Error() 字符串。
+	Message string `json:"message"`
+	// Code and HTTPStatus come from the first Coder in the chain and are omitted for uncoded errors.
+	// Code 和 HTTPStatus 来自链中的第一个 Coder，没有错误码时省略。
+	Code       int `json:"code,omitempty"`
+	HTTPStatus int `json:"http_status,omitempty"`
+	// Details holds the details attached with WithDetails.
+	// Details 包含通过 WithDetails 附加的详情。
+	Details map[string]any `json:"details,omitempty"`
+	// Chain lists the layers of the error from outermost to innermost.
+	// Chain 从最外层到最内层列出错误的各层。
+	Chain []ChainEntry `json:"chain"`
+}
+
+// ChainEntry is one layer of an error chain.
+// ChainEntry 是错误链中的一层。
+type ChainEntry struct {
+	// Message is the message this layer adds, without the messages of its causes.
+	// Message 是该层添加的消息，不含其原因的消息。
+	Message string `json:"message"`
+	// Code is set for layers that attach a Coder.
+	// Code 在附加了 Coder 的层上设置。
+	Code int `json:"code,omitempty"`
+	// Stack is the stack captured when this layer was created, if any.
+	// Stack 是创建该层时捕获的堆栈（如有）。
+	Stack []StackFrame `json:"stack,omitempty"`
+}
+
+// Document builds the ErrorDocument of err, or returns nil when err is nil. Layers added by WithDetails are merged
+// into Details instead of the chain, and the chain stops at an error that wraps several errors, such as an ErrorGroup.
+// Document 构建 err 的 ErrorDocument，err 为 nil 时返回 nil。WithDetails 添加的层合并到 Details 中而不出现在链中，
+// 链在包装多个错误的错误（例如 ErrorGroup）处结束。
+func Document(err error) *ErrorDocument {
+	if err == nil {
+		return nil
+	}
+	doc := &ErrorDocument{Message: err.Error(), Details: Details(err)}
+	if coder := GetCoder(err); coder != nil {
+		doc.Code, doc.HTTPStatus = coder.Code(), coder.HTTPStatus()
+	}
+	for err != nil {
+		var (
+			entry ChainEntry
+			next  error
+		)
+		switch e := err.(type) {
+		case *withDetails:
+			err = e.cause
+			continue
+		case *fundamental:
+			entry = ChainEntry{Message: e.message(), Stack: e.stack.Frames()}
+		case *wrapper:
+			entry = ChainEntry{Message: e.message(), Stack: e.stack.Frames()}
+			next = e.cause
+		case *withCode:
+			entry = ChainEntry{Stack: e.stack.Frames()}
+			if e.coder != nil {
+				entry.Message, entry.Code = e.coder.String(), e.coder.Code()
+			}
+			next = e.cause
+		default:
+			// 其他包装错误的消息去掉原因的部分，例如 fmt.Errorf("load: %w", err) 记为 "load"
+			// (Other wrapping errors drop the message of their cause, e.g. fmt.Errorf("load: %w", err) becomes "load")
+			entry.Message = err.Error()
+			if unwrapper, ok := err.(interface{ Unwrap() error }); ok {
+				if next = unwrapper.Unwrap(); next != nil {
+					entry.Message = strings.TrimSuffix(strings.TrimSuffix(entry.Message, next.Error()), ": ")
+				}
+			}
+		}
+		doc.Chain = append(doc.Chain, entry)
+		err = next
+	}
+	return doc
+}
+
+// WithoutStack returns a copy of the document without stacks, suitable for API error payloads sent to clients.
+// WithoutStack 返回不含堆栈的文档副本，适合放入发送给客户端的 API 错误响应中。
+func (d *ErrorDocument) WithoutStack() *ErrorDocument {
+	if d == nil {
+		return nil
+	}
+	out := *d
+	out.Chain = make([]ChainEntry, len(d.Chain))
+	for i, entry := range d.Chain {
+		entry.Stack = nil
+		out.Chain[i] = entry
+	}
+	return &out
+}
+
+// ToJSON encodes the ErrorDocument of err, including stacks, for structured logging pipelines that would otherwise
+// parse %+v output. A nil err encodes as null. Errors created by this package also implement json.Marshaler with
+// the same output, so they serialize when embedded in other JSON documents.
+// ToJSON 编码 err 的 ErrorDocument（包括堆栈），供结构化日志管道使用，而不必解析 %+v 输出。err 为 nil 时编码为 null。
+// 本包创建的错误也以相同的输出实现了 json.Marshaler，因此嵌入其他 JSON 文档时同样可以序列化。
+func ToJSON(err error) ([]byte, error) {
+	return json.Marshal(Document(err))
+}
+
+// MarshalJSON implements json.Marshaler with the output of ToJSON.
+// MarshalJSON 以 ToJSON 的输出实现 json.Marshaler。
+func (f *fundamental) MarshalJSON() ([]byte, error) { return ToJSON(f) }
+
+// MarshalJSON implements json.Marshaler with the output of ToJSON.
+// MarshalJSON 以 ToJSON 的输出实现 json.Marshaler。
+func (w *wrapper) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// MarshalJSON implements json.Marshaler with the output of ToJSON.
+// MarshalJSON 以 ToJSON 的输出实现 json.Marshaler。
+func (wc *withCode) MarshalJSON() ([]byte, error) { return ToJSON(wc) }
+
+// MarshalJSON implements json.Marshaler with the output of ToJSON.
+// MarshalJSON 以 ToJSON 的输出实现 json.Marshaler。
+func (d *withDetails) MarshalJSON() ([]byte, error) { return ToJSON(d) }
@@ -0,0 +1,79 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	assert.Nil(t, errors.Document(nil))
+
+	root := errors.New("connection refused")
+	coded := errors.WithCode(root, errors.ErrTimeout)
+	err := fmt.Errorf("handle request: %w", errors.WithDetails(errors.Wrap(coded, "load user"), map[string]any{"user_id": 42}))
+
+	doc := errors.Document(err)
+	require.NotNil(t, doc)
+	assert.Equal(t, err.Error(), doc.Message)
+	assert.Equal(t, errors.ErrTimeout.Code(), doc.Code)
+	assert.Equal(t, errors.ErrTimeout.HTTPStatus(), doc.HTTPStatus)
+	assert.Equal(t, map[string]any{"user_id": 42}, doc.Details)
+
+	require.Len(t, doc.Chain, 4, "the details layer is merged into Details")
+	assert.Equal(t, errors.ChainEntry{Message: "handle request"}, doc.Chain[0])
+	assert.Equal(t, "load user", doc.Chain[1].Message)
+	assert.Equal(t, errors.ErrTimeout.String(), doc.Chain[2].Message)
+	assert.Equal(t, errors.ErrTimeout.Code(), doc.Chain[2].Code)
+	assert.Equal(t, "connection refused", doc.Chain[3].Message)
+	assert.Zero(t, doc.Chain[3].Code)
+	for _, entry := range doc.Chain[1:] {
+		require.Greater(t, len(entry.Stack), 1)
+		assert.True(t, strings.HasSuffix(entry.Stack[1].Function, "TestDocument"), entry.Stack[1].Function)
+	}
+
+	stripped := doc.WithoutStack()
+	for _, entry := range stripped.Chain {
+		assert.Empty(t, entry.Stack)
+	}
+	assert.NotEmpty(t, doc.Chain[1].Stack, "WithoutStack returns a copy")
+}
+
+func TestToJSON(t *testing.T) {
+	data, err := errors.ToJSON(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	restore := errors.DisableStackCapture()
+	defer restore()
+
+	coded := errors.Wrap(errors.NewWithCode(errors.ErrNotFound, "user 42"), "get profile")
+	data, err = errors.ToJSON(coded)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"message": "get profile: Resource not found: user 42",
+		"code": 100002,
+		"http_status": 404,
+		"chain": [
+			{"message": "get profile"},
+			{"message": "Resource not found", "code": 100002},
+			{"message": "user 42"}
+		]
+	}`, string(data))
+
+	// 嵌入其他文档时同样序列化 (Errors serialize when embedded in other documents too)
+	payload, err := json.Marshal(map[string]any{"error": coded})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error":`+string(data)+`}`, string(payload))
+}
@@ -0,0 +1,58 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import "sync"
+
+// lazyMessage is an error message built by a function the first time it is needed.
+// lazyMessage 是在首次需要时才由函数构建的错误消息。
+type lazyMessage struct {
+	once sync.Once
+	fn   func() string
+	msg  string
+}
+
+// String builds the message on the first call and returns the cached message afterwards. It is safe for concurrent use.
+// String 在首次调用时构建消息，之后返回缓存的消息。可并发调用。
+func (l *lazyMessage) String() string {
+	l.once.Do(func() {
+		if l.fn != nil {
+			l.msg = l.fn()
+		}
+		l.fn = nil // release whatever the function captured (释放函数捕获的对象)
+	})
+	return l.msg
+}
+
+// NewLazy creates a new fundamental error whose message is built by fn only when it is first needed, e.g. by Error,
+// formatting or ToJSON, and then cached. Use it on hot paths where the error is usually discarded and building its
+// message is expensive. fn must be safe to call later from another goroutine; the stack trace is captured as with New.
+// NewLazy 创建一个新的 fundamental 错误，其消息仅在首次需要时（例如 Error、格式化或 ToJSON）由 fn 构建并缓存。
+// 适用于错误通常被丢弃、而构建消息代价较高的热点路径。fn 必须可以稍后在其他 goroutine 中调用；堆栈跟踪与 New 一样捕获。
+func NewLazy(fn func() string) error {
+	return notifyError(&fundamental{
+		lazy:  &lazyMessage{fn: fn},
+		stack: callers(skipFrames), // skip NewLazy itself and runtime.Callers
+	})
+}
+
+// WrapLazy annotates err with a message built by fn only when it is first needed, and a stack trace.
+// It is Wrapf for hot paths: the fmt.Sprintf of large values is deferred until the error is printed or logged.
+// If err is nil, WrapLazy returns nil without calling fn.
+// WrapLazy 使用仅在首次需要时由 fn 构建的消息和堆栈跟踪来注解错误 err。
+// 它是用于热点路径的 Wrapf：对大型值的 fmt.Sprintf 被推迟到错误被打印或记录时。
+// 如果 err 为 nil，WrapLazy 返回 nil 且不调用 fn。
+func WrapLazy(err error, fn func() string) error {
+	if err == nil {
+		return nil
+	}
+	return notifyWrap(&wrapper{
+		lazy:  &lazyMessage{fn: fn},
+		cause: err,
+		stack: callers(skipFrames), // skip WrapLazy itself and runtime.Callers
+	}, err)
+}
@@ -0,0 +1,89 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapLazy(t *testing.T) {
+	var calls atomic.Int32
+	build := func() string {
+		calls.Add(1)
+		return fmt.Sprintf("save order %d", 42)
+	}
+
+	assert.Nil(t, errors.WrapLazy(nil, build))
+	err := errors.WrapLazy(io.EOF, build)
+	require.Error(t, err)
+	assert.True(t, stdErrors.Is(err, io.EOF))
+	assert.Zero(t, calls.Load(), "the message is not built before it is needed")
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "save order 42: EOF", err.Error())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, "save order 42: EOF", fmt.Sprintf("%v", err))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "TestWrapLazy")
+	assert.Equal(t, "save order 42", errors.Document(err).Chain[0].Message)
+	assert.Equal(t, int32(1), calls.Load(), "the message is built once")
+
+	coded := errors.WithCode(errors.WrapLazy(io.EOF, build), errors.ErrInternalServer)
+	assert.True(t, errors.IsCode(coded, errors.ErrInternalServer))
+}
+
+func TestNewLazy(t *testing.T) {
+	var calls atomic.Int32
+	err := errors.NewLazy(func() string {
+		calls.Add(1)
+		return "quota exceeded"
+	})
+	assert.Zero(t, calls.Load())
+	assert.Equal(t, "quota exceeded", err.Error())
+	assert.Equal(t, "upload: quota exceeded", errors.Wrap(err, "upload").Error())
+	assert.Contains(t, fmt.Sprintf("%+v", err), "TestNewLazy")
+	assert.Equal(t, int32(1), calls.Load())
+
+	assert.Empty(t, errors.NewLazy(nil).Error())
+}
+
+// BenchmarkWrapLazy compares Wrapf with WrapLazy for errors that are discarded without being printed.
+// BenchmarkWrapLazy 比较未被打印即丢弃的错误使用 Wrapf 和 WrapLazy 的开销。
+func BenchmarkWrapLazy(b *testing.B) {
+	order := struct {
+		ID    int
+		Items []string
+		Notes map[string]string
+	}{42, []string{"book", "pen", "lamp"}, map[string]string{"gift": "yes", "express": "no"}}
+
+	b.Run("Wrapf", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = errors.Wrapf(io.EOF, "save order %+v", order)
+		}
+	})
+	b.Run("WrapLazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = errors.WrapLazy(io.EOF, func() string { return fmt.Sprintf("save order %+v", order) })
+		}
+	})
+}
@@ -0,0 +1,122 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// panicReporter holds the function Go reports recovered panics to.
+// panicReporter 保存 Go 报告已恢复 panic 时调用的函数。
+var panicReporter atomic.Pointer[func(err error)]
+
+// WrapPanic converts a value returned by recover() into an error coded ErrPanic. The stack trace starts at the
+// frame that panicked rather than at the recovery site. When p is an error it stays in the chain, so errors.Is
+// and errors.As still match it. WrapPanic returns nil when p is nil.
+// WrapPanic 将 recover() 返回的值转换为带 ErrPanic 错误码的错误。堆栈跟踪从发生 panic 的帧开始，而不是从恢复处开始。
+// p 是 error 时会保留在错误链中，因此 errors.Is 和 errors.As 仍能匹配。p 为 nil 时返回 nil。
+func WrapPanic(p any) error {
+	if p == nil {
+		return nil
+	}
+	cause, ok := p.(error)
+	if !ok {
+		cause = &fundamental{msg: fmt.Sprint(p)}
+	}
+	return notifyError(&withCode{cause: cause, coder: ErrPanic, stack: panicStack()})
+}
+
+// Recover converts a panic into an error stored in *errp. It must be deferred directly:
+//
+//	func process() (err error) {
+//		defer errors.Recover(&err)
+//		...
+//	}
+//
+// A panic replaces any error already in *errp. When errp is nil the panic is passed to the panic reporter, as in Go.
+// Recover 将 panic 转换为错误并存入 *errp，必须直接 defer 调用。panic 会替换 *errp 中已有的错误。
+// errp 为 nil 时，panic 与 Go 中一样交给 panic 报告函数。
+func Recover(errp *error) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	if errp == nil {
+		reportPanic(WrapPanic(p))
+		return
+	}
+	*errp = WrapPanic(p)
+}
+
+// HandlePanic recovers a panic and passes the recovered value to handler; pass it to WrapPanic to get an error.
+// It must be deferred directly, e.g. `defer errors.HandlePanic(func(p any) { ... })`. A nil handler only recovers.
+// HandlePanic 恢复 panic 并将恢复的值传给 handler；将该值传给 WrapPanic 即可得到错误。
+// 必须直接 defer 调用，例如 `defer errors.HandlePanic(func(p any) { ... })`。handler 为 nil 时只恢复 panic。
+func HandlePanic(handler func(p any)) {
+	p := recover()
+	if p == nil || handler == nil {
+		return
+	}
+	handler(p)
+}
+
+// Go runs fn in a new goroutine. A panic in fn does not crash the process: it is converted with WrapPanic and
+// passed to the function set with SetPanicReporter, which by default prints it with its stack to standard error.
+// Go 在新的 goroutine 中执行 fn。fn 中的 panic 不会使进程崩溃：它经 WrapPanic 转换后交给通过 SetPanicReporter
+// 设置的函数，默认连同堆栈打印到标准错误输出。
+func Go(fn func()) {
+	go func() {
+		defer HandlePanic(func(p any) { reportPanic(WrapPanic(p)) })
+		fn()
+	}()
+}
+
+// SetPanicReporter sets the function that receives panics recovered by Go, for example one that logs them.
+// A nil report restores the default, which prints the error with "%+v" to standard error. It is safe for concurrent use.
+// SetPanicReporter 设置接收 Go 恢复的 panic 的函数，例如将其写入日志的函数。
+// report 为 nil 时恢复默认行为，即以 "%+v" 将错误打印到标准错误输出。可并发调用。
+func SetPanicReporter(report func(err error)) {
+	if report == nil {
+		panicReporter.Store(nil)
+		return
+	}
+	panicReporter.Store(&report)
+}
+
+// reportPanic passes err to the current panic reporter.
+// reportPanic 将 err 交给当前的 panic 报告函数。
+func reportPanic(err error) {
+	if report := panicReporter.Load(); report != nil {
+		(*report)(err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%+v\n", err)
+}
+
+// panicStack captures the stack of a panicking goroutine, dropping the recovery frames and the runtime's panic
+// machinery so that the trace starts where the panic happened.
+// panicStack 捕获正在 panic 的 goroutine 的堆栈，去掉恢复相关的帧和运行时的 panic 处理帧，使堆栈从发生 panic 处开始。
+func panicStack() StackTrace {
+	st := callers(skipFrames + 1)
+	for i, f := range st {
+		if f.name() != "runtime.gopanic" {
+			continue
+		}
+		j := i + 1
+		for j < len(st) && strings.HasPrefix(st[j].name(), "runtime.") {
+			j++
+		}
+		if j < len(st) {
+			return st[j:]
+		}
+		break
+	}
+	return st
+}
@@ -0,0 +1,85 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func panickingTask() {
+	panic("boom")
+}
+
+func recoverTask() (err error) {
+	defer errors.Recover(&err)
+	panickingTask()
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	err := recoverTask()
+	require.Error(t, err)
+	assert.True(t, errors.IsCode(err, errors.ErrPanic))
+	assert.Equal(t, "Recovered from panic: boom", err.Error())
+
+	st := errors.StackTraceOf(err)
+	require.NotEmpty(t, st)
+	assert.True(t, strings.HasPrefix(fmt.Sprintf("%+v", st), "\ngithub.com/lmcc-dev/lmcc-go-sdk/pkg/errors_test.panickingTask"),
+		"the stack starts at the frame that panicked")
+
+	assert.NotPanics(t, func() {
+		defer errors.Recover(nil)
+		panic("ignored")
+	})
+}
+
+func TestWrapPanic(t *testing.T) {
+	assert.NoError(t, errors.WrapPanic(nil))
+
+	err := errors.WrapPanic(io.ErrUnexpectedEOF)
+	assert.True(t, stdErrors.Is(err, io.ErrUnexpectedEOF))
+	assert.True(t, errors.IsCode(err, errors.ErrPanic))
+	assert.Equal(t, "Recovered from panic: unexpected EOF", err.Error())
+}
+
+func TestHandlePanic(t *testing.T) {
+	var recovered any
+	func() {
+		defer errors.HandlePanic(func(p any) { recovered = p })
+		panic(42)
+	}()
+	assert.Equal(t, 42, recovered)
+
+	assert.NotPanics(t, func() {
+		defer errors.HandlePanic(nil)
+		panic("ignored")
+	})
+}
+
+func TestGo(t *testing.T) {
+	reported := make(chan error, 1)
+	errors.SetPanicReporter(func(err error) { reported <- err })
+	defer errors.SetPanicReporter(nil)
+
+	errors.Go(panickingTask)
+	select {
+	case err := <-reported:
+		assert.True(t, errors.IsCode(err, errors.ErrPanic))
+		assert.Contains(t, fmt.Sprintf("%+v", err), "panickingTask")
+	case <-time.After(time.Second):
+		t.Fatal("the panic was not reported")
+	}
+}
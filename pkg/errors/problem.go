@@ -0,0 +1,155 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+// ProblemContentType 是 RFC 7807 问题详情的媒体类型。
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details object. Extensions are written as top-level members next to the
+// standard ones; extensions named like a standard member are ignored.
+// ProblemDetails 是 RFC 7807 问题详情对象。Extensions 与标准成员并列写为顶层成员，与标准成员同名的扩展会被忽略。
+type ProblemDetails struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// ProblemOptions configures ToProblemDetails.
+// ProblemOptions 配置 ToProblemDetails。
+type ProblemOptions struct {
+	// Instance is a URI identifying this occurrence, usually the request path.
+	// Instance 是标识本次问题的 URI，通常是请求路径。
+	Instance string
+	// Detail explains this occurrence to the client. When empty, err.Error() is used only if ExposeError is set,
+	// since the full message may contain internal details.
+	// Detail 向客户端说明本次问题。为空时仅在设置了 ExposeError 时使用 err.Error()，因为完整消息可能包含内部细节。
+	Detail string
+	// ExposeError uses err.Error() as the detail when Detail is empty.
+	// ExposeError 在 Detail 为空时使用 err.Error() 作为 detail。
+	ExposeError bool
+	// Locale translates the title with Localize when set.
+	// Locale 设置时使用 Localize 翻译 title。
+	Locale string
+}
+
+// ToProblemDetails builds the RFC 7807 problem details of err. The Coder is resolved like NewHTTPErrorBody: type is
+// its Reference (omitted, meaning "about:blank", when empty), title its description and status its HTTP status. The
+// error code is added as the "code" extension, and details attached with WithDetails as further extensions.
+// A nil err is described as the unknown error.
+// ToProblemDetails 构建 err 的 RFC 7807 问题详情。Coder 的解析方式与 NewHTTPErrorBody 相同：type 为其 Reference
+// （为空时省略，即 "about:blank"），title 为其描述，status 为其 HTTP 状态码。错误码作为 "code" 扩展写入，
+// 通过 WithDetails 附加的详情作为其他扩展写入。nil 按未知错误描述。
+func ToProblemDetails(err error, opts ProblemOptions) *ProblemDetails {
+	coder := GetCoder(err)
+	if coder == nil {
+		coder = unknownCoder
+	} else if registered, ok := LookupCoder(coder.Code()); ok {
+		coder = registered
+	}
+	status := HTTPStatus(err)
+	if err == nil {
+		status = unknownCoder.HTTPStatus()
+	}
+
+	problem := &ProblemDetails{
+		Type:     coder.Reference(),
+		Title:    coder.String(),
+		Status:   status,
+		Detail:   opts.Detail,
+		Instance: opts.Instance,
+	}
+	if opts.Locale != "" && err != nil {
+		problem.Title = Localize(err, opts.Locale)
+	}
+	if problem.Detail == "" && opts.ExposeError && err != nil {
+		problem.Detail = err.Error()
+	}
+
+	details := Details(err)
+	problem.Extensions = make(map[string]any, len(details)+1)
+	for k, v := range details {
+		problem.Extensions[k] = v
+	}
+	problem.Extensions["code"] = coder.Code()
+	return problem
+}
+
+// MarshalJSON writes the standard members and the extensions as one object, omitting empty type, detail and instance.
+// MarshalJSON 将标准成员和扩展写为同一个对象，省略空的 type、detail 和 instance。
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	for _, name := range []string{"type", "title", "status", "detail", "instance"} {
+		delete(members, name)
+	}
+	if p.Type != "" {
+		members["type"] = p.Type
+	}
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	return json.Marshal(members)
+}
+
+// WriteProblem writes err as an application/problem+json response with the status of its problem details.
+// The instance defaults to the request path, and the request ID from the RequestIDHeader response header is added
+// as the "request_id" extension.
+// WriteProblem 将 err 写为 application/problem+json 响应，状态码取自其问题详情。instance 默认为请求路径，
+// 取自 RequestIDHeader 响应头的请求 ID 作为 "request_id" 扩展写入。
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error, opts ProblemOptions) {
+	if opts.Instance == "" && r != nil && r.URL != nil {
+		opts.Instance = r.URL.Path
+	}
+	problem := ToProblemDetails(err, opts)
+	if requestID := w.Header().Get(RequestIDHeader); requestID != "" {
+		problem.Extensions["request_id"] = requestID
+	}
+	body, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		// 详情无法编码时只写出标准成员 (Write only the standard members when the details cannot be encoded)
+		problem.Extensions = nil
+		body, _ = json.Marshal(problem)
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write(body)
+}
+
+// ProblemHandler adapts a handler returning an error to http.Handler, writing a returned error with WriteProblem
+// and Options. A handler that already wrote a response should return nil.
+// ProblemHandler 将返回错误的处理函数适配为 http.Handler，返回的错误通过 WriteProblem 按 Options 写出。
+// 已经写出响应的处理函数应返回 nil。
+type ProblemHandler struct {
+	Handle  func(w http.ResponseWriter, r *http.Request) error
+	Options ProblemOptions
+}
+
+// ServeHTTP implements http.Handler.
+// ServeHTTP 实现 http.Handler。
+func (h ProblemHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.Handle(w, r); err != nil {
+		WriteProblem(w, r, err, h.Options)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToProblemDetails(t *testing.T) {
+	errQuotaExceeded := lmccerrors.NewCoder(990301, http.StatusTooManyRequests, "Quota exceeded", "https://example.com/problems/quota")
+	require.NoError(t, lmccerrors.RegisterCoder(errQuotaExceeded))
+	lmccerrors.RegisterTranslations("de", map[int]string{990301: "Kontingent überschritten"})
+
+	err := lmccerrors.WithDetails(
+		fmt.Errorf("charge account: %w", lmccerrors.NewWithCode(errQuotaExceeded, "tenant 7 used 1000 of 1000 calls")),
+		map[string]any{"limit": 1000, "title": "ignored"},
+	)
+	problem := lmccerrors.ToProblemDetails(err, lmccerrors.ProblemOptions{Instance: "/accounts/7/charges"})
+	data, marshalErr := json.Marshal(problem)
+	require.NoError(t, marshalErr)
+	assert.JSONEq(t, `{
+		"type": "https://example.com/problems/quota",
+		"title": "Quota exceeded",
+		"status": 429,
+		"instance": "/accounts/7/charges",
+		"code": 990301,
+		"limit": 1000
+	}`, string(data), "the detail is not exposed by default and extensions cannot replace standard members")
+
+	problem = lmccerrors.ToProblemDetails(err, lmccerrors.ProblemOptions{ExposeError: true, Locale: "de-DE"})
+	assert.Equal(t, "Kontingent überschritten", problem.Title)
+	assert.Equal(t, err.Error(), problem.Detail)
+	problem = lmccerrors.ToProblemDetails(err, lmccerrors.ProblemOptions{Detail: "Try again tomorrow.", ExposeError: true})
+	assert.Equal(t, "Try again tomorrow.", problem.Detail)
+
+	// 没有错误码或 Reference 的错误：type 省略，即 "about:blank"
+	// (Errors without a code or Reference omit type, meaning "about:blank")
+	data, marshalErr = json.Marshal(lmccerrors.ToProblemDetails(errors.New("boom"), lmccerrors.ProblemOptions{}))
+	require.NoError(t, marshalErr)
+	assert.JSONEq(t, `{"title":"An internal server error occurred","status":500,"code":-1}`, string(data))
+}
+
+func TestProblemHandler(t *testing.T) {
+	handler := lmccerrors.ProblemHandler{Handle: func(w http.ResponseWriter, r *http.Request) error {
+		w.Header().Set(lmccerrors.RequestIDHeader, "req-1")
+		if r.URL.Query().Get("id") == "" {
+			return lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "order lookup")
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders?x=1", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, lmccerrors.ProblemContentType, rec.Header().Get("Content-Type"))
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "/orders", body["instance"])
+	assert.Equal(t, "req-1", body["request_id"])
+	assert.Equal(t, float64(http.StatusNotFound), body["status"])
+	assert.Equal(t, float64(lmccerrors.ErrNotFound.Code()), body["code"])
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders?id=7", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
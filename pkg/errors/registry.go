@@ -0,0 +1,195 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"net/http"
+	"sync"
+)
+
+// registration is a registered Coder with its optional name.
+// registration 是已注册的 Coder 及其可选名称。
+type registration struct {
+	coder Coder
+	name  string
+}
+
+var (
+	// registry maps integer codes to their registration.
+	// registry 将整数错误码映射到其注册信息。
+	registry   = make(map[int]registration)
+	registryMu sync.RWMutex
+)
+
+func init() {
+	for _, predefined := range []struct {
+		name  string
+		coder Coder
+	}{
+		{"ErrInternalServer", ErrInternalServer},
+		{"ErrNotFound", ErrNotFound},
+		{"ErrBadRequest", ErrBadRequest},
+		{"ErrUnauthorized", ErrUnauthorized},
+		{"ErrForbidden", ErrForbidden},
+		{"ErrValidation", ErrValidation},
+		{"ErrTimeout", ErrTimeout},
+		{"ErrTooManyRequests", ErrTooManyRequests},
+		{"ErrOperationFailed", ErrOperationFailed},
+		{"ErrPanic", ErrPanic},
+		{"ErrAlreadyExists", ErrAlreadyExists},
+		{"ErrUnavailable", ErrUnavailable},
+		{"ErrConfigFileRead", ErrConfigFileRead},
+		{"ErrConfigSetup", ErrConfigSetup},
+		{"ErrConfigEnvBind", ErrConfigEnvBind},
+		{"ErrConfigDefaultTagParse", ErrConfigDefaultTagParse},
+		{"ErrConfigInternal", ErrConfigInternal},
+		{"ErrConfigHotReload", ErrConfigHotReload},
+		{"ErrConfigRemote", ErrConfigRemote},
+		{"ErrConfigValidation", ErrConfigValidation},
+		{"ErrConfigSecret", ErrConfigSecret},
+		{"ErrLogInternal", ErrLogInternal},
+		{"ErrLogOptionInvalid", ErrLogOptionInvalid},
+		{"ErrLogReconfigure", ErrLogReconfigure},
+		{"ErrLogInitialization", ErrLogInitialization},
+		{"ErrLogRotationSetup", ErrLogRotationSetup},
+		{"ErrLogRotationDirCreate", ErrLogRotationDirCreate},
+		{"ErrLogRotationDirStat", ErrLogRotationDirStat},
+		{"ErrLogRotationDirInvalid", ErrLogRotationDirInvalid},
+		{"ErrLogAuditTampered", ErrLogAuditTampered},
+		{"ErrDebugOptionInvalid", ErrDebugOptionInvalid},
+		{"ErrDebugServerStart", ErrDebugServerStart},
+		{"ErrSecretNotFound", ErrSecretNotFound},
+		{"ErrSecretProvider", ErrSecretProvider},
+		{"ErrSecretInvalidKey", ErrSecretInvalidKey},
+		{"ErrProfileOptionInvalid", ErrProfileOptionInvalid},
+		{"ErrProfileCapture", ErrProfileCapture},
+		{"ErrAuthOptionInvalid", ErrAuthOptionInvalid},
+		{"ErrAuthTokenInvalid", ErrAuthTokenInvalid},
+		{"ErrAuthKeyFetch", ErrAuthKeyFetch},
+		{"ErrPaginationInvalid", ErrPaginationInvalid},
+		{"ErrPaginationCursorInvalid", ErrPaginationCursorInvalid},
+		{"ErrTraceOptionInvalid", ErrTraceOptionInvalid},
+		{"ErrTraceExporter", ErrTraceExporter},
+		{"ErrMetricsOptionInvalid", ErrMetricsOptionInvalid},
+		{"ErrMetricsRegister", ErrMetricsRegister},
+		{"ErrMetricsPush", ErrMetricsPush},
+		{"ErrQueueOptionInvalid", ErrQueueOptionInvalid},
+		{"ErrQueueSource", ErrQueueSource},
+		{"ErrLockOptionInvalid", ErrLockOptionInvalid},
+		{"ErrLockHeld", ErrLockHeld},
+		{"ErrLockTimeout", ErrLockTimeout},
+		{"ErrLockNotHeld", ErrLockNotHeld},
+		{"ErrLockBackend", ErrLockBackend},
+		{"ErrTenantOptionInvalid", ErrTenantOptionInvalid},
+		{"ErrTenantMissing", ErrTenantMissing},
+		{"ErrTenantInvalid", ErrTenantInvalid},
+		{"ErrTenantConfig", ErrTenantConfig},
+		{"ErrCLIFormatUnsupported", ErrCLIFormatUnsupported},
+		{"ErrCLIRecordInvalid", ErrCLIRecordInvalid},
+		{"ErrCLIUsage", ErrCLIUsage},
+		{"ErrTasksClosed", ErrTasksClosed},
+		{"ErrTasksShutdown", ErrTasksShutdown},
+		{"ErrSDKOptionInvalid", ErrSDKOptionInvalid},
+		{"ErrSDKBootstrap", ErrSDKBootstrap},
+		{"ErrRetryOptionInvalid", ErrRetryOptionInvalid},
+	} {
+		if err := RegisterNamedCoder(predefined.name, predefined.coder); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterCoder adds coder to the registry. Registering a code that is already taken by a different Coder
+// returns an error and keeps the earlier registration; registering the same Coder again is a no-op.
+// Registered Coders are found by LookupCoder, listed by Catalog and drive HTTPStatus for every error carrying their code.
+// Nil Coders are ignored.
+// RegisterCoder 将 coder 加入注册表。注册已被其他 Coder 占用的错误码会返回错误并保留之前的注册；重复注册同一 Coder 不做任何操作。
+// 已注册的 Coder 可通过 LookupCoder 查找、由 Catalog 列出，并决定所有携带其错误码的错误的 HTTPStatus。忽略 nil。
+func RegisterCoder(coder Coder) error {
+	return RegisterNamedCoder("", coder)
+}
+
+// RegisterNamedCoder is RegisterCoder with a name, such as the Go variable name, shown in the Catalog.
+// RegisterNamedCoder 与 RegisterCoder 相同，并附带一个在 Catalog 中显示的名称，例如 Go 变量名。
+func RegisterNamedCoder(name string, coder Coder) error {
+	if coder == nil {
+		return nil
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if existing, ok := registry[coder.Code()]; ok {
+		if !sameCoder(existing.coder, coder) {
+			return Errorf("error code %d (%q) is already registered for %q", coder.Code(), coder.String(), existing.describe())
+		}
+		if existing.name != "" {
+			return nil
+		}
+	}
+	registry[coder.Code()] = registration{coder: coder, name: name}
+	return nil
+}
+
+// MustRegisterCoder registers every coder and panics on the first duplicate code. It is meant for package init
+// functions, so code collisions stop a service at startup rather than surfacing in production responses.
+// MustRegisterCoder 注册所有 coder，遇到第一个重复的错误码时 panic。它用于包的 init 函数，使错误码冲突在服务启动时暴露，而不是出现在生产响应中。
+func MustRegisterCoder(coders ...Coder) {
+	for _, coder := range coders {
+		if err := RegisterCoder(coder); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// sameCoder reports whether a and b are the same Coder or describe the same code identically.
+// sameCoder 报告 a 和 b 是否为同一 Coder，或以完全相同的内容描述同一错误码。
+func sameCoder(a, b Coder) bool {
+	if a == b {
+		return true
+	}
+	return a.Code() == b.Code() && a.HTTPStatus() == b.HTTPStatus() &&
+		a.String() == b.String() && a.Reference() == b.Reference()
+}
+
+// describe returns the name of the registration, or the Coder's description when it has no name.
+// describe 返回注册的名称，没有名称时返回 Coder 的描述。
+func (r registration) describe() string {
+	if r.name != "" {
+		return r.name
+	}
+	return r.coder.String()
+}
+
+// LookupCoder returns the Coder registered for code.
+// LookupCoder 返回为 code 注册的 Coder。
+func LookupCoder(code int) (Coder, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	r, ok := registry[code]
+	return r.coder, ok
+}
+
+// HTTPStatus returns the HTTP status for err: 200 for nil, otherwise the status of the Coder
+// registered for the code found in err's chain, falling back to that Coder's own status.
+// Errors without a Coder, or with a Coder that has no status, map to 500.
+// HTTPStatus 返回 err 对应的 HTTP 状态码：nil 返回 200，否则使用错误链中错误码对应的已注册 Coder 的状态码，
+// 未注册时使用该 Coder 自身的状态码。没有 Coder 或 Coder 没有状态码的错误映射为 500。
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	coder := GetCoder(err)
+	if coder == nil {
+		return http.StatusInternalServerError
+	}
+	if registered, ok := LookupCoder(coder.Code()); ok && registered.HTTPStatus() != 0 {
+		return registered.HTTPStatus()
+	}
+	if status := coder.HTTPStatus(); status != 0 {
+		return status
+	}
+	return http.StatusInternalServerError
+}
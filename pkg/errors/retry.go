@@ -0,0 +1,51 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc/status"
+)
+
+// Retryable reports whether err is worth retrying based on its classification: server errors (5xx except 501),
+// timeouts (408, 504 and network timeouts) and 429 are retryable, other client errors (4xx) are not.
+// Errors without a Coder are classified by the gRPC status in their chain if any, and otherwise count as server errors,
+// except context.Canceled, which is never retried.
+// Retryable 根据错误分类判断 err 是否值得重试：服务端错误（除 501 外的 5xx）、超时（408、504 和网络超时）和 429 可以重试，
+// 其他客户端错误（4xx）不可重试。没有 Coder 的错误按其错误链中的 gRPC 状态分类，没有时视为服务端错误，但 context.Canceled 永不重试。
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	httpStatus := HTTPStatus(err)
+	if GetCoder(err) == nil {
+		if errors.Is(err, context.Canceled) {
+			return false
+		}
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return true
+		}
+		// gRPC 错误按其状态码分类 (gRPC errors are classified by their status code)
+		if st, ok := status.FromError(err); ok {
+			httpStatus = httpStatusFromGRPCCode(st.Code())
+		}
+	}
+
+	switch {
+	case httpStatus == http.StatusRequestTimeout, httpStatus == http.StatusTooManyRequests:
+		return true
+	case httpStatus == http.StatusNotImplemented:
+		return false
+	default:
+		return httpStatus >= 500
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package errors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), true},
+		{"internal", lmccerrors.NewWithCode(lmccerrors.ErrInternalServer, "db down"), true},
+		{"timeout coder", lmccerrors.NewWithCode(lmccerrors.ErrTimeout, "upstream"), true},
+		{"too many requests", lmccerrors.NewWithCode(lmccerrors.ErrTooManyRequests, "slow down"), true},
+		{"not found", lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42"), false},
+		{"validation", fmt.Errorf("handler: %w", lmccerrors.WithCode(errors.New("bad"), lmccerrors.ErrValidation)), false},
+		{"context canceled", fmt.Errorf("call: %w", context.Canceled), false},
+		{"deadline exceeded", fmt.Errorf("call: %w", context.DeadlineExceeded), true},
+		{"network timeout", &net.OpError{Op: "dial", Err: timeoutError{}}, true},
+		{"grpc unavailable", status.Error(codes.Unavailable, "down"), true},
+		{"grpc invalid argument", status.Error(codes.InvalidArgument, "bad"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lmccerrors.Retryable(tt.err))
+		})
+	}
+}
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
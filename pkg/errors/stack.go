@@ -7,11 +7,14 @@
 package errors
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // Frame represents a program counter inside a stack trace.
@@ -63,17 +66,123 @@ func (f Frame) name() string {
 // StackTrace 是一个从最内层 (最新) 到最外层 (最旧) 的 Frame 堆栈。
 type StackTrace []Frame
 
+// DefaultStackCaptureDepth is the maximum number of frames captured per error unless changed with SetStackCaptureDepth.
+// DefaultStackCaptureDepth 是每个错误默认捕获的最大帧数，可通过 SetStackCaptureDepth 修改。
+const DefaultStackCaptureDepth = 32
+
+// stackCaptureDepth holds the current maximum number of captured frames; 0 disables capture.
+// stackCaptureDepth 保存当前捕获的最大帧数；0 表示禁用捕获。
+var stackCaptureDepth atomic.Int32
+
+func init() {
+	stackCaptureDepth.Store(DefaultStackCaptureDepth)
+}
+
+// SetStackCaptureDepth sets the maximum number of frames captured when an error is created, wrapped or given a code,
+// and returns the previous value. A smaller depth makes capture cheaper; 0 or a negative depth disables capture, so
+// the errors carry no stack trace and %+v prints the messages only. It is safe for concurrent use and affects errors
+// created afterwards.
+// SetStackCaptureDepth 设置创建、包装错误或附加错误码时捕获的最大帧数，并返回之前的值。深度越小捕获开销越低；
+// 0 或负数禁用捕获，此后的错误不携带堆栈，%+v 只输出消息。可并发调用，只影响之后创建的错误。
+func SetStackCaptureDepth(depth int) (previous int) {
+	if depth < 0 {
+		depth = 0
+	}
+	return int(stackCaptureDepth.Swap(int32(depth)))
+}
+
+// StackCaptureDepth returns the current maximum number of captured frames; 0 means capture is disabled.
+// StackCaptureDepth 返回当前捕获的最大帧数；0 表示已禁用捕获。
+func StackCaptureDepth() int {
+	return int(stackCaptureDepth.Load())
+}
+
+// DisableStackCapture turns stack capture off for every error created afterwards and returns a function restoring the
+// previous depth, e.g. for hot paths in benchmarks or high-throughput services that never print %+v.
+// DisableStackCapture 为之后创建的所有错误关闭堆栈捕获，并返回恢复之前深度的函数，
+// 例如用于基准测试中的热点路径，或从不输出 %+v 的高吞吐服务。
+func DisableStackCapture() (restore func()) {
+	previous := SetStackCaptureDepth(0)
+	return func() { SetStackCaptureDepth(previous) }
+}
+
+// StackFormat selects how %+v renders stack traces.
+// StackFormat 选择 %+v 输出堆栈跟踪的方式。
+type StackFormat int32
+
 const (
-	maxStackDepth = 32
-	// skipFrames    = 3 // Default skip for callers, New, Errorf etc. // 已在 errors.go 中定义 (Defined in errors.go)
+	// StackFormatMultiline renders one "function\n\tfile:line" block per frame; the default.
+	// StackFormatMultiline 为每帧输出一个 "函数\n\t文件:行号" 块；默认值。
+	StackFormatMultiline StackFormat = iota
+	// StackFormatSingleLine renders the frames on the same line as the message, as " stack=" followed by a JSON array
+	// of {"function","file","line"} objects, so each error stays one line in line-oriented log pipelines.
+	// StackFormatSingleLine 将帧与消息输出在同一行，形式为 " stack=" 加上由 {"function","file","line"} 对象组成的 JSON 数组，
+	// 使每个错误在按行处理的日志管道中保持为一行。
+	StackFormatSingleLine
 )
 
+// stackFormat holds the current StackFormat.
+// stackFormat 保存当前的 StackFormat。
+var stackFormat atomic.Int32
+
+// SetStackFormat sets how %+v renders stack traces for every error, including ErrorGroup, and returns the previous
+// format. It is safe for concurrent use.
+// SetStackFormat 设置 %+v 为所有错误（包括 ErrorGroup）输出堆栈跟踪的方式，并返回之前的格式。可并发调用。
+func SetStackFormat(format StackFormat) (previous StackFormat) {
+	return StackFormat(stackFormat.Swap(int32(format)))
+}
+
+// CurrentStackFormat returns the format %+v currently uses for stack traces.
+// CurrentStackFormat 返回 %+v 当前输出堆栈跟踪所用的格式。
+func CurrentStackFormat() StackFormat {
+	return StackFormat(stackFormat.Load())
+}
+
+// StackFrame is the resolved form of a Frame.
+// StackFrame 是 Frame 解析后的形式。
+type StackFrame struct {
+	// Function is the fully qualified function name, e.g. "main.handler".
+	// Function 是完整的函数名，例如 "main.handler"。
+	Function string `json:"function"`
+	// File is the full path of the source file.
+	// File 是源文件的完整路径。
+	File string `json:"file"`
+	// Line is the line number in File.
+	// Line 是 File 中的行号。
+	Line int `json:"line"`
+}
+
+// Frames resolves every frame of st, from innermost to outermost.
+// Frames 解析 st 中的每一帧，从最内层到最外层。
+func (st StackTrace) Frames() []StackFrame {
+	if len(st) == 0 {
+		return nil
+	}
+	frames := make([]StackFrame, len(st))
+	for i, f := range st {
+		frames[i] = StackFrame{Function: f.name(), File: f.file(), Line: f.line()}
+	}
+	return frames
+}
+
+// FormatStack returns the resolved frames of the stack StackTraceOf returns for err, for callers that process stacks
+// programmatically instead of parsing %+v output. It returns nil when no error in the chain carries a stack.
+// FormatStack 返回 StackTraceOf 为 err 给出的堆栈中解析后的各帧，供以程序方式处理堆栈而不是解析 %+v 输出的调用方使用。
+// 链中没有错误携带堆栈时返回 nil。
+func FormatStack(err error) []StackFrame {
+	return StackTraceOf(err).Frames()
+}
+
 // callers retrieves the current call stack.
 // callers 检索当前的调用堆栈。
 // It skips a number of frames specified by the 'skip' argument.
 // 它会跳过 'skip' 参数指定的帧数。
 func callers(skip int) StackTrace {
-	pc := make([]uintptr, maxStackDepth)
+	depth := int(stackCaptureDepth.Load())
+	if depth == 0 {
+		return nil
+	}
+	pc := make([]uintptr, depth)
 	n := runtime.Callers(skip, pc)
 	if n == 0 {
 		return nil
@@ -116,10 +225,21 @@ func callers(skip int) StackTrace {
 //
 //	  <函数名>
 //		<文件>:<行号>)
+//
+// With StackFormatSingleLine the frames are written as ` stack=[{"function":...,"file":...,"line":...},...]` instead.
+// (使用 StackFormatSingleLine 时，各帧改为输出为 ` stack=[{"function":...,"file":...,"line":...},...]`。)
 func (st StackTrace) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
+			if CurrentStackFormat() == StackFormatSingleLine {
+				if len(st) > 0 {
+					data, _ := json.Marshal(st.Frames())
+					_, _ = io.WriteString(s, " stack=")
+					_, _ = s.Write(data)
+				}
+				return
+			}
 			for _, f := range st {
 				// Note: Using io.WriteString for potentially better performance
 				// and to avoid issues if frame components contain formatting verbs.
@@ -134,3 +254,35 @@ func (st StackTrace) Format(s fmt.State, verb rune) {
 		}
 	}
 }
+
+// StackTrace returns the stack captured when the error was created.
+// StackTrace 返回创建错误时捕获的堆栈。
+func (f *fundamental) StackTrace() StackTrace { return f.stack }
+
+// StackTrace returns the stack captured when the error was wrapped.
+// StackTrace 返回包装错误时捕获的堆栈。
+func (w *wrapper) StackTrace() StackTrace { return w.stack }
+
+// StackTrace returns the stack captured when the Coder was attached.
+// StackTrace 返回附加 Coder 时捕获的堆栈。
+func (wc *withCode) StackTrace() StackTrace { return wc.stack }
+
+// StackTraceOf returns the innermost stack in the Unwrap chain of err, i.e. the one captured closest to where the
+// error originated, or nil when no error in the chain carries a stack.
+// StackTraceOf 返回 err 的 Unwrap 链中最内层的堆栈，即最接近错误产生位置时捕获的堆栈；链中没有错误携带堆栈时返回 nil。
+func StackTraceOf(err error) StackTrace {
+	type stackTracer interface {
+		StackTrace() StackTrace
+	}
+
+	var st StackTrace
+	for err != nil {
+		if tracer, ok := err.(stackTracer); ok {
+			if s := tracer.StackTrace(); len(s) > 0 {
+				st = s
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	return st
+}
@@ -7,6 +7,9 @@
 package errors
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -132,3 +135,160 @@ func TestFrameMethods(t *testing.T) {
 
 // TestStackTraceFormat and its helpers (aTestFunctionForStackTrace, anotherTestFunction)
 // have been migrated to format_test.go as TestStackTrace_Format.
+
+// TestStackCaptureDepth tests limiting and disabling stack capture, and NewNoStack.
+// TestStackCaptureDepth 测试限制和禁用堆栈捕获，以及 NewNoStack。
+func TestStackCaptureDepth(t *testing.T) {
+	if got := StackCaptureDepth(); got != DefaultStackCaptureDepth {
+		t.Fatalf("StackCaptureDepth() = %d, want %d", got, DefaultStackCaptureDepth)
+	}
+
+	previous := SetStackCaptureDepth(2)
+	t.Cleanup(func() { SetStackCaptureDepth(previous) })
+	if previous != DefaultStackCaptureDepth {
+		t.Errorf("SetStackCaptureDepth() returned %d, want %d", previous, DefaultStackCaptureDepth)
+	}
+	if st := New("limited").(*fundamental).stack; len(st) != 2 {
+		t.Errorf("len(stack) = %d with depth 2, want 2", len(st))
+	}
+
+	restore := DisableStackCapture()
+	if got := StackCaptureDepth(); got != 0 {
+		t.Errorf("StackCaptureDepth() = %d after DisableStackCapture, want 0", got)
+	}
+	err := Wrap(NewWithCode(ErrNotFound, "user missing"), "lookup failed")
+	if got := fmt.Sprintf("%+v", err); strings.Contains(got, "stack_test.go") {
+		t.Errorf("%%+v printed a stack trace while capture was disabled: %q", got)
+	}
+	restore()
+	if got := StackCaptureDepth(); got != 2 {
+		t.Errorf("StackCaptureDepth() = %d after restore, want 2", got)
+	}
+
+	SetStackCaptureDepth(-1)
+	if got := StackCaptureDepth(); got != 0 {
+		t.Errorf("StackCaptureDepth() = %d after a negative depth, want 0", got)
+	}
+	SetStackCaptureDepth(DefaultStackCaptureDepth)
+
+	noStack := NewNoStack("not found")
+	if noStack.Error() != "not found" || noStack.(*fundamental).stack != nil {
+		t.Errorf("NewNoStack() = %+v, want message only", noStack)
+	}
+	if !errors.Is(Wrap(noStack, "cache"), NewNoStack("not found")) {
+		t.Error("NewNoStack errors with the same message should match with Is")
+	}
+}
+
+// BenchmarkNew compares error creation with full, shallow and disabled stack capture.
+// BenchmarkNew 比较完整、浅层和禁用堆栈捕获时创建错误的开销。
+func BenchmarkNew(b *testing.B) {
+	for _, depth := range []int{DefaultStackCaptureDepth, 4, 0} {
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			previous := SetStackCaptureDepth(depth)
+			defer SetStackCaptureDepth(previous)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = New("benchmark error")
+			}
+		})
+	}
+	b.Run("NewNoStack", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = NewNoStack("benchmark error")
+		}
+	})
+}
+
+func TestStackTraceOf(t *testing.T) {
+	if st := StackTraceOf(errors.New("plain")); st != nil {
+		t.Errorf("StackTraceOf(plain error) = %v, want nil", st)
+	}
+	if st := StackTraceOf(nil); st != nil {
+		t.Errorf("StackTraceOf(nil) = %v, want nil", st)
+	}
+
+	origin := New("origin")
+	wrapped := fmt.Errorf("context: %w", Wrap(WithCode(origin, ErrNotFound), "outer"))
+	st := StackTraceOf(wrapped)
+	want := origin.(*fundamental).stack
+	if len(st) == 0 || &st[0] != &want[0] {
+		t.Errorf("StackTraceOf(wrapped) did not return the innermost stack")
+	}
+
+	coded := NewWithCode(ErrNotFound, "no stack on the fundamental cause")
+	if st := StackTraceOf(coded); len(st) == 0 || !strings.Contains(fmt.Sprintf("%+v", st), "TestStackTraceOf") {
+		t.Errorf("StackTraceOf(coded) = %+v, want the stack captured by NewWithCode", st)
+	}
+}
+
+func TestFormatStack(t *testing.T) {
+	if frames := FormatStack(errors.New("plain")); frames != nil {
+		t.Errorf("FormatStack(plain error) = %v, want nil", frames)
+	}
+
+	err := Wrap(New("origin"), "outer")
+	frames := FormatStack(err)
+	if len(frames) == 0 {
+		t.Fatal("FormatStack() returned no frames")
+	}
+	if f, ok := findFrame(frames, "TestFormatStack"); !ok || !strings.HasSuffix(f.File, "stack_test.go") || f.Line == 0 {
+		t.Errorf("FormatStack() = %+v, want a frame of TestFormatStack in stack_test.go", frames)
+	}
+}
+
+// findFrame returns the first frame whose function name ends with name.
+// findFrame 返回函数名以 name 结尾的第一帧。
+func findFrame(frames []StackFrame, name string) (StackFrame, bool) {
+	for _, f := range frames {
+		if strings.HasSuffix(f.Function, name) {
+			return f, true
+		}
+	}
+	return StackFrame{}, false
+}
+
+func TestSetStackFormat(t *testing.T) {
+	err := Wrap(NewWithCode(ErrNotFound, "user 42"), "load profile")
+	multiline := fmt.Sprintf("%+v", err)
+	if !strings.Contains(multiline, "\n\t") {
+		t.Fatalf("%%+v with the default format = %q, want a multi-line stack", multiline)
+	}
+
+	previous := SetStackFormat(StackFormatSingleLine)
+	defer SetStackFormat(previous)
+	if previous != StackFormatMultiline || CurrentStackFormat() != StackFormatSingleLine {
+		t.Errorf("SetStackFormat() previous = %v, current = %v", previous, CurrentStackFormat())
+	}
+
+	single := fmt.Sprintf("%+v", err)
+	if strings.Contains(single, "\n") {
+		t.Errorf("%%+v with StackFormatSingleLine = %q, want a single line", single)
+	}
+	msg, stack, ok := strings.Cut(single, " stack=")
+	if !ok || msg != err.Error() {
+		t.Fatalf("%%+v with StackFormatSingleLine = %q, want %q followed by \" stack=\"", single, err.Error())
+	}
+	var frames []StackFrame
+	if err := json.Unmarshal([]byte(stack), &frames); err != nil {
+		t.Fatalf("stack %q is not a JSON array of frames: %v", stack, err)
+	}
+	if _, ok := findFrame(frames, "TestSetStackFormat"); !ok {
+		t.Errorf("frames = %+v, want a frame of TestSetStackFormat", frames)
+	}
+
+	if got := fmt.Sprintf("%+v", New("no stack")); !strings.HasPrefix(got, "no stack stack=[") {
+		t.Errorf("%%+v of New() = %q", got)
+	}
+	if got := fmt.Sprintf("%v", err); got != err.Error() {
+		t.Errorf("%%v with StackFormatSingleLine = %q, want %q", got, err.Error())
+	}
+
+	group := NewErrorGroup("validation failed")
+	group.Add(New("first"))
+	group.Add(New("second"))
+	if got := fmt.Sprintf("%+v", group); strings.Contains(got, "\n") || !strings.Contains(got, "validation failed; Error 1 of 2: first stack=[") {
+		t.Errorf("%%+v of ErrorGroup with StackFormatSingleLine = %q, want a single line", got)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package grpcx provides gRPC server and client interceptors built on pkg/log, pkg/errors, pkg/metrics and pkg/trace.
+(grpcx 包提供基于 pkg/log、pkg/errors、pkg/metrics 和 pkg/trace 的 gRPC 服务端和客户端拦截器。)
+
+Every interceptor comes in a unary and a streaming form:
+(每个拦截器都有一元和流式两种形式：)
+
+  - RequestID reuses valid x-request-id metadata or generates an ID, echoes it in the response header
+    and stores it, together with the trace ID of the incoming traceparent, in the call context. On the
+    client it copies both into the outgoing metadata so downstream services continue the same request.
+    (RequestID 复用合法的 x-request-id 元数据或生成新的 ID，在响应头中回写，并与入站 traceparent 中的 trace ID 一起写入调用 context。
+    在客户端，它将两者写入出站元数据，使下游服务延续同一请求。)
+  - Logging stores a call-scoped Logger in the context (see log.FromContext) and logs every call with its
+    method, status code and duration: Info for OK, Warn for client errors and Error for server errors.
+    (Logging 将调用级 Logger 写入 context（见 log.FromContext），并记录每次调用的方法、状态码和耗时：OK 为 Info，客户端错误为 Warn，服务端错误为 Error。)
+  - Errors converts handler errors into gRPC statuses with errors.ToGRPCStatus on the server, and restores
+    them with errors.FromGRPCStatus on the client, so errors.IsCode works across the wire.
+    (Errors 在服务端通过 errors.ToGRPCStatus 将处理器的错误转换为 gRPC 状态，在客户端通过 errors.FromGRPCStatus 还原，使 errors.IsCode 跨进程可用。)
+  - Recovery turns a panic in a handler into a logged ErrPanic error, answered with an Internal status.
+    (Recovery 将处理器中的 panic 转换为记录到日志的 ErrPanic 错误，并以 Internal 状态响应。)
+
+DefaultServerInterceptors and DefaultClientInterceptors chain all of them, together with the RED metrics
+of metrics.GRPCMetrics:
+(DefaultServerInterceptors 和 DefaultClientInterceptors 串联所有拦截器以及 metrics.GRPCMetrics 的 RED 指标：)
+
+	srv := grpc.NewServer(grpcx.DefaultServerInterceptors(
+		grpcx.WithSkipMethods("/grpc.health.v1.Health/*"),
+	)...)
+	pb.RegisterOrderServiceServer(srv, &orderService{})
+
+	func (s *orderService) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+		logger := log.FromContext(ctx) // carries request_id and trace_id (携带 request_id 和 trace_id)
+		order, err := s.store.Get(ctx, req.Id)
+		if err != nil {
+			logger.Errorw("Failed to load order", "error", err)
+			return nil, err // becomes a NotFound status for errors.ErrNotFound (ErrNotFound 转换为 NotFound 状态)
+		}
+		return order, nil
+	}
+
+	conn, err := grpc.NewClient(target, append(grpcx.DefaultClientInterceptors(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	_, err = pb.NewOrderServiceClient(conn).GetOrder(ctx, req)
+	if errors.IsCode(err, errors.ErrNotFound) { ... }
+*/
+package grpcx
@@ -0,0 +1,93 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerErrors 返回将处理器返回的错误通过 errors.ToGRPCStatus 转换为 gRPC 状态的拦截器，
+// 状态码由错误的 Coder 决定，错误码、描述和详情随状态传给客户端。
+// (UnaryServerErrors returns an interceptor converting the errors returned by handlers into gRPC statuses with
+// errors.ToGRPCStatus; the status code follows the error's Coder, and the error code, description and details travel
+// with the status to the client.)
+func UnaryServerErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatusError(err)
+	}
+}
+
+// StreamServerErrors 是 UnaryServerErrors 的流式版本。(StreamServerErrors is the streaming counterpart of UnaryServerErrors.)
+func StreamServerErrors() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatusError(handler(srv, ss))
+	}
+}
+
+// UnaryClientErrors 返回将调用返回的 gRPC 状态通过 errors.FromGRPCStatus 还原为带 Coder 的错误的拦截器，
+// 调用方可以直接使用 errors.IsCode 判断。
+// (UnaryClientErrors returns an interceptor restoring the gRPC statuses returned by calls into errors carrying a Coder
+// with errors.FromGRPCStatus, so callers can check them with errors.IsCode.)
+func UnaryClientErrors() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return fromStatusError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientErrors 是 UnaryClientErrors 的流式版本，同样转换 SendMsg 和 RecvMsg 返回的错误，io.EOF 保持不变。
+// (StreamClientErrors is the streaming counterpart of UnaryClientErrors; it also converts the errors returned by SendMsg
+// and RecvMsg, leaving io.EOF unchanged.)
+func StreamClientErrors() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, fromStatusError(err)
+		}
+		return &codedClientStream{ClientStream: cs}, nil
+	}
+}
+
+// codedClientStream 将流返回的 gRPC 状态还原为带 Coder 的错误。
+// (codedClientStream restores the gRPC statuses returned by a stream into errors carrying a Coder.)
+type codedClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *codedClientStream) SendMsg(msg any) error {
+	return fromStatusError(s.ClientStream.SendMsg(msg))
+}
+
+func (s *codedClientStream) RecvMsg(msg any) error {
+	return fromStatusError(s.ClientStream.RecvMsg(msg))
+}
+
+// toStatusError 将 err 转换为 gRPC 状态错误，nil 保持为 nil。(toStatusError converts err into a gRPC status error; nil stays nil.)
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return lmccerrors.ToGRPCStatus(err).Err()
+}
+
+// fromStatusError 将携带 gRPC 状态的错误还原为带 Coder 的错误，nil、io.EOF 和其他错误保持不变。
+// (fromStatusError restores an error carrying a gRPC status into an error carrying a Coder; nil, io.EOF and other errors are unchanged.)
+func fromStatusError(err error) error {
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+	if st, ok := status.FromError(err); ok {
+		return lmccerrors.FromGRPCStatus(st)
+	}
+	return err
+}
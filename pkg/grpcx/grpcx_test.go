@@ -0,0 +1,183 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the gRPC interceptors.
+ */
+
+package grpcx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/grpcx"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// syncBuffer 是可并发写入的缓冲区。(syncBuffer is a buffer safe for concurrent writes.)
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// entries 解析缓冲区中的 JSON 日志行。(entries parses the JSON log lines in the buffer.)
+func (b *syncBuffer) entries(t *testing.T) []map[string]any {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// healthService 根据请求的服务名返回成功、错误或 panic，并记录 context 中的请求 ID 和 trace ID。
+// (healthService succeeds, fails or panics depending on the requested service name, and records the request ID and trace ID in the context.)
+type healthService struct {
+	healthpb.UnimplementedHealthServer
+	requestID, traceID string
+	scoped             bool
+}
+
+func (s *healthService) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.requestID, _ = log.RequestIDFromContext(ctx)
+	s.traceID, _ = log.TraceIDFromContext(ctx)
+	s.scoped = log.FromContext(ctx) != log.Std()
+	switch req.GetService() {
+	case "missing":
+		return nil, lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "service missing is not registered")
+	case "panic":
+		panic("boom")
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// TestDefaultInterceptors tests request ID and trace propagation, error translation, panic recovery, logging and metrics
+// between a client and a server using the default interceptors.
+// (TestDefaultInterceptors 测试使用默认拦截器的客户端和服务端之间的请求 ID 和 trace 传播、错误转换、panic 恢复、日志和指标。)
+func TestDefaultInterceptors(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Format = "json"
+	opts.Level = "debug"
+	opts.DisableStacktrace = true
+	var buf syncBuffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	reg := metrics.NewRegistry()
+	m, err := metrics.NewGRPCMetrics(nil, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	service := &healthService{}
+	srv := grpc.NewServer(grpcx.DefaultServerInterceptors(grpcx.WithLogger(logger), grpcx.WithMetrics(m))...)
+	healthpb.RegisterHealthServer(srv, service)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), append(grpcx.DefaultClientInterceptors(grpcx.WithLogger(logger), grpcx.WithMetrics(nil)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	ctx := log.ContextWithRequestID(context.Background(), "req-1")
+	ctx = log.ContextWithTraceID(ctx, "4bf92f3577b34da6a3ce929d0e0e4736")
+	var header metadata.MD
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", service.requestID, "the request ID is propagated in metadata")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", service.traceID, "the trace ID is propagated through traceparent")
+	assert.True(t, service.scoped, "handlers get a call-scoped Logger")
+	assert.Equal(t, []string{"req-1"}, header.Get(grpcx.RequestIDMetadataKey))
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "missing"})
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrNotFound), "the client restores the Coder")
+	assert.Equal(t, codes.NotFound, lmccerrors.GRPCCode(err))
+	assert.Len(t, service.requestID, 36, "a request ID is generated when none is sent")
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "panic"})
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrPanic))
+	assert.Equal(t, codes.Internal, lmccerrors.GRPCCode(err))
+
+	levels := map[string][]string{}
+	for _, entry := range buf.entries(t) {
+		msg, _ := entry["M"].(string)
+		levels[msg] = append(levels[msg], entry["L"].(string))
+		if msg == "gRPC request" && entry["code"] == "OK" {
+			assert.Equal(t, "req-1", entry["request_id"])
+			assert.Equal(t, "/grpc.health.v1.Health/Check", entry["method"])
+		}
+	}
+	assert.Equal(t, []string{"INFO", "WARN", "ERROR"}, levels["gRPC request"])
+	assert.Equal(t, []string{"DEBUG", "WARN", "ERROR"}, levels["gRPC client call"])
+	assert.Equal(t, []string{"ERROR"}, levels["Recovered from panic"])
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.Contains(t, names, "grpc_server_requests_total")
+	assert.NotContains(t, names, "grpc_client_requests_total", "WithMetrics(nil) disables client metrics")
+}
+
+// TestServerRequestID tests that invalid incoming request IDs are replaced and the skip list silences logging.
+// (TestServerRequestID 测试替换非法的入站请求 ID，以及跳过列表中的方法不写日志。)
+func TestServerRequestID(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Format = "json"
+	var buf syncBuffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	options := []grpcx.Option{
+		grpcx.WithRequestIDGenerator(func() string { return "generated" }),
+		grpcx.WithLogger(logger),
+		grpcx.WithSkipMethods("/grpc.health.v1.Health/*"),
+	}
+	requestID := grpcx.UnaryServerRequestID(options...)
+	logging := grpcx.UnaryServerLogging(options...)
+
+	var got string
+	handler := func(ctx context.Context, _ any) (any, error) {
+		got, _ = log.RequestIDFromContext(ctx)
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcx.RequestIDMetadataKey, "bad\nid"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	_, err := requestID(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return logging(ctx, req, info, handler)
+	})
+	require.Error(t, err)
+	assert.Equal(t, "generated", got)
+	assert.Empty(t, buf.entries(t), "skipped methods are not logged")
+}
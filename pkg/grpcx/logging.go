@@ -0,0 +1,163 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// UnaryServerLogging 返回写调用日志的拦截器。它将带有 request_id 和 trace_id 字段的调用级 Logger 写入 context，
+// 处理器通过 log.FromContext 获取；调用完成后以该 Logger 记录方法、状态码、耗时和错误，OK 为 Info，
+// 客户端错误（InvalidArgument、NotFound 等）为 Warn，服务端错误（Internal、Unavailable 等）为 Error。
+// WithSkipMethods 中的方法不写调用日志，但仍会获得调用级 Logger。
+// (UnaryServerLogging returns an interceptor writing call logs. It stores a call-scoped Logger with request_id and trace_id
+// fields in the context, which handlers obtain through log.FromContext; once the call completes it logs the method, status
+// code, duration and error with that Logger, at Info for OK, Warn for client errors such as InvalidArgument and NotFound, and
+// Error for server errors such as Internal and Unavailable. Methods in WithSkipMethods are not logged but still get a call-scoped Logger.)
+func UnaryServerLogging(options ...Option) grpc.UnaryServerInterceptor {
+	s := newSettings(options)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		logger := callLogger(s.baseLogger(), ctx)
+		resp, err := handler(log.NewContextWithLogger(ctx, logger), req)
+		if !s.skip(info.FullMethod) {
+			logCall(logger, "gRPC request", logger.Infow, info.FullMethod, err, start)
+		}
+		return resp, err
+	}
+}
+
+// StreamServerLogging 是 UnaryServerLogging 的流式版本，在流结束时写一条日志。
+// (StreamServerLogging is the streaming counterpart of UnaryServerLogging, writing one entry when the stream ends.)
+func StreamServerLogging(options ...Option) grpc.StreamServerInterceptor {
+	s := newSettings(options)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		logger := callLogger(s.baseLogger(), ss.Context())
+		err := handler(srv, withContext(ss, log.NewContextWithLogger(ss.Context(), logger)))
+		if !s.skip(info.FullMethod) {
+			logCall(logger, "gRPC stream", logger.Infow, info.FullMethod, err, start)
+		}
+		return err
+	}
+}
+
+// UnaryClientLogging 返回记录出站调用的拦截器：成功的调用为 Debug，失败的调用按状态码为 Warn 或 Error。
+// (UnaryClientLogging returns an interceptor logging outgoing calls: successful calls at Debug, failed calls at Warn or
+// Error depending on the status code.)
+func UnaryClientLogging(options ...Option) grpc.UnaryClientInterceptor {
+	s := newSettings(options)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if !s.skip(method) {
+			logger := callLogger(s.baseLogger(), ctx)
+			logCall(logger, "gRPC client call", logger.Debugw, method, err, start)
+		}
+		return err
+	}
+}
+
+// StreamClientLogging 是 UnaryClientLogging 的流式版本，流在接收结束或出错时写一条日志。
+// (StreamClientLogging is the streaming counterpart of UnaryClientLogging; a stream is logged when receiving ends or fails.)
+func StreamClientLogging(options ...Option) grpc.StreamClientInterceptor {
+	s := newSettings(options)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		finish := func(err error) {
+			if !s.skip(method) {
+				logger := callLogger(s.baseLogger(), ctx)
+				logCall(logger, "gRPC client stream", logger.Debugw, method, err, start)
+			}
+		}
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			finish(err)
+			return nil, err
+		}
+		return &loggedClientStream{ClientStream: cs, finish: finish}, nil
+	}
+}
+
+// loggedClientStream 在 RecvMsg 返回错误（含 io.EOF）时写一条日志。
+// (loggedClientStream writes one log entry once RecvMsg returns an error, including io.EOF.)
+type loggedClientStream struct {
+	grpc.ClientStream
+	once   sync.Once
+	finish func(err error)
+}
+
+func (s *loggedClientStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil {
+		s.once.Do(func() {
+			if errors.Is(err, io.EOF) {
+				s.finish(nil)
+			} else {
+				s.finish(err)
+			}
+		})
+	}
+	return err
+}
+
+// callLogger 返回附带请求 ID 和 trace ID 字段的 Logger。(callLogger returns a Logger carrying the request ID and trace ID fields.)
+func callLogger(base log.Logger, ctx context.Context) log.Logger {
+	var keysAndValues []any
+	if id, ok := log.RequestIDFromContext(ctx); ok {
+		keysAndValues = append(keysAndValues, "request_id", id)
+	}
+	if traceID, ok := log.TraceIDFromContext(ctx); ok {
+		keysAndValues = append(keysAndValues, log.TraceIDField, traceID)
+	}
+	if len(keysAndValues) == 0 {
+		return base
+	}
+	return base.WithValues(keysAndValues...)
+}
+
+// logCall 记录一次调用：成功时使用 success，客户端错误为 Warn，服务端错误为 Error。
+// (logCall logs one call: with success when it succeeded, at Warn for client errors and at Error for server errors.)
+func logCall(logger log.Logger, msg string, success func(string, ...any), method string, err error, start time.Time) {
+	code := lmccerrors.GRPCCode(err)
+	keysAndValues := []any{
+		"method", method,
+		"code", code.String(),
+		"duration", time.Since(start),
+	}
+	if err != nil {
+		keysAndValues = append(keysAndValues, "error", err)
+	}
+	switch {
+	case code == codes.OK:
+		success(msg, keysAndValues...)
+	case serverError(code):
+		logger.Errorw(msg, keysAndValues...)
+	default:
+		logger.Warnw(msg, keysAndValues...)
+	}
+}
+
+// serverError 报告状态码是否表示服务端故障而非调用方的问题。
+// (serverError reports whether a status code indicates a server fault rather than a problem with the call.)
+func serverError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package healthcheck provides a registry of named health checks and HTTP handlers for liveness and readiness probes.
+(healthcheck 包提供命名健康检查的注册表，以及用于存活和就绪探针的 HTTP 处理器。)
+
+Each check is a func(ctx) error that returns nil when the dependency is healthy. Checks run concurrently,
+each with its own timeout; a check that panics or does not return in time fails. Results can be cached
+for a TTL so that frequent probes do not hammer the dependencies.
+(每个检查是一个 func(ctx) error，依赖健康时返回 nil。检查并发执行，各自带有超时；发生 panic 或未按时返回的检查视为失败。
+结果可以缓存一段 TTL，避免频繁的探针压垮依赖。)
+
+Criticality:
+(关键性：)
+
+  - Critical checks (the default) make the service "failing" and the readiness endpoint return 503.
+    (关键检查（默认）失败时服务为 "failing"，就绪端点返回 503。)
+  - Non-critical checks only make the service "degraded"; the readiness endpoint still returns 200.
+    (非关键检查失败时服务仅为 "degraded"，就绪端点仍返回 200。)
+
+Thresholds and transitions:
+(阈值与状态变化：)
+
+A check's status follows its consecutive failures: with WithThresholds(degradedAfter, failingAfter) it becomes
+"degraded" after degradedAfter failures and "failing" after failingAfter, and one success restores "ok". Both default to
+1, so a single failure is failing. Every change is logged, at Warn level when it gets worse and at Info level when it
+recovers, and passed to the function set with OnTransition.
+(检查的状态取决于连续失败的次数：使用 WithThresholds(degradedAfter, failingAfter) 时，连续失败 degradedAfter 次后为 "degraded"，
+failingAfter 次后为 "failing"，一次成功即恢复为 "ok"。两者默认均为 1，即一次失败就是 failing。每次变化都会记录日志，变差时为 Warn
+级别，恢复时为 Info 级别，并传给通过 OnTransition 设置的函数。)
+
+Background probing:
+(后台探测：)
+
+Registry.Start runs the checks every interval in the background until Registry.Stop. While it runs, Check and the
+readiness endpoint serve the latest probe instead of running the checks, so transitions are detected even when nothing
+polls the endpoints and probe traffic never reaches the dependencies.
+(Registry.Start 在后台每隔 interval 执行一次检查，直到调用 Registry.Stop。运行期间 Check 和就绪端点返回最近一次探测的结果而不执行检查，
+因此即使没有请求访问端点也能发现状态变化，探针请求也不会传到依赖上。)
+
+The handlers write the SDK's response envelope, {"success", "data", "error", "request_id", "timestamp"},
+with the report in data. The liveness handler runs no checks: it only reports that the process is serving.
+(处理器输出 SDK 的响应信封 {"success", "data", "error", "request_id", "timestamp"}，报告位于 data 中。
+存活处理器不执行任何检查，只表明进程正在提供服务。)
+
+Usage:
+(用法：)
+
+	registry := healthcheck.New(healthcheck.WithLogger(logger))
+	registry.Register("database", db.PingContext, healthcheck.WithTimeout(2*time.Second), healthcheck.WithThresholds(2, 3))
+	registry.Register("cache", cache.Ping, healthcheck.NonCritical(), healthcheck.WithCacheTTL(10*time.Second))
+
+	if err := registry.Start(ctx, 15*time.Second); err != nil {
+		return err
+	}
+	defer registry.Stop(context.Background())
+
+	mux := http.NewServeMux()
+	registry.Mount(mux) // GET /healthz and GET /readyz
+*/
+package healthcheck
@@ -0,0 +1,79 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package healthcheck
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// Mount 挂载的默认路径。(Default paths used by Mount.)
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// Response 是健康端点的响应体，与 SDK 的 API 响应结构一致。
+// (Response is the body of the health endpoints, matching the SDK's API response shape.)
+type Response struct {
+	Success   bool      `json:"success"`
+	Data      *Report   `json:"data,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	RequestID string    `json:"request_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// LivenessHandler 返回存活处理器：不执行任何检查，始终返回 200 和状态 ok。
+// (LivenessHandler returns the liveness handler: it runs no checks and always returns 200 with status ok.)
+func (r *Registry) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeResponse(w, req, http.StatusOK, Response{Success: true, Data: &Report{Status: StatusOK}})
+	})
+}
+
+// ReadinessHandler 返回就绪处理器：执行所有检查，状态为 ok 或 degraded 时返回 200，为 failing 时返回 503。
+// (ReadinessHandler returns the readiness handler: it runs every check and returns 200 when the status is ok or
+// degraded, and 503 when it is failing.)
+func (r *Registry) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context())
+		if report.Status == StatusFailing {
+			writeResponse(w, req, http.StatusServiceUnavailable, Response{Data: &report, Error: "service not ready"})
+			return
+		}
+		writeResponse(w, req, http.StatusOK, Response{Success: true, Data: &report})
+	})
+}
+
+// Mount 在 mux 上挂载 GET LivenessPath 和 GET ReadinessPath。
+// (Mount registers GET LivenessPath and GET ReadinessPath on mux.)
+func (r *Registry) Mount(mux *http.ServeMux) {
+	mux.Handle("GET "+LivenessPath, r.LivenessHandler())
+	mux.Handle("GET "+ReadinessPath, r.ReadinessHandler())
+}
+
+// writeResponse 补全请求 ID 和时间戳后以 JSON 写出响应。请求 ID 取自 httpx.RequestID 写入的 context，
+// 否则取自 X-Request-ID 请求头。
+// (writeResponse fills in the request ID and timestamp and writes the response as JSON. The request ID comes from
+// the context set by httpx.RequestID, or else from the X-Request-ID request header.)
+func writeResponse(w http.ResponseWriter, req *http.Request, status int, resp Response) {
+	if id, ok := log.RequestIDFromContext(req.Context()); ok {
+		resp.RequestID = id
+	} else {
+		resp.RequestID = req.Header.Get(lmccerrors.RequestIDHeader)
+	}
+	resp.Timestamp = time.Now().UTC()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
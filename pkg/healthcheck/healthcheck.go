@@ -0,0 +1,288 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package healthcheck
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// 健康状态。(Health statuses.)
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusFailing  = "failing"
+)
+
+// DefaultTimeout 是每个检查的默认超时。(DefaultTimeout is the default timeout of each check.)
+const DefaultTimeout = 5 * time.Second
+
+// Check 检查一个依赖，返回 nil 表示健康。(Check checks one dependency; nil means healthy.)
+type Check func(ctx context.Context) error
+
+// CheckOption 配置一个已注册的检查。(CheckOption configures a registered check.)
+type CheckOption func(*entry)
+
+// WithTimeout 设置检查的超时，默认为 DefaultTimeout；d <= 0 时保留默认值。
+// (WithTimeout sets the check's timeout, DefaultTimeout by default; d <= 0 keeps the default.)
+func WithTimeout(d time.Duration) CheckOption {
+	return func(e *entry) {
+		if d > 0 {
+			e.timeout = d
+		}
+	}
+}
+
+// NonCritical 将检查标记为非关键：失败时服务为 degraded 而不是 failing，就绪端点仍返回 200。
+// (NonCritical marks the check as non-critical: a failure makes the service degraded instead of failing,
+// and the readiness endpoint still returns 200.)
+func NonCritical() CheckOption {
+	return func(e *entry) {
+		e.critical = false
+	}
+}
+
+// WithCacheTTL 使检查结果在 ttl 内被复用，而不是每次都重新执行；默认不缓存。
+// (WithCacheTTL reuses the check's result for ttl instead of running it every time; results are not cached by default.)
+func WithCacheTTL(ttl time.Duration) CheckOption {
+	return func(e *entry) {
+		e.cacheTTL = ttl
+	}
+}
+
+// WithThresholds 设置检查状态变化所需的连续失败次数：连续失败 degradedAfter 次后为 degraded，failingAfter 次后为 failing，
+// 一次成功即恢复为 ok。默认均为 1，即一次失败就是 failing；degradedAfter < 1 时按 1 处理，failingAfter 小于 degradedAfter 时取 degradedAfter。
+// (WithThresholds sets how many consecutive failures change the check's status: degraded after degradedAfter failures and
+// failing after failingAfter, while one success restores ok. Both default to 1, so a single failure is failing;
+// degradedAfter < 1 counts as 1 and a failingAfter below degradedAfter counts as degradedAfter.)
+func WithThresholds(degradedAfter, failingAfter int) CheckOption {
+	return func(e *entry) {
+		e.degradedAfter = max(degradedAfter, 1)
+		e.failingAfter = max(failingAfter, e.degradedAfter)
+	}
+}
+
+// Result 是一个检查的结果。(Result is the outcome of one check.)
+type Result struct {
+	Name string `json:"name"`
+	// Status 是 ok、degraded 或 failing，取决于连续失败的次数（见 WithThresholds）。
+	// (Status is ok, degraded or failing depending on the number of consecutive failures; see WithThresholds.)
+	Status    string    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report 是所有检查的汇总。(Report summarizes all checks.)
+type Report struct {
+	// Status 是 ok、degraded 或 failing。(Status is ok, degraded or failing.)
+	Status string `json:"status"`
+	// Checks 是各个检查的结果，按名称排序。(Checks are the results of the individual checks, sorted by name.)
+	Checks []Result `json:"checks,omitempty"`
+}
+
+// entry 是一个已注册的检查及其缓存的结果。(entry is a registered check and its cached result.)
+type entry struct {
+	name          string
+	check         Check
+	timeout       time.Duration
+	critical      bool
+	cacheTTL      time.Duration
+	degradedAfter int
+	failingAfter  int
+
+	mu       sync.Mutex
+	last     Result
+	hasRun   bool
+	failures int
+}
+
+// Option 配置注册表。(Option configures a registry.)
+type Option func(*Registry)
+
+// WithLogger 设置记录检查状态变化的日志记录器，默认使用全局日志记录器。
+// (WithLogger sets the logger recording check status transitions; the global logger is used by default.)
+func WithLogger(logger log.Logger) Option {
+	return func(r *Registry) {
+		r.logger = logger
+	}
+}
+
+// OnTransition 设置检查状态变化时调用的函数，例如用于发送告警。fn 在执行检查的 goroutine 中调用，不应阻塞。
+// (OnTransition sets the function called when a check's status changes, e.g. to send alerts. fn is called on the
+// goroutine that ran the check and should not block.)
+func OnTransition(fn func(Transition)) Option {
+	return func(r *Registry) {
+		r.onTransition = fn
+	}
+}
+
+// Registry 保存命名的健康检查，可以被多个 goroutine 并发使用。
+// (Registry holds named health checks and is safe for concurrent use.)
+type Registry struct {
+	mu           sync.RWMutex
+	entries      map[string]*entry
+	logger       log.Logger
+	onTransition func(Transition)
+	probe        prober
+}
+
+// New 创建空的注册表。(New creates an empty registry.)
+func New(options ...Option) *Registry {
+	r := &Registry{entries: make(map[string]*entry)}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Register 以 name 注册检查，检查默认是关键的。同名检查会被替换；name 为空或 check 为 nil 时返回错误。
+// (Register registers check under name; checks are critical by default. A check with the same name is replaced;
+// an empty name or a nil check returns an error.)
+func (r *Registry) Register(name string, check Check, options ...CheckOption) error {
+	if name == "" || check == nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrValidation, "health check requires a name and a check function")
+	}
+	e := &entry{name: name, check: check, timeout: DefaultTimeout, critical: true, degradedAfter: 1, failingAfter: 1}
+	for _, option := range options {
+		option(e)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[name] = e
+	return nil
+}
+
+// Unregister 移除名为 name 的检查。(Unregister removes the check named name.)
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, name)
+}
+
+// Check 并发执行所有检查（缓存仍有效的除外）并汇总结果：任一关键检查为 failing 时为 failing，
+// 有其他检查不为 ok 时为 degraded，否则为 ok。没有注册检查时为 ok。后台探测运行期间（见 Start）返回最近一次探测的结果，不再执行检查。
+// (Check runs every check concurrently, except those with a valid cached result, and summarizes the results: failing
+// when a critical check is failing, degraded when any other check is not ok and ok otherwise. With no checks it is ok.
+// While background probing runs (see Start) it returns the results of the latest probe instead of running the checks.)
+func (r *Registry) Check(ctx context.Context) Report {
+	return r.check(ctx, r.probe.running())
+}
+
+// check 执行所有检查并汇总结果；latest 为 true 时已有结果的检查直接返回最近一次的结果。
+// (check runs every check and summarizes the results; with latest set, checks that have a result return their most recent one.)
+func (r *Registry) check(ctx context.Context, latest bool) Report {
+	r.mu.RLock()
+	entries := make([]*entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, e)
+	}
+	r.mu.RUnlock()
+
+	results := make([]Result, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var transition *Transition
+			results[i], transition = e.run(ctx, latest)
+			if transition != nil {
+				r.transitioned(*transition)
+			}
+		}()
+	}
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, result := range results {
+		if result.Status == StatusOK {
+			continue
+		}
+		if result.Critical && result.Status == StatusFailing {
+			report.Status = StatusFailing
+			break
+		}
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+// run 执行检查，缓存仍有效或 latest 为 true 且已有结果时直接返回上次的结果。并发调用会等待同一次执行。
+// 状态发生变化时返回对应的 Transition。
+// (run runs the check, returning the previous result while the cache is valid, or when latest is set and there is one.
+// Concurrent calls wait for the same run. It returns the Transition when the status changed.)
+func (e *entry) run(ctx context.Context, latest bool) (Result, *Transition) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.hasRun && (latest || e.cacheTTL > 0 && time.Since(e.last.CheckedAt) < e.cacheTTL) {
+		return e.last, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	start := time.Now()
+	result := Result{Name: e.name, Critical: e.critical, CheckedAt: start}
+	err := runCheck(ctx, e.check)
+	if err != nil {
+		e.failures++
+		result.Error = err.Error()
+	} else {
+		e.failures = 0
+	}
+	result.Status = e.status()
+	result.Duration = time.Since(start).String()
+
+	// 首次执行前视为 ok (A check counts as ok before its first run)
+	previous := StatusOK
+	if e.hasRun {
+		previous = e.last.Status
+	}
+	e.last, e.hasRun = result, true
+	if result.Status == previous {
+		return result, nil
+	}
+	return result, &Transition{Name: e.name, From: previous, To: result.Status, Critical: e.critical, Error: result.Error, At: start}
+}
+
+// status 根据连续失败次数和阈值返回检查的状态。(status returns the check's status from its consecutive failures and thresholds.)
+func (e *entry) status() string {
+	switch {
+	case e.failures >= e.failingAfter:
+		return StatusFailing
+	case e.failures >= e.degradedAfter:
+		return StatusDegraded
+	default:
+		return StatusOK
+	}
+}
+
+// runCheck 执行检查，检查超时未返回或发生 panic 时返回错误。
+// (runCheck runs a check, returning an error when it panics or does not return before the timeout.)
+func runCheck(ctx context.Context, check Check) error {
+	done := make(chan error, 1)
+	go func() {
+		defer lmccerrors.HandlePanic(func(p any) {
+			done <- lmccerrors.Wrap(lmccerrors.WrapPanic(p), "health check panicked")
+		})
+		done <- check(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
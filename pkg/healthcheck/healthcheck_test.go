@@ -0,0 +1,195 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the health check registry and its HTTP handlers.
+ */
+
+package healthcheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) error { return nil }
+
+// TestRegistry_Check tests the overall status for critical and non-critical failures, timeouts and panics.
+// (TestRegistry_Check 测试关键和非关键检查失败、超时以及 panic 时的整体状态。)
+func TestRegistry_Check(t *testing.T) {
+	registry := healthcheck.New()
+	require.NoError(t, registry.Register("database", ok))
+	assert.Error(t, registry.Register("", ok))
+	assert.Error(t, registry.Register("nil", nil))
+
+	report := registry.Check(context.Background())
+	assert.Equal(t, healthcheck.StatusOK, report.Status)
+	require.Len(t, report.Checks, 1)
+	assert.True(t, report.Checks[0].Critical)
+
+	require.NoError(t, registry.Register("cache", func(context.Context) error { return errors.New("connection refused") }, healthcheck.NonCritical()))
+	report = registry.Check(context.Background())
+	assert.Equal(t, healthcheck.StatusDegraded, report.Status)
+	assert.Equal(t, "cache", report.Checks[0].Name)
+	assert.Equal(t, "connection refused", report.Checks[0].Error)
+
+	require.NoError(t, registry.Register("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}, healthcheck.WithTimeout(20*time.Millisecond)))
+	require.NoError(t, registry.Register("broken", func(context.Context) error { panic("boom") }, healthcheck.NonCritical()))
+	report = registry.Check(context.Background())
+	assert.Equal(t, healthcheck.StatusFailing, report.Status)
+	results := make(map[string]healthcheck.Result)
+	for _, result := range report.Checks {
+		results[result.Name] = result
+	}
+	assert.Contains(t, results["slow"].Error, "deadline exceeded")
+	assert.Contains(t, results["broken"].Error, "boom")
+
+	registry.Unregister("slow")
+	assert.Equal(t, healthcheck.StatusDegraded, registry.Check(context.Background()).Status)
+}
+
+// TestRegistry_CacheTTL tests that cached results are reused until the TTL expires.
+// (TestRegistry_CacheTTL 测试缓存的结果在 TTL 过期前被复用。)
+func TestRegistry_CacheTTL(t *testing.T) {
+	var calls atomic.Int32
+	registry := healthcheck.New()
+	require.NoError(t, registry.Register("database", func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}, healthcheck.WithCacheTTL(50*time.Millisecond)))
+
+	registry.Check(context.Background())
+	registry.Check(context.Background())
+	assert.Equal(t, int32(1), calls.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	registry.Check(context.Background())
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// TestHandlers tests the liveness and readiness endpoints and their response envelope.
+// (TestHandlers 测试存活和就绪端点及其响应信封。)
+func TestHandlers(t *testing.T) {
+	var failing atomic.Bool
+	registry := healthcheck.New()
+	require.NoError(t, registry.Register("database", func(context.Context) error {
+		if failing.Load() {
+			return errors.New("down")
+		}
+		return nil
+	}))
+	mux := http.NewServeMux()
+	registry.Mount(mux)
+
+	get := func(path string) (int, healthcheck.Response) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Request-ID", "req-1")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var resp healthcheck.Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return rec.Code, resp
+	}
+
+	code, resp := get(healthcheck.ReadinessPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Success)
+	assert.Equal(t, "req-1", resp.RequestID)
+	assert.False(t, resp.Timestamp.IsZero())
+	require.NotNil(t, resp.Data)
+	assert.Equal(t, healthcheck.StatusOK, resp.Data.Status)
+	require.Len(t, resp.Data.Checks, 1)
+
+	failing.Store(true)
+	code, resp = get(healthcheck.ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, resp.Success)
+	assert.NotEmpty(t, resp.Error)
+	assert.Equal(t, healthcheck.StatusFailing, resp.Data.Status)
+	assert.Equal(t, "down", resp.Data.Checks[0].Error)
+
+	code, resp = get(healthcheck.LivenessPath)
+	assert.Equal(t, http.StatusOK, code, "liveness does not depend on the checks")
+	assert.True(t, resp.Success)
+	assert.Empty(t, resp.Data.Checks)
+}
+
+// TestRegistry_Thresholds tests the ok, degraded and failing transitions driven by consecutive failures and their logs.
+// (TestRegistry_Thresholds 测试由连续失败驱动的 ok、degraded 和 failing 状态变化及其日志。)
+func TestRegistry_Thresholds(t *testing.T) {
+	logger := logtest.NewTestLogger(t)
+	var transitions []healthcheck.Transition
+	registry := healthcheck.New(healthcheck.WithLogger(logger), healthcheck.OnTransition(func(tr healthcheck.Transition) {
+		transitions = append(transitions, tr)
+	}))
+	var failing atomic.Bool
+	require.NoError(t, registry.Register("database", func(context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}, healthcheck.WithThresholds(2, 3)))
+
+	failing.Store(true)
+	report := registry.Check(context.Background())
+	assert.Equal(t, healthcheck.StatusOK, report.Status, "one failure is below the degraded threshold")
+	assert.Equal(t, "connection refused", report.Checks[0].Error)
+	assert.Equal(t, healthcheck.StatusDegraded, registry.Check(context.Background()).Status)
+	assert.Equal(t, healthcheck.StatusFailing, registry.Check(context.Background()).Status)
+	failing.Store(false)
+	assert.Equal(t, healthcheck.StatusOK, registry.Check(context.Background()).Status, "one success recovers")
+
+	require.Len(t, transitions, 3)
+	assert.Equal(t, []string{healthcheck.StatusOK, healthcheck.StatusDegraded}, []string{transitions[0].From, transitions[0].To})
+	assert.Equal(t, []string{healthcheck.StatusDegraded, healthcheck.StatusFailing}, []string{transitions[1].From, transitions[1].To})
+	assert.Equal(t, []string{healthcheck.StatusFailing, healthcheck.StatusOK}, []string{transitions[2].From, transitions[2].To})
+	assert.Equal(t, "connection refused", transitions[1].Error)
+	logger.ContainsEntry("warn", "Health check status changed", "check", "database", "to", healthcheck.StatusFailing)
+	logger.ContainsEntry("info", "Health check recovered", "check", "database", "from", healthcheck.StatusFailing)
+}
+
+// TestRegistry_Start tests that background probing runs the checks periodically and that Check serves the latest probe.
+// (TestRegistry_Start 测试后台探测周期性地执行检查，且 Check 返回最近一次探测的结果。)
+func TestRegistry_Start(t *testing.T) {
+	var calls atomic.Int32
+	registry := healthcheck.New(healthcheck.WithLogger(logtest.NewTestLogger(t)))
+	require.NoError(t, registry.Register("database", func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}))
+	ctx := context.Background()
+	assert.Error(t, registry.Start(ctx, 0))
+
+	require.NoError(t, registry.Start(ctx, time.Hour))
+	assert.Error(t, registry.Start(ctx, time.Hour), "probing is already running")
+	assert.Equal(t, int32(1), calls.Load(), "Start probes once immediately")
+	for range 3 {
+		assert.Equal(t, healthcheck.StatusOK, registry.Check(ctx).Status)
+	}
+	assert.Equal(t, int32(1), calls.Load(), "Check serves the latest probe")
+	require.NoError(t, registry.Stop(ctx))
+
+	require.NoError(t, registry.Start(ctx, 10*time.Millisecond))
+	require.Eventually(t, func() bool { return calls.Load() >= 4 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, registry.Stop(ctx))
+	require.NoError(t, registry.Stop(ctx), "stopping twice is a no-op")
+
+	before := calls.Load()
+	registry.Check(ctx)
+	assert.Equal(t, before+1, calls.Load(), "Check runs the checks on demand once probing stopped")
+}
@@ -0,0 +1,124 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// Transition 描述一个检查的状态变化。(Transition describes a change in the status of one check.)
+type Transition struct {
+	// Name 是检查的名称。(Name is the name of the check.)
+	Name string `json:"name"`
+	// From 和 To 是变化前后的状态，检查首次执行前视为 ok。(From and To are the statuses before and after; a check counts as ok before its first run.)
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Critical 表示检查是否是关键的。(Critical reports whether the check is critical.)
+	Critical bool `json:"critical"`
+	// Error 是导致变化的最近一次错误，恢复为 ok 时为空。(Error is the latest error behind the change, empty when it recovered to ok.)
+	Error string `json:"error,omitempty"`
+	// At 是执行检查的时间。(At is when the check ran.)
+	At time.Time `json:"at"`
+}
+
+// prober 保存后台探测的状态。(prober holds the state of background probing.)
+type prober struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// running 报告后台探测是否在运行。(running reports whether background probing is running.)
+func (p *prober) running() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cancel != nil
+}
+
+// Start 立即执行一次所有检查，然后在后台每隔 interval 执行一次，直到 ctx 结束或调用 Stop。
+// 探测运行期间 Check 和就绪端点返回最近一次探测的结果，探针请求不再触发检查；状态变化由探测记录日志并通知 OnTransition。
+// interval <= 0 或探测已在运行时返回带 ErrValidation 的错误。
+// (Start runs every check once and then every interval in the background until ctx is done or Stop is called.
+// While probing runs, Check and the readiness endpoint return the results of the latest probe, so probe requests no longer
+// trigger the checks; status transitions are logged and passed to OnTransition by the probe. It returns an error coded
+// ErrValidation when interval <= 0 or probing is already running.)
+func (r *Registry) Start(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrValidation, "invalid health probe interval '%s', must be positive", interval)
+	}
+	r.probe.mu.Lock()
+	if r.probe.cancel != nil {
+		r.probe.mu.Unlock()
+		return lmccerrors.NewWithCode(lmccerrors.ErrValidation, "health probing already started")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	r.probe.cancel = cancel
+	r.probe.wg.Add(1)
+	r.probe.mu.Unlock()
+
+	// 在锁外执行首次探测，OnTransition 中调用 Check 不会死锁 (Run the first probe outside the lock so OnTransition may call Check)
+	r.check(ctx, false)
+	go func() {
+		defer r.probe.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.check(ctx, false)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop 停止后台探测并等待正在进行的探测结束，之后 Check 恢复为按需执行检查。探测未运行时不做任何事。
+// (Stop stops background probing and waits for a probe in progress; Check then runs the checks on demand again.
+// It does nothing when probing is not running.)
+func (r *Registry) Stop(context.Context) error {
+	r.probe.mu.Lock()
+	cancel := r.probe.cancel
+	r.probe.cancel = nil
+	r.probe.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	r.probe.wg.Wait()
+	return nil
+}
+
+// transitioned 记录状态变化并通知 OnTransition：变差时以 Warn 级别记录，恢复为 ok 时以 Info 级别记录。
+// (transitioned logs a status transition and passes it to OnTransition: at Warn level when it got worse and at Info
+// level when it recovered to ok.)
+func (r *Registry) transitioned(t Transition) {
+	kvs := []any{"check", t.Name, "from", t.From, "to", t.To, "critical", t.Critical}
+	if t.To == StatusOK {
+		r.log().Infow("Health check recovered", kvs...)
+	} else {
+		r.log().Warnw("Health check status changed", append(kvs, "error", t.Error)...)
+	}
+	if r.onTransition != nil {
+		r.onTransition(t)
+	}
+}
+
+// log 返回日志记录器。(log returns the logger.)
+func (r *Registry) log() log.Logger {
+	if r.logger != nil {
+		return r.logger
+	}
+	return log.Std()
+}
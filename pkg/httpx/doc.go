@@ -0,0 +1,57 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package httpx provides framework-agnostic net/http middleware built on pkg/log and pkg/errors.
+(httpx 包提供基于 pkg/log 和 pkg/errors、与框架无关的 net/http 中间件。)
+
+Every middleware has the form func(http.Handler) http.Handler, so it works with http.ServeMux
+and any router that accepts standard handlers:
+(每个中间件的形式都是 func(http.Handler) http.Handler，因此可用于 http.ServeMux 以及任何接受标准处理器的路由器：)
+
+  - RequestID reuses a valid incoming X-Request-ID header or generates one, echoes it in the
+    response and stores it, together with the trace ID, in the request context.
+    (RequestID 复用合法的 X-Request-ID 请求头或生成新的请求 ID，在响应中回写，并与 trace ID 一起写入请求 context。)
+  - Logging stores a request-scoped Logger in the context (see log.FromContext) and writes one
+    access log entry per request: Info for 1xx-3xx, Warn for 4xx and Error for 5xx.
+    (Logging 将请求级 Logger 写入 context（见 log.FromContext），并为每个请求写一条访问日志：1xx-3xx 为 Info，4xx 为 Warn，5xx 为 Error。)
+  - ContextLogger only stores the request-scoped Logger, with method and path fields added, for
+    services that write their access logs elsewhere.
+    (ContextLogger 只写入请求级 Logger，并附加 method 和 path 字段，适用于在别处写访问日志的服务。)
+  - Recovery turns a panic into a logged ErrPanic error and a JSON error response written by
+    errors.WriteHTTPError.
+    (Recovery 将 panic 转换为记录到日志的 ErrPanic 错误，并通过 errors.WriteHTTPError 写出 JSON 错误响应。)
+  - WrapResponseWriter captures the status code and body size of a response while keeping
+    http.Flusher, http.Hijacker and http.ResponseController working.
+    (WrapResponseWriter 记录响应的状态码和响应体大小，同时保持 http.Flusher、http.Hijacker 和 http.ResponseController 可用。)
+
+The trace ID is taken from the active OpenTelemetry span, then from a W3C traceparent header,
+and is generated otherwise, so every log entry of a request can be correlated.
+(trace ID 依次取自当前 OpenTelemetry span 和 W3C traceparent 请求头，都没有时生成新的值，使请求的每条日志都能关联起来。)
+
+Usage:
+(用法：)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", getUser)
+
+	// Default chains RequestID, Logging and Recovery, outermost first
+	// (Default 按从外到内的顺序串联 RequestID、Logging 和 Recovery)
+	handler := httpx.Default(httpx.WithSkipPaths("/healthz"))(mux)
+	http.ListenAndServe(":8080", handler)
+
+	func getUser(w http.ResponseWriter, r *http.Request) {
+		logger := log.FromContext(r.Context()) // carries request_id and trace_id (携带 request_id 和 trace_id)
+		user, err := users.Get(r.Context(), r.PathValue("id"))
+		if err != nil {
+			logger.Errorw("Failed to load user", "error", err)
+			errors.WriteHTTPError(w, err)
+			return
+		}
+		json.NewEncoder(w).Encode(user)
+	}
+*/
+package httpx
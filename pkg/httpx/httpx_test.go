@@ -0,0 +1,208 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the net/http middlewares.
+ */
+
+package httpx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/httpx"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLogger 返回写入 buf 的 JSON Logger。(newTestLogger returns a JSON Logger writing to buf.)
+func newTestLogger(buf *bytes.Buffer) log.Logger {
+	opts := log.NewOptions()
+	opts.Format = "json"
+	opts.Level = "debug"
+	opts.DisableStacktrace = true
+	return log.NewLoggerWithWriter(opts, buf)
+}
+
+// logEntries 解析 buf 中的 JSON 日志行。(logEntries parses the JSON log lines in buf.)
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// TestRequestID tests reusing a valid incoming request ID, replacing an invalid one and extracting the trace ID.
+// (TestRequestID 测试复用合法的请求 ID、替换非法的请求 ID 以及提取 trace ID。)
+func TestRequestID(t *testing.T) {
+	var gotID, gotTrace string
+	handler := httpx.RequestID(
+		httpx.WithRequestIDGenerator(func() string { return "generated" }),
+		httpx.WithTraceIDHeader(true),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = httpx.RequestIDFromRequest(r)
+		gotTrace, _ = log.TraceIDFromContext(r.Context())
+		assert.Equal(t, gotID, r.Header.Get(httpx.RequestIDHeader), "the request header carries the ID downstream")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(httpx.RequestIDHeader, "client-id-1")
+	req.Header.Set(httpx.TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "client-id-1", gotID)
+	assert.Equal(t, "client-id-1", rec.Header().Get(httpx.RequestIDHeader))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", gotTrace)
+	assert.Equal(t, gotTrace, rec.Header().Get(httpx.TraceIDHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(httpx.RequestIDHeader, "bad\nid")
+	req.Header.Set(httpx.TraceParentHeader, "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "generated", gotID, "IDs with control characters are replaced")
+	assert.Equal(t, "generated", rec.Header().Get(httpx.RequestIDHeader))
+	assert.Len(t, gotTrace, 32, "a trace ID is generated for an invalid traceparent")
+	assert.NotEqual(t, strings.Repeat("0", 32), gotTrace)
+}
+
+// TestLogging tests the access log entry, its level and the request-scoped logger.
+// (TestLogging 测试访问日志条目、日志级别以及请求级 Logger。)
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		log.FromContext(r.Context()).Infow("handling")
+		_, _ = w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		lmccerrors.WriteHTTPError(w, lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "order 7"))
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+	handler := httpx.Chain(
+		httpx.RequestID(httpx.WithRequestIDGenerator(func() string { return "req-1" })),
+		httpx.Logging(httpx.WithLogger(newTestLogger(&buf)), httpx.WithSkipPaths("/healthz")),
+	)(mux)
+
+	for _, path := range []string{"/ok", "/missing", "/healthz"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	entries := logEntries(t, &buf)
+	require.Len(t, entries, 3, "the skipped path is not access-logged")
+
+	assert.Equal(t, "handling", entries[0]["M"])
+	assert.Equal(t, "req-1", entries[0]["request_id"], "the request-scoped logger carries the request ID")
+	assert.NotEmpty(t, entries[0]["trace_id"])
+
+	assert.Equal(t, "HTTP request", entries[1]["M"])
+	assert.Equal(t, "INFO", entries[1]["L"])
+	assert.Equal(t, "/ok", entries[1]["path"])
+	assert.EqualValues(t, http.StatusOK, entries[1]["status"])
+	assert.EqualValues(t, 5, entries[1]["bytes"])
+	assert.Equal(t, "req-1", entries[1]["request_id"])
+
+	assert.Equal(t, "WARN", entries[2]["L"])
+	assert.EqualValues(t, http.StatusNotFound, entries[2]["status"])
+}
+
+// TestContextLogger tests that handlers get a request-scoped logger and no access log is written.
+// (TestContextLogger 测试处理器获得请求级 Logger，并且不写访问日志。)
+func TestContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := httpx.Chain(
+		httpx.RequestID(httpx.WithRequestIDGenerator(func() string { return "req-2" })),
+		httpx.ContextLogger(httpx.WithLogger(newTestLogger(&buf))),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.FromContext(r.Context()).Infow("handling")
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+	entries := logEntries(t, &buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "handling", entries[0]["M"])
+	assert.Equal(t, "req-2", entries[0]["request_id"])
+	assert.NotEmpty(t, entries[0]["trace_id"])
+	assert.Equal(t, http.MethodPost, entries[0]["method"])
+	assert.Equal(t, "/orders", entries[0]["path"])
+}
+
+// TestRecovery tests that a panic is logged and answered with an ErrPanic JSON response carrying the request ID.
+// (TestRecovery 测试 panic 被记录，并以携带请求 ID 的 ErrPanic JSON 响应作答。)
+func TestRecovery(t *testing.T) {
+	var buf bytes.Buffer
+	handler := httpx.Default(
+		httpx.WithLogger(newTestLogger(&buf)),
+		httpx.WithRequestIDGenerator(func() string { return "req-panic" }),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body lmccerrors.HTTPErrorBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, lmccerrors.ErrPanic.Code(), body.Code)
+	assert.Equal(t, "req-panic", body.RequestID)
+	assert.NotContains(t, rec.Body.String(), "boom", "the panic value is not exposed to clients")
+
+	entries := logEntries(t, &buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Recovered from panic", entries[0]["M"])
+	assert.Contains(t, entries[0]["error"], "panic: boom")
+	assert.Contains(t, entries[0]["stack"], "httpx_test.go", "the stack starts at the panicking frame")
+	assert.Equal(t, "req-panic", entries[0]["request_id"])
+	assert.Equal(t, "ERROR", entries[1]["L"], "the access log records the 500 response")
+	assert.EqualValues(t, http.StatusInternalServerError, entries[1]["status"])
+
+	// 已写出响应头后不再改写响应 (A response whose header was already written is not rewritten)
+	handler = httpx.Recovery(httpx.WithLogger(newTestLogger(&bytes.Buffer{})))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("late")
+	}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		httpx.Recovery()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+// TestWrapResponseWriter tests capturing status and size and sharing one wrapper across middlewares.
+// (TestWrapResponseWriter 测试记录状态码和大小，以及多个中间件共享同一个包装器。)
+func TestWrapResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rw := httpx.WrapResponseWriter(rec)
+	assert.Same(t, rw, httpx.WrapResponseWriter(rw))
+	assert.False(t, rw.Written())
+
+	rw.WriteHeader(http.StatusCreated)
+	_, _ = rw.Write([]byte("abc"))
+	_, _ = rw.Write([]byte("de"))
+	assert.Equal(t, http.StatusCreated, rw.Status())
+	assert.EqualValues(t, 5, rw.Size())
+
+	require.NoError(t, http.NewResponseController(rw).Flush(), "the controller reaches the underlying writer")
+	assert.True(t, rec.Flushed)
+}
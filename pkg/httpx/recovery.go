@@ -0,0 +1,58 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// Recovery 返回恢复处理器 panic 的中间件。panic 被转换为带 ErrPanic 的错误并连同堆栈记录到请求级 Logger，
+// 尚未写出响应头时再通过 errors.WriteHTTPError 写出 500 JSON 响应。http.ErrAbortHandler 会继续向上传播，以便 net/http 中止连接。
+// (Recovery returns middleware that recovers panics in handlers. The panic is converted into an error coded ErrPanic and
+// logged with its stack on the request-scoped Logger; when the header has not been written yet a 500 JSON response is written
+// through errors.WriteHTTPError. http.ErrAbortHandler is re-raised so that net/http aborts the connection.)
+func Recovery(options ...Option) Middleware {
+	s := newSettings(options)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := WrapResponseWriter(w)
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+
+				err := lmccerrors.WrapPanic(p)
+				logger := log.FromContext(r.Context())
+				if s.logger != nil {
+					logger = requestLogger(s.logger, r)
+				}
+				logger.Errorw("Recovered from panic",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"stack", fmt.Sprintf("%+v", err),
+				)
+
+				if rw.Written() {
+					// 响应已部分写出，无法再改写状态码 (The response is partly written; the status can no longer change)
+					return
+				}
+				lmccerrors.WriteHTTPError(rw, err)
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package httpx
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// RequestIDHeader 是携带请求 ID 的请求头和响应头。(RequestIDHeader is the request and response header carrying the request ID.)
+	RequestIDHeader = lmccerrors.RequestIDHeader
+	// TraceIDHeader 是 WithTraceIDHeader 启用时回写 trace ID 的响应头。
+	// (TraceIDHeader is the response header echoing the trace ID when WithTraceIDHeader is enabled.)
+	TraceIDHeader = "X-Trace-ID"
+	// TraceParentHeader 是 W3C Trace Context 的 traceparent 请求头。(TraceParentHeader is the W3C Trace Context traceparent header.)
+	TraceParentHeader = "traceparent"
+)
+
+// maxRequestIDLength 是接受的请求 ID 的最大长度。(maxRequestIDLength is the maximum length of an accepted request ID.)
+const maxRequestIDLength = 128
+
+// RequestID 返回为每个请求确定请求 ID 和 trace ID 的中间件。
+// 合法的 X-Request-ID 请求头会被复用，否则生成新的 ID；请求 ID 写回请求头和响应头，
+// 并与 trace ID 一起通过 log.ContextWithRequestID 和 log.ContextWithTraceID 写入请求 context。
+// (RequestID returns middleware that determines the request ID and trace ID of every request.
+// A valid X-Request-ID header is reused and a new ID is generated otherwise; the request ID is set on the request and
+// response headers and, with the trace ID, stored in the request context through log.ContextWithRequestID and log.ContextWithTraceID.)
+func RequestID(options ...Option) Middleware {
+	s := newSettings(options)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = s.generateID()
+				r.Header.Set(RequestIDHeader, id)
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			traceID := traceIDFromRequest(r)
+			if s.exposeTrace {
+				w.Header().Set(TraceIDHeader, traceID)
+			}
+
+			ctx := log.ContextWithRequestID(r.Context(), id)
+			ctx = log.ContextWithTraceID(ctx, traceID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequestIDFromRequest 返回 RequestID 中间件为请求确定的请求 ID。
+// (RequestIDFromRequest returns the request ID the RequestID middleware determined for the request.)
+func RequestIDFromRequest(r *http.Request) string {
+	id, _ := log.RequestIDFromContext(r.Context())
+	return id
+}
+
+// validRequestID 报告传入的请求 ID 是否可以复用：非空、长度有限且只含可打印 ASCII 字符，避免日志注入。
+// (validRequestID reports whether an incoming request ID may be reused: non-empty, of bounded length and made of
+// printable ASCII only, to prevent log injection.)
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// traceIDFromRequest 依次从 OpenTelemetry span 和 traceparent 请求头中获取 trace ID，都没有时生成新的值。
+// (traceIDFromRequest takes the trace ID from the OpenTelemetry span, then from the traceparent header, and generates one otherwise.)
+func traceIDFromRequest(r *http.Request) string {
+	if sc := oteltrace.SpanContextFromContext(r.Context()); sc.IsValid() {
+		return sc.TraceID().String()
+	}
+	if traceID, ok := parseTraceParent(r.Header.Get(TraceParentHeader)); ok {
+		return traceID
+	}
+	return newTraceID()
+}
+
+// parseTraceParent 从 "version-traceid-parentid-flags" 格式的 traceparent 中取出 trace ID。
+// (parseTraceParent extracts the trace ID from a traceparent of the form "version-traceid-parentid-flags".)
+func parseTraceParent(header string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", false
+	}
+	traceID, err := oteltrace.TraceIDFromHex(parts[1])
+	if err != nil {
+		return "", false
+	}
+	return traceID.String(), true
+}
+
+// newRequestID 生成 UUID v4 请求 ID。(newRequestID generates a UUID v4 request ID.)
+func newRequestID() string {
+	return uuid.NewString()
+}
+
+// newTraceID 生成 W3C 格式的 32 位十六进制 trace ID。(newTraceID generates a 32-digit hex trace ID in W3C format.)
+func newTraceID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,104 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package httpx
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ResponseWriter 是记录状态码和响应体大小的 http.ResponseWriter。
+// (ResponseWriter is an http.ResponseWriter that records the status code and body size.)
+type ResponseWriter interface {
+	http.ResponseWriter
+
+	// Status 返回已写出的状态码，尚未写出响应头时返回 0。
+	// (Status returns the status code written, or 0 when the header has not been written yet.)
+	Status() int
+	// Size 返回已写出的响应体字节数。(Size returns the number of body bytes written.)
+	Size() int64
+	// Written 报告响应头是否已写出。(Written reports whether the header has been written.)
+	Written() bool
+}
+
+// WrapResponseWriter 包装 w 以记录状态码和响应体大小；w 已是 ResponseWriter 时原样返回，因此多个中间件共享同一份记录。
+// (WrapResponseWriter wraps w to record the status code and body size. When w already is a ResponseWriter it is
+// returned as is, so several middlewares share the same record.)
+func WrapResponseWriter(w http.ResponseWriter) ResponseWriter {
+	if rw, ok := w.(ResponseWriter); ok {
+		return rw
+	}
+	return &responseWriter{ResponseWriter: w}
+}
+
+// responseWriter 是 ResponseWriter 的默认实现。(responseWriter is the default implementation of ResponseWriter.)
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	// 1xx 信息响应之后还会有最终响应 (A final response still follows 1xx informational responses)
+	if w.status == 0 && (status < 100 || status >= 200) {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Status() int {
+	return w.status
+}
+
+func (w *responseWriter) Size() int64 {
+	return w.size
+}
+
+func (w *responseWriter) Written() bool {
+	return w.status != 0
+}
+
+// Flush 转发给底层的 http.Flusher。(Flush forwards to the underlying http.Flusher.)
+func (w *responseWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack 转发给底层的 http.Hijacker，供 WebSocket 等协议升级使用。
+// (Hijack forwards to the underlying http.Hijacker, for protocol upgrades such as WebSocket.)
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("httpx: %T does not implement http.Hijacker", w.ResponseWriter)
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap 让 http.ResponseController 访问底层 ResponseWriter。
+// (Unwrap lets http.ResponseController reach the underlying ResponseWriter.)
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,322 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Message 是一条可翻译消息，Other 为必填，其余复数类别可选。
+// (Message is a translatable message; Other is required, the remaining plural categories are optional.)
+type Message struct {
+	Zero  string `json:"zero,omitempty" yaml:"zero,omitempty"`
+	One   string `json:"one,omitempty" yaml:"one,omitempty"`
+	Two   string `json:"two,omitempty" yaml:"two,omitempty"`
+	Few   string `json:"few,omitempty" yaml:"few,omitempty"`
+	Many  string `json:"many,omitempty" yaml:"many,omitempty"`
+	Other string `json:"other" yaml:"other"`
+}
+
+// form 返回指定复数类别的文本，缺失时回退到 Other。
+// (form returns the text of the given plural category, falling back to Other when missing.)
+func (m Message) form(f PluralForm) string {
+	var s string
+	switch f {
+	case Zero:
+		s = m.Zero
+	case One:
+		s = m.One
+	case Two:
+		s = m.Two
+	case Few:
+		s = m.Few
+	case Many:
+		s = m.Many
+	}
+	if s == "" {
+		return m.Other
+	}
+	return s
+}
+
+// Catalog 保存多种语言的消息，并在查找时按 请求语言 -> 基础语言 -> 默认语言 的顺序回退。
+// (Catalog holds messages for multiple languages and falls back request language -> base language -> default language on lookup.)
+type Catalog struct {
+	mu          sync.RWMutex
+	defaultLang language.Tag
+	messages    map[language.Tag]map[string]Message
+	tags        []language.Tag
+	supported   []language.Tag
+	matcher     language.Matcher
+}
+
+// NewCatalog 创建以 defaultLang 为默认语言的消息目录。
+// (NewCatalog creates a message catalog whose default language is defaultLang.)
+func NewCatalog(defaultLang language.Tag) *Catalog {
+	return &Catalog{
+		defaultLang: defaultLang,
+		messages:    make(map[language.Tag]map[string]Message),
+	}
+}
+
+// DefaultLanguage 返回默认语言。(DefaultLanguage returns the default language.)
+func (c *Catalog) DefaultLanguage() language.Tag {
+	return c.defaultLang
+}
+
+// Languages 返回目录中已有消息的语言，默认语言排在首位。
+// (Languages returns the languages that have messages in the catalog, with the default language first.)
+func (c *Catalog) Languages() []language.Tag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]language.Tag(nil), c.tags...)
+}
+
+// Set 为语言添加或替换一条简单消息。
+// (Set adds or replaces a simple message for a language.)
+func (c *Catalog) Set(tag language.Tag, key, text string) {
+	c.SetMessage(tag, key, Message{Other: text})
+}
+
+// SetMessage 为语言添加或替换一条消息（可包含复数形式）。
+// (SetMessage adds or replaces a message, possibly with plural forms, for a language.)
+func (c *Catalog) SetMessage(tag language.Tag, key string, msg Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	msgs, ok := c.messages[tag]
+	if !ok {
+		msgs = make(map[string]Message)
+		c.messages[tag] = msgs
+		c.rebuildMatcherLocked(tag)
+	}
+	msgs[key] = msg
+}
+
+// rebuildMatcherLocked 在新增语言后重建语言匹配器，调用方需持有写锁。
+// (rebuildMatcherLocked rebuilds the language matcher after a language is added; the caller must hold the write lock.)
+func (c *Catalog) rebuildMatcherLocked(added language.Tag) {
+	if added == c.defaultLang {
+		c.tags = append([]language.Tag{added}, c.tags...)
+	} else {
+		c.tags = append(c.tags, added)
+	}
+	// 匹配器把第一个语言作为无匹配时的结果 (The matcher returns the first tag when nothing matches)
+	supported := c.tags
+	if len(supported) == 0 || supported[0] != c.defaultLang {
+		supported = append([]language.Tag{c.defaultLang}, supported...)
+	}
+	c.supported = supported
+	c.matcher = language.NewMatcher(supported)
+}
+
+// Load 从 JSON 或 YAML 数据中加载一种语言的消息。
+// 值可以是字符串，也可以是包含复数类别（zero/one/two/few/many/other）的对象。
+// (Load loads the messages of one language from JSON or YAML data.)
+// (A value is either a string or an object with plural categories (zero/one/two/few/many/other).)
+func (c *Catalog) Load(tag language.Tag, data []byte, format string) error {
+	raw := make(map[string]any)
+	switch strings.ToLower(format) {
+	case "json":
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("failed to parse %s catalog: %w", tag, err)
+		}
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("failed to parse %s catalog: %w", tag, err)
+		}
+	default:
+		return fmt.Errorf("unsupported catalog format '%s'", format)
+	}
+
+	flat := make(map[string]Message)
+	if err := flatten("", raw, flat); err != nil {
+		return fmt.Errorf("invalid %s catalog: %w", tag, err)
+	}
+	for key, msg := range flat {
+		c.SetMessage(tag, key, msg)
+	}
+	return nil
+}
+
+// LoadFS 加载 dir 目录下所有以语言标签命名的 .json/.yaml/.yml 文件，例如 en.json、zh-CN.yaml。
+// 通常与 embed.FS 一起使用，将消息目录编译进二进制文件。
+// (LoadFS loads every .json/.yaml/.yml file named after a language tag in dir, e.g. en.json or zh-CN.yaml.)
+// (It is typically used with embed.FS to compile catalogs into the binary.)
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("failed to read catalog directory '%s': %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := strings.TrimPrefix(path.Ext(name), ".")
+		if ext != "json" && ext != "yaml" && ext != "yml" {
+			continue
+		}
+		tag, err := language.Parse(strings.TrimSuffix(name, path.Ext(name)))
+		if err != nil {
+			return fmt.Errorf("catalog file '%s' is not named after a language tag: %w", name, err)
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read catalog file '%s': %w", name, err)
+		}
+		if err := c.Load(tag, data, ext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flatten 将嵌套对象展开为以点号分隔的键，识别复数对象。
+// (flatten expands nested objects into dot-separated keys, recognising plural objects.)
+func flatten(prefix string, raw map[string]any, out map[string]Message) error {
+	for k, v := range raw {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case string:
+			out[key] = Message{Other: val}
+		case map[string]any:
+			if msg, ok := asPluralMessage(val); ok {
+				out[key] = msg
+				continue
+			}
+			if err := flatten(key, val, out); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported value for key '%s'", key)
+		}
+	}
+	return nil
+}
+
+// asPluralMessage 判断对象是否为复数消息（仅包含复数类别键且含 other）。
+// (asPluralMessage reports whether the object is a plural message: only plural category keys, including other.)
+func asPluralMessage(obj map[string]any) (Message, bool) {
+	if _, ok := obj[string(Other)].(string); !ok {
+		return Message{}, false
+	}
+	var msg Message
+	for k, v := range obj {
+		s, ok := v.(string)
+		if !ok {
+			return Message{}, false
+		}
+		switch PluralForm(k) {
+		case Zero:
+			msg.Zero = s
+		case One:
+			msg.One = s
+		case Two:
+			msg.Two = s
+		case Few:
+			msg.Few = s
+		case Many:
+			msg.Many = s
+		case Other:
+			msg.Other = s
+		default:
+			return Message{}, false
+		}
+	}
+	return msg, true
+}
+
+// lookup 按回退顺序查找消息，返回找到消息时使用的语言。
+// (lookup finds a message following the fallback order and returns the language it was found in.)
+func (c *Catalog) lookup(tag language.Tag, key string) (Message, language.Tag, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for t := tag; ; t = t.Parent() {
+		if msg, ok := c.messages[t][key]; ok {
+			return msg, t, true
+		}
+		if t.IsRoot() {
+			break
+		}
+	}
+	if msg, ok := c.messages[c.defaultLang][key]; ok {
+		return msg, c.defaultLang, true
+	}
+	return Message{}, tag, false
+}
+
+// Has 判断键在指定语言（含回退）中是否存在。
+// (Has reports whether the key exists for the language, including fallbacks.)
+func (c *Catalog) Has(tag language.Tag, key string) bool {
+	_, _, ok := c.lookup(tag, key)
+	return ok
+}
+
+// T 翻译消息，keysAndValues 以键值对形式提供占位符，例如 T(tag, "greeting", "name", "Bob") 会替换 {name}。
+// 消息不存在时返回键本身。
+// (T translates a message; keysAndValues supply placeholders as pairs, e.g. T(tag, "greeting", "name", "Bob") replaces {name}.)
+// (The key itself is returned when the message does not exist.)
+func (c *Catalog) T(tag language.Tag, key string, keysAndValues ...any) string {
+	msg, _, ok := c.lookup(tag, key)
+	if !ok {
+		return key
+	}
+	return interpolate(msg.Other, keysAndValues)
+}
+
+// Plural 按 count 选择复数形式并翻译，{count} 会被替换为数量。
+// (Plural translates a message choosing the plural form by count; {count} is replaced with the count.)
+func (c *Catalog) Plural(tag language.Tag, key string, count int, keysAndValues ...any) string {
+	msg, found, ok := c.lookup(tag, key)
+	if !ok {
+		return key
+	}
+	text := msg.form(PluralFormOf(found, count))
+	return interpolate(text, append([]any{"count", count}, keysAndValues...))
+}
+
+// Match 返回目录中与 preferred 最匹配的语言，无匹配时返回默认语言。
+// (Match returns the catalog language that best matches preferred, or the default language when nothing matches.)
+func (c *Catalog) Match(preferred ...language.Tag) language.Tag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.matcher == nil || len(preferred) == 0 {
+		return c.defaultLang
+	}
+	_, idx, conf := c.matcher.Match(preferred...)
+	if conf == language.No {
+		return c.defaultLang
+	}
+	return c.supported[idx]
+}
+
+// interpolate 将 {key} 占位符替换为对应的值。
+// (interpolate replaces {key} placeholders with the corresponding values.)
+func interpolate(text string, keysAndValues []any) string {
+	if len(keysAndValues) < 2 || !strings.Contains(text, "{") {
+		return text
+	}
+	pairs := make([]string, 0, len(keysAndValues))
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		pairs = append(pairs, "{"+fmt.Sprint(keysAndValues[i])+"}", fmt.Sprint(keysAndValues[i+1]))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
@@ -0,0 +1,123 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for catalogs, pluralization and language negotiation.
+ */
+
+package i18n_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+// testFS is an in-memory locale directory.
+// (testFS 是内存中的语言目录。)
+var testFS = fstest.MapFS{
+	"locales/en.json": {Data: []byte(`{
+		"greeting": "Hello, {name}!",
+		"cart": {"items": {"one": "{count} item", "other": "{count} items"}},
+		"only_en": "English only"
+	}`)},
+	"locales/zh-CN.yaml": {Data: []byte("greeting: \"你好，{name}！\"\ncart:\n  items:\n    other: \"{count} 件商品\"\n")},
+	"locales/ru.yml":     {Data: []byte("cart:\n  items:\n    one: \"{count} товар\"\n    few: \"{count} товара\"\n    many: \"{count} товаров\"\n    other: \"{count} товара\"\n")},
+	"locales/README.md":  {Data: []byte("ignored")},
+}
+
+// newTestCatalog loads testFS into a new catalog.
+// (newTestCatalog 将 testFS 加载到新目录中。)
+func newTestCatalog(t *testing.T) *i18n.Catalog {
+	t.Helper()
+	c := i18n.NewCatalog(language.English)
+	require.NoError(t, c.LoadFS(testFS, "locales"))
+	return c
+}
+
+// TestCatalogTranslate tests translation, interpolation and fallback.
+// (TestCatalogTranslate 测试翻译、占位符替换和回退。)
+func TestCatalogTranslate(t *testing.T) {
+	c := newTestCatalog(t)
+	zh := language.MustParse("zh-CN")
+
+	assert.Equal(t, "Hello, Bob!", c.T(language.English, "greeting", "name", "Bob"))
+	assert.Equal(t, "你好，Bob！", c.T(zh, "greeting", "name", "Bob"))
+	assert.Equal(t, "English only", c.T(zh, "only_en"), "falls back to the default language")
+	assert.Equal(t, "Hello, Bob!", c.T(language.BritishEnglish, "greeting", "name", "Bob"), "falls back to the parent language")
+	assert.Equal(t, "missing.key", c.T(zh, "missing.key"), "unknown keys are returned as-is")
+	assert.True(t, c.Has(zh, "cart.items"))
+}
+
+// TestCatalogPlural tests plural form selection.
+// (TestCatalogPlural 测试复数形式的选择。)
+func TestCatalogPlural(t *testing.T) {
+	c := newTestCatalog(t)
+	ru := language.Russian
+
+	assert.Equal(t, "1 item", c.Plural(language.English, "cart.items", 1))
+	assert.Equal(t, "3 items", c.Plural(language.English, "cart.items", 3))
+	assert.Equal(t, "1 件商品", c.Plural(language.MustParse("zh-CN"), "cart.items", 1))
+	assert.Equal(t, "21 товар", c.Plural(ru, "cart.items", 21))
+	assert.Equal(t, "3 товара", c.Plural(ru, "cart.items", 3))
+	assert.Equal(t, "11 товаров", c.Plural(ru, "cart.items", 11))
+}
+
+// TestPluralFormOf tests plural rules for several languages.
+// (TestPluralFormOf 测试多种语言的复数规则。)
+func TestPluralFormOf(t *testing.T) {
+	assert.Equal(t, i18n.One, i18n.PluralFormOf(language.French, 0))
+	assert.Equal(t, i18n.Other, i18n.PluralFormOf(language.English, 0))
+	assert.Equal(t, i18n.Other, i18n.PluralFormOf(language.Japanese, 1))
+	assert.Equal(t, i18n.Few, i18n.PluralFormOf(language.Polish, 22))
+	assert.Equal(t, i18n.Many, i18n.PluralFormOf(language.Polish, 25))
+	assert.Equal(t, i18n.Two, i18n.PluralFormOf(language.Arabic, 2))
+	assert.Equal(t, i18n.Many, i18n.PluralFormOf(language.Arabic, 11))
+
+	i18n.RegisterPluralRule("eo", func(int) i18n.PluralForm { return i18n.Few })
+	assert.Equal(t, i18n.Few, i18n.PluralFormOf(language.MustParse("eo"), 7))
+}
+
+// TestNegotiate tests Accept-Language negotiation.
+// (TestNegotiate 测试 Accept-Language 协商。)
+func TestNegotiate(t *testing.T) {
+	c := newTestCatalog(t)
+
+	assert.Equal(t, "zh-CN", c.Negotiate("zh-CN,zh;q=0.9,en;q=0.8").String())
+	assert.Equal(t, "ru", c.Negotiate("de-DE, ru;q=0.5").String())
+	assert.Equal(t, "en", c.Negotiate("ja").String(), "unsupported languages fall back to the default")
+	assert.Equal(t, "en", c.Negotiate("").String())
+	assert.Equal(t, "en", c.Negotiate("!!!").String())
+}
+
+// TestMiddleware tests that the middleware stores the negotiated language in the context.
+// (TestMiddleware 测试中间件将协商后的语言写入 context。)
+func TestMiddleware(t *testing.T) {
+	c := newTestCatalog(t)
+	h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(c.LocalizerFromContext(r.Context()).T("greeting", "name", "Ann")))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "zh-CN")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, "你好，Ann！", rec.Body.String())
+	assert.Equal(t, "zh-CN", rec.Header().Get("Content-Language"))
+
+	req = httptest.NewRequest(http.MethodGet, "/?lang=en", nil)
+	req.Header.Set("Accept-Language", "zh-CN")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, "Hello, Ann!", rec.Body.String(), "query parameter wins over the header")
+
+	l := c.LocalizerFromContext(context.Background())
+	assert.Equal(t, language.English, l.Language())
+}
@@ -0,0 +1,46 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package i18n provides a lightweight message catalog with language negotiation and
+pluralization, shared by error localization and API response messages.
+(i18n 包提供了一个轻量的消息目录，支持语言协商和复数形式，供错误本地化和 API 响应消息共用。)
+
+Catalog files are named after a language tag and may be JSON or YAML. Nested objects
+become dot-separated keys; an object made only of plural categories is a plural message.
+(目录文件以语言标签命名，可以是 JSON 或 YAML。嵌套对象会展开为点号分隔的键；
+仅由复数类别组成的对象被视为复数消息。)
+
+	# locales/en.yaml
+	greeting: "Hello, {name}!"
+	cart:
+	  items:
+	    one: "{count} item"
+	    other: "{count} items"
+
+Usage:
+(用法：)
+
+	//go:embed locales
+	var locales embed.FS
+
+	catalog := i18n.NewCatalog(language.English)
+	if err := catalog.LoadFS(locales, "locales"); err != nil {
+		// handle error (处理错误)
+	}
+
+	handler = catalog.Middleware(handler) // negotiates from ?lang= or Accept-Language (根据 ?lang= 或 Accept-Language 协商)
+
+	// In a handler (在处理器中)
+	l := catalog.LocalizerFromContext(r.Context())
+	l.T("greeting", "name", user.Name)
+	l.Plural("cart.items", len(items))
+
+Lookups fall back from the requested language to its parents and finally to the
+default language; a missing key is returned unchanged.
+(查找会从请求的语言回退到其父语言，最后回退到默认语言；缺失的键会原样返回。)
+*/
+package i18n
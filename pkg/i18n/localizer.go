@@ -0,0 +1,105 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package i18n
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// contextKey 是本包在 context 中使用的私有键类型。
+// (contextKey is the private key type used by this package in contexts.)
+type contextKey int
+
+// languageKey 是语言在 context 中的键。(languageKey is the context key for the language.)
+const languageKey contextKey = iota
+
+// ContextWithLanguage 返回携带语言的新 context。
+// (ContextWithLanguage returns a new context carrying the language.)
+func ContextWithLanguage(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, languageKey, tag)
+}
+
+// LanguageFromContext 从 context 中读取语言。
+// (LanguageFromContext reads the language from the context.)
+func LanguageFromContext(ctx context.Context) (language.Tag, bool) {
+	if ctx == nil {
+		return language.Und, false
+	}
+	tag, ok := ctx.Value(languageKey).(language.Tag)
+	return tag, ok
+}
+
+// Negotiate 根据 Accept-Language 头选择目录中最合适的语言，头为空或无法解析时返回默认语言。
+// (Negotiate picks the best catalog language for an Accept-Language header; the default language is returned when it is empty or unparsable.)
+func (c *Catalog) Negotiate(acceptLanguage string) language.Tag {
+	if acceptLanguage == "" {
+		return c.defaultLang
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return c.defaultLang
+	}
+	return c.Match(tags...)
+}
+
+// Localizer 是绑定到某一语言的翻译器。
+// (Localizer is a translator bound to a single language.)
+type Localizer struct {
+	catalog *Catalog
+	tag     language.Tag
+}
+
+// Localizer 返回绑定到 tag 的翻译器。
+// (Localizer returns a translator bound to tag.)
+func (c *Catalog) Localizer(tag language.Tag) *Localizer {
+	return &Localizer{catalog: c, tag: tag}
+}
+
+// LocalizerFromContext 返回绑定到 context 中语言的翻译器，context 中没有语言时使用默认语言。
+// (LocalizerFromContext returns a translator bound to the context language, or the default language when none is set.)
+func (c *Catalog) LocalizerFromContext(ctx context.Context) *Localizer {
+	tag, ok := LanguageFromContext(ctx)
+	if !ok {
+		tag = c.defaultLang
+	}
+	return c.Localizer(tag)
+}
+
+// Language 返回翻译器绑定的语言。(Language returns the language of the translator.)
+func (l *Localizer) Language() language.Tag {
+	return l.tag
+}
+
+// T 翻译消息，参见 Catalog.T。(T translates a message, see Catalog.T.)
+func (l *Localizer) T(key string, keysAndValues ...any) string {
+	return l.catalog.T(l.tag, key, keysAndValues...)
+}
+
+// Plural 按数量翻译消息，参见 Catalog.Plural。(Plural translates a message by count, see Catalog.Plural.)
+func (l *Localizer) Plural(key string, count int, keysAndValues ...any) string {
+	return l.catalog.Plural(l.tag, key, count, keysAndValues...)
+}
+
+// Middleware 返回 net/http 中间件，根据 "lang" 查询参数或 Accept-Language 头协商语言并写入请求 context，
+// 同时设置 Content-Language 响应头。
+// (Middleware returns net/http middleware that negotiates the language from the "lang" query parameter or the
+// Accept-Language header, stores it in the request context and sets the Content-Language response header.)
+func (c *Catalog) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tag language.Tag
+		if q := r.URL.Query().Get("lang"); q != "" {
+			tag = c.Negotiate(q)
+		} else {
+			tag = c.Negotiate(r.Header.Get("Accept-Language"))
+		}
+		w.Header().Set("Content-Language", tag.String())
+		next.ServeHTTP(w, r.WithContext(ContextWithLanguage(r.Context(), tag)))
+	})
+}
@@ -0,0 +1,156 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package i18n
+
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// PluralForm 是 CLDR 定义的复数类别。
+// (PluralForm is a plural category as defined by CLDR.)
+type PluralForm string
+
+const (
+	// Zero 复数类别 "zero"。(Plural category "zero".)
+	Zero PluralForm = "zero"
+	// One 复数类别 "one"。(Plural category "one".)
+	One PluralForm = "one"
+	// Two 复数类别 "two"。(Plural category "two".)
+	Two PluralForm = "two"
+	// Few 复数类别 "few"。(Plural category "few".)
+	Few PluralForm = "few"
+	// Many 复数类别 "many"。(Plural category "many".)
+	Many PluralForm = "many"
+	// Other 复数类别 "other"，所有语言都必须提供。(Plural category "other", required for every language.)
+	Other PluralForm = "other"
+)
+
+// PluralRule 根据整数数量返回复数类别。
+// (PluralRule returns the plural category for an integer count.)
+type PluralRule func(n int) PluralForm
+
+// pluralMu 保护 pluralRules。(pluralMu guards pluralRules.)
+var pluralMu sync.RWMutex
+
+// pluralRules 是按基础语言划分的整数复数规则，覆盖常用语言；未列出的语言使用 ruleOneOther。
+// (pluralRules are integer plural rules keyed by base language covering common languages; unlisted languages use ruleOneOther.)
+var pluralRules = map[string]PluralRule{
+	// 无复数变化 (No plural inflection)
+	"zh": ruleOtherOnly, "ja": ruleOtherOnly, "ko": ruleOtherOnly, "vi": ruleOtherOnly,
+	"th": ruleOtherOnly, "id": ruleOtherOnly, "ms": ruleOtherOnly,
+	// 0 和 1 都归为 one (Both 0 and 1 are "one")
+	"fr": ruleZeroIsOne, "hi": ruleZeroIsOne, "bn": ruleZeroIsOne,
+	// 东斯拉夫语系 (East Slavic)
+	"ru": ruleEastSlavic, "uk": ruleEastSlavic, "be": ruleEastSlavic,
+	"pl": rulePolish,
+	"cs": ruleCzech, "sk": ruleCzech,
+	"ar": ruleArabic,
+}
+
+// RegisterPluralRule 为基础语言注册或覆盖复数规则，例如 "lt"。
+// (RegisterPluralRule registers or overrides the plural rule of a base language, e.g. "lt".)
+func RegisterPluralRule(baseLanguage string, rule PluralRule) {
+	pluralMu.Lock()
+	defer pluralMu.Unlock()
+	pluralRules[baseLanguage] = rule
+}
+
+// PluralFormOf 返回 count 在指定语言中的复数类别。
+// (PluralFormOf returns the plural category of count in the given language.)
+func PluralFormOf(tag language.Tag, count int) PluralForm {
+	base, _ := tag.Base()
+	pluralMu.RLock()
+	rule, ok := pluralRules[base.String()]
+	pluralMu.RUnlock()
+	if !ok {
+		rule = ruleOneOther
+	}
+	if count < 0 {
+		count = -count
+	}
+	return rule(count)
+}
+
+// ruleOtherOnly 用于没有复数变化的语言。(ruleOtherOnly is for languages without plural inflection.)
+func ruleOtherOnly(int) PluralForm {
+	return Other
+}
+
+// ruleOneOther 用于英语、德语等仅区分 1 与其他的语言。(ruleOneOther is for languages such as English or German that only distinguish 1.)
+func ruleOneOther(n int) PluralForm {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+// ruleZeroIsOne 用于法语等将 0 和 1 视为单数的语言。(ruleZeroIsOne is for languages such as French that treat 0 and 1 as singular.)
+func ruleZeroIsOne(n int) PluralForm {
+	if n == 0 || n == 1 {
+		return One
+	}
+	return Other
+}
+
+// ruleEastSlavic 用于俄语、乌克兰语和白俄罗斯语。(ruleEastSlavic is for Russian, Ukrainian and Belarusian.)
+func ruleEastSlavic(n int) PluralForm {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return One
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+// rulePolish 用于波兰语。(rulePolish is for Polish.)
+func rulePolish(n int) PluralForm {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case n == 1:
+		return One
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+// ruleCzech 用于捷克语和斯洛伐克语。(ruleCzech is for Czech and Slovak.)
+func ruleCzech(n int) PluralForm {
+	switch {
+	case n == 1:
+		return One
+	case n >= 2 && n <= 4:
+		return Few
+	default:
+		return Other
+	}
+}
+
+// ruleArabic 用于阿拉伯语。(ruleArabic is for Arabic.)
+func ruleArabic(n int) PluralForm {
+	mod100 := n % 100
+	switch {
+	case n == 0:
+		return Zero
+	case n == 1:
+		return One
+	case n == 2:
+		return Two
+	case mod100 >= 3 && mod100 <= 10:
+		return Few
+	case mod100 >= 11:
+		return Many
+	default:
+		return Other
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package lock provides local and distributed locking primitives behind a single Locker interface.
+(lock 包在统一的 Locker 接口下提供本地和分布式锁原语。)
+
+Implementations:
+(实现：)
+
+  - LocalLocker:       in-process mutex per key (进程内按键的互斥锁)
+  - FileLocker:        OS file locks shared by processes on one host (同一主机上进程间共享的操作系统文件锁)
+  - DistributedLocker: TTL leases on a Backend such as Redis or etcd, renewed automatically (基于 Redis、etcd 等 Backend 的 TTL 租约，自动续期)
+
+Lock waits at most AcquireTimeout and fails with ErrLockTimeout; TryLock fails with ErrLockHeld
+right away. Contention is logged: waiting starts at Debug level, acquisitions that waited longer
+than ContentionThreshold and timeouts are logged at Warn level, and lost leases at Error level.
+(Lock 最多等待 AcquireTimeout，超时返回 ErrLockTimeout；TryLock 在锁被占用时立即返回 ErrLockHeld。
+锁竞争会被记录：开始等待时为 Debug 级别，等待超过 ContentionThreshold 才获取到锁以及超时为 Warn 级别，
+租约丢失为 Error 级别。)
+
+Usage:
+(用法：)
+
+	locker, err := lock.NewDistributedLocker(redisBackend, opts)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	err = lock.With(ctx, locker, "jobs/nightly-report", func(ctx context.Context) error {
+		// ctx is cancelled if the lease is lost (租约丢失时 ctx 会被取消)
+		return runReport(ctx)
+	})
+
+Errors carry the ErrLockOptionInvalid, ErrLockHeld, ErrLockTimeout, ErrLockNotHeld or ErrLockBackend codes from pkg/errors.
+(错误携带 pkg/errors 中的 ErrLockOptionInvalid、ErrLockHeld、ErrLockTimeout、ErrLockNotHeld 或 ErrLockBackend 错误码。)
+*/
+package lock
@@ -0,0 +1,21 @@
+//go:build !unix && !windows
+
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package lock
+
+import (
+	"errors"
+	"os"
+)
+
+// errFileLockUnsupported 表示当前平台不支持文件锁。(errFileLockUnsupported means file locks are unsupported on this platform.)
+var errFileLockUnsupported = errors.New("file locks are not supported on this platform")
+
+func tryLockFile(*os.File) (bool, error) { return false, errFileLockUnsupported }
+
+func unlockFile(*os.File) error { return errFileLockUnsupported }
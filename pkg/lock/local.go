@@ -0,0 +1,124 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package lock
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// LocalLocker 是进程内按键的互斥锁，等待者在锁释放时立即被唤醒。
+// (LocalLocker is an in-process mutex per key; waiters are woken as soon as the lock is released.)
+type LocalLocker struct {
+	base
+	mu      sync.Mutex
+	entries map[string]*localEntry
+}
+
+// localEntry 是一个键的锁，ch 容量为 1，写入表示持有。refs 统计持有者和等待者，为 0 时删除条目。
+// (localEntry is the lock of one key; ch has capacity 1 and a send means holding it. refs counts holders and waiters, and the entry is deleted at 0.)
+type localEntry struct {
+	ch   chan struct{}
+	refs int
+}
+
+// NewLocalLocker 创建进程内锁。opts 为 nil 时使用默认选项。
+// (NewLocalLocker creates an in-process locker. Default options are used when opts is nil.)
+func NewLocalLocker(opts *Options, options ...LockerOption) (*LocalLocker, error) {
+	b, err := newBase(opts, options)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalLocker{base: b, entries: make(map[string]*localEntry)}, nil
+}
+
+// Lock 实现 Locker 接口。(Lock implements Locker.)
+func (l *LocalLocker) Lock(ctx context.Context, key string) (Lease, error) {
+	e := l.ref(key)
+	select {
+	case e.ch <- struct{}{}:
+		return l.lease(key, e), nil
+	default:
+	}
+
+	l.log().Debugw("Lock contended, waiting", "lock", key)
+	ctx, cancel := l.acquireContext(ctx)
+	defer cancel()
+	start := time.Now()
+	select {
+	case e.ch <- struct{}{}:
+		l.acquiredAfterContention(key, time.Since(start))
+		return l.lease(key, e), nil
+	case <-ctx.Done():
+		l.unref(key, e)
+		return nil, l.timedOut(ctx, key, time.Since(start))
+	}
+}
+
+// TryLock 实现 Locker 接口。(TryLock implements Locker.)
+func (l *LocalLocker) TryLock(_ context.Context, key string) (Lease, error) {
+	e := l.ref(key)
+	select {
+	case e.ch <- struct{}{}:
+		return l.lease(key, e), nil
+	default:
+		l.unref(key, e)
+		return nil, lmccerrors.ErrorfWithCode(lmccerrors.ErrLockHeld, "lock '%s' is held", key)
+	}
+}
+
+// ref 返回 key 的条目并增加引用计数。(ref returns the entry of key and increments its reference count.)
+func (l *LocalLocker) ref(key string) *localEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	if !ok {
+		e = &localEntry{ch: make(chan struct{}, 1)}
+		l.entries[key] = e
+	}
+	e.refs++
+	return e
+}
+
+// unref 减少引用计数，不再使用的条目会被删除。(unref decrements the reference count and deletes unused entries.)
+func (l *LocalLocker) unref(key string, e *localEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.refs--; e.refs == 0 {
+		delete(l.entries, key)
+	}
+}
+
+// lease 创建本地锁租约。(lease creates a local lock lease.)
+func (l *LocalLocker) lease(key string, e *localEntry) *localLease {
+	return &localLease{locker: l, key: key, entry: e}
+}
+
+// localLease 是 LocalLocker 的租约。(localLease is a lease of LocalLocker.)
+type localLease struct {
+	locker   *LocalLocker
+	key      string
+	entry    *localEntry
+	released atomic.Bool
+}
+
+func (l *localLease) Key() string { return l.key }
+
+func (l *localLease) Lost() <-chan struct{} { return neverLost }
+
+func (l *localLease) Release(context.Context) error {
+	if !l.released.CompareAndSwap(false, true) {
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrLockNotHeld, "lock '%s' already released", l.key)
+	}
+	<-l.entry.ch
+	l.locker.unref(l.key, l.entry)
+	return nil
+}
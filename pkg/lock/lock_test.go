@@ -0,0 +1,206 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the local, file and distributed lockers.
+ */
+
+package lock_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastOptions returns options with short intervals for tests.
+// (fastOptions 返回适合测试的短间隔选项。)
+func fastOptions() *lock.Options {
+	opts := lock.NewOptions()
+	opts.AcquireTimeout = 50 * time.Millisecond
+	opts.RetryInterval = 5 * time.Millisecond
+	opts.TTL = 60 * time.Millisecond
+	opts.RenewInterval = 10 * time.Millisecond
+	return opts
+}
+
+// testLocker runs the behaviour shared by every Locker implementation.
+// (testLocker 运行所有 Locker 实现共有的行为测试。)
+func testLocker(t *testing.T, locker lock.Locker) {
+	ctx := context.Background()
+	lease, err := locker.Lock(ctx, "jobs/a")
+	require.NoError(t, err)
+	assert.Equal(t, "jobs/a", lease.Key())
+
+	_, err = locker.TryLock(ctx, "jobs/a")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLockHeld))
+	_, err = locker.Lock(ctx, "jobs/a")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLockTimeout))
+
+	other, err := locker.TryLock(ctx, "jobs/b")
+	require.NoError(t, err, "other keys are independent")
+	require.NoError(t, other.Release(ctx))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, lease.Release(ctx))
+	}()
+	next, err := locker.Lock(ctx, "jobs/a")
+	require.NoError(t, err, "waiters acquire the lock once it is released")
+	require.NoError(t, next.Release(ctx))
+	assert.True(t, lmccerrors.IsCode(next.Release(ctx), lmccerrors.ErrLockNotHeld))
+}
+
+// TestLocalLocker tests the in-process locker.
+// (TestLocalLocker 测试进程内锁。)
+func TestLocalLocker(t *testing.T) {
+	locker, err := lock.NewLocalLocker(fastOptions())
+	require.NoError(t, err)
+	testLocker(t, locker)
+}
+
+// TestFileLocker tests the file locker, including exclusion between separate lockers.
+// (TestFileLocker 测试文件锁，包括不同锁实例之间的互斥。)
+func TestFileLocker(t *testing.T) {
+	dir := t.TempDir()
+	locker, err := lock.NewFileLocker(dir, fastOptions())
+	require.NoError(t, err)
+	testLocker(t, locker)
+
+	other, err := lock.NewFileLocker(dir, fastOptions())
+	require.NoError(t, err)
+	lease, err := locker.Lock(context.Background(), "shared")
+	require.NoError(t, err)
+	_, err = other.TryLock(context.Background(), "shared")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLockHeld))
+	require.NoError(t, lease.Release(context.Background()))
+	assert.FileExists(t, locker.Path("shared"))
+}
+
+// memoryBackend is an in-memory Backend with TTL expiry.
+// (memoryBackend 是带 TTL 过期的内存 Backend。)
+type memoryBackend struct {
+	mu   sync.Mutex
+	keys map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	token   string
+	expires time.Time
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{keys: map[string]memoryEntry{}}
+}
+
+func (b *memoryBackend) TryAcquire(_ context.Context, key, token string, ttl time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if e, ok := b.keys[key]; ok && time.Now().Before(e.expires) {
+		return false, nil
+	}
+	b.keys[key] = memoryEntry{token: token, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (b *memoryBackend) Refresh(_ context.Context, key, token string, ttl time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if e, ok := b.keys[key]; !ok || e.token != token || time.Now().After(e.expires) {
+		return false, nil
+	}
+	b.keys[key] = memoryEntry{token: token, expires: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (b *memoryBackend) Release(_ context.Context, key, token string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if e, ok := b.keys[key]; ok && e.token == token {
+		delete(b.keys, key)
+	}
+	return nil
+}
+
+// steal simulates another owner taking over key.
+// (steal 模拟其他持有者接管 key。)
+func (b *memoryBackend) steal(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.keys[key] = memoryEntry{token: "intruder", expires: time.Now().Add(time.Hour)}
+}
+
+// TestDistributedLocker tests TTL leases, automatic renewal and lease loss.
+// (TestDistributedLocker 测试 TTL 租约、自动续期和租约丢失。)
+func TestDistributedLocker(t *testing.T) {
+	backend := newMemoryBackend()
+	locker, err := lock.NewDistributedLocker(backend, fastOptions())
+	require.NoError(t, err)
+	testLocker(t, locker)
+
+	ctx := context.Background()
+	lease, err := locker.Lock(ctx, "renewed")
+	require.NoError(t, err)
+	time.Sleep(150 * time.Millisecond)
+	_, err = locker.TryLock(ctx, "renewed")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLockHeld), "renewal keeps the lease beyond its TTL")
+	require.NoError(t, lease.Release(ctx))
+
+	err = lock.With(ctx, locker, "stolen", func(ctx context.Context) error {
+		backend.steal("stolen")
+		<-ctx.Done()
+		return context.Cause(ctx)
+	})
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLockNotHeld))
+}
+
+// unreachableBackend is a memoryBackend whose Refresh blocks until its context ends, like a backend that stopped responding.
+// (unreachableBackend 是 Refresh 会阻塞到 context 结束的 memoryBackend，模拟停止响应的后端。)
+type unreachableBackend struct {
+	*memoryBackend
+}
+
+func (b unreachableBackend) Refresh(ctx context.Context, _, _ string, _ time.Duration) (bool, error) {
+	<-ctx.Done()
+	return false, ctx.Err()
+}
+
+// TestDistributedLocker_RefreshFailing tests that a lease whose renewals keep failing is lost before the key expires in the backend.
+// (TestDistributedLocker_RefreshFailing 测试续期持续失败的租约在键于后端过期之前就被视为丢失。)
+func TestDistributedLocker_RefreshFailing(t *testing.T) {
+	backend := unreachableBackend{newMemoryBackend()}
+	opts := fastOptions()
+	opts.TTL = 300 * time.Millisecond
+	opts.RenewInterval = 100 * time.Millisecond
+	locker, err := lock.NewDistributedLocker(backend, opts)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	lease, err := locker.TryLock(ctx, "unreachable")
+	require.NoError(t, err)
+	select {
+	case <-lease.Lost():
+	case <-time.After(opts.TTL):
+		t.Fatal("lease must be lost before its TTL runs out")
+	}
+	_, err = locker.TryLock(ctx, "unreachable")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLockHeld), "the key has not expired yet when the loss is reported")
+	assert.True(t, lmccerrors.IsCode(lease.Release(ctx), lmccerrors.ErrLockNotHeld))
+}
+
+// TestOptions_Validate tests option validation.
+// (TestOptions_Validate 测试选项验证。)
+func TestOptions_Validate(t *testing.T) {
+	assert.Empty(t, lock.NewOptions().Validate())
+
+	opts := lock.NewOptions()
+	opts.RenewInterval = opts.TTL
+	_, err := lock.NewLocalLocker(opts)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLockOptionInvalid))
+}
@@ -0,0 +1,80 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package lock
+
+import (
+	"fmt"
+	"time"
+)
+
+// Options 定义了锁的配置选项，通常作为应用配置中的 "lock" 节加载。
+// (Options defines locking configuration, typically loaded as the "lock" section of the application configuration.)
+type Options struct {
+	// AcquireTimeout 是 Lock 等待获取锁的最长时间，0 表示只受 ctx 限制。
+	// (AcquireTimeout is how long Lock waits to acquire a lock; 0 means it is bounded by ctx only.)
+	AcquireTimeout time.Duration `json:"acquire-timeout" mapstructure:"acquire-timeout"`
+
+	// RetryInterval 是文件锁和分布式锁重试获取的间隔。
+	// (RetryInterval is the interval between acquisition attempts of file and distributed locks.)
+	RetryInterval time.Duration `json:"retry-interval" mapstructure:"retry-interval"`
+
+	// TTL 是分布式锁的租约时长，持有者崩溃后锁最多在 TTL 后释放。
+	// (TTL is the lease duration of distributed locks; a crashed owner's lock is freed after at most TTL.)
+	TTL time.Duration `json:"ttl" mapstructure:"ttl"`
+
+	// AutoRenew 启用分布式锁租约的自动续期。
+	// (AutoRenew enables automatic renewal of distributed lock leases.)
+	AutoRenew bool `json:"auto-renew" mapstructure:"auto-renew"`
+
+	// RenewInterval 是自动续期的间隔，必须小于 TTL。
+	// (RenewInterval is the automatic renewal interval and must be less than TTL.)
+	RenewInterval time.Duration `json:"renew-interval" mapstructure:"renew-interval"`
+
+	// ContentionThreshold 是锁竞争的告警阈值，等待超过该时长才获取到锁时以 Warn 级别记录。
+	// (ContentionThreshold is the contention warning threshold; acquisitions that waited longer are logged at Warn level.)
+	ContentionThreshold time.Duration `json:"contention-threshold" mapstructure:"contention-threshold"`
+}
+
+// NewOptions 创建具有默认值的锁选项 (creates locking options with default values)
+func NewOptions() *Options {
+	return &Options{
+		AcquireTimeout:      10 * time.Second,       // 默认最多等待 10s (Wait at most 10s by default)
+		RetryInterval:       100 * time.Millisecond, // 每 100ms 重试 (Retry every 100ms)
+		TTL:                 30 * time.Second,       // 默认租约 30s (30s lease by default)
+		AutoRenew:           true,                   // 默认自动续期 (Renew automatically by default)
+		RenewInterval:       10 * time.Second,       // TTL 的三分之一 (A third of the TTL)
+		ContentionThreshold: time.Second,            // 等待超过 1s 告警 (Warn after waiting over 1s)
+	}
+}
+
+// Validate 验证锁选项是否有效。
+// (Validate validates if the locking options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.AcquireTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid acquire timeout '%s', must not be negative", o.AcquireTimeout))
+	}
+
+	if o.RetryInterval <= 0 {
+		errs = append(errs, fmt.Errorf("invalid retry interval '%s', must be positive", o.RetryInterval))
+	}
+
+	if o.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("invalid ttl '%s', must be positive", o.TTL))
+	}
+
+	if o.AutoRenew && (o.RenewInterval <= 0 || o.RenewInterval >= o.TTL) {
+		errs = append(errs, fmt.Errorf("invalid renew interval '%s', must be positive and less than ttl '%s'", o.RenewInterval, o.TTL))
+	}
+
+	if o.ContentionThreshold < 0 {
+		errs = append(errs, fmt.Errorf("invalid contention threshold '%s', must not be negative", o.ContentionThreshold))
+	}
+
+	return errs
+}
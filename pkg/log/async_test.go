@@ -0,0 +1,185 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the asynchronous buffered writer.
+ */
+
+package log_test
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// gatedSink 在第一次写入时阻塞，直到 gate 被关闭，用来模拟缓慢的磁盘。
+// (gatedSink blocks its first write until gate is closed, simulating a slow disk.)
+type gatedSink struct {
+	memorySink
+	entered chan struct{}
+	gate    chan struct{}
+	first   bool
+}
+
+func newGatedSink() *gatedSink {
+	return &gatedSink{entered: make(chan struct{}), gate: make(chan struct{}), first: true}
+}
+
+func (s *gatedSink) Write(p []byte) (int, error) {
+	if s.first {
+		// 只有后台协程写入，无需加锁 (Only the background goroutine writes, so no lock is needed)
+		s.first = false
+		close(s.entered)
+		<-s.gate
+	}
+	return s.memorySink.Write(p)
+}
+
+var (
+	asyncSinks        sync.Map // 按 URL 主机名查找测试输出 (test sinks looked up by URL host)
+	registerAsyncOnce sync.Once
+)
+
+// initAsyncLogger 将全局记录器初始化为写入 sink 的异步记录器，输出路径为 asynctest://name。
+// (initAsyncLogger initializes the global logger as an async logger writing to sink at asynctest://name.)
+func initAsyncLogger(t *testing.T, name string, sink zapcore.WriteSyncer, async log.AsyncBufferOptions) {
+	t.Helper()
+	registerAsyncOnce.Do(func() {
+		require.NoError(t, log.RegisterSink("asynctest", func(u *url.URL) (zapcore.WriteSyncer, error) {
+			sink, _ := asyncSinks.Load(u.Host)
+			return sink.(zapcore.WriteSyncer), nil
+		}))
+	})
+	asyncSinks.Store(name, sink)
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{"asynctest://" + name}
+	opts.AsyncBuffer = async
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+}
+
+// TestAsyncBuffer_OverflowPolicies tests that a full buffer drops the oldest or the new entry and counts the drop.
+// (TestAsyncBuffer_OverflowPolicies 测试缓冲区已满时丢弃最旧或最新的条目并计数。)
+func TestAsyncBuffer_OverflowPolicies(t *testing.T) {
+	tests := []struct {
+		policy  string
+		kept    []string
+		dropped string
+	}{
+		{policy: log.OverflowDropOldest, kept: []string{"entry-1", "entry-3", "entry-4"}, dropped: "entry-2"},
+		{policy: log.OverflowDropNew, kept: []string{"entry-1", "entry-2", "entry-3"}, dropped: "entry-4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			sink := newGatedSink()
+			initAsyncLogger(t, tt.policy, sink, log.AsyncBufferOptions{Size: 2, OverflowPolicy: tt.policy})
+
+			// entry-1 被后台协程取出并阻塞在 sink 中，之后的条目只能进入缓冲区
+			// (entry-1 is taken by the background goroutine and blocks in the sink, so later entries can only go to the buffer)
+			log.Info("entry-1")
+			<-sink.entered
+			for i := 2; i <= 4; i++ {
+				log.Info(fmt.Sprintf("entry-%d", i))
+			}
+
+			stats := log.AsyncBufferStats()
+			require.Len(t, stats, 1)
+			assert.Equal(t, "asynctest://"+tt.policy, stats[0].Path)
+			assert.Equal(t, 2, stats[0].Capacity)
+			assert.Equal(t, 2, stats[0].Buffered)
+			assert.Equal(t, uint64(1), stats[0].Dropped)
+
+			close(sink.gate)
+			require.NoError(t, log.Sync())
+
+			out := sink.String()
+			for _, msg := range tt.kept {
+				assert.Contains(t, out, msg)
+			}
+			assert.NotContains(t, out, tt.dropped)
+
+			stats = log.AsyncBufferStats()
+			assert.Equal(t, 0, stats[0].Buffered)
+			assert.Equal(t, uint64(3), stats[0].Written)
+			assert.Equal(t, uint64(0), stats[0].Failed)
+		})
+	}
+}
+
+// TestAsyncBuffer_Block tests that the block policy waits for space instead of dropping entries.
+// (TestAsyncBuffer_Block 测试 block 策略等待空间而不是丢弃条目。)
+func TestAsyncBuffer_Block(t *testing.T) {
+	sink := newGatedSink()
+	initAsyncLogger(t, "block", sink, log.AsyncBufferOptions{Size: 1, OverflowPolicy: log.OverflowBlock})
+
+	log.Info("entry-1")
+	<-sink.entered
+	log.Info("entry-2")
+
+	done := make(chan struct{})
+	go func() {
+		log.Info("entry-3")
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("write should block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(sink.gate)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("write did not resume after the buffer drained")
+	}
+	require.NoError(t, log.Sync())
+
+	out := sink.String()
+	for _, msg := range []string{"entry-1", "entry-2", "entry-3"} {
+		assert.Contains(t, out, msg)
+	}
+	stats := log.AsyncBufferStats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, uint64(3), stats[0].Written)
+	assert.Equal(t, uint64(0), stats[0].Dropped)
+}
+
+// TestAsyncBuffer_Reconfigure tests that replacing the global logger flushes the old async sink and that
+// stdout is never wrapped.
+// (TestAsyncBuffer_Reconfigure 测试替换全局记录器时会写出旧的异步输出，且 stdout 不会被包装。)
+func TestAsyncBuffer_Reconfigure(t *testing.T) {
+	sink := &memorySink{}
+	initAsyncLogger(t, "reconfigure", sink, log.AsyncBufferOptions{Size: 16})
+	old := log.Std()
+
+	opts := log.NewOptions()
+	opts.AsyncBuffer = log.AsyncBufferOptions{Size: 16}
+	require.NoError(t, log.ReconfigureGlobalLogger(opts))
+	assert.Nil(t, log.AsyncBufferStats(), "stdout must stay synchronous")
+
+	// 旧记录器的输出已关闭，之后的写入同步完成 (The old logger's sink is closed, so later writes complete synchronously)
+	old.Info("after reconfigure")
+	assert.Contains(t, sink.String(), "after reconfigure")
+}
+
+// TestAsyncBufferOptions_Validate tests validation of the async buffer options.
+// (TestAsyncBufferOptions_Validate 测试异步缓冲选项的校验。)
+func TestAsyncBufferOptions_Validate(t *testing.T) {
+	opts := log.NewOptions()
+	opts.AsyncBuffer = log.AsyncBufferOptions{Size: 1024, FlushInterval: time.Second, OverflowPolicy: log.OverflowBlock}
+	assert.Empty(t, opts.Validate())
+
+	opts.AsyncBuffer = log.AsyncBufferOptions{Size: -1, FlushInterval: -time.Second, OverflowPolicy: "spill"}
+	errs := opts.Validate()
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[2].Error(), "overflow policy 'spill'")
+}
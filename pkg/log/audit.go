@@ -0,0 +1,320 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// AuditOutcomeSuccess 表示操作成功，是未指定 "outcome" 时的默认值。
+	// (AuditOutcomeSuccess means the action succeeded; the default when no "outcome" is given.)
+	AuditOutcomeSuccess = "success"
+	// AuditOutcomeFailure 表示操作失败。(AuditOutcomeFailure means the action failed.)
+	AuditOutcomeFailure = "failure"
+	// AuditOutcomeDenied 表示操作因权限不足被拒绝。(AuditOutcomeDenied means the action was refused for lack of permission.)
+	AuditOutcomeDenied = "denied"
+)
+
+// auditUnknownActor 是 context 和字段中都没有操作者时记录的值。
+// (auditUnknownActor is recorded when neither the context nor the fields name an actor.)
+const auditUnknownActor = "unknown"
+
+// AuditOptions 配置审计日志通道。审计事件与应用日志分开写入，每行一个 JSON 对象，不受日志级别、模块级别和采样影响，
+// 也不经过 AsyncBuffer，写入失败时由 Audit 返回错误而不是静默丢弃。
+// (AuditOptions configures the audit log channel. Audit events are written apart from application logs, one JSON object
+// per line; they are not subject to the log level, module levels or sampling, never go through AsyncBuffer, and a failed
+// write is returned by Audit instead of being dropped silently.)
+type AuditOptions struct {
+	// OutputPaths 是审计事件的输出：文件路径（沿用日志轮转设置）、"stdout"、"stderr" 或 RegisterSink 注册的 URL，
+	// 不能与应用日志的输出重合。为空时审计事件作为 "Audit event" 条目写入应用日志。
+	// (OutputPaths are the outputs of audit events: file paths (using the log rotation settings), "stdout", "stderr" or
+	// URLs registered with RegisterSink, none of them shared with the application log outputs. When empty, audit events
+	// are written to the application log as "Audit event" entries.)
+	OutputPaths []string `json:"output-paths" mapstructure:"output-paths"`
+}
+
+// Enabled 报告是否配置了独立的审计输出。(Enabled reports whether dedicated audit outputs are configured.)
+func (a AuditOptions) Enabled() bool {
+	return len(a.OutputPaths) > 0
+}
+
+// Validate 验证审计选项。(Validate validates the audit options.)
+func (a AuditOptions) Validate() []error {
+	var errs []error
+	for i, path := range a.OutputPaths {
+		if path == "" {
+			errs = append(errs, fmt.Errorf("invalid audit output path at index %d, must not be empty", i))
+		}
+	}
+	return errs
+}
+
+// validateAuditOutputs 确保审计输出不与应用日志的输出重合，使审计事件与调试日志分开。
+// (validateAuditOutputs makes sure no audit output is shared with the application log, keeping audit events apart from debug noise.)
+func validateAuditOutputs(o *Options) []error {
+	appPaths := map[string]bool{}
+	if routes := o.levelRoutes(); len(routes) > 0 {
+		for _, paths := range routes {
+			for _, path := range paths {
+				appPaths[path] = true
+			}
+		}
+	} else {
+		for _, path := range o.OutputPaths {
+			appPaths[path] = true
+		}
+	}
+	var errs []error
+	for _, path := range o.Audit.OutputPaths {
+		if appPaths[path] {
+			errs = append(errs, fmt.Errorf("invalid audit output path '%s', it is also an application log output", path))
+		}
+	}
+	return errs
+}
+
+// auditActorKey 是在 context 中存储审计操作者的键。(auditActorKey is the key for the audit actor in context.)
+type auditActorKey struct{}
+
+// ContextWithActor 将审计操作者（例如用户 ID 或服务账号）添加到 context 中，通常由认证中间件调用。
+// (ContextWithActor adds the audit actor, such as a user ID or service account, to the context; usually called by the
+// authentication middleware.)
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// ActorFromContext 从 context 中提取审计操作者。(ActorFromContext extracts the audit actor from the context.)
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(auditActorKey{}).(string)
+	return actor, ok
+}
+
+// auditChain 是进程内审计事件的序列号和哈希链状态，重新配置日志记录器后继续累加。
+// (auditChain holds the sequence number and hash chain of the process's audit events; it carries on across reconfigurations.)
+var auditChain struct {
+	mu   sync.Mutex
+	seq  uint64
+	hash string
+}
+
+// Audit 写出一条审计事件。每条事件包含必需字段 actor、action、target、outcome 和 timestamp（UTC，RFC 3339），
+// context 中有 Request ID 或 Trace ID 时一并记录；fields 中的其余键值对写入 "details"。
+// actor 取自 ContextWithActor，outcome 默认为 "success"，两者都可在 fields 中以 "actor" 和 "outcome" 覆盖。
+// 为防篡改，每条事件带有从 1 开始连续递增的 "seq"，以及 "hash"：前一条事件的 hash 与本条事件（不含 hash、键按字母排序的
+// JSON）拼接后的 SHA-256。修改、删除或重排事件都会使 VerifyAuditLog 失败。
+// (Audit writes an audit event. Every event carries the mandatory fields actor, action, target, outcome and timestamp
+// (UTC, RFC 3339), plus the Request ID and Trace ID when the context holds them; the remaining key-value pairs of fields
+// go into "details". The actor comes from ContextWithActor and the outcome defaults to "success"; both can be overridden
+// with "actor" and "outcome" in fields. To make the trail tamper-evident every event carries "seq", increasing by one
+// from 1, and "hash": the SHA-256 of the previous event's hash followed by this event as JSON without the hash and with
+// sorted keys. Modifying, removing or reordering events makes VerifyAuditLog fail.)
+//
+// Parameters:
+//   ctx:    携带操作者和请求标识的 context。(The context carrying the actor and request identifiers.)
+//   action: 执行的操作，例如 "user.delete"。(The action performed, e.g. "user.delete".)
+//   target: 操作的对象，例如 "user/42"。(The subject acted upon, e.g. "user/42".)
+//   fields: 附加的键值对。(Additional key-value pairs.)
+//
+// Returns:
+//   error: 写入失败时为带 ErrLogInternal 的错误；此时序列号不前进。
+//          (An error coded ErrLogInternal when the write fails; the sequence number does not advance then.)
+func Audit(ctx context.Context, action, target string, fields ...any) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	record := map[string]any{
+		"actor":   auditUnknownActor,
+		"action":  action,
+		"target":  target,
+		"outcome": AuditOutcomeSuccess,
+	}
+	if actor, ok := ActorFromContext(ctx); ok && actor != "" {
+		record["actor"] = actor
+	}
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		record["request_id"] = requestID
+	}
+	if traceID, ok := TraceIDFromContext(ctx); ok {
+		record["trace_id"] = traceID
+	}
+	details := map[string]any{}
+	for i := 0; i < len(fields); i += 2 {
+		key := fmt.Sprint(fields[i])
+		var value any
+		if i+1 < len(fields) {
+			value = auditValue(fields[i+1])
+		}
+		if s, ok := value.(string); ok && (key == "actor" || key == "outcome") {
+			record[key] = s
+			continue
+		}
+		details[key] = value
+	}
+	if len(details) > 0 {
+		record["details"] = details
+	}
+
+	l, _ := Std().(*logger)
+	auditChain.mu.Lock()
+	defer auditChain.mu.Unlock()
+
+	seq := auditChain.seq + 1
+	record["seq"] = seq
+	record["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	line, hash, err := sealAuditRecord(record, auditChain.hash)
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to encode audit event"), lmccerrors.ErrLogInternal)
+	}
+
+	if l != nil && l.audit != nil {
+		_, err = l.audit.Write(append(line, '\n'))
+	} else if l != nil {
+		// 未配置独立输出时写入应用日志，绕过级别检查 (Without dedicated outputs, write to the application log bypassing the level check)
+		ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: time.Now(), Message: "Audit event"}
+		err = l.zapLogger.Core().Write(ent, []zap.Field{zap.Reflect("audit", json.RawMessage(line))})
+	}
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to write audit event"), lmccerrors.ErrLogInternal)
+	}
+	auditChain.seq, auditChain.hash = seq, hash
+	return nil
+}
+
+// auditValue 把错误转换为其消息，把无法编码为 JSON 的值转换为字符串。
+// (auditValue turns errors into their message and values that cannot be encoded as JSON into strings.)
+func auditValue(value any) any {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return value
+}
+
+// sealAuditRecord 返回以 hash 字段结尾的审计事件行及其 hash。
+// (sealAuditRecord returns the audit event line, ending with its hash field, and the hash.)
+func sealAuditRecord(record map[string]any, prevHash string) ([]byte, string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, "", err
+	}
+	// 经过一次解码再编码，使结构体等值的键顺序与校验时一致
+	// (Decode and re-encode once so values such as structs get the same key order as during verification)
+	canonical, err := canonicalAuditJSON(data)
+	if err != nil {
+		return nil, "", err
+	}
+	hash := auditHash(prevHash, canonical)
+	line := append(canonical[:len(canonical)-1:len(canonical)-1], `,"hash":"`+hash+`"}`...)
+	return line, hash, nil
+}
+
+// canonicalAuditJSON 将 JSON 对象重新编码为键按字母排序的形式，数字保持原样。
+// (canonicalAuditJSON re-encodes a JSON object with sorted keys, keeping numbers as they are.)
+func canonicalAuditJSON(data []byte) ([]byte, error) {
+	record, err := decodeAuditRecord(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(record)
+}
+
+// decodeAuditRecord 解码一行审计事件，数字解码为 json.Number。
+// (decodeAuditRecord decodes an audit event line, decoding numbers as json.Number.)
+func decodeAuditRecord(data []byte) (map[string]any, error) {
+	var record map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// auditHash 返回前一条 hash 与本条事件拼接后的十六进制 SHA-256。
+// (auditHash returns the hex SHA-256 of the previous hash followed by this event.)
+func auditHash(prevHash string, canonical []byte) string {
+	sum := sha256.Sum256(append([]byte(prevHash), canonical...))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAuditLog 校验 Audit 写出的审计日志：序列号必须连续，每条事件的 hash 必须与重新计算的结果一致。
+// seq 为 1 的事件开始一条新链（进程重启后）；输入的第一条事件不是从 1 开始时（例如轮转后的文件），以它的 hash 为起点。
+// (VerifyAuditLog verifies an audit log written by Audit: sequence numbers must be consecutive and the hash of every
+// event must match the recomputed one. An event with seq 1 starts a new chain (after a process restart); when the first
+// event of the input does not start at 1, e.g. in a rotated file, its hash is taken as the starting point.)
+//
+// Returns:
+//   error: 校验通过时为 nil；否则为带 ErrLogAuditTampered、指出首个异常行的错误。
+//          (nil when the trail verifies; otherwise an error coded ErrLogAuditTampered naming the first bad line.)
+func VerifyAuditLog(r io.Reader) error {
+	var (
+		prevSeq  uint64
+		prevHash string
+		started  bool
+	)
+	reader := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return lmccerrors.WithCode(lmccerrors.Wrap(readErr, "failed to read audit log"), lmccerrors.ErrLogInternal)
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			record, err := decodeAuditRecord(line)
+			if err != nil {
+				return lmccerrors.ErrorfWithCode(lmccerrors.ErrLogAuditTampered, "line %d: invalid audit event: %v", n, err)
+			}
+			hash, _ := record["hash"].(string)
+			delete(record, "hash")
+			number, _ := record["seq"].(json.Number)
+			seq, err := strconv.ParseUint(number.String(), 10, 64)
+			if err != nil || seq == 0 {
+				return lmccerrors.ErrorfWithCode(lmccerrors.ErrLogAuditTampered, "line %d: missing or invalid sequence number", n)
+			}
+			canonical, err := json.Marshal(record)
+			if err != nil {
+				return lmccerrors.ErrorfWithCode(lmccerrors.ErrLogAuditTampered, "line %d: invalid audit event: %v", n, err)
+			}
+
+			switch {
+			case seq == 1:
+				prevHash = ""
+			case !started:
+				// 无法校验第一条事件与更早事件的衔接 (The link of the first event to earlier ones cannot be checked)
+				prevHash, prevSeq, started = hash, seq, true
+				continue
+			case seq != prevSeq+1:
+				return lmccerrors.ErrorfWithCode(lmccerrors.ErrLogAuditTampered,
+					"line %d: sequence %d follows %d, events are missing or reordered", n, seq, prevSeq)
+			}
+			if hash != auditHash(prevHash, canonical) {
+				return lmccerrors.ErrorfWithCode(lmccerrors.ErrLogAuditTampered,
+					"line %d: hash mismatch at sequence %d, the event was modified", n, seq)
+			}
+			prevHash, prevSeq, started = hash, seq, true
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the audit log channel.
+ */
+
+package log_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initAuditLogger initializes the global logger with separate application and audit files.
+// (initAuditLogger 使用独立的应用日志文件和审计文件初始化全局日志记录器。)
+func initAuditLogger(t *testing.T) (appPath, auditPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	appPath, auditPath = filepath.Join(dir, "app.log"), filepath.Join(dir, "audit.log")
+	opts := log.NewOptions()
+	opts.Level = "error"
+	opts.OutputPaths = []string{appPath}
+	opts.Audit.OutputPaths = []string{auditPath}
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+	return appPath, auditPath
+}
+
+// TestAudit tests that audit events carry the mandatory fields and go to the audit output only.
+// (TestAudit 测试审计事件包含必需字段，并且只写入审计输出。)
+func TestAudit(t *testing.T) {
+	appPath, auditPath := initAuditLogger(t)
+
+	ctx := log.ContextWithActor(log.ContextWithRequestID(context.Background(), "req-1"), "alice")
+	before := time.Now().UTC()
+	require.NoError(t, log.Audit(ctx, "user.delete", "user/42", "reason", "gdpr request", "error", errors.New("partial")))
+	require.NoError(t, log.Audit(context.Background(), "login", "session", "actor", "bob", "outcome", log.AuditOutcomeDenied))
+	log.Error("application failure")
+	require.NoError(t, log.Sync())
+
+	lines := readLogLines(t, auditPath)
+	require.Len(t, lines, 2)
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "alice", first["actor"])
+	assert.Equal(t, "user.delete", first["action"])
+	assert.Equal(t, "user/42", first["target"])
+	assert.Equal(t, log.AuditOutcomeSuccess, first["outcome"])
+	assert.Equal(t, "req-1", first["request_id"])
+	assert.Equal(t, map[string]any{"reason": "gdpr request", "error": "partial"}, first["details"])
+	assert.NotEmpty(t, first["hash"])
+	ts, err := time.Parse(time.RFC3339Nano, first["timestamp"].(string))
+	require.NoError(t, err)
+	assert.False(t, ts.Before(before.Truncate(time.Second)))
+
+	var second map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.Equal(t, "bob", second["actor"])
+	assert.Equal(t, log.AuditOutcomeDenied, second["outcome"])
+	assert.NotContains(t, second, "details")
+	assert.Equal(t, first["seq"].(float64)+1, second["seq"])
+
+	appLines := readLogLines(t, appPath)
+	require.Len(t, appLines, 1)
+	assert.Contains(t, appLines[0], "application failure")
+	assert.NotContains(t, appLines[0], "user.delete")
+}
+
+// TestAudit_ApplicationLogFallback tests that audit events go to the application log, whatever its level, without audit outputs.
+// (TestAudit_ApplicationLogFallback 测试未配置审计输出时，审计事件无论级别如何都写入应用日志。)
+func TestAudit_ApplicationLogFallback(t *testing.T) {
+	appPath := filepath.Join(t.TempDir(), "app.log")
+	opts := log.NewOptions()
+	opts.Level = "error"
+	opts.OutputPaths = []string{appPath}
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	require.NoError(t, log.Audit(context.Background(), "config.update", "feature-flags"))
+	require.NoError(t, log.Sync())
+
+	lines := readLogLines(t, appPath)
+	require.Len(t, lines, 1)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "Audit event", entry["M"])
+	audit := entry["audit"].(map[string]any)
+	assert.Equal(t, "config.update", audit["action"])
+	assert.Equal(t, "unknown", audit["actor"])
+}
+
+// TestVerifyAuditLog tests that modified, removed and reordered audit events are detected.
+// (TestVerifyAuditLog 测试能够发现被修改、删除和重排的审计事件。)
+func TestVerifyAuditLog(t *testing.T) {
+	_, auditPath := initAuditLogger(t)
+	for _, target := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, log.Audit(context.Background(), "read", target, "count", 3, "payload", struct {
+			Z string `json:"z"`
+			A int    `json:"a"`
+		}{"z", 1}))
+	}
+	require.NoError(t, log.Sync())
+	lines := readLogLines(t, auditPath)
+	require.Len(t, lines, 4)
+
+	verify := func(lines ...string) error {
+		return log.VerifyAuditLog(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		data, err := os.ReadFile(auditPath)
+		require.NoError(t, err)
+		assert.NoError(t, log.VerifyAuditLog(strings.NewReader(string(data))))
+		assert.NoError(t, verify(lines[1:]...), "a trail starting after sequence 1 is anchored at its first event")
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		tampered := append([]string{}, lines...)
+		tampered[2] = strings.Replace(tampered[2], `"target":"c"`, `"target":"x"`, 1)
+		err := verify(tampered...)
+		require.Error(t, err)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLogAuditTampered))
+		assert.Contains(t, err.Error(), "line 3: hash mismatch")
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		err := verify(lines[0], lines[1], lines[3])
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 3: sequence")
+	})
+
+	t.Run("reordered", func(t *testing.T) {
+		err := verify(lines[0], lines[2], lines[1], lines[3])
+		require.Error(t, err)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLogAuditTampered))
+	})
+
+	t.Run("not an audit event", func(t *testing.T) {
+		err := verify(lines[0], "plain text")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2: invalid audit event")
+	})
+}
+
+// TestAuditOptions_Validate tests that audit outputs must be valid and separate from the application outputs.
+// (TestAuditOptions_Validate 测试审计输出必须有效且与应用日志的输出分开。)
+func TestAuditOptions_Validate(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Audit.OutputPaths = []string{"/var/log/audit.log"}
+	assert.Empty(t, opts.Validate())
+
+	opts.Audit.OutputPaths = []string{"stdout", ""}
+	errs := opts.Validate()
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "audit output path at index 1")
+	assert.Contains(t, errs[1].Error(), "'stdout', it is also an application log output")
+
+	opts.Audit.OutputPaths = []string{"stderr"}
+	opts.SplitStdStreams = true
+	assert.NotEmpty(t, opts.Validate(), "stderr is used by the split standard streams")
+}
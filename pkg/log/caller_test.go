@@ -0,0 +1,93 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for caller reporting and caller skipping.
+ */
+
+package log_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callerHere 返回调用者的 "file:line"，与记录器报告的格式一致。
+// (callerHere returns the caller's "file:line" in the format reported by the logger.)
+func callerHere(offset int) string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("log/%s:%d", filepath.Base(file), line+offset)
+}
+
+// logViaHelper 模拟团队自己的日志辅助函数。(logViaHelper mimics a team's own logging helper.)
+func logViaHelper(logger log.Logger, msg string) {
+	logger.Infow(msg, "helper", true)
+}
+
+// TestCaller tests that the caller points at the code calling the logger, not at the SDK's logging facade.
+// (TestCaller 测试调用者指向调用记录器的代码，而不是 SDK 的日志门面。)
+func TestCaller(t *testing.T) {
+	newLogger := func(opts *log.Options) (log.Logger, *syncBuffer) {
+		opts.DisableStacktrace = true
+		require.Empty(t, opts.Validate())
+		buf := &syncBuffer{}
+		return log.NewLoggerWithWriter(opts, buf), buf
+	}
+	lastCaller := func(buf *syncBuffer) any {
+		entries := buf.entries(t)
+		require.NotEmpty(t, entries)
+		return entries[len(entries)-1]["C"]
+	}
+
+	logger, buf := newLogger(log.NewOptions())
+	logger.Info("instance")
+	assert.Equal(t, callerHere(-1), lastCaller(buf))
+	logger.WithValues("k", "v").WithName("sub").Errorf("derived %d", 1)
+	assert.Equal(t, callerHere(-1), lastCaller(buf))
+
+	original := log.GetGlobalLogger()
+	defer log.SetGlobalLogger(original)
+	log.SetGlobalLogger(logger)
+	log.Info("global")
+	assert.Equal(t, callerHere(-1), lastCaller(buf))
+	log.Warnw("global", "k", "v")
+	assert.Equal(t, callerHere(-1), lastCaller(buf))
+	log.Infos("global", log.String("k", "v"))
+	assert.Equal(t, callerHere(-1), lastCaller(buf))
+	log.Errors("global", log.Int("n", 1))
+	assert.Equal(t, callerHere(-1), lastCaller(buf))
+
+	// 包装记录器的辅助函数通过 WithCallerSkip 或 Options.CallerSkip 跳过自身
+	// (Helpers wrapping the logger skip themselves with WithCallerSkip or Options.CallerSkip)
+	logViaHelper(logger, "unskipped")
+	assert.Contains(t, lastCaller(buf), "log/caller_test.go:30", "without a skip the helper is reported")
+	logViaHelper(logger.WithCallerSkip(1), "skipped")
+	assert.Equal(t, callerHere(-1), lastCaller(buf))
+
+	opts := log.NewOptions()
+	opts.CallerSkip = 1
+	skipping, skippingBuf := newLogger(opts)
+	logViaHelper(skipping, "configured")
+	assert.Equal(t, callerHere(-1), lastCaller(skippingBuf))
+
+	// key=value 格式的包装器同样报告正确的调用者 (The key=value wrapper reports the right caller too)
+	opts = log.NewOptions()
+	opts.Format = log.FormatKeyValue
+	kvLogger, kvBuf := newLogger(opts)
+	kvLogger.WithValues("k", "v").Info("kv")
+	assert.Contains(t, kvBuf.buf.String(), callerHere(-1))
+}
+
+// TestCallerSkipValidate tests rejecting a negative caller skip.
+// (TestCallerSkipValidate 测试拒绝负的调用者跳过帧数。)
+func TestCallerSkipValidate(t *testing.T) {
+	opts := log.NewOptions()
+	opts.CallerSkip = -1
+	assert.Len(t, opts.Validate(), 1)
+}
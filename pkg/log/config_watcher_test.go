@@ -24,6 +24,8 @@ import (
 // mockConfigManager 用于测试 RegisterConfigHotReload，并实现 config.Manager 接口。
 // (mockConfigManager is used for testing RegisterConfigHotReload and implements the config.Manager interface.)
 type mockConfigManager struct {
+	config.Values
+
 	// generalCallback stores the callback registered via RegisterCallback.
 	// (generalCallback 存储通过 RegisterCallback 注册的回调。)
 	generalCallback func(*viper.Viper, any) error
@@ -64,6 +66,17 @@ func (m *mockConfigManager) RegisterSectionChangeCallback(sectionKey string, cb
 	m.sectionCallbacksCalled[sectionKey] = true
 }
 
+// LastChangeSet (mock implementation for config.Manager)
+func (m *mockConfigManager) LastChangeSet() config.ChangeSet { return nil }
+
+// Set and Save (mock implementations for config.Manager)
+func (m *mockConfigManager) Set(string, any) error { return nil }
+func (m *mockConfigManager) Save() error           { return nil }
+
+// Snapshot and Version (mock implementations for config.Manager)
+func (m *mockConfigManager) Snapshot() (any, uint64) { return nil, 0 }
+func (m *mockConfigManager) Version() uint64         { return 0 }
+
 // Helper method to simulate triggering the log section callback
 func (m *mockConfigManager) triggerLogSectionCallback(v *viper.Viper) error {
 	m.sectionCallbacksMutex.RLock()
@@ -47,4 +47,25 @@ func TraceIDFromContext(ctx context.Context) (string, bool) {
 func RequestIDFromContext(ctx context.Context) (string, bool) {
 	val, ok := ctx.Value(RequestIDKey).(string)
 	return val, ok
-} 
\ No newline at end of file
+}
+
+// loggerContextKey 是在 context 中存储请求级 Logger 的键，与 contextKey 分开以免被当作日志字段提取
+// (loggerContextKey is the key for the request-scoped Logger in context, kept apart from contextKey so it is never extracted as a log field)
+type loggerContextKey struct{}
+
+// NewContextWithLogger 返回携带指定 Logger 的 context 副本，供中间件向下游处理器传递请求级 Logger，命名遵循标准库 NewContext 的习惯
+// (NewContextWithLogger returns a copy of ctx carrying logger, letting middleware hand a request-scoped Logger to downstream handlers; it is named after the standard library's NewContext convention)
+func NewContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext 返回 context 中由 NewContextWithLogger 存入的 Logger；不存在时返回全局 Logger，因此结果总是可用的
+// (FromContext returns the Logger stored in ctx by NewContextWithLogger, or the global Logger when there is none, so the result is always usable)
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return Std()
+}
@@ -255,4 +255,20 @@ func TestGlobalCtxLevelFunctions(t *testing.T) {
 			localAssert.Contains(line, `"stacktrace":`)
 		}
 	}
-} 
\ No newline at end of file
+}
+
+// TestLoggerContext tests storing and retrieving a request-scoped logger.
+// (TestLoggerContext 测试存取请求级 logger。)
+func TestLoggerContext(t *testing.T) {
+	assert.Same(t, log.Std(), log.FromContext(context.Background()), "falls back to the global logger")
+	//nolint:staticcheck // nil context 也应安全 (A nil context is also safe)
+	assert.Same(t, log.Std(), log.FromContext(nil))
+
+	requestLogger := log.Std().WithValues("request_id", "req-1")
+	ctx := log.NewContextWithLogger(context.Background(), requestLogger)
+	assert.Same(t, requestLogger, log.FromContext(ctx))
+	assert.Same(t, requestLogger, log.FromContext(context.WithValue(ctx, struct{}{}, "other")), "survives derived contexts")
+
+	otherLogger := log.Std().WithValues("request_id", "req-2")
+	assert.Same(t, otherLogger, log.FromContext(log.NewContextWithLogger(ctx, otherLogger)), "the innermost logger wins")
+}
@@ -0,0 +1,114 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// contextField 将 context 键映射为日志字段名。(contextField maps a context key to a log field name.)
+type contextField struct {
+	name string
+	key  any
+}
+
+var (
+	// contextFieldsMu 串行化字段的注册与移除。(contextFieldsMu serialises field registration and removal.)
+	contextFieldsMu sync.Mutex
+	// registeredFields 是写时复制的全局字段列表，读取时无锁。(registeredFields is a copy-on-write global field list, read without locks.)
+	registeredFields atomic.Pointer[[]*contextField]
+)
+
+// RegisterContextField 注册一个全局 context 字段：所有 Logger 的 Ctx* 方法都会读取 ctx.Value(key)，
+// 值非 nil 时以 name 为字段名写入日志。与 Options.ContextKeys 不同，它对所有 Logger 生效且不受配置热重载影响。
+// 返回用于移除该字段的函数；name 为空或 key 为 nil 时不注册。
+// (RegisterContextField registers a global context field: the Ctx* methods of every Logger read ctx.Value(key) and,
+// when it is not nil, write it under the field name. Unlike Options.ContextKeys it applies to all Loggers and survives
+// configuration hot reload. It returns a function that removes the field; nothing is registered for an empty name or nil key.)
+func RegisterContextField(name string, key any) (remove func()) {
+	if name == "" || key == nil {
+		return func() {}
+	}
+	field := &contextField{name: name, key: key}
+
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+	var fields []*contextField
+	if cur := registeredFields.Load(); cur != nil {
+		fields = append(fields, *cur...)
+	}
+	fields = append(fields, field)
+	registeredFields.Store(&fields)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			contextFieldsMu.Lock()
+			defer contextFieldsMu.Unlock()
+			cur := registeredFields.Load()
+			if cur == nil {
+				return
+			}
+			remaining := make([]*contextField, 0, len(*cur))
+			for _, f := range *cur {
+				if f != field {
+					remaining = append(remaining, f)
+				}
+			}
+			registeredFields.Store(&remaining)
+		})
+	}
+}
+
+// validateContextFields 校验每个字段名非空且 context 键不为 nil。
+// (validateContextFields checks that every field name is non-empty and no context key is nil.)
+func validateContextFields(fields map[string]any) []error {
+	var errs []error
+	for name, key := range fields {
+		if name == "" {
+			errs = append(errs, errors.New("context field name must not be empty"))
+		} else if key == nil {
+			errs = append(errs, errors.New("context field '"+name+"' has a nil key"))
+		}
+	}
+	return errs
+}
+
+// appendContextFields 追加 Options.ContextFields 和全局注册的字段，跳过 ctx 中不存在的值和已存在的字段名。
+// (appendContextFields appends Options.ContextFields and the globally registered fields, skipping values missing from ctx
+// and field names already present.)
+func appendContextFields(ctx context.Context, opts *Options, fields []zap.Field) []zap.Field {
+	// 按名称排序使字段顺序稳定 (Sort by name so the field order is stable)
+	for _, name := range slices.Sorted(maps.Keys(opts.ContextFields)) {
+		fields = appendContextValue(ctx, fields, name, opts.ContextFields[name])
+	}
+	if registered := registeredFields.Load(); registered != nil {
+		for _, f := range *registered {
+			fields = appendContextValue(ctx, fields, f.name, f.key)
+		}
+	}
+	return fields
+}
+
+// appendContextValue 在 ctx 中存在 key 的值且尚无同名字段时追加该字段。
+// (appendContextValue appends the value of key in ctx when it exists and no field of that name is present yet.)
+func appendContextValue(ctx context.Context, fields []zap.Field, name string, key any) []zap.Field {
+	if name == "" || key == nil || hasField(fields, name) {
+		return fields
+	}
+	if value := ctx.Value(key); value != nil {
+		fields = append(fields, zap.Any(name, value))
+	}
+	return fields
+}
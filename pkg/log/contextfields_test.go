@@ -0,0 +1,65 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for registered context fields.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantCtxKey struct{}
+
+type regionCtxKey struct{}
+
+// TestContextFields tests extracting context values configured through Options.ContextFields and RegisterContextField.
+// (TestContextFields 测试提取通过 Options.ContextFields 和 RegisterContextField 配置的 context 值。)
+func TestContextFields(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableCaller = true
+	opts.ContextKeys = []any{log.RequestIDKey}
+	opts.ContextFields = map[string]any{"tenant_id": tenantCtxKey{}, "request_id": "ignored"}
+	require.Empty(t, opts.Validate())
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	remove := log.RegisterContextField("region", regionCtxKey{})
+	ctx := context.WithValue(context.Background(), tenantCtxKey{}, "acme")
+	ctx = context.WithValue(ctx, regionCtxKey{}, "eu-west-1")
+	ctx = log.ContextWithRequestID(ctx, "req-1")
+
+	logger.Ctxw(ctx, "with fields")
+	logger.WithValues("k", "v").CtxInfof(ctx, "with values")
+	remove()
+	remove() // 重复调用是安全的 (Calling it twice is safe)
+	logger.Ctxw(ctx, "after remove")
+	logger.Ctxw(context.Background(), "empty context")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 4)
+	assert.Contains(t, string(lines[0]), `"request_id":"req-1","tenant_id":"acme","region":"eu-west-1"`)
+	assert.Equal(t, 1, bytes.Count(lines[0], []byte(`"request_id"`)), "fields already extracted are not repeated")
+	assert.Contains(t, string(lines[1]), `"tenant_id":"acme"`)
+	assert.Contains(t, string(lines[1]), `"region":"eu-west-1"`)
+	assert.Contains(t, string(lines[2]), `"tenant_id":"acme"`)
+	assert.NotContains(t, string(lines[2]), "region", "removed fields are no longer extracted")
+	assert.NotContains(t, string(lines[3]), "tenant_id", "missing values are skipped")
+}
+
+// TestContextFieldsValidate tests rejecting empty field names and nil keys.
+// (TestContextFieldsValidate 测试拒绝空字段名和 nil 键。)
+func TestContextFieldsValidate(t *testing.T) {
+	opts := log.NewOptions()
+	opts.ContextFields = map[string]any{"": tenantCtxKey{}, "tenant_id": nil}
+	assert.Len(t, opts.Validate(), 2)
+}
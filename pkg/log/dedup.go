@@ -0,0 +1,177 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DedupRepeatedKey 是去重汇总条目中记录重复次数的字段名。
+// (DedupRepeatedKey is the field name holding the repeat count in dedup summary entries.)
+const DedupRepeatedKey = "repeated"
+
+// DedupOptions 配置重复日志的合并：Window 内相同级别、记录器名称和消息的条目只记录第一条，
+// 其余的在窗口结束时合并为一条带 "repeated" 字段的汇总条目，防止紧密的错误循环写满磁盘。
+// (DedupOptions configures collapsing of duplicate entries: within Window only the first entry with the same level, logger
+// name and message is logged, and the rest are collapsed into one summary entry with a "repeated" field when the window
+// ends, so tight error loops cannot fill the disk.)
+type DedupOptions struct {
+	// Window 是合并重复条目的时间窗口，为 0 时不去重。只要窗口内出现重复，窗口就会延续，
+	// 因此持续的日志风暴每个窗口只产生一条汇总条目。
+	// (Window is the period over which duplicates are collapsed; 0 disables deduplication. A window is extended as long as
+	// duplicates keep arriving, so a sustained log storm produces one summary entry per window.)
+	Window time.Duration `json:"window" mapstructure:"window"`
+}
+
+// Validate 验证去重选项。(Validate validates the dedup options.)
+func (d DedupOptions) Validate() []error {
+	if d.Window < 0 {
+		return []error{fmt.Errorf("invalid dedup window '%s', must not be negative", d.Window)}
+	}
+	return nil
+}
+
+// newDedupCore 在设置了 Window 时包装 core 以合并重复条目；否则原样返回 core。
+// (newDedupCore wraps core to collapse duplicate entries when Window is set; otherwise core is returned unchanged.)
+func newDedupCore(core zapcore.Core, opts DedupOptions) zapcore.Core {
+	if opts.Window <= 0 {
+		return core
+	}
+	return &dedupCore{Core: core, state: &dedupState{window: opts.Window, pending: make(map[dedupKey]*dedupEntry)}}
+}
+
+// dedupKey 标识被视为重复的条目。(dedupKey identifies entries considered duplicates.)
+type dedupKey struct {
+	level   zapcore.Level
+	logger  string
+	message string
+}
+
+// dedupEntry 记录一个窗口内被抑制的重复条目，汇总条目使用最后一条的内容和字段。
+// (dedupEntry records the duplicates suppressed within a window; the summary uses the entry and fields of the last one.)
+type dedupEntry struct {
+	count  int
+	ent    zapcore.Entry
+	fields []zapcore.Field
+	core   zapcore.Core
+	timer  *time.Timer
+}
+
+// dedupState 是 With 派生的 core 共享的去重状态。(dedupState is the dedup state shared by cores derived with With.)
+type dedupState struct {
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[dedupKey]*dedupEntry
+}
+
+// dedupCore 让窗口内的第一条条目直接通过，之后的重复条目只被计数，窗口结束时写出汇总条目。
+// 汇总条目经底层 core 的 Check 写入，因此仍遵循级别和级别路由。DPanic 及以上级别不去重。
+// (dedupCore lets the first entry of a window through and only counts the duplicates that follow, writing a summary entry when
+// the window ends. Summaries are written through Check of the underlying core, so levels and level routes still apply.
+// DPanic and above are never deduplicated.)
+type dedupCore struct {
+	zapcore.Core
+	state *dedupState
+}
+
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{Core: c.Core.With(fields), state: c.state}
+}
+
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level > zapcore.ErrorLevel || !c.Enabled(ent.Level) {
+		return c.Core.Check(ent, ce)
+	}
+	key := dedupKey{level: ent.Level, logger: ent.LoggerName, message: ent.Message}
+	s := c.state
+	s.mu.Lock()
+	_, duplicate := s.pending[key]
+	if !duplicate {
+		s.pending[key] = &dedupEntry{timer: time.AfterFunc(s.window, func() { s.flush(key) })}
+	}
+	s.mu.Unlock()
+	if duplicate {
+		// 重复条目交给 Write 记录字段，不写出 (Duplicates go to Write, which records their fields without writing them)
+		return ce.AddCore(ent, c)
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// Write 记录一条被抑制的重复条目。(Write records a suppressed duplicate.)
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := dedupKey{level: ent.Level, logger: ent.LoggerName, message: ent.Message}
+	s := c.state
+	s.mu.Lock()
+	e, ok := s.pending[key]
+	if ok {
+		e.count++
+		e.ent = ent
+		e.fields = append(e.fields[:0], fields...)
+		e.core = c.Core
+	}
+	s.mu.Unlock()
+	if !ok {
+		// 窗口在 Check 和 Write 之间结束，按普通条目写出 (The window ended between Check and Write, so write it as a normal entry)
+		writeChecked(c.Core, ent, fields)
+	}
+	return nil
+}
+
+// Sync 先写出所有待汇总的条目，再同步底层 core。(Sync writes every pending summary before syncing the underlying core.)
+func (c *dedupCore) Sync() error {
+	c.state.flushAll()
+	return c.Core.Sync()
+}
+
+// flush 在窗口结束时写出 key 的汇总条目；窗口内有重复时延续窗口，否则结束去重。
+// (flush writes the summary of key when its window ends; the window is extended when there were duplicates, otherwise
+// deduplication of key ends.)
+func (s *dedupState) flush(key dedupKey) {
+	s.mu.Lock()
+	e, ok := s.pending[key]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	if e.count == 0 {
+		delete(s.pending, key)
+		s.mu.Unlock()
+		return
+	}
+	count, ent, fields, core := e.count, e.ent, e.fields, e.core
+	e.count, e.fields = 0, nil
+	e.timer.Reset(s.window)
+	s.mu.Unlock()
+	writeChecked(core, ent, append(fields, zap.Int(DedupRepeatedKey, count)))
+}
+
+// flushAll 写出所有待汇总的条目并清空状态。(flushAll writes every pending summary and clears the state.)
+func (s *dedupState) flushAll() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[dedupKey]*dedupEntry)
+	s.mu.Unlock()
+	for _, e := range pending {
+		e.timer.Stop()
+		if e.count > 0 {
+			writeChecked(e.core, e.ent, append(e.fields, zap.Int(DedupRepeatedKey, e.count)))
+		}
+	}
+}
+
+// writeChecked 经 core.Check 写出条目，使级别和路由规则生效。
+// (writeChecked writes the entry through core.Check so levels and route rules apply.)
+func writeChecked(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) {
+	if ce := core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for duplicate log suppression.
+ */
+
+package log_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer 是可并发写入的缓冲区，汇总条目由定时器协程写出。
+// (syncBuffer is a buffer safe for concurrent writes, since summaries are written from timer goroutines.)
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// entries 解析已写出的 JSON 行。(entries parses the JSON lines written so far.)
+func (b *syncBuffer) entries(t *testing.T) []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []map[string]any
+	scanner := bufio.NewScanner(strings.NewReader(b.buf.String()))
+	for scanner.Scan() {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		out = append(out, entry)
+	}
+	return out
+}
+
+// TestDedup tests that duplicates within the window are collapsed into one summary entry with the repeat count.
+// (TestDedup 测试窗口内的重复条目被合并为一条带重复次数的汇总条目。)
+func TestDedup(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.Dedup = log.DedupOptions{Window: 200 * time.Millisecond}
+	require.Empty(t, opts.Validate())
+
+	buf := &syncBuffer{}
+	logger := log.NewLoggerWithWriter(opts, buf)
+	for i := 0; i < 10; i++ {
+		logger.Errorw("connection refused", "attempt", i)
+	}
+	logger.Warn("connection refused")
+	logger.Info("other")
+
+	entries := buf.entries(t)
+	require.Len(t, entries, 3, "only the first of each distinct entry is written during the window")
+	assert.Equal(t, float64(0), entries[0]["attempt"])
+	assert.NotContains(t, entries[0], log.DedupRepeatedKey)
+
+	require.Eventually(t, func() bool { return len(buf.entries(t)) == 4 }, 2*time.Second, 10*time.Millisecond)
+	summary := buf.entries(t)[3]
+	assert.Equal(t, "connection refused", summary["M"])
+	assert.Equal(t, "ERROR", summary["L"])
+	assert.Equal(t, float64(9), summary[log.DedupRepeatedKey])
+	assert.Equal(t, float64(9), summary["attempt"], "the summary keeps the fields of the last duplicate")
+
+	// 汇总后窗口延续，新的重复仍被合并；Sync 写出待汇总条目并结束窗口，之后恢复正常记录
+	// (The window continues after a summary, so new duplicates are still collapsed; Sync writes pending summaries and ends the
+	// windows, after which entries are logged again)
+	logger.Error("connection refused")
+	require.NoError(t, logger.Sync())
+	entries = buf.entries(t)
+	require.Len(t, entries, 5)
+	assert.Equal(t, float64(1), entries[4][log.DedupRepeatedKey], "Sync writes pending summaries")
+
+	logger.Error("connection refused")
+	entries = buf.entries(t)
+	require.Len(t, entries, 6)
+	assert.NotContains(t, entries[5], log.DedupRepeatedKey)
+}
+
+// TestDedupValidate tests rejecting a negative window.
+// (TestDedupValidate 测试拒绝负的窗口。)
+func TestDedupValidate(t *testing.T) {
+	assert.Empty(t, log.DedupOptions{}.Validate())
+	assert.Len(t, log.DedupOptions{Window: -time.Second}.Validate(), 1)
+}
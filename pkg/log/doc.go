@@ -25,7 +25,9 @@ Core Features:
   - Multiple Output Paths: Can write logs to stdout, stderr, and one or more files simultaneously.
     (多输出路径：可以同时将日志写入 stdout、stderr 以及一个或多个文件。)
   - Log Rotation: Built-in support for log rotation based on size, age, and number of backups, with optional compression.
-    (日志轮转：内置支持基于大小、保留时间、备份数量的日志轮转，并可选压缩。)
+    LogRotateInterval ("daily", "hourly" or e.g. "6h") rotates by time instead, writing one dated file per period such as app-2024-05-03.log.
+    (日志轮转：内置支持基于大小、保留时间、备份数量的日志轮转，并可选压缩。
+    LogRotateInterval（"daily"、"hourly" 或例如 "6h"）改为按时间轮转，每个时间段写入一个带日期的文件，例如 app-2024-05-03.log。)
   - Dynamic Configuration: Integrates with `pkg/config` for hot-reloading of logging configurations (level, format, output paths, etc.)
     without application restart.
     (动态配置：与 `pkg/config` 集成，支持在不重启应用的情况下热重载日志配置（级别、格式、输出路径等）。)
@@ -185,6 +187,385 @@ log aggregation systems and human-readable structured logs.
 	// Values with spaces are automatically quoted (包含空格的值会自动加引号)
 	log.Infow("System status", "message", "all systems operational", "uptime", "24h")
 	// Output: 2024-01-15T10:30:47Z INFO System status message="all systems operational" uptime=24h
+
+	// Values are escaped so each entry stays one logfmt line, and nested maps are flattened into dotted keys
+	// (值会被转义，使每条日志保持为一行 logfmt；嵌套映射展开为点分隔的键)
+	log.Infow("Request failed", "error", "read: connection\nreset", "user", map[string]any{"id": 7, "name": "Jo Doe"})
+	// Output: ... Request failed error="read: connection\nreset" user.id=7 user.name="Jo Doe"
+
+Request-Scoped Logger:
+(请求级 Logger：)
+
+Middleware stores a logger with request fields via NewContextWithLogger; handlers read it back with
+FromContext, which falls back to the global logger when none is stored.
+(中间件通过 NewContextWithLogger 存入带请求字段的 logger，处理器通过 FromContext 取出；未存入时返回全局 logger。)
+
+	ctx = log.NewContextWithLogger(ctx, log.Std().WithValues("request_id", requestID))
+	log.FromContext(ctx).Infow("Processing request")
+
+Context Fields:
+(context 字段：)
+
+RegisterContextField makes the Ctx* methods of every logger write a context value under a field
+name, so values such as a tenant ID need not be passed on each call. Options.ContextFields does the
+same for a single logger. Missing values are skipped and names already written are not repeated.
+(RegisterContextField 使所有 logger 的 Ctx* 方法以指定字段名写出 context 中的值，租户 ID 等值无需在每次调用时传入。
+Options.ContextFields 对单个 logger 起相同作用。不存在的值会被跳过，已写出的字段名不会重复。)
+
+	remove := log.RegisterContextField("tenant_id", tenantKey{})
+	defer remove()
+	log.Std().Ctxw(context.WithValue(ctx, tenantKey{}, "acme"), "Order created") // tenant_id=acme
+
+Scoped Fields:
+(作用域字段：)
+
+WithScope returns a context carrying extra fields that every Ctx* call deeper in the call chain
+writes, like SLF4J's MDC; it helps code that only has a context. Nested scopes accumulate fields and
+the inner value wins for a repeated name. ScopeFields returns the fields of a context.
+(WithScope 返回携带额外字段的 context，调用链更深处的每个 Ctx* 调用都会写出这些字段，类似 SLF4J 的 MDC，适用于只持有 context 的代码。
+嵌套的作用域会累积字段，同名字段以内层为准。ScopeFields 返回 context 中的字段。)
+
+	ctx = log.WithScope(ctx, "job_id", job.ID, "tenant", job.Tenant)
+	process(ctx) // log.Std().Ctxw(ctx, "Step done") 带有 job_id 和 tenant (carries job_id and tenant)
+
+Context Hooks:
+(上下文钩子：)
+
+Hooks registered with AddContextHook are called for every enabled log written through
+the Ctx* methods and may append fields to it. pkg/trace uses them to correlate logs with spans.
+(通过 AddContextHook 注册的钩子会在每条已启用级别、经由 Ctx* 方法写出的日志上调用，并可向其追加字段。
+pkg/trace 使用钩子将日志与 span 关联。)
+
+	remove := log.AddContextHook(func(ctx context.Context, e log.Entry) []any {
+		if tenant, ok := ctx.Value(tenantKey).(string); ok {
+			return []any{"tenant", tenant}
+		}
+		return nil
+	})
+	defer remove()
+
+OpenTelemetry Trace Context:
+(OpenTelemetry 追踪上下文：)
+
+With Options.EnableOTelTraceContext, the Ctx* methods read the active OpenTelemetry span from
+the context and add trace_id, span_id and trace_flags in W3C Trace Context format, so logs
+correlate with traces in Jaeger or Tempo without calling ContextWithTraceID. A trace ID set with
+ContextWithTraceID and extracted through ContextKeys takes precedence.
+(启用 Options.EnableOTelTraceContext 后，Ctx* 方法会从 context 中读取当前的 OpenTelemetry span，并以 W3C Trace Context
+格式添加 trace_id、span_id 和 trace_flags，无需调用 ContextWithTraceID 即可在 Jaeger 或 Tempo 中将日志与追踪关联。
+通过 ContextWithTraceID 设置并由 ContextKeys 提取的 trace ID 优先。)
+
+	opts := log.NewOptions()
+	opts.EnableOTelTraceContext = true
+	log.Init(opts)
+
+	ctx, span := tracer.Start(ctx, "charge")
+	defer span.End()
+	log.Std().CtxInfof(ctx, "charging %s", orderID) // trace_id, span_id, trace_flags
+
+Service Identity:
+(服务标识：)
+
+When Options.Service.Name is set, every log entry carries service.name, service.version,
+deployment.environment.name and host.name (host defaults to os.Hostname()). The keys follow
+the OpenTelemetry resource conventions, and trace.NewResource builds a resource from the same
+section, so logs, metrics and traces share identical identity attributes.
+(设置 Options.Service.Name 后，每条日志都会带有 service.name、service.version、deployment.environment.name
+和 host.name（主机名默认取 os.Hostname()）。字段名遵循 OpenTelemetry 资源约定，trace.NewResource 基于同一配置段
+构建资源，从而使日志、指标和追踪共享完全相同的标识属性。)
+
+	opts := log.NewOptions()
+	opts.Service = log.ServiceOptions{Name: "checkout", Version: "1.2.3", Environment: "production"}
+	log.Init(opts)
+	res, err := trace.NewResource(ctx, opts.Service)
+
+Sampling:
+(采样：)
+
+Options.Sampling applies zap-style sampling: within each Tick, the first Initial entries with the
+same level and message are logged, then every Thereafter-th one. Levels overrides the rule per
+level, and an Initial of 0 there turns sampling off for that level. The section is part of the
+log configuration, so RegisterConfigHotReload applies changes without a restart.
+(Options.Sampling 提供 zap 风格的采样：每个 Tick 内相同级别和消息的条目先记录 Initial 条，之后每 Thereafter 条记录一条。
+Levels 按级别覆盖规则，其中 Initial 为 0 表示该级别不采样。该配置属于日志配置节，RegisterConfigHotReload 可在不重启的情况下生效。)
+
+	log:
+	  sampling:
+	    initial: 100
+	    thereafter: 100
+	    tick: 1s
+	    levels:
+	      error: {initial: 0} # never sample errors (错误日志不采样)
+
+Deduplication:
+(重复日志抑制：)
+
+Options.Dedup collapses identical entries within Window: the first entry with the same level, logger
+name and message is logged, the duplicates are counted, and one summary entry with a "repeated" field
+is written when the window ends. The window continues while duplicates arrive, so a tight error loop
+costs one line per window instead of filling the disk. DPanic and above are never suppressed.
+(Options.Dedup 合并 Window 内相同的条目：相同级别、记录器名称和消息的条目记录第一条，重复的只计数，窗口结束时写出一条带
+"repeated" 字段的汇总条目。只要重复持续出现，窗口就会延续，因此紧密的错误循环每个窗口只产生一行，不会写满磁盘。DPanic 及以上级别从不抑制。)
+
+	log:
+	  dedup:
+	    window: 10s
+
+Asynchronous Writes:
+(异步写入：)
+
+Options.AsyncBuffer puts file and network sinks behind a bounded ring buffer drained by a background
+goroutine, so callers do not wait on the disk. OverflowPolicy decides what happens when the buffer is
+full: "drop-oldest" (default), "drop-new" or "block". Sync waits for buffered entries, and
+AsyncBufferStats reports buffered, written and dropped counts per sink. stdout and stderr stay synchronous.
+(Options.AsyncBuffer 让文件和网络输出经过由后台协程写出的有界环形缓冲区，调用方无需等待磁盘。OverflowPolicy 决定缓冲区已满时的行为：
+"drop-oldest"（默认）、"drop-new" 或 "block"。Sync 会等待缓冲条目写出，AsyncBufferStats 按输出报告缓冲、已写和丢弃的条目数。stdout 和 stderr 保持同步写入。)
+
+	log:
+	  outputPaths: ["/var/log/app.log"]
+	  async-buffer:
+	    size: 8192
+	    overflow-policy: drop-oldest
+
+Log Volume Statistics:
+(日志量统计：)
+
+Stats returns the number of entries written since the process started and since the previous call, by
+level and by logger name, to find the components generating the most logs. Entries below the level or
+dropped by sampling or dedup are not counted. Options.StatsReport.Interval makes the global logger write
+the same counts for every interval as an info "Log volume" entry from the "log.stats" logger.
+(Stats 返回自进程启动以来和自上次调用以来写出的条目数，按级别和记录器名称统计，用于找出产生日志最多的组件。低于级别或被采样、
+去重丢弃的条目不计入。设置 Options.StatsReport.Interval 后，全局记录器每个间隔以 "log.stats" 记录器写出一条 info 级别的
+"Log volume" 条目，包含该间隔内的计数。)
+
+	stats := log.Stats()
+	fmt.Println(stats.SinceLastCall.Loggers["orders.db"], stats.Total.Levels["error"])
+
+	log:
+	  stats-report:
+	    interval: 1m
+
+Level Routes:
+(级别路由：)
+
+Options.LevelRoutes writes entries to different outputs by level rule. A rule is an optional
+operator (>=, <=, >, <, =) and a level name; an entry goes to every matching route. When set, it
+replaces OutputPaths.
+(Options.LevelRoutes 按级别规则将条目写入不同输出。规则为可选的运算符（>=、<=、>、<、=）加级别名，条目写入所有匹配的路由。
+设置后取代 OutputPaths。)
+
+	log:
+	  level-routes:
+	    ">=error": ["/var/log/app.err"]
+	    "<=info": ["stdout"]
+
+Options.SplitStdStreams is the shortcut container platforms such as Cloud Run and Nomad expect:
+debug and info go to stdout, warn and above to stderr. It cannot be combined with LevelRoutes.
+(Options.SplitStdStreams 是 Cloud Run、Nomad 等容器平台期望的快捷方式：debug 和 info 写入 stdout，warn 及以上写入 stderr。
+它不能与 LevelRoutes 同时使用。)
+
+	log:
+	  split-std-streams: true
+
+Audit Log:
+(审计日志：)
+
+Audit writes security audit events to the outputs in Options.Audit, apart from application logs and
+regardless of the log level. Every event is one JSON line with actor (from ContextWithActor), action,
+target, outcome ("success" unless overridden) and timestamp, plus a "seq" increasing by one and a
+"hash" chaining it to the previous event. VerifyAuditLog reports modified, removed or reordered events.
+Without audit outputs the events go to the application log as "Audit event" entries.
+(Audit 将安全审计事件写入 Options.Audit 中的输出，与应用日志分开，且不受日志级别影响。每条事件是一行 JSON，包含 actor（来自
+ContextWithActor）、action、target、outcome（默认 "success"）和 timestamp，以及逐条加一的 "seq" 和与前一条事件相连的 "hash"。
+VerifyAuditLog 会报告被修改、删除或重排的事件。未配置审计输出时，事件作为 "Audit event" 条目写入应用日志。)
+
+	ctx = log.ContextWithActor(ctx, "alice")
+	if err := log.Audit(ctx, "user.delete", "user/42", "outcome", log.AuditOutcomeDenied); err != nil {
+	    // 审计写入失败 (The audit write failed)
+	}
+
+	log:
+	  audit:
+	    output-paths: ["/var/log/app-audit.log"]
+
+Typed Fields:
+(强类型字段：)
+
+Debugs, Infos, Warns and Errors take fields built with String, Int, Int64, Float64, Bool, Duration,
+Time, Err and Any instead of alternating keys and values, so hot paths skip the boxing and type
+switches of the sugared API. The *w methods remain available; calls with up to eight string keys
+convert them through a pooled field slice instead of zap's SugaredLogger.
+(Debugs、Infos、Warns 和 Errors 接收由 String、Int、Int64、Float64、Bool、Duration、Time、Err 和 Any 创建的字段，
+而不是交替出现的键和值，热点路径因此省去 sugared API 的装箱和类型判断。*w 方法依然可用；不超过八个字符串键的调用
+通过池化的字段切片转换，而不经过 zap 的 SugaredLogger。)
+
+	log.Infos("request served", log.String("path", r.URL.Path), log.Int("status", 200), log.Duration("latency", d))
+
+Redaction:
+(脱敏：)
+
+Options.RedactKeys masks fields whose name matches a case-insensitive pattern such as "*password*",
+including matching keys inside logged maps and structs; DefaultRedactKeys lists common names.
+Options.RedactPatterns masks the parts of string values and messages matching a regular expression,
+and Options.RedactCardNumbers masks numbers passing the Luhn check. AddRedactor registers a global
+Redactor for anything else. Masked values are written as RedactedValue.
+(Options.RedactKeys 遮蔽名称匹配不区分大小写的模式（如 "*password*"）的字段，包括记录的 map 和结构体中匹配的键；
+DefaultRedactKeys 列出了常见名称。Options.RedactPatterns 遮蔽字符串值和消息中匹配正则表达式的部分，
+Options.RedactCardNumbers 遮蔽通过 Luhn 校验的卡号。其他情况可通过 AddRedactor 注册全局 Redactor。被遮蔽的值写为 RedactedValue。)
+
+	log:
+	  redact-keys: ["*password*", "*token*", "authorization"]
+	  redact-card-numbers: true
+
+Error Fields:
+(错误字段：)
+
+With Options.ExpandErrors, error fields are written as objects holding message, code, http_status
+and stack instead of the flat Error() string. The code and HTTP status come from the pkg/errors
+Coder, details attached with errors.WithDetails are written under "details", and the stack is
+the one captured closest to where the error was created. After
+errors.SetStackFormat(errors.StackFormatSingleLine) the stack is an array of
+{"function","file","line"} objects instead of a multi-line string.
+(启用 Options.ExpandErrors 后，错误字段写为包含 message、code、http_status 和 stack 的对象，而不是扁平的 Error() 字符串。
+错误码和 HTTP 状态码来自 pkg/errors 的 Coder，通过 errors.WithDetails 附加的详情写在 "details" 下，
+堆栈取最接近错误创建位置时捕获的那一个。调用 errors.SetStackFormat(errors.StackFormatSingleLine) 后，
+堆栈写为 {"function","file","line"} 对象数组，而不是多行字符串。)
+
+	{"M":"request failed","error":{"message":"Resource not found: user 42","code":100002,"http_status":404,"stack":"..."}}
+
+Caller Skip:
+(调用者跳过：)
+
+The caller ("C") is the code that called the logger, including for the package-level functions
+such as log.Info. Code wrapping the logger in its own helpers sets Options.CallerSkip to the number
+of wrapper layers, or derives a logger with WithCallerSkip(n) for one helper, so the caller points
+at the helper's caller rather than the helper itself. Both add up.
+(调用者（"C"）是调用记录器的代码，log.Info 等包级函数也是如此。将记录器包装在自己辅助函数中的代码可以把
+Options.CallerSkip 设为包装层数，或用 WithCallerSkip(n) 为某个辅助函数派生记录器，使调用者指向辅助函数的调用方
+而不是辅助函数本身。两者累加。)
+
+	var helperLogger = log.Std().WithCallerSkip(1)
+
+	func logFailure(msg string, err error) {
+		helperLogger.Errorw(msg, "error", err) // reported at the caller of logFailure
+	}
+
+Module Levels:
+(模块级别：)
+
+Options.ModuleLevels sets the level of loggers created with WithName. Names are hierarchical:
+WithName("db").WithName("pool") is "db.pool", which uses its own entry if present, otherwise the
+entry for "db", otherwise Level. Like the rest of the log section, module levels are applied by
+RegisterConfigHotReload, so one subsystem can be switched to debug in production. The level "off"
+silences a name and everything below it, for example a chatty third-party client. The full name is
+written with every entry under the "N" key, so a taxonomy such as "svc.http.client" can also be
+filtered downstream.
+(Options.ModuleLevels 为通过 WithName 创建的记录器设置级别。名称按层级组织：WithName("db").WithName("pool") 的名称为 "db.pool"，
+优先使用其自身的配置，否则使用 "db" 的配置，都没有时使用 Level。与日志配置节的其他部分一样，模块级别由 RegisterConfigHotReload 应用，
+因此可以在生产环境中只为某个子系统开启 debug。级别 "off" 关闭该名称及其下所有名称的输出，例如过于啰嗦的第三方客户端。
+完整名称随每条日志写在 "N" 键下，因此 "svc.http.client" 这样的命名体系也可以在下游过滤。)
+
+	log:
+	  level: info
+	  module-levels:
+	    db: debug
+	    http: warn
+	    svc.http.client: off
+
+Runtime Level Endpoint:
+(运行时级别端点：)
+
+SetLevel changes the global level without rebuilding the logger, and LevelHandler exposes it over
+HTTP: GET returns {"level":"info"}, PUT with {"level":"debug"} or ?level=debug changes it. Every
+change writes an audit entry with the previous and new level, the operator ("changed_by", the
+Basic auth user unless WithLevelChangedBy says otherwise), the remote address and the time; the
+entry is written whatever the current level. The debug server mounts it at /loglevel.
+(SetLevel 无需重建记录器即可调整全局级别，LevelHandler 通过 HTTP 暴露该能力：GET 返回 {"level":"info"}，
+PUT {"level":"debug"} 或 ?level=debug 进行调整。每次调整都会写出审计日志，记录调整前后的级别、操作者（"changed_by"，
+默认为 Basic 认证用户名，可用 WithLevelChangedBy 替换）、来源地址和时间；无论当前级别如何，审计日志都会写出。
+调试服务器将其挂载在 /loglevel。)
+
+	mux.Handle("/admin/loglevel", log.LevelHandler(log.WithLevelChangedBy(func(r *http.Request) string {
+		return r.Header.Get("X-Forwarded-User")
+	})))
+
+Custom Sinks and Encoders:
+(自定义输出与编码器：)
+
+RegisterSink adds an output scheme: OutputPaths entries such as "kafka://broker/topic" are parsed
+as URLs and passed to the registered factory. RegisterEncoder adds a format name usable in
+Options.Format; the factory receives the EncoderConfig derived from the other options. Register
+during program initialization, before the logger is built.
+(RegisterSink 注册输出 scheme：OutputPaths 中形如 "kafka://broker/topic" 的条目被解析为 URL 并交给已注册的工厂。
+RegisterEncoder 注册可用于 Options.Format 的格式名称，工厂接收由其他选项生成的 EncoderConfig。
+请在程序初始化阶段、构建 logger 之前注册。)
+
+	_ = log.RegisterSink("kafka", func(u *url.URL) (zapcore.WriteSyncer, error) {
+		return newKafkaWriter(u.Host, strings.TrimPrefix(u.Path, "/"))
+	})
+	_ = log.RegisterEncoder("logfmt", func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		return newLogfmtEncoder(cfg), nil
+	})
+
+	opts := log.NewOptions()
+	opts.Format = "logfmt"
+	opts.OutputPaths = []string{"stdout", "kafka://broker:9092/app-logs"}
+	log.Init(opts)
+
+System Log Sinks:
+(系统日志输出：)
+
+Two schemes are built in so that hosts without a file collection agent can log to the system log.
+"journald://" sends entries to systemd-journald over its native protocol: the level becomes PRIORITY,
+the caller becomes CODE_FILE and CODE_LINE and, with the JSON format, every field becomes an
+upper-case journal field (request_id becomes REQUEST_ID). The identifier query parameter sets
+SYSLOG_IDENTIFIER. "eventlog://<source>" writes to the Windows Event Log with the event type taken
+from the level and the event-id query parameter setting the event ID; it fails on other platforms.
+(内置两个 scheme，使没有文件采集代理的主机也能写入系统日志。"journald://" 通过原生协议将条目发送到 systemd-journald：
+级别写为 PRIORITY，调用者写为 CODE_FILE 和 CODE_LINE；使用 JSON 格式时，每个字段写为大写的日志字段
+（request_id 写为 REQUEST_ID）。查询参数 identifier 设置 SYSLOG_IDENTIFIER。"eventlog://<source>" 写入 Windows 事件日志，
+事件类型取自级别，查询参数 event-id 设置事件 ID；在其他平台上会失败。)
+
+	opts.Format = log.FormatJSON
+	opts.OutputPaths = []string{"journald://?identifier=orders"}     // Linux with systemd
+	opts.OutputPaths = []string{"eventlog://OrderService?event-id=100"} // Windows
+
+GELF Output:
+(GELF 输出：)
+
+"gelf://host:12201" sends entries to Graylog in the GELF 1.1 format, over UDP by default or over TCP
+with transport=tcp. The level becomes a syslog level, the message short_message, the stack trace
+full_message and, with the JSON format, every field an additional field (request_id becomes
+_request_id, nested objects are flattened as _user_id). UDP messages are gzip-compressed (compress
+selects gzip, zlib or none) and split into GELF chunks larger than chunk-size bytes (1420 by default).
+(“gelf://host:12201” 以 GELF 1.1 格式将条目发送到 Graylog，默认使用 UDP，transport=tcp 时使用 TCP。级别写为 syslog 级别，
+消息写为 short_message，堆栈写为 full_message；使用 JSON 格式时，每个字段写为附加字段（request_id 写为 _request_id，
+嵌套对象展开为 _user_id）。UDP 消息使用 gzip 压缩（compress 可选 gzip、zlib 或 none），超过 chunk-size 字节（默认 1420）时按 GELF 分块发送。)
+
+	opts.Format = log.FormatJSON
+	opts.OutputPaths = []string{"stdout", "gelf://graylog.internal:12201?host=orders-1"}
+
+log/slog Bridge:
+(log/slog 桥接：)
+
+NewSlogHandler returns a slog.Handler writing through the SDK logger, so libraries using log/slog
+share its outputs, format, sampling, redaction and runtime level; with a nil logger it follows the
+global logger. FromSlog goes the other way and returns a Logger writing to a *slog.Logger.
+(NewSlogHandler 返回经 SDK 记录器写出的 slog.Handler，使使用 log/slog 的库共享其输出、格式、采样、脱敏和运行时级别；
+传入 nil 时跟随全局记录器。FromSlog 方向相反，返回写入 *slog.Logger 的 Logger。)
+
+	slog.SetDefault(slog.New(log.NewSlogHandler(nil)))
+
+Testing:
+(测试：)
+
+The logtest subpackage provides a Logger capturing entries in memory; ContainsEntry asserts on the level,
+a message substring and fields, and the captured entries are printed when the test fails.
+(logtest 子包提供将日志捕获在内存中的 Logger；ContainsEntry 断言级别、消息子串和字段，测试失败时打印捕获的日志。)
+
+	logger := logtest.NewTestLogger(t)
+	NewOrderService(logger).Create(ctx, order)
+	logger.ContainsEntry("info", "Order created", "order_id", order.ID)
 */
 package log
 
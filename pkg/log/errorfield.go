@@ -0,0 +1,110 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"fmt"
+	"strings"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// 展开后的错误对象中的字段名。(Field names inside an expanded error object.)
+const (
+	// ErrorMessageKey 是错误消息字段。(ErrorMessageKey is the error message field.)
+	ErrorMessageKey = "message"
+	// ErrorCodeKey 是 Coder 的业务错误码字段。(ErrorCodeKey is the business error code of the Coder.)
+	ErrorCodeKey = "code"
+	// ErrorHTTPStatusKey 是 Coder 的 HTTP 状态码字段。(ErrorHTTPStatusKey is the HTTP status of the Coder.)
+	ErrorHTTPStatusKey = "http_status"
+	// ErrorStackKey 是错误创建时捕获的堆栈字段。(ErrorStackKey is the stack captured when the error was created.)
+	ErrorStackKey = "stack"
+	// ErrorDetailsKey 是通过 errors.WithDetails 附加的详情字段。(ErrorDetailsKey holds the details attached with errors.WithDetails.)
+	ErrorDetailsKey = "details"
+)
+
+// expandedError 将错误编码为包含消息、错误码、HTTP 状态码、详情和堆栈的对象。
+// (expandedError encodes an error as an object holding its message, code, HTTP status, details and stack.)
+type expandedError struct {
+	err error
+}
+
+func (e expandedError) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString(ErrorMessageKey, e.err.Error())
+	if coder := lmccerrors.GetCoder(e.err); coder != nil {
+		enc.AddInt(ErrorCodeKey, coder.Code())
+		enc.AddInt(ErrorHTTPStatusKey, coder.HTTPStatus())
+	}
+	if details := lmccerrors.Details(e.err); len(details) > 0 {
+		if err := enc.AddReflected(ErrorDetailsKey, details); err != nil {
+			return err
+		}
+	}
+	if st := lmccerrors.StackTraceOf(e.err); len(st) > 0 {
+		if lmccerrors.CurrentStackFormat() == lmccerrors.StackFormatSingleLine {
+			// 单行堆栈模式下写为帧数组 (Written as an array of frames in single-line stack mode)
+			return enc.AddReflected(ErrorStackKey, st.Frames())
+		}
+		enc.AddString(ErrorStackKey, strings.TrimPrefix(fmt.Sprintf("%+v", st), "\n"))
+	}
+	return nil
+}
+
+// expandErrorFields 将错误类型的字段替换为展开后的错误对象，没有错误字段时返回原切片。
+// (expandErrorFields replaces error fields with expanded error objects, returning the original slice when there are none.)
+func expandErrorFields(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		err, ok := f.Interface.(error)
+		if f.Type != zapcore.ErrorType || !ok {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make([]zapcore.Field, 0, len(fields)), fields[:i]...)
+		}
+		out = append(out, zap.Object(f.Key, expandedError{err: err}))
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// newErrorExpandingCore 在 enabled 为 true 时包装 core，使错误字段在编码前展开；否则原样返回 core。
+// (newErrorExpandingCore wraps core so error fields are expanded before encoding when enabled is true; otherwise core is returned as is.)
+func newErrorExpandingCore(core zapcore.Core, enabled bool) zapcore.Core {
+	if !enabled {
+		return core
+	}
+	return &errorExpandingCore{Core: core}
+}
+
+// errorExpandingCore 在写入前展开错误字段，通过 With 添加的字段在添加时展开。
+// (errorExpandingCore expands error fields before writing; fields added with With are expanded when added.)
+type errorExpandingCore struct {
+	zapcore.Core
+}
+
+func (c *errorExpandingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorExpandingCore{Core: c.Core.With(expandErrorFields(fields))}
+}
+
+func (c *errorExpandingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *errorExpandingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, expandErrorFields(fields))
+}
@@ -0,0 +1,88 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for expanding error fields into structured objects.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExpandErrors tests that coded, wrapped and plain errors become objects with message, code, http_status, details and stack.
+// (TestExpandErrors 测试带错误码的错误、包装的错误和普通错误被展开为包含 message、code、http_status、details 和 stack 的对象。)
+func TestExpandErrors(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.ExpandErrors = true
+	opts.RedactPatterns = []string{`tok-\w+`}
+	require.Empty(t, opts.Validate())
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	coded := lmccerrors.Wrap(lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42"), "load profile")
+	coded = lmccerrors.WithDetails(coded, map[string]any{"user_id": 42})
+	logger.WithValues("cause", errors.New("upstream")).Errorw("request failed", "error", coded, "attempt", 2)
+	logger.Errorw("refresh failed", "error", errors.New("bad token tok-abc"))
+
+	entries := decodeLogLines(t, buf.String())
+	require.Len(t, entries, 2)
+
+	expanded := entries[0]["error"].(map[string]any)
+	assert.Equal(t, coded.Error(), expanded["message"])
+	assert.EqualValues(t, lmccerrors.ErrNotFound.Code(), expanded["code"])
+	assert.EqualValues(t, 404, expanded["http_status"])
+	assert.Equal(t, map[string]any{"user_id": float64(42)}, expanded["details"])
+	assert.Contains(t, expanded["stack"], "TestExpandErrors")
+	assert.NotContains(t, entries[0], "errorVerbose")
+	assert.EqualValues(t, 2, entries[0]["attempt"])
+	assert.Equal(t, map[string]any{"message": "upstream"}, entries[0]["cause"], "fields added with With are expanded as well")
+
+	// 展开后的消息仍会脱敏 (The expanded message is still redacted)
+	assert.Equal(t, map[string]any{"message": "bad token " + log.RedactedValue}, entries[1]["error"])
+}
+
+// TestExpandErrors_Disabled tests that error fields stay flat strings by default.
+// (TestExpandErrors_Disabled 测试默认情况下错误字段保持为字符串。)
+func TestExpandErrors_Disabled(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	var buf bytes.Buffer
+	log.NewLoggerWithWriter(opts, &buf).Errorw("request failed", "error", lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "user 42"))
+
+	entries := decodeLogLines(t, buf.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Resource not found: user 42", entries[0]["error"])
+}
+
+// TestExpandErrors_SingleLineStack tests that the stack becomes an array of frames in single-line stack mode.
+// (TestExpandErrors_SingleLineStack 测试单行堆栈模式下堆栈写为帧数组。)
+func TestExpandErrors_SingleLineStack(t *testing.T) {
+	previous := lmccerrors.SetStackFormat(lmccerrors.StackFormatSingleLine)
+	t.Cleanup(func() { lmccerrors.SetStackFormat(previous) })
+
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.ExpandErrors = true
+	var buf bytes.Buffer
+	log.NewLoggerWithWriter(opts, &buf).Errorw("request failed", "error", lmccerrors.New("boom"))
+
+	entries := decodeLogLines(t, buf.String())
+	require.Len(t, entries, 1)
+	stack, ok := entries[0]["error"].(map[string]any)["stack"].([]any)
+	require.True(t, ok, "stack should be an array")
+	require.NotEmpty(t, stack)
+	frame := stack[0].(map[string]any)
+	assert.Contains(t, frame, "function")
+	assert.Contains(t, frame, "file")
+	assert.Contains(t, frame, "line")
+}
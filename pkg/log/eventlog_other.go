@@ -0,0 +1,21 @@
+//go:build !windows
+
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"net/url"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// newEventLogSink 在非 Windows 平台上拒绝 "eventlog://" 输出。(newEventLogSink rejects "eventlog://" outputs on platforms other than Windows.)
+func newEventLogSink(*url.URL) (zapcore.WriteSyncer, error) {
+	return nil, lmccerrors.NewWithCode(lmccerrors.ErrLogOptionInvalid, "eventlog output is only supported on Windows")
+}
@@ -0,0 +1,187 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field 是强类型的日志字段，由 String、Int、Err 等函数创建，传给 Infos 等方法时无需装箱和类型判断。
+// (Field is a strongly typed log field created by String, Int, Err and friends; passed to Infos and the other
+// typed methods it needs neither boxing nor type switches.)
+type Field = zap.Field
+
+// maxPooledFields 是 *w 方法走快速路径的最大字段数。(maxPooledFields is the largest field count taking the fast path of the *w methods.)
+const maxPooledFields = 8
+
+// fieldPool 复用 *w 方法快速路径中的字段切片。(fieldPool reuses the field slices of the fast path of the *w methods.)
+var fieldPool = sync.Pool{
+	New: func() any {
+		fields := make([]Field, 0, maxPooledFields)
+		return &fields
+	},
+}
+
+// String 创建字符串字段。(String creates a string field.)
+func String(key, value string) Field { return zap.String(key, value) }
+
+// Int 创建整数字段。(Int creates an integer field.)
+func Int(key string, value int) Field { return zap.Int(key, value) }
+
+// Int64 创建 int64 字段。(Int64 creates an int64 field.)
+func Int64(key string, value int64) Field { return zap.Int64(key, value) }
+
+// Float64 创建浮点数字段。(Float64 creates a float64 field.)
+func Float64(key string, value float64) Field { return zap.Float64(key, value) }
+
+// Bool 创建布尔字段。(Bool creates a boolean field.)
+func Bool(key string, value bool) Field { return zap.Bool(key, value) }
+
+// Duration 创建时长字段。(Duration creates a duration field.)
+func Duration(key string, value time.Duration) Field { return zap.Duration(key, value) }
+
+// Time 创建时间字段。(Time creates a time field.)
+func Time(key string, value time.Time) Field { return zap.Time(key, value) }
+
+// Err 创建键为 "error" 的错误字段；与 *w 方法中的错误值一样，启用 ExpandErrors 时会展开为消息、错误码和堆栈字段。
+// (Err creates an error field keyed "error"; like error values given to the *w methods, it is expanded into message,
+// code and stack fields when ExpandErrors is enabled.)
+func Err(err error) Field { return zap.Error(err) }
+
+// Any 根据 value 的类型创建字段，用于没有专门构造函数的类型。(Any creates a field based on the type of value, for types without a dedicated constructor.)
+func Any(key string, value any) Field { return zap.Any(key, value) }
+
+// acquireFields 将少量字符串键的键值对转换为池化的字段切片；参数过多、个数为奇数或键不是字符串时返回 false，
+// 由 zap 的 SugaredLogger 处理。使用完毕后须调用 releaseFields。
+// (acquireFields converts a few string-keyed pairs into a pooled field slice; it returns false for too many arguments,
+// an odd count or a non-string key, leaving them to zap's SugaredLogger. The slice must be returned with releaseFields.)
+func acquireFields(keysAndValues []any) (*[]Field, bool) {
+	if len(keysAndValues) == 0 || len(keysAndValues)%2 != 0 || len(keysAndValues) > 2*maxPooledFields {
+		return nil, false
+	}
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if _, ok := keysAndValues[i].(string); !ok {
+			return nil, false
+		}
+	}
+	fields := fieldPool.Get().(*[]Field)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		*fields = append(*fields, zap.Any(keysAndValues[i].(string), keysAndValues[i+1]))
+	}
+	return fields, true
+}
+
+// releaseFields 清空字段切片并放回池中。(releaseFields clears the field slice and puts it back into the pool.)
+func releaseFields(fields *[]Field) {
+	clear(*fields)
+	*fields = (*fields)[:0]
+	fieldPool.Put(fields)
+}
+
+// appendFieldsToMessage 在 key=value 格式下将字段附加到消息。(appendFieldsToMessage appends the fields to the message in the key=value format.)
+func appendFieldsToMessage(msg string, fields []Field) string {
+	if kvStr := formatFieldsAsKeyValue(fields); kvStr != "" {
+		return msg + " " + kvStr
+	}
+	return msg
+}
+
+// Debugs 在全局 logger 上调用 Debugs。
+// (Debugs calls Debugs on the global logger.)
+func Debugs(msg string, fields ...Field) {
+	global().Debugs(msg, fields...)
+}
+
+// Infos 在全局 logger 上调用 Infos。
+// (Infos calls Infos on the global logger.)
+func Infos(msg string, fields ...Field) {
+	global().Infos(msg, fields...)
+}
+
+// Warns 在全局 logger 上调用 Warns。
+// (Warns calls Warns on the global logger.)
+func Warns(msg string, fields ...Field) {
+	global().Warns(msg, fields...)
+}
+
+// Errors 在全局 logger 上调用 Errors。
+// (Errors calls Errors on the global logger.)
+func Errors(msg string, fields ...Field) {
+	global().Errors(msg, fields...)
+}
+
+func (l *logger) Debugs(msg string, fields ...Field) {
+	if l.opts.Format == FormatKeyValue {
+		if l.zapLogger.Core().Enabled(zapcore.DebugLevel) {
+			l.zapLogger.Debug(appendFieldsToMessage(msg, fields))
+		}
+		return
+	}
+	l.zapLogger.Debug(msg, fields...)
+}
+
+func (l *logger) Infos(msg string, fields ...Field) {
+	if l.opts.Format == FormatKeyValue {
+		if l.zapLogger.Core().Enabled(zapcore.InfoLevel) {
+			l.zapLogger.Info(appendFieldsToMessage(msg, fields))
+		}
+		return
+	}
+	l.zapLogger.Info(msg, fields...)
+}
+
+func (l *logger) Warns(msg string, fields ...Field) {
+	if l.opts.Format == FormatKeyValue {
+		if l.zapLogger.Core().Enabled(zapcore.WarnLevel) {
+			l.zapLogger.Warn(appendFieldsToMessage(msg, fields))
+		}
+		return
+	}
+	l.zapLogger.Warn(msg, fields...)
+}
+
+func (l *logger) Errors(msg string, fields ...Field) {
+	if l.opts.Format == FormatKeyValue {
+		if l.zapLogger.Core().Enabled(zapcore.ErrorLevel) {
+			l.zapLogger.Error(appendFieldsToMessage(msg, fields))
+		}
+		return
+	}
+	l.zapLogger.Error(msg, fields...)
+}
+
+func (kvl *keyValueLogger) Debugs(msg string, fields ...Field) {
+	if kvStr := formatKeyValuePairs(kvl.fields...); kvStr != "" {
+		msg = msg + " " + kvStr
+	}
+	kvl.baseLogger.Debugs(msg, fields...)
+}
+
+func (kvl *keyValueLogger) Infos(msg string, fields ...Field) {
+	if kvStr := formatKeyValuePairs(kvl.fields...); kvStr != "" {
+		msg = msg + " " + kvStr
+	}
+	kvl.baseLogger.Infos(msg, fields...)
+}
+
+func (kvl *keyValueLogger) Warns(msg string, fields ...Field) {
+	if kvStr := formatKeyValuePairs(kvl.fields...); kvStr != "" {
+		msg = msg + " " + kvStr
+	}
+	kvl.baseLogger.Warns(msg, fields...)
+}
+
+func (kvl *keyValueLogger) Errors(msg string, fields ...Field) {
+	if kvStr := formatKeyValuePairs(kvl.fields...); kvStr != "" {
+		msg = msg + " " + kvStr
+	}
+	kvl.baseLogger.Errors(msg, fields...)
+}
@@ -0,0 +1,141 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests and benchmarks for typed fields and the pooled fast path of the *w methods.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTypedFields tests that Infos and the other typed methods encode every field type and report the caller's file.
+// (TestTypedFields 测试 Infos 等强类型方法能编码各种字段类型，并报告调用方所在的文件。)
+func TestTypedFields(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Level = "debug"
+	opts.DisableStacktrace = true
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	logger.Infos("request served",
+		log.String("path", "/users"),
+		log.Int("status", 200),
+		log.Int64("bytes", 512),
+		log.Float64("ratio", 0.5),
+		log.Bool("cached", true),
+		log.Duration("latency", 1500*time.Millisecond),
+		log.Err(errors.New("partial")),
+		log.Any("tags", []string{"a", "b"}),
+	)
+	logger.Debugs("debug", log.Int("n", 1))
+	logger.Warns("warn", log.Int("n", 2))
+	logger.WithValues("svc", "api").Errors("error", log.Int("n", 3))
+
+	entries := decodeLogLines(t, buf.String())
+	require.Len(t, entries, 4)
+	assert.Equal(t, "/users", entries[0]["path"])
+	assert.EqualValues(t, 200, entries[0]["status"])
+	assert.EqualValues(t, 512, entries[0]["bytes"])
+	assert.EqualValues(t, 0.5, entries[0]["ratio"])
+	assert.Equal(t, true, entries[0]["cached"])
+	assert.NotNil(t, entries[0]["latency"])
+	assert.Equal(t, "partial", entries[0]["error"])
+	assert.Equal(t, []any{"a", "b"}, entries[0]["tags"])
+	assert.Contains(t, entries[0]["C"], "field_test.go")
+
+	assert.Equal(t, "DEBUG", entries[1]["L"])
+	assert.Equal(t, "WARN", entries[2]["L"])
+	assert.Equal(t, "api", entries[3]["svc"])
+	assert.EqualValues(t, 3, entries[3]["n"])
+}
+
+// TestTypedFields_KeyValue tests that typed fields are appended to the message in the key=value format.
+// (TestTypedFields_KeyValue 测试 key=value 格式下强类型字段被附加到消息中。)
+func TestTypedFields_KeyValue(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Format = log.FormatKeyValue
+	opts.DisableCaller = true
+	opts.DisableStacktrace = true
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	logger.WithValues("svc", "api").Infos("served", log.String("path", "/users"), log.Int("status", 200))
+	logger.Debugs("hidden", log.Int("n", 1))
+
+	assert.Contains(t, buf.String(), "served svc=api path=/users status=200")
+	assert.NotContains(t, buf.String(), "hidden")
+}
+
+// TestInfow_FastPath tests that the pooled fast path and the SugaredLogger fallback produce the same fields.
+// (TestInfow_FastPath 测试池化的快速路径与 SugaredLogger 回退路径输出相同的字段。)
+func TestInfow_FastPath(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	logger.Infow("fast", "user", "u1", "attempt", 2)
+	logger.Infow("fast again", "user", "u2")
+	// 超过池化上限的字段数由 SugaredLogger 处理 (More fields than the pooled limit go to the SugaredLogger)
+	many := make([]any, 0, 20)
+	for i := 0; i < 10; i++ {
+		many = append(many, string(rune('a'+i)), i)
+	}
+	logger.Infow("slow", many...)
+	logger.Infow("odd", "user", "u3", "dangling")
+
+	entries := decodeLogLines(t, buf.String())
+	require.GreaterOrEqual(t, len(entries), 4)
+	assert.Equal(t, "u1", entries[0]["user"])
+	assert.EqualValues(t, 2, entries[0]["attempt"])
+	assert.Contains(t, entries[0]["C"], "field_test.go")
+	assert.Equal(t, "u2", entries[1]["user"])
+	assert.NotContains(t, entries[1], "attempt", "pooled slices are cleared before reuse")
+	assert.EqualValues(t, 9, entries[2]["j"])
+	assert.Equal(t, "u3", entries[len(entries)-1]["user"])
+}
+
+func newBenchmarkLogger(b *testing.B) log.Logger {
+	b.Helper()
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	return log.NewLoggerWithWriter(opts, io.Discard)
+}
+
+func BenchmarkInfow(b *testing.B) {
+	logger := newBenchmarkLogger(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Infow("request served", "path", "/users", "status", 200, "cached", true)
+	}
+}
+
+func BenchmarkDebugwDisabled(b *testing.B) {
+	logger := newBenchmarkLogger(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Debugw("cache lookup", "key", "users/42", "hit", false)
+	}
+}
+
+func BenchmarkInfos(b *testing.B) {
+	logger := newBenchmarkLogger(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Infos("request served", log.String("path", "/users"), log.Int("status", 200), log.Bool("cached", true))
+	}
+}
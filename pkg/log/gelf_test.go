@@ -0,0 +1,200 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the GELF sink.
+ */
+
+package log_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// listenGELF 在本地 UDP 端口上监听，代替 Graylog。(listenGELF listens on a local UDP port in place of Graylog.)
+func listenGELF(t *testing.T) *net.UDPConn {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readGELFDatagram 读取一个数据报。(readGELFDatagram reads one datagram.)
+func readGELFDatagram(t *testing.T, conn *net.UDPConn) []byte {
+	t.Helper()
+	buf := make([]byte, 64*1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	return buf[:n]
+}
+
+// decodeGELF 解压并解析一条 GELF 消息。(decodeGELF decompresses and parses one GELF message.)
+func decodeGELF(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var r io.Reader = bytes.NewReader(data)
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(r)
+		require.NoError(t, err)
+		r = gz
+	case data[0] == 0x78:
+		zr, err := zlib.NewReader(r)
+		require.NoError(t, err)
+		r = zr
+	}
+	var msg map[string]any
+	require.NoError(t, json.NewDecoder(r).Decode(&msg))
+	return msg
+}
+
+// TestGELFSink_UDP tests that JSON entries are sent compressed with their level, timestamp and additional fields.
+// (TestGELFSink_UDP 测试 JSON 条目连同级别、时间戳和附加字段被压缩发送。)
+func TestGELFSink_UDP(t *testing.T) {
+	conn := listenGELF(t)
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{"gelf://" + conn.LocalAddr().String() + "?host=orders-1"}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+
+	before := time.Now()
+	logger.Warnw("disk almost full", "request_id", "req-1", "free-bytes", 1024, "cached", true,
+		"id", "u-7", "user", map[string]any{"name": "ann", "roles": []string{"admin"}})
+	data := readGELFDatagram(t, conn)
+	assert.Equal(t, []byte{0x1f, 0x8b}, data[:2], "UDP messages are gzip-compressed by default")
+	msg := decodeGELF(t, data)
+	assert.Equal(t, "1.1", msg["version"])
+	assert.Equal(t, "orders-1", msg["host"])
+	assert.Equal(t, "disk almost full", msg["short_message"])
+	assert.Equal(t, float64(4), msg["level"])
+	assert.InDelta(t, float64(before.UnixMilli())/1e3, msg["timestamp"], 2)
+	assert.Equal(t, "req-1", msg["_request_id"])
+	assert.Equal(t, float64(1024), msg["_free-bytes"])
+	assert.Equal(t, "true", msg["_cached"])
+	assert.Equal(t, "u-7", msg["_field_id"], "_id is reserved")
+	assert.Equal(t, "ann", msg["_user_name"], "nested objects are flattened")
+	assert.Equal(t, `["admin"]`, msg["_user_roles"])
+	assert.Contains(t, msg["_caller"], "gelf_test.go")
+	assert.NotContains(t, msg, "_ts")
+
+	logger.Errorw("payment failed", zap.Error(errors.New("declined")))
+	msg = decodeGELF(t, readGELFDatagram(t, conn))
+	assert.Equal(t, float64(3), msg["level"])
+	assert.Equal(t, "declined", msg["_error"])
+	assert.Contains(t, msg["full_message"], "TestGELFSink_UDP", "the stack trace is the full message")
+}
+
+// TestGELFSink_Chunking tests that messages larger than the chunk size are split into GELF chunks.
+// (TestGELFSink_Chunking 测试超过分块大小的消息按 GELF 分块发送。)
+func TestGELFSink_Chunking(t *testing.T) {
+	conn := listenGELF(t)
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{"gelf://" + conn.LocalAddr().String() + "?compress=none&chunk-size=512"}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+
+	payload := strings.Repeat("x", 2000)
+	logger.Infow("large", "payload", payload)
+
+	var whole []byte
+	var id []byte
+	for seq := 0; ; seq++ {
+		chunk := readGELFDatagram(t, conn)
+		require.LessOrEqual(t, len(chunk), 512)
+		require.Equal(t, []byte{0x1e, 0x0f}, chunk[:2], "chunk magic bytes")
+		if id == nil {
+			id = chunk[2:10]
+		}
+		assert.Equal(t, id, chunk[2:10], "chunks share the message ID")
+		assert.Equal(t, byte(seq), chunk[10])
+		whole = append(whole, chunk[12:]...)
+		if int(chunk[11]) == seq+1 {
+			break
+		}
+	}
+	msg := decodeGELF(t, whole)
+	assert.Equal(t, "large", msg["short_message"])
+	assert.Equal(t, payload, msg["_payload"])
+}
+
+// TestGELFSink_TCP tests that TCP messages are null-delimited and uncompressed.
+// (TestGELFSink_TCP 测试 TCP 消息以空字节分隔且不压缩。)
+func TestGELFSink_TCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	received := make(chan []byte, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			frame, err := r.ReadBytes(0)
+			if err != nil {
+				return
+			}
+			received <- frame
+		}
+	}()
+
+	opts := log.NewOptions()
+	opts.Format = log.FormatText
+	opts.OutputPaths = []string{"gelf://" + ln.Addr().String() + "?transport=tcp"}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+	logger.Info("first")
+	logger.Error("second")
+
+	for _, want := range []struct {
+		message string
+		level   float64
+	}{{"first", 6}, {"second", 3}} {
+		select {
+		case frame := <-received:
+			require.Equal(t, byte(0), frame[len(frame)-1])
+			msg := decodeGELF(t, frame[:len(frame)-1])
+			assert.Contains(t, msg["short_message"], want.message, "text entries are sent whole")
+			assert.Equal(t, want.level, msg["level"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("no GELF message received")
+		}
+	}
+}
+
+// TestGELFSink_InvalidOptions tests that invalid GELF outputs are rejected.
+// (TestGELFSink_InvalidOptions 测试拒绝无效的 GELF 输出。)
+func TestGELFSink_InvalidOptions(t *testing.T) {
+	for _, path := range []string{
+		"gelf://",
+		"gelf://127.0.0.1?transport=http",
+		"gelf://127.0.0.1?transport=tcp&compress=gzip",
+		"gelf://127.0.0.1?compress=brotli",
+		"gelf://127.0.0.1?chunk-size=8",
+	} {
+		opts := log.NewOptions()
+		opts.OutputPaths = []string{path}
+		_, err := log.NewLogger(opts)
+		require.Error(t, err, path)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLogOptionInvalid), "%s: %v", path, err)
+	}
+}
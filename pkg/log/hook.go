@@ -0,0 +1,109 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Entry 是传递给上下文钩子的日志条目。
+// (Entry is the log entry passed to context hooks.)
+type Entry struct {
+	// Time 是日志的时间戳。(Time is the timestamp of the log.)
+	Time time.Time
+	// Level 是日志级别。(Level is the log level.)
+	Level zapcore.Level
+	// Message 是日志消息，不含附加字段。(Message is the log message without additional fields.)
+	Message string
+}
+
+// ContextHook 在通过 Ctx* 方法写出日志之前调用，可用于与追踪等系统关联。
+// 返回的键值对会被追加到该条日志中。
+// (ContextHook is called before a log is written through the Ctx* methods, e.g. to correlate with tracing.)
+// (The returned key-value pairs are appended to that log entry.)
+type ContextHook func(ctx context.Context, entry Entry) []any
+
+// hookRegistration 包装钩子以便按指针移除。(hookRegistration wraps a hook so it can be removed by pointer.)
+type hookRegistration struct {
+	hook ContextHook
+}
+
+var (
+	// hooksMu 串行化钩子的注册与移除。(hooksMu serialises hook registration and removal.)
+	hooksMu sync.Mutex
+	// contextHooks 是写时复制的钩子列表，读取时无锁。(contextHooks is a copy-on-write hook list, read without locks.)
+	contextHooks atomic.Pointer[[]*hookRegistration]
+)
+
+// AddContextHook 注册一个全局上下文钩子，返回用于移除该钩子的函数。
+// 钩子只会在日志级别已启用时调用。
+// (AddContextHook registers a global context hook and returns a function that removes it.)
+// (Hooks are only called when the log level is enabled.)
+func AddContextHook(hook ContextHook) (remove func()) {
+	reg := &hookRegistration{hook: hook}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	var hooks []*hookRegistration
+	if cur := contextHooks.Load(); cur != nil {
+		hooks = append(hooks, *cur...)
+	}
+	hooks = append(hooks, reg)
+	contextHooks.Store(&hooks)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			hooksMu.Lock()
+			defer hooksMu.Unlock()
+			cur := contextHooks.Load()
+			if cur == nil {
+				return
+			}
+			remaining := make([]*hookRegistration, 0, len(*cur))
+			for _, r := range *cur {
+				if r != reg {
+					remaining = append(remaining, r)
+				}
+			}
+			contextHooks.Store(&remaining)
+		})
+	}
+}
+
+// runContextHooks 调用所有已注册的钩子并返回它们追加的字段。
+// message 为延迟求值，只有在存在钩子且级别启用时才会格式化。
+// (runContextHooks calls every registered hook and returns the fields they append.)
+// (message is evaluated lazily, only when hooks exist and the level is enabled.)
+func runContextHooks(ctx context.Context, core zapcore.Core, level zapcore.Level, message func() string) []any {
+	hooks := contextHooks.Load()
+	if hooks == nil || len(*hooks) == 0 || ctx == nil || !core.Enabled(level) {
+		return nil
+	}
+	entry := Entry{Time: time.Now(), Level: level, Message: message()}
+	var extra []any
+	for _, reg := range *hooks {
+		extra = append(extra, reg.hook(ctx, entry)...)
+	}
+	return extra
+}
+
+// contextHookFields 运行上下文钩子并将返回的键值对转换为 zap 字段。
+// (contextHookFields runs the context hooks and converts the returned key-value pairs into zap fields.)
+func (l *logger) contextHookFields(ctx context.Context, level zapcore.Level, message func() string) []zap.Field {
+	extra := runContextHooks(ctx, l.zapLogger.Core(), level, message)
+	if len(extra) == 0 {
+		return nil
+	}
+	return zapFields(extra...)
+}
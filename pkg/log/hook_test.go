@@ -0,0 +1,57 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for context hooks.
+ */
+
+package log_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestContextHook tests that hooks see enabled Ctx* logs and can append fields.
+// (TestContextHook 测试钩子能看到已启用级别的 Ctx* 日志并追加字段。)
+func TestContextHook(t *testing.T) {
+	logFilePath := filepath.Join(t.TempDir(), "hook.log")
+	opts := log.NewOptions()
+	opts.Level = "info"
+	opts.Format = "json"
+	opts.OutputPaths = []string{logFilePath}
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	var entries []log.Entry
+	remove := log.AddContextHook(func(ctx context.Context, entry log.Entry) []any {
+		entries = append(entries, entry)
+		return []any{"hooked", true}
+	})
+
+	ctx := context.Background()
+	log.Std().CtxDebugf(ctx, "filtered %d", 1)
+	log.Std().CtxErrorf(ctx, "failed %d", 2)
+	log.Ctxw(ctx, "plain")
+	remove()
+	remove() // 重复移除是安全的 (Removing twice is safe)
+	log.Std().CtxErrorf(ctx, "unhooked")
+	require.NoError(t, log.Sync())
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "failed 2", entries[0].Message)
+	assert.Equal(t, "plain", entries[1].Message)
+
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), `"hooked":true`))
+}
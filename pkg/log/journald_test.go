@@ -0,0 +1,137 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the journald and Windows Event Log sinks.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenJournal 在临时目录中监听一个 unixgram 套接字，代替 journald。
+// (listenJournal listens on a unixgram socket in a temporary directory in place of journald.)
+func listenJournal(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("journald is not available on Windows")
+	}
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, socket
+}
+
+// readJournalRecord 读取一个数据报并按 journald 原生协议解析其字段。
+// (readJournalRecord reads one datagram and parses its fields with the journald native protocol.)
+func readJournalRecord(t *testing.T, conn *net.UnixConn) map[string]string {
+	t.Helper()
+	buf := make([]byte, 64*1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	fields := make(map[string]string)
+	data := buf[:n]
+	for len(data) > 0 {
+		nl := bytes.IndexByte(data, '\n')
+		require.GreaterOrEqual(t, nl, 0)
+		line := data[:nl]
+		if eq := bytes.IndexByte(line, '='); eq >= 0 {
+			fields[string(line[:eq])] = string(line[eq+1:])
+			data = data[nl+1:]
+			continue
+		}
+		size := binary.LittleEndian.Uint64(data[nl+1 : nl+9])
+		fields[string(line)] = string(data[nl+9 : nl+9+int(size)])
+		data = data[nl+9+int(size)+1:]
+	}
+	return fields
+}
+
+// TestJournaldSink tests that JSON entries are sent with their priority, caller and structured fields.
+// (TestJournaldSink 测试 JSON 条目以其优先级、调用者和结构化字段发送。)
+func TestJournaldSink(t *testing.T) {
+	conn, socket := listenJournal(t)
+	opts := log.NewOptions()
+	opts.Format = log.FormatJSON
+	opts.OutputPaths = []string{"journald://" + socket + "?identifier=orders"}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+
+	logger.Warnw("disk almost full", "request_id", "req-1", "free-bytes", 1024, "detail", "line1\nline2", "message", "user field")
+	record := readJournalRecord(t, conn)
+	assert.Equal(t, "4", record["PRIORITY"])
+	assert.Equal(t, "orders", record["SYSLOG_IDENTIFIER"])
+	assert.Equal(t, "disk almost full", record["MESSAGE"])
+	assert.Equal(t, "req-1", record["REQUEST_ID"])
+	assert.Equal(t, "1024", record["FREE_BYTES"])
+	assert.Equal(t, "line1\nline2", record["DETAIL"], "multi-line values use the binary form")
+	assert.Equal(t, "user field", record["FIELD_MESSAGE"], "fields colliding with journald fields are prefixed")
+	assert.Equal(t, "journald_test.go", filepath.Base(record["CODE_FILE"]))
+	assert.NotEmpty(t, record["CODE_LINE"])
+	assert.NotContains(t, record, "TS")
+
+	logger.Errorw("payment failed", "error", errors.New("declined"))
+	record = readJournalRecord(t, conn)
+	assert.Equal(t, "3", record["PRIORITY"])
+	assert.Equal(t, "declined", record["ERROR"])
+}
+
+// TestJournaldSink_TextFormat tests that text entries are sent as messages with the priority of their level.
+// (TestJournaldSink_TextFormat 测试文本格式的条目作为消息发送，优先级取自其级别。)
+func TestJournaldSink_TextFormat(t *testing.T) {
+	conn, socket := listenJournal(t)
+	opts := log.NewOptions()
+	opts.Format = log.FormatText
+	opts.Level = "debug"
+	opts.EnableColor = true
+	opts.OutputPaths = []string{"journald://" + socket}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+
+	logger.Debug("cache miss")
+	record := readJournalRecord(t, conn)
+	assert.Equal(t, "7", record["PRIORITY"])
+	assert.Contains(t, record["MESSAGE"], "cache miss")
+	assert.NotContains(t, record["MESSAGE"], "\x1b[", "color codes are stripped")
+	assert.NotEmpty(t, record["SYSLOG_IDENTIFIER"], "the identifier defaults to the executable name")
+}
+
+// TestJournaldSink_Unavailable tests that a missing journald socket fails logger creation.
+// (TestJournaldSink_Unavailable 测试 journald 套接字不存在时创建日志记录器失败。)
+func TestJournaldSink_Unavailable(t *testing.T) {
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{"journald://" + filepath.Join(t.TempDir(), "missing.sock")}
+	_, err := log.NewLogger(opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLogInitialization))
+}
+
+// TestEventLogSink_UnsupportedPlatform tests that eventlog outputs are rejected outside Windows.
+// (TestEventLogSink_UnsupportedPlatform 测试在 Windows 以外的平台上拒绝 eventlog 输出。)
+func TestEventLogSink_UnsupportedPlatform(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the Windows Event Log is available on Windows")
+	}
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{"eventlog://OrderService"}
+	_, err := log.NewLogger(opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLogOptionInvalid))
+}
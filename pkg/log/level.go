@@ -0,0 +1,43 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// GetLevel 返回全局日志记录器当前生效的级别字符串。
+// (GetLevel returns the currently effective level of the global logger as a string.)
+func GetLevel() string {
+	l, ok := Std().(*logger)
+	if !ok || l.level == nil {
+		return ""
+	}
+	return l.level.Level().String()
+}
+
+// SetLevel 在运行时调整全局日志记录器的级别，无需重建记录器。
+// 通过 WithValues/WithName 派生的记录器共享同一级别，因此也会随之生效。
+// (SetLevel adjusts the level of the global logger at runtime without rebuilding it.)
+// (Loggers derived via WithValues/WithName share the same level and are affected as well.)
+func SetLevel(level string) error {
+	var zapLevel zapcore.Level
+	if err := zapLevel.UnmarshalText([]byte(level)); err != nil {
+		return lmccerrors.WithCode(
+			lmccerrors.Wrapf(err, "invalid log level '%s'", level),
+			lmccerrors.ErrLogOptionInvalid,
+		)
+	}
+
+	l, ok := Std().(*logger)
+	if !ok || l.level == nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrLogInternal, "global logger does not support dynamic level changes")
+	}
+	l.level.SetLevel(zapLevel)
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the runtime level HTTP handler.
+ */
+
+package log_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLevelHandler tests reading and changing the level over HTTP and the audit entry written for each change.
+// (TestLevelHandler 测试通过 HTTP 查询和调整级别，以及每次调整写出的审计日志。)
+func TestLevelHandler(t *testing.T) {
+	logFilePath := filepath.Join(t.TempDir(), "level.log")
+	opts := log.NewOptions()
+	opts.Level = "info"
+	opts.Format = log.FormatJSON
+	opts.OutputPaths = []string{logFilePath}
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	h := log.LevelHandler()
+	do := func(method, target, body string, prepare func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if prepare != nil {
+			prepare(req)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodGet, "/", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"info"}`, rec.Body.String())
+
+	rec = do(http.MethodPut, "/", `{"level":"debug"}`, func(r *http.Request) { r.SetBasicAuth("oncall", "secret") })
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"debug"}`, rec.Body.String())
+	assert.Equal(t, "debug", log.GetLevel())
+
+	// 调到 error 后审计日志仍然写出 (The audit entry is still written after switching to error)
+	rec = do(http.MethodPut, "/?level=error", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "error", log.GetLevel())
+
+	rec = do(http.MethodPut, "/", `{"level":"loud"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "error", log.GetLevel())
+	assert.Equal(t, http.StatusMethodNotAllowed, do(http.MethodDelete, "/", "", nil).Code)
+
+	require.NoError(t, log.Sync())
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	var audits []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["audit"] == "log_level" {
+			audits = append(audits, entry)
+		}
+	}
+	require.Len(t, audits, 2)
+	assert.Equal(t, "Log level changed", audits[0]["M"])
+	assert.Equal(t, "info", audits[0]["from"])
+	assert.Equal(t, "debug", audits[0]["to"])
+	assert.Equal(t, "oncall", audits[0]["changed_by"])
+	assert.NotEmpty(t, audits[0]["remote_addr"])
+	assert.NotEmpty(t, audits[0]["ts"], "the entry records when the change happened")
+	assert.Equal(t, "debug", audits[1]["from"])
+	assert.Equal(t, "error", audits[1]["to"])
+	assert.Equal(t, "anonymous", audits[1]["changed_by"])
+}
+
+// TestLevelHandler_ChangedBy tests identifying the operator with WithLevelChangedBy.
+// (TestLevelHandler_ChangedBy 测试使用 WithLevelChangedBy 识别操作者。)
+func TestLevelHandler_ChangedBy(t *testing.T) {
+	logFilePath := filepath.Join(t.TempDir(), "level.log")
+	opts := log.NewOptions()
+	opts.Format = log.FormatJSON
+	opts.OutputPaths = []string{logFilePath}
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	h := log.LevelHandler(log.WithLevelChangedBy(func(r *http.Request) string {
+		return r.Header.Get("X-Forwarded-User")
+	}))
+	req := httptest.NewRequest(http.MethodPut, "/?level=warn", nil)
+	req.Header.Set("X-Forwarded-User", "alice@example.com")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NoError(t, log.Sync())
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"changed_by":"alice@example.com"`)
+}
@@ -0,0 +1,52 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for runtime level adjustment of the global logger.
+ */
+
+package log_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSetLevel tests changing the global log level at runtime.
+// (TestSetLevel 测试在运行时调整全局日志级别。)
+func TestSetLevel(t *testing.T) {
+	logFilePath := filepath.Join(t.TempDir(), "level.log")
+	opts := log.NewOptions()
+	opts.Level = "info"
+	opts.OutputPaths = []string{logFilePath}
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	assert.Equal(t, "info", log.GetLevel())
+
+	derived := log.WithValues("component", "level-test")
+	derived.Debug("suppressed debug message")
+
+	require.NoError(t, log.SetLevel("debug"))
+	assert.Equal(t, "debug", log.GetLevel())
+
+	// 派生的记录器共享同一级别 (Derived loggers share the same level)
+	derived.Debug("visible debug message")
+	require.NoError(t, log.Sync())
+
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "suppressed debug message")
+	assert.Contains(t, string(content), "visible debug message")
+
+	err = log.SetLevel("verbose")
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLogOptionInvalid))
+	assert.Equal(t, "debug", log.GetLevel(), "invalid level should leave the current level untouched")
+}
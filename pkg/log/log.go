@@ -87,6 +87,19 @@ type Logger interface {
 	// (DPanicw logs a message at DPanicLevel with key-value pairs.)
 	DPanicw(msg string, keysAndValues ...any)
 
+	// Debugs 记录一条 Debug 级别的消息，并附带强类型字段，适用于热点路径。
+	// (Debugs logs a message at DebugLevel with strongly typed fields, for hot paths.)
+	Debugs(msg string, fields ...Field)
+	// Infos 记录一条 Info 级别的消息，并附带强类型字段，适用于热点路径。
+	// (Infos logs a message at InfoLevel with strongly typed fields, for hot paths.)
+	Infos(msg string, fields ...Field)
+	// Warns 记录一条 Warn 级别的消息，并附带强类型字段，适用于热点路径。
+	// (Warns logs a message at WarnLevel with strongly typed fields, for hot paths.)
+	Warns(msg string, fields ...Field)
+	// Errors 记录一条 Error 级别的消息，并附带强类型字段，适用于热点路径。
+	// (Errors logs a message at ErrorLevel with strongly typed fields, for hot paths.)
+	Errors(msg string, fields ...Field)
+
 	// Ctx 使用 fmt.Sprint 风格记录一条 Info 级别的消息，并从 context 中提取字段。
 	// (Ctx logs a message at InfoLevel using fmt.Sprint style, extracting fields from the context.)
 	Ctx(ctx context.Context, args ...any)
@@ -109,6 +122,12 @@ type Logger interface {
 	// (WithName adds a new element to the logger's name.)
 	WithName(name string) Logger
 
+	// WithCallerSkip 返回报告调用者时额外跳过 n 个栈帧的日志记录器，供包装 SDK 记录器的辅助函数使用，
+	// 使调用者指向辅助函数的调用方。n 与 Options.CallerSkip 及之前的 WithCallerSkip 累加。
+	// (WithCallerSkip returns a logger that skips n more stack frames when reporting the caller, for helpers wrapping the
+	// SDK logger so the caller points at the helper's caller. n adds to Options.CallerSkip and earlier WithCallerSkip calls.)
+	WithCallerSkip(n int) Logger
+
 	// GetZapLogger 返回底层的 zap.Logger。
 	// (GetZapLogger returns the underlying zap.Logger.)
 	GetZapLogger() *zap.Logger
@@ -140,7 +159,11 @@ type Logger interface {
 // (Note: Keep the logger struct itself unexported to encapsulate implementation details.)
 type logger struct {
 	zapLogger *zap.Logger
-	opts      *Options // Store applied options
+	opts      *Options               // Store applied options
+	level     *zap.AtomicLevel       // 可在运行时调整的级别 (Level adjustable at runtime)
+	async     []*asyncWriter         // 启用 AsyncBuffer 时的异步输出 (Async sinks when AsyncBuffer is enabled)
+	audit     zapcore.WriteSyncer    // 配置 Audit 时的审计输出，仅根记录器持有 (Audit sink when Audit is configured; held by the root logger only)
+	facade    atomic.Pointer[logger] // 全局函数使用的派生记录器，按需创建 (Derived logger used by the global functions, created on demand)
 }
 
 // keyValueLogger 是一个包装器，用于在 key=value 格式下处理 WithValues
@@ -184,7 +207,8 @@ func Init(opts *Options) {
 			lmccerrors.ErrLogInitialization,
 		))
 	}
-	std.Store(l)
+	closeAsyncWriters(std.Swap(l))
+	restartStatsReport(l)
 }
 
 // NewLogger 根据提供的选项创建一个新的 Logger 实例。
@@ -206,6 +230,12 @@ func NewLogger(opts *Options) (Logger, error) {
 // (This function ensures thread-safety through double-checked locking and atomic operations.)
 // (If an error occurs during the first lazy initialization, it will panic.)
 func Std() Logger {
+	return stdLogger()
+}
+
+// stdLogger 返回全局 *logger，必要时使用默认选项初始化。
+// (stdLogger returns the global *logger, initializing it with default options if needed.)
+func stdLogger() *logger {
 	l := std.Load()
 	if l == nil {
 		mu.Lock()
@@ -256,7 +286,9 @@ func ReconfigureGlobalLogger(newOpts *Options) error {
 			lmccerrors.ErrLogReconfigure,
 		)
 	}
-	std.Store(newL)
+	// 旧记录器的异步输出在写完缓冲条目后关闭 (The old logger's async sinks are closed once their buffered entries are written)
+	closeAsyncWriters(std.Swap(newL))
+	restartStatsReport(newL)
 	return nil
 }
 
@@ -288,19 +320,19 @@ func (l *logger) DPanicw(msg string, keysAndValues ...any) {
 // DPanic 在全局 logger 上调用 DPanic。
 // (DPanic calls DPanic on the global logger.)
 func DPanic(args ...any) {
-	Std().DPanic(args...)
+	global().DPanic(args...)
 }
 
 // DPanicf 在全局 logger 上调用 DPanicf。
 // (DPanicf calls DPanicf on the global logger.)
 func DPanicf(template string, args ...any) {
-	Std().DPanicf(template, args...)
+	global().DPanicf(template, args...)
 }
 
 // DPanicw 在全局 logger 上调用 DPanicw。
 // (DPanicw calls DPanicw on the global logger.)
 func DPanicw(msg string, keysAndValues ...any) {
-	Std().DPanicw(msg, keysAndValues...)
+	global().DPanicw(msg, keysAndValues...)
 }
 
 // getEncoderConfig 根据选项创建并返回一个 zapcore.EncoderConfig。
@@ -368,8 +400,9 @@ func getEncoderConfig(opts *Options) zapcore.EncoderConfig {
 }
 
 // newLoggerInternal 是创建 zap.Logger 的核心逻辑，可被 NewLogger 和 NewLoggerWithWriter 复用。
-// 它接收 Options 和一个已经构建好的 zapcore.WriteSyncer。
-func newLoggerInternal(opts *Options, syncer zapcore.WriteSyncer) (*zap.Logger, *zap.AtomicLevel, error) {
+// 它接收 Options 和一个已经构建好的 zapcore.WriteSyncer；提供级别路由时按路由写入，不使用 syncer。
+// (When level routes are given, entries are written by route and syncer is not used.)
+func newLoggerInternal(opts *Options, syncer zapcore.WriteSyncer, routes ...levelRoute) (*zap.Logger, *zap.AtomicLevel, error) {
 	if opts == nil {
 		return nil, nil, lmccerrors.NewWithCode(lmccerrors.ErrLogOptionInvalid, "options cannot be nil for newLoggerInternal")
 	}
@@ -397,17 +430,40 @@ func newLoggerInternal(opts *Options, syncer zapcore.WriteSyncer) (*zap.Logger,
 		encoder = zapcore.NewJSONEncoder(encoderConfig)
 	} else if opts.Format == FormatText || opts.Format == FormatKeyValue {
 		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	} else if factory, ok := lookupEncoder(opts.Format); ok {
+		// 通过 RegisterEncoder 注册的自定义格式 (Custom format registered with RegisterEncoder)
+		var err error
+		if encoder, err = factory(encoderConfig); err != nil {
+			return nil, nil, lmccerrors.WithCode(
+				lmccerrors.Wrapf(err, "failed to create encoder '%s'", opts.Format),
+				lmccerrors.ErrLogInitialization,
+			)
+		}
 	} else {
 		// Validate() 应该已经捕获了这个问题，但作为防御性检查
 		// (Validate() should have caught this, but as a defensive check)
 		return nil, nil, lmccerrors.ErrorfWithCode(lmccerrors.ErrLogOptionInvalid, "invalid log format: %s", opts.Format)
 	}
 
-	core := zapcore.NewCore(encoder, syncer, atomicLevel)
+	enabler, withModuleLevels := newModuleLevelCore(atomicLevel, opts.ModuleLevels)
+	redact := newRedaction(opts)
+	// 错误字段先展开再脱敏，随后编码 (Error fields are expanded, then redacted, right before encoding)
+	leaf := func(c zapcore.Core) zapcore.Core {
+		return newErrorExpandingCore(newRedactingCore(c, redact), opts.ExpandErrors)
+	}
+	var base zapcore.Core
+	if len(routes) > 0 {
+		base = newRoutedCore(encoder, enabler, routes, leaf)
+	} else {
+		base = leaf(zapcore.NewCore(encoder, syncer, enabler))
+	}
+	// 统计实际写出的条目，写入多个路由的条目只计一次 (Count the entries actually written, once even when written to several routes)
+	base = &countingCore{Core: base}
+	core := withModuleLevels(newSamplingCore(newDedupCore(base, opts.Dedup), opts.Sampling))
 
 	var zapOpts []zap.Option
 	if !opts.DisableCaller { // 使用 !opts.DisableCaller
-		zapOpts = append(zapOpts, zap.AddCaller(), zap.AddCallerSkip(1)) // Skip our wrapper method
+		zapOpts = append(zapOpts, zap.AddCaller(), zap.AddCallerSkip(1+opts.CallerSkip)) // Skip our wrapper method and user wrappers
 	}
 
 	if opts.Development {
@@ -427,6 +483,15 @@ func newLoggerInternal(opts *Options, syncer zapcore.WriteSyncer) (*zap.Logger,
 		zapOpts = append(zapOpts, zap.AddStacktrace(stacktraceLevel))
 	}
 
+	// 服务标识作为标准字段 (Service identity as standard fields)
+	if fields := opts.Service.Fields(); len(fields) > 0 {
+		zapFields := make([]zap.Field, 0, len(fields)/2)
+		for i := 0; i < len(fields); i += 2 {
+			zapFields = append(zapFields, zap.String(fields[i].(string), fields[i+1].(string)))
+		}
+		zapOpts = append(zapOpts, zap.Fields(zapFields...))
+	}
+
 	// Options 结构体中没有 ZapOptions 字段，移除相关代码
 	// if len(opts.ZapOptions) > 0 {
 	// zapOpts = append(zapOpts, opts.ZapOptions...)
@@ -447,8 +512,18 @@ func newLogger(opts *Options) (*logger, error) { // Changed return type to (*log
 		opts = NewOptions() // 使用默认选项，如果提供的是 nil (Use default options if nil is provided)
 	}
 
-	// 获取写入同步器 (Get write syncer)
-	writeSyncer, err := getWriteSyncer(opts) // getWriteSyncer will handle OutputPaths
+	// 获取写入同步器；配置了级别路由时按路由创建 (Get write syncer; built per route when level routes are configured)
+	var (
+		writeSyncer zapcore.WriteSyncer
+		routes      []levelRoute
+		async       []*asyncWriter
+		err         error
+	)
+	if len(opts.levelRoutes()) > 0 {
+		routes, async, err = getLevelRoutes(opts)
+	} else {
+		writeSyncer, async, err = getWriteSyncer(opts) // getWriteSyncer will handle OutputPaths
+	}
 	if err != nil {
 		// 返回带有上下文的错误，而不是 panic (Return an error with context instead of panic)
 		// 确保返回的错误是 ErrLogInitialization 类型 (Ensure the returned error is of type ErrLogInitialization)
@@ -458,8 +533,11 @@ func newLogger(opts *Options) (*logger, error) { // Changed return type to (*log
 		)
 	}
 
-	zapL, _, err := newLoggerInternal(opts, writeSyncer) // Use newLoggerInternal
+	zapL, atomicLevel, err := newLoggerInternal(opts, writeSyncer, routes...) // Use newLoggerInternal
 	if err != nil {
+		for _, w := range async {
+			w.Close()
+		}
 		// 如果 newLoggerInternal 返回错误，则将其包装并返回
 		// (If newLoggerInternal returns an error, wrap and return it)
 		return nil, lmccerrors.WithCode(
@@ -468,10 +546,29 @@ func newLogger(opts *Options) (*logger, error) { // Changed return type to (*log
 		)
 	}
 
+	// 审计输出始终同步写入，不会因缓冲区已满而丢弃事件 (Audit sinks are always written synchronously so no event is dropped on a full buffer)
+	var auditSyncer zapcore.WriteSyncer
+	if opts.Audit.Enabled() {
+		auditOpts := *opts
+		auditOpts.AsyncBuffer = AsyncBufferOptions{}
+		if auditSyncer, _, err = getWriteSyncerForPaths(opts.Audit.OutputPaths, &auditOpts); err != nil {
+			for _, w := range async {
+				w.Close()
+			}
+			return nil, lmccerrors.WithCode(
+				lmccerrors.Wrap(err, "failed to get write syncer for audit log"),
+				lmccerrors.ErrLogInitialization,
+			)
+		}
+	}
+
 	// 返回包装后的 logger (Return the wrapped logger)
 	return &logger{
 		zapLogger: zapL,
 		opts:      opts, // 存储应用的选项 (Store applied options)
+		level:     atomicLevel,
+		async:     async,
+		audit:     auditSyncer,
 	}, nil
 }
 
@@ -487,7 +584,7 @@ func NewLoggerWithWriter(opts *Options, writer io.Writer) Logger {
 	// 直接使用传入的 writer 创建 WriteSyncer
 	writeSyncer := zapcore.AddSync(writer)
 
-	zapL, _, err := newLoggerInternal(opts, writeSyncer) // Use newLoggerInternal
+	zapL, atomicLevel, err := newLoggerInternal(opts, writeSyncer) // Use newLoggerInternal
 	if err != nil {
 		// 这种情况理论上不应该发生，因为我们控制了 writer 且 newLoggerInternal 内部处理了其他选项错误
 		// 但如果 newLoggerInternal 的其他部分失败了
@@ -500,6 +597,7 @@ func NewLoggerWithWriter(opts *Options, writer io.Writer) Logger {
 	return &logger{
 		zapLogger: zapL,
 		opts:      opts,
+		level:     atomicLevel,
 	}
 }
 
@@ -527,28 +625,40 @@ func SetGlobalLogger(l Logger) {
 		panic(fmt.Sprintf("SetGlobalLogger: incompatible logger type %T, expected *logger created by this package", l))
 	}
 	std.Store(internalLog)
+	restartStatsReport(internalLog)
 }
 
 // getWriteSyncer 根据提供的选项确定并返回一个 zapcore.WriteSyncer。
 //它可以配置为写入标准输出、标准错误或一个或多个文件。
 // (getWriteSyncer determines and returns a zapcore.WriteSyncer based on the provided options.)
 // (It can be configured to write to stdout, stderr, or one or more files.)
-func getWriteSyncer(opts *Options) (zapcore.WriteSyncer, error) {
+// 启用 AsyncBuffer 时还返回包装文件和网络输出的异步写入器。
+// (When AsyncBuffer is enabled it also returns the async writers wrapping file and network sinks.)
+func getWriteSyncer(opts *Options) (zapcore.WriteSyncer, []*asyncWriter, error) {
 	if len(opts.OutputPaths) == 0 {
 		// 如果没有指定输出路径，则默认为 stdout (Default to stdout if no output paths are specified)
 		// 但通常 NewOptions 会设置默认值，所以这里更多是防御性编程
 		// (But usually NewOptions sets defaults, so this is more defensive)
-		return zapcore.AddSync(os.Stdout), nil
+		return zapcore.AddSync(os.Stdout), nil, nil
 	}
 	return getWriteSyncerForPaths(opts.OutputPaths, opts)
 }
 
 // getWriteSyncerForPaths 为给定的路径列表创建一个 zapcore.WriteSyncer。
-// 支持 "stdout", "stderr" 以及文件路径。
+// 支持 "stdout", "stderr", 文件路径以及已注册 scheme 的 URL。
 // (getWriteSyncerForPaths creates a zapcore.WriteSyncer for the given list of paths.)
-// (Supports "stdout", "stderr", and file paths.)
-func getWriteSyncerForPaths(paths []string, opts *Options) (zapcore.WriteSyncer, error) {
+// (Supports "stdout", "stderr", file paths and URLs of registered schemes.)
+func getWriteSyncerForPaths(paths []string, opts *Options) (syncer zapcore.WriteSyncer, async []*asyncWriter, err error) {
 	var writers []zapcore.WriteSyncer
+	defer func() {
+		// 构建失败时停止已启动的异步写入器 (Stop the async writers already started when building fails)
+		if err != nil {
+			for _, w := range async {
+				w.Close()
+			}
+			async = nil
+		}
+	}()
 	for _, path := range paths {
 		var ws zapcore.WriteSyncer
 		// var err error // err is declared within the loop for file opening specifically
@@ -560,30 +670,48 @@ func getWriteSyncerForPaths(paths []string, opts *Options) (zapcore.WriteSyncer,
 		default:
 			// 文件路径处理，包括轮转
 			// (File path handling, including rotation)
-			// Also handle cases like "http://", "tcp://", etc. as invalid file paths
+			// 带 scheme 的路径由 RegisterSink 注册的工厂创建，未注册的 scheme（如 "http://"）视为无效
+			// (Paths with a scheme are created by factories registered with RegisterSink; unregistered schemes such as "http://" are invalid)
 			if strings.Contains(path, "://") {
-				return nil, lmccerrors.NewWithCode(lmccerrors.ErrLogOptionInvalid, "unsupported output path scheme: "+path)
-			}
-
-			if opts.LogRotateMaxSize > 0 { // 使用 LogRotateMaxSize 判断是否启用轮转
+				var err error
+				if ws, err = newRegisteredSink(path); err != nil {
+					return nil, nil, err
+				}
+			} else if opts.LogRotateInterval != "" {
+				// 按时间轮转优先于按大小轮转 (Time-based rotation takes precedence over size-based rotation)
+				interval, err := parseRotateInterval(opts.LogRotateInterval)
+				if err != nil {
+					return nil, nil, lmccerrors.WithCode(lmccerrors.Wrap(err, "invalid log rotation"), lmccerrors.ErrLogOptionInvalid)
+				}
+				if ws, err = newTimeRotateLogger(path, interval, opts); err != nil {
+					return nil, nil, err
+				}
+			} else if opts.LogRotateMaxSize > 0 { // 使用 LogRotateMaxSize 判断是否启用轮转
 				// 使用 newRotateLogger 函数，它包含了目录创建和错误处理逻辑
 				// (Use newRotateLogger function which includes directory creation and error handling logic)
 				var err error
 				ws, err = newRotateLogger(path, opts)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 			} else {
 				// 普通文件写入 (Regular file writing)
 				file, errOpen := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 				if errOpen != nil {
-					return nil, lmccerrors.WithCode(
+					return nil, nil, lmccerrors.WithCode(
 						lmccerrors.Wrapf(errOpen, "failed to open log file %s", path),
 						lmccerrors.ErrLogInitialization,
 					)
 				}
 				ws = zapcore.AddSync(file)
 			}
+			// 文件和网络输出可在后台写出，stdout/stderr 保持同步以免进程退出时丢失输出
+			// (File and network sinks may be written in the background; stdout/stderr stay synchronous so output is not lost on exit)
+			if ws != nil && opts.AsyncBuffer.Enabled() {
+				w := newAsyncWriter(path, ws, opts.AsyncBuffer)
+				async = append(async, w)
+				ws = w
+			}
 		}
 		// if err != nil { // This err check is problematic if err is not properly assigned in all paths within default
 		// return nil, err
@@ -597,9 +725,9 @@ func getWriteSyncerForPaths(paths []string, opts *Options) (zapcore.WriteSyncer,
 		// This case should ideally be caught by opts.Validate() if OutputPaths becomes empty after processing
 		// or if all paths are invalid but don't immediately error out above.
 		// However, if paths contained only invalid schemes that returned early, writers could be empty.
-		return nil, lmccerrors.NewWithCode(lmccerrors.ErrLogOptionInvalid, "no valid log output writers configured from paths")
+		return nil, nil, lmccerrors.NewWithCode(lmccerrors.ErrLogOptionInvalid, "no valid log output writers configured from paths")
 	}
-	return zapcore.NewMultiWriteSyncer(writers...), nil
+	return zapcore.NewMultiWriteSyncer(writers...), async, nil
 }
 
 // 以下是原有的 logger 方法和全局包装函数，保持不变
@@ -627,109 +755,109 @@ func Sync() error {
 // Debug 在全局 logger 上调用 Debug。
 // (Debug calls Debug on the global logger.)
 func Debug(args ...any) {
-	Std().Debug(args...)
+	global().Debug(args...)
 }
 
 // Debugf 在全局 logger 上调用 Debugf。
 // (Debugf calls Debugf on the global logger.)
 func Debugf(template string, args ...any) {
-	Std().Debugf(template, args...)
+	global().Debugf(template, args...)
 }
 
 // Debugw 在全局 logger 上调用 Debugw。
 // (Debugw calls Debugw on the global logger.)
 func Debugw(msg string, keysAndValues ...any) {
-	Std().Debugw(msg, keysAndValues...)
+	global().Debugw(msg, keysAndValues...)
 }
 
 // Info 在全局 logger 上调用 Info。
 // (Info calls Info on the global logger.)
 func Info(args ...any) {
-	Std().Info(args...)
+	global().Info(args...)
 }
 
 // Infof 在全局 logger 上调用 Infof。
 // (Infof calls Infof on the global logger.)
 func Infof(template string, args ...any) {
-	Std().Infof(template, args...)
+	global().Infof(template, args...)
 }
 
 // Infow 在全局 logger 上调用 Infow。
 // (Infow calls Infow on the global logger.)
 func Infow(msg string, keysAndValues ...any) {
-	Std().Infow(msg, keysAndValues...)
+	global().Infow(msg, keysAndValues...)
 }
 
 // Warn 在全局 logger 上调用 Warn。
 // (Warn calls Warn on the global logger.)
 func Warn(args ...any) {
-	Std().Warn(args...)
+	global().Warn(args...)
 }
 
 // Warnf 在全局 logger 上调用 Warnf。
 // (Warnf calls Warnf on the global logger.)
 func Warnf(template string, args ...any) {
-	Std().Warnf(template, args...)
+	global().Warnf(template, args...)
 }
 
 // Warnw 在全局 logger 上调用 Warnw。
 // (Warnw calls Warnw on the global logger.)
 func Warnw(msg string, keysAndValues ...any) {
-	Std().Warnw(msg, keysAndValues...)
+	global().Warnw(msg, keysAndValues...)
 }
 
 // Error 在全局 logger 上调用 Error。
 // (Error calls Error on the global logger.)
 func Error(args ...any) {
-	Std().Error(args...)
+	global().Error(args...)
 }
 
 // Errorf 在全局 logger 上调用 Errorf。
 // (Errorf calls Errorf on the global logger.)
 func Errorf(template string, args ...any) {
-	Std().Errorf(template, args...)
+	global().Errorf(template, args...)
 }
 
 // Errorw 在全局 logger 上调用 Errorw。
 // (Errorw calls Errorw on the global logger.)
 func Errorw(msg string, keysAndValues ...any) {
-	Std().Errorw(msg, keysAndValues...)
+	global().Errorw(msg, keysAndValues...)
 }
 
 // Fatal 在全局 logger 上调用 Fatal。
 // (Fatal calls Fatal on the global logger.)
 func Fatal(args ...any) {
-	Std().Fatal(args...)
+	global().Fatal(args...)
 }
 
 // Fatalf 在全局 logger 上调用 Fatalf。
 // (Fatalf calls Fatalf on the global logger.)
 func Fatalf(template string, args ...any) {
-	Std().Fatalf(template, args...)
+	global().Fatalf(template, args...)
 }
 
 // Fatalw 在全局 logger 上调用 Fatalw。
 // (Fatalw calls Fatalw on the global logger.)
 func Fatalw(msg string, keysAndValues ...any) {
-	Std().Fatalw(msg, keysAndValues...)
+	global().Fatalw(msg, keysAndValues...)
 }
 
 // Ctx 在全局 logger 上调用 Ctx。
 // (Ctx calls Ctx on the global logger.)
 func Ctx(ctx context.Context, args ...any) {
-	Std().Ctx(ctx, args...)
+	global().Ctx(ctx, args...)
 }
 
 // Ctxf 在全局 logger 上调用 Ctxf。
 // (Ctxf calls Ctxf on the global logger.)
 func Ctxf(ctx context.Context, template string, args ...any) {
-	Std().Ctxf(ctx, template, args...)
+	global().Ctxf(ctx, template, args...)
 }
 
 // Ctxw 在全局 logger 上调用 Ctxw。
 // (Ctxw calls Ctxw on the global logger.)
 func Ctxw(ctx context.Context, msg string, keysAndValues ...any) {
-	Std().Ctxw(ctx, msg, keysAndValues...)
+	global().Ctxw(ctx, msg, keysAndValues...)
 }
 
 // WithValues 在全局 logger 上调用 WithValues 并返回一个新的 Logger 实例。
@@ -747,7 +875,23 @@ func WithName(name string) Logger {
 // --- 已有的 logger 方法实现 (示例，确保它们都存在) ---
 func (l *logger) Debug(args ...any) { l.zapLogger.Sugar().Debug(args...) }
 func (l *logger) Debugf(template string, args ...any) { l.zapLogger.Sugar().Debugf(template, args...) }
-func (l *logger) Debugw(msg string, keysAndValues ...any) { l.zapLogger.Sugar().Debugw(msg, keysAndValues...) }
+func (l *logger) Debugw(msg string, keysAndValues ...any) {
+	if l.opts.Format == FormatKeyValue {
+		if kvStr := formatKeyValuePairs(keysAndValues...); kvStr != "" {
+			msg = msg + " " + kvStr
+		}
+		l.zapLogger.Sugar().Debug(msg)
+	} else if !l.zapLogger.Core().Enabled(zapcore.DebugLevel) {
+		// 级别未启用时在构造字段前返回 (Return before building fields when the level is disabled)
+		return
+	} else if fields, ok := acquireFields(keysAndValues); ok {
+		// 少量字符串键的字段使用池化切片，避免 SugaredLogger 的分配 (A few string-keyed fields use a pooled slice, avoiding the SugaredLogger's allocations)
+		l.zapLogger.Debug(msg, *fields...)
+		releaseFields(fields)
+	} else {
+		l.zapLogger.Sugar().Debugw(msg, keysAndValues...)
+	}
+}
 
 func (l *logger) Info(args ...any) { l.zapLogger.Sugar().Info(args...) }
 func (l *logger) Infof(template string, args ...any) { l.zapLogger.Sugar().Infof(template, args...) }
@@ -757,6 +901,13 @@ func (l *logger) Infow(msg string, keysAndValues ...any) {
 			msg = msg + " " + kvStr
 		}
 		l.zapLogger.Sugar().Info(msg)
+	} else if !l.zapLogger.Core().Enabled(zapcore.InfoLevel) {
+		// 级别未启用时在构造字段前返回 (Return before building fields when the level is disabled)
+		return
+	} else if fields, ok := acquireFields(keysAndValues); ok {
+		// 少量字符串键的字段使用池化切片，避免 SugaredLogger 的分配 (A few string-keyed fields use a pooled slice, avoiding the SugaredLogger's allocations)
+		l.zapLogger.Info(msg, *fields...)
+		releaseFields(fields)
 	} else {
 		l.zapLogger.Sugar().Infow(msg, keysAndValues...)
 	}
@@ -770,6 +921,13 @@ func (l *logger) Warnw(msg string, keysAndValues ...any) {
 			msg = msg + " " + kvStr
 		}
 		l.zapLogger.Sugar().Warn(msg)
+	} else if !l.zapLogger.Core().Enabled(zapcore.WarnLevel) {
+		// 级别未启用时在构造字段前返回 (Return before building fields when the level is disabled)
+		return
+	} else if fields, ok := acquireFields(keysAndValues); ok {
+		// 少量字符串键的字段使用池化切片，避免 SugaredLogger 的分配 (A few string-keyed fields use a pooled slice, avoiding the SugaredLogger's allocations)
+		l.zapLogger.Warn(msg, *fields...)
+		releaseFields(fields)
 	} else {
 		l.zapLogger.Sugar().Warnw(msg, keysAndValues...)
 	}
@@ -777,24 +935,52 @@ func (l *logger) Warnw(msg string, keysAndValues ...any) {
 
 func (l *logger) Error(args ...any) { l.zapLogger.Sugar().Error(args...) }
 func (l *logger) Errorf(template string, args ...any) { l.zapLogger.Sugar().Errorf(template, args...) }
-func (l *logger) Errorw(msg string, keysAndValues ...any) { l.zapLogger.Sugar().Errorw(msg, keysAndValues...) }
+func (l *logger) Errorw(msg string, keysAndValues ...any) {
+	if l.opts.Format == FormatKeyValue {
+		if kvStr := formatKeyValuePairs(keysAndValues...); kvStr != "" {
+			msg = msg + " " + kvStr
+		}
+		l.zapLogger.Sugar().Error(msg)
+	} else if !l.zapLogger.Core().Enabled(zapcore.ErrorLevel) {
+		// 级别未启用时在构造字段前返回 (Return before building fields when the level is disabled)
+		return
+	} else if fields, ok := acquireFields(keysAndValues); ok {
+		// 少量字符串键的字段使用池化切片，避免 SugaredLogger 的分配 (A few string-keyed fields use a pooled slice, avoiding the SugaredLogger's allocations)
+		l.zapLogger.Error(msg, *fields...)
+		releaseFields(fields)
+	} else {
+		l.zapLogger.Sugar().Errorw(msg, keysAndValues...)
+	}
+}
 
 func (l *logger) Fatal(args ...any) { l.zapLogger.Sugar().Fatal(args...) }
 func (l *logger) Fatalf(template string, args ...any) { l.zapLogger.Sugar().Fatalf(template, args...) }
-func (l *logger) Fatalw(msg string, keysAndValues ...any) { l.zapLogger.Sugar().Fatalw(msg, keysAndValues...) }
+func (l *logger) Fatalw(msg string, keysAndValues ...any) {
+	if l.opts.Format == FormatKeyValue {
+		if kvStr := formatKeyValuePairs(keysAndValues...); kvStr != "" {
+			msg = msg + " " + kvStr
+		}
+		l.zapLogger.Sugar().Fatal(msg)
+	} else {
+		l.zapLogger.Sugar().Fatalw(msg, keysAndValues...)
+	}
+}
 
 func (l *logger) Ctx(ctx context.Context, args ...any) {
-	fields := extractContextFields(ctx, l.opts.ContextKeys)
+	fields := extractContextFields(ctx, l.opts)
+	fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.InfoLevel, func() string { return fmt.Sprint(args...) }))
 	l.zapLogger.With(fields...).Sugar().Info(args...)
 }
 
 func (l *logger) Ctxf(ctx context.Context, template string, args ...any) {
-	fields := extractContextFields(ctx, l.opts.ContextKeys)
+	fields := extractContextFields(ctx, l.opts)
+	fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.InfoLevel, func() string { return fmt.Sprintf(template, args...) }))
 	l.zapLogger.With(fields...).Sugar().Infof(template, args...)
 }
 
 func (l *logger) Ctxw(ctx context.Context, msg string, keysAndValues ...any) {
-	fields := extractContextFields(ctx, l.opts.ContextKeys)
+	fields := extractContextFields(ctx, l.opts)
+	fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.InfoLevel, func() string { return msg }))
 	
 	if l.opts.Format == FormatKeyValue {
 		// 对于 key=value 格式，将字段格式化为字符串并附加到消息中
@@ -828,7 +1014,15 @@ func (l *logger) Ctxw(ctx context.Context, msg string, keysAndValues ...any) {
 	}
 }
 
-func (l *logger) Sync() error { return l.zapLogger.Sync() }
+func (l *logger) Sync() error {
+	err := l.zapLogger.Sync()
+	if l.audit != nil {
+		if auditErr := l.audit.Sync(); err == nil {
+			err = auditErr
+		}
+	}
+	return err
+}
 
 func (l *logger) WithValues(keysAndValues ...any) Logger {
 	if l.opts.Format == FormatKeyValue {
@@ -836,8 +1030,9 @@ func (l *logger) WithValues(keysAndValues ...any) Logger {
 		// 创建一个包装器，在日志时添加这些字段
 		// (For key=value format, we need special handling)
 		// (Create a wrapper that adds these fields when logging)
+		// 包装器的方法多占一个栈帧 (The wrapper methods add a stack frame)
 		return &keyValueLogger{
-			baseLogger: l,
+			baseLogger: l.withCallerSkip(1),
 			fields:     keysAndValues,
 		}
 	} else {
@@ -846,6 +1041,8 @@ func (l *logger) WithValues(keysAndValues ...any) Logger {
 		return &logger{
 			zapLogger: l.zapLogger.With(zapFields(keysAndValues...)...), // Ensure zapFields handles pairs correctly
 			opts:      l.opts, // Options are typically immutable after logger creation or carried over
+			level:     l.level,
+			async:     l.async,
 		}
 	}
 }
@@ -854,35 +1051,79 @@ func (l *logger) WithName(name string) Logger {
 	return &logger{
 		zapLogger: l.zapLogger.Named(name),
 		opts:      l.opts,
+		level:     l.level,
+		async:     l.async,
 	}
 }
+
+// WithCallerSkip 返回报告调用者时额外跳过 n 个栈帧的日志记录器。
+// (WithCallerSkip returns a logger that skips n more stack frames when reporting the caller.)
+func (l *logger) WithCallerSkip(n int) Logger {
+	return l.withCallerSkip(n)
+}
+
+func (l *logger) withCallerSkip(n int) *logger {
+	if n == 0 {
+		return l
+	}
+	return &logger{
+		zapLogger: l.zapLogger.WithOptions(zap.AddCallerSkip(n)),
+		opts:      l.opts,
+		level:     l.level,
+		async:     l.async,
+	}
+}
+
+// global 返回全局函数使用的记录器，它多跳过全局函数自身的栈帧，使调用者指向全局函数的调用方。
+// 派生记录器在首次使用时创建并缓存在 l 上，替换全局记录器时随之更新。
+// (global returns the logger used by the global functions. It also skips the frame of the global function itself, so the
+// caller points at the function's caller. The derived logger is created on first use and cached on l, so it follows
+// replacements of the global logger.)
+func global() *logger {
+	l := stdLogger()
+	if f := l.facade.Load(); f != nil {
+		return f
+	}
+	f := l.withCallerSkip(1)
+	if !l.facade.CompareAndSwap(nil, f) {
+		return l.facade.Load()
+	}
+	return f
+}
+
 func (l *logger) GetZapLogger() *zap.Logger {
 	return l.zapLogger
 }
 
 // --- Contextual logging methods for *logger ---
 func (l *logger) CtxDebugf(ctx context.Context, template string, args ...interface{}) {
-		fields := extractContextFields(ctx, l.opts.ContextKeys)
+		fields := extractContextFields(ctx, l.opts)
+		fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.DebugLevel, func() string { return fmt.Sprintf(template, args...) }))
 		l.zapLogger.With(fields...).Sugar().Debugf(template, args...)
 	}
 func (l *logger) CtxInfof(ctx context.Context, template string, args ...interface{}) {
-		fields := extractContextFields(ctx, l.opts.ContextKeys)
+		fields := extractContextFields(ctx, l.opts)
+		fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.InfoLevel, func() string { return fmt.Sprintf(template, args...) }))
 		l.zapLogger.With(fields...).Sugar().Infof(template, args...)
 	}
 func (l *logger) CtxWarnf(ctx context.Context, template string, args ...interface{}) {
-		fields := extractContextFields(ctx, l.opts.ContextKeys)
+		fields := extractContextFields(ctx, l.opts)
+		fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.WarnLevel, func() string { return fmt.Sprintf(template, args...) }))
 		l.zapLogger.With(fields...).Sugar().Warnf(template, args...)
 	}
 func (l *logger) CtxErrorf(ctx context.Context, template string, args ...interface{}) {
-		fields := extractContextFields(ctx, l.opts.ContextKeys)
+		fields := extractContextFields(ctx, l.opts)
+		fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.ErrorLevel, func() string { return fmt.Sprintf(template, args...) }))
 		l.zapLogger.With(fields...).Sugar().Errorf(template, args...)
 	}
 func (l *logger) CtxPanicf(ctx context.Context, template string, args ...interface{}) {
-	fields := extractContextFields(ctx, l.opts.ContextKeys)
+	fields := extractContextFields(ctx, l.opts)
+	fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.PanicLevel, func() string { return fmt.Sprintf(template, args...) }))
 	l.zapLogger.With(fields...).Sugar().Panicf(template, args...)
 }
 func (l *logger) CtxFatalf(ctx context.Context, template string, args ...interface{}) {
-	fields := extractContextFields(ctx, l.opts.ContextKeys)
+	fields := extractContextFields(ctx, l.opts)
+	fields = appendHookFields(fields, l.contextHookFields(ctx, zapcore.FatalLevel, func() string { return fmt.Sprintf(template, args...) }))
 	l.zapLogger.With(fields...).Sugar().Fatalf(template, args...)
 }
 
@@ -960,75 +1201,34 @@ func fieldsToZapAny(fields []zap.Field) []any {
 	return result
 }
 
-// formatFieldsAsKeyValue 将字段格式化为 key=value 格式的字符串
-// (formatFieldsAsKeyValue formats fields as key=value format string)
+// formatFieldsAsKeyValue 将字段格式化为 logfmt 格式的 key=value 字符串
+// (formatFieldsAsKeyValue formats fields as a logfmt key=value string)
 func formatFieldsAsKeyValue(fields []zap.Field) string {
-	if len(fields) == 0 {
-		return ""
-	}
-	
-	var parts []string
-	for _, f := range fields {
-		var value string
-		if f.Interface != nil {
-			value = fmt.Sprintf("%v", f.Interface)
-		} else {
-			switch f.Type {
-			case zapcore.StringType:
-				value = f.String
-			case zapcore.BoolType:
-				if f.Integer != 0 {
-					value = "true"
-				} else {
-					value = "false"
-				}
-			default:
-				value = fmt.Sprintf("%v", f.Integer)
-			}
-		}
-		// 如果值包含空格，用引号包围
-		// (If value contains spaces, surround with quotes)
-		if strings.Contains(value, " ") {
-			value = fmt.Sprintf(`"%s"`, value)
-		}
-		parts = append(parts, fmt.Sprintf("%s=%s", f.Key, value))
-	}
-	return strings.Join(parts, " ")
+	return strings.Join(appendLogfmtFields(nil, fields), " ")
 }
 
-// formatKeyValuePairs 将键值对格式化为 key=value 格式的字符串
-// (formatKeyValuePairs formats key-value pairs as key=value format string)
+// formatKeyValuePairs 将键值对格式化为 logfmt 格式的 key=value 字符串，需要时加引号并转义，嵌套映射展开为点分隔的键
+// (formatKeyValuePairs formats key-value pairs as a logfmt key=value string, quoting and escaping values where needed
+// and flattening nested maps into dotted keys)
 func formatKeyValuePairs(keysAndValues ...any) string {
-	if len(keysAndValues) == 0 {
-		return ""
-	}
-	
 	var parts []string
-	for i := 0; i < len(keysAndValues); i += 2 {
-		if i+1 >= len(keysAndValues) {
-			break // 跳过奇数个参数的最后一个
-		}
-		
-		key := fmt.Sprintf("%v", keysAndValues[i])
-		value := fmt.Sprintf("%v", keysAndValues[i+1])
-		
-		// 如果值包含空格，用引号包围
-		// (If value contains spaces, surround with quotes)
-		if strings.Contains(value, " ") {
-			value = fmt.Sprintf(`"%s"`, value)
-		}
-		parts = append(parts, fmt.Sprintf("%s=%s", key, value))
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		// 跳过奇数个参数的最后一个 (The last of an odd number of arguments is skipped)
+		parts = appendLogfmtPair(parts, fmt.Sprint(keysAndValues[i]), keysAndValues[i+1])
 	}
 	return strings.Join(parts, " ")
 }
 
-// extractContextFields extracts configured keys from context and returns them as zap.Fields
-func extractContextFields(ctx context.Context, contextKeys []any) []zap.Field { // Changed to []any
-	if ctx == nil || len(contextKeys) == 0 {
+// extractContextFields extracts configured keys from context and returns them as zap.Fields,
+// followed by Options.ContextFields and the fields registered with RegisterContextField.
+// With EnableOTelTraceContext it also adds the trace context of the active OpenTelemetry span.
+// (extractContextFields 从 context 中提取配置的键，随后是 Options.ContextFields 和通过 RegisterContextField 注册的字段；启用 EnableOTelTraceContext 时还会添加当前 OpenTelemetry span 的追踪上下文。)
+func extractContextFields(ctx context.Context, opts *Options) []zap.Field {
+	if ctx == nil {
 		return nil
 	}
 	var fields []zap.Field
-	for _, keyAny := range contextKeys {
+	for _, keyAny := range opts.ContextKeys {
 		if keyAny == nil {
 			continue
 		} // Skip nil keys in the list
@@ -1058,6 +1258,11 @@ func extractContextFields(ctx context.Context, contextKeys []any) []zap.Field {
 			fields = append(fields, zap.Any(keyStr, value))
 		}
 	}
+	fields = appendContextFields(ctx, opts, fields)
+	fields = appendScopeFields(ctx, fields)
+	if opts.EnableOTelTraceContext {
+		fields = append(fields, otelTraceFields(ctx, fields)...)
+	}
 	return fields
 }
 
@@ -1079,37 +1284,37 @@ func extractContextFields(ctx context.Context, contextKeys []any) []zap.Field {
 // CtxDebugf 在全局 logger 上调用 CtxDebugf。
 // (CtxDebugf calls CtxDebugf on the global logger.)
 func CtxDebugf(ctx context.Context, template string, args ...interface{}) {
-	Std().CtxDebugf(ctx, template, args...)
+	global().CtxDebugf(ctx, template, args...)
 }
 
 // CtxInfof 在全局 logger 上调用 CtxInfof。
 // (CtxInfof calls CtxInfof on the global logger.)
 func CtxInfof(ctx context.Context, template string, args ...interface{}) {
-	Std().CtxInfof(ctx, template, args...)
+	global().CtxInfof(ctx, template, args...)
 }
 
 // CtxWarnf 在全局 logger 上调用 CtxWarnf。
 // (CtxWarnf calls CtxWarnf on the global logger.)
 func CtxWarnf(ctx context.Context, template string, args ...interface{}) {
-	Std().CtxWarnf(ctx, template, args...)
+	global().CtxWarnf(ctx, template, args...)
 }
 
 // CtxErrorf 在全局 logger 上调用 CtxErrorf。
 // (CtxErrorf calls CtxErrorf on the global logger.)
 func CtxErrorf(ctx context.Context, template string, args ...interface{}) {
-	Std().CtxErrorf(ctx, template, args...)
+	global().CtxErrorf(ctx, template, args...)
 }
 
 // CtxPanicf 在全局 logger 上调用 CtxPanicf。
 // (CtxPanicf calls CtxPanicf on the global logger.)
 func CtxPanicf(ctx context.Context, template string, args ...interface{}) {
-	Std().CtxPanicf(ctx, template, args...)
+	global().CtxPanicf(ctx, template, args...)
 }
 
 // CtxFatalf 在全局 logger 上调用 CtxFatalf。
 // (CtxFatalf calls CtxFatalf on the global logger.)
 func CtxFatalf(ctx context.Context, template string, args ...interface{}) {
-	Std().CtxFatalf(ctx, template, args...)
+	global().CtxFatalf(ctx, template, args...)
 }
 
 // --- keyValueLogger 方法实现 ---
@@ -1261,7 +1466,8 @@ func (kvl *keyValueLogger) DPanicw(msg string, keysAndValues ...any) {
 
 func (kvl *keyValueLogger) Ctx(ctx context.Context, args ...any) {
 	msg := fmt.Sprint(args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.InfoLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1279,7 +1485,8 @@ func (kvl *keyValueLogger) Ctx(ctx context.Context, args ...any) {
 
 func (kvl *keyValueLogger) Ctxf(ctx context.Context, template string, args ...any) {
 	msg := fmt.Sprintf(template, args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.InfoLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1296,7 +1503,8 @@ func (kvl *keyValueLogger) Ctxf(ctx context.Context, template string, args ...an
 }
 
 func (kvl *keyValueLogger) Ctxw(ctx context.Context, msg string, keysAndValues ...any) {
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.InfoLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1333,18 +1541,28 @@ func (kvl *keyValueLogger) WithName(name string) Logger {
 		baseLogger: &logger{
 			zapLogger: kvl.baseLogger.zapLogger.Named(name),
 			opts:      kvl.baseLogger.opts,
+			level:     kvl.baseLogger.level,
+			async:     kvl.baseLogger.async,
 		},
 		fields: kvl.fields,
 	}
 }
 
+func (kvl *keyValueLogger) WithCallerSkip(n int) Logger {
+	return &keyValueLogger{
+		baseLogger: kvl.baseLogger.withCallerSkip(n),
+		fields:     kvl.fields,
+	}
+}
+
 func (kvl *keyValueLogger) GetZapLogger() *zap.Logger {
 	return kvl.baseLogger.GetZapLogger()
 }
 
 func (kvl *keyValueLogger) CtxDebugf(ctx context.Context, template string, args ...interface{}) {
 	msg := fmt.Sprintf(template, args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.DebugLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1362,7 +1580,8 @@ func (kvl *keyValueLogger) CtxDebugf(ctx context.Context, template string, args
 
 func (kvl *keyValueLogger) CtxInfof(ctx context.Context, template string, args ...interface{}) {
 	msg := fmt.Sprintf(template, args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.InfoLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1380,7 +1599,8 @@ func (kvl *keyValueLogger) CtxInfof(ctx context.Context, template string, args .
 
 func (kvl *keyValueLogger) CtxWarnf(ctx context.Context, template string, args ...interface{}) {
 	msg := fmt.Sprintf(template, args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.WarnLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1398,7 +1618,8 @@ func (kvl *keyValueLogger) CtxWarnf(ctx context.Context, template string, args .
 
 func (kvl *keyValueLogger) CtxErrorf(ctx context.Context, template string, args ...interface{}) {
 	msg := fmt.Sprintf(template, args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.ErrorLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1416,7 +1637,8 @@ func (kvl *keyValueLogger) CtxErrorf(ctx context.Context, template string, args
 
 func (kvl *keyValueLogger) CtxPanicf(ctx context.Context, template string, args ...interface{}) {
 	msg := fmt.Sprintf(template, args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.PanicLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
@@ -1434,7 +1656,8 @@ func (kvl *keyValueLogger) CtxPanicf(ctx context.Context, template string, args
 
 func (kvl *keyValueLogger) CtxFatalf(ctx context.Context, template string, args ...interface{}) {
 	msg := fmt.Sprintf(template, args...)
-	fields := extractContextFields(ctx, kvl.baseLogger.opts.ContextKeys)
+	fields := extractContextFields(ctx, kvl.baseLogger.opts)
+	fields = appendHookFields(fields, kvl.baseLogger.contextHookFields(ctx, zapcore.FatalLevel, func() string { return msg }))
 	
 	var allParts []string
 	if contextStr := formatFieldsAsKeyValue(fields); contextStr != "" {
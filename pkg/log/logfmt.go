@@ -0,0 +1,115 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// appendLogfmtPair 以 logfmt 格式追加 key=value。映射按键排序后展开为点分隔的键，例如 user.id=1 user.name=alice。
+// (appendLogfmtPair appends key=value in the logfmt format. Maps are flattened into dotted keys in key order,
+// e.g. user.id=1 user.name=alice.)
+func appendLogfmtPair(parts []string, key string, value any) []string {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Map && rv.Len() > 0 {
+		type entry struct {
+			name  string
+			value reflect.Value
+		}
+		entries := make([]entry, 0, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			entries = append(entries, entry{name: fmt.Sprint(iter.Key().Interface()), value: iter.Value()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+		for _, e := range entries {
+			parts = appendLogfmtPair(parts, key+"."+e.name, e.value.Interface())
+		}
+		return parts
+	}
+	return append(parts, logfmtKey(key)+"="+logfmtValue(value))
+}
+
+// appendLogfmtField 以 logfmt 格式追加 zap 字段，键加上 prefix，字段值按 JSON 编码器的规则取得，对象字段展开为点分隔的键。
+// (appendLogfmtField appends a zap field in the logfmt format with prefix before its key. The value is taken the way the
+// JSON encoder takes it, and object fields are flattened into dotted keys.)
+func appendLogfmtField(parts []string, prefix string, f zap.Field) []string {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	value, ok := enc.Fields[f.Key]
+	if !ok {
+		return parts
+	}
+	return appendLogfmtPair(parts, prefix+f.Key, value)
+}
+
+// appendLogfmtFields 以 logfmt 格式依次追加 zap 字段。zap.Namespace 为之后的字段加上 "名称." 前缀，内联对象的字段按键排序后
+// 直接追加，与 JSON 编码器的结构一致。
+// (appendLogfmtFields appends zap fields in order in the logfmt format. A zap.Namespace prefixes the fields after it with
+// "name.", and the fields of inlined objects are appended directly in key order, matching the structure of the JSON encoder.)
+func appendLogfmtFields(parts []string, fields []zap.Field) []string {
+	prefix := ""
+	for _, f := range fields {
+		switch f.Type {
+		case zapcore.NamespaceType:
+			prefix += f.Key + "."
+		case zapcore.InlineMarshalerType:
+			enc := zapcore.NewMapObjectEncoder()
+			f.AddTo(enc)
+			keys := make([]string, 0, len(enc.Fields))
+			for key := range enc.Fields {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				parts = appendLogfmtPair(parts, prefix+key, enc.Fields[key])
+			}
+		default:
+			parts = appendLogfmtField(parts, prefix, f)
+		}
+	}
+	return parts
+}
+
+// logfmtKey 将键中的空白、'='、'"' 和控制字符替换为 '_'，保证键不被拆开。
+// (logfmtKey replaces whitespace, '=', '"' and control characters in a key with '_' so the key is never split.)
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	if strings.IndexFunc(key, needsLogfmtQuoting) < 0 {
+		return key
+	}
+	return strings.Map(func(r rune) rune {
+		if needsLogfmtQuoting(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtValue 格式化值；空值以及包含空白、'='、'"' 或控制字符的值加双引号并按 Go 字符串字面量转义，换行写作 \n。
+// (logfmtValue formats a value. Empty values and values containing whitespace, '=', '"' or control characters are
+// double-quoted and escaped as a Go string literal, so a newline is written as \n.)
+func logfmtValue(value any) string {
+	s := fmt.Sprint(value)
+	if s == "" || strings.IndexFunc(s, needsLogfmtQuoting) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsLogfmtQuoting(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r)
+}
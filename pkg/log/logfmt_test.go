@@ -0,0 +1,106 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for quoting, escaping and flattening in the key=value format.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKeyValueFormat_Escaping tests that values are quoted and escaped so that every entry stays on one parseable line.
+// (TestKeyValueFormat_Escaping 测试值会被加引号并转义，使每条日志保持为可解析的单行。)
+func TestKeyValueFormat_Escaping(t *testing.T) {
+	var buf bytes.Buffer
+	opts := log.NewOptions()
+	opts.Format = log.FormatKeyValue
+	opts.Level = "debug"
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	logger.Infow("request failed",
+		"path", "/users",
+		"reason", "upstream timed out",
+		"query", "a=1&b=2",
+		"body", "line one\nline two",
+		"quote", `say "hi"`,
+		"empty", "",
+		"bad key", "v",
+		"error", errors.New("connection refused by peer"),
+		"latency", 1500*time.Millisecond,
+	)
+
+	output := strings.TrimSuffix(buf.String(), "\n")
+	assert.NotContains(t, output, "\n", "a newline in a value must not split the entry")
+	for _, want := range []string{
+		`path=/users`,
+		`reason="upstream timed out"`,
+		`query="a=1&b=2"`,
+		`body="line one\nline two"`,
+		`quote="say \"hi\""`,
+		`empty=""`,
+		`bad_key=v`,
+		`error="connection refused by peer"`,
+		`latency=1.5s`,
+	} {
+		assert.Contains(t, output, want)
+	}
+}
+
+// TestKeyValueFormat_NestedMaps tests that nested maps are flattened into dotted keys in key order.
+// (TestKeyValueFormat_NestedMaps 测试嵌套映射按键排序展开为点分隔的键。)
+func TestKeyValueFormat_NestedMaps(t *testing.T) {
+	var buf bytes.Buffer
+	opts := log.NewOptions()
+	opts.Format = log.FormatKeyValue
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	logger.WithValues("service", "orders").Errorw("user lookup failed", "user", map[string]any{
+		"name": "John Doe",
+		"id":   123,
+		"org":  map[string]string{"region": "eu west"},
+	})
+
+	output := buf.String()
+	assert.Contains(t, output, `service=orders user.id=123 user.name="John Doe" user.org.region="eu west"`)
+}
+
+// TestKeyValueFormat_AllLevels tests that the sugared methods of every level use the key=value format.
+// (TestKeyValueFormat_AllLevels 测试所有级别的结构化方法均使用 key=value 格式。)
+func TestKeyValueFormat_AllLevels(t *testing.T) {
+	var buf bytes.Buffer
+	opts := log.NewOptions()
+	opts.Format = log.FormatKeyValue
+	opts.Level = "debug"
+	opts.ContextKeys = []any{log.TraceIDKey}
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	logger.Debugw("debug", "msg", "two words")
+	logger.Errorw("error", "msg", "two words")
+	logger.Ctxw(context.WithValue(context.Background(), log.TraceIDKey, "trace 1"), "ctx", "msg", "two words")
+
+	// 错误级别之后还有堆栈行 (The error entry is followed by stack trace lines)
+	var lines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "msg=") {
+			lines = append(lines, line)
+		}
+	}
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		assert.Contains(t, line, `msg="two words"`)
+		assert.NotContains(t, line, "{", "fields must not be written as a JSON object")
+	}
+	assert.Contains(t, lines[2], `trace_id="trace 1"`)
+}
@@ -0,0 +1,33 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package logtest provides a log.Logger that captures entries in memory for assertions in tests.
+(logtest 包提供将日志捕获在内存中的 log.Logger，用于在测试中断言日志输出。)
+
+NewTestLogger returns a Logger at debug level that can be passed wherever a log.Logger is expected.
+ContainsEntry asserts on the level, a message substring and fields given as key-value pairs; expected
+values are encoded the way the logger encodes them, so 200, time.Second or an error compare directly.
+The logger is flushed when the test ends, and the captured entries are printed when the test failed.
+(NewTestLogger 返回 debug 级别的 Logger，可以传给任何需要 log.Logger 的地方。ContainsEntry 断言级别、消息子串和以键值对给出的字段；
+期望值按日志的编码方式编码，因此 200、time.Second 或 error 可以直接比较。测试结束时日志会被刷新，测试失败时打印捕获的日志。)
+
+	func TestCreateOrder(t *testing.T) {
+		logger := logtest.NewTestLogger(t)
+		svc := NewOrderService(logger)
+
+		_, err := svc.Create(ctx, order)
+		require.NoError(t, err)
+
+		logger.ContainsEntry("info", "Order created", "order_id", order.ID)
+		logger.NotContainsEntry("error", "")
+	}
+
+Code logging through the global functions (log.Info, log.Infow, ...) is captured after UseAsGlobal,
+which restores the previous global Logger when the test ends.
+(通过全局函数（log.Info、log.Infow 等）写日志的代码在调用 UseAsGlobal 后也会被捕获，测试结束时恢复之前的全局 Logger。)
+*/
+package logtest
@@ -0,0 +1,251 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package logtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// 日志条目中由编码器写入的键。(Keys written by the encoder in a log entry.)
+const (
+	levelKey      = "L"
+	messageKey    = "M"
+	nameKey       = "N"
+	callerKey     = "C"
+	timeKey       = "ts"
+	stacktraceKey = "stacktrace"
+)
+
+// Entry 是捕获的一条日志。(Entry is one captured log entry.)
+type Entry struct {
+	Level   string         // 大写的级别，例如 "INFO" (Upper-case level such as "INFO")
+	Message string         // 日志消息 (Log message)
+	Name    string         // Logger 名称，由 WithName 设置 (Logger name set with WithName)
+	Caller  string         // file:line 形式的调用位置 (Call site as file:line)
+	Fields  map[string]any // 上下文和调用时的字段，值为 JSON 解码后的形式 (Context and call fields, as decoded from JSON)
+	Raw     string         // 原始 JSON 行 (The raw JSON line)
+}
+
+// Logger 是将日志捕获在内存中的 log.Logger，供测试断言日志输出。WithValues 和 WithName 返回的 Logger 写入同一捕获区。
+// (Logger is a log.Logger capturing entries in memory so tests can assert on log output. Loggers returned by
+// WithValues and WithName write to the same capture.)
+type Logger struct {
+	log.Logger
+	t     testing.TB
+	mu    sync.Mutex
+	lines []string
+}
+
+// NewTestLogger 创建捕获所有级别日志的 Logger。测试结束时日志会被刷新，测试失败时捕获的日志通过 t.Log 输出。
+// (NewTestLogger creates a Logger capturing entries of every level. The logger is flushed when the test ends, and the
+// captured entries are written with t.Log when the test failed.)
+func NewTestLogger(t testing.TB) *Logger {
+	opts := log.NewOptions()
+	opts.Level = "debug"
+	opts.DisableStacktrace = true
+	return NewTestLoggerWithOptions(t, opts)
+}
+
+// NewTestLoggerWithOptions 与 NewTestLogger 相同，但使用 opts 配置级别、名称、脱敏、错误展开等行为。
+// 输出总是以 JSON 格式写入内存，opts 中的输出路径、级别路由和编码器配置被忽略。opts 为 nil 时使用 log.NewOptions()。
+// (NewTestLoggerWithOptions is NewTestLogger configured by opts, e.g. level, name, redaction and error expansion.
+// Output is always written to memory as JSON; output paths, level routes and the encoder config in opts are ignored.
+// A nil opts uses log.NewOptions().)
+func NewTestLoggerWithOptions(t testing.TB, opts *log.Options) *Logger {
+	t.Helper()
+	if opts == nil {
+		opts = log.NewOptions()
+	}
+	o := *opts
+	o.Format = log.FormatJSON
+	o.EncoderConfig = nil
+	o.LevelRoutes = nil
+	o.OutputPaths = nil
+	o.TimeFormat = ""
+
+	l := &Logger{t: t}
+	l.Logger = log.NewLoggerWithWriter(&o, writerFunc(l.write))
+	t.Cleanup(func() {
+		_ = l.Sync()
+		if t.Failed() {
+			for _, line := range l.rawLines() {
+				t.Log(line)
+			}
+		}
+	})
+	return l
+}
+
+// UseAsGlobal 将 l 设置为全局 Logger，使 log.Infow 等全局函数写入捕获区，测试结束时恢复之前的全局 Logger。
+// (UseAsGlobal makes l the global Logger so global functions such as log.Infow write to the capture; the previous
+// global Logger is restored when the test ends.)
+func (l *Logger) UseAsGlobal() {
+	previous := log.Std()
+	log.SetGlobalLogger(l.Logger)
+	l.t.Cleanup(func() { log.SetGlobalLogger(previous) })
+}
+
+// Entries 返回按写入顺序排列的所有捕获的日志。(Entries returns every captured entry in the order written.)
+func (l *Logger) Entries() []Entry {
+	lines := l.rawLines()
+	entries := make([]Entry, 0, len(lines))
+	for _, line := range lines {
+		entries = append(entries, parseEntry(line))
+	}
+	return entries
+}
+
+// Reset 丢弃已捕获的日志。(Reset discards the captured entries.)
+func (l *Logger) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = nil
+}
+
+// Has 报告是否捕获了匹配的日志：level 不区分大小写，为空时匹配任意级别；消息包含 msgSubstring；
+// fields 为键值对，每个键都存在且值与按日志编码后的期望值相等，例如 Has("info", "served", "status", 200)。
+// (Has reports whether a matching entry was captured: level is case-insensitive and matches any level when empty; the
+// message contains msgSubstring; fields are key-value pairs whose keys must all be present with values equal to the
+// expected values as the logger encodes them, e.g. Has("info", "served", "status", 200).)
+func (l *Logger) Has(level, msgSubstring string, fields ...any) bool {
+	want := expectedFields(fields)
+	for _, entry := range l.Entries() {
+		if entry.matches(level, msgSubstring, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsEntry 断言捕获了匹配的日志，参数与 Has 相同；不存在时以捕获的所有日志报告测试失败。
+// (ContainsEntry asserts that a matching entry was captured, with the same arguments as Has; otherwise it fails the test,
+// listing every captured entry.)
+func (l *Logger) ContainsEntry(level, msgSubstring string, fields ...any) bool {
+	l.t.Helper()
+	if l.Has(level, msgSubstring, fields...) {
+		return true
+	}
+	l.t.Errorf("no log entry matching level=%q message~=%q fields=%v\ncaptured entries:\n%s",
+		level, msgSubstring, fields, strings.Join(l.rawLines(), "\n"))
+	return false
+}
+
+// NotContainsEntry 断言没有捕获匹配的日志，参数与 Has 相同。
+// (NotContainsEntry asserts that no matching entry was captured, with the same arguments as Has.)
+func (l *Logger) NotContainsEntry(level, msgSubstring string, fields ...any) bool {
+	l.t.Helper()
+	want := expectedFields(fields)
+	for _, entry := range l.Entries() {
+		if entry.matches(level, msgSubstring, want) {
+			l.t.Errorf("unexpected log entry matching level=%q message~=%q fields=%v:\n%s", level, msgSubstring, fields, entry.Raw)
+			return false
+		}
+	}
+	return true
+}
+
+// write 记录编码器写出的日志行。(write records the lines written by the encoder.)
+func (l *Logger) write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, line := range bytes.Split(bytes.TrimRight(p, "\n"), []byte("\n")) {
+		if len(line) > 0 {
+			l.lines = append(l.lines, string(line))
+		}
+	}
+	return len(p), nil
+}
+
+// rawLines 返回捕获的日志行的副本。(rawLines returns a copy of the captured lines.)
+func (l *Logger) rawLines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.lines...)
+}
+
+// matches 报告日志是否匹配级别、消息子串和期望的字段。
+// (matches reports whether the entry matches the level, message substring and expected fields.)
+func (e Entry) matches(level, msgSubstring string, want map[string]any) bool {
+	if level != "" && !strings.EqualFold(e.Level, level) {
+		return false
+	}
+	if !strings.Contains(e.Message, msgSubstring) {
+		return false
+	}
+	for key, value := range want {
+		got, ok := e.Fields[key]
+		if !ok || !reflect.DeepEqual(got, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseEntry 解析一行 JSON 日志，无法解析的行只保留 Raw。(parseEntry parses a JSON log line; unparsable lines only keep Raw.)
+func parseEntry(line string) Entry {
+	entry := Entry{Raw: line, Fields: map[string]any{}}
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+		return entry
+	}
+	entry.Level, _ = decoded[levelKey].(string)
+	entry.Message, _ = decoded[messageKey].(string)
+	entry.Name, _ = decoded[nameKey].(string)
+	entry.Caller, _ = decoded[callerKey].(string)
+	for key, value := range decoded {
+		switch key {
+		case levelKey, messageKey, nameKey, callerKey, timeKey, stacktraceKey:
+		default:
+			entry.Fields[key] = value
+		}
+	}
+	return entry
+}
+
+// expectedFields 将键值对按日志的 JSON 编码方式编码再解码，使 200、time.Second 或 error 等期望值能与捕获的值直接比较。
+// (expectedFields encodes the key-value pairs the way the logger encodes JSON and decodes them again, so expected values
+// such as 200, time.Second or an error compare directly with captured values.)
+func expectedFields(keysAndValues []any) map[string]any {
+	want := make(map[string]any, len(keysAndValues)/2)
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.TimeKey, cfg.LevelKey, cfg.NameKey, cfg.CallerKey, cfg.MessageKey, cfg.StacktraceKey = "", "", "", "", "", ""
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		var value any
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		buf, err := zapcore.NewJSONEncoder(cfg).EncodeEntry(zapcore.Entry{}, []zapcore.Field{zap.Any(key, value)})
+		if err != nil {
+			want[key] = value
+			continue
+		}
+		var decoded map[string]any
+		if json.Unmarshal(buf.Bytes(), &decoded) == nil {
+			want[key] = decoded[key]
+		} else {
+			want[key] = value
+		}
+		buf.Free()
+	}
+	return want
+}
+
+// writerFunc 将函数适配为 io.Writer。(writerFunc adapts a function to an io.Writer.)
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
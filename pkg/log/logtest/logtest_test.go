@@ -0,0 +1,98 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the log capture helper.
+ */
+
+package logtest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewTestLogger tests capturing entries and matching them by level, message and fields.
+// (TestNewTestLogger 测试捕获日志并按级别、消息和字段匹配。)
+func TestNewTestLogger(t *testing.T) {
+	logger := logtest.NewTestLogger(t)
+	logger.WithValues("component", "orders").WithName("svc").Infow("Order created",
+		"order_id", 42, "elapsed", 1500*time.Millisecond, "tags", []string{"a", "b"})
+	logger.Debugw("Cache miss", "key", "order:42")
+	logger.Errorw("Payment failed", "error", errors.New("card declined"))
+
+	entries := logger.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "INFO", entries[0].Level)
+	assert.Equal(t, "Order created", entries[0].Message)
+	assert.Equal(t, "svc", entries[0].Name)
+	assert.NotEmpty(t, entries[0].Caller)
+	assert.Equal(t, "orders", entries[0].Fields["component"])
+
+	assert.True(t, logger.ContainsEntry("info", "created", "order_id", 42, "elapsed", 1500*time.Millisecond, "tags", []string{"a", "b"}))
+	assert.True(t, logger.ContainsEntry("", "Cache", "key", "order:42"), "an empty level matches any level")
+	assert.True(t, logger.ContainsEntry("ERROR", "Payment", "error", errors.New("card declined")))
+	assert.True(t, logger.NotContainsEntry("warn", ""))
+
+	assert.False(t, logger.Has("info", "created", "order_id", 43), "field values must match")
+	assert.False(t, logger.Has("info", "created", "missing", "x"), "fields must be present")
+	assert.False(t, logger.Has("debug", "Order created"), "levels must match")
+
+	logger.Reset()
+	assert.Empty(t, logger.Entries())
+}
+
+// TestContainsEntryFailure tests that failed assertions report the captured entries on the test.
+// (TestContainsEntryFailure 测试断言失败时在测试上报告捕获的日志。)
+func TestContainsEntryFailure(t *testing.T) {
+	rec := &recordingT{TB: t}
+	logger := logtest.NewTestLogger(rec)
+	logger.Warn("disk almost full")
+
+	assert.False(t, logger.ContainsEntry("info", "disk"))
+	assert.False(t, logger.NotContainsEntry("warn", "disk"))
+	require.Len(t, rec.errors, 2)
+	assert.Contains(t, rec.errors[0], "disk almost full", "the failure lists the captured entries")
+}
+
+// TestNewTestLoggerWithOptions tests that the options still apply to the captured entries.
+// (TestNewTestLoggerWithOptions 测试选项仍作用于捕获的日志。)
+func TestNewTestLoggerWithOptions(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Level = "warn"
+	opts.DisableCaller = true
+	logger := logtest.NewTestLoggerWithOptions(t, opts)
+	logger.Info("ignored")
+	logger.Warn("kept")
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0].Message)
+	assert.Empty(t, entries[0].Caller)
+}
+
+// TestUseAsGlobal tests that the global functions write to the capture.
+// (TestUseAsGlobal 测试全局函数写入捕获区。)
+func TestUseAsGlobal(t *testing.T) {
+	logger := logtest.NewTestLogger(t)
+	logger.UseAsGlobal()
+	log.Infow("via global", "n", 1)
+	logger.ContainsEntry("info", "via global", "n", 1)
+}
+
+// recordingT 记录 Errorf 的消息而不使测试失败。(recordingT records Errorf messages without failing the test.)
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,113 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for per-module log levels.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestModuleLevels tests that named loggers use their module level and inherit it hierarchically.
+// (TestModuleLevels 测试命名记录器使用其模块级别，并按层级继承。)
+func TestModuleLevels(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.ModuleLevels = map[string]string{"db": "debug", "db.pool": "error", "http": "warn"}
+	require.Empty(t, opts.Validate())
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.WithName("db").Debug("db debug")
+	logger.WithName("db").WithName("query").WithValues("table", "orders").Debug("db.query debug")
+	logger.WithName("db").WithName("pool").Warn("db.pool warn")
+	logger.WithName("db").WithName("pool").Error("db.pool error")
+	logger.WithName("http").Info("http info")
+	logger.WithName("http").Warn("http warn")
+	logger.WithName("database").Debug("database debug")
+
+	out := buf.String()
+	assert.NotContains(t, out, "root debug", "unnamed loggers use the global level")
+	assert.Contains(t, out, "root info")
+	assert.Contains(t, out, "db debug")
+	assert.Contains(t, out, "db.query debug", "db.query inherits the level of db")
+	assert.NotContains(t, out, "db.pool warn", "db.pool overrides db")
+	assert.Contains(t, out, "db.pool error")
+	assert.NotContains(t, out, "http info")
+	assert.Contains(t, out, "http warn")
+	assert.NotContains(t, out, "database debug", "only whole name segments match")
+}
+
+// TestModuleLevelsOff tests that "off" silences a name and its descendants and that names appear in the output.
+// (TestModuleLevelsOff 测试 "off" 关闭某个名称及其子名称的输出，以及名称出现在输出中。)
+func TestModuleLevelsOff(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Format = log.FormatJSON
+	opts.DisableStacktrace = true
+	opts.ModuleLevels = map[string]string{"svc.http": "OFF", "svc.http.server": "info"}
+	require.Empty(t, opts.Validate())
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	svc := logger.WithName("svc")
+	svc.WithName("http").WithName("client").Error("client error")
+	svc.WithName("http").Error("http error")
+	svc.WithName("http").WithName("server").Info("server info")
+	svc.Info("svc info")
+
+	lines := decodeLogLines(t, buf.String())
+	require.Len(t, lines, 2)
+	assert.Equal(t, "server info", lines[0]["M"])
+	assert.Equal(t, "svc.http.server", lines[0]["N"])
+	assert.Equal(t, "svc", lines[1]["N"])
+}
+
+// TestModuleLevelsValidate tests rejecting invalid levels and empty module names.
+// (TestModuleLevelsValidate 测试拒绝无效级别和空模块名。)
+func TestModuleLevelsValidate(t *testing.T) {
+	opts := log.NewOptions()
+	opts.ModuleLevels = map[string]string{"db": "verbose", "": "info"}
+	assert.Len(t, opts.Validate(), 2)
+
+	_, err := log.NewLogger(opts)
+	assert.Error(t, err)
+}
+
+// TestModuleLevelsHotReload tests that module levels in the log section are applied on reload.
+// (TestModuleLevelsHotReload 测试日志配置节中的模块级别在重载时生效。)
+func TestModuleLevelsHotReload(t *testing.T) {
+	defer log.Init(log.NewOptions())
+
+	cm := &sectionManager{callbacks: make(map[string]config.SectionChangeCallback)}
+	log.RegisterConfigHotReload(cm)
+
+	v := viper.New()
+	v.Set("log", map[string]any{
+		"level":         "warn",
+		"format":        "json",
+		"module-levels": map[string]any{"payments": "debug"},
+	})
+	var applied *log.Options
+	id := log.RegisterCallback(func(opts *log.Options) error {
+		applied = opts
+		return nil
+	})
+	defer log.UnregisterCallback(id)
+
+	require.NoError(t, cm.callbacks["log"](v))
+	require.NotNil(t, applied)
+	assert.Equal(t, map[string]string{"payments": "debug"}, applied.ModuleLevels)
+	assert.Equal(t, "warn", log.GetLevel(), "the global level is unaffected by module levels")
+}
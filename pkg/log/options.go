@@ -25,26 +25,55 @@ const (
 // 它遵循选项模式，允许用户自定义日志行为。
 // (It follows the options pattern, allowing users to customize logging behavior.)
 type Options struct {
-	// OutputPaths 指定了日志的输出路径，可以是 stdout、stderr 或文件路径。
-	// (OutputPaths specifies the log output paths. It can be stdout, stderr, or file paths.)
+	// OutputPaths 指定了日志的输出路径，可以是 stdout、stderr、文件路径或通过 RegisterSink 注册的 scheme URL（如 kafka://broker/topic）。
+	// (OutputPaths specifies the log output paths. It can be stdout, stderr, file paths, or URLs of a scheme registered with RegisterSink, such as kafka://broker/topic.)
 	OutputPaths []string `json:"output-paths" mapstructure:"outputPaths"`
 
 	// ErrorOutputPaths 指定了内部错误日志的输出路径。
 	// (ErrorOutputPaths specifies the output paths for internal error logs.)
 	ErrorOutputPaths []string `json:"error-output-paths" mapstructure:"errorOutputPaths"`
 
+	// LevelRoutes 按级别规则把日志写入不同输出，例如 {">=error": ["/var/log/app.err"], "<=info": ["stdout"]}。
+	// 规则为可选的比较运算符（>=、<=、>、<、=）加级别名，不带运算符时只匹配该级别；条目写入所有匹配的路由。
+	// 设置后取代 OutputPaths，需要全量输出时添加 ">=debug" 之类的路由。
+	// (LevelRoutes writes entries to different outputs by level rule, e.g. {">=error": ["/var/log/app.err"], "<=info": ["stdout"]}.
+	// A rule is an optional comparison operator (>=, <=, >, <, =) followed by a level name, matching only that level without
+	// an operator; an entry is written to every matching route. When set it replaces OutputPaths; add a route such as
+	// ">=debug" to keep an output with every entry.)
+	LevelRoutes map[string][]string `json:"level-routes" mapstructure:"level-routes"`
+
+	// SplitStdStreams 按容器平台（Cloud Run、Nomad 等）区分严重级别的约定，将 debug 和 info 写入 stdout，warn 及以上写入 stderr。
+	// 设置后取代 OutputPaths，不能与 LevelRoutes 同时使用。
+	// (SplitStdStreams follows the convention container platforms such as Cloud Run and Nomad use to classify severity:
+	// debug and info go to stdout, warn and above to stderr. When set it replaces OutputPaths; it cannot be combined with LevelRoutes.)
+	SplitStdStreams bool `json:"split-std-streams" mapstructure:"split-std-streams"`
+
 	// Level 指定了日志级别，例如 "debug", "info", "warn", "error", "fatal"。
 	// (Level specifies the log level, e.g., "debug", "info", "warn", "error", "fatal".)
 	Level string `json:"level" mapstructure:"level"`
 
-	// Format 指定了日志的输出格式，"json" 或 "text"。
-	// (Format specifies the log output format, either "json" or "text".)
+	// ModuleLevels 为指定名称的记录器（通过 WithName 创建）单独设置级别，例如 {"db": "debug", "http": "warn"}。
+	// 名称按层级继承："db.pool" 未配置时使用 "db" 的级别，都未配置时使用 Level。"off" 关闭该名称及其子名称的输出。
+	// (ModuleLevels sets the level of named loggers created with WithName, e.g. {"db": "debug", "http": "warn"}.
+	// Names inherit hierarchically: "db.pool" uses the level of "db" unless configured itself, and Level applies when neither is.
+	// "off" silences a name and its descendants.)
+	ModuleLevels map[string]string `json:"module-levels" mapstructure:"module-levels"`
+
+	// Format 指定了日志的输出格式："json"、"text"、"keyvalue" 或通过 RegisterEncoder 注册的名称。
+	// (Format specifies the log output format: "json", "text", "keyvalue", or a name registered with RegisterEncoder.)
 	Format string `json:"format" mapstructure:"format"`
 
 	// DisableCaller 禁用在日志条目中包含调用者信息（文件和行号）。
 	// (DisableCaller disables including caller information (file and line number) in log entries.)
 	DisableCaller bool `json:"disable-caller" mapstructure:"disable-caller"`
 
+	// CallerSkip 是报告调用者时额外跳过的栈帧数。将 SDK 记录器包装在自己的辅助函数中时设为包装层数，
+	// 使调用者指向辅助函数的调用方而不是辅助函数本身。不能为负数。
+	// (CallerSkip is the number of extra stack frames skipped when reporting the caller. Set it to the number of wrapper
+	// layers when wrapping the SDK logger in your own helpers, so the caller points at the helper's caller rather than the
+	// helper itself. Must not be negative.)
+	CallerSkip int `json:"caller-skip" mapstructure:"caller-skip"`
+
 	// DisableStacktrace 禁用在 Error 级别及以上的日志中自动记录堆栈跟踪。
 	// (DisableStacktrace disables automatic stacktrace recording on logs at Error level and above.)
 	DisableStacktrace bool `json:"disable-stacktrace" mapstructure:"disable-stacktrace"`
@@ -93,12 +122,80 @@ type Options struct {
 	// (LogRotateCompress determines if the rotated log files should be compressed (gzip).)
 	LogRotateCompress bool `json:"log-rotate-compress" mapstructure:"log-rotate-compress"`
 
+	// LogRotateInterval 启用按时间轮转："daily" 在本地午夜、"hourly" 在每个整点，或能整除 24h 的时长（例如 "6h"）。
+	// 文件名中插入时间段的时间戳，例如 app.log 写入 app-2024-05-03.log；此时忽略 LogRotateMaxSize，
+	// LogRotateMaxBackups、LogRotateMaxAge 和 LogRotateCompress 作用于之前时间段的文件。为空时不按时间轮转。
+	// (LogRotateInterval enables time-based rotation: "daily" at local midnight, "hourly" at the top of every hour, or a duration
+	// dividing 24h evenly such as "6h". The period's timestamp is inserted into the file name, so app.log is written to
+	// app-2024-05-03.log; LogRotateMaxSize is then ignored and LogRotateMaxBackups, LogRotateMaxAge and LogRotateCompress
+	// apply to the files of earlier periods. Empty disables time-based rotation.)
+	LogRotateInterval string `json:"log-rotate-interval" mapstructure:"log-rotate-interval"`
+
 	// ContextKeys 是用户希望从 context 中自动提取并添加到日志字段的额外键列表。
 	// 这些键的类型应该与 context.WithValue 中使用的键类型完全匹配。
 	// (ContextKeys is a list of additional keys that the user wants to automatically extract
 	// from the context and add to the log fields. The type of these keys should exactly match
 	// the type of keys used in context.WithValue.)
 	ContextKeys []any `json:"context-keys" mapstructure:"context-keys"`
+
+	// ContextFields 将日志字段名映射为 context 键，例如 {"tenant_id": tenantKey}，Ctx* 方法会以该名称写入 ctx.Value(key)。
+	// 键是 Go 值，无法从配置文件加载，因此热重载后不会保留；需要全局生效时使用 RegisterContextField。
+	// (ContextFields maps log field names to context keys, e.g. {"tenant_id": tenantKey}; the Ctx* methods write ctx.Value(key)
+	// under that name. Keys are Go values that cannot be loaded from configuration files, so they are not kept across hot reloads;
+	// use RegisterContextField for fields that apply globally.)
+	ContextFields map[string]any `json:"-" mapstructure:"-"`
+
+	// EnableOTelTraceContext 使 Ctx* 方法从 context 中的 OpenTelemetry span 提取 trace_id、span_id 和 trace_flags，
+	// 值采用 W3C Trace Context 格式，使日志可以在 Jaeger、Tempo 等系统中与追踪关联。
+	// (EnableOTelTraceContext makes the Ctx* methods extract trace_id, span_id and trace_flags from the OpenTelemetry span in the context,
+	// formatted as in W3C Trace Context, so logs correlate with traces in systems such as Jaeger and Tempo.)
+	EnableOTelTraceContext bool `json:"enable-otel-trace-context" mapstructure:"enable-otel-trace-context"`
+
+	// Service 是服务标识，设置 Name 后作为标准字段写入每条日志。
+	// (Service is the service identity, written to every log entry as standard fields once Name is set.)
+	Service ServiceOptions `json:"service" mapstructure:"service"`
+
+	// RedactKeys 是需要脱敏的字段名模式，不区分大小写，支持 * 和 ? 通配符，例如 "*password*"、"authorization"。
+	// 嵌套对象中匹配的键同样会被替换为 RedactedValue；DefaultRedactKeys 提供了常见的敏感字段名。
+	// (RedactKeys holds case-insensitive field name patterns to redact, supporting * and ? wildcards, e.g. "*password*" or "authorization".
+	// Matching keys inside nested objects are replaced with RedactedValue too; DefaultRedactKeys lists common sensitive names.)
+	RedactKeys []string `json:"redact-keys" mapstructure:"redact-keys"`
+
+	// RedactPatterns 是正则表达式，字符串值和日志消息中匹配的部分会被替换为 RedactedValue。
+	// (RedactPatterns holds regular expressions; the parts of string values and log messages they match are replaced with RedactedValue.)
+	RedactPatterns []string `json:"redact-patterns" mapstructure:"redact-patterns"`
+
+	// RedactCardNumbers 将字符串值和日志消息中通过 Luhn 校验的 13 到 19 位卡号替换为 RedactedValue。
+	// (RedactCardNumbers replaces 13 to 19 digit card numbers passing the Luhn check in string values and log messages with RedactedValue.)
+	RedactCardNumbers bool `json:"redact-card-numbers" mapstructure:"redact-card-numbers"`
+
+	// ExpandErrors 将错误字段展开为包含 message、code、http_status 和 stack 的对象，而不是只写入 Error() 字符串；
+	// code 和 http_status 来自 pkg/errors 的 Coder，stack 来自错误创建时捕获的堆栈，缺失时省略。
+	// (ExpandErrors expands error fields into an object with message, code, http_status and stack instead of only the Error() string;
+	// code and http_status come from the pkg/errors Coder and stack from the stack captured when the error was created, each omitted when absent.)
+	ExpandErrors bool `json:"expand-errors" mapstructure:"expand-errors"`
+
+	// Sampling 配置高流量场景下的日志采样，默认不采样；随日志配置节一起热重载。
+	// (Sampling configures log sampling for high-volume services; off by default and hot-reloaded with the log section.)
+	Sampling SamplingOptions `json:"sampling" mapstructure:"sampling"`
+
+	// Dedup 将窗口内相同的日志合并为一条带 "repeated" 字段的条目，防止日志风暴；默认关闭，随日志配置节一起热重载。
+	// (Dedup collapses identical entries within a window into one entry with a "repeated" field to guard against log storms;
+	// off by default and hot-reloaded with the log section.)
+	Dedup DedupOptions `json:"dedup" mapstructure:"dedup"`
+
+	// AsyncBuffer 使文件和网络输出通过有界缓冲区异步写入，避免磁盘写入阻塞调用方；默认关闭。
+	// (AsyncBuffer makes file and network sinks write asynchronously through a bounded buffer so disk writes do not block callers; off by default.)
+	AsyncBuffer AsyncBufferOptions `json:"async-buffer" mapstructure:"async-buffer"`
+
+	// Audit 配置 Audit 写出的审计事件的独立输出，与应用日志分开；默认写入应用日志。
+	// (Audit configures the dedicated outputs of the audit events written by Audit, apart from the application log; they go to the application log by default.)
+	Audit AuditOptions `json:"audit" mapstructure:"audit"`
+
+	// StatsReport 配置全局记录器周期性写出的日志量报告条目，统计本身始终可通过 Stats 获取；默认不报告。
+	// (StatsReport configures the log volume report entries the global logger writes periodically; the statistics are always
+	// available from Stats. No report is written by default.)
+	StatsReport StatsReportOptions `json:"stats-report" mapstructure:"stats-report"`
 }
 
 // NewOptions 创建具有默认值的日志选项 (creates logging options with default values)
@@ -120,6 +217,7 @@ func NewOptions() *Options {
 		LogRotateMaxBackups: 5,                             // 默认保留 5 个备份 (Default retain 5 backups)
 		LogRotateCompress:   false,                          // 默认不压缩 (No compression by default)
 		ContextKeys:         nil,                            // 默认不提取额外键 (No extra keys by default, now type is []any)
+		EnableOTelTraceContext: false,                       // 默认不提取 OTel span (OTel spans are not extracted by default)
 	}
 }
 
@@ -134,9 +232,21 @@ func (o *Options) Validate() []error {
 		errs = append(errs, fmt.Errorf("invalid log level '%s': %w", o.Level, err))
 	}
 
+	errs = append(errs, validateModuleLevels(o.ModuleLevels)...)
+	errs = append(errs, validateLevelRoutes(o.LevelRoutes)...)
+	if o.SplitStdStreams && len(o.LevelRoutes) > 0 {
+		errs = append(errs, fmt.Errorf("split-std-streams cannot be combined with level-routes"))
+	}
+	errs = append(errs, validateContextFields(o.ContextFields)...)
+	errs = append(errs, validateRedaction(o.RedactKeys, o.RedactPatterns)...)
+
+	if o.CallerSkip < 0 {
+		errs = append(errs, fmt.Errorf("invalid caller skip %d, must not be negative", o.CallerSkip))
+	}
+
 	// 验证 Format
-	if o.Format != FormatJSON && o.Format != FormatText && o.Format != FormatKeyValue {
-		errs = append(errs, fmt.Errorf("invalid log format '%s', must be '%s', '%s', or '%s'", o.Format, FormatJSON, FormatText, FormatKeyValue))
+	if err := validFormat(o.Format); err != nil {
+		errs = append(errs, err)
 	}
 
 	// 验证 StacktraceLevel
@@ -145,6 +255,17 @@ func (o *Options) Validate() []error {
 		errs = append(errs, fmt.Errorf("invalid stacktrace level '%s': %w", o.StacktraceLevel, err))
 	}
 
+	if _, err := parseRotateInterval(o.LogRotateInterval); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, o.Sampling.Validate()...)
+	errs = append(errs, o.Dedup.Validate()...)
+	errs = append(errs, o.AsyncBuffer.Validate()...)
+	errs = append(errs, o.Audit.Validate()...)
+	errs = append(errs, o.StatsReport.Validate()...)
+	errs = append(errs, validateAuditOutputs(o)...)
+
 	// 其他验证可以根据需要添加，例如 OutputPaths 是否有效等。
 
 	return errs
@@ -0,0 +1,66 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"context"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// 从 OpenTelemetry span 提取的日志字段名，值采用 W3C Trace Context 的小写十六进制格式。
+// (Log field names extracted from an OpenTelemetry span; values use the lowercase hex format of W3C Trace Context.)
+const (
+	// TraceIDField 是 32 位十六进制的 trace ID。(TraceIDField is the 32-digit hex trace ID.)
+	TraceIDField = "trace_id"
+	// SpanIDField 是 16 位十六进制的 span ID。(SpanIDField is the 16-digit hex span ID.)
+	SpanIDField = "span_id"
+	// TraceFlagsField 是 2 位十六进制的 trace flags，"01" 表示已采样。(TraceFlagsField is the 2-digit hex trace flags; "01" means sampled.)
+	TraceFlagsField = "trace_flags"
+)
+
+// otelTraceFields 返回 ctx 中有效 span 的 trace_id、span_id 和 trace_flags 字段。
+// 已由 ContextKeys 提取 trace_id 时不重复写入，使 ContextWithTraceID 设置的值优先。
+// (otelTraceFields returns the trace_id, span_id and trace_flags fields of the valid span in ctx.
+// trace_id is not repeated when ContextKeys already extracted one, so a value set with ContextWithTraceID takes precedence.)
+func otelTraceFields(ctx context.Context, existing []zap.Field) []zap.Field {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	fields := make([]zap.Field, 0, 3)
+	if !hasField(existing, TraceIDField) {
+		fields = append(fields, zap.String(TraceIDField, sc.TraceID().String()))
+	}
+	return append(fields,
+		zap.String(SpanIDField, sc.SpanID().String()),
+		zap.String(TraceFlagsField, sc.TraceFlags().String()),
+	)
+}
+
+// hasField 报告 fields 中是否已有名为 key 的字段。(hasField reports whether fields already holds a field named key.)
+func hasField(fields []zap.Field, key string) bool {
+	for _, f := range fields {
+		if f.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// appendHookFields 追加上下文钩子返回的字段，跳过已存在的键，避免 OTel 提取与 trace.CorrelateLogs 重复写入 trace_id 和 span_id。
+// (appendHookFields appends the fields returned by context hooks, skipping keys already present so OTel extraction and
+// trace.CorrelateLogs do not both write trace_id and span_id.)
+func appendHookFields(fields, hookFields []zap.Field) []zap.Field {
+	for _, f := range hookFields {
+		if !hasField(fields, f.Key) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,94 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for extracting OpenTelemetry trace context into logs.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// spanContext 返回带有固定 ID 的远程 span 上下文。(spanContext returns a context holding a remote span with fixed IDs.)
+func spanContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := oteltrace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := oteltrace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: oteltrace.FlagsSampled,
+		Remote:     true,
+	})
+	return oteltrace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func decodeEntry(t *testing.T, out *bytes.Buffer) map[string]any {
+	t.Helper()
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(out.Bytes()), &entry))
+	out.Reset()
+	return entry
+}
+
+// TestOTelTraceContext tests that Ctx* methods add the W3C trace context of the active span only when enabled.
+// (TestOTelTraceContext 测试仅在启用时 Ctx* 方法才会添加当前 span 的 W3C 追踪上下文。)
+func TestOTelTraceContext(t *testing.T) {
+	ctx := spanContext(t)
+	var out bytes.Buffer
+
+	opts := log.NewOptions()
+	logger := log.NewLoggerWithWriter(opts, &out)
+	logger.Ctxw(ctx, "disabled")
+	assert.NotContains(t, decodeEntry(t, &out), log.TraceIDField)
+
+	opts.EnableOTelTraceContext = true
+	logger = log.NewLoggerWithWriter(opts, &out)
+	logger.Ctxw(ctx, "enabled", "order", 42)
+	entry := decodeEntry(t, &out)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry[log.TraceIDField])
+	assert.Equal(t, "00f067aa0ba902b7", entry[log.SpanIDField])
+	assert.Equal(t, "01", entry[log.TraceFlagsField])
+
+	logger.CtxErrorf(ctx, "failed %d", 1)
+	assert.Equal(t, "00f067aa0ba902b7", decodeEntry(t, &out)[log.SpanIDField])
+
+	logger.Ctx(context.Background(), "no span")
+	assert.NotContains(t, decodeEntry(t, &out), log.SpanIDField)
+}
+
+// TestOTelTraceContextPrecedence tests that an explicit trace ID wins and that CorrelateLogs does not duplicate fields.
+// (TestOTelTraceContextPrecedence 测试显式设置的 trace ID 优先，且 CorrelateLogs 不会重复写入字段。)
+func TestOTelTraceContextPrecedence(t *testing.T) {
+	var out bytes.Buffer
+	opts := log.NewOptions()
+	opts.EnableOTelTraceContext = true
+	opts.ContextKeys = []any{log.TraceIDKey}
+	logger := log.NewLoggerWithWriter(opts, &out)
+
+	ctx := log.ContextWithTraceID(spanContext(t), "legacy-trace")
+	logger.Ctxw(ctx, "explicit")
+	assert.Equal(t, "legacy-trace", decodeEntry(t, &out)[log.TraceIDField])
+
+	remove := trace.CorrelateLogs(zapcore.InfoLevel)
+	defer remove()
+	logger.Ctxw(spanContext(t), "correlated")
+	line := out.String()
+	assert.Equal(t, 1, strings.Count(line, `"span_id"`), line)
+	assert.Equal(t, 1, strings.Count(line, `"trace_id"`), line)
+}
@@ -0,0 +1,370 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactedValue 是被脱敏的值在日志中的替换文本。(RedactedValue replaces redacted values in log output.)
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactKeys 是常见敏感字段名的模式，可直接赋给 Options.RedactKeys。
+// (DefaultRedactKeys holds patterns for common sensitive field names, ready to be assigned to Options.RedactKeys.)
+var DefaultRedactKeys = []string{"*password*", "*passwd*", "*secret*", "*token*", "authorization", "cookie", "*api_key*", "*api-key*", "*apikey*"}
+
+// Redactor 检查一个字段，返回替换值和 true 表示该字段需要脱敏。嵌套对象中的每个键都会被检查，
+// 此时 key 为嵌套键名。Redactor 可能被并发调用。
+// (Redactor inspects a field and returns the replacement value and true when the field must be redacted. Every key of
+// nested objects is inspected too, with key set to the nested key name. A Redactor may be called concurrently.)
+type Redactor func(key string, val any) (any, bool)
+
+// redactorRegistration 包装脱敏函数以便按指针移除。(redactorRegistration wraps a redactor so it can be removed by pointer.)
+type redactorRegistration struct {
+	redact Redactor
+}
+
+var (
+	// redactorsMu 串行化脱敏函数的注册与移除。(redactorsMu serialises redactor registration and removal.)
+	redactorsMu sync.Mutex
+	// redactors 是写时复制的脱敏函数列表，读取时无锁。(redactors is a copy-on-write redactor list, read without locks.)
+	redactors atomic.Pointer[[]*redactorRegistration]
+
+	// cardNumberPattern 匹配可能是银行卡号的 13 到 19 位数字，允许空格或连字符分隔。
+	// (cardNumberPattern matches 13 to 19 digits that may be a card number, optionally separated by spaces or hyphens.)
+	cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// AddRedactor 注册一个全局脱敏函数，对所有记录器（包括已创建的）生效，返回用于移除它的函数。
+// 脱敏函数按注册顺序调用，在 Options 的字段名规则之后、值规则之前。
+// (AddRedactor registers a global redactor that applies to every logger, including existing ones, and returns a function
+// that removes it. Redactors run in registration order, after the field name rules of Options and before its value rules.)
+func AddRedactor(redactor Redactor) (remove func()) {
+	reg := &redactorRegistration{redact: redactor}
+
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	var regs []*redactorRegistration
+	if cur := redactors.Load(); cur != nil {
+		regs = append(regs, *cur...)
+	}
+	regs = append(regs, reg)
+	redactors.Store(&regs)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			redactorsMu.Lock()
+			defer redactorsMu.Unlock()
+			cur := redactors.Load()
+			if cur == nil {
+				return
+			}
+			remaining := make([]*redactorRegistration, 0, len(*cur))
+			for _, r := range *cur {
+				if r != reg {
+					remaining = append(remaining, r)
+				}
+			}
+			redactors.Store(&remaining)
+		})
+	}
+}
+
+// validateRedaction 校验字段名模式和值正则表达式。
+// (validateRedaction checks the field name patterns and value regular expressions.)
+func validateRedaction(keys, patterns []string) []error {
+	var errs []error
+	for _, key := range keys {
+		if _, err := path.Match(strings.ToLower(key), ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid redact key pattern '%s': %w", key, err))
+		}
+	}
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("invalid redact pattern '%s': %w", pattern, err))
+		}
+	}
+	return errs
+}
+
+// redaction 是根据 Options 编译好的脱敏规则。(redaction holds the redaction rules compiled from Options.)
+type redaction struct {
+	keys        []string
+	patterns    []*regexp.Regexp
+	cardNumbers bool
+}
+
+// newRedaction 编译 Options 中的脱敏规则，忽略无效项（Validate 已报告）。
+// (newRedaction compiles the redaction rules of Options, skipping invalid entries that Validate already reports.)
+func newRedaction(opts *Options) *redaction {
+	r := &redaction{cardNumbers: opts.RedactCardNumbers}
+	for _, key := range opts.RedactKeys {
+		key = strings.ToLower(key)
+		if _, err := path.Match(key, ""); err == nil {
+			r.keys = append(r.keys, key)
+		}
+	}
+	for _, pattern := range opts.RedactPatterns {
+		if re, err := regexp.Compile(pattern); err == nil {
+			r.patterns = append(r.patterns, re)
+		}
+	}
+	return r
+}
+
+// redactsValues 报告是否配置了值规则。(redactsValues reports whether any value rule is configured.)
+func (r *redaction) redactsValues() bool {
+	return len(r.patterns) > 0 || r.cardNumbers
+}
+
+// matchKey 报告字段名是否匹配任一模式，不区分大小写。
+// (matchKey reports whether the field name matches any pattern, case-insensitively.)
+func (r *redaction) matchKey(key string) bool {
+	if len(r.keys) == 0 {
+		return false
+	}
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redactString 将字符串中匹配值规则的部分替换为 RedactedValue。
+// (redactString replaces the parts of s matching the value rules with RedactedValue.)
+func (r *redaction) redactString(s string) (string, bool) {
+	out := s
+	for _, re := range r.patterns {
+		out = re.ReplaceAllString(out, RedactedValue)
+	}
+	if r.cardNumbers {
+		out = cardNumberPattern.ReplaceAllStringFunc(out, func(match string) string {
+			if luhnValid(match) {
+				return RedactedValue
+			}
+			return match
+		})
+	}
+	return out, out != s
+}
+
+// redactValue 递归地脱敏一个已归一化的值（map、切片或标量）。
+// (redactValue recursively redacts a normalized value: a map, a slice or a scalar.)
+func (r *redaction) redactValue(key string, val any, regs []*redactorRegistration) (any, bool) {
+	for _, reg := range regs {
+		if replaced, ok := reg.redact(key, val); ok {
+			return replaced, true
+		}
+	}
+	switch v := val.(type) {
+	case map[string]any:
+		var out map[string]any
+		for k, item := range v {
+			var replaced any = RedactedValue
+			changed := true
+			if !r.matchKey(k) {
+				replaced, changed = r.redactValue(k, item, regs)
+			}
+			if changed {
+				if out == nil {
+					out = make(map[string]any, len(v))
+					for ck, cv := range v {
+						out[ck] = cv
+					}
+				}
+				out[k] = replaced
+			}
+		}
+		if out != nil {
+			return out, true
+		}
+	case []any:
+		var out []any
+		for i, item := range v {
+			if replaced, changed := r.redactValue(key, item, regs); changed {
+				if out == nil {
+					out = append([]any(nil), v...)
+				}
+				out[i] = replaced
+			}
+		}
+		if out != nil {
+			return out, true
+		}
+	case string:
+		return r.redactString(v)
+	}
+	return val, false
+}
+
+// redactFields 返回脱敏后的字段；没有字段需要脱敏时返回原切片。
+// (redactFields returns the redacted fields, or the original slice when no field needs redaction.)
+func (r *redaction) redactFields(fields []zapcore.Field) []zapcore.Field {
+	var regs []*redactorRegistration
+	if cur := redactors.Load(); cur != nil {
+		regs = *cur
+	}
+	if len(r.keys) == 0 && !r.redactsValues() && len(regs) == 0 {
+		return fields
+	}
+
+	var out []zapcore.Field
+	for i, f := range fields {
+		replaced, changed := r.redactField(f, regs)
+		if changed && out == nil {
+			out = append(make([]zapcore.Field, 0, len(fields)), fields[:i]...)
+		}
+		switch {
+		case changed:
+			out = append(out, replaced...)
+		case out != nil:
+			out = append(out, f)
+		}
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// redactField 脱敏单个字段，需要脱敏时返回替换字段。复杂字段先编码为 map 再逐键检查。
+// (redactField redacts one field, returning the replacement fields when it changed. Complex fields are encoded into a map
+// first and inspected key by key.)
+func (r *redaction) redactField(f zapcore.Field, regs []*redactorRegistration) ([]zapcore.Field, bool) {
+	switch f.Type {
+	case zapcore.NamespaceType, zapcore.SkipType:
+		return nil, false
+	}
+	if r.matchKey(f.Key) {
+		return []zapcore.Field{zap.String(f.Key, RedactedValue)}, true
+	}
+	switch f.Type {
+	case zapcore.StringType:
+		if replaced, changed := r.redactValue(f.Key, f.String, regs); changed {
+			return []zapcore.Field{zap.Any(f.Key, replaced)}, true
+		}
+		return nil, false
+	case zapcore.ObjectMarshalerType, zapcore.InlineMarshalerType, zapcore.ArrayMarshalerType, zapcore.ReflectType,
+		zapcore.ErrorType, zapcore.StringerType, zapcore.ByteStringType:
+	default:
+		// 数值、布尔和时间等标量只需交给脱敏函数检查 (Scalars such as numbers, bools and times only need the redactors)
+		if len(regs) == 0 {
+			return nil, false
+		}
+	}
+
+	// 编码后可能得到多个键，例如错误字段的 "error" 和 "errorVerbose"
+	// (Encoding may yield several keys, e.g. "error" and "errorVerbose" for an error field)
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	keys := make([]string, 0, len(enc.Fields))
+	for k := range enc.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	changed := false
+	values := make([]any, len(keys))
+	for i, k := range keys {
+		var fieldChanged bool
+		values[i], fieldChanged = r.redactValue(k, normalizeValue(enc.Fields[k]), regs)
+		changed = changed || fieldChanged
+	}
+	if !changed {
+		return nil, false
+	}
+	out := make([]zapcore.Field, len(keys))
+	for i, k := range keys {
+		out[i] = zap.Any(k, values[i])
+	}
+	return out, true
+}
+
+// normalizeValue 将反射得到的值（结构体、具体类型的 map 等）通过 JSON 转换为 map[string]any、[]any 或标量，
+// 与 JSON 编码器的输出一致；无法转换时原样返回。
+// (normalizeValue converts reflected values such as structs and typed maps into map[string]any, []any or scalars
+// through JSON, matching what the JSON encoder writes; values that cannot be converted are returned as is.)
+func normalizeValue(val any) any {
+	switch val.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, map[string]any, []any:
+		return val
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return val
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return val
+	}
+	return out
+}
+
+// luhnValid 报告数字串（忽略空格和连字符）是否通过 Luhn 校验。
+// (luhnValid reports whether the digits of s, ignoring spaces and hyphens, pass the Luhn check.)
+func luhnValid(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// newRedactingCore 包装 core，使条目消息和字段在编码前脱敏。
+// (newRedactingCore wraps core so entry messages and fields are redacted before encoding.)
+func newRedactingCore(core zapcore.Core, r *redaction) zapcore.Core {
+	return &redactingCore{Core: core, redaction: r}
+}
+
+// redactingCore 在写入前脱敏字段和消息，通过 With 添加的字段在添加时脱敏。
+// (redactingCore redacts fields and messages before writing; fields added with With are redacted when added.)
+type redactingCore struct {
+	zapcore.Core
+	redaction *redaction
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redaction.redactFields(fields)), redaction: c.redaction}
+}
+
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.redaction.redactsValues() {
+		ent.Message, _ = c.redaction.redactString(ent.Message)
+	}
+	return c.Core.Write(ent, c.redaction.redactFields(fields))
+}
@@ -0,0 +1,130 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for redacting sensitive fields and values.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLogLines 将 JSON 日志的每一行解码为 map。(decodeLogLines decodes every line of JSON log output into a map.)
+func decodeLogLines(t *testing.T, out string) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// TestRedactKeys tests that fields and nested keys matching RedactKeys are masked, including fields added with With.
+// (TestRedactKeys 测试匹配 RedactKeys 的字段和嵌套键会被遮蔽，包括通过 With 添加的字段。)
+func TestRedactKeys(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.RedactKeys = log.DefaultRedactKeys
+	require.Empty(t, opts.Validate())
+
+	type credentials struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	}
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	logger.WithValues("Authorization", "Bearer abc").Infow("login",
+		"user", "alice",
+		"db_password", "hunter2",
+		"attempts", 3,
+		"request", map[string]any{"headers": map[string]any{"X-Api-Key": "k-123", "Accept": "json"}},
+		"credentials", credentials{User: "bob", Password: "s3cret"},
+	)
+
+	entries := decodeLogLines(t, buf.String())
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, log.RedactedValue, entry["Authorization"])
+	assert.Equal(t, "alice", entry["user"])
+	assert.Equal(t, log.RedactedValue, entry["db_password"])
+	assert.EqualValues(t, 3, entry["attempts"])
+	headers := entry["request"].(map[string]any)["headers"].(map[string]any)
+	assert.Equal(t, log.RedactedValue, headers["X-Api-Key"])
+	assert.Equal(t, "json", headers["Accept"])
+	assert.Equal(t, map[string]any{"user": "bob", "password": log.RedactedValue}, entry["credentials"])
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "s3cret")
+}
+
+// TestRedactValues tests that value patterns and card numbers are masked in string fields, errors and messages.
+// (TestRedactValues 测试值规则和卡号会在字符串字段、错误和消息中被遮蔽。)
+func TestRedactValues(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.RedactPatterns = []string{`ssn-\d{3}-\d{2}-\d{4}`}
+	opts.RedactCardNumbers = true
+	require.Empty(t, opts.Validate())
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	logger.Infof("charging card 4111 1111 1111 1111 for order 1234567890123")
+	logger.Infow("payment",
+		"card", "4111-1111-1111-1111",
+		"note", "customer ssn-123-45-6789",
+		"error", errors.New("declined: 5500005555555559"),
+	)
+
+	entries := decodeLogLines(t, buf.String())
+	require.Len(t, entries, 2)
+	// 订单号不通过 Luhn 校验，保持不变 (The order number fails the Luhn check and is kept)
+	assert.Equal(t, "charging card "+log.RedactedValue+" for order 1234567890123", entries[0]["M"])
+	assert.Equal(t, log.RedactedValue, entries[1]["card"])
+	assert.Equal(t, "customer "+log.RedactedValue, entries[1]["note"])
+	assert.Equal(t, "declined: "+log.RedactedValue, entries[1]["error"])
+}
+
+// TestAddRedactor tests that a global redactor applies to existing loggers until it is removed.
+// (TestAddRedactor 测试全局脱敏函数对已创建的记录器生效，直到被移除。)
+func TestAddRedactor(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	remove := log.AddRedactor(func(key string, val any) (any, bool) {
+		if email, ok := val.(string); ok && key == "email" {
+			return email[:1] + "***", true
+		}
+		return nil, false
+	})
+	logger.Infow("signup", "email", "alice@example.com", "plan", "pro")
+	remove()
+	logger.Infow("signup", "email", "bob@example.com")
+
+	entries := decodeLogLines(t, buf.String())
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a***", entries[0]["email"])
+	assert.Equal(t, "pro", entries[0]["plan"])
+	assert.Equal(t, "bob@example.com", entries[1]["email"])
+}
+
+// TestRedactValidate tests rejecting malformed key patterns and regular expressions.
+// (TestRedactValidate 测试拒绝格式错误的键模式和正则表达式。)
+func TestRedactValidate(t *testing.T) {
+	opts := log.NewOptions()
+	opts.RedactKeys = []string{"[pass"}
+	opts.RedactPatterns = []string{"(unclosed"}
+	assert.Len(t, opts.Validate(), 2)
+}
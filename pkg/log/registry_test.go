@@ -0,0 +1,128 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for registering custom sinks and encoders.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// memorySink 是记录写入内容的测试输出。(memorySink is a test output that records what was written.)
+type memorySink struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *memorySink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *memorySink) Sync() error { return nil }
+
+func (s *memorySink) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+// TestRegisterSink tests that OutputPaths URLs of a registered scheme are opened by its factory.
+// (TestRegisterSink 测试已注册 scheme 的 OutputPaths URL 由其工厂创建。)
+func TestRegisterSink(t *testing.T) {
+	sink := &memorySink{}
+	var opened *url.URL
+	require.NoError(t, log.RegisterSink("memtest", func(u *url.URL) (zapcore.WriteSyncer, error) {
+		opened = u
+		return sink, nil
+	}))
+	require.NoError(t, log.RegisterSink("failtest", func(*url.URL) (zapcore.WriteSyncer, error) {
+		return nil, errors.New("broker unreachable")
+	}))
+
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{"MemTest://broker:9092/app-logs"}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+	logger.Infow("to custom sink", "k", "v")
+
+	require.NotNil(t, opened)
+	assert.Equal(t, "broker:9092", opened.Host)
+	assert.Equal(t, "/app-logs", opened.Path)
+	assert.Contains(t, sink.String(), "to custom sink")
+
+	opts.OutputPaths = []string{"failtest://broker/topic"}
+	_, err = log.NewLogger(opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrLogInitialization))
+	assert.Contains(t, err.Error(), "broker unreachable")
+
+	opts.OutputPaths = []string{"unregistered://broker/topic"}
+	_, err = log.NewLogger(opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output path scheme")
+
+	factory := func(*url.URL) (zapcore.WriteSyncer, error) { return sink, nil }
+	assert.Error(t, log.RegisterSink("memtest", factory), "duplicate scheme")
+	assert.Error(t, log.RegisterSink("stdout", factory), "reserved scheme")
+	assert.Error(t, log.RegisterSink("bad scheme", factory), "invalid scheme")
+	assert.Error(t, log.RegisterSink("nilfactory", nil), "nil factory")
+}
+
+// TestRegisterEncoder tests that Options.Format accepts and uses a registered encoder.
+// (TestRegisterEncoder 测试 Options.Format 接受并使用已注册的编码器。)
+func TestRegisterEncoder(t *testing.T) {
+	var gotConfig zapcore.EncoderConfig
+	require.NoError(t, log.RegisterEncoder("upperjson", func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		gotConfig = cfg
+		return &upperEncoder{Encoder: zapcore.NewJSONEncoder(cfg)}, nil
+	}))
+
+	opts := log.NewOptions()
+	opts.Format = "upperjson"
+	assert.Empty(t, opts.Validate())
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	logger.Info("custom format")
+	assert.Contains(t, buf.String(), "CUSTOM FORMAT")
+	assert.Equal(t, "M", gotConfig.MessageKey)
+
+	opts.Format = "unknown"
+	assert.NotEmpty(t, opts.Validate())
+
+	factory := func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) { return zapcore.NewJSONEncoder(cfg), nil }
+	assert.Error(t, log.RegisterEncoder("upperjson", factory), "duplicate name")
+	assert.Error(t, log.RegisterEncoder(log.FormatJSON, factory), "reserved name")
+	assert.Error(t, log.RegisterEncoder("", factory), "empty name")
+}
+
+// upperEncoder 将消息转为大写。(upperEncoder upper-cases the message.)
+type upperEncoder struct {
+	zapcore.Encoder
+}
+
+func (e *upperEncoder) Clone() zapcore.Encoder {
+	return &upperEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e *upperEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	entry.Message = strings.ToUpper(entry.Message)
+	return e.Encoder.EncodeEntry(entry, fields)
+}
@@ -0,0 +1,117 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for time-based log file rotation.
+ */
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseRotateInterval tests the accepted interval names and durations.
+// (TestParseRotateInterval 测试可接受的间隔名称和时长。)
+func TestParseRotateInterval(t *testing.T) {
+	testCases := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "daily", want: 24 * time.Hour},
+		{in: "Hourly", want: time.Hour},
+		{in: "6h", want: 6 * time.Hour},
+		{in: "30m", want: 30 * time.Minute},
+		{in: "7h", wantErr: true},
+		{in: "10s", wantErr: true},
+		{in: "weekly", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := parseRotateInterval(tc.in)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// TestTimeRotatingWriter tests switching to a new dated file at each boundary and pruning earlier files.
+// (TestTimeRotatingWriter 测试在每个边界切换到新的带日期文件，以及清理之前的文件。)
+func TestTimeRotatingWriter(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 3, 23, 59, 0, 0, time.Local)
+	opts := NewOptions()
+	opts.LogRotateMaxBackups = 1
+	opts.LogRotateCompress = true
+
+	rotateNow = func() time.Time { return now }
+	defer func() { rotateNow = time.Now }()
+
+	w, err := newTimeRotateLogger(filepath.Join(dir, "app.log"), 24*time.Hour, opts)
+	require.NoError(t, err)
+	defer w.Close()
+
+	write := func(s string) {
+		_, errWrite := w.Write([]byte(s))
+		require.NoError(t, errWrite)
+	}
+	write("first\n")
+	now = now.Add(2 * time.Minute)
+	write("second\n")
+	now = now.Add(24 * time.Hour)
+	write("third\n")
+	w.prune(now)
+
+	data, err := os.ReadFile(filepath.Join(dir, "app-2024-05-05.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(data))
+	assert.FileExists(t, filepath.Join(dir, "app-2024-05-04.log.gz"), "the previous day is compressed")
+	assert.NoFileExists(t, filepath.Join(dir, "app-2024-05-04.log"))
+	assert.NoFileExists(t, filepath.Join(dir, "app-2024-05-03.log"), "files beyond MaxBackups are removed")
+}
+
+// TestTimeRotatingWriter_Period tests hourly file names and boundaries counted from local midnight.
+// (TestTimeRotatingWriter_Period 测试按小时的文件名以及从本地午夜开始计算的边界。)
+func TestTimeRotatingWriter_Period(t *testing.T) {
+	w := &timeRotatingWriter{interval: 6 * time.Hour, layout: rotateLayout(6 * time.Hour)}
+	start, end := w.period(time.Date(2024, 5, 3, 13, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, 5, 3, 18, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, "2024-05-03T12", start.Format(w.layout))
+	assert.Equal(t, "2024-05-03T12-30", time.Date(2024, 5, 3, 12, 30, 0, 0, time.UTC).Format(rotateLayout(30*time.Minute)))
+}
+
+// TestNewLogger_TimeRotation tests that a logger with LogRotateInterval writes to the dated file and that Validate rejects bad intervals.
+// (TestNewLogger_TimeRotation 测试设置 LogRotateInterval 的日志器写入带日期的文件，以及 Validate 拒绝无效的间隔。)
+func TestNewLogger_TimeRotation(t *testing.T) {
+	dir := t.TempDir()
+	opts := NewOptions()
+	opts.OutputPaths = []string{filepath.Join(dir, "app.log")}
+	opts.LogRotateInterval = RotateDaily
+	require.Empty(t, opts.Validate())
+
+	logger, err := NewLogger(opts)
+	require.NoError(t, err)
+	logger.Info("hello")
+	require.NoError(t, logger.Sync())
+
+	data, err := os.ReadFile(filepath.Join(dir, "app-"+time.Now().Format("2006-01-02")+".log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "hello")
+	assert.NoFileExists(t, filepath.Join(dir, "app.log"))
+
+	opts.LogRotateInterval = "7h"
+	assert.Len(t, opts.Validate(), 1)
+}
@@ -0,0 +1,146 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelRoute 是一条已解析的级别路由：匹配级别的条目写入 syncer。
+// (levelRoute is a parsed level route: entries whose level matches are written to syncer.)
+type levelRoute struct {
+	match  zapcore.LevelEnabler
+	syncer zapcore.WriteSyncer
+}
+
+// parseLevelRule 解析级别规则，格式为可选的比较运算符（>=、<=、>、<、=）加级别名，例如 ">=error"、"<=info"、"warn"。
+// 不带运算符时只匹配该级别。
+// (parseLevelRule parses a level rule: an optional comparison operator (>=, <=, >, <, =) followed by a level name,
+// e.g. ">=error", "<=info" or "warn". Without an operator only that level matches.)
+func parseLevelRule(rule string) (zapcore.LevelEnabler, error) {
+	rule = strings.TrimSpace(rule)
+	op := ""
+	for _, candidate := range []string{">=", "<=", "==", ">", "<", "="} {
+		if strings.HasPrefix(rule, candidate) {
+			op = candidate
+			break
+		}
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(strings.TrimPrefix(rule, op)))); err != nil {
+		return nil, fmt.Errorf("invalid level route '%s': %w", rule, err)
+	}
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		switch op {
+		case ">=":
+			return l >= level
+		case "<=":
+			return l <= level
+		case ">":
+			return l > level
+		case "<":
+			return l < level
+		default:
+			return l == level
+		}
+	}), nil
+}
+
+// stdStreamRoutes 是 SplitStdStreams 使用的级别路由。(stdStreamRoutes are the level routes used by SplitStdStreams.)
+var stdStreamRoutes = map[string][]string{
+	"<warn":  {"stdout"},
+	">=warn": {"stderr"},
+}
+
+// levelRoutes 返回生效的级别路由：设置了 SplitStdStreams 时为 stdStreamRoutes，否则为 LevelRoutes。
+// (levelRoutes returns the effective level routes: stdStreamRoutes when SplitStdStreams is set, LevelRoutes otherwise.)
+func (o *Options) levelRoutes() map[string][]string {
+	if o.SplitStdStreams {
+		return stdStreamRoutes
+	}
+	return o.LevelRoutes
+}
+
+// validateLevelRoutes 校验每条路由的规则有效且至少有一个输出路径。
+// (validateLevelRoutes checks that every route has a valid rule and at least one output path.)
+func validateLevelRoutes(routes map[string][]string) []error {
+	var errs []error
+	for rule, paths := range routes {
+		if _, err := parseLevelRule(rule); err != nil {
+			errs = append(errs, err)
+		}
+		if len(paths) == 0 {
+			errs = append(errs, fmt.Errorf("level route '%s' has no output paths", rule))
+		}
+	}
+	return errs
+}
+
+// getLevelRoutes 为每条级别路由创建写入器。多条路由中的同一路径只打开一次，避免同一文件被多个轮转写入器写入。
+// (getLevelRoutes creates the writers of every level route. A path used by several routes is opened once,
+// so a file is never written by several rotating writers.)
+func getLevelRoutes(opts *Options) (routes []levelRoute, async []*asyncWriter, err error) {
+	defer func() {
+		// 构建失败时停止已启动的异步写入器 (Stop the async writers already started when building fails)
+		if err != nil {
+			for _, w := range async {
+				w.Close()
+			}
+			async = nil
+		}
+	}()
+
+	// 按规则排序，使构建顺序稳定 (Sort the rules so the build order is stable)
+	levelRoutes := opts.levelRoutes()
+	rules := make([]string, 0, len(levelRoutes))
+	for rule := range levelRoutes {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	opened := make(map[string]zapcore.WriteSyncer)
+	for _, rule := range rules {
+		match, errRule := parseLevelRule(rule)
+		if errRule != nil {
+			return nil, nil, errRule
+		}
+		var writers []zapcore.WriteSyncer
+		for _, path := range levelRoutes[rule] {
+			ws, ok := opened[path]
+			if !ok {
+				var pathAsync []*asyncWriter
+				if ws, pathAsync, err = getWriteSyncerForPaths([]string{path}, opts); err != nil {
+					return nil, nil, err
+				}
+				async = append(async, pathAsync...)
+				opened[path] = ws
+			}
+			writers = append(writers, ws)
+		}
+		routes = append(routes, levelRoute{match: match, syncer: zapcore.NewMultiWriteSyncer(writers...)})
+	}
+	return routes, async, nil
+}
+
+// newRoutedCore 为每条路由创建一个经 wrap 包装（展开错误、脱敏）的 core，条目需同时满足日志级别和路由规则才会写入该路由。
+// (newRoutedCore creates one core per route wrapped by wrap, which expands errors and redacts; an entry is written to a route
+// when it passes both the log level and the route rule.)
+func newRoutedCore(encoder zapcore.Encoder, enabler zapcore.LevelEnabler, routes []levelRoute, wrap func(zapcore.Core) zapcore.Core) zapcore.Core {
+	cores := make([]zapcore.Core, 0, len(routes))
+	for _, route := range routes {
+		match := route.match
+		cores = append(cores, wrap(zapcore.NewCore(encoder.Clone(), route.syncer, zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return enabler.Enabled(l) && match.Enabled(l)
+		}))))
+	}
+	return zapcore.NewTee(cores...)
+}
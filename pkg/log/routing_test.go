@@ -0,0 +1,156 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for routing log entries to outputs by level.
+ */
+
+package log_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readLogLines returns the non-empty lines of a log file.
+// (readLogLines 返回日志文件中的非空行。)
+func readLogLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// TestLevelRoutes tests that entries are written to every route whose rule matches their level.
+// (TestLevelRoutes 测试条目被写入所有规则匹配其级别的路由。)
+func TestLevelRoutes(t *testing.T) {
+	dir := t.TempDir()
+	errFile := filepath.Join(dir, "app.err")
+	infoFile := filepath.Join(dir, "app.info")
+	allFile := filepath.Join(dir, "app.log")
+	warnFile := filepath.Join(dir, "app.warn")
+
+	opts := log.NewOptions()
+	opts.Level = "debug"
+	opts.DisableStacktrace = true
+	opts.LogRotateMaxSize = 0
+	opts.LevelRoutes = map[string][]string{
+		">=error": {errFile, allFile},
+		"<=info":  {infoFile},
+		">debug":  {allFile},
+		"warn":    {warnFile},
+	}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+
+	logger.Debug("debug entry")
+	logger.Info("info entry")
+	logger.Warn("warn entry")
+	logger.Error("error entry")
+	require.NoError(t, logger.Sync())
+
+	errLines := readLogLines(t, errFile)
+	require.Len(t, errLines, 1)
+	assert.Contains(t, errLines[0], "error entry")
+
+	infoLines := readLogLines(t, infoFile)
+	require.Len(t, infoLines, 2)
+	assert.Contains(t, infoLines[0], "debug entry")
+	assert.Contains(t, infoLines[1], "info entry")
+
+	warnLines := readLogLines(t, warnFile)
+	require.Len(t, warnLines, 1)
+	assert.Contains(t, warnLines[0], "warn entry")
+
+	// 同一文件被两条路由匹配时写入两次，但只打开一次 (A file matched by two routes gets the entry twice but is opened once)
+	allLines := readLogLines(t, allFile)
+	require.Len(t, allLines, 4)
+	assert.Contains(t, allLines[0], "info entry")
+	assert.Contains(t, allLines[3], "error entry")
+}
+
+// TestLevelRoutes_RespectsLevel tests that routes never receive entries below the logger level.
+// (TestLevelRoutes_RespectsLevel 测试路由不会收到低于记录器级别的条目。)
+func TestLevelRoutes_RespectsLevel(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.log")
+	opts := log.NewOptions()
+	opts.Level = "warn"
+	opts.LogRotateMaxSize = 0
+	opts.LevelRoutes = map[string][]string{"<=warn": {file}}
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+	require.NoError(t, logger.Sync())
+
+	lines := readLogLines(t, file)
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], "kept")
+}
+
+// TestLevelRoutes_Validate tests that invalid rules and routes without outputs are rejected.
+// (TestLevelRoutes_Validate 测试无效规则和没有输出的路由会被拒绝。)
+func TestLevelRoutes_Validate(t *testing.T) {
+	opts := log.NewOptions()
+	opts.LevelRoutes = map[string][]string{">=loud": {"stdout"}, "error": nil}
+	errs := opts.Validate()
+	require.Len(t, errs, 2)
+
+	_, err := log.NewLogger(opts)
+	assert.Error(t, err)
+}
+
+// TestSplitStdStreams tests that debug and info go to stdout while warn and above go to stderr.
+// (TestSplitStdStreams 测试 debug 和 info 写入 stdout，warn 及以上写入 stderr。)
+func TestSplitStdStreams(t *testing.T) {
+	dir := t.TempDir()
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	require.NoError(t, err)
+	stderr, err := os.Create(filepath.Join(dir, "stderr"))
+	require.NoError(t, err)
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	t.Cleanup(func() { os.Stdout, os.Stderr = origStdout, origStderr })
+
+	opts := log.NewOptions()
+	opts.Level = "debug"
+	opts.DisableStacktrace = true
+	opts.OutputPaths = []string{filepath.Join(dir, "ignored.log")}
+	opts.SplitStdStreams = true
+	logger, err := log.NewLogger(opts)
+	require.NoError(t, err)
+
+	logger.Debug("debug entry")
+	logger.Info("info entry")
+	logger.Warn("warn entry")
+	logger.Error("error entry")
+	_ = logger.Sync()
+
+	outLines := readLogLines(t, stdout.Name())
+	require.Len(t, outLines, 2)
+	assert.Contains(t, outLines[0], "debug entry")
+	assert.Contains(t, outLines[1], "info entry")
+
+	errLines := readLogLines(t, stderr.Name())
+	require.Len(t, errLines, 2)
+	assert.Contains(t, errLines[0], "warn entry")
+	assert.Contains(t, errLines[1], "error entry")
+
+	assert.NoFileExists(t, filepath.Join(dir, "ignored.log"), "SplitStdStreams replaces OutputPaths")
+
+	opts.LevelRoutes = map[string][]string{">=error": {"stderr"}}
+	assert.Len(t, opts.Validate(), 1, "SplitStdStreams cannot be combined with LevelRoutes")
+}
@@ -0,0 +1,131 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingRule 描述一个级别的采样：每个 Tick 内每条消息先记录 Initial 条，之后每 Thereafter 条记录一条。
+// Thereafter 为 0 时丢弃 Initial 之后的所有同类日志。
+// (SamplingRule describes sampling for one level: within each Tick the first Initial entries of a message are logged,
+// then every Thereafter-th one. A Thereafter of 0 drops every entry after the first Initial.)
+type SamplingRule struct {
+	// Initial 是每个 Tick 内每条消息无条件记录的条数，为 0 时不采样。
+	// (Initial is the number of entries per message logged unconditionally within each Tick; 0 disables sampling.)
+	Initial int `json:"initial" mapstructure:"initial"`
+
+	// Thereafter 表示超过 Initial 后每多少条记录一条。
+	// (Thereafter means every Thereafter-th entry is logged once Initial is exceeded.)
+	Thereafter int `json:"thereafter" mapstructure:"thereafter"`
+}
+
+// SamplingOptions 配置与 zap 相同语义的日志采样，同一级别和消息的条目共享计数。
+// 顶层规则适用于所有级别，Levels 可按级别覆盖（Initial 为 0 表示该级别不采样）。
+// (SamplingOptions configures log sampling with zap semantics; entries with the same level and message share a counter.
+// The top-level rule applies to every level and Levels overrides it per level (an Initial of 0 disables sampling for that level).)
+type SamplingOptions struct {
+	SamplingRule `mapstructure:",squash"`
+
+	// Tick 是采样计数的重置周期，为 0 时使用 1 秒。
+	// (Tick is the period after which sampling counters reset; 1 second is used when 0.)
+	Tick time.Duration `json:"tick" mapstructure:"tick"`
+
+	// Levels 按级别名称（如 "info"）覆盖顶层规则。
+	// (Levels overrides the top-level rule by level name, e.g. "info".)
+	Levels map[string]SamplingRule `json:"levels" mapstructure:"levels"`
+}
+
+// defaultSamplingTick 是未设置 Tick 时的重置周期。(defaultSamplingTick is the reset period when Tick is unset.)
+const defaultSamplingTick = time.Second
+
+// Validate 验证采样选项。(Validate validates the sampling options.)
+func (s SamplingOptions) Validate() []error {
+	var errs []error
+	if s.Initial < 0 || s.Thereafter < 0 {
+		errs = append(errs, fmt.Errorf("invalid sampling initial %d / thereafter %d, must not be negative", s.Initial, s.Thereafter))
+	}
+	if s.Tick < 0 {
+		errs = append(errs, fmt.Errorf("invalid sampling tick '%s', must not be negative", s.Tick))
+	}
+	for name, rule := range s.Levels {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(name)); err != nil {
+			errs = append(errs, fmt.Errorf("invalid sampling level '%s': %w", name, err))
+		}
+		if rule.Initial < 0 || rule.Thereafter < 0 {
+			errs = append(errs, fmt.Errorf("invalid sampling initial %d / thereafter %d for level '%s', must not be negative", rule.Initial, rule.Thereafter, name))
+		}
+	}
+	return errs
+}
+
+// rules 返回每个采样级别的生效规则，未启用采样的级别不在结果中。
+// (rules returns the effective rule of every sampled level; levels without sampling are absent.)
+func (s SamplingOptions) rules() map[zapcore.Level]SamplingRule {
+	out := make(map[zapcore.Level]SamplingRule)
+	for level := zapcore.DebugLevel; level <= zapcore.FatalLevel; level++ {
+		if s.Initial > 0 {
+			out[level] = s.SamplingRule
+		}
+	}
+	for name, rule := range s.Levels {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(name)); err != nil {
+			continue
+		}
+		if rule.Initial > 0 {
+			out[level] = rule
+		} else {
+			delete(out, level)
+		}
+	}
+	return out
+}
+
+// newSamplingCore 按选项为各级别包装采样器；未启用采样时原样返回 core。
+// (newSamplingCore wraps core with a sampler per level as configured; core is returned unchanged when sampling is off.)
+func newSamplingCore(core zapcore.Core, opts SamplingOptions) zapcore.Core {
+	rules := opts.rules()
+	if len(rules) == 0 {
+		return core
+	}
+	tick := opts.Tick
+	if tick <= 0 {
+		tick = defaultSamplingTick
+	}
+	sampled := make(map[zapcore.Level]zapcore.Core, len(rules))
+	for level, rule := range rules {
+		sampled[level] = zapcore.NewSamplerWithOptions(core, tick, rule.Initial, rule.Thereafter)
+	}
+	return &levelSampledCore{Core: core, sampled: sampled}
+}
+
+// levelSampledCore 将条目分派给其级别的采样器，没有采样器的级别直接写入底层 core。
+// (levelSampledCore dispatches entries to the sampler of their level; levels without one go straight to the underlying core.)
+type levelSampledCore struct {
+	zapcore.Core
+	sampled map[zapcore.Level]zapcore.Core
+}
+
+func (c *levelSampledCore) With(fields []zapcore.Field) zapcore.Core {
+	sampled := make(map[zapcore.Level]zapcore.Core, len(c.sampled))
+	for level, core := range c.sampled {
+		sampled[level] = core.With(fields)
+	}
+	return &levelSampledCore{Core: c.Core.With(fields), sampled: sampled}
+}
+
+func (c *levelSampledCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if core, ok := c.sampled[ent.Level]; ok {
+		return core.Check(ent, ce)
+	}
+	return c.Core.Check(ent, ce)
+}
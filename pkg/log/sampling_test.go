@@ -0,0 +1,112 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for log sampling.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSampling tests the top-level rule and per-level overrides.
+// (TestSampling 测试顶层规则和按级别覆盖。)
+func TestSampling(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.Sampling = log.SamplingOptions{
+		SamplingRule: log.SamplingRule{Initial: 2, Thereafter: 5},
+		Tick:         time.Hour,
+		Levels: map[string]log.SamplingRule{
+			"warn":  {Initial: 1},
+			"error": {Initial: 0},
+		},
+	}
+	require.Empty(t, opts.Validate())
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+	for i := 0; i < 12; i++ {
+		logger.Info("hot path")
+		logger.Warn("noisy warning")
+		logger.Error("failure")
+	}
+	logger.WithValues("k", "v").Info("hot path")
+
+	out := buf.String()
+	// 第 1、2、7、12 条，以及 With 后共享计数器的第 13 条不记录
+	// (Entries 1, 2, 7 and 12; the 13th, from a derived logger sharing the counter, is dropped)
+	assert.Equal(t, 4, strings.Count(out, "hot path"))
+	assert.Equal(t, 1, strings.Count(out, "noisy warning"))
+	assert.Equal(t, 12, strings.Count(out, "failure"))
+}
+
+// TestSamplingValidate tests rejecting negative values and unknown levels.
+// (TestSamplingValidate 测试拒绝负值和未知级别。)
+func TestSamplingValidate(t *testing.T) {
+	assert.Empty(t, log.SamplingOptions{}.Validate())
+	assert.Len(t, log.SamplingOptions{SamplingRule: log.SamplingRule{Initial: -1}, Tick: -time.Second}.Validate(), 2)
+	assert.Len(t, log.SamplingOptions{Levels: map[string]log.SamplingRule{"loud": {Initial: 1}, "info": {Thereafter: -1}}}.Validate(), 2)
+}
+
+// sectionManager 是只记录节回调的 config.Manager。(sectionManager is a config.Manager that only records section callbacks.)
+type sectionManager struct {
+	config.Values
+	callbacks map[string]config.SectionChangeCallback
+}
+
+func (m *sectionManager) GetViperInstance() *viper.Viper                 { return nil }
+func (m *sectionManager) RegisterCallback(func(*viper.Viper, any) error) {}
+func (m *sectionManager) RegisterSectionChangeCallback(key string, cb config.SectionChangeCallback) {
+	m.callbacks[key] = cb
+}
+func (m *sectionManager) LastChangeSet() config.ChangeSet { return nil }
+func (m *sectionManager) Set(string, any) error           { return nil }
+func (m *sectionManager) Save() error                     { return nil }
+func (m *sectionManager) Snapshot() (any, uint64)         { return nil, 0 }
+func (m *sectionManager) Version() uint64                 { return 0 }
+
+// TestSamplingHotReload tests that sampling settings in the log section are applied on reload.
+// (TestSamplingHotReload 测试日志配置节中的采样设置在重载时生效。)
+func TestSamplingHotReload(t *testing.T) {
+	defer log.Init(log.NewOptions())
+
+	cm := &sectionManager{callbacks: make(map[string]config.SectionChangeCallback)}
+	log.RegisterConfigHotReload(cm)
+	require.Contains(t, cm.callbacks, "log")
+
+	v := viper.New()
+	v.Set("log", map[string]any{
+		"level":  "info",
+		"format": "json",
+		"sampling": map[string]any{
+			"initial":    3,
+			"thereafter": 0,
+			"tick":       "1m",
+			"levels":     map[string]any{"warn": map[string]any{"initial": 1}},
+		},
+	})
+	var applied *log.Options
+	id := log.RegisterCallback(func(opts *log.Options) error {
+		applied = opts
+		return nil
+	})
+	defer log.UnregisterCallback(id)
+
+	require.NoError(t, cm.callbacks["log"](v))
+	require.NotNil(t, applied)
+	assert.Equal(t, 3, applied.Sampling.Initial)
+	assert.Equal(t, time.Minute, applied.Sampling.Tick)
+	assert.Equal(t, log.SamplingRule{Initial: 1}, applied.Sampling.Levels["warn"])
+}
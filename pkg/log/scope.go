@@ -0,0 +1,88 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// scopeContextKey 是在 context 中存储作用域字段的键。(scopeContextKey is the key for the scoped fields in context.)
+type scopeContextKey struct{}
+
+// scopeField 是 WithScope 添加的一个字段。(scopeField is one field added by WithScope.)
+type scopeField struct {
+	name  string
+	value any
+}
+
+// WithScope 返回携带额外日志字段的 context 副本，调用链更深处的所有 Ctx* 调用都会自动带上这些字段，
+// 类似 SLF4J 的 MDC。适用于只持有 context、无法使用 Logger.WithValues 的代码。嵌套调用会累积字段，
+// 同名字段以内层为准；外层 context 不受影响。非字符串键通过 fmt.Sprint 转换，末尾没有值的键被忽略。
+// (WithScope returns a copy of ctx carrying additional log fields that every Ctx* call deeper in the call chain includes
+// automatically, like SLF4J's MDC. It suits code that only has a context and cannot use Logger.WithValues. Nested calls
+// accumulate fields, with the inner value winning for the same name; the outer context is unaffected. Non-string keys are
+// converted with fmt.Sprint and a trailing key without a value is ignored.)
+func WithScope(ctx context.Context, keysAndValues ...any) context.Context {
+	parent := scopeFromContext(ctx)
+	fields := make([]scopeField, 0, len(parent)+len(keysAndValues)/2)
+	fields = append(fields, parent...)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		name, ok := keysAndValues[i].(string)
+		if !ok {
+			name = fmt.Sprint(keysAndValues[i])
+		}
+		field := scopeField{name: name, value: keysAndValues[i+1]}
+		replaced := false
+		for j := range fields {
+			if fields[j].name == name {
+				fields[j], replaced = field, true
+				break
+			}
+		}
+		if !replaced {
+			fields = append(fields, field)
+		}
+	}
+	return context.WithValue(ctx, scopeContextKey{}, fields)
+}
+
+// ScopeFields 返回 ctx 中通过 WithScope 添加的字段的副本，没有时返回 nil。
+// (ScopeFields returns a copy of the fields added to ctx with WithScope, or nil when there are none.)
+func ScopeFields(ctx context.Context) map[string]any {
+	fields := scopeFromContext(ctx)
+	if len(fields) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(fields))
+	for _, f := range fields {
+		out[f.name] = f.value
+	}
+	return out
+}
+
+// scopeFromContext 返回 ctx 中的作用域字段。(scopeFromContext returns the scoped fields in ctx.)
+func scopeFromContext(ctx context.Context) []scopeField {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(scopeContextKey{}).([]scopeField)
+	return fields
+}
+
+// appendScopeFields 按添加顺序追加 ctx 中的作用域字段，跳过已存在的字段名。
+// (appendScopeFields appends the scoped fields in ctx in the order they were added, skipping field names already present.)
+func appendScopeFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	for _, f := range scopeFromContext(ctx) {
+		if !hasField(fields, f.name) {
+			fields = append(fields, zap.Any(f.name, f.value))
+		}
+	}
+	return fields
+}
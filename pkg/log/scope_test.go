@@ -0,0 +1,48 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for scoped context fields.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWithScope tests that scoped fields reach every Ctx* call deeper in the chain and that nested scopes override outer ones.
+// (TestWithScope 测试作用域字段出现在调用链更深处的所有 Ctx* 调用中，且内层作用域覆盖外层。)
+func TestWithScope(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableCaller = true
+	opts.ContextKeys = []any{log.RequestIDKey}
+
+	var buf bytes.Buffer
+	logger := log.NewLoggerWithWriter(opts, &buf)
+
+	outer := log.WithScope(log.ContextWithRequestID(context.Background(), "req-1"), "tenant", "acme", "job", "import")
+	inner := log.WithScope(outer, "job", "export", "attempt", 2, "request_id", "shadowed", "dangling")
+
+	logger.Ctxw(outer, "outer")
+	logger.CtxInfof(inner, "inner %d", 1)
+	logger.Ctxw(context.Background(), "no scope")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.Contains(t, string(lines[0]), `"request_id":"req-1","tenant":"acme","job":"import"`)
+	assert.Contains(t, string(lines[1]), `"request_id":"req-1","tenant":"acme","job":"export","attempt":2`)
+	assert.Equal(t, 1, bytes.Count(lines[1], []byte(`"request_id"`)), "fields already extracted are not repeated")
+	assert.NotContains(t, string(lines[1]), "dangling")
+	assert.NotContains(t, string(lines[2]), "tenant")
+
+	assert.Equal(t, map[string]any{"tenant": "acme", "job": "import"}, log.ScopeFields(outer), "the outer context is unaffected")
+	assert.Equal(t, map[string]any{"tenant": "acme", "job": "export", "attempt": 2, "request_id": "shadowed"}, log.ScopeFields(inner))
+	assert.Nil(t, log.ScopeFields(context.Background()))
+}
@@ -0,0 +1,75 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import "os"
+
+// 服务标识字段名，与 OpenTelemetry 资源语义约定保持一致，
+// 保证日志、指标和追踪使用完全相同的标识属性。
+// (Service identity field keys, matching the OpenTelemetry resource semantic conventions
+// so that logs, metrics and traces share identical identity attributes.)
+const (
+	// ServiceNameKey 是服务名字段。(ServiceNameKey is the service name field.)
+	ServiceNameKey = "service.name"
+	// ServiceVersionKey 是服务版本字段。(ServiceVersionKey is the service version field.)
+	ServiceVersionKey = "service.version"
+	// DeploymentEnvironmentKey 是部署环境字段。(DeploymentEnvironmentKey is the deployment environment field.)
+	DeploymentEnvironmentKey = "deployment.environment.name"
+	// HostNameKey 是主机名字段。(HostNameKey is the host name field.)
+	HostNameKey = "host.name"
+)
+
+// ServiceOptions 描述服务标识，作为标准字段写入每条日志，并可用于构建 OpenTelemetry 资源。
+// (ServiceOptions describes the service identity, written to every log entry as standard fields
+// and usable for building an OpenTelemetry resource.)
+type ServiceOptions struct {
+	// Name 是服务名，为空时不输出任何服务标识字段。
+	// (Name is the service name; no identity fields are emitted when it is empty.)
+	Name string `json:"name" mapstructure:"name"`
+
+	// Version 是服务版本。(Version is the service version.)
+	Version string `json:"version" mapstructure:"version"`
+
+	// Environment 是部署环境，例如 "production"。
+	// (Environment is the deployment environment, e.g. "production".)
+	Environment string `json:"environment" mapstructure:"environment"`
+
+	// Host 是主机名，为空时使用 os.Hostname()。
+	// (Host is the host name; os.Hostname() is used when empty.)
+	Host string `json:"host" mapstructure:"host"`
+}
+
+// Resolved 返回填充了默认主机名的副本。
+// (Resolved returns a copy with the default host name filled in.)
+func (s ServiceOptions) Resolved() ServiceOptions {
+	if s.Host == "" {
+		if host, err := os.Hostname(); err == nil {
+			s.Host = host
+		}
+	}
+	return s
+}
+
+// Fields 以键值对形式返回服务标识字段，省略空值；Name 为空时返回 nil。
+// (Fields returns the service identity fields as key-value pairs, omitting empty values; it returns nil when Name is empty.)
+func (s ServiceOptions) Fields() []any {
+	if s.Name == "" {
+		return nil
+	}
+	s = s.Resolved()
+	fields := []any{ServiceNameKey, s.Name}
+	for _, kv := range [...]struct{ key, value string }{
+		{ServiceVersionKey, s.Version},
+		{DeploymentEnvironmentKey, s.Environment},
+		{HostNameKey, s.Host},
+	} {
+		if kv.value != "" {
+			fields = append(fields, kv.key, kv.value)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,86 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ansiPattern 匹配彩色文本格式中的 ANSI 颜色代码。(ansiPattern matches the ANSI color codes of the colored text format.)
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// sinkEntry 是系统日志输出（journald、Windows 事件日志）和 GELF 输出从编码后的条目中解析出的内容。
+// (sinkEntry is what the system log sinks, journald and the Windows Event Log, and the GELF sink parse from an encoded entry.)
+type sinkEntry struct {
+	level   zapcore.Level
+	message string
+	// time 是 JSON 条目中 ISO8601 或 RFC 3339 格式的时间，无法解析时为零值。
+	// (time is the ISO8601 or RFC 3339 time of a JSON entry; zero when it cannot be parsed.)
+	time time.Time
+	// fields 是 JSON 条目中除级别、消息和时间外的字段，其他格式为 nil。
+	// (fields are the fields of a JSON entry other than the level, message and time; nil for other formats.)
+	fields map[string]any
+}
+
+// parseSinkEntry 解析一条编码后的日志条目。JSON 条目取出级别、消息和其余字段；其他格式的条目整行作为消息，
+// 级别取自前几列中的级别名。无法识别级别时为 info。
+// (parseSinkEntry parses an encoded log entry. A JSON entry yields its level, message and remaining fields; for other
+// formats the whole line is the message and the level comes from a level name in the first columns. The level is info
+// when none is recognized.)
+func parseSinkEntry(p []byte) sinkEntry {
+	line := bytes.TrimSpace(p)
+	entry := sinkEntry{level: zapcore.InfoLevel, message: string(line)}
+
+	if len(line) > 0 && line[0] == '{' {
+		var fields map[string]any
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.UseNumber()
+		if dec.Decode(&fields) == nil {
+			if level, ok := fields["L"].(string); ok {
+				_ = entry.level.UnmarshalText([]byte(level))
+			}
+			if msg, ok := fields["M"].(string); ok && msg != "" {
+				entry.message = msg
+			}
+			if ts, ok := fields["ts"].(string); ok {
+				entry.time = parseSinkTime(ts)
+			}
+			delete(fields, "L")
+			delete(fields, "M")
+			delete(fields, "ts")
+			entry.fields = fields
+			return entry
+		}
+	}
+
+	plain := ansiPattern.ReplaceAll(line, nil)
+	for i, column := range bytes.SplitN(plain, []byte("\t"), 4) {
+		var level zapcore.Level
+		if i < 3 && level.UnmarshalText(bytes.TrimSpace(column)) == nil {
+			entry.level = level
+			break
+		}
+	}
+	entry.message = string(plain)
+	return entry
+}
+
+// parseSinkTime 解析默认的 ISO8601 时间或 RFC 3339 时间，其他格式返回零值。
+// (parseSinkTime parses the default ISO8601 time or an RFC 3339 time, returning the zero time for other formats.)
+func parseSinkTime(ts string) time.Time {
+	for _, layout := range []string{"2006-01-02T15:04:05.000Z0700", time.RFC3339Nano} {
+		if t, err := time.Parse(layout, ts); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,111 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the log/slog bridge.
+ */
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSlogHandler tests that slog records go through the SDK logger with their attributes, groups, caller and level.
+// (TestSlogHandler 测试 slog 记录连同属性、分组、调用者和级别经 SDK 记录器写出。)
+func TestSlogHandler(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.ContextKeys = []any{log.RequestIDKey}
+	buf := &syncBuffer{}
+	sdkLogger := log.NewLoggerWithWriter(opts, buf)
+
+	sl := slog.New(log.NewSlogHandler(sdkLogger)).With("component", "billing")
+	ctx := context.WithValue(context.Background(), log.RequestIDKey, "req-9")
+	sl.InfoContext(ctx, "charged", "amount", 42, slog.Group("card", "brand", "visa"), "err", errors.New("retry"))
+	line := callerHere(-1)
+	sl.WithGroup("db").Warn("slow", "took", 2*time.Second)
+	sl.Debug("hidden")
+
+	entries := buf.entries(t)
+	require.Len(t, entries, 2, "debug is below the logger level")
+	assert.Equal(t, "charged", entries[0]["M"])
+	assert.Equal(t, "INFO", entries[0]["L"])
+	assert.Equal(t, line, entries[0]["C"], "the caller comes from the slog record")
+	assert.Equal(t, "billing", entries[0]["component"])
+	assert.Equal(t, float64(42), entries[0]["amount"])
+	assert.Equal(t, map[string]any{"brand": "visa"}, entries[0]["card"])
+	assert.Equal(t, "retry", entries[0]["err"])
+	assert.Equal(t, "req-9", entries[0]["request_id"])
+	assert.Equal(t, "WARN", entries[1]["L"])
+	assert.Equal(t, map[string]any{"took": float64(2)}, entries[1]["db"])
+
+	// 运行时调整的级别同样生效 (Levels adjusted at runtime apply too)
+	original := log.GetGlobalLogger()
+	defer log.SetGlobalLogger(original)
+	log.SetGlobalLogger(sdkLogger)
+	global := slog.New(log.NewSlogHandler(nil))
+	require.NoError(t, log.SetLevel("debug"))
+	assert.True(t, global.Enabled(context.Background(), slog.LevelDebug))
+	global.Debug("visible")
+	require.NoError(t, log.SetLevel("error"))
+	assert.False(t, global.Enabled(context.Background(), slog.LevelWarn))
+	entries = buf.entries(t)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "visible", entries[2]["M"])
+}
+
+// TestSlogHandler_KeyValue tests that slog records on a key=value logger are written in logfmt like the other key=value paths.
+// (TestSlogHandler_KeyValue 测试 key=value 格式记录器上的 slog 记录与其他 key=value 路径一样以 logfmt 格式写出。)
+func TestSlogHandler_KeyValue(t *testing.T) {
+	opts := log.NewOptions()
+	opts.Format = log.FormatKeyValue
+	opts.DisableStacktrace = true
+	var buf bytes.Buffer
+	sdkLogger := log.NewLoggerWithWriter(opts, &buf)
+
+	sl := slog.New(log.NewSlogHandler(sdkLogger)).With("component", "billing")
+	sl.Info("charged", "k", "v v", slog.Group("card", "brand", "visa"))
+	sl.WithGroup("db").Warn("slow", "took", 2*time.Second)
+	sdkLogger.Infow("charged", "k", "v v")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `charged component=billing k="v v" card.brand=visa`)
+	assert.NotContains(t, lines[0], "{", "fields are not written as JSON")
+	assert.Contains(t, lines[1], "slow component=billing db.took=2s")
+	assert.Contains(t, lines[2], `charged k="v v"`, "the same logfmt as Infow")
+}
+
+// TestFromSlog tests the SDK Logger writing to a slog.Logger, and unwrapping loggers created with NewSlogHandler.
+// (TestFromSlog 测试写入 slog.Logger 的 SDK Logger，以及解开由 NewSlogHandler 创建的记录器。)
+func TestFromSlog(t *testing.T) {
+	var out bytes.Buffer
+	sl := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	logger := log.FromSlog(sl).WithName("payments").WithValues("tenant", "acme")
+	logger.Infow("settled", "orders", 3)
+	logger.Debug("hidden")
+	assert.Contains(t, out.String(), `level=INFO msg=settled logger=payments tenant=acme orders=3`)
+	assert.NotContains(t, out.String(), "hidden")
+
+	buf := &syncBuffer{}
+	opts := log.NewOptions()
+	sdkLogger := log.NewLoggerWithWriter(opts, buf)
+	unwrapped := log.FromSlog(slog.New(log.NewSlogHandler(sdkLogger)).With("k", "v"))
+	unwrapped.Info("direct")
+	entries := buf.entries(t)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "v", entries[0]["k"])
+	assert.Equal(t, callerHere(-4), entries[0]["C"])
+}
@@ -0,0 +1,241 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package log
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// StatsLoggerName 是周期性日志量报告条目使用的记录器名称，可在 ModuleLevels 中单独设置其级别。
+// (StatsLoggerName is the logger name of the periodic log volume report entries; its level can be set in ModuleLevels.)
+const StatsLoggerName = "log.stats"
+
+// numLevels 是被计数的级别数，从 debug 到 fatal。(numLevels is the number of counted levels, debug through fatal.)
+const numLevels = int(zapcore.FatalLevel-zapcore.DebugLevel) + 1
+
+// VolumeStats 是一段时间内写出的日志条目数。(VolumeStats holds the number of log entries written over a period.)
+type VolumeStats struct {
+	// Entries 是条目总数。(Entries is the total number of entries.)
+	Entries uint64 `json:"entries"`
+	// Levels 按级别名称（"debug"、"info"、"warn" 等）计数，不含计数为 0 的级别。
+	// (Levels counts entries by level name, such as "debug", "info" or "warn", leaving out levels with no entries.)
+	Levels map[string]uint64 `json:"levels"`
+	// Loggers 按记录器名称（WithName 设置，例如 "orders.db"）计数，未命名记录器的条目计在 "" 下。
+	// (Loggers counts entries by logger name as set with WithName, such as "orders.db"; entries of unnamed loggers are
+	// counted under "".)
+	Loggers map[string]uint64 `json:"loggers"`
+}
+
+// LogStats 是 Stats 返回的日志量统计。(LogStats holds the log volume statistics returned by Stats.)
+type LogStats struct {
+	// Since 是开始计数的时间，即进程启动时。(Since is when counting started, at process start.)
+	Since time.Time `json:"since"`
+	// Total 是自 Since 以来的日志量。(Total is the volume since Since.)
+	Total VolumeStats `json:"total"`
+	// LastCall 是上一次调用 Stats 的时间，首次调用时等于 Since。
+	// (LastCall is when Stats was previously called; it equals Since on the first call.)
+	LastCall time.Time `json:"last_call"`
+	// SinceLastCall 是自 LastCall 以来的日志量。(SinceLastCall is the volume since LastCall.)
+	SinceLastCall VolumeStats `json:"since_last_call"`
+}
+
+// Stats 返回本包创建的所有记录器写出的日志量，按级别和记录器名称统计，用于容量规划时找出产生日志最多的组件。
+// 只计入实际写出的条目：低于级别、被采样或去重丢弃的条目不计入，写入多个级别路由的条目只计一次。
+// 计数在重新配置全局记录器后继续累计。可并发调用。
+// (Stats returns the volume of log entries written by every logger created by this package, by level and by logger name,
+// so capacity planning can find the components generating the most logs. Only entries actually written are counted: entries
+// below the level or dropped by sampling or dedup are not, and an entry written to several level routes counts once.
+// Counts keep accumulating across reconfigurations of the global logger. It is safe for concurrent use.)
+func Stats() LogStats {
+	current := volume.snapshot()
+	volume.mu.Lock()
+	defer volume.mu.Unlock()
+	stats := LogStats{
+		Since:         volume.since,
+		Total:         current.stats(),
+		LastCall:      volume.lastCall,
+		SinceLastCall: current.sub(volume.last).stats(),
+	}
+	volume.last, volume.lastCall = current, time.Now()
+	return stats
+}
+
+// volume 是进程范围的日志量计数。(volume holds the process-wide log volume counts.)
+var volume = newVolumeCounter()
+
+// volumeCounter 按级别和记录器名称原子地计数。(volumeCounter counts entries by level and logger name atomically.)
+type volumeCounter struct {
+	since   time.Time
+	levels  [numLevels]atomic.Uint64
+	loggers sync.Map // 记录器名称到 *atomic.Uint64 (logger name to *atomic.Uint64)
+
+	mu       sync.Mutex // 保护 last 和 lastCall (guards last and lastCall)
+	last     volumeSnapshot
+	lastCall time.Time
+}
+
+func newVolumeCounter() *volumeCounter {
+	now := time.Now()
+	return &volumeCounter{since: now, lastCall: now}
+}
+
+// record 计入一条写出的条目。(record counts one written entry.)
+func (c *volumeCounter) record(ent zapcore.Entry) error {
+	if i := int(ent.Level - zapcore.DebugLevel); i >= 0 && i < numLevels {
+		c.levels[i].Add(1)
+	}
+	counter, ok := c.loggers.Load(ent.LoggerName)
+	if !ok {
+		counter, _ = c.loggers.LoadOrStore(ent.LoggerName, new(atomic.Uint64))
+	}
+	counter.(*atomic.Uint64).Add(1)
+	return nil
+}
+
+// volumeRecorder 是只计数的核心，加入已通过检查的条目后在写出时计数。
+// (volumeRecorder is a core that only counts; added to a checked entry, it counts the entry when it is written.)
+var volumeRecorder = zapcore.RegisterHooks(zapcore.NewNopCore(), volume.record)
+
+// countingCore 计入下游核心写出的条目，包括直接调用 Write 写出的条目。
+// (countingCore counts the entries written by the downstream core, including those written by calling Write directly.)
+type countingCore struct {
+	zapcore.Core
+}
+
+func (c *countingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &countingCore{Core: c.Core.With(fields)}
+}
+
+func (c *countingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if downstream := c.Core.Check(ent, ce); downstream != nil {
+		return downstream.AddCore(ent, volumeRecorder)
+	}
+	return ce
+}
+
+func (c *countingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	_ = volume.record(ent)
+	return c.Core.Write(ent, fields)
+}
+
+// volumeSnapshot 是某一时刻的累计计数。(volumeSnapshot holds the cumulative counts at one point in time.)
+type volumeSnapshot struct {
+	levels  [numLevels]uint64
+	loggers map[string]uint64
+}
+
+// snapshot 读取当前的累计计数。(snapshot reads the current cumulative counts.)
+func (c *volumeCounter) snapshot() volumeSnapshot {
+	s := volumeSnapshot{loggers: make(map[string]uint64)}
+	for i := range c.levels {
+		s.levels[i] = c.levels[i].Load()
+	}
+	c.loggers.Range(func(name, counter any) bool {
+		s.loggers[name.(string)] = counter.(*atomic.Uint64).Load()
+		return true
+	})
+	return s
+}
+
+// sub 返回 s 相对于更早的快照 previous 的增量。(sub returns the increase of s over the earlier snapshot previous.)
+func (s volumeSnapshot) sub(previous volumeSnapshot) volumeSnapshot {
+	d := volumeSnapshot{loggers: maps.Clone(s.loggers)}
+	for i := range s.levels {
+		d.levels[i] = s.levels[i] - previous.levels[i]
+	}
+	for name, n := range previous.loggers {
+		d.loggers[name] -= n
+	}
+	return d
+}
+
+// stats 将快照转换为 VolumeStats，省略计数为 0 的项。(stats converts the snapshot into VolumeStats, leaving out zero counts.)
+func (s volumeSnapshot) stats() VolumeStats {
+	v := VolumeStats{Levels: make(map[string]uint64), Loggers: make(map[string]uint64)}
+	for i, n := range s.levels {
+		if n > 0 {
+			v.Levels[(zapcore.DebugLevel + zapcore.Level(i)).String()] = n
+			v.Entries += n
+		}
+	}
+	for name, n := range s.loggers {
+		if n > 0 {
+			v.Loggers[name] = n
+		}
+	}
+	return v
+}
+
+// StatsReportOptions 配置全局记录器周期性写出的日志量报告条目。
+// (StatsReportOptions configures the log volume report entries the global logger writes periodically.)
+type StatsReportOptions struct {
+	// Interval 是报告间隔，为 0 时不报告。每个间隔结束时以 info 级别、记录器名称 "log.stats" 写出一条 "Log volume" 条目，
+	// 包含该间隔内的 entries、levels 和 loggers 字段。
+	// (Interval is the report period; 0 disables reporting. At the end of every period an info entry "Log volume" is written
+	// with the logger name "log.stats" and the entries, levels and loggers fields of that period.)
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+}
+
+// Validate 验证日志量报告选项。(Validate validates the log volume report options.)
+func (s StatsReportOptions) Validate() []error {
+	if s.Interval < 0 {
+		return []error{fmt.Errorf("invalid stats report interval '%s', must not be negative", s.Interval)}
+	}
+	return nil
+}
+
+// statsReport 是当前全局记录器的报告协程。(statsReport is the report goroutine of the current global logger.)
+var statsReport struct {
+	mu   sync.Mutex
+	stop chan struct{}
+}
+
+// restartStatsReport 停止之前的报告协程，并按新的全局记录器 l 的选项启动新的协程。
+// (restartStatsReport stops the previous report goroutine and starts a new one with the options of l, the new global logger.)
+func restartStatsReport(l *logger) {
+	statsReport.mu.Lock()
+	defer statsReport.mu.Unlock()
+	if statsReport.stop != nil {
+		close(statsReport.stop)
+		statsReport.stop = nil
+	}
+	if l == nil || l.opts == nil || l.opts.StatsReport.Interval <= 0 {
+		return
+	}
+
+	interval, stop := l.opts.StatsReport.Interval, make(chan struct{})
+	statsReport.stop = stop
+	reporter := l.zapLogger.Named(StatsLoggerName).WithOptions(zap.WithCaller(false))
+	previous := volume.snapshot()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				current := volume.snapshot()
+				period := current.sub(previous).stats()
+				previous = current
+				reporter.Info("Log volume",
+					zap.Duration("interval", interval),
+					zap.Uint64("entries", period.Entries),
+					zap.Any("levels", period.Levels),
+					zap.Any("loggers", period.Loggers),
+				)
+			}
+		}
+	}()
+}
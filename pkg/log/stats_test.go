@@ -0,0 +1,96 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the log volume statistics.
+ */
+
+package log_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStats tests that written entries are counted by level and logger name, in total and since the last call.
+// (TestStats 测试写出的条目按级别和记录器名称计数，包括总数和自上次调用以来的数量。)
+func TestStats(t *testing.T) {
+	opts := log.NewOptions()
+	opts.DisableStacktrace = true
+	opts.Dedup = log.DedupOptions{Window: time.Hour}
+	logger := log.NewLoggerWithWriter(opts, io.Discard)
+	orders := logger.WithName("statstest").WithName("orders")
+
+	before := log.Stats()
+	for i := 0; i < 3; i++ {
+		orders.Infof("order %d created", i)
+	}
+	orders.Warn("slow query")
+	orders.Debug("below the level")
+	for i := 0; i < 5; i++ {
+		logger.WithName("statstest-dedup").Error("connection refused")
+	}
+
+	stats := log.Stats()
+	assert.Equal(t, before.Since, stats.Since)
+	assert.False(t, stats.LastCall.Before(before.LastCall))
+	assert.Equal(t, uint64(4), stats.SinceLastCall.Loggers["statstest.orders"])
+	assert.Equal(t, uint64(1), stats.SinceLastCall.Loggers["statstest-dedup"], "entries suppressed by dedup are not counted")
+	assert.GreaterOrEqual(t, stats.SinceLastCall.Levels["info"], uint64(3))
+	assert.GreaterOrEqual(t, stats.SinceLastCall.Levels["warn"], uint64(1))
+	assert.GreaterOrEqual(t, stats.SinceLastCall.Levels["error"], uint64(1))
+	assert.NotContains(t, stats.SinceLastCall.Levels, "debug")
+	assert.GreaterOrEqual(t, stats.SinceLastCall.Entries, uint64(5))
+	assert.Equal(t, before.Total.Loggers["statstest.orders"]+4, stats.Total.Loggers["statstest.orders"])
+	assert.GreaterOrEqual(t, stats.Total.Entries, before.Total.Entries+5)
+
+	orders.Info("once more")
+	again := log.Stats()
+	assert.Equal(t, stats.Total.Loggers["statstest.orders"]+1, again.Total.Loggers["statstest.orders"])
+	assert.Equal(t, uint64(1), again.SinceLastCall.Loggers["statstest.orders"])
+	assert.NotContains(t, again.SinceLastCall.Loggers, "statstest-dedup")
+}
+
+// TestStatsReport tests that the global logger periodically writes the volume of each interval.
+// (TestStatsReport 测试全局记录器周期性写出每个间隔的日志量。)
+func TestStatsReport(t *testing.T) {
+	original := log.GetGlobalLogger()
+	t.Cleanup(func() { log.SetGlobalLogger(original) })
+
+	opts := log.NewOptions()
+	opts.StatsReport = log.StatsReportOptions{Interval: 50 * time.Millisecond}
+	require.Empty(t, opts.Validate())
+	buf := &syncBuffer{}
+	log.SetGlobalLogger(log.NewLoggerWithWriter(opts, buf))
+	log.Std().WithName("statstest-report").Infow("payment captured")
+
+	var report map[string]any
+	require.Eventually(t, func() bool {
+		for _, entry := range buf.entries(t) {
+			if entry["N"] == log.StatsLoggerName {
+				report = entry
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "Log volume", report["M"])
+	assert.Equal(t, "INFO", report["L"])
+	assert.NotContains(t, report, "C", "report entries have no caller")
+	assert.Equal(t, map[string]any{"statstest-report": float64(1)}, report["loggers"])
+	assert.Equal(t, float64(1), report["entries"])
+
+	// 替换全局记录器后停止报告 (Reporting stops once the global logger is replaced)
+	log.SetGlobalLogger(original)
+	count := len(buf.entries(t))
+	time.Sleep(150 * time.Millisecond)
+	assert.Len(t, buf.entries(t), count)
+
+	opts.StatsReport.Interval = -time.Second
+	assert.NotEmpty(t, opts.Validate())
+}
@@ -0,0 +1,62 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// 消息处理结果标签值。(Message handling result label values.)
+const (
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+// ConsumerMetrics 为消息消费者导出 RED 指标：
+// <namespace>_queue_consumer_requests_total{consumer,topic,result}、
+// <namespace>_queue_consumer_request_errors_total{consumer,topic,category} 和
+// <namespace>_queue_consumer_request_duration_seconds{consumer,topic}。
+// (ConsumerMetrics exports RED metrics for message consumers:
+// <namespace>_queue_consumer_requests_total{consumer,topic,result},
+// <namespace>_queue_consumer_request_errors_total{consumer,topic,category} and
+// <namespace>_queue_consumer_request_duration_seconds{consumer,topic}.)
+type ConsumerMetrics struct {
+	red *red
+}
+
+// NewConsumerMetrics 创建消息消费者指标并注册。
+// (NewConsumerMetrics creates and registers message consumer metrics.)
+func NewConsumerMetrics(opts *Options, options ...Option) (*ConsumerMetrics, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid metrics options"),
+			lmccerrors.ErrMetricsOptionInvalid,
+		)
+	}
+	s := newSettings(options)
+	r, err := newRED(opts, s.registry, "queue_consumer", []string{"consumer", "topic"}, "result")
+	if err != nil {
+		return nil, err
+	}
+	return &ConsumerMetrics{red: r}, nil
+}
+
+// Observe 记录一条消息的处理结果，err 按 ErrorCategory 归类。
+// (Observe records the outcome of handling one message; err is classified with ErrorCategory.)
+func (m *ConsumerMetrics) Observe(consumer, topic string, err error, elapsed time.Duration) {
+	result := resultOK
+	if err != nil {
+		result = resultError
+	}
+	m.red.observe([]string{consumer, topic}, result, ErrorCategory(err), elapsed)
+}
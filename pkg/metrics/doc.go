@@ -0,0 +1,104 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package metrics publishes SDK and application metrics through a Prometheus registry.
+(metrics 包通过 Prometheus 注册表发布 SDK 和应用指标。)
+
+Runtime stats are opt-in: with RuntimeStats enabled, a RuntimeCollector samples goroutines,
+heap usage, GC pauses and open file descriptors every RuntimeInterval and publishes them as
+<namespace>_runtime_* metrics. The latest sample is also available through Stats, e.g. for
+health checks.
+(运行时指标需显式启用：开启 RuntimeStats 后，RuntimeCollector 每隔 RuntimeInterval 采样协程数、堆使用、
+GC 暂停和打开的文件描述符，并发布为 <namespace>_runtime_* 指标。最近一次采样结果也可通过 Stats 获取，
+例如用于健康检查。)
+
+RED metrics (request rate, errors by category and duration histograms) are exported by
+HTTPMiddleware for net/http handlers and by GRPCMetrics interceptors for gRPC servers and
+clients. Routes are recorded as templates, never raw paths: the http.ServeMux pattern, a
+custom RouteFunc, or the path with identifier segments replaced by ":id"; unmatched 404s share
+the "unmatched" route. Errors are categorised by the HTTP status of their Coder (see
+RecordError), otherwise by the response status or gRPC code.
+(RED 指标（请求速率、按类别统计的错误和耗时直方图）由 net/http 处理器的 HTTPMiddleware 以及
+gRPC 服务端和客户端的 GRPCMetrics 拦截器导出。路由始终记录为模板而非原始路径：http.ServeMux 的模式、
+自定义 RouteFunc，或将标识符段替换为 ":id" 的路径；未匹配路由的 404 统一记为 "unmatched"。
+错误按其 Coder 的 HTTP 状态码归类（见 RecordError），否则按响应状态码或 gRPC 状态码归类。)
+
+Application metrics use Counter, Gauge and Histogram, created with NewCounter, NewGauge and
+NewHistogram under the same namespace. Their label values are passed as key-value pairs, the
+form log.Infow takes, so the fields of a log line can be reused: undeclared keys are ignored and
+missing labels are recorded as empty. ErrorCounter counts errors as
+<namespace>_errors_total{code,category} by the Coder they carry. Handler serves the default
+registry at "/metrics".
+(应用指标使用 Counter、Gauge 和 Histogram，它们由 NewCounter、NewGauge 和 NewHistogram 在同一命名空间下创建。
+标签值以 log.Infow 使用的键值对形式传入，因此可以复用日志行的字段：未声明的键会被忽略，缺少的标签记为空。
+ErrorCounter 按错误携带的 Coder 以 <namespace>_errors_total{code,category} 统计错误。Handler 在 "/metrics"
+上暴露默认注册表。)
+
+Batch jobs and CLIs that cannot be scraped push instead: Options.Push selects a Prometheus
+Pushgateway or an OTLP/gRPC collector, and a Pusher sends the registry once with Push, or
+periodically between Start and Stop. With no exporter configured the Pusher does nothing.
+(无法被抓取的批处理任务和命令行工具改为推送：Options.Push 选择 Prometheus Pushgateway 或 OTLP/gRPC
+收集器，Pusher 通过 Push 推送一次注册表，或在 Start 与 Stop 之间周期推送。未配置导出器时 Pusher 不执行任何操作。)
+
+Usage:
+(用法：)
+
+	opts := metrics.NewOptions()
+	opts.Namespace = "checkout"
+	opts.RuntimeStats = true
+	collector, err := metrics.NewRuntimeCollector(opts)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	if err := collector.Start(ctx); err != nil {
+		// handle error (处理错误)
+	}
+	defer collector.Stop(context.Background())
+
+	srv, err := debug.NewServer(debugOpts, debug.WithMetricsHandler(metrics.Default().Handler()))
+
+	mw, err := metrics.NewHTTPMiddleware(opts)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	http.ListenAndServe(":8080", mw.Handler(mux))
+
+	duration, err := metrics.NewHistogram(opts, "job_duration_seconds", "Job duration.", []string{"job"})
+	if err != nil {
+		// handle error (处理错误)
+	}
+	fields := []any{"job", "import", "batch", 42}
+	logger.Infow("job finished", fields...)
+	duration.ObserveDuration(start, fields...) // 忽略 "batch" (Ignores "batch")
+
+	errorCounter, err := metrics.NewErrorCounter(opts, []string{"job"})
+	if err != nil {
+		// handle error (处理错误)
+	}
+	errorCounter.Record(err, "job", "import") // 按 err 的 Coder 设置 code 和 category (code and category come from the Coder of err)
+
+	grpcMetrics, err := metrics.NewGRPCMetrics(opts)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcMetrics.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(grpcMetrics.StreamServerInterceptor()),
+	)
+
+	// metrics:
+	//   push:
+	//     exporter: pushgateway
+	//     endpoint: http://pushgateway:9091
+	//     job: nightly-import
+	pusher, err := metrics.NewPusher(opts)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	defer pusher.Stop(context.Background()) // 最后推送一次 (Pushes one last time)
+*/
+package metrics
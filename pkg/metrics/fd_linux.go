@@ -0,0 +1,27 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileDescriptors 返回当前打开的文件描述符数和上限。
+// (fileDescriptors returns the number of open file descriptors and the limit.)
+func fileDescriptors() (open, limit int64) {
+	open, limit = -1, -1
+	if entries, err := os.ReadDir("/proc/self/fd"); err == nil {
+		// 读取目录本身占用一个描述符 (Reading the directory itself holds one descriptor)
+		open = int64(len(entries)) - 1
+	}
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err == nil {
+		limit = int64(rlimit.Cur)
+	}
+	return open, limit
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+// fileDescriptors 在非 Linux 平台上不受支持，返回 -1。
+// (fileDescriptors is not supported on non-Linux platforms and returns -1.)
+func fileDescriptors() (open, limit int64) {
+	return -1, -1
+}
@@ -0,0 +1,176 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC 调用类型标签值。(Call type label values for gRPC.)
+const (
+	grpcUnary  = "unary"
+	grpcStream = "stream"
+)
+
+// GRPCMetrics 为 gRPC 服务端和客户端导出 RED 指标，方法名本身就是模板，不会产生高基数标签：
+// <namespace>_grpc_server_requests_total{type,method,code}、_request_errors_total{type,method,category}、
+// _request_duration_seconds{type,method}，客户端对应 <namespace>_grpc_client_*。
+// (GRPCMetrics exports RED metrics for gRPC servers and clients; full method names are templates already, so labels stay low-cardinality:
+// <namespace>_grpc_server_requests_total{type,method,code}, _request_errors_total{type,method,category},
+// _request_duration_seconds{type,method}, and <namespace>_grpc_client_* for clients.)
+type GRPCMetrics struct {
+	server *red
+	client *red
+}
+
+// NewGRPCMetrics 创建 gRPC 指标拦截器并注册其指标。
+// (NewGRPCMetrics creates the gRPC metrics interceptors and registers their metrics.)
+func NewGRPCMetrics(opts *Options, options ...Option) (*GRPCMetrics, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid metrics options"),
+			lmccerrors.ErrMetricsOptionInvalid,
+		)
+	}
+	s := newSettings(options)
+	labels := []string{"type", "method"}
+	server, err := newRED(opts, s.registry, "grpc_server", labels, "code")
+	if err != nil {
+		return nil, err
+	}
+	client, err := newRED(opts, s.registry, "grpc_client", labels, "code")
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCMetrics{server: server, client: client}, nil
+}
+
+// UnaryServerInterceptor 返回记录一元调用的服务端拦截器。
+// (UnaryServerInterceptor returns a server interceptor recording unary calls.)
+func (m *GRPCMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeGRPC(m.server, grpcUnary, info.FullMethod, err, start)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor 返回记录流式调用的服务端拦截器。
+// (StreamServerInterceptor returns a server interceptor recording streaming calls.)
+func (m *GRPCMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeGRPC(m.server, grpcStream, info.FullMethod, err, start)
+		return err
+	}
+}
+
+// UnaryClientInterceptor 返回记录一元调用的客户端拦截器。
+// (UnaryClientInterceptor returns a client interceptor recording unary calls.)
+func (m *GRPCMetrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		observeGRPC(m.client, grpcUnary, method, err, start)
+		return err
+	}
+}
+
+// StreamClientInterceptor 返回记录流式调用的客户端拦截器，流在接收结束或出错时计入指标。
+// (StreamClientInterceptor returns a client interceptor recording streaming calls; a stream is recorded when receiving ends or fails.)
+func (m *GRPCMetrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			observeGRPC(m.client, grpcStream, method, err, start)
+			return nil, err
+		}
+		return &monitoredClientStream{ClientStream: cs, finish: func(err error) {
+			observeGRPC(m.client, grpcStream, method, err, start)
+		}}, nil
+	}
+}
+
+// monitoredClientStream 在 RecvMsg 返回错误（含 io.EOF）时记录一次调用。
+// (monitoredClientStream records the call once RecvMsg returns an error, including io.EOF.)
+type monitoredClientStream struct {
+	grpc.ClientStream
+	once   sync.Once
+	finish func(err error)
+}
+
+func (s *monitoredClientStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil {
+		s.once.Do(func() {
+			if errors.Is(err, io.EOF) {
+				s.finish(nil)
+			} else {
+				s.finish(err)
+			}
+		})
+	}
+	return err
+}
+
+// observeGRPC 记录一次 gRPC 调用。(observeGRPC records one gRPC call.)
+func observeGRPC(r *red, callType, method string, err error, start time.Time) {
+	r.observe([]string{callType, method}, status.Code(err).String(), GRPCErrorCategory(err), time.Since(start))
+}
+
+// GRPCErrorCategory 返回 gRPC 调用错误的类别：带 Coder 的错误按 Coder 归类，其他错误按 gRPC 状态码归类，nil 返回空字符串。
+// (GRPCErrorCategory returns the category of a gRPC call error: errors carrying a Coder are classified by the Coder,
+// others by their gRPC status code, and nil yields an empty string.)
+func GRPCErrorCategory(err error) string {
+	if err == nil {
+		return ""
+	}
+	if coder := lmccerrors.GetCoder(err); coder != nil && !lmccerrors.IsUnknownCoder(coder) {
+		return ErrorCategory(err)
+	}
+	switch status.Code(err) {
+	case codes.OK:
+		return ""
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return CategoryBadRequest
+	case codes.Unauthenticated:
+		return CategoryUnauthorized
+	case codes.PermissionDenied:
+		return CategoryForbidden
+	case codes.NotFound:
+		return CategoryNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return CategoryConflict
+	case codes.ResourceExhausted:
+		return CategoryRateLimited
+	case codes.DeadlineExceeded:
+		return CategoryTimeout
+	case codes.Unavailable:
+		return CategoryUnavailable
+	case codes.Canceled:
+		return CategoryClient
+	case codes.Unknown:
+		return CategoryUnknown
+	default:
+		return CategoryInternal
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the RED metrics gRPC interceptors.
+ */
+
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestGRPCServerInterceptors tests that unary and stream server calls are counted by method, code and category.
+// (TestGRPCServerInterceptors 测试服务端一元和流式调用按方法、状态码和类别计数。)
+func TestGRPCServerInterceptors(t *testing.T) {
+	reg := metrics.NewRegistry()
+	opts := metrics.NewOptions()
+	opts.Namespace = "svc"
+	m, err := metrics.NewGRPCMetrics(opts, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	unary := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.v1.Users/Get"}
+	_, err = unary(context.Background(), nil, info, func(context.Context, any) (any, error) { return "ok", nil })
+	require.NoError(t, err)
+	_, err = unary(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+	require.Error(t, err)
+
+	stream := m.StreamServerInterceptor()
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/users.v1.Users/Watch"}
+	err = stream(nil, nil, streamInfo, func(any, grpc.ServerStream) error {
+		return lmccerrors.NewWithCode(lmccerrors.ErrForbidden, "denied")
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, 1.0, metricValue(t, reg, "svc_grpc_server_requests_total", map[string]string{"type": "unary", "method": info.FullMethod, "code": "OK"}))
+	assert.Equal(t, 1.0, metricValue(t, reg, "svc_grpc_server_request_errors_total", map[string]string{"method": info.FullMethod, "category": metrics.CategoryNotFound}))
+	assert.Equal(t, 1.0, metricValue(t, reg, "svc_grpc_server_request_errors_total", map[string]string{"type": "stream", "category": metrics.CategoryForbidden}))
+	assert.Equal(t, 2.0, metricValue(t, reg, "svc_grpc_server_request_duration_seconds", map[string]string{"method": info.FullMethod}))
+}
+
+// fakeClientStream is a client stream that returns the queued errors from RecvMsg.
+// (fakeClientStream 是从 RecvMsg 依次返回预设错误的客户端流。)
+type fakeClientStream struct {
+	grpc.ClientStream
+	recv []error
+}
+
+func (s *fakeClientStream) RecvMsg(any) error {
+	err := s.recv[0]
+	s.recv = s.recv[1:]
+	return err
+}
+
+// TestGRPCClientInterceptors tests that client calls are recorded, streams once they finish.
+// (TestGRPCClientInterceptors 测试客户端调用被记录，流式调用在结束时记录。)
+func TestGRPCClientInterceptors(t *testing.T) {
+	reg := metrics.NewRegistry()
+	m, err := metrics.NewGRPCMetrics(nil, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	unary := m.UnaryClientInterceptor()
+	err = unary(context.Background(), "/users.v1.Users/Get", nil, nil, nil,
+		func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			return status.Error(codes.Unavailable, "down")
+		})
+	require.Error(t, err)
+	assert.Equal(t, 1.0, metricValue(t, reg, "grpc_client_request_errors_total", map[string]string{"type": "unary", "category": metrics.CategoryUnavailable}))
+
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{recv: []error{nil, io.EOF, io.EOF}}, nil
+	}
+	cs, err := m.StreamClientInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, "/users.v1.Users/Watch", streamer)
+	require.NoError(t, err)
+	labels := map[string]string{"type": "stream", "method": "/users.v1.Users/Watch", "code": "OK"}
+	require.NoError(t, cs.RecvMsg(nil))
+	assert.Equal(t, 0.0, metricValue(t, reg, "grpc_client_requests_total", labels), "open streams are not recorded yet")
+	assert.ErrorIs(t, cs.RecvMsg(nil), io.EOF)
+	assert.ErrorIs(t, cs.RecvMsg(nil), io.EOF)
+	assert.Equal(t, 1.0, metricValue(t, reg, "grpc_client_requests_total", labels), "a stream is recorded once")
+}
+
+// TestGRPCErrorCategory tests classification of gRPC status codes and coded errors.
+// (TestGRPCErrorCategory 测试 gRPC 状态码和带错误码错误的归类。)
+func TestGRPCErrorCategory(t *testing.T) {
+	assert.Equal(t, "", metrics.GRPCErrorCategory(nil))
+	assert.Equal(t, metrics.CategoryTimeout, metrics.GRPCErrorCategory(status.Error(codes.DeadlineExceeded, "slow")))
+	assert.Equal(t, metrics.CategoryRateLimited, metrics.GRPCErrorCategory(status.Error(codes.ResourceExhausted, "quota")))
+	assert.Equal(t, metrics.CategoryInternal, metrics.GRPCErrorCategory(status.Error(codes.Internal, "bug")))
+	assert.Equal(t, metrics.CategoryRateLimited, metrics.GRPCErrorCategory(lmccerrors.NewWithCode(lmccerrors.ErrTooManyRequests, "slow down")))
+	assert.Equal(t, metrics.CategoryUnknown, metrics.GRPCErrorCategory(lmccerrors.New("plain")))
+}
@@ -0,0 +1,195 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// UnmatchedRoute 是没有匹配到任何路由的请求使用的路由标签，避免扫描器制造高基数路径。
+// (UnmatchedRoute is the route label of requests that matched no route, so scanners cannot create high-cardinality paths.)
+const UnmatchedRoute = "unmatched"
+
+// RouteFunc 返回请求的路由模板（例如 "/users/{id}"），用作指标标签。它在处理器返回后调用。
+// (RouteFunc returns the route template of a request, e.g. "/users/{id}", used as a metric label. It is called after the handler returns.)
+type RouteFunc func(r *http.Request) string
+
+// idSegmentPattern 匹配看起来像标识符的路径段：数字、UUID 和较长的十六进制串。
+// (idSegmentPattern matches path segments that look like identifiers: numbers, UUIDs and long hex strings.)
+var idSegmentPattern = regexp.MustCompile(`^(\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,})$`)
+
+// DefaultRoute 优先使用 http.ServeMux 匹配到的模式（Request.Pattern），否则用 TemplatePath 模板化请求路径。
+// 未设置 WithRouteFunc 时中间件使用它，并把未匹配路由的 404 记为 UnmatchedRoute。
+// (DefaultRoute prefers the pattern matched by http.ServeMux (Request.Pattern) and otherwise templates the request path with TemplatePath.
+// The middleware uses it when WithRouteFunc is not set, recording 404s without a matched pattern as UnmatchedRoute.)
+func DefaultRoute(r *http.Request) string {
+	if pattern := r.Pattern; pattern != "" {
+		// 去掉 "GET " 等方法和主机前缀 (Strip the method and host prefix, e.g. "GET ")
+		if i := strings.Index(pattern, "/"); i >= 0 {
+			return pattern[i:]
+		}
+		return pattern
+	}
+	return TemplatePath(r.URL.Path)
+}
+
+// TemplatePath 将路径中的数字、UUID 和长十六进制段替换为 ":id"，例如 "/users/42/orders" 变为 "/users/:id/orders"。
+// (TemplatePath replaces numeric, UUID and long hex segments of a path with ":id", e.g. "/users/42/orders" becomes "/users/:id/orders".)
+func TemplatePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if idSegmentPattern.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// standardMethods 是作为标签保留的 HTTP 方法，其他方法记为 "OTHER"。
+// (standardMethods are the HTTP methods kept as labels; other methods are recorded as "OTHER".)
+var standardMethods = map[string]struct{}{
+	http.MethodGet: {}, http.MethodHead: {}, http.MethodPost: {}, http.MethodPut: {}, http.MethodPatch: {},
+	http.MethodDelete: {}, http.MethodConnect: {}, http.MethodOptions: {}, http.MethodTrace: {},
+}
+
+// requestErrorKey 是保存请求错误的 context 键。(requestErrorKey is the context key holding the request error.)
+type requestErrorKey struct{}
+
+// RecordError 记录当前请求的错误，HTTP 中间件会按 err 携带的 Coder 对请求归类。不在中间件内调用时不执行任何操作。
+// (RecordError records the error of the current request; the HTTP middleware classifies the request by the Coder carried by err.
+// It does nothing outside the middleware.)
+func RecordError(ctx context.Context, err error) {
+	if holder, ok := ctx.Value(requestErrorKey{}).(*error); ok {
+		*holder = err
+	}
+}
+
+// HTTPMiddleware 为 net/http 处理器导出 RED 指标：
+// <namespace>_http_server_requests_total{method,route,status}、
+// <namespace>_http_server_request_errors_total{method,route,category} 和
+// <namespace>_http_server_request_duration_seconds{method,route}。
+// (HTTPMiddleware exports RED metrics for net/http handlers:
+// <namespace>_http_server_requests_total{method,route,status},
+// <namespace>_http_server_request_errors_total{method,route,category} and
+// <namespace>_http_server_request_duration_seconds{method,route}.)
+type HTTPMiddleware struct {
+	red   *red
+	route RouteFunc
+}
+
+// NewHTTPMiddleware 创建 HTTP 指标中间件并注册其指标。
+// (NewHTTPMiddleware creates the HTTP metrics middleware and registers its metrics.)
+func NewHTTPMiddleware(opts *Options, options ...Option) (*HTTPMiddleware, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid metrics options"),
+			lmccerrors.ErrMetricsOptionInvalid,
+		)
+	}
+	s := newSettings(options)
+	r, err := newRED(opts, s.registry, "http_server", []string{"method", "route"}, "status")
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPMiddleware{red: r, route: s.routeFunc}, nil
+}
+
+// Handler 包装 next，记录每个请求的状态、错误类别和耗时。
+// 错误类别优先取自 RecordError 记录的错误，否则由 4xx/5xx 状态码推导；处理器 panic 时记为 500 后继续向上传播。
+// (Handler wraps next and records the status, error category and duration of every request.
+// The category comes from the error passed to RecordError, otherwise from a 4xx/5xx status; a panicking handler is recorded as 500 and the panic is re-raised.)
+func (m *HTTPMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		var reqErr error
+		r = r.WithContext(context.WithValue(r.Context(), requestErrorKey{}, &reqErr))
+		rec := &statusRecorder{ResponseWriter: w}
+
+		defer func() {
+			p := recover()
+			status := rec.status
+			if p != nil {
+				status = http.StatusInternalServerError
+			} else if status == 0 {
+				status = http.StatusOK
+			}
+
+			var route string
+			switch {
+			case m.route != nil:
+				route = m.route(r)
+			case status == http.StatusNotFound && r.Pattern == "":
+				route = UnmatchedRoute
+			default:
+				route = DefaultRoute(r)
+			}
+			method := r.Method
+			if _, ok := standardMethods[method]; !ok {
+				method = "OTHER"
+			}
+
+			category := ErrorCategory(reqErr)
+			if category == "" {
+				category = StatusCategory(status)
+			}
+			m.red.observe([]string{method, route}, strconv.Itoa(status), category, time.Since(start))
+
+			if p != nil {
+				panic(p)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// statusRecorder 记录响应状态码。(statusRecorder records the response status code.)
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush 转发给底层的 http.Flusher。(Flush forwards to the underlying http.Flusher.)
+func (r *statusRecorder) Flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap 让 http.ResponseController 访问底层 ResponseWriter。
+// (Unwrap lets http.ResponseController reach the underlying ResponseWriter.)
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,116 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the RED metrics HTTP middleware.
+ */
+
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metricValue returns the counter value or histogram sample count of the series matching labels.
+// (metricValue 返回与 labels 匹配的序列的计数器值或直方图样本数。)
+func metricValue(t *testing.T, reg *metrics.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, mf := range families {
+		if mf.GetName() != name {
+			continue
+		}
+	next:
+		for _, m := range mf.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if want, ok := labels[lp.GetName()]; ok && want != lp.GetValue() {
+					continue next
+				}
+			}
+			if h := m.GetHistogram(); h != nil {
+				return float64(h.GetSampleCount())
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+// TestHTTPMiddleware tests that requests are counted by route template, status and error category.
+// (TestHTTPMiddleware 测试请求按路由模板、状态码和错误类别计数。)
+func TestHTTPMiddleware(t *testing.T) {
+	reg := metrics.NewRegistry()
+	opts := metrics.NewOptions()
+	opts.Namespace = "svc"
+	m, err := metrics.NewHTTPMiddleware(opts, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "0" {
+			metrics.RecordError(r.Context(), lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "no such user"))
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/boom", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	handler := m.Handler(mux)
+
+	for _, path := range []string{"/users/1", "/users/2", "/users/0", "/boom", "/scanner/probe-1", "/scanner/probe-2"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, metricValue(t, reg, "svc_http_server_requests_total", map[string]string{"method": "GET", "route": "/users/{id}", "status": "200"}))
+	assert.Equal(t, 1.0, metricValue(t, reg, "svc_http_server_request_errors_total", map[string]string{"route": "/users/{id}", "category": metrics.CategoryNotFound}))
+	assert.Equal(t, 1.0, metricValue(t, reg, "svc_http_server_request_errors_total", map[string]string{"route": "/boom", "category": metrics.CategoryInternal}))
+	assert.Equal(t, 2.0, metricValue(t, reg, "svc_http_server_requests_total", map[string]string{"route": metrics.UnmatchedRoute, "status": "404"}))
+	assert.Equal(t, 3.0, metricValue(t, reg, "svc_http_server_request_duration_seconds", map[string]string{"route": "/users/{id}"}))
+
+	// A second middleware on the same registry reuses the metrics (同一注册表上的第二个中间件复用指标)
+	_, err = metrics.NewHTTPMiddleware(opts, metrics.WithRegistry(reg))
+	assert.NoError(t, err)
+}
+
+// TestHTTPMiddleware_Panic tests that a panicking handler is recorded as 500 and the panic propagates.
+// (TestHTTPMiddleware_Panic 测试处理器 panic 时记为 500 并继续向上传播。)
+func TestHTTPMiddleware_Panic(t *testing.T) {
+	reg := metrics.NewRegistry()
+	m, err := metrics.NewHTTPMiddleware(nil, metrics.WithRegistry(reg), metrics.WithRouteFunc(func(*http.Request) string { return "/custom" }))
+	require.NoError(t, err)
+
+	handler := m.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic("boom") }))
+	assert.Panics(t, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("PURGE", "/anything/42", nil))
+	})
+	assert.Equal(t, 1.0, metricValue(t, reg, "http_server_requests_total", map[string]string{"method": "OTHER", "route": "/custom", "status": "500"}))
+}
+
+// TestTemplatePath tests that identifier segments are replaced by ":id".
+// (TestTemplatePath 测试标识符路径段被替换为 ":id"。)
+func TestTemplatePath(t *testing.T) {
+	assert.Equal(t, "/users/:id/orders/:id", metrics.TemplatePath("/users/42/orders/3f2504e0-4f89-11d3-9a0c-0305e82c3301"))
+	assert.Equal(t, "/blobs/:id", metrics.TemplatePath("/blobs/deadbeefdeadbeef"))
+	assert.Equal(t, "/users/me", metrics.TemplatePath("/users/me"))
+}
+
+// TestErrorCategory tests classification of errors and statuses.
+// (TestErrorCategory 测试错误和状态码的归类。)
+func TestErrorCategory(t *testing.T) {
+	assert.Equal(t, "", metrics.ErrorCategory(nil))
+	assert.Equal(t, metrics.CategoryUnauthorized, metrics.ErrorCategory(lmccerrors.NewWithCode(lmccerrors.ErrUnauthorized, "denied")))
+	assert.Equal(t, metrics.CategoryUnknown, metrics.ErrorCategory(lmccerrors.New("plain")))
+	assert.Equal(t, "", metrics.StatusCategory(http.StatusNoContent))
+	assert.Equal(t, metrics.CategoryRateLimited, metrics.StatusCategory(http.StatusTooManyRequests))
+	assert.Equal(t, metrics.CategoryClient, metrics.StatusCategory(http.StatusTeapot))
+}
@@ -0,0 +1,235 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Counter 是带标签的计数器。标签值以与 log.Infow 相同的键值对形式传入，例如
+// Inc("operation", "get_user")：未声明的键会被忽略，缺少的标签记为空字符串，因此同一组日志字段可以直接复用。
+// (Counter is a labelled counter. Label values are passed as key-value pairs, the same form log.Infow takes, e.g.
+// Inc("operation", "get_user"): undeclared keys are ignored and missing labels are recorded as an empty string,
+// so the same set of log fields can be reused as is.)
+type Counter struct {
+	vec    *prometheus.CounterVec
+	labels []string
+}
+
+// NewCounter 创建并注册名为 <namespace>_<name> 的计数器；同名指标已注册时复用已有实例。
+// (NewCounter creates and registers a counter named <namespace>_<name>; a metric already registered under that name is reused.)
+func NewCounter(opts *Options, name, help string, labels []string, options ...Option) (*Counter, error) {
+	opts, s, err := instrumentSettings(opts, options)
+	if err != nil {
+		return nil, err
+	}
+	vec, err := register(s.registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: opts.Namespace, Name: name, Help: help,
+	}, labels))
+	if err != nil {
+		return nil, err
+	}
+	return &Counter{vec: vec, labels: labels}, nil
+}
+
+// Inc 将计数加 1。(Inc increments the counter by 1.)
+func (c *Counter) Inc(keysAndValues ...any) {
+	c.vec.WithLabelValues(labelValues(c.labels, keysAndValues)...).Inc()
+}
+
+// Add 将计数加 v，v 不能为负。(Add adds v to the counter; v must not be negative.)
+func (c *Counter) Add(v float64, keysAndValues ...any) {
+	c.vec.WithLabelValues(labelValues(c.labels, keysAndValues)...).Add(v)
+}
+
+// Gauge 是带标签的仪表，标签的传入方式与 Counter 相同。
+// (Gauge is a labelled gauge; labels are passed the same way as for Counter.)
+type Gauge struct {
+	vec    *prometheus.GaugeVec
+	labels []string
+}
+
+// NewGauge 创建并注册名为 <namespace>_<name> 的仪表；同名指标已注册时复用已有实例。
+// (NewGauge creates and registers a gauge named <namespace>_<name>; a metric already registered under that name is reused.)
+func NewGauge(opts *Options, name, help string, labels []string, options ...Option) (*Gauge, error) {
+	opts, s, err := instrumentSettings(opts, options)
+	if err != nil {
+		return nil, err
+	}
+	vec, err := register(s.registry, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: opts.Namespace, Name: name, Help: help,
+	}, labels))
+	if err != nil {
+		return nil, err
+	}
+	return &Gauge{vec: vec, labels: labels}, nil
+}
+
+// Set 将仪表设为 v。(Set sets the gauge to v.)
+func (g *Gauge) Set(v float64, keysAndValues ...any) {
+	g.vec.WithLabelValues(labelValues(g.labels, keysAndValues)...).Set(v)
+}
+
+// Add 将仪表加 v，v 可以为负。(Add adds v to the gauge; v may be negative.)
+func (g *Gauge) Add(v float64, keysAndValues ...any) {
+	g.vec.WithLabelValues(labelValues(g.labels, keysAndValues)...).Add(v)
+}
+
+// Inc 将仪表加 1。(Inc increments the gauge by 1.)
+func (g *Gauge) Inc(keysAndValues ...any) {
+	g.Add(1, keysAndValues...)
+}
+
+// Dec 将仪表减 1。(Dec decrements the gauge by 1.)
+func (g *Gauge) Dec(keysAndValues ...any) {
+	g.Add(-1, keysAndValues...)
+}
+
+// Histogram 是带标签的直方图，标签的传入方式与 Counter 相同。
+// (Histogram is a labelled histogram; labels are passed the same way as for Counter.)
+type Histogram struct {
+	vec    *prometheus.HistogramVec
+	labels []string
+}
+
+// NewHistogram 创建并注册名为 <namespace>_<name> 的直方图，桶上界默认取 Options.DurationBuckets，可用 WithBuckets 覆盖；
+// 同名指标已注册时复用已有实例。
+// (NewHistogram creates and registers a histogram named <namespace>_<name>. Its bucket upper bounds default to
+// Options.DurationBuckets and can be overridden with WithBuckets; a metric already registered under that name is reused.)
+func NewHistogram(opts *Options, name, help string, labels []string, options ...Option) (*Histogram, error) {
+	opts, s, err := instrumentSettings(opts, options)
+	if err != nil {
+		return nil, err
+	}
+	buckets := opts.DurationBuckets
+	if s.buckets != nil {
+		buckets = s.buckets
+	}
+	vec, err := register(s.registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: opts.Namespace, Name: name, Help: help, Buckets: buckets,
+	}, labels))
+	if err != nil {
+		return nil, err
+	}
+	return &Histogram{vec: vec, labels: labels}, nil
+}
+
+// Observe 记录一个样本。(Observe records one sample.)
+func (h *Histogram) Observe(v float64, keysAndValues ...any) {
+	h.vec.WithLabelValues(labelValues(h.labels, keysAndValues)...).Observe(v)
+}
+
+// ObserveDuration 记录从 start 到现在经过的秒数，通常与 defer 一起使用。
+// (ObserveDuration records the seconds elapsed since start; it is typically used with defer.)
+func (h *Histogram) ObserveDuration(start time.Time, keysAndValues ...any) {
+	h.Observe(time.Since(start).Seconds(), keysAndValues...)
+}
+
+// UnknownCode 是不携带已注册 Coder 的错误在 ErrorCounter 中使用的 code 标签。
+// (UnknownCode is the code label ErrorCounter uses for errors that carry no registered Coder.)
+const UnknownCode = "unknown"
+
+// ErrorCounter 按 Coder 统计错误，导出 <namespace>_errors_total{<labels>,code,category}。
+// code 是 Coder 的数字错误码，其基数受已注册的 Coder 数量限制；category 与 RED 指标使用相同的错误类别。
+// (ErrorCounter counts errors by Coder, exporting <namespace>_errors_total{<labels>,code,category}.
+// code is the numeric code of the Coder, so its cardinality is bounded by the registered Coders; category is the same
+// error category used by the RED metrics.)
+type ErrorCounter struct {
+	counter *Counter
+}
+
+// NewErrorCounter 创建并注册错误计数器，labels 是 code 和 category 之外的标签，例如 "operation"。
+// (NewErrorCounter creates and registers the error counter; labels are the labels besides code and category, e.g. "operation".)
+func NewErrorCounter(opts *Options, labels []string, options ...Option) (*ErrorCounter, error) {
+	c, err := NewCounter(opts, "errors_total", "Number of errors by error code and category.",
+		append(labels[:len(labels):len(labels)], "code", "category"), options...)
+	if err != nil {
+		return nil, err
+	}
+	return &ErrorCounter{counter: c}, nil
+}
+
+// Record 记录 err，err 为 nil 时不执行任何操作。keysAndValues 提供额外标签的值，其中的 code 和 category 会被忽略。
+// (Record records err and does nothing when err is nil. keysAndValues supply the extra labels; code and category in them are ignored.)
+func (e *ErrorCounter) Record(err error, keysAndValues ...any) {
+	if err == nil {
+		return
+	}
+	code := UnknownCode
+	if coder := lmccerrors.GetCoder(err); coder != nil && !lmccerrors.IsUnknownCoder(coder) {
+		code = strconv.Itoa(coder.Code())
+	}
+	values := labelValues(e.counter.labels[:len(e.counter.labels)-2], keysAndValues)
+	e.counter.vec.WithLabelValues(append(values, code, ErrorCategory(err))...).Inc()
+}
+
+// Handler 返回以 Prometheus 文本格式暴露 Default() 注册表的 HTTP 处理器，通常挂载在 "/metrics"。
+// (Handler returns an HTTP handler exposing the Default() registry in the Prometheus text format, usually mounted at "/metrics".)
+func Handler() http.Handler {
+	return Default().Handler()
+}
+
+// instrumentSettings 校验选项并应用 Option。(instrumentSettings validates the options and applies the Options.)
+func instrumentSettings(opts *Options, options []Option) (*Options, *settings, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	errs := opts.Validate()
+	s := newSettings(options)
+	for i := 1; i < len(s.buckets); i++ {
+		if s.buckets[i] <= s.buckets[i-1] {
+			errs = append(errs, fmt.Errorf("invalid buckets %v, must be strictly increasing", s.buckets))
+			break
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid metrics options"),
+			lmccerrors.ErrMetricsOptionInvalid,
+		)
+	}
+	return opts, s, nil
+}
+
+// labelValues 按 labels 的顺序从日志风格的键值对中取出标签值。
+// (labelValues picks the label values, in the order of labels, out of log-style key-value pairs.)
+func labelValues(labels []string, keysAndValues []any) []string {
+	values := make([]string, len(labels))
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			continue
+		}
+		for j, label := range labels {
+			if label == key {
+				values[j] = labelString(keysAndValues[i+1])
+				break
+			}
+		}
+	}
+	return values
+}
+
+func labelString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case error:
+		return v.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the counter, gauge, histogram and error counter helpers.
+ */
+
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInstruments tests that label values are taken from log-style key-value pairs and that instruments are reused by name.
+// (TestInstruments 测试标签值取自日志风格的键值对，且同名指标会被复用。)
+func TestInstruments(t *testing.T) {
+	reg := metrics.NewRegistry()
+	opts := metrics.NewOptions()
+	opts.Namespace = "svc"
+
+	requests, err := metrics.NewCounter(opts, "requests_total", "Requests.", []string{"operation", "status"}, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+	// 与日志字段相同的键值对：多余的键被忽略，缺少的标签记为空 (The same pairs as log fields: extra keys are ignored, missing labels are empty)
+	fields := []any{"operation", "get_user", "user_id", "42", "status", 200}
+	requests.Inc(fields...)
+	requests.Add(2, fields...)
+	requests.Inc("operation", "create_user")
+
+	again, err := metrics.NewCounter(opts, "requests_total", "Requests.", []string{"operation", "status"}, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+	again.Inc(fields...)
+
+	assert.Equal(t, 4.0, metricValue(t, reg, "svc_requests_total", map[string]string{"operation": "get_user", "status": "200"}))
+	assert.Equal(t, 1.0, metricValue(t, reg, "svc_requests_total", map[string]string{"operation": "create_user", "status": ""}))
+
+	inflight, err := metrics.NewGauge(opts, "inflight", "In-flight requests.", []string{"operation"}, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+	inflight.Inc("operation", "get_user")
+	inflight.Inc("operation", "get_user")
+	inflight.Dec("operation", "get_user")
+	inflight.Set(7, "operation", "create_user")
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	gauges := map[string]float64{}
+	for _, mf := range families {
+		if mf.GetName() == "svc_inflight" {
+			for _, m := range mf.GetMetric() {
+				gauges[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"get_user": 1, "create_user": 7}, gauges)
+
+	latency, err := metrics.NewHistogram(opts, "latency_seconds", "Latency.", []string{"operation"},
+		metrics.WithRegistry(reg), metrics.WithBuckets(0.1, 1))
+	require.NoError(t, err)
+	latency.Observe(0.05, "operation", "get_user")
+	latency.ObserveDuration(time.Now().Add(-time.Second), "operation", "get_user")
+	assert.Equal(t, 2.0, metricValue(t, reg, "svc_latency_seconds", map[string]string{"operation": "get_user"}))
+
+	_, err = metrics.NewHistogram(opts, "bad_seconds", "Bad.", nil, metrics.WithRegistry(reg), metrics.WithBuckets(1, 0.5))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrMetricsOptionInvalid))
+
+	_, err = metrics.NewGauge(opts, "requests_total", "Clashes with the counter.", []string{"operation"}, metrics.WithRegistry(reg))
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrMetricsRegister))
+}
+
+// TestErrorCounter tests that errors are counted by Coder code and category, and that uncoded errors use UnknownCode.
+// (TestErrorCounter 测试错误按 Coder 错误码和类别计数，未携带 Coder 的错误使用 UnknownCode。)
+func TestErrorCounter(t *testing.T) {
+	reg := metrics.NewRegistry()
+	opts := metrics.NewOptions()
+	opts.Namespace = "svc"
+	counter, err := metrics.NewErrorCounter(opts, []string{"operation"}, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	counter.Record(lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "no such user"), "operation", "get_user")
+	counter.Record(lmccerrors.Wrap(lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "no such user"), "lookup"), "operation", "get_user", "code", "ignored")
+	counter.Record(errors.New("boom"), "operation", "create_user")
+	counter.Record(nil, "operation", "create_user")
+
+	assert.Equal(t, 2.0, metricValue(t, reg, "svc_errors_total", map[string]string{
+		"operation": "get_user", "code": "100002", "category": metrics.CategoryNotFound,
+	}))
+	assert.Equal(t, 1.0, metricValue(t, reg, "svc_errors_total", map[string]string{
+		"operation": "create_user", "code": metrics.UnknownCode, "category": metrics.CategoryUnknown,
+	}))
+}
+
+// TestHandler tests that the package-level handler serves the default registry in the Prometheus text format.
+// (TestHandler 测试包级处理器以 Prometheus 文本格式输出默认注册表。)
+func TestHandler(t *testing.T) {
+	c, err := metrics.NewCounter(nil, "instruments_test_handler_total", "Handler test.", nil)
+	require.NoError(t, err)
+	c.Inc()
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Result().Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, string(body), "# TYPE instruments_test_handler_total counter")
+}
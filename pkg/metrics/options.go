@@ -0,0 +1,228 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// namespacePattern 是合法的 Prometheus 指标名前缀。
+// (namespacePattern matches a valid Prometheus metric name prefix.)
+var namespacePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Options 定义了指标的配置选项，通常作为应用配置中的 "metrics" 节加载。
+// (Options defines metrics configuration, typically loaded as the "metrics" section of the application configuration.)
+type Options struct {
+	// Namespace 是 SDK 发布的指标名前缀，例如 "checkout" 会得到 "checkout_runtime_goroutines"。
+	// (Namespace is the prefix of metrics published by the SDK, e.g. "checkout" yields "checkout_runtime_goroutines".)
+	Namespace string `json:"namespace" mapstructure:"namespace"`
+
+	// RuntimeStats 启用 Go 运行时指标采集（协程数、堆、GC 暂停、文件描述符数）。
+	// (RuntimeStats enables collection of Go runtime stats: goroutines, heap, GC pauses and file descriptors.)
+	RuntimeStats bool `json:"runtime-stats" mapstructure:"runtime-stats"`
+
+	// RuntimeInterval 是运行时指标的采样周期。
+	// (RuntimeInterval is the sampling period of runtime stats.)
+	RuntimeInterval time.Duration `json:"runtime-interval" mapstructure:"runtime-interval"`
+
+	// DurationBuckets 是 HTTP、gRPC 请求和消息处理耗时直方图的桶上界（秒），必须严格递增。
+	// (DurationBuckets are the upper bounds in seconds of the HTTP, gRPC and message handling duration histograms; they must be strictly increasing.)
+	DurationBuckets []float64 `json:"duration-buckets" mapstructure:"duration-buckets"`
+
+	// Push 配置主动推送指标，供无法被抓取的批处理任务和命令行工具使用。
+	// (Push configures pushing metrics, for batch jobs and CLIs that cannot be scraped.)
+	Push *PushOptions `json:"push" mapstructure:"push"`
+}
+
+// 推送导出器名称。(Push exporter names.)
+const (
+	// ExporterNone 关闭推送，只通过抓取暴露指标。(ExporterNone disables pushing; metrics are only exposed for scraping.)
+	ExporterNone = ""
+	// ExporterPushgateway 推送到 Prometheus Pushgateway。(ExporterPushgateway pushes to a Prometheus Pushgateway.)
+	ExporterPushgateway = "pushgateway"
+	// ExporterOTLP 通过 OTLP/gRPC 推送。(ExporterOTLP pushes over OTLP/gRPC.)
+	ExporterOTLP = "otlp"
+)
+
+// PushOptions 定义了指标推送的配置选项。
+// (PushOptions defines the configuration of metrics pushing.)
+type PushOptions struct {
+	// Exporter 选择推送目标：""（关闭）、"pushgateway" 或 "otlp"。
+	// (Exporter selects the push target: "" (disabled), "pushgateway" or "otlp".)
+	Exporter string `json:"exporter" mapstructure:"exporter"`
+
+	// Endpoint 是 Pushgateway 的 URL（例如 "http://pushgateway:9091"）或 OTLP 收集器的 host:port（例如 "otel-collector:4317"）。
+	// (Endpoint is the Pushgateway URL, e.g. "http://pushgateway:9091", or the OTLP collector host:port, e.g. "otel-collector:4317".)
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+
+	// Job 是 Pushgateway 的 job 标签，使用 OTLP 时作为 service.name 资源属性。
+	// (Job is the Pushgateway job label; with OTLP it is used as the service.name resource attribute.)
+	Job string `json:"job" mapstructure:"job"`
+
+	// Grouping 是 Pushgateway 的分组标签，例如 {"instance": "batch-01"}。
+	// (Grouping holds the Pushgateway grouping labels, e.g. {"instance": "batch-01"}.)
+	Grouping map[string]string `json:"grouping" mapstructure:"grouping"`
+
+	// Headers 是随每次推送发送的请求头，例如认证信息。
+	// (Headers are sent with every push, e.g. for authentication.)
+	Headers map[string]string `json:"headers" mapstructure:"headers"`
+
+	// Insecure 让 OTLP 使用明文连接。(Insecure makes OTLP use a plaintext connection.)
+	Insecure bool `json:"insecure" mapstructure:"insecure"`
+
+	// Interval 是周期推送的间隔，Pusher.Stop 时还会再推送一次。
+	// (Interval is the period between pushes; Pusher.Stop pushes once more.)
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+
+	// Timeout 是单次推送的超时。(Timeout is the timeout of a single push.)
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// NewPushOptions 创建具有默认值的推送选项 (creates push options with default values)
+func NewPushOptions() *PushOptions {
+	return &PushOptions{
+		Exporter: ExporterNone,     // 默认不推送 (No pushing by default)
+		Interval: 15 * time.Second, // 与运行时采样周期一致 (Matches the runtime sampling period)
+		Timeout:  10 * time.Second, // 单次推送超时 (Timeout of one push)
+	}
+}
+
+// Validate 验证推送选项是否有效。
+// (Validate validates if the push options are valid.)
+func (o *PushOptions) Validate() []error {
+	var errs []error
+
+	switch o.Exporter {
+	case ExporterNone:
+		return nil
+	case ExporterPushgateway:
+		if o.Job == "" {
+			errs = append(errs, fmt.Errorf("push job must not be empty for the pushgateway exporter"))
+		}
+	case ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("invalid push exporter '%s', must be '%s' or '%s'", o.Exporter, ExporterPushgateway, ExporterOTLP))
+	}
+
+	if o.Endpoint == "" {
+		errs = append(errs, fmt.Errorf("push endpoint must not be empty"))
+	}
+	if o.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("invalid push interval '%s', must be positive", o.Interval))
+	}
+	if o.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("invalid push timeout '%s', must be positive", o.Timeout))
+	}
+
+	return errs
+}
+
+// NewOptions 创建具有默认值的指标选项 (creates metrics options with default values)
+func NewOptions() *Options {
+	return &Options{
+		Namespace:       "",                                  // 默认无前缀 (No prefix by default)
+		RuntimeStats:    false,                               // 默认关闭运行时采集 (Runtime stats disabled by default)
+		RuntimeInterval: 15 * time.Second,                    // 与常见抓取周期一致 (Matches a common scrape interval)
+		DurationBuckets: slices.Clone(prometheus.DefBuckets), // 5ms 到 10s (5ms to 10s)
+		Push:            NewPushOptions(),                    // 默认不推送 (No pushing by default)
+	}
+}
+
+// Validate 验证指标选项是否有效。
+// (Validate validates if the metrics options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.Namespace != "" && !namespacePattern.MatchString(o.Namespace) {
+		errs = append(errs, fmt.Errorf("invalid namespace '%s', must match %s", o.Namespace, namespacePattern))
+	}
+
+	if o.RuntimeInterval <= 0 {
+		errs = append(errs, fmt.Errorf("invalid runtime interval '%s', must be positive", o.RuntimeInterval))
+	}
+
+	if len(o.DurationBuckets) == 0 {
+		errs = append(errs, fmt.Errorf("duration buckets must not be empty"))
+	}
+	for i := 1; i < len(o.DurationBuckets); i++ {
+		if o.DurationBuckets[i] <= o.DurationBuckets[i-1] {
+			errs = append(errs, fmt.Errorf("invalid duration buckets %v, must be strictly increasing", o.DurationBuckets))
+			break
+		}
+	}
+
+	if o.Push != nil {
+		errs = append(errs, o.Push.Validate()...)
+	}
+
+	return errs
+}
+
+// Option 是配置指标组件（运行时采集器、HTTP 中间件、gRPC 拦截器、消息消费者指标和指标工具）的函数类型。
+// (Option is a function type for configuring metrics components: runtime collector, HTTP middleware, gRPC interceptors, consumer metrics and instruments.)
+type Option func(*settings)
+
+// RuntimeCollectorOption 是 Option 的别名，为兼容保留。
+// (RuntimeCollectorOption is an alias of Option, kept for compatibility.)
+type RuntimeCollectorOption = Option
+
+// settings 保存由 Option 设置的值。(settings holds the values set by Options.)
+type settings struct {
+	registry  *Registry
+	routeFunc RouteFunc
+	logger    log.Logger
+	buckets   []float64
+}
+
+// newSettings 应用选项并填充默认值。(newSettings applies the options and fills in defaults.)
+func newSettings(opts []Option) *settings {
+	s := &settings{registry: Default(), logger: log.Std()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithRegistry 设置发布指标的注册表，默认使用 Default()。
+// (WithRegistry sets the registry metrics are published to; Default() is used otherwise.)
+func WithRegistry(reg *Registry) Option {
+	return func(s *settings) {
+		s.registry = reg
+	}
+}
+
+// WithRouteFunc 设置 HTTP 中间件提取路由标签的函数，默认使用 DefaultRoute。
+// (WithRouteFunc sets the function the HTTP middleware uses to derive the route label; DefaultRoute is used otherwise.)
+func WithRouteFunc(fn RouteFunc) Option {
+	return func(s *settings) {
+		s.routeFunc = fn
+	}
+}
+
+// WithBuckets 设置 NewHistogram 的桶上界，必须严格递增；默认使用 Options.DurationBuckets。
+// (WithBuckets sets the bucket upper bounds of NewHistogram, which must be strictly increasing; Options.DurationBuckets is used otherwise.)
+func WithBuckets(buckets ...float64) Option {
+	return func(s *settings) {
+		s.buckets = buckets
+	}
+}
+
+// WithLogger 设置 Pusher 报告周期推送失败所用的 logger，默认使用 log.Std()。
+// (WithLogger sets the logger the Pusher reports periodic push failures to; log.Std() is used otherwise.)
+func WithLogger(logger log.Logger) Option {
+	return func(s *settings) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
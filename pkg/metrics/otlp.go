@@ -0,0 +1,164 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"context"
+	"math"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// scopeName 是推送的 OTLP 指标的 instrumentation scope。(scopeName is the instrumentation scope of pushed OTLP metrics.)
+const scopeName = "github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+
+// otlpExporter 采集注册表并将结果转换为 OTLP 累积指标后导出。
+// (otlpExporter gathers the registry and exports the result as cumulative OTLP metrics.)
+type otlpExporter struct {
+	registry *Registry
+	exporter *otlpmetricgrpc.Exporter
+	resource *resource.Resource
+	start    time.Time
+}
+
+func newOTLPExporter(opts *PushOptions, reg *Registry) (*otlpExporter, error) {
+	grpcOpts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(opts.Endpoint),
+		otlpmetricgrpc.WithTimeout(opts.Timeout),
+	}
+	if opts.Insecure {
+		grpcOpts = append(grpcOpts, otlpmetricgrpc.WithInsecure())
+	}
+	if len(opts.Headers) > 0 {
+		grpcOpts = append(grpcOpts, otlpmetricgrpc.WithHeaders(opts.Headers))
+	}
+	exporter, err := otlpmetricgrpc.New(context.Background(), grpcOpts...)
+	if err != nil {
+		return nil, lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to create otlp metrics exporter"), lmccerrors.ErrMetricsPush)
+	}
+
+	res := resource.Default()
+	if opts.Job != "" {
+		if merged, err := resource.Merge(res, resource.NewSchemaless(attribute.String("service.name", opts.Job))); err == nil {
+			res = merged
+		}
+	}
+	return &otlpExporter{registry: reg, exporter: exporter, resource: res, start: time.Now()}, nil
+}
+
+func (e *otlpExporter) push(ctx context.Context) error {
+	families, err := e.registry.Gather()
+	if err != nil {
+		return lmccerrors.Wrap(err, "failed to gather metrics")
+	}
+	return e.exporter.Export(ctx, &metricdata.ResourceMetrics{
+		Resource: e.resource,
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope:   instrumentation.Scope{Name: scopeName},
+			Metrics: convertFamilies(families, e.start, time.Now()),
+		}},
+	})
+}
+
+func (e *otlpExporter) shutdown(ctx context.Context) error {
+	return e.exporter.Shutdown(ctx)
+}
+
+// convertFamilies 将 Prometheus 指标族转换为 OTLP 指标：计数器为单调累积 Sum，仪表和无类型指标为 Gauge，
+// 直方图的累积桶计数被转换为逐桶计数。
+// (convertFamilies converts Prometheus metric families to OTLP metrics: counters become monotonic cumulative Sums,
+// gauges and untyped metrics become Gauges, and cumulative histogram bucket counts are converted to per-bucket counts.)
+func convertFamilies(families []*dto.MetricFamily, start, now time.Time) []metricdata.Metrics {
+	out := make([]metricdata.Metrics, 0, len(families))
+	for _, mf := range families {
+		m := metricdata.Metrics{Name: mf.GetName(), Description: mf.GetHelp()}
+		switch mf.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := metricdata.Sum[float64]{Temporality: metricdata.CumulativeTemporality, IsMonotonic: true}
+			for _, metric := range mf.GetMetric() {
+				sum.DataPoints = append(sum.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: labelSet(metric), StartTime: start, Time: now, Value: metric.GetCounter().GetValue(),
+				})
+			}
+			m.Data = sum
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			gauge := metricdata.Gauge[float64]{}
+			for _, metric := range mf.GetMetric() {
+				value := metric.GetGauge().GetValue()
+				if mf.GetType() == dto.MetricType_UNTYPED {
+					value = metric.GetUntyped().GetValue()
+				}
+				gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: labelSet(metric), Time: now, Value: value,
+				})
+			}
+			m.Data = gauge
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			hist := metricdata.Histogram[float64]{Temporality: metricdata.CumulativeTemporality}
+			for _, metric := range mf.GetMetric() {
+				hist.DataPoints = append(hist.DataPoints, histogramPoint(metric, start, now))
+			}
+			m.Data = hist
+		case dto.MetricType_SUMMARY:
+			summary := metricdata.Summary{}
+			for _, metric := range mf.GetMetric() {
+				s := metric.GetSummary()
+				point := metricdata.SummaryDataPoint{
+					Attributes: labelSet(metric), StartTime: start, Time: now,
+					Count: s.GetSampleCount(), Sum: s.GetSampleSum(),
+				}
+				for _, q := range s.GetQuantile() {
+					point.QuantileValues = append(point.QuantileValues, metricdata.QuantileValue{Quantile: q.GetQuantile(), Value: q.GetValue()})
+				}
+				summary.DataPoints = append(summary.DataPoints, point)
+			}
+			m.Data = summary
+		default:
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// histogramPoint 转换一个直方图样本。Prometheus 的桶计数是累积的且可能包含 +Inf 桶，OTLP 需要逐桶计数外加一个溢出桶。
+// (histogramPoint converts one histogram sample. Prometheus bucket counts are cumulative and may include a +Inf bucket,
+// while OTLP needs per-bucket counts plus an overflow bucket.)
+func histogramPoint(metric *dto.Metric, start, now time.Time) metricdata.HistogramDataPoint[float64] {
+	h := metric.GetHistogram()
+	point := metricdata.HistogramDataPoint[float64]{
+		Attributes: labelSet(metric), StartTime: start, Time: now,
+		Count: h.GetSampleCount(), Sum: h.GetSampleSum(),
+	}
+	var previous uint64
+	for _, b := range h.GetBucket() {
+		if math.IsInf(b.GetUpperBound(), +1) {
+			break
+		}
+		point.Bounds = append(point.Bounds, b.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, b.GetCumulativeCount()-previous)
+		previous = b.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, h.GetSampleCount()-previous)
+	return point
+}
+
+// labelSet 将样本的标签转换为属性集。(labelSet converts the labels of a sample to an attribute set.)
+func labelSet(metric *dto.Metric) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(metric.GetLabel()))
+	for _, label := range metric.GetLabel() {
+		kvs = append(kvs, attribute.String(label.GetName(), label.GetValue()))
+	}
+	return attribute.NewSet(kvs...)
+}
@@ -0,0 +1,168 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for pushing metrics to a Pushgateway and over OTLP.
+ */
+
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectorpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+)
+
+// TestPusher_Pushgateway tests pushing to a Pushgateway with grouping labels and headers.
+// (TestPusher_Pushgateway 测试带分组标签和请求头推送到 Pushgateway。)
+func TestPusher_Pushgateway(t *testing.T) {
+	var mu sync.Mutex
+	var method, path, auth, body string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		method, path, auth, body = r.Method, r.URL.Path, r.Header.Get("Authorization"), string(data)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	reg := metrics.NewRegistry()
+	processed := prometheus.NewCounter(prometheus.CounterOpts{Name: "batch_records_processed_total", Help: "Processed records."})
+	reg.MustRegister(processed)
+	processed.Add(42)
+
+	opts := metrics.NewOptions()
+	opts.Push.Exporter = metrics.ExporterPushgateway
+	opts.Push.Endpoint = gateway.URL
+	opts.Push.Job = "nightly-import"
+	opts.Push.Grouping = map[string]string{"instance": "batch-01"}
+	opts.Push.Headers = map[string]string{"Authorization": "Bearer token"}
+	pusher, err := metrics.NewPusher(opts, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+	require.True(t, pusher.Enabled())
+
+	require.NoError(t, pusher.Push(context.Background()))
+	mu.Lock()
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/nightly-import/instance/batch-01", path)
+	assert.Equal(t, "Bearer token", auth)
+	assert.NotEmpty(t, body)
+	mu.Unlock()
+
+	gateway.Close()
+	err = pusher.Stop(context.Background())
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrMetricsPush))
+}
+
+// metricsCollector 是记录收到的导出请求的 OTLP 收集器。(metricsCollector is an OTLP collector recording the export requests it receives.)
+type metricsCollector struct {
+	collectorpb.UnimplementedMetricsServiceServer
+	requests chan *collectorpb.ExportMetricsServiceRequest
+}
+
+func (c *metricsCollector) Export(_ context.Context, req *collectorpb.ExportMetricsServiceRequest) (*collectorpb.ExportMetricsServiceResponse, error) {
+	c.requests <- req
+	return &collectorpb.ExportMetricsServiceResponse{}, nil
+}
+
+// TestPusher_OTLP tests periodic OTLP pushes and the conversion of counters and histograms.
+// (TestPusher_OTLP 测试周期 OTLP 推送以及计数器和直方图的转换。)
+func TestPusher_OTLP(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	collector := &metricsCollector{requests: make(chan *collectorpb.ExportMetricsServiceRequest, 16)}
+	srv := grpc.NewServer()
+	collectorpb.RegisterMetricsServiceServer(srv, collector)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	reg := metrics.NewRegistry()
+	jobs := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "jobs_total", Help: "Jobs."}, []string{"result"})
+	latency := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "job_seconds", Help: "Job latency.", Buckets: []float64{1, 5}})
+	reg.MustRegister(jobs, latency)
+	jobs.WithLabelValues("ok").Add(3)
+	for _, v := range []float64{0.5, 2, 3, 10} {
+		latency.Observe(v)
+	}
+
+	opts := metrics.NewOptions()
+	opts.Push.Exporter = metrics.ExporterOTLP
+	opts.Push.Endpoint = lis.Addr().String()
+	opts.Push.Insecure = true
+	opts.Push.Job = "report-cli"
+	opts.Push.Interval = 20 * time.Millisecond
+	pusher, err := metrics.NewPusher(opts, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+	require.NoError(t, pusher.Start(context.Background()))
+
+	var req *collectorpb.ExportMetricsServiceRequest
+	select {
+	case req = <-collector.requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no periodic push received")
+	}
+	require.NoError(t, pusher.Stop(context.Background()))
+
+	rm := req.GetResourceMetrics()[0]
+	var service string
+	for _, kv := range rm.GetResource().GetAttributes() {
+		if kv.GetKey() == "service.name" {
+			service = kv.GetValue().GetStringValue()
+		}
+	}
+	assert.Equal(t, "report-cli", service)
+
+	byName := map[string]*metricspb.Metric{}
+	for _, m := range rm.GetScopeMetrics()[0].GetMetrics() {
+		byName[m.GetName()] = m
+	}
+	sum := byName["jobs_total"].GetSum()
+	require.NotNil(t, sum)
+	assert.True(t, sum.GetIsMonotonic())
+	assert.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.GetAggregationTemporality())
+	assert.Equal(t, 3.0, sum.GetDataPoints()[0].GetAsDouble())
+	assert.Equal(t, "result", sum.GetDataPoints()[0].GetAttributes()[0].GetKey())
+
+	hist := byName["job_seconds"].GetHistogram().GetDataPoints()[0]
+	assert.Equal(t, []float64{1, 5}, hist.GetExplicitBounds())
+	assert.Equal(t, []uint64{1, 2, 1}, hist.GetBucketCounts())
+	assert.Equal(t, uint64(4), hist.GetCount())
+	assert.Equal(t, 15.5, hist.GetSum())
+}
+
+// TestPushOptions_Validate tests push option validation and the disabled default.
+// (TestPushOptions_Validate 测试推送选项校验和默认关闭。)
+func TestPushOptions_Validate(t *testing.T) {
+	pusher, err := metrics.NewPusher(nil)
+	require.NoError(t, err)
+	assert.False(t, pusher.Enabled())
+	assert.NoError(t, pusher.Push(context.Background()))
+	assert.NoError(t, pusher.Stop(context.Background()))
+
+	opts := metrics.NewOptions()
+	opts.Push.Exporter = "statsd"
+	_, err = metrics.NewPusher(opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrMetricsOptionInvalid))
+	assert.True(t, strings.Contains(err.Error(), "invalid push exporter 'statsd'"))
+
+	opts.Push.Exporter = metrics.ExporterPushgateway
+	errs := opts.Push.Validate()
+	assert.Len(t, errs, 2, "job and endpoint are required")
+}
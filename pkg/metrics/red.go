@@ -0,0 +1,148 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 错误类别，由 Coder 的 HTTP 状态码或响应状态推导，基数固定。
+// (Error categories, derived from a Coder's HTTP status or the response status; their cardinality is fixed.)
+const (
+	CategoryBadRequest   = "bad_request"
+	CategoryUnauthorized = "unauthorized"
+	CategoryForbidden    = "forbidden"
+	CategoryNotFound     = "not_found"
+	CategoryConflict     = "conflict"
+	CategoryRateLimited  = "rate_limited"
+	CategoryTimeout      = "timeout"
+	CategoryUnavailable  = "unavailable"
+	CategoryClient       = "client"
+	CategoryInternal     = "internal"
+	CategoryUnknown      = "unknown"
+)
+
+// ErrorCategory 返回 err 的错误类别：带 Coder 的错误按 Coder 的 HTTP 状态码归类，其他错误为 CategoryUnknown，nil 返回空字符串。
+// (ErrorCategory returns the error category of err: errors carrying a Coder are classified by the Coder's HTTP status,
+// other errors are CategoryUnknown, and nil yields an empty string.)
+func ErrorCategory(err error) string {
+	if err == nil {
+		return ""
+	}
+	coder := lmccerrors.GetCoder(err)
+	if coder == nil || lmccerrors.IsUnknownCoder(coder) {
+		return CategoryUnknown
+	}
+	if category := StatusCategory(coder.HTTPStatus()); category != "" {
+		return category
+	}
+	return CategoryUnknown
+}
+
+// StatusCategory 返回 HTTP 状态码对应的错误类别，非错误状态返回空字符串。
+// (StatusCategory returns the error category of an HTTP status code, or an empty string for non-error statuses.)
+func StatusCategory(status int) string {
+	switch {
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return CategoryBadRequest
+	case status == http.StatusUnauthorized:
+		return CategoryUnauthorized
+	case status == http.StatusForbidden:
+		return CategoryForbidden
+	case status == http.StatusNotFound:
+		return CategoryNotFound
+	case status == http.StatusConflict:
+		return CategoryConflict
+	case status == http.StatusTooManyRequests:
+		return CategoryRateLimited
+	case status == http.StatusRequestTimeout, status == http.StatusGatewayTimeout:
+		return CategoryTimeout
+	case status == http.StatusServiceUnavailable:
+		return CategoryUnavailable
+	case status >= 400 && status < 500:
+		return CategoryClient
+	case status >= 500:
+		return CategoryInternal
+	default:
+		return ""
+	}
+}
+
+// red 是一组 RED（速率、错误、耗时）指标。
+// (red is a set of RED metrics: rate, errors and duration.)
+type red struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// newRED 创建并注册 <namespace>_<subsystem>_requests_total、_request_errors_total 和 _request_duration_seconds。
+// 已注册的同名指标会被复用，因此同一注册表上可以创建多个中间件。
+// (newRED creates and registers <namespace>_<subsystem>_requests_total, _request_errors_total and _request_duration_seconds.
+// Metrics already registered under the same name are reused, so several middlewares can share a registry.)
+func newRED(opts *Options, reg *Registry, subsystem string, labels []string, statusLabel string) (*red, error) {
+	r := &red{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace, Subsystem: subsystem, Name: "requests_total",
+			Help: "Number of handled requests.",
+		}, append(labels[:len(labels):len(labels)], statusLabel)),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace, Subsystem: subsystem, Name: "request_errors_total",
+			Help: "Number of failed requests by error category.",
+		}, append(labels[:len(labels):len(labels)], "category")),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace, Subsystem: subsystem, Name: "request_duration_seconds",
+			Help: "Request duration in seconds.", Buckets: opts.DurationBuckets,
+		}, labels),
+	}
+
+	var err error
+	if r.requests, err = register(reg, r.requests); err != nil {
+		return nil, err
+	}
+	if r.errors, err = register(reg, r.errors); err != nil {
+		return nil, err
+	}
+	if r.duration, err = register(reg, r.duration); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// observe 记录一次请求。category 为空表示请求成功。
+// (observe records one request. An empty category means the request succeeded.)
+func (r *red) observe(labels []string, status, category string, elapsed time.Duration) {
+	r.requests.WithLabelValues(append(labels[:len(labels):len(labels)], status)...).Inc()
+	if category != "" {
+		r.errors.WithLabelValues(append(labels[:len(labels):len(labels)], category)...).Inc()
+	}
+	r.duration.WithLabelValues(labels...).Observe(elapsed.Seconds())
+}
+
+// register 注册 collector；已存在同名指标时返回已注册的实例。
+// (register registers collector; when a metric with the same name exists, the registered instance is returned.)
+func register[C prometheus.Collector](reg *Registry, collector C) (C, error) {
+	err := reg.Register(collector)
+	if err == nil {
+		return collector, nil
+	}
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		if existing, ok := are.ExistingCollector.(C); ok {
+			return existing, nil
+		}
+	}
+	return collector, lmccerrors.WithCode(
+		lmccerrors.Wrap(err, "failed to register metrics"),
+		lmccerrors.ErrMetricsRegister,
+	)
+}
@@ -0,0 +1,40 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry 是 SDK 指标的注册表，基于 Prometheus 注册表。
+// (Registry is the registry for SDK metrics, backed by a Prometheus registry.)
+type Registry struct {
+	*prometheus.Registry
+}
+
+// NewRegistry 创建空的注册表。
+// (NewRegistry creates an empty registry.)
+func NewRegistry() *Registry {
+	return &Registry{Registry: prometheus.NewRegistry()}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default 返回包级默认注册表。
+// (Default returns the package-level default registry.)
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Handler 返回以 Prometheus 文本格式暴露注册表的 HTTP 处理器，可直接传给 debug.WithMetricsHandler。
+// (Handler returns an HTTP handler exposing the registry in the Prometheus text format; it can be passed to debug.WithMetricsHandler.)
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.Registry, promhttp.HandlerOpts{})
+}
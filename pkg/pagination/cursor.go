@@ -0,0 +1,86 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// CursorCodec 将游标值编码为不透明的 URL 安全字符串。设置密钥后游标带有 HMAC 签名，
+// 可防止客户端篡改游标中的排序键。
+// (CursorCodec encodes cursor values as opaque URL-safe strings. With a key, cursors carry an HMAC
+// signature so clients cannot tamper with the sort keys inside.)
+type CursorCodec struct {
+	key []byte
+}
+
+// NewCursorCodec 创建游标编解码器，key 为空时不签名。
+// (NewCursorCodec creates a cursor codec; cursors are not signed when key is empty.)
+func NewCursorCodec(key []byte) *CursorCodec {
+	return &CursorCodec{key: key}
+}
+
+// defaultCodec 是不签名的编解码器。(defaultCodec is the unsigned codec.)
+var defaultCodec = NewCursorCodec(nil)
+
+// EncodeCursor 使用不签名的编解码器编码游标。(EncodeCursor encodes a cursor with the unsigned codec.)
+func EncodeCursor(v any) (string, error) {
+	return defaultCodec.Encode(v)
+}
+
+// DecodeCursor 使用不签名的编解码器解码游标。(DecodeCursor decodes a cursor with the unsigned codec.)
+func DecodeCursor(cursor string, v any) error {
+	return defaultCodec.Decode(cursor, v)
+}
+
+// Encode 将 v 编码为 JSON 后以 base64url 表示，带签名时追加 "." 与签名。
+// (Encode encodes v as base64url JSON, followed by "." and the signature when signing.)
+func (c *CursorCodec) Encode(v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to encode cursor"), lmccerrors.ErrPaginationCursorInvalid)
+	}
+	cursor := base64.RawURLEncoding.EncodeToString(payload)
+	if len(c.key) > 0 {
+		cursor += "." + base64.RawURLEncoding.EncodeToString(c.sign(payload))
+	}
+	return cursor, nil
+}
+
+// Decode 校验并解码游标到 v。(Decode verifies and decodes the cursor into v.)
+func (c *CursorCodec) Decode(cursor string, v any) error {
+	encoded, sig, signed := strings.Cut(cursor, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrPaginationCursorInvalid, "malformed cursor")
+	}
+
+	if len(c.key) > 0 {
+		got, err := base64.RawURLEncoding.DecodeString(sig)
+		if !signed || err != nil || !hmac.Equal(got, c.sign(payload)) {
+			return lmccerrors.NewWithCode(lmccerrors.ErrPaginationCursorInvalid, "cursor signature mismatch")
+		}
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrPaginationCursorInvalid, "malformed cursor")
+	}
+	return nil
+}
+
+// sign 计算负载的 HMAC-SHA256 签名。(sign computes the HMAC-SHA256 signature of the payload.)
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,44 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package pagination provides cursor and offset pagination helpers so list endpoints
+across services parse parameters and report metadata the same way.
+(pagination 包提供游标分页和偏移分页的辅助工具，使各服务的列表接口以相同方式解析参数并返回元数据。)
+
+Requests use the limit, page, offset and cursor query parameters; the parameter names,
+default limit and maximum limit are configurable through Options.
+(请求使用 limit、page、offset 和 cursor 查询参数；参数名、默认条数和最大条数可通过 Options 配置。)
+
+Offset pagination:
+(偏移分页：)
+
+	req, err := pagination.Parse(r)
+	if err != nil {
+		// 400, ErrPaginationInvalid
+	}
+	users, total := repo.List(ctx, req.Offset, req.Limit)
+	writeJSON(w, pagination.OffsetPage(req, users, total))
+
+Cursor pagination, querying one extra row to detect further pages:
+(游标分页，多查询一条数据以判断是否还有下一页：)
+
+	var after struct{ ID int64 `json:"id"` }
+	if req.Cursor != "" {
+		if err := codec.Decode(req.Cursor, &after); err != nil {
+			// 400, ErrPaginationCursorInvalid
+		}
+	}
+	rows := repo.ListAfter(ctx, after.ID, req.Limit+1)
+	page, err := pagination.CursorPage(req, rows, func(last User) (string, error) {
+		return codec.Encode(map[string]int64{"id": last.ID})
+	})
+
+The pages are plain structs with JSON tags, so a service writes them with its own response
+encoding. An integration with pkg/apikit is deferred until that package exists in the SDK.
+(分页结果是带 JSON 标签的普通结构体，服务可使用自己的响应编码写出。与 pkg/apikit 的集成将在该包加入 SDK 后再进行。)
+*/
+package pagination
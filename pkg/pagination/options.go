@@ -0,0 +1,63 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package pagination
+
+import "fmt"
+
+// Options 定义了分页参数解析的配置选项。
+// (Options defines configuration options for parsing pagination parameters.)
+// 通常作为应用配置中的 "pagination" 节加载，使各服务的列表接口行为一致。
+// (It is typically loaded as the "pagination" section of the application configuration so list endpoints behave consistently.)
+type Options struct {
+	// DefaultLimit 是请求未指定 limit 时的每页条数。
+	// (DefaultLimit is the page size used when a request does not specify a limit.)
+	DefaultLimit int `json:"default-limit" mapstructure:"default-limit"`
+
+	// MaxLimit 是允许的最大每页条数，超出时截断为该值。
+	// (MaxLimit is the largest allowed page size; larger limits are clamped to it.)
+	MaxLimit int `json:"max-limit" mapstructure:"max-limit"`
+
+	// LimitParam 是每页条数的查询参数名。(LimitParam is the query parameter name of the page size.)
+	LimitParam string `json:"limit-param" mapstructure:"limit-param"`
+
+	// CursorParam 是游标的查询参数名。(CursorParam is the query parameter name of the cursor.)
+	CursorParam string `json:"cursor-param" mapstructure:"cursor-param"`
+
+	// PageParam 是页码（从 1 开始）的查询参数名。(PageParam is the query parameter name of the 1-based page number.)
+	PageParam string `json:"page-param" mapstructure:"page-param"`
+
+	// OffsetParam 是偏移量的查询参数名。(OffsetParam is the query parameter name of the offset.)
+	OffsetParam string `json:"offset-param" mapstructure:"offset-param"`
+}
+
+// NewOptions 创建具有默认值的分页选项 (creates pagination options with default values)
+func NewOptions() *Options {
+	return &Options{
+		DefaultLimit: 20,
+		MaxLimit:     100,
+		LimitParam:   "limit",
+		CursorParam:  "cursor",
+		PageParam:    "page",
+		OffsetParam:  "offset",
+	}
+}
+
+// Validate 验证分页选项是否有效。
+// (Validate validates if the pagination options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.DefaultLimit <= 0 {
+		errs = append(errs, fmt.Errorf("invalid pagination default limit %d, must be positive", o.DefaultLimit))
+	}
+
+	if o.MaxLimit < o.DefaultLimit {
+		errs = append(errs, fmt.Errorf("invalid pagination max limit %d, must not be less than the default limit %d", o.MaxLimit, o.DefaultLimit))
+	}
+
+	return errs
+}
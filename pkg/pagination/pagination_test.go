@@ -0,0 +1,119 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for pagination request parsing, response metadata and cursors.
+ */
+
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parse parses a raw query string with the given parser.
+// (parse 使用给定解析器解析原始查询字符串。)
+func parse(t *testing.T, p *pagination.Parser, query string) (pagination.Request, error) {
+	t.Helper()
+	values, err := url.ParseQuery(query)
+	require.NoError(t, err)
+	return p.ParseValues(values)
+}
+
+// TestParseValues tests parsing of limit, page, offset and cursor parameters.
+// (TestParseValues 测试 limit、page、offset 和 cursor 参数的解析。)
+func TestParseValues(t *testing.T) {
+	p, err := pagination.NewParser(nil)
+	require.NoError(t, err)
+
+	req, err := parse(t, p, "")
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Request{Mode: pagination.ModeOffset, Limit: 20, Page: 1}, req)
+
+	req, err = parse(t, p, "limit=10&page=3")
+	require.NoError(t, err)
+	assert.Equal(t, 20, req.Offset)
+	assert.Equal(t, 3, req.Page)
+
+	req, err = parse(t, p, "limit=1000&offset=250&page=9")
+	require.NoError(t, err)
+	assert.Equal(t, 100, req.Limit, "limit is clamped to the maximum")
+	assert.Equal(t, 250, req.Offset, "offset wins over page")
+	assert.Equal(t, 3, req.Page)
+
+	req, err = parse(t, p, "cursor=abc&page=2")
+	require.NoError(t, err)
+	assert.Equal(t, pagination.ModeCursor, req.Mode)
+	assert.Equal(t, "abc", req.Cursor)
+
+	for _, q := range []string{"limit=0", "limit=x", "page=0", "offset=-1", "page=9223372036854775807"} {
+		_, err := parse(t, p, q)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrPaginationInvalid), q)
+	}
+
+	_, err = pagination.NewParser(&pagination.Options{DefaultLimit: 50, MaxLimit: 10})
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrPaginationInvalid))
+
+	custom, err := pagination.NewParser(&pagination.Options{DefaultLimit: 5, MaxLimit: 5, LimitParam: "per_page"})
+	require.NoError(t, err)
+	req, err = parse(t, custom, "per_page=3&page=2")
+	require.NoError(t, err)
+	assert.Equal(t, 3, req.Offset)
+}
+
+// TestOffsetPage tests offset response metadata with known and unknown totals.
+// (TestOffsetPage 测试总数已知和未知时的偏移分页元数据。)
+func TestOffsetPage(t *testing.T) {
+	req := pagination.Request{Mode: pagination.ModeOffset, Limit: 2, Offset: 2, Page: 2}
+
+	page := pagination.OffsetPage(req, []int{3, 4}, 5)
+	assert.True(t, page.Pagination.HasMore)
+	assert.Equal(t, int64(3), *page.Pagination.TotalPages)
+
+	page = pagination.OffsetPage(req, []int{3}, -1)
+	assert.False(t, page.Pagination.HasMore)
+	assert.Nil(t, page.Pagination.Total)
+
+	data, err := json.Marshal(pagination.OffsetPage[int](req, nil, 0))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[],"pagination":{"limit":2,"count":0,"has_more":false,"offset":2,"page":2,"total":0,"total_pages":0}}`, string(data))
+}
+
+// TestCursorPage tests cursor pagination with the limit+1 pattern and signed cursors.
+// (TestCursorPage 测试 limit+1 模式的游标分页和签名游标。)
+func TestCursorPage(t *testing.T) {
+	type key struct {
+		ID int `json:"id"`
+	}
+	codec := pagination.NewCursorCodec([]byte("secret"))
+	req := pagination.Request{Mode: pagination.ModeCursor, Limit: 2}
+
+	page, err := pagination.CursorPage(req, []int{1, 2, 3}, func(last int) (string, error) {
+		return codec.Encode(key{ID: last})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, page.Items)
+	assert.True(t, page.Pagination.HasMore)
+
+	var got key
+	require.NoError(t, codec.Decode(page.Pagination.NextCursor, &got))
+	assert.Equal(t, 2, got.ID)
+
+	unsigned, err := pagination.EncodeCursor(key{ID: 2})
+	require.NoError(t, err)
+	assert.True(t, lmccerrors.IsCode(codec.Decode(unsigned, &got), lmccerrors.ErrPaginationCursorInvalid), "unsigned cursors are rejected")
+	assert.True(t, lmccerrors.IsCode(codec.Decode(page.Pagination.NextCursor+"x", &got), lmccerrors.ErrPaginationCursorInvalid))
+	assert.True(t, lmccerrors.IsCode(pagination.DecodeCursor("%%%", &got), lmccerrors.ErrPaginationCursorInvalid))
+
+	page, err = pagination.CursorPage(req, []int{1}, nil)
+	require.NoError(t, err)
+	assert.False(t, page.Pagination.HasMore)
+	assert.Empty(t, page.Pagination.NextCursor)
+}
@@ -0,0 +1,135 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package pagination
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// Mode 是分页方式。(Mode is the pagination style.)
+type Mode string
+
+const (
+	// ModeOffset 表示基于页码或偏移量的分页。(ModeOffset is page- or offset-based pagination.)
+	ModeOffset Mode = "offset"
+	// ModeCursor 表示基于游标的分页。(ModeCursor is cursor-based pagination.)
+	ModeCursor Mode = "cursor"
+)
+
+// Request 是从请求中解析出的分页参数。
+// (Request holds the pagination parameters parsed from a request.)
+type Request struct {
+	// Mode 是分页方式，请求带有游标时为 ModeCursor。(Mode is the pagination style; ModeCursor when a cursor is present.)
+	Mode Mode
+	// Limit 是每页条数，已按选项校正。(Limit is the page size, already clamped by the options.)
+	Limit int
+	// Offset 是偏移量，由 offset 或 page 计算得出。(Offset is the offset, taken from offset or computed from page.)
+	Offset int
+	// Page 是从 1 开始的页码。(Page is the 1-based page number.)
+	Page int
+	// Cursor 是客户端传入的不透明游标。(Cursor is the opaque cursor sent by the client.)
+	Cursor string
+}
+
+// Parser 按统一规则解析分页参数。
+// (Parser parses pagination parameters following uniform rules.)
+type Parser struct {
+	opts *Options
+}
+
+// NewParser 根据选项创建解析器。(NewParser creates a parser from options.)
+func NewParser(opts *Options) (*Parser, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid pagination options"),
+			lmccerrors.ErrPaginationInvalid,
+		)
+	}
+	defaults := NewOptions()
+	p := &Parser{opts: &Options{
+		DefaultLimit: opts.DefaultLimit,
+		MaxLimit:     opts.MaxLimit,
+		LimitParam:   orDefault(opts.LimitParam, defaults.LimitParam),
+		CursorParam:  orDefault(opts.CursorParam, defaults.CursorParam),
+		PageParam:    orDefault(opts.PageParam, defaults.PageParam),
+		OffsetParam:  orDefault(opts.OffsetParam, defaults.OffsetParam),
+	}}
+	return p, nil
+}
+
+// defaultParser 是使用默认选项的解析器。(defaultParser is the parser with default options.)
+var defaultParser, _ = NewParser(nil)
+
+// Parse 使用默认选项解析请求的分页参数。(Parse parses the request's pagination parameters using default options.)
+func Parse(r *http.Request) (Request, error) {
+	return defaultParser.Parse(r)
+}
+
+// Parse 解析请求查询字符串中的分页参数。(Parse parses the pagination parameters in the request's query string.)
+func (p *Parser) Parse(r *http.Request) (Request, error) {
+	return p.ParseValues(r.URL.Query())
+}
+
+// ParseValues 解析查询参数中的分页参数。同时提供 cursor 与 page/offset 时优先使用 cursor，
+// 同时提供 page 与 offset 时优先使用 offset。
+// (ParseValues parses pagination parameters from query values. A cursor takes precedence over page/offset,
+// and offset takes precedence over page.)
+func (p *Parser) ParseValues(values url.Values) (Request, error) {
+	req := Request{Mode: ModeOffset, Limit: p.opts.DefaultLimit, Page: 1}
+
+	if v := values.Get(p.opts.LimitParam); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return Request{}, lmccerrors.ErrorfWithCode(lmccerrors.ErrPaginationInvalid, "%s must be a positive integer, got '%s'", p.opts.LimitParam, v)
+		}
+		req.Limit = min(limit, p.opts.MaxLimit)
+	}
+
+	if cursor := values.Get(p.opts.CursorParam); cursor != "" {
+		req.Mode = ModeCursor
+		req.Cursor = cursor
+		req.Page = 0
+		return req, nil
+	}
+
+	if v := values.Get(p.opts.OffsetParam); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return Request{}, lmccerrors.ErrorfWithCode(lmccerrors.ErrPaginationInvalid, "%s must be a non-negative integer, got '%s'", p.opts.OffsetParam, v)
+		}
+		req.Offset = offset
+		req.Page = offset/req.Limit + 1
+		return req, nil
+	}
+
+	if v := values.Get(p.opts.PageParam); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page <= 0 || page-1 > math.MaxInt/req.Limit {
+			return Request{}, lmccerrors.ErrorfWithCode(lmccerrors.ErrPaginationInvalid, "%s must be a positive integer, got '%s'", p.opts.PageParam, v)
+		}
+		req.Page = page
+		req.Offset = (page - 1) * req.Limit
+	}
+	return req, nil
+}
+
+// orDefault 在 s 为空时返回 def。(orDefault returns def when s is empty.)
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
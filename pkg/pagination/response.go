@@ -0,0 +1,97 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package pagination
+
+// Meta 是列表响应中的分页元数据，偏移分页与游标分页共用。
+// (Meta is the pagination metadata of a list response, shared by offset and cursor pagination.)
+type Meta struct {
+	// Limit 是每页条数。(Limit is the page size.)
+	Limit int `json:"limit"`
+	// Count 是本页返回的条数。(Count is the number of items in this page.)
+	Count int `json:"count"`
+	// HasMore 表示是否还有下一页。(HasMore reports whether another page follows.)
+	HasMore bool `json:"has_more"`
+
+	// Offset 是本页的偏移量，仅用于偏移分页。(Offset is the offset of this page, offset pagination only.)
+	Offset *int `json:"offset,omitempty"`
+	// Page 是从 1 开始的页码，仅用于偏移分页。(Page is the 1-based page number, offset pagination only.)
+	Page *int `json:"page,omitempty"`
+	// Total 是总条数，未知时省略。(Total is the total number of items, omitted when unknown.)
+	Total *int64 `json:"total,omitempty"`
+	// TotalPages 是总页数，Total 已知时计算。(TotalPages is the number of pages, computed when Total is known.)
+	TotalPages *int64 `json:"total_pages,omitempty"`
+
+	// NextCursor 是获取下一页的游标，仅用于游标分页。(NextCursor fetches the next page, cursor pagination only.)
+	NextCursor string `json:"next_cursor,omitempty"`
+	// PrevCursor 是获取上一页的游标，仅用于游标分页。(PrevCursor fetches the previous page, cursor pagination only.)
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// Page 是带分页元数据的列表响应。
+// (Page is a list response with pagination metadata.)
+type Page[T any] struct {
+	// Items 是本页的数据，始终编码为数组。(Items are the data of this page, always encoded as an array.)
+	Items []T `json:"items"`
+	// Pagination 是分页元数据。(Pagination is the pagination metadata.)
+	Pagination Meta `json:"pagination"`
+}
+
+// OffsetPage 构造偏移分页的响应。total 小于 0 表示总数未知，此时根据 items 是否填满一页推断 HasMore。
+// (OffsetPage builds an offset-paginated response. A negative total means unknown, in which case HasMore is
+// inferred from whether items fill the page.)
+func OffsetPage[T any](req Request, items []T, total int64) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	offset, page := req.Offset, max(req.Page, 1)
+	meta := Meta{
+		Limit:   req.Limit,
+		Count:   len(items),
+		Offset:  &offset,
+		Page:    &page,
+		HasMore: len(items) >= req.Limit,
+	}
+	if total >= 0 {
+		pages := int64(0)
+		if req.Limit > 0 {
+			pages = (total + int64(req.Limit) - 1) / int64(req.Limit)
+		}
+		meta.Total = &total
+		meta.TotalPages = &pages
+		meta.HasMore = int64(offset+len(items)) < total
+	}
+	return Page[T]{Items: items, Pagination: meta}
+}
+
+// CursorPage 构造游标分页的响应。items 应按 "多取一条" 的方式查询（limit+1 条），
+// 多出的一条会被截掉并用于判断 HasMore；nextCursor 根据本页最后一条数据生成。
+// (CursorPage builds a cursor-paginated response. Items should be queried with one extra row (limit+1);
+// the extra row is trimmed and used to set HasMore. nextCursor builds the cursor from the last item of the page.)
+func CursorPage[T any](req Request, items []T, nextCursor func(last T) (string, error)) (Page[T], error) {
+	items, hasMore := Trim(items, req.Limit)
+	meta := Meta{Limit: req.Limit, Count: len(items), HasMore: hasMore}
+	if hasMore && nextCursor != nil {
+		cursor, err := nextCursor(items[len(items)-1])
+		if err != nil {
+			return Page[T]{}, err
+		}
+		meta.NextCursor = cursor
+	}
+	return Page[T]{Items: items, Pagination: meta}, nil
+}
+
+// Trim 将按 limit+1 查询得到的结果截断为 limit 条，并返回是否还有更多数据。
+// (Trim cuts results queried with limit+1 rows down to limit and reports whether more data exists.)
+func Trim[T any](items []T, limit int) ([]T, bool) {
+	if items == nil {
+		return []T{}, false
+	}
+	if limit > 0 && len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
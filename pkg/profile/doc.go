@@ -0,0 +1,39 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package profile provides config-driven control over Go runtime profiling.
+(profile 包提供了由配置驱动的 Go 运行时性能分析控制。)
+
+Features:
+(特性：)
+
+  - pprof endpoints, either on a standalone listener (PprofAddr) or mounted via RegisterHandlers.
+    (pprof 端点，可运行在独立监听地址（PprofAddr）上，或通过 RegisterHandlers 挂载。)
+  - Capture to file on signal: SIGUSR1 writes a CPU profile, SIGUSR2 a heap snapshot.
+    (信号触发写入文件：SIGUSR1 写入 CPU 分析，SIGUSR2 写入堆快照。)
+  - Continuous profiling: periodic capture handed to an Exporter, e.g. HTTPExporter for Pyroscope.
+    (持续分析：周期性采集并交给 Exporter，例如用于 Pyroscope 的 HTTPExporter。)
+
+All profile events are logged through pkg/log.
+(所有分析事件均通过 pkg/log 记录。)
+
+	opts := profile.NewOptions()
+	opts.EnableSignals = true
+	opts.OutputDir = "/var/tmp/profiles"
+
+	p, err := profile.New(opts)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	if err := p.Start(ctx); err != nil {
+		// handle error (处理错误)
+	}
+	defer p.Stop(context.Background())
+
+	// kill -USR1 <pid>  -> /var/tmp/profiles/cpu-<time>-<pid>.pprof
+*/
+package profile
@@ -0,0 +1,96 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package profile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// Exporter 将持续分析采集到的数据发送到外部系统。
+// (Exporter sends continuously captured profiles to an external system.)
+type Exporter interface {
+	Export(ctx context.Context, p *Profile) error
+}
+
+// ExporterFunc 是函数形式的 Exporter。
+// (ExporterFunc is an Exporter implemented as a function.)
+type ExporterFunc func(ctx context.Context, p *Profile) error
+
+// Export 实现 Exporter 接口。(Export implements the Exporter interface.)
+func (f ExporterFunc) Export(ctx context.Context, p *Profile) error {
+	return f(ctx, p)
+}
+
+// HTTPExporter 以 POST 请求上传 pprof 数据，兼容 Pyroscope 的 /ingest 接口。
+// 查询参数包含 name、from、until（Unix 秒）、format=pprof 和 spyName=gospy，请求体为原始 pprof 数据。
+// (HTTPExporter uploads pprof data with POST requests, compatible with the Pyroscope /ingest API.)
+// (Query parameters carry name, from, until (Unix seconds), format=pprof and spyName=gospy; the body is the raw pprof data.)
+type HTTPExporter struct {
+	// URL 是上传地址，例如 http://pyroscope:4040/ingest。(URL is the upload address, e.g. http://pyroscope:4040/ingest.)
+	URL string
+	// AppName 是应用名称，分析类型会以 ".cpu" 等后缀追加。(AppName is the application name; the profile type is appended as ".cpu" etc.)
+	AppName string
+	// Headers 是附加的请求头，例如认证信息。(Headers are extra request headers, e.g. authentication.)
+	Headers map[string]string
+	// Client 是使用的 HTTP 客户端，为 nil 时使用 10 秒超时的默认客户端。
+	// (Client is the HTTP client to use; a default client with a 10s timeout is used when nil.)
+	Client *http.Client
+}
+
+// Export 上传一次分析数据。(Export uploads a single profile.)
+func (e *HTTPExporter) Export(ctx context.Context, p *Profile) error {
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "invalid exporter url"), lmccerrors.ErrProfileOptionInvalid)
+	}
+
+	until := p.Start.Add(p.Duration)
+	if p.Duration == 0 {
+		until = p.Start
+	}
+	q := u.Query()
+	q.Set("name", e.AppName+"."+p.Type)
+	q.Set("from", strconv.FormatInt(p.Start.Unix(), 10))
+	q.Set("until", strconv.FormatInt(until.Unix(), 10))
+	q.Set("format", "pprof")
+	q.Set("spyName", "gospy")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(p.Data))
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to build export request"), lmccerrors.ErrProfileCapture)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := e.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to export profile"), lmccerrors.ErrProfileCapture)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return lmccerrors.WithCode(fmt.Errorf("profile export returned status %d", resp.StatusCode), lmccerrors.ErrProfileCapture)
+	}
+	return nil
+}
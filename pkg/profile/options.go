@@ -0,0 +1,98 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package profile
+
+import (
+	"fmt"
+	"time"
+)
+
+// Options 定义了性能分析的配置选项，通常作为应用配置中的 "profile" 节加载。
+// (Options defines profiling configuration, typically loaded as the "profile" section of the application configuration.)
+type Options struct {
+	// PprofAddr 是独立 pprof 服务器的监听地址，为空时不启动。
+	// 已使用 pkg/debug 时通常留空，pprof 端点由调试服务器提供。
+	// (PprofAddr is the listen address of a standalone pprof server; none is started when empty.)
+	// (Leave it empty when pkg/debug is used, as the debug server already serves the pprof endpoints.)
+	PprofAddr string `json:"pprof-addr" mapstructure:"pprof-addr"`
+
+	// OutputDir 是按信号或手动采集的性能分析文件的输出目录。
+	// (OutputDir is the directory where profiles captured on signal or on demand are written.)
+	OutputDir string `json:"output-dir" mapstructure:"output-dir"`
+
+	// EnableSignals 启用信号触发的采集：SIGUSR1 采集 CPU 分析，SIGUSR2 采集堆快照（Windows 不支持）。
+	// (EnableSignals enables signal-triggered capture: SIGUSR1 captures a CPU profile, SIGUSR2 a heap snapshot (not supported on Windows).)
+	EnableSignals bool `json:"enable-signals" mapstructure:"enable-signals"`
+
+	// CPUDuration 是每次 CPU 分析的采样时长。
+	// (CPUDuration is the sampling duration of each CPU profile.)
+	CPUDuration time.Duration `json:"cpu-duration" mapstructure:"cpu-duration"`
+
+	// BlockProfileRate 设置 runtime.SetBlockProfileRate，0 表示不采集阻塞事件。
+	// (BlockProfileRate sets runtime.SetBlockProfileRate; 0 disables block profiling.)
+	BlockProfileRate int `json:"block-profile-rate" mapstructure:"block-profile-rate"`
+
+	// MutexProfileFraction 设置 runtime.SetMutexProfileFraction，0 表示不采集锁竞争。
+	// (MutexProfileFraction sets runtime.SetMutexProfileFraction; 0 disables mutex profiling.)
+	MutexProfileFraction int `json:"mutex-profile-fraction" mapstructure:"mutex-profile-fraction"`
+
+	// ContinuousInterval 是持续分析的周期，为 0 时不启用。需要通过 WithExporter 提供导出器。
+	// (ContinuousInterval is the period of continuous profiling; 0 disables it. An exporter must be supplied via WithExporter.)
+	ContinuousInterval time.Duration `json:"continuous-interval" mapstructure:"continuous-interval"`
+
+	// ContinuousTypes 是持续分析采集的类型，可选 "cpu"、"heap"、"goroutine"、"block"、"mutex"、"allocs"。
+	// (ContinuousTypes are the profile types collected continuously: "cpu", "heap", "goroutine", "block", "mutex", "allocs".)
+	ContinuousTypes []string `json:"continuous-types" mapstructure:"continuous-types"`
+}
+
+// NewOptions 创建具有默认值的性能分析选项 (creates profiling options with default values)
+func NewOptions() *Options {
+	return &Options{
+		PprofAddr:            "",               // 默认不启动独立服务器 (No standalone server by default)
+		OutputDir:            "./profiles",     // 默认输出目录 (Default output directory)
+		EnableSignals:        false,            // 默认关闭信号采集 (Signal capture disabled by default)
+		CPUDuration:          30 * time.Second, // 与 pprof 默认值一致 (Matches the pprof default)
+		BlockProfileRate:     0,
+		MutexProfileFraction: 0,
+		ContinuousInterval:   0, // 默认关闭持续分析 (Continuous profiling disabled by default)
+		ContinuousTypes:      []string{TypeCPU, TypeHeap},
+	}
+}
+
+// Validate 验证性能分析选项是否有效。
+// (Validate validates if the profiling options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.CPUDuration <= 0 {
+		errs = append(errs, fmt.Errorf("invalid cpu duration '%s', must be positive", o.CPUDuration))
+	}
+
+	if o.ContinuousInterval < 0 {
+		errs = append(errs, fmt.Errorf("invalid continuous interval '%s', must not be negative", o.ContinuousInterval))
+	}
+
+	if o.ContinuousInterval > 0 && o.CPUDuration >= o.ContinuousInterval {
+		errs = append(errs, fmt.Errorf("cpu duration '%s' must be shorter than continuous interval '%s'", o.CPUDuration, o.ContinuousInterval))
+	}
+
+	for _, t := range o.ContinuousTypes {
+		if !isKnownType(t) {
+			errs = append(errs, fmt.Errorf("invalid profile type '%s'", t))
+		}
+	}
+
+	if o.BlockProfileRate < 0 {
+		errs = append(errs, fmt.Errorf("invalid block profile rate %d, must not be negative", o.BlockProfileRate))
+	}
+
+	if o.MutexProfileFraction < 0 {
+		errs = append(errs, fmt.Errorf("invalid mutex profile fraction %d, must not be negative", o.MutexProfileFraction))
+	}
+
+	return errs
+}
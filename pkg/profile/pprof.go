@@ -0,0 +1,30 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package profile
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// RegisterHandlers 在 mux 上注册 /debug/pprof/ 下的标准 pprof 端点。
+// (RegisterHandlers registers the standard pprof endpoints under /debug/pprof/ on mux.)
+func RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+}
+
+// Handler 返回仅包含 pprof 端点的 HTTP 处理器。
+// (Handler returns an HTTP handler serving only the pprof endpoints.)
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	RegisterHandlers(mux)
+	return mux
+}
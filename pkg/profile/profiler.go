@@ -0,0 +1,222 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// ProfilerOption 是配置 Profiler 的函数类型。
+// (ProfilerOption is a function type for configuring a Profiler.)
+type ProfilerOption func(*Profiler)
+
+// WithExporter 设置持续分析使用的导出器。
+// (WithExporter sets the exporter used by continuous profiling.)
+func WithExporter(exporter Exporter) ProfilerOption {
+	return func(p *Profiler) {
+		p.exporter = exporter
+	}
+}
+
+// Profiler 根据配置管理 pprof 服务器、信号触发采集和持续分析。
+// (Profiler manages the pprof server, signal-triggered capture and continuous profiling according to the options.)
+type Profiler struct {
+	opts     *Options
+	exporter Exporter
+
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	server   *http.Server
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// New 根据选项创建 Profiler。
+// (New creates a Profiler from the options.)
+func New(opts *Options, profilerOpts ...ProfilerOption) (*Profiler, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid profile options"),
+			lmccerrors.ErrProfileOptionInvalid,
+		)
+	}
+	p := &Profiler{opts: opts}
+	for _, opt := range profilerOpts {
+		opt(p)
+	}
+	if opts.ContinuousInterval > 0 && p.exporter == nil {
+		return nil, lmccerrors.NewWithCode(lmccerrors.ErrProfileOptionInvalid, "continuous profiling requires an exporter")
+	}
+	return p, nil
+}
+
+// Start 应用运行时采样率，并按配置启动 pprof 服务器、信号处理和持续分析。
+// ctx 被取消或调用 Stop 时，所有后台任务都会停止。
+// (Start applies runtime sampling rates and starts the pprof server, signal handling and continuous profiling as configured.)
+// (All background work stops when ctx is cancelled or Stop is called.)
+func (p *Profiler) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrProfileOptionInvalid, "profiler already started")
+	}
+
+	runtime.SetBlockProfileRate(p.opts.BlockProfileRate)
+	runtime.SetMutexProfileFraction(p.opts.MutexProfileFraction)
+
+	if p.opts.PprofAddr != "" {
+		ln, err := net.Listen("tcp", p.opts.PprofAddr)
+		if err != nil {
+			return lmccerrors.WithCode(
+				lmccerrors.Wrapf(err, "failed to listen on %s", p.opts.PprofAddr),
+				lmccerrors.ErrProfileOptionInvalid,
+			)
+		}
+		p.listener = ln
+		p.server = &http.Server{Handler: Handler(), ReadHeaderTimeout: 10 * time.Second}
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			if err := p.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Errorw("Pprof server stopped unexpectedly", "error", err)
+			}
+		}()
+		log.Infow("Pprof server started", "addr", ln.Addr().String())
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+
+	if p.opts.EnableSignals {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.watchSignals(ctx)
+		}()
+	}
+
+	if p.opts.ContinuousInterval > 0 {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.runContinuous(ctx)
+		}()
+	}
+
+	return nil
+}
+
+// PprofAddr 返回独立 pprof 服务器的实际监听地址，未启动时返回空字符串。
+// (PprofAddr returns the actual listen address of the standalone pprof server, or an empty string when not started.)
+func (p *Profiler) PprofAddr() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.listener == nil {
+		return ""
+	}
+	return p.listener.Addr().String()
+}
+
+// Stop 停止所有后台任务并关闭 pprof 服务器。
+// (Stop stops all background work and shuts down the pprof server.)
+func (p *Profiler) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	cancel, server := p.cancel, p.server
+	p.cancel, p.server, p.listener = nil, nil, nil
+	p.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	var err error
+	if server != nil {
+		err = server.Shutdown(ctx)
+	}
+	p.wg.Wait()
+	return err
+}
+
+// CaptureToFile 采集指定类型的分析数据并写入 OutputDir，返回文件路径。
+// (CaptureToFile captures a profile of the given type into OutputDir and returns the file path.)
+func (p *Profiler) CaptureToFile(ctx context.Context, profileType string) (string, error) {
+	prof, err := Capture(ctx, profileType, p.opts.CPUDuration)
+	if err != nil {
+		log.Errorw("Profile capture failed", "type", profileType, "error", err)
+		return "", err
+	}
+
+	if err := os.MkdirAll(p.opts.OutputDir, 0o755); err != nil {
+		return "", lmccerrors.WithCode(
+			lmccerrors.Wrapf(err, "failed to create profile directory %s", p.opts.OutputDir),
+			lmccerrors.ErrProfileCapture,
+		)
+	}
+	name := fmt.Sprintf("%s-%s-%d.pprof", profileType, prof.Start.Format("20060102T150405"), os.Getpid())
+	path := filepath.Join(p.opts.OutputDir, name)
+	if err := os.WriteFile(path, prof.Data, 0o644); err != nil {
+		return "", lmccerrors.WithCode(
+			lmccerrors.Wrapf(err, "failed to write profile %s", path),
+			lmccerrors.ErrProfileCapture,
+		)
+	}
+
+	log.Infow("Profile captured", "type", profileType, "path", path, "bytes", len(prof.Data), "duration", prof.Duration)
+	return path, nil
+}
+
+// runContinuous 周期性地采集配置的分析类型并交给导出器。
+// (runContinuous periodically captures the configured profile types and hands them to the exporter.)
+func (p *Profiler) runContinuous(ctx context.Context) {
+	ticker := time.NewTicker(p.opts.ContinuousInterval)
+	defer ticker.Stop()
+
+	log.Infow("Continuous profiling started", "interval", p.opts.ContinuousInterval, "types", p.opts.ContinuousTypes)
+	for {
+		for _, t := range p.opts.ContinuousTypes {
+			if ctx.Err() != nil {
+				return
+			}
+			prof, err := Capture(ctx, t, p.opts.CPUDuration)
+			if err != nil {
+				log.Warnw("Continuous profile capture failed", "type", t, "error", err)
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err := p.exporter.Export(ctx, prof); err != nil {
+				log.Warnw("Continuous profile export failed", "type", t, "error", err)
+				continue
+			}
+			log.Debugw("Continuous profile exported", "type", t, "bytes", len(prof.Data))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Infow("Continuous profiling stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
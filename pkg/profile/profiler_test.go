@@ -0,0 +1,129 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for profile capture, the pprof server and continuous export.
+ */
+
+package profile_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCapture tests capturing the supported profile types.
+// (TestCapture 测试采集支持的分析类型。)
+func TestCapture(t *testing.T) {
+	for _, typ := range []string{profile.TypeHeap, profile.TypeGoroutine, profile.TypeAllocs} {
+		p, err := profile.Capture(context.Background(), typ, 0)
+		require.NoError(t, err, typ)
+		assert.Equal(t, typ, p.Type)
+		assert.NotEmpty(t, p.Data)
+	}
+
+	p, err := profile.Capture(context.Background(), profile.TypeCPU, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.NotEmpty(t, p.Data)
+	assert.GreaterOrEqual(t, p.Duration, 50*time.Millisecond)
+
+	_, err = profile.Capture(context.Background(), "bogus", 0)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrProfileOptionInvalid))
+}
+
+// TestCaptureToFile tests writing a profile into the output directory.
+// (TestCaptureToFile 测试将分析数据写入输出目录。)
+func TestCaptureToFile(t *testing.T) {
+	opts := profile.NewOptions()
+	opts.OutputDir = t.TempDir()
+	p, err := profile.New(opts)
+	require.NoError(t, err)
+
+	path, err := p.CaptureToFile(context.Background(), profile.TypeHeap)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Positive(t, info.Size())
+}
+
+// TestPprofServer tests the standalone pprof server lifecycle.
+// (TestPprofServer 测试独立 pprof 服务器的生命周期。)
+func TestPprofServer(t *testing.T) {
+	opts := profile.NewOptions()
+	opts.PprofAddr = "127.0.0.1:0"
+	p, err := profile.New(opts)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background()))
+
+	resp, err := http.Get("http://" + p.PprofAddr() + "/debug/pprof/")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, p.Stop(context.Background()))
+	assert.Empty(t, p.PprofAddr())
+}
+
+// TestContinuousProfiling tests exporting profiles through an HTTPExporter.
+// (TestContinuousProfiling 测试通过 HTTPExporter 导出分析数据。)
+func TestContinuousProfiling(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		names []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.NotEmpty(t, body)
+		assert.Equal(t, "pprof", r.URL.Query().Get("format"))
+		mu.Lock()
+		names = append(names, r.URL.Query().Get("name"))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	opts := profile.NewOptions()
+	opts.CPUDuration = 20 * time.Millisecond
+	opts.ContinuousInterval = time.Hour
+	opts.ContinuousTypes = []string{profile.TypeCPU, profile.TypeHeap}
+
+	p, err := profile.New(opts, profile.WithExporter(&profile.HTTPExporter{URL: srv.URL + "/ingest", AppName: "svc"}))
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background()))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(names) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Stop(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"svc.cpu", "svc.heap"}, names)
+}
+
+// TestNewInvalidOptions tests option validation.
+// (TestNewInvalidOptions 测试选项校验。)
+func TestNewInvalidOptions(t *testing.T) {
+	opts := profile.NewOptions()
+	opts.ContinuousTypes = []string{"threads"}
+	_, err := profile.New(opts)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrProfileOptionInvalid))
+
+	opts = profile.NewOptions()
+	opts.ContinuousInterval = time.Minute
+	opts.CPUDuration = time.Second
+	_, err = profile.New(opts)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrProfileOptionInvalid), "continuous profiling without exporter")
+}
@@ -0,0 +1,42 @@
+//go:build !windows
+
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package profile
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// watchSignals 监听 SIGUSR1/SIGUSR2，分别采集 CPU 分析和堆快照。
+// (watchSignals listens for SIGUSR1/SIGUSR2 and captures a CPU profile or a heap snapshot respectively.)
+func (p *Profiler) watchSignals(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1, syscall.SIGUSR2)
+	defer signal.Stop(sigCh)
+
+	log.Infow("Profile signal handler installed", "cpu_signal", "SIGUSR1", "heap_signal", "SIGUSR2", "output_dir", p.opts.OutputDir)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sigCh:
+			profileType := TypeHeap
+			if sig == syscall.SIGUSR1 {
+				profileType = TypeCPU
+			}
+			log.Infow("Profile capture triggered by signal", "signal", sig.String(), "type", profileType)
+			// 错误已在 CaptureToFile 中记录 (Errors are already logged by CaptureToFile)
+			_, _ = p.CaptureToFile(ctx, profileType)
+		}
+	}
+}
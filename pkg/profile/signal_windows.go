@@ -0,0 +1,22 @@
+//go:build windows
+
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package profile
+
+import (
+	"context"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// watchSignals 在 Windows 上不可用，因为没有 SIGUSR1/SIGUSR2。
+// (watchSignals is unavailable on Windows, which has no SIGUSR1/SIGUSR2.)
+func (p *Profiler) watchSignals(ctx context.Context) {
+	log.Warnw("Signal-triggered profiling is not supported on Windows; use the pprof endpoints instead")
+	<-ctx.Done()
+}
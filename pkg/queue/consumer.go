@@ -0,0 +1,132 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/concurrency"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// ConsumerOption 是配置 Consumer 的函数类型。
+// (ConsumerOption is a function type for configuring a Consumer.)
+type ConsumerOption func(*Consumer)
+
+// WithMiddleware 添加包装处理器的中间件，它们位于重试之外，因此只看到每条消息的最终结果。
+// (WithMiddleware adds middleware wrapping the handler. It sits outside the retries and therefore sees the final outcome of each message.)
+func WithMiddleware(mws ...Middleware) ConsumerOption {
+	return func(c *Consumer) {
+		c.middlewares = append(c.middlewares, mws...)
+	}
+}
+
+// WithLogger 设置用于记录确认失败的日志记录器，默认使用全局日志记录器。
+// (WithLogger sets the logger used to record acknowledgement failures; the global logger is used by default.)
+func WithLogger(logger log.Logger) ConsumerOption {
+	return func(c *Consumer) {
+		c.logger = logger
+	}
+}
+
+// Consumer 从 Source 接收消息并由 Concurrency 个 worker 调用处理器，成功时 Ack，失败时 Nack。
+// 处理器链由内到外为：panic 恢复、单次超时、重试、WithMiddleware 添加的中间件。
+// (Consumer receives messages from a Source and calls the handler on Concurrency workers, acking on success and nacking on failure.)
+// (From the inside out the handler chain is: panic recovery, per-attempt timeout, retries, then the middleware added with WithMiddleware.)
+type Consumer struct {
+	source      Source
+	handler     Handler
+	opts        *Options
+	middlewares []Middleware
+	logger      log.Logger
+}
+
+// NewConsumer 创建消费者。opts 为 nil 时使用默认选项。
+// (NewConsumer creates a consumer. Default options are used when opts is nil.)
+func NewConsumer(source Source, handler Handler, opts *Options, options ...ConsumerOption) (*Consumer, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	errs := opts.Validate()
+	if source == nil {
+		errs = append(errs, errors.New("source must not be nil"))
+	}
+	if handler == nil {
+		errs = append(errs, errors.New("handler must not be nil"))
+	}
+	if len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid queue options"),
+			lmccerrors.ErrQueueOptionInvalid,
+		)
+	}
+
+	c := &Consumer{source: source, opts: opts}
+	for _, opt := range options {
+		opt(c)
+	}
+
+	h := Timeout(opts.HandlerTimeout)(Recover()(handler))
+	if opts.Retry != nil && opts.Retry.MaxAttempts > 1 {
+		h = Retry(opts.Retry)(h)
+	}
+	c.handler = Chain(h, c.middlewares...)
+	return c, nil
+}
+
+// Run 处理消息直到 ctx 结束或 Source 返回 io.EOF，两种情况都返回 nil。
+// 接收消息失败时停止所有 worker 并返回带 ErrQueueSource 的错误。
+// (Run handles messages until ctx is done or the Source returns io.EOF, returning nil in both cases.)
+// (A receive failure stops all workers and returns an error coded ErrQueueSource.)
+func (c *Consumer) Run(ctx context.Context) error {
+	g, gctx := concurrency.NewGroup(ctx, concurrency.WithName(c.opts.Name), concurrency.WithLogger(c.log()))
+	for i := 0; i < c.opts.Concurrency; i++ {
+		g.Go(func() error { return c.work(gctx) })
+	}
+	return g.Wait()
+}
+
+// work 是单个 worker 的接收循环。(work is the receive loop of a single worker.)
+func (c *Consumer) work(ctx context.Context) error {
+	for {
+		msg, err := c.source.Receive(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to receive message"), lmccerrors.ErrQueueSource)
+		}
+		c.handle(ctx, msg)
+	}
+}
+
+// handle 处理一条消息并确认或否认它。确认不受 ctx 取消影响，避免关闭时重复投递已处理的消息。
+// (handle handles one message and acks or nacks it. Acknowledgement ignores ctx cancellation so messages handled during shutdown are not redelivered.)
+func (c *Consumer) handle(ctx context.Context, msg *Message) {
+	err := c.handler(ctx, msg)
+	ackCtx := context.WithoutCancel(ctx)
+	if err == nil {
+		if ackErr := c.source.Ack(ackCtx, msg); ackErr != nil {
+			c.log().Errorw("Failed to ack message", "consumer", c.opts.Name, "topic", msg.Topic, "message_id", msg.ID, "error", ackErr)
+		}
+		return
+	}
+	if nackErr := c.source.Nack(ackCtx, msg, err); nackErr != nil {
+		c.log().Errorw("Failed to nack message", "consumer", c.opts.Name, "topic", msg.Topic, "message_id", msg.ID, "error", nackErr)
+	}
+}
+
+// log 返回消费者的日志记录器。(log returns the consumer's logger.)
+func (c *Consumer) log() log.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return log.Std()
+}
@@ -0,0 +1,200 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the message consumer and its middleware.
+ */
+
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSource wraps a ChannelSource and records acked and nacked message IDs.
+// (recordingSource 包装 ChannelSource 并记录被确认和否认的消息 ID。)
+type recordingSource struct {
+	*queue.ChannelSource
+	mu     sync.Mutex
+	acked  []string
+	nacked []string
+}
+
+func (s *recordingSource) Ack(_ context.Context, msg *queue.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acked = append(s.acked, msg.ID)
+	return nil
+}
+
+func (s *recordingSource) Nack(_ context.Context, msg *queue.Message, _ error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nacked = append(s.nacked, msg.ID)
+	return nil
+}
+
+// newSource returns a source that yields msgs and is then exhausted.
+// (newSource 返回依次产生 msgs 后耗尽的消息来源。)
+func newSource(msgs ...*queue.Message) *recordingSource {
+	ch := make(chan *queue.Message, len(msgs))
+	for _, msg := range msgs {
+		ch <- msg
+	}
+	close(ch)
+	return &recordingSource{ChannelSource: queue.NewChannelSource(ch)}
+}
+
+// TestConsumer tests retries, permanent errors, panics, dead-lettering and acknowledgement.
+// (TestConsumer 测试重试、永久性错误、panic、死信和消息确认。)
+func TestConsumer(t *testing.T) {
+	source := newSource(
+		&queue.Message{ID: "ok", Topic: "orders"},
+		&queue.Message{ID: "flaky", Topic: "orders"},
+		&queue.Message{ID: "bad", Topic: "orders"},
+		&queue.Message{ID: "panic", Topic: "orders"},
+	)
+
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	handler := func(ctx context.Context, msg *queue.Message) error {
+		mu.Lock()
+		attempts[msg.ID] = msg.Attempt
+		mu.Unlock()
+		switch msg.ID {
+		case "flaky":
+			if msg.Attempt < 2 {
+				return errors.New("transient")
+			}
+		case "bad":
+			return queue.Permanent(lmccerrors.NewWithCode(lmccerrors.ErrValidation, "cannot decode"))
+		case "panic":
+			panic("boom")
+		}
+		return nil
+	}
+
+	reg := metrics.NewRegistry()
+	consumerMetrics, err := metrics.NewConsumerMetrics(nil, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	var deadLettered []string
+	opts := queue.NewOptions()
+	opts.Concurrency = 2
+	opts.Retry.InitialBackoff = time.Millisecond
+	c, err := queue.NewConsumer(source, handler, opts, queue.WithMiddleware(
+		queue.Metrics(consumerMetrics, opts.Name),
+		queue.Logging(nil),
+		queue.DeadLetter(func(_ context.Context, msg *queue.Message, cause error) error {
+			if msg.ID == "panic" {
+				assert.True(t, lmccerrors.IsCode(cause, lmccerrors.ErrPanic))
+				return errors.New("dead-letter queue unavailable")
+			}
+			mu.Lock()
+			deadLettered = append(deadLettered, msg.ID)
+			mu.Unlock()
+			return nil
+		}),
+	))
+	require.NoError(t, err)
+	require.NoError(t, c.Run(context.Background()))
+
+	assert.Equal(t, map[string]int{"ok": 1, "flaky": 2, "bad": 1, "panic": 3}, attempts)
+	assert.Equal(t, []string{"bad"}, deadLettered)
+	assert.ElementsMatch(t, []string{"ok", "flaky", "bad"}, source.acked)
+	assert.Equal(t, []string{"panic"}, source.nacked)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	results := map[string]float64{}
+	for _, mf := range families {
+		if mf.GetName() != "queue_consumer_requests_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if lp.GetName() == "result" {
+					results[lp.GetValue()] += m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"ok": 3, "error": 1}, results)
+}
+
+// TestConsumer_ReceiveError tests that a receive failure stops the consumer with ErrQueueSource.
+// (TestConsumer_ReceiveError 测试接收失败时消费者以 ErrQueueSource 停止。)
+func TestConsumer_ReceiveError(t *testing.T) {
+	source := queue.FuncSource{ReceiveFunc: func(context.Context) (*queue.Message, error) {
+		return nil, errors.New("broker down")
+	}}
+	c, err := queue.NewConsumer(source, func(context.Context, *queue.Message) error { return nil }, nil)
+	require.NoError(t, err)
+
+	err = c.Run(context.Background())
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrQueueSource))
+}
+
+// TestConsumer_Cancel tests that Run returns nil once the context is cancelled.
+// (TestConsumer_Cancel 测试上下文取消后 Run 返回 nil。)
+func TestConsumer_Cancel(t *testing.T) {
+	c, err := queue.NewConsumer(queue.NewChannelSource(make(chan *queue.Message)), func(context.Context, *queue.Message) error { return nil }, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, c.Run(ctx))
+}
+
+// TestOptions_Validate tests option validation.
+// (TestOptions_Validate 测试选项验证。)
+func TestOptions_Validate(t *testing.T) {
+	assert.Empty(t, queue.NewOptions().Validate())
+
+	opts := queue.NewOptions()
+	opts.Concurrency = 0
+	opts.Retry.Multiplier = 0.5
+	opts.Retry.Jitter = 2
+	assert.Len(t, opts.Validate(), 3)
+
+	_, err := queue.NewConsumer(nil, nil, opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrQueueOptionInvalid))
+}
+
+// TestRetry tests that Retry updates Message.Attempt, returns the last error unchanged and rejects invalid options.
+// (TestRetry 测试 Retry 更新 Message.Attempt、原样返回最后一次错误并拒绝无效选项。)
+func TestRetry(t *testing.T) {
+	opts := queue.NewRetryOptions()
+	opts.InitialBackoff = time.Millisecond
+	opts.MaxBackoff = time.Millisecond
+
+	failure := lmccerrors.NewWithCode(lmccerrors.ErrValidation, "still failing")
+	var seen []int
+	handler := queue.Retry(opts)(func(_ context.Context, msg *queue.Message) error {
+		seen = append(seen, msg.Attempt)
+		return failure
+	})
+	err := handler(context.Background(), &queue.Message{ID: "m1"})
+	assert.Same(t, failure, err, "4xx codes are retried too, and the last error is returned unchanged")
+	assert.Equal(t, []int{1, 2, 3}, seen)
+
+	seen = nil
+	assert.Same(t, failure, handler(context.Background(), &queue.Message{ID: "m2"}))
+	assert.Len(t, seen, 3, "the middleware can be reused")
+
+	opts.Jitter = 2
+	err = queue.Retry(opts)(func(context.Context, *queue.Message) error { return nil })(context.Background(), &queue.Message{})
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrRetryOptionInvalid), "%v", err)
+}
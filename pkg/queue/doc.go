@@ -0,0 +1,66 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package queue provides a minimal message consumer framework, giving background workers the
+same logging, metrics and error handling as HTTP handlers.
+(queue 包提供了一个最小化的消息消费者框架，让后台 worker 拥有与 HTTP 处理器相同的日志、指标和错误处理能力。)
+
+A Consumer receives messages from a Source, runs a Handler on a bounded number of workers and
+acks or nacks each message. Handlers are wrapped with panic recovery, a per-attempt timeout and
+exponential-backoff retries from Options, run by pkg/retry; Permanent errors are not retried.
+Middleware added with WithMiddleware sees the final outcome of each message:
+(Consumer 从 Source 接收消息，在有限数量的 worker 上运行 Handler，并确认或否认每条消息。处理器会被包装上
+panic 恢复、单次超时以及 Options 中由 pkg/retry 执行的指数退避重试；Permanent 错误不会重试。通过 WithMiddleware 添加的中间件
+看到的是每条消息的最终结果：)
+
+  - Logging:    structured logs with a message-scoped Logger in the context (带消息级 Logger 的结构化日志)
+  - Metrics:    RED metrics via metrics.ConsumerMetrics (通过 metrics.ConsumerMetrics 导出 RED 指标)
+  - DeadLetter: hand finally failed messages to a callback (将最终失败的消息交给回调处理)
+
+Sources:
+(消息来源：)
+
+  - ChannelSource: in-process Go channels (进程内 Go 通道)
+  - FuncSource:    adapts any broker client, e.g. kafka-go, NATS JetStream or SQS, without adding its dependency (无需引入依赖即可适配任意消息中间件客户端)
+
+Usage:
+(用法：)
+
+	reader := kafka.NewReader(kafka.ReaderConfig{Brokers: brokers, GroupID: "billing", Topic: "orders"})
+	source := queue.FuncSource{
+		ReceiveFunc: func(ctx context.Context) (*queue.Message, error) {
+			m, err := reader.FetchMessage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &queue.Message{Topic: m.Topic, Key: string(m.Key), Body: m.Value, Timestamp: m.Time, Raw: m}, nil
+		},
+		AckFunc: func(ctx context.Context, msg *queue.Message) error {
+			return reader.CommitMessages(ctx, msg.Raw.(kafka.Message))
+		},
+	}
+
+	consumerMetrics, err := metrics.NewConsumerMetrics(metricsOpts)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	consumer, err := queue.NewConsumer(source, handleOrder, opts,
+		queue.WithMiddleware(
+			queue.Metrics(consumerMetrics, opts.Name),
+			queue.Logging(nil),
+			queue.DeadLetter(publishToDLQ),
+		),
+	)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	err = consumer.Run(ctx)
+
+Errors carry the ErrQueueOptionInvalid or ErrQueueSource codes from pkg/errors.
+(错误携带 pkg/errors 中的 ErrQueueOptionInvalid 或 ErrQueueSource 错误码。)
+*/
+package queue
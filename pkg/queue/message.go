@@ -0,0 +1,75 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Message 是消费者处理的一条消息，与具体的消息中间件无关。
+// (Message is a single message handled by a consumer, independent of the broker.)
+type Message struct {
+	// ID 消息标识，用于日志和去重。(ID identifies the message in logs and for deduplication.)
+	ID string
+	// Topic 消息所属的主题、队列或流。(Topic is the topic, queue or stream the message came from.)
+	Topic string
+	// Key 分区或排序键，可为空。(Key is the partition or ordering key, may be empty.)
+	Key string
+	// Body 消息体。(Body is the message payload.)
+	Body []byte
+	// Headers 消息头或属性。(Headers are the message headers or attributes.)
+	Headers map[string]string
+	// Timestamp 消息的发布时间，未知时为零值。(Timestamp is when the message was published, zero if unknown.)
+	Timestamp time.Time
+	// Attempt 当前处理尝试次数，从 1 开始，由 Retry 中间件维护。
+	// (Attempt is the current handling attempt, starting at 1, maintained by the Retry middleware.)
+	Attempt int
+	// Raw 消息源的原始消息，供 Ack/Nack 使用。(Raw is the source's native message, used by Ack/Nack.)
+	Raw any
+}
+
+// Handler 处理一条消息，返回 nil 表示消息处理成功并应被确认。
+// (Handler handles one message; returning nil means the message was handled and should be acknowledged.)
+type Handler func(ctx context.Context, msg *Message) error
+
+// Middleware 包装 Handler，用于添加日志、指标、重试等横切逻辑。
+// (Middleware wraps a Handler to add cross-cutting behaviour such as logging, metrics and retries.)
+type Middleware func(next Handler) Handler
+
+// Chain 用 mws 包装 h，第一个中间件位于最外层。
+// (Chain wraps h with mws; the first middleware is the outermost.)
+func Chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// permanentError 标记不应重试的错误。(permanentError marks an error that must not be retried.)
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 标记 err 为永久性错误，Retry 中间件不会重试它，例如无法解析的消息。
+// (Permanent marks err as permanent so the Retry middleware does not retry it, e.g. for a message that cannot be decoded.)
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 判断 err 是否被 Permanent 标记。(IsPermanent reports whether err was marked by Permanent.)
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
@@ -0,0 +1,134 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/retry"
+)
+
+// Logging 返回记录消息处理结果的中间件，并通过 log.NewContextWithLogger 向处理器传递带消息字段的 Logger。
+// logger 为 nil 时使用 log.FromContext(ctx)。成功以 Debug 级别记录，失败以 Error 级别记录。
+// (Logging returns middleware that logs the outcome of handling and hands the handler a Logger carrying the message fields via log.NewContextWithLogger.)
+// (A nil logger means log.FromContext(ctx). Successes are logged at Debug level, failures at Error level.)
+func Logging(logger log.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			l := logger
+			if l == nil {
+				l = log.FromContext(ctx)
+			}
+			l = l.WithValues("topic", msg.Topic, "message_id", msg.ID)
+
+			start := time.Now()
+			err := next(log.NewContextWithLogger(ctx, l), msg)
+			kvs := []any{"attempt", msg.Attempt, "duration", time.Since(start)}
+			if err != nil {
+				l.Errorw("Message handling failed", append(kvs, "error", err)...)
+				return err
+			}
+			l.Debugw("Message handled", kvs...)
+			return nil
+		}
+	}
+}
+
+// Metrics 返回用 m 记录消息处理结果的中间件，consumer 为指标中的消费者标签。
+// (Metrics returns middleware recording handling outcomes with m; consumer is the consumer label of the metrics.)
+func Metrics(m *metrics.ConsumerMetrics, consumer string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			start := time.Now()
+			err := next(ctx, msg)
+			m.Observe(consumer, msg.Topic, err, time.Since(start))
+			return err
+		}
+	}
+}
+
+// Retry 返回按 opts 以指数退避重试失败处理的中间件，退避和抖动由 retry 包计算。被 Permanent 标记的错误和 ctx 结束时不再重试，
+// 最终失败时原样返回最后一次处理的错误。每次尝试前更新 Message.Attempt。opts 无效时，处理器返回带 ErrRetryOptionInvalid 的错误。
+// (Retry returns middleware retrying failed handling with exponential backoff per opts; backoff and jitter are computed by the retry package.)
+// (Errors marked by Permanent are not retried, nor is anything once ctx is done; a final failure returns the last handling error unchanged.)
+// (Message.Attempt is updated before each attempt. With invalid opts the handler returns an error coded ErrRetryOptionInvalid.)
+func Retry(opts *RetryOptions) Middleware {
+	retrier, err := retry.New(opts.retryOptions(),
+		retry.WithRetryIf(func(err error) bool { return !IsPermanent(err) }),
+		retry.WithName("queue handler"),
+	)
+	return func(next Handler) Handler {
+		if err != nil {
+			return func(context.Context, *Message) error { return err }
+		}
+		return func(ctx context.Context, msg *Message) error {
+			var last error
+			attempt := 0
+			if retrier.Do(ctx, func(ctx context.Context) error {
+				attempt++
+				msg.Attempt = attempt
+				last = next(ctx, msg)
+				return last
+			}) != nil {
+				return last
+			}
+			return nil
+		}
+	}
+}
+
+// DeadLetter 返回在处理最终失败时调用 fn 的中间件，例如把消息发布到死信队列。
+// fn 返回 nil 时消息视为已处理并被确认；ctx 结束导致的失败不会进入死信。
+// (DeadLetter returns middleware calling fn when handling finally fails, e.g. to publish the message to a dead-letter queue.)
+// (When fn returns nil the message counts as handled and is acknowledged; failures caused by ctx being done are not dead-lettered.)
+func DeadLetter(fn func(ctx context.Context, msg *Message, cause error) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			err := next(ctx, msg)
+			if err == nil || ctx.Err() != nil {
+				return err
+			}
+			if dlqErr := fn(ctx, msg, err); dlqErr != nil {
+				return errors.Join(err, lmccerrors.Wrap(dlqErr, "failed to dead-letter message"))
+			}
+			return nil
+		}
+	}
+}
+
+// Timeout 返回为每次处理设置超时的中间件，d <= 0 时不限制。
+// (Timeout returns middleware bounding each handling attempt by d; d <= 0 means no limit.)
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		if d <= 0 {
+			return next
+		}
+		return func(ctx context.Context, msg *Message) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Recover 返回将处理器 panic 转换为带 ErrPanic 错误的中间件。
+// (Recover returns middleware converting a handler panic into an error coded ErrPanic.)
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) (err error) {
+			defer lmccerrors.HandlePanic(func(p any) {
+				err = lmccerrors.Wrapf(lmccerrors.WrapPanic(p), "panic handling message '%s'", msg.ID)
+			})
+			return next(ctx, msg)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package queue
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/retry"
+)
+
+// Options 定义了消息消费者的配置选项，通常作为应用配置中的一节加载。
+// (Options defines message consumer configuration, typically loaded as a section of the application configuration.)
+type Options struct {
+	// Name 是消费者名称，出现在日志和指标标签中。
+	// (Name is the consumer name, used in logs and metric labels.)
+	Name string `json:"name" mapstructure:"name"`
+
+	// Concurrency 是并发处理消息的 worker 数。
+	// (Concurrency is the number of workers handling messages concurrently.)
+	Concurrency int `json:"concurrency" mapstructure:"concurrency"`
+
+	// HandlerTimeout 是单次处理尝试的超时时间，0 表示不限制。
+	// (HandlerTimeout bounds a single handling attempt; 0 means no limit.)
+	HandlerTimeout time.Duration `json:"handler-timeout" mapstructure:"handler-timeout"`
+
+	// Retry 是处理失败时的进程内重试策略。
+	// (Retry is the in-process retry policy for failed handling.)
+	Retry *RetryOptions `json:"retry" mapstructure:"retry"`
+}
+
+// RetryOptions 定义了指数退避重试策略。
+// (RetryOptions defines an exponential backoff retry policy.)
+type RetryOptions struct {
+	// MaxAttempts 是包括首次在内的最大处理次数，1 表示不重试。
+	// (MaxAttempts is the maximum number of attempts including the first one; 1 disables retries.)
+	MaxAttempts int `json:"max-attempts" mapstructure:"max-attempts"`
+
+	// InitialBackoff 是第一次重试前的等待时间。
+	// (InitialBackoff is the wait before the first retry.)
+	InitialBackoff time.Duration `json:"initial-backoff" mapstructure:"initial-backoff"`
+
+	// MaxBackoff 是重试等待时间的上限。
+	// (MaxBackoff caps the wait between retries.)
+	MaxBackoff time.Duration `json:"max-backoff" mapstructure:"max-backoff"`
+
+	// Multiplier 是每次重试后等待时间的增长倍数。
+	// (Multiplier is the growth factor of the wait after each retry.)
+	Multiplier float64 `json:"multiplier" mapstructure:"multiplier"`
+
+	// Jitter 将每次等待时间在正负该比例内随机化，例如 0.2 表示 ±20%，0 表示不加抖动。
+	// (Jitter randomizes each wait by up to this fraction in either direction, e.g. 0.2 for ±20%; 0 disables jitter.)
+	Jitter float64 `json:"jitter" mapstructure:"jitter"`
+}
+
+// NewOptions 创建具有默认值的消费者选项 (creates consumer options with default values)
+func NewOptions() *Options {
+	return &Options{
+		Name:           "consumer",        // 默认名称 (Default name)
+		Concurrency:    1,                 // 默认顺序处理 (Sequential handling by default)
+		HandlerTimeout: 0,                 // 默认不限制 (No limit by default)
+		Retry:          NewRetryOptions(), // 默认重试策略 (Default retry policy)
+	}
+}
+
+// NewRetryOptions 创建具有默认值的重试选项 (creates retry options with default values)
+func NewRetryOptions() *RetryOptions {
+	return &RetryOptions{
+		MaxAttempts:    3,                      // 首次加两次重试 (First attempt plus two retries)
+		InitialBackoff: 100 * time.Millisecond, // 第一次重试前等待 100ms (Wait 100ms before the first retry)
+		MaxBackoff:     10 * time.Second,       // 最长等待 10s (Wait at most 10s)
+		Multiplier:     2,                      // 每次翻倍 (Double each time)
+		Jitter:         0.2,                    // ±20% 抖动 (±20% jitter)
+	}
+}
+
+// Validate 验证消费者选项是否有效。
+// (Validate validates if the consumer options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.Name == "" {
+		errs = append(errs, fmt.Errorf("consumer name must not be empty"))
+	}
+
+	if o.Concurrency <= 0 {
+		errs = append(errs, fmt.Errorf("invalid concurrency %d, must be positive", o.Concurrency))
+	}
+
+	if o.HandlerTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid handler timeout '%s', must not be negative", o.HandlerTimeout))
+	}
+
+	if o.Retry != nil {
+		errs = append(errs, o.Retry.Validate()...)
+	}
+
+	return errs
+}
+
+// Validate 验证重试选项是否有效。
+// (Validate validates if the retry options are valid.)
+func (o *RetryOptions) Validate() []error {
+	var errs []error
+
+	if o.MaxAttempts <= 0 {
+		errs = append(errs, fmt.Errorf("invalid max attempts %d, must be positive", o.MaxAttempts))
+	}
+
+	if o.InitialBackoff < 0 || o.MaxBackoff < 0 {
+		errs = append(errs, fmt.Errorf("invalid backoff %s..%s, must not be negative", o.InitialBackoff, o.MaxBackoff))
+	} else if o.MaxBackoff < o.InitialBackoff {
+		errs = append(errs, fmt.Errorf("max backoff '%s' must not be less than initial backoff '%s'", o.MaxBackoff, o.InitialBackoff))
+	}
+
+	if o.Multiplier < 1 {
+		errs = append(errs, fmt.Errorf("invalid multiplier %g, must be at least 1", o.Multiplier))
+	}
+
+	if o.Jitter < 0 || o.Jitter > 1 {
+		errs = append(errs, fmt.Errorf("invalid jitter %g, must be between 0 and 1", o.Jitter))
+	}
+
+	return errs
+}
+
+// retryOptions 转换为 retry 包的指数退避选项。(retryOptions converts to exponential backoff options of the retry package.)
+func (o *RetryOptions) retryOptions() *retry.Options {
+	return &retry.Options{
+		MaxAttempts:     o.MaxAttempts,
+		Strategy:        retry.StrategyExponential,
+		InitialInterval: o.InitialBackoff,
+		MaxInterval:     o.MaxBackoff,
+		Multiplier:      o.Multiplier,
+		Jitter:          o.Jitter,
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package queue
+
+import (
+	"context"
+	"io"
+)
+
+// Source 是消息来源，由各消息中间件的适配器实现。
+// Receive 阻塞直到有消息、ctx 结束或来源关闭；来源关闭时返回 io.EOF。
+// (Source is where messages come from, implemented by adapters for each broker.)
+// (Receive blocks until a message arrives, ctx is done or the source is closed; a closed source returns io.EOF.)
+type Source interface {
+	// Receive 接收下一条消息。(Receive returns the next message.)
+	Receive(ctx context.Context) (*Message, error)
+	// Ack 确认消息已处理。(Ack acknowledges a handled message.)
+	Ack(ctx context.Context, msg *Message) error
+	// Nack 报告消息处理失败，cause 为处理器返回的错误。来源可以据此重新投递消息。
+	// (Nack reports that handling failed with cause; the source may redeliver the message.)
+	Nack(ctx context.Context, msg *Message, cause error) error
+}
+
+// ChannelSource 从 Go 通道读取消息，适用于进程内工作队列和测试。通道关闭后 Receive 返回 io.EOF。
+// Ack 不执行任何操作；Nack 在设置了 OnNack 时调用它。
+// (ChannelSource reads messages from a Go channel, suitable for in-process work queues and tests. Receive returns io.EOF once the channel is closed.)
+// (Ack is a no-op; Nack calls OnNack when it is set.)
+type ChannelSource struct {
+	ch <-chan *Message
+	// OnNack 在消息处理失败时调用，可用于重新入队。(OnNack is called when handling fails, e.g. to requeue the message.)
+	OnNack func(msg *Message, cause error)
+}
+
+// NewChannelSource 创建读取 ch 的消息来源。(NewChannelSource creates a source reading from ch.)
+func NewChannelSource(ch <-chan *Message) *ChannelSource {
+	return &ChannelSource{ch: ch}
+}
+
+// Receive 实现 Source 接口。(Receive implements Source.)
+func (s *ChannelSource) Receive(ctx context.Context) (*Message, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case msg, ok := <-s.ch:
+		if !ok {
+			return nil, io.EOF
+		}
+		return msg, nil
+	}
+}
+
+// Ack 实现 Source 接口。(Ack implements Source.)
+func (s *ChannelSource) Ack(context.Context, *Message) error {
+	return nil
+}
+
+// Nack 实现 Source 接口。(Nack implements Source.)
+func (s *ChannelSource) Nack(_ context.Context, msg *Message, cause error) error {
+	if s.OnNack != nil {
+		s.OnNack(msg, cause)
+	}
+	return nil
+}
+
+// FuncSource 用函数实现 Source，用于适配消息中间件客户端而无需引入其依赖，
+// 例如 kafka-go 的 FetchMessage/CommitMessages、NATS JetStream 的 Fetch/Ack 或 SQS 的 ReceiveMessage/DeleteMessage。
+// AckFunc 和 NackFunc 为 nil 时对应操作不执行任何操作。
+// (FuncSource implements Source with functions, adapting broker clients without pulling in their dependencies,)
+// (e.g. kafka-go FetchMessage/CommitMessages, NATS JetStream Fetch/Ack or SQS ReceiveMessage/DeleteMessage.)
+// (A nil AckFunc or NackFunc makes the corresponding operation a no-op.)
+type FuncSource struct {
+	ReceiveFunc func(ctx context.Context) (*Message, error)
+	AckFunc     func(ctx context.Context, msg *Message) error
+	NackFunc    func(ctx context.Context, msg *Message, cause error) error
+}
+
+// Receive 实现 Source 接口。(Receive implements Source.)
+func (s FuncSource) Receive(ctx context.Context) (*Message, error) {
+	return s.ReceiveFunc(ctx)
+}
+
+// Ack 实现 Source 接口。(Ack implements Source.)
+func (s FuncSource) Ack(ctx context.Context, msg *Message) error {
+	if s.AckFunc == nil {
+		return nil
+	}
+	return s.AckFunc(ctx, msg)
+}
+
+// Nack 实现 Source 接口。(Nack implements Source.)
+func (s FuncSource) Nack(ctx context.Context, msg *Message, cause error) error {
+	if s.NackFunc == nil {
+		return nil
+	}
+	return s.NackFunc(ctx, msg, cause)
+}
@@ -0,0 +1,67 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package retry
+
+import (
+	"math"
+	"time"
+)
+
+// Backoff 计算两次尝试之间的等待时间。
+// (Backoff computes the wait between two attempts.)
+type Backoff interface {
+	// Next 返回第 retry 次重试前的等待时间，retry 从 1 开始。
+	// (Next returns the wait before retry number retry, starting at 1.)
+	Next(retry int) time.Duration
+}
+
+// BackoffFunc 将普通函数适配为 Backoff。(BackoffFunc adapts a plain function to a Backoff.)
+type BackoffFunc func(retry int) time.Duration
+
+// Next 实现 Backoff。(Next implements Backoff.)
+func (f BackoffFunc) Next(retry int) time.Duration {
+	return f(retry)
+}
+
+// Exponential 返回指数退避：等待时间从 initial 开始，每次乘以 multiplier，最长为 max。
+// (Exponential returns exponential backoff: waits start at initial, grow by multiplier each time and are capped at max.)
+func Exponential(initial, max time.Duration, multiplier float64) Backoff {
+	return BackoffFunc(func(retry int) time.Duration {
+		wait := float64(initial) * math.Pow(multiplier, float64(retry-1))
+		if wait > float64(max) {
+			return max
+		}
+		return time.Duration(wait)
+	})
+}
+
+// Constant 返回固定间隔的退避。(Constant returns backoff with a fixed interval.)
+func Constant(interval time.Duration) Backoff {
+	return BackoffFunc(func(int) time.Duration {
+		return interval
+	})
+}
+
+// Fibonacci 返回斐波那契退避：等待时间依次为 initial 的 1、1、2、3、5、8... 倍，最长为 max。
+// 增长比指数退避平缓，适合恢复时间难以预估的依赖。
+// (Fibonacci returns Fibonacci backoff: waits are 1, 1, 2, 3, 5, 8... times initial, capped at max. It grows more gently
+// than exponential backoff, which suits dependencies whose recovery time is hard to predict.)
+func Fibonacci(initial, max time.Duration) Backoff {
+	return BackoffFunc(func(retry int) time.Duration {
+		prev, cur := time.Duration(0), initial
+		for i := 1; i < retry; i++ {
+			prev, cur = cur, prev+cur
+			if cur >= max || cur < prev { // 溢出时同样取上限 (Also cap on overflow)
+				return max
+			}
+		}
+		if cur > max {
+			return max
+		}
+		return cur
+	})
+}
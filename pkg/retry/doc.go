@@ -0,0 +1,50 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package retry repeats failing operations with configurable backoff, jitter, per-attempt timeouts and
+context cancellation.
+(retry 包以可配置的退避、抖动、单次尝试超时和上下文取消来重复执行失败的操作。)
+
+Backoff strategies:
+(退避策略：)
+
+  - Exponential: waits grow by Multiplier up to MaxInterval (等待时间按 Multiplier 增长，最长 MaxInterval)
+  - Constant:    a fixed InitialInterval between attempts (两次尝试之间固定等待 InitialInterval)
+  - Fibonacci:   waits of 1, 1, 2, 3, 5... times InitialInterval up to MaxInterval (等待时间为 InitialInterval 的 1、1、2、3、5... 倍，最长 MaxInterval)
+
+Errors are classified with errors.Retryable by default, so 5xx codes, timeouts and 429 are retried
+while other 4xx codes fail right away; WithRetryIf and RetryOnCodes change the rule. Every retry is
+logged at Warn level through pkg/log and passed to the WithOnRetry hooks. A non-retryable error is
+returned unchanged and the last error keeps its Coder when the attempts are used up.
+(错误默认通过 errors.Retryable 分类：5xx 错误码、超时和 429 会重试，其他 4xx 错误码立即失败；WithRetryIf 和 RetryOnCodes
+可修改规则。每次重试都会通过 pkg/log 以 Warn 级别记录并传给 WithOnRetry 钩子。不可重试的错误原样返回，
+用完尝试次数时最后一个错误保留其 Coder。)
+
+This package is the single home of the SDK's retry loop; the queue consumer retries through it too.
+(本包是 SDK 中唯一的重试循环实现，queue 消费者也通过它重试。)
+
+Usage:
+(用法：)
+
+	opts := retry.NewOptions()
+	opts.Strategy = retry.StrategyFibonacci
+	opts.AttemptTimeout = 2 * time.Second
+	r, err := retry.New(opts, retry.WithName("charge"), retry.WithRetryIf(retry.RetryOnCodes(errors.ErrTimeout)))
+	if err != nil {
+		// handle error (处理错误)
+	}
+	receipt, err := retry.DoValue(ctx, r, func(ctx context.Context) (*Receipt, error) {
+		return payments.Charge(ctx, order)
+	})
+
+	// 使用默认策略 (With the default policy)
+	err = retry.Do(ctx, func(ctx context.Context) error { return client.Ping(ctx) })
+
+Invalid options return an error coded ErrRetryOptionInvalid from pkg/errors.
+(无效的选项返回带 pkg/errors 中 ErrRetryOptionInvalid 错误码的错误。)
+*/
+package retry
@@ -0,0 +1,115 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package retry
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// StrategyExponential 表示指数退避。(StrategyExponential selects exponential backoff.)
+	StrategyExponential = "exponential"
+	// StrategyConstant 表示固定间隔。(StrategyConstant selects a fixed interval.)
+	StrategyConstant = "constant"
+	// StrategyFibonacci 表示斐波那契退避。(StrategyFibonacci selects Fibonacci backoff.)
+	StrategyFibonacci = "fibonacci"
+)
+
+// Options 定义了重试策略，通常作为应用配置中的一节加载，例如 "retry"。
+// (Options defines a retry policy, typically loaded as a section of the application configuration such as "retry".)
+type Options struct {
+	// MaxAttempts 是包括首次在内的最大尝试次数，1 表示不重试。
+	// (MaxAttempts is the maximum number of attempts including the first one; 1 disables retries.)
+	MaxAttempts int `json:"max-attempts" mapstructure:"max-attempts"`
+
+	// Strategy 是退避策略："exponential"、"constant" 或 "fibonacci"。
+	// (Strategy is the backoff strategy: "exponential", "constant" or "fibonacci".)
+	Strategy string `json:"strategy" mapstructure:"strategy"`
+
+	// InitialInterval 是第一次重试前的等待时间，也是 "constant" 策略的间隔。
+	// (InitialInterval is the wait before the first retry, and the interval of the "constant" strategy.)
+	InitialInterval time.Duration `json:"initial-interval" mapstructure:"initial-interval"`
+
+	// MaxInterval 是等待时间的上限。
+	// (MaxInterval caps the wait between attempts.)
+	MaxInterval time.Duration `json:"max-interval" mapstructure:"max-interval"`
+
+	// Multiplier 是 "exponential" 策略每次重试后等待时间的增长倍数。
+	// (Multiplier is the growth factor of the wait after each retry for the "exponential" strategy.)
+	Multiplier float64 `json:"multiplier" mapstructure:"multiplier"`
+
+	// Jitter 将每次等待时间在正负该比例内随机化，例如 0.2 表示 ±20%，0 表示不加抖动。
+	// (Jitter randomizes each wait by up to this fraction in either direction, e.g. 0.2 for ±20%; 0 disables jitter.)
+	Jitter float64 `json:"jitter" mapstructure:"jitter"`
+
+	// AttemptTimeout 限制单次尝试的时长，0 表示只受调用方 ctx 限制。
+	// (AttemptTimeout bounds a single attempt; 0 means it is bounded by the caller's ctx only.)
+	AttemptTimeout time.Duration `json:"attempt-timeout" mapstructure:"attempt-timeout"`
+}
+
+// NewOptions 创建具有默认值的重试选项 (creates retry options with default values)
+func NewOptions() *Options {
+	return &Options{
+		MaxAttempts:     3,                      // 首次加两次重试 (First attempt plus two retries)
+		Strategy:        StrategyExponential,    // 默认指数退避 (Exponential backoff by default)
+		InitialInterval: 100 * time.Millisecond, // 第一次重试前等待 100ms (Wait 100ms before the first retry)
+		MaxInterval:     10 * time.Second,       // 最长等待 10s (Wait at most 10s)
+		Multiplier:      2,                      // 每次翻倍 (Double each time)
+		Jitter:          0.2,                    // ±20% 抖动 (±20% jitter)
+		AttemptTimeout:  0,                      // 默认不限制单次尝试 (No per-attempt limit by default)
+	}
+}
+
+// Validate 验证重试选项是否有效。
+// (Validate validates if the retry options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("invalid max attempts %d, must be at least 1", o.MaxAttempts))
+	}
+
+	switch o.Strategy {
+	case StrategyExponential, StrategyConstant, StrategyFibonacci:
+	default:
+		errs = append(errs, fmt.Errorf("invalid strategy '%s', must be one of: %s, %s, %s",
+			o.Strategy, StrategyExponential, StrategyConstant, StrategyFibonacci))
+	}
+
+	if o.InitialInterval < 0 || o.MaxInterval < 0 {
+		errs = append(errs, fmt.Errorf("invalid interval %s..%s, must not be negative", o.InitialInterval, o.MaxInterval))
+	} else if o.MaxInterval < o.InitialInterval {
+		errs = append(errs, fmt.Errorf("max interval '%s' must not be less than initial interval '%s'", o.MaxInterval, o.InitialInterval))
+	}
+
+	if o.Strategy == StrategyExponential && o.Multiplier < 1 {
+		errs = append(errs, fmt.Errorf("invalid multiplier %g, must be at least 1", o.Multiplier))
+	}
+
+	if o.Jitter < 0 || o.Jitter > 1 {
+		errs = append(errs, fmt.Errorf("invalid jitter %g, must be between 0 and 1", o.Jitter))
+	}
+
+	if o.AttemptTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid attempt timeout '%s', must not be negative", o.AttemptTimeout))
+	}
+
+	return errs
+}
+
+// backoff 返回 Strategy 对应的 Backoff。(backoff returns the Backoff of the Strategy.)
+func (o *Options) backoff() Backoff {
+	switch o.Strategy {
+	case StrategyConstant:
+		return Constant(o.InitialInterval)
+	case StrategyFibonacci:
+		return Fibonacci(o.InitialInterval, o.MaxInterval)
+	default:
+		return Exponential(o.InitialInterval, o.MaxInterval, o.Multiplier)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+)
+
+// OnRetryFunc 在每次等待前以失败的尝试序号（从 1 开始）、其错误和等待时间调用。
+// (OnRetryFunc is called before every wait with the failed attempt number, starting at 1, its error and the wait.)
+type OnRetryFunc func(ctx context.Context, attempt int, err error, wait time.Duration)
+
+// Option 是配置 Retrier 的函数类型。
+// (Option is a function type for configuring a Retrier.)
+type Option func(*Retrier)
+
+// WithBackoff 使用自定义的 Backoff 代替 Options.Strategy。
+// (WithBackoff uses a custom Backoff instead of Options.Strategy.)
+func WithBackoff(backoff Backoff) Option {
+	return func(r *Retrier) {
+		r.backoff = backoff
+	}
+}
+
+// WithRetryIf 设置判断错误是否值得再次尝试的函数，默认使用 errors.Retryable：5xx、超时和 429 可以重试，其他 4xx 不可重试。
+// (WithRetryIf sets the function deciding whether an error is worth another attempt; errors.Retryable is used by default:
+// 5xx, timeouts and 429 are retried, other 4xx are not.)
+func WithRetryIf(retryable func(err error) bool) Option {
+	return func(r *Retrier) {
+		r.retryable = retryable
+	}
+}
+
+// WithOnRetry 添加一个在每次等待前调用的钩子，可多次使用。(WithOnRetry adds a hook called before every wait; it may be used several times.)
+func WithOnRetry(hook OnRetryFunc) Option {
+	return func(r *Retrier) {
+		r.onRetry = append(r.onRetry, hook)
+	}
+}
+
+// WithLogger 设置记录重试的日志记录器，默认使用全局日志记录器。
+// (WithLogger sets the logger recording retries; the global logger is used by default.)
+func WithLogger(logger log.Logger) Option {
+	return func(r *Retrier) {
+		r.logger = logger
+	}
+}
+
+// WithName 设置操作名称，作为 operation 字段写入重试日志。(WithName sets the operation name, written as the operation field of retry logs.)
+func WithName(name string) Option {
+	return func(r *Retrier) {
+		r.name = name
+	}
+}
+
+// RetryOnCodes 返回只在错误链中的 Coder 是 coders 之一时才重试的判断函数，用于 WithRetryIf。
+// (RetryOnCodes returns a predicate for WithRetryIf that retries only when the Coder in the error chain is one of coders.)
+func RetryOnCodes(coders ...lmccerrors.Coder) func(err error) bool {
+	return func(err error) bool {
+		coder := lmccerrors.GetCoder(err)
+		if coder == nil {
+			return false
+		}
+		for _, c := range coders {
+			if c != nil && c.Code() == coder.Code() {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Retrier 按重试策略反复执行操作，可并发使用。
+// (Retrier runs operations repeatedly according to a retry policy; it is safe for concurrent use.)
+type Retrier struct {
+	opts      *Options
+	backoff   Backoff
+	retryable func(err error) bool
+	onRetry   []OnRetryFunc
+	logger    log.Logger
+	name      string
+}
+
+// New 校验选项并创建 Retrier，opts 为 nil 时使用 NewOptions()。选项无效时返回带 ErrRetryOptionInvalid 的错误。
+// (New validates the options and creates a Retrier, using NewOptions() when opts is nil. Invalid options return an error
+// coded ErrRetryOptionInvalid.)
+func New(opts *Options, options ...Option) (*Retrier, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid retry options"),
+			lmccerrors.ErrRetryOptionInvalid,
+		)
+	}
+	r := &Retrier{opts: opts}
+	for _, opt := range options {
+		opt(r)
+	}
+	if r.backoff == nil {
+		r.backoff = opts.backoff()
+	}
+	if r.retryable == nil {
+		r.retryable = lmccerrors.Retryable
+	}
+	return r, nil
+}
+
+// Do 使用默认选项和给定的 Option 执行 fn，参见 Retrier.Do。
+// (Do runs fn with the default options and the given Options; see Retrier.Do.)
+func Do(ctx context.Context, fn func(ctx context.Context) error, options ...Option) error {
+	r, err := New(nil, options...)
+	if err != nil {
+		return err
+	}
+	return r.Do(ctx, fn)
+}
+
+// DoValue 与 Retrier.Do 相同，但返回 fn 成功时的结果；r 为 nil 时使用默认选项。
+// (DoValue is like Retrier.Do but returns the result of the successful call of fn; a nil r uses the default options.)
+func DoValue[T any](ctx context.Context, r *Retrier, fn func(ctx context.Context) (T, error)) (T, error) {
+	if r == nil {
+		var err error
+		if r, err = New(nil); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	var result T
+	err := r.Do(ctx, func(ctx context.Context) error {
+		value, err := fn(ctx)
+		if err == nil {
+			result = value
+		}
+		return err
+	})
+	return result, err
+}
+
+// Do 反复调用 fn，直到成功、返回不可重试的错误、用完 MaxAttempts 或 ctx 结束。每次调用的 ctx 受 AttemptTimeout 限制，
+// 每次重试前按退避策略等待，记录一条 Warn 日志并调用 OnRetry 钩子。不可重试的错误原样返回；最后一次尝试失败后，
+// 错误被包装上尝试次数并保留其 Coder；ctx 结束时返回上下文错误，并包装上最后一次错误的消息。
+// (Do calls fn until it succeeds, returns an error that is not retryable, MaxAttempts are used up or ctx is done. The ctx of
+// each call is bounded by AttemptTimeout; before each retry Do waits according to the backoff, logs a Warn entry and calls the
+// OnRetry hooks. A non-retryable error is returned unchanged; after the last attempt the error is wrapped with the attempt
+// count, keeping its Coder; when ctx is done the context error is returned, wrapped with the last error message.)
+func (r *Retrier) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := r.attempt(ctx, fn)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return lmccerrors.Wrapf(ctx.Err(), "retry stopped after %d attempts, last error: %v", attempt, err)
+		}
+		if !r.retryable(err) {
+			return err
+		}
+		if attempt >= r.opts.MaxAttempts {
+			return lmccerrors.Wrapf(err, "giving up after %d attempts", attempt)
+		}
+
+		wait := r.jittered(r.backoff.Next(attempt))
+		r.log().Warnw("Operation failed, retrying",
+			"operation", r.name, "attempt", attempt, "max_attempts", r.opts.MaxAttempts, "wait", wait, "error", err)
+		for _, hook := range r.onRetry {
+			hook(ctx, attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return lmccerrors.Wrapf(ctx.Err(), "retry stopped after %d attempts, last error: %v", attempt, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt 以受 AttemptTimeout 限制的 ctx 调用一次 fn。(attempt calls fn once with a ctx bounded by AttemptTimeout.)
+func (r *Retrier) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.opts.AttemptTimeout <= 0 {
+		return fn(ctx)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, r.opts.AttemptTimeout)
+	defer cancel()
+	return fn(attemptCtx)
+}
+
+// jittered 将 wait 在正负 Jitter 比例内随机化。(jittered randomizes wait by up to the Jitter fraction in either direction.)
+func (r *Retrier) jittered(wait time.Duration) time.Duration {
+	if r.opts.Jitter == 0 {
+		return wait
+	}
+	return time.Duration(float64(wait) * (1 + r.opts.Jitter*(2*rand.Float64()-1)))
+}
+
+// log 返回日志记录器。(log returns the logger.)
+func (r *Retrier) log() log.Logger {
+	if r.logger != nil {
+		return r.logger
+	}
+	return log.Std()
+}
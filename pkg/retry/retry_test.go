@@ -0,0 +1,165 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the retry policies and the Retrier.
+ */
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log/logtest"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastOptions returns options with short intervals and no jitter for tests.
+// (fastOptions 返回适合测试的短间隔、无抖动选项。)
+func fastOptions() *retry.Options {
+	opts := retry.NewOptions()
+	opts.InitialInterval = time.Millisecond
+	opts.MaxInterval = 5 * time.Millisecond
+	opts.Jitter = 0
+	return opts
+}
+
+// TestBackoff tests the waits of the exponential, constant and Fibonacci strategies.
+// (TestBackoff 测试指数、固定和斐波那契策略的等待时间。)
+func TestBackoff(t *testing.T) {
+	waits := func(b retry.Backoff, n int) []time.Duration {
+		var out []time.Duration
+		for i := 1; i <= n; i++ {
+			out = append(out, b.Next(i))
+		}
+		return out
+	}
+	ms := time.Millisecond
+	assert.Equal(t, []time.Duration{100 * ms, 200 * ms, 400 * ms, 500 * ms}, waits(retry.Exponential(100*ms, 500*ms, 2), 4))
+	assert.Equal(t, []time.Duration{50 * ms, 50 * ms, 50 * ms}, waits(retry.Constant(50*ms), 3))
+	assert.Equal(t, []time.Duration{10 * ms, 10 * ms, 20 * ms, 30 * ms, 50 * ms, 60 * ms}, waits(retry.Fibonacci(10*ms, 60*ms), 6))
+	assert.Equal(t, time.Hour, retry.Fibonacci(time.Second, time.Hour).Next(200), "large retry numbers stay capped")
+}
+
+// TestRetrier_Do tests that retryable errors are retried with hooks and logs until the attempts are used up.
+// (TestRetrier_Do 测试可重试的错误会被重试，调用钩子并记录日志，直到用完尝试次数。)
+func TestRetrier_Do(t *testing.T) {
+	logger := logtest.NewTestLogger(t)
+	var hooked []int
+	r, err := retry.New(fastOptions(), retry.WithLogger(logger), retry.WithName("charge"),
+		retry.WithOnRetry(func(_ context.Context, attempt int, err error, wait time.Duration) {
+			hooked = append(hooked, attempt)
+			assert.Positive(t, wait)
+		}))
+	require.NoError(t, err)
+
+	calls := 0
+	err = r.Do(context.Background(), func(context.Context) error {
+		calls++
+		return lmccerrors.NewWithCode(lmccerrors.ErrOperationFailed, "gateway down")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, hooked)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrOperationFailed), "the last error keeps its Coder")
+	assert.Contains(t, err.Error(), "giving up after 3 attempts")
+	logger.ContainsEntry("warn", "retrying", "operation", "charge", "attempt", 2, "max_attempts", 3)
+
+	calls = 0
+	result, err := retry.DoValue(context.Background(), r, func(context.Context) (string, error) {
+		if calls++; calls < 2 {
+			return "", errors.New("flaky")
+		}
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 2, calls)
+}
+
+// TestRetrier_NonRetryable tests that non-retryable errors are returned right away and that RetryOnCodes narrows retries.
+// (TestRetrier_NonRetryable 测试不可重试的错误被立即返回，以及 RetryOnCodes 缩小重试范围。)
+func TestRetrier_NonRetryable(t *testing.T) {
+	logger := logtest.NewTestLogger(t)
+	calls := 0
+	validation := lmccerrors.NewWithCode(lmccerrors.ErrValidation, "bad input")
+	err := retry.Do(context.Background(), func(context.Context) error {
+		calls++
+		return validation
+	}, retry.WithLogger(logger))
+	assert.Same(t, validation, err)
+	assert.Equal(t, 1, calls)
+
+	r, err := retry.New(fastOptions(), retry.WithLogger(logger), retry.WithRetryIf(retry.RetryOnCodes(lmccerrors.ErrTimeout)))
+	require.NoError(t, err)
+	calls = 0
+	_ = r.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return lmccerrors.NewWithCode(lmccerrors.ErrTimeout, "slow")
+		}
+		return lmccerrors.NewWithCode(lmccerrors.ErrInternalServer, "broken")
+	})
+	assert.Equal(t, 2, calls, "only the listed codes are retried")
+}
+
+// TestRetrier_Context tests the per-attempt timeout and stopping when the caller's context ends.
+// (TestRetrier_Context 测试单次尝试超时以及调用方 context 结束时停止重试。)
+func TestRetrier_Context(t *testing.T) {
+	logger := logtest.NewTestLogger(t)
+	opts := fastOptions()
+	opts.AttemptTimeout = 5 * time.Millisecond
+	r, err := retry.New(opts, retry.WithLogger(logger))
+	require.NoError(t, err)
+
+	calls := 0
+	err = r.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, calls, "timed-out attempts are retried")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	opts = fastOptions()
+	opts.Strategy = retry.StrategyConstant
+	opts.InitialInterval = time.Hour
+	opts.MaxInterval = time.Hour
+	r, err = retry.New(opts, retry.WithLogger(logger))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = r.Do(ctx, func(context.Context) error {
+		calls++
+		cancel()
+		return errors.New("unavailable")
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "retry stopped after 1 attempts, last error: unavailable")
+	assert.Equal(t, 1, calls)
+}
+
+// TestOptions_Validate tests rejecting invalid retry options.
+// (TestOptions_Validate 测试拒绝无效的重试选项。)
+func TestOptions_Validate(t *testing.T) {
+	assert.Empty(t, retry.NewOptions().Validate())
+
+	opts := retry.NewOptions()
+	opts.MaxAttempts = 0
+	opts.Strategy = "linear"
+	opts.MaxInterval = time.Millisecond
+	opts.Jitter = 2
+	opts.AttemptTimeout = -time.Second
+	assert.Len(t, opts.Validate(), 5)
+
+	_, err := retry.New(opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrRetryOptionInvalid))
+}
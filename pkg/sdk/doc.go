@@ -0,0 +1,40 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package sdk 用一次调用完成服务的可观测性启动引导，取代各服务中重复的初始化代码。
+(Package sdk bootstraps a service's observability in one call, replacing the initialization code every service repeats.)
+
+Bootstrap 按依赖顺序初始化：全局日志（配置了 WithConfigManager 时支持热重载）、OpenTelemetry 追踪与日志关联、
+指标注册表（后台任务指标、运行时采集、推送）、健康检查和调试服务器，最后输出启动诊断报告。返回的 Runtime 持有
+各子系统的句柄，Runtime.Shutdown 按相反顺序关闭它们：先等待后台任务，再停止调试服务器、最终推送指标、刷新 span。
+(Bootstrap initializes, in dependency order: the global logger (hot-reloaded when WithConfigManager is given), OpenTelemetry
+tracing and log correlation, the metrics registry (background task metrics, runtime collection, pushing), health checks and
+the debug server, and finally emits the startup diagnostics report. The returned Runtime holds a handle to each subsystem and
+Runtime.Shutdown stops them in reverse order: background tasks are awaited first, then the debug server stops, metrics are
+pushed a final time and spans are flushed.)
+
+Options 的各节与各子系统的选项相同，可以直接由 pkg/config 加载：
+(The sections of Options are the subsystems' own options, so they can be loaded directly with pkg/config:)
+
+	opts := sdk.NewOptions()
+	cm, err := config.LoadConfigAndWatch(opts, config.WithConfigFile("config.yaml", ""), config.WithHotReload(true))
+	if err != nil {
+		return err
+	}
+	rt, err := sdk.Bootstrap(ctx, opts,
+		sdk.WithConfigManager(cm),
+		sdk.WithVersion(version),
+		sdk.WithHealthCheck("database", db.PingContext),
+		sdk.WithHealthCheck("cache", cache.Ping, healthcheck.NonCritical()),
+		sdk.WithSpanExporter(exporter),
+	)
+	if err != nil {
+		return err
+	}
+	defer rt.Shutdown(context.Background())
+*/
+package sdk
@@ -0,0 +1,212 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/debug"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/tasks"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Options 定义了 Bootstrap 初始化的各子系统的配置，每个字段对应应用配置中的一节。
+// (Options defines the configuration of the subsystems initialized by Bootstrap; each field is one section of the application configuration.)
+type Options struct {
+	// Log 配置全局 logger。(Log configures the global logger.)
+	Log *log.Options `json:"log" mapstructure:"log"`
+
+	// Metrics 配置指标注册表、运行时采集和推送。(Metrics configures the metrics registry, runtime collection and pushing.)
+	Metrics *metrics.Options `json:"metrics" mapstructure:"metrics"`
+
+	// Trace 配置追踪和日志关联。(Trace configures tracing and log correlation.)
+	Trace *trace.Options `json:"trace" mapstructure:"trace"`
+
+	// Debug 配置调试服务器，其 /metrics、/healthz 和 /readyz 端点由 Bootstrap 接入。
+	// (Debug configures the debug server, whose /metrics, /healthz and /readyz endpoints are wired by Bootstrap.)
+	Debug *debug.Options `json:"debug" mapstructure:"debug"`
+
+	// StartupReport 控制启动完成后是否输出诊断报告。(StartupReport controls whether the diagnostics report is emitted once startup completes.)
+	StartupReport bool `json:"startup-report" mapstructure:"startup-report"`
+
+	// ShutdownTimeout 是 Shutdown 在调用方未设置截止时间时使用的超时。
+	// (ShutdownTimeout bounds Shutdown when the caller's context has no deadline.)
+	ShutdownTimeout time.Duration `json:"shutdown-timeout" mapstructure:"shutdown-timeout"`
+}
+
+// NewOptions 创建具有默认值的启动引导选项 (creates bootstrap options with default values)
+func NewOptions() *Options {
+	return &Options{
+		Log:             log.NewOptions(),
+		Metrics:         metrics.NewOptions(),
+		Trace:           trace.NewOptions(),
+		Debug:           debug.NewOptions(),
+		StartupReport:   true,             // 启动时记录一次环境信息 (Record the environment once at startup)
+		ShutdownTimeout: 30 * time.Second, // 与常见的 Kubernetes 终止宽限期一致 (Matches the common Kubernetes termination grace period)
+	}
+}
+
+// Validate 验证各子系统的选项是否有效。
+// (Validate validates the options of every subsystem.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.Log != nil {
+		errs = append(errs, o.Log.Validate()...)
+	}
+	if o.Metrics != nil {
+		errs = append(errs, o.Metrics.Validate()...)
+	}
+	if o.Trace != nil {
+		errs = append(errs, o.Trace.Validate()...)
+	}
+	if o.Debug != nil {
+		errs = append(errs, o.Debug.Validate()...)
+	}
+	if o.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("invalid shutdown timeout '%s', must be positive", o.ShutdownTimeout))
+	}
+
+	return errs
+}
+
+// withDefaults 返回将 nil 节替换为默认值后的副本。(withDefaults returns a copy with nil sections replaced by their defaults.)
+func (o *Options) withDefaults() *Options {
+	out := *o
+	if out.Log == nil {
+		out.Log = log.NewOptions()
+	}
+	if out.Metrics == nil {
+		out.Metrics = metrics.NewOptions()
+	}
+	if out.Trace == nil {
+		out.Trace = trace.NewOptions()
+	}
+	if out.Debug == nil {
+		out.Debug = debug.NewOptions()
+	}
+	return &out
+}
+
+// Option 是配置 Bootstrap 的函数类型。(Option is a function type for configuring Bootstrap.)
+type Option func(*settings)
+
+// settings 保存由 Option 设置的值。(settings holds the values set by Options.)
+type settings struct {
+	version       string
+	configManager config.Manager
+	registry      *metrics.Registry
+	tasks         *tasks.Manager
+	spanExporters []sdktrace.SpanExporter
+	sampler       sdktrace.Sampler
+	health        *healthcheck.Registry
+	healthChecks  []healthCheck
+	redactKeys    []string
+}
+
+func newSettings(options []Option) *settings {
+	s := &settings{registry: metrics.Default(), tasks: tasks.Default(), health: healthcheck.New()}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// WithVersion 设置应用版本，用于调试服务器的 /buildinfo 和启动诊断报告。
+// (WithVersion sets the application version reported by the debug server's /buildinfo and the startup diagnostics.)
+func WithVersion(version string) Option {
+	return func(s *settings) {
+		s.version = version
+	}
+}
+
+// WithConfigManager 接入由 config.LoadConfigAndWatch 返回的配置管理器：日志节支持热重载，
+// 调试服务器的 /config 和启动报告展示其脱敏后的设置。
+// (WithConfigManager wires the manager returned by config.LoadConfigAndWatch: the log section is hot-reloaded and
+// the debug server's /config endpoint and the startup report show its redacted settings.)
+func WithConfigManager(cm config.Manager) Option {
+	return func(s *settings) {
+		s.configManager = cm
+	}
+}
+
+// WithRegistry 设置指标注册表，默认为 metrics.Default()。
+// (WithRegistry sets the metrics registry; defaults to metrics.Default().)
+func WithRegistry(reg *metrics.Registry) Option {
+	return func(s *settings) {
+		if reg != nil {
+			s.registry = reg
+		}
+	}
+}
+
+// WithTaskManager 设置由 Shutdown 关闭的后台任务管理器，默认为 tasks.Default()。
+// (WithTaskManager sets the background task manager shut down by Shutdown; defaults to tasks.Default().)
+func WithTaskManager(m *tasks.Manager) Option {
+	return func(s *settings) {
+		if m != nil {
+			s.tasks = m
+		}
+	}
+}
+
+// WithSpanExporter 添加追踪启用时使用的 span 导出器，span 以批量方式导出。
+// (WithSpanExporter adds a span exporter used when tracing is enabled; spans are exported in batches.)
+func WithSpanExporter(exporter sdktrace.SpanExporter) Option {
+	return func(s *settings) {
+		s.spanExporters = append(s.spanExporters, exporter)
+	}
+}
+
+// WithSampler 设置追踪采样器，默认遵循父 span 的采样决定并按 Trace.SampleRatio 采样根 span。
+// (WithSampler sets the trace sampler; by default parent decisions are honoured and root spans are sampled by Trace.SampleRatio.)
+func WithSampler(sampler sdktrace.Sampler) Option {
+	return func(s *settings) {
+		s.sampler = sampler
+	}
+}
+
+// healthCheck 是通过 WithHealthCheck 提供、由 Bootstrap 注册的检查。
+// (healthCheck is a check given with WithHealthCheck and registered by Bootstrap.)
+type healthCheck struct {
+	name    string
+	check   healthcheck.Check
+	options []healthcheck.CheckOption
+}
+
+// WithHealthCheck 在健康检查注册表中注册一个检查，由调试服务器的 /readyz 端点和启动报告执行。
+// (WithHealthCheck registers a check in the health registry, run by the debug server's /readyz endpoint and the startup report.)
+func WithHealthCheck(name string, check healthcheck.Check, options ...healthcheck.CheckOption) Option {
+	return func(s *settings) {
+		s.healthChecks = append(s.healthChecks, healthCheck{name: name, check: check, options: options})
+	}
+}
+
+// WithHealthRegistry 设置健康检查注册表，默认为新建的空注册表。应用可以继续向其注册检查，或将其挂载到自己的服务器上。
+// (WithHealthRegistry sets the health registry; defaults to a new empty registry. Applications may keep registering checks
+// in it or mount it on their own server.)
+func WithHealthRegistry(registry *healthcheck.Registry) Option {
+	return func(s *settings) {
+		if registry != nil {
+			s.health = registry
+		}
+	}
+}
+
+// WithRedactKeys 添加在 /config 和启动报告中需要脱敏的额外键名片段。
+// (WithRedactKeys adds extra key fragments to redact in /config and the startup report.)
+func WithRedactKeys(keys ...string) Option {
+	return func(s *settings) {
+		s.redactKeys = append(s.redactKeys, keys...)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/debug"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/diagnostics"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/tasks"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/trace"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Runtime 保存 Bootstrap 初始化的各子系统的句柄。未启用的子系统对应字段为 nil。
+// (Runtime holds handles to the subsystems initialized by Bootstrap. Fields of disabled subsystems are nil.)
+type Runtime struct {
+	// Options 是生效的选项，nil 节已替换为默认值。(Options are the effective options, with nil sections replaced by defaults.)
+	Options *Options
+	// Config 是通过 WithConfigManager 接入的配置管理器。(Config is the manager wired with WithConfigManager.)
+	Config config.Manager
+	// Registry 是指标注册表。(Registry is the metrics registry.)
+	Registry *metrics.Registry
+	// Collector 是运行时指标采集器，Metrics.RuntimeStats 为 false 时不采集。
+	// (Collector is the runtime metrics collector; it collects nothing when Metrics.RuntimeStats is false.)
+	Collector *metrics.RuntimeCollector
+	// Pusher 是指标推送器，未配置 Metrics.Push.Exporter 时不推送。
+	// (Pusher is the metrics pusher; it pushes nothing when Metrics.Push.Exporter is unset.)
+	Pusher *metrics.Pusher
+	// TracerProvider 是已设置为 otel 全局提供者的 TracerProvider，追踪关闭时为 nil。
+	// (TracerProvider is the TracerProvider installed as the otel global provider, or nil when tracing is disabled.)
+	TracerProvider *sdktrace.TracerProvider
+	// Debug 是调试服务器，Debug.Enabled 为 false 时为 nil。(Debug is the debug server, or nil when Debug.Enabled is false.)
+	Debug *debug.Server
+	// Tasks 是后台任务管理器，Shutdown 时最先关闭。(Tasks is the background task manager, shut down first by Shutdown.)
+	Tasks *tasks.Manager
+	// Diagnostics 是启动诊断报告，StartupReport 为 false 时为 nil。
+	// (Diagnostics is the startup diagnostics report, or nil when StartupReport is false.)
+	Diagnostics *diagnostics.Document
+	// Health 是健康检查注册表，调试服务器的 /readyz 和启动报告执行其中的检查。
+	// (Health is the health registry whose checks the debug server's /readyz and the startup report run.)
+	Health *healthcheck.Registry
+
+	shutdowns []shutdownStep
+	once      sync.Once
+	err       error
+}
+
+// shutdownStep 是一个有名称的关闭操作。(shutdownStep is a named shutdown action.)
+type shutdownStep struct {
+	name string
+	fn   func(context.Context) error
+}
+
+// Bootstrap 按依赖顺序初始化日志、追踪、指标、健康检查和调试服务器，并在配置了 WithConfigManager 时启用日志热重载：
+// 先配置日志，使后续步骤的日志使用最终格式；追踪先于指标和调试服务器安装，使它们的日志带有 trace 标识；
+// 调试服务器最后启动，此时 /metrics、/healthz 和 /readyz 已可用。任一步骤失败时，已启动的子系统会被逆序关闭。
+// opts 为 nil 时使用 NewOptions()。
+// (Bootstrap initializes logging, tracing, metrics, health checks and the debug server in dependency order, enabling log
+// hot-reload when WithConfigManager is given: logging is configured first so later steps log in the final format; tracing is
+// installed before metrics and the debug server so their logs carry trace identifiers; the debug server starts last, once
+// /metrics, /healthz and /readyz are ready. When a step fails the subsystems already started are shut down in reverse order.
+// nil opts means NewOptions().)
+func Bootstrap(ctx context.Context, opts *Options, options ...Option) (*Runtime, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	opts = opts.withDefaults()
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid sdk options"),
+			lmccerrors.ErrSDKOptionInvalid,
+		)
+	}
+	s := newSettings(options)
+	rt := &Runtime{
+		Options:  opts,
+		Config:   s.configManager,
+		Registry: s.registry,
+		Tasks:    s.tasks,
+		Health:   s.health,
+	}
+	fail := func(err error, step string) (*Runtime, error) {
+		_ = rt.shutdown(context.Background())
+		return nil, lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to bootstrap %s", step), lmccerrors.ErrSDKBootstrap)
+	}
+
+	// 1. 日志 (Logging)
+	if err := log.ReconfigureGlobalLogger(opts.Log); err != nil {
+		return fail(err, "logging")
+	}
+	rt.addShutdown("logging", func(context.Context) error {
+		// 标准输出在部分平台上不支持 Sync，忽略其错误 (Sync is unsupported on stdout on some platforms, so its error is ignored)
+		_ = log.Std().Sync()
+		return nil
+	})
+	if s.configManager != nil {
+		log.RegisterConfigHotReload(s.configManager)
+	}
+
+	// 2. 追踪 (Tracing)
+	if opts.Trace.Enabled {
+		tp, err := newTracerProvider(ctx, opts, s)
+		if err != nil {
+			return fail(err, "tracing")
+		}
+		rt.TracerProvider = tp
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(trace.Propagator)
+		rt.addShutdown("tracing", tp.Shutdown)
+	}
+	untrace, err := trace.Setup(opts.Trace)
+	if err != nil {
+		return fail(err, "tracing")
+	}
+	rt.addShutdown("log correlation", untrace)
+
+	// 3. 指标 (Metrics)
+	for _, c := range rt.Tasks.Collectors(opts.Metrics.Namespace) {
+		if err := rt.Registry.Register(c); err != nil {
+			var are prometheus.AlreadyRegisteredError
+			if !errors.As(err, &are) {
+				return fail(lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to register task metrics"), lmccerrors.ErrMetricsRegister), "metrics")
+			}
+		}
+	}
+	if rt.Collector, err = metrics.NewRuntimeCollector(opts.Metrics, metrics.WithRegistry(rt.Registry)); err != nil {
+		return fail(err, "metrics")
+	}
+	if err := rt.Collector.Start(ctx); err != nil {
+		return fail(err, "metrics")
+	}
+	rt.addShutdown("runtime metrics", rt.Collector.Stop)
+	if rt.Pusher, err = metrics.NewPusher(opts.Metrics, metrics.WithRegistry(rt.Registry)); err != nil {
+		return fail(err, "metrics")
+	}
+	if err := rt.Pusher.Start(ctx); err != nil {
+		return fail(err, "metrics")
+	}
+	rt.addShutdown("metrics push", rt.Pusher.Stop)
+
+	// 4. 健康检查 (Health checks)
+	for _, hc := range s.healthChecks {
+		if err := rt.Health.Register(hc.name, hc.check, hc.options...); err != nil {
+			return fail(err, "health checks")
+		}
+	}
+
+	// 5. 调试服务器 (Debug server)
+	serverOpts := []debug.ServerOption{
+		debug.WithMetricsHandler(rt.Registry.Handler()),
+		debug.WithHealthRegistry(rt.Health),
+		debug.WithVersion(s.version),
+		debug.WithRedactKeys(s.redactKeys...),
+	}
+	if s.configManager != nil {
+		serverOpts = append(serverOpts, debug.WithConfigManager(s.configManager))
+	}
+	if rt.Debug, err = debug.Run(ctx, opts.Debug, serverOpts...); err != nil {
+		return fail(err, "debug server")
+	}
+	if rt.Debug != nil {
+		rt.addShutdown("debug server", rt.Debug.Stop)
+	}
+
+	// 6. 后台任务在最后注册，因此最先关闭 (Background tasks are registered last so they shut down first)
+	rt.addShutdown("tasks", rt.Tasks.Shutdown)
+
+	// 7. 启动诊断 (Startup diagnostics)
+	if opts.StartupReport {
+		reportOpts := []diagnostics.Option{
+			diagnostics.WithVersion(s.version),
+			diagnostics.WithLogOptions(opts.Log),
+			diagnostics.WithRedactKeys(s.redactKeys...),
+			diagnostics.WithHealthRegistry(rt.Health),
+		}
+		if s.configManager != nil {
+			reportOpts = append(reportOpts, diagnostics.WithConfigManager(s.configManager))
+		}
+		if rt.Diagnostics, err = diagnostics.Report(ctx, reportOpts...); err != nil {
+			return fail(err, "startup diagnostics")
+		}
+	}
+	return rt, nil
+}
+
+// newTracerProvider 创建以日志服务标识为资源的 TracerProvider，导出到 Trace.Exporter 配置的导出器和 WithSpanExporter 添加的导出器。
+// (newTracerProvider creates a TracerProvider whose resource is the logging service identity, exporting to the exporter
+// configured by Trace.Exporter and those added with WithSpanExporter.)
+func newTracerProvider(ctx context.Context, opts *Options, s *settings) (*sdktrace.TracerProvider, error) {
+	var tpOpts []sdktrace.TracerProviderOption
+	if s.sampler != nil {
+		tpOpts = append(tpOpts, sdktrace.WithSampler(s.sampler))
+	}
+	for _, exporter := range s.spanExporters {
+		tpOpts = append(tpOpts, sdktrace.WithBatcher(exporter))
+	}
+	return trace.NewTracerProvider(ctx, opts.Trace, opts.Log.Service, tpOpts...)
+}
+
+// addShutdown 登记关闭操作，Shutdown 按登记的逆序执行。
+// (addShutdown records a shutdown action; Shutdown runs them in reverse order.)
+func (rt *Runtime) addShutdown(name string, fn func(context.Context) error) {
+	rt.shutdowns = append(rt.shutdowns, shutdownStep{name: name, fn: fn})
+}
+
+// Logger 返回当前的全局 logger，日志配置热重载后返回新的 logger。
+// (Logger returns the current global logger, which is replaced when the log configuration is hot-reloaded.)
+func (rt *Runtime) Logger() log.Logger {
+	return log.Std()
+}
+
+// CheckHealth 执行健康检查注册表中的所有检查。
+// (CheckHealth runs every check in the health registry.)
+func (rt *Runtime) CheckHealth(ctx context.Context) healthcheck.Report {
+	return rt.Health.Check(ctx)
+}
+
+// HealthHandler 返回执行健康检查的就绪处理器：状态为 ok 或 degraded 时返回 200，为 failing 时返回 503。
+// (HealthHandler returns the readiness handler running the health checks: 200 when the status is ok or degraded and 503 when it is failing.)
+func (rt *Runtime) HealthHandler() http.Handler {
+	return rt.Health.ReadinessHandler()
+}
+
+// Shutdown 按启动的逆序关闭所有子系统：后台任务、调试服务器、指标推送（含最终推送）、运行时采集、追踪（刷新 span）和日志。
+// 单个子系统失败不会阻止其余子系统关闭，所有失败汇总在返回的错误中。ctx 没有截止时间时使用 ShutdownTimeout。
+// 重复调用返回第一次的结果。
+// (Shutdown stops every subsystem in reverse start order: background tasks, the debug server, metrics pushing including the final
+// push, runtime collection, tracing (flushing spans) and logging. A failing subsystem does not keep the others from stopping; every
+// failure is collected into the returned error. ShutdownTimeout applies when ctx has no deadline. Repeated calls return the first result.)
+func (rt *Runtime) Shutdown(ctx context.Context) error {
+	rt.once.Do(func() {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, rt.Options.ShutdownTimeout)
+			defer cancel()
+		}
+		rt.err = rt.shutdown(ctx)
+	})
+	return rt.err
+}
+
+// shutdown 逆序执行已登记的关闭操作。(shutdown runs the recorded shutdown actions in reverse order.)
+func (rt *Runtime) shutdown(ctx context.Context) error {
+	eg := lmccerrors.NewErrorGroup("sdk shutdown had failures")
+	for i := len(rt.shutdowns) - 1; i >= 0; i-- {
+		step := rt.shutdowns[i]
+		if err := step.fn(ctx); err != nil {
+			log.Errorw("Failed to shut down subsystem", "subsystem", step.name, "error", err)
+			eg.Add(lmccerrors.Wrapf(err, "failed to shut down %s", step.name))
+		}
+	}
+	rt.shutdowns = nil
+	if len(eg.Errors()) > 0 {
+		return eg
+	}
+	return nil
+}
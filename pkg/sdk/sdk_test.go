@@ -0,0 +1,189 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the observability bootstrap.
+ */
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/healthcheck"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/metrics"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/sdk"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/tasks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// testOptions 返回只启用调试服务器（随机端口）的选项。(testOptions returns options enabling only the debug server on a random port.)
+func testOptions() *sdk.Options {
+	opts := sdk.NewOptions()
+	opts.Log.OutputPaths = []string{"stderr"}
+	opts.Metrics.Namespace = "app"
+	opts.Debug.Enabled = true
+	opts.Debug.Host = "127.0.0.1"
+	opts.Debug.Port = 0
+	opts.StartupReport = false
+	return opts
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+// TestBootstrap tests that the debug server exposes the registry and the registered health checks, and that Shutdown stops every subsystem.
+// (TestBootstrap 测试调试服务器暴露指标注册表和已注册的健康检查，且 Shutdown 会关闭所有子系统。)
+func TestBootstrap(t *testing.T) {
+	manager := tasks.NewManager()
+	healthy := true
+	rt, err := sdk.Bootstrap(context.Background(), testOptions(),
+		sdk.WithRegistry(metrics.NewRegistry()),
+		sdk.WithTaskManager(manager),
+		sdk.WithVersion("v1.2.3"),
+		sdk.WithHealthCheck("db", func(context.Context) error {
+			if !healthy {
+				return errors.New("connection refused")
+			}
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, rt.Debug)
+	assert.Nil(t, rt.TracerProvider, "tracing is disabled by default")
+	assert.Same(t, manager, rt.Tasks)
+	base := "http://" + rt.Debug.Addr()
+
+	status, body := get(t, base+"/readyz")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"name":"db"`)
+
+	healthy = false
+	status, body = get(t, base+"/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	var health healthcheck.Response
+	require.NoError(t, json.Unmarshal([]byte(body), &health))
+	require.NotNil(t, health.Data)
+	assert.Equal(t, healthcheck.StatusFailing, health.Data.Status)
+	assert.Equal(t, healthcheck.StatusFailing, rt.CheckHealth(context.Background()).Status)
+	status, _ = get(t, base+"/healthz")
+	assert.Equal(t, http.StatusOK, status, "liveness does not run the checks")
+
+	stop := make(chan struct{})
+	require.NoError(t, manager.Go(context.Background(), "worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stop)
+		return ctx.Err()
+	}))
+	_, body = get(t, base+"/metrics")
+	assert.Contains(t, body, "app_tasks_running 1")
+
+	require.NoError(t, rt.Shutdown(context.Background()))
+	<-stop
+	_, err = http.Get(base + "/healthz")
+	assert.Error(t, err, "debug server must be stopped")
+	assert.True(t, lmccerrors.IsCode(manager.Go(context.Background(), "late", func(context.Context) error { return nil }), lmccerrors.ErrTasksClosed))
+	assert.NoError(t, rt.Shutdown(context.Background()), "repeated shutdown returns the first result")
+}
+
+// keepSpansExporter 在关闭后保留已导出的 span。(keepSpansExporter keeps the exported spans after shutdown.)
+type keepSpansExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (e *keepSpansExporter) Shutdown(context.Context) error { return nil }
+
+// TestBootstrapTracing tests that spans are exported through the configured exporter and flushed on shutdown.
+// (TestBootstrapTracing 测试 span 通过配置的导出器导出，并在关闭时刷新。)
+func TestBootstrapTracing(t *testing.T) {
+	opts := testOptions()
+	opts.Debug.Enabled = false
+	opts.Trace.Enabled = true
+	opts.Log.Service.Name = "checkout"
+	exporter := &keepSpansExporter{tracetest.NewInMemoryExporter()}
+
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	rt, err := sdk.Bootstrap(context.Background(), opts,
+		sdk.WithRegistry(metrics.NewRegistry()),
+		sdk.WithTaskManager(tasks.NewManager()),
+		sdk.WithSpanExporter(exporter),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, rt.TracerProvider)
+	assert.Nil(t, rt.Debug)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "charge")
+	span.End()
+	assert.Empty(t, exporter.GetSpans(), "spans are batched until shutdown")
+
+	require.NoError(t, rt.Shutdown(context.Background()))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "charge", spans[0].Name)
+	serviceName, ok := spans[0].Resource.Set().Value("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", serviceName.AsString())
+}
+
+// TestBootstrapWithConfigManager tests loading Options from a config file and reporting the loaded configuration at startup.
+// (TestBootstrapWithConfigManager 测试从配置文件加载 Options，并在启动报告中包含加载的配置。)
+func TestBootstrapWithConfigManager(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: debug\nmetrics:\n  namespace: shop\ndebug:\n  password: hunter2\n"), 0o600))
+
+	opts := sdk.NewOptions()
+	cm, err := config.LoadConfigAndWatch(opts, config.WithConfigFile(path, ""))
+	require.NoError(t, err)
+	assert.Equal(t, "debug", opts.Log.Level)
+	assert.Equal(t, "shop", opts.Metrics.Namespace)
+	assert.Equal(t, "json", opts.Log.Format, "defaults are kept for keys missing from the file")
+
+	opts.Log.OutputPaths = []string{"stderr"}
+	rt, err := sdk.Bootstrap(context.Background(), opts,
+		sdk.WithConfigManager(cm),
+		sdk.WithRegistry(metrics.NewRegistry()),
+		sdk.WithTaskManager(tasks.NewManager()),
+	)
+	require.NoError(t, err)
+	defer rt.Shutdown(context.Background())
+
+	require.NotNil(t, rt.Diagnostics)
+	cfg, err := json.Marshal(rt.Diagnostics.Config)
+	require.NoError(t, err)
+	assert.Contains(t, string(cfg), `"namespace":"shop"`)
+	assert.NotContains(t, string(cfg), "hunter2")
+}
+
+// TestBootstrapInvalidOptions tests that invalid options are rejected with ErrSDKOptionInvalid.
+// (TestBootstrapInvalidOptions 测试无效选项以 ErrSDKOptionInvalid 被拒绝。)
+func TestBootstrapInvalidOptions(t *testing.T) {
+	opts := sdk.NewOptions()
+	opts.Metrics.Namespace = "bad-name"
+	opts.Debug.Port = -1
+
+	_, err := sdk.Bootstrap(context.Background(), opts)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSDKOptionInvalid))
+	assert.Contains(t, err.Error(), "bad-name")
+	assert.Contains(t, err.Error(), "debug port")
+}
@@ -0,0 +1,48 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package secrets defines a Provider abstraction for reading secrets and reacting to
+their rotation at runtime.
+(secrets 包定义了用于读取密钥并在运行时响应其轮换的 Provider 抽象。)
+
+Built-in providers:
+(内置提供者：)
+
+  - EnvProvider:   process environment variables (进程环境变量)
+  - FileProvider:  one secret per file, e.g. /run/secrets (每个文件一个密钥，例如 /run/secrets)
+  - VaultProvider: HashiCorp Vault KV v1/v2 over HTTP (通过 HTTP 访问 HashiCorp Vault KV v1/v2)
+  - AWSProvider:   AWS Secrets Manager with SigV4 signing (使用 SigV4 签名的 AWS Secrets Manager)
+  - GCPProvider:   Google Cloud Secret Manager (Google Cloud Secret Manager)
+
+Remote providers accept "name#field" keys to pick a single entry of a JSON secret.
+Chain combines providers, falling through to the next one only when a secret is not found.
+(远程提供者支持 "name#field" 形式的键，用于从 JSON 密钥中取出单个字段。
+Chain 组合多个提供者，仅在密钥不存在时才查询下一个。)
+
+	p := secrets.NewVaultProvider()
+	password, err := p.Get(ctx, "kv/data/db#password")
+	if err != nil {
+		// handle error (处理错误)
+	}
+
+	// React to rotation without restarting (无需重启即可响应轮换)
+	err = p.Watch(ctx, "kv/data/db#password", func(value string, err error) {
+		if err != nil {
+			log.Warnw("Secret refresh failed", "error", err)
+			return
+		}
+		pool.UpdatePassword(value)
+	})
+
+Any Provider can also resolve ${secret:<name>:<key>} references in configuration files through
+config.WithSecretResolver.
+(任意 Provider 也可以通过 config.WithSecretResolver 解析配置文件中的 ${secret:<name>:<key>} 引用。)
+
+Errors carry the ErrSecretNotFound, ErrSecretProvider or ErrSecretInvalidKey codes from pkg/errors.
+(错误携带 pkg/errors 中的 ErrSecretNotFound、ErrSecretProvider 或 ErrSecretInvalidKey 错误码。)
+*/
+package secrets
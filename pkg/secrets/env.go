@@ -0,0 +1,87 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package secrets
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// EnvOption 是配置环境变量提供者的函数类型。
+// (EnvOption is a function type for configuring the environment variable provider.)
+type EnvOption func(*EnvProvider)
+
+// WithEnvPrefix 设置查找环境变量时使用的前缀，例如前缀 "APP" 会将键 "db_password" 映射为 APP_DB_PASSWORD。
+// (WithEnvPrefix sets the prefix used when looking up variables, e.g. prefix "APP" maps key "db_password" to APP_DB_PASSWORD.)
+func WithEnvPrefix(prefix string) EnvOption {
+	return func(p *EnvProvider) {
+		p.prefix = prefix
+	}
+}
+
+// WithEnvWatchInterval 设置 Watch 的轮询间隔。
+// (WithEnvWatchInterval sets the polling interval of Watch.)
+func WithEnvWatchInterval(interval time.Duration) EnvOption {
+	return func(p *EnvProvider) {
+		p.interval = interval
+	}
+}
+
+// EnvProvider 从进程环境变量中读取密钥。
+// (EnvProvider reads secrets from process environment variables.)
+type EnvProvider struct {
+	prefix   string
+	interval time.Duration
+}
+
+// NewEnvProvider 创建环境变量提供者。
+// (NewEnvProvider creates an environment variable provider.)
+func NewEnvProvider(opts ...EnvOption) *EnvProvider {
+	p := &EnvProvider{interval: DefaultWatchInterval}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name 返回提供者名称。(Name returns the provider name.)
+func (p *EnvProvider) Name() string {
+	return "env"
+}
+
+// varName 将键名转换为环境变量名。
+// (varName converts a key into an environment variable name.)
+func (p *EnvProvider) varName(key string) string {
+	name := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_", "/", "_").Replace(key))
+	if p.prefix != "" {
+		name = strings.ToUpper(p.prefix) + "_" + name
+	}
+	return name
+}
+
+// Get 读取环境变量的值。(Get reads the value of the environment variable.)
+func (p *EnvProvider) Get(_ context.Context, key string) (string, error) {
+	if key == "" {
+		return "", lmccerrors.NewWithCode(lmccerrors.ErrSecretInvalidKey, "secret key must not be empty")
+	}
+	value, ok := os.LookupEnv(p.varName(key))
+	if !ok {
+		return "", notFound(p.Name(), key)
+	}
+	return value, nil
+}
+
+// Watch 轮询环境变量的变化。(Watch polls the environment variable for changes.)
+func (p *EnvProvider) Watch(ctx context.Context, key string, fn WatchFunc) error {
+	return pollWatch(ctx, p.interval, func(ctx context.Context) (string, error) {
+		return p.Get(ctx, key)
+	}, fn)
+}
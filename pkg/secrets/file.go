@@ -0,0 +1,106 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// FileOption 是配置文件提供者的函数类型。
+// (FileOption is a function type for configuring the file provider.)
+type FileOption func(*FileProvider)
+
+// WithFileBaseDir 设置键名的基础目录，例如 Docker/Kubernetes 的 /run/secrets。设置后键名必须是基础目录内的相对路径，
+// 绝对路径和跳出基础目录的键名（例如 "../etc/passwd"）会被拒绝。未设置时键名是文件路径，应只来自可信的配置。
+// (WithFileBaseDir sets the base directory of keys, e.g. /run/secrets for Docker/Kubernetes. Keys must then be relative paths
+// inside the base directory; absolute keys and keys escaping it, such as "../etc/passwd", are rejected. Without a base
+// directory keys are file paths and must come from trusted configuration only.)
+func WithFileBaseDir(dir string) FileOption {
+	return func(p *FileProvider) {
+		p.baseDir = dir
+	}
+}
+
+// WithFileWatchInterval 设置 Watch 的轮询间隔。
+// 使用轮询而非文件事件，是因为 Kubernetes 通过替换符号链接来轮换挂载的密钥。
+// (WithFileWatchInterval sets the polling interval of Watch.)
+// (Polling is used instead of file events because Kubernetes rotates mounted secrets by swapping symlinks.)
+func WithFileWatchInterval(interval time.Duration) FileOption {
+	return func(p *FileProvider) {
+		p.interval = interval
+	}
+}
+
+// FileProvider 从文件中读取密钥，每个文件保存一个密钥值。
+// (FileProvider reads secrets from files, one secret value per file.)
+type FileProvider struct {
+	baseDir  string
+	interval time.Duration
+}
+
+// NewFileProvider 创建文件提供者。
+// (NewFileProvider creates a file provider.)
+func NewFileProvider(opts ...FileOption) *FileProvider {
+	p := &FileProvider{interval: DefaultWatchInterval}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name 返回提供者名称。(Name returns the provider name.)
+func (p *FileProvider) Name() string {
+	return "file"
+}
+
+// path 将键名解析为文件路径，设置了基础目录时拒绝解析到其外部的键名。
+// (path resolves a key into a file path, rejecting keys that resolve outside the base directory when one is set.)
+func (p *FileProvider) path(key string) (string, error) {
+	if p.baseDir == "" {
+		return key, nil
+	}
+	if !filepath.IsLocal(key) {
+		return "", lmccerrors.ErrorfWithCode(lmccerrors.ErrSecretInvalidKey,
+			"secret key '%s' must be a relative path inside the base directory", key)
+	}
+	return filepath.Join(p.baseDir, key), nil
+}
+
+// Get 读取文件内容，并去除末尾的换行符。
+// (Get reads the file content with trailing newlines removed.)
+func (p *FileProvider) Get(_ context.Context, key string) (string, error) {
+	if key == "" {
+		return "", lmccerrors.NewWithCode(lmccerrors.ErrSecretInvalidKey, "secret key must not be empty")
+	}
+	path, err := p.path(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", notFound(p.Name(), key)
+		}
+		return "", providerError(err, p.Name(), key)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Watch 轮询文件内容的变化。(Watch polls the file content for changes.)
+func (p *FileProvider) Watch(ctx context.Context, key string, fn WatchFunc) error {
+	return pollWatch(ctx, p.interval, func(ctx context.Context) (string, error) {
+		return p.Get(ctx, key)
+	}, fn)
+}
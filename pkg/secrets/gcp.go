@@ -0,0 +1,226 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// gcpMetadataTokenURL 是 GCE/GKE 元数据服务器上默认服务账号的令牌地址。
+// (gcpMetadataTokenURL is the token URL of the default service account on the GCE/GKE metadata server.)
+const gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// TokenSource 返回用于访问云服务 API 的 OAuth2 访问令牌。
+// (TokenSource returns an OAuth2 access token for calling cloud service APIs.)
+type TokenSource func(ctx context.Context) (string, error)
+
+// GCPOption 是配置 GCP Secret Manager 提供者的函数类型。
+// (GCPOption is a function type for configuring the GCP Secret Manager provider.)
+type GCPOption func(*GCPProvider)
+
+// WithGCPProject 设置短键名所属的项目，默认读取 GOOGLE_CLOUD_PROJECT。
+// (WithGCPProject sets the project of short keys; GOOGLE_CLOUD_PROJECT is used by default.)
+func WithGCPProject(project string) GCPOption {
+	return func(p *GCPProvider) {
+		p.project = project
+	}
+}
+
+// WithGCPTokenSource 设置访问令牌来源，默认从元数据服务器获取。
+// (WithGCPTokenSource sets the access token source; tokens are fetched from the metadata server by default.)
+func WithGCPTokenSource(ts TokenSource) GCPOption {
+	return func(p *GCPProvider) {
+		p.tokenSource = ts
+	}
+}
+
+// WithGCPEndpoint 覆盖服务端点。
+// (WithGCPEndpoint overrides the service endpoint.)
+func WithGCPEndpoint(endpoint string) GCPOption {
+	return func(p *GCPProvider) {
+		p.endpoint = strings.TrimRight(endpoint, "/")
+	}
+}
+
+// WithGCPHTTPClient 设置使用的 HTTP 客户端。
+// (WithGCPHTTPClient sets the HTTP client to use.)
+func WithGCPHTTPClient(client *http.Client) GCPOption {
+	return func(p *GCPProvider) {
+		p.client = client
+	}
+}
+
+// WithGCPWatchInterval 设置 Watch 的轮询间隔。
+// (WithGCPWatchInterval sets the polling interval of Watch.)
+func WithGCPWatchInterval(interval time.Duration) GCPOption {
+	return func(p *GCPProvider) {
+		p.interval = interval
+	}
+}
+
+// GCPProvider 从 Google Cloud Secret Manager 读取密钥。
+// 键可以是完整资源名 "projects/p/secrets/s/versions/v"，也可以是短名 "s" 或 "s@version"，
+// 均可追加 "#field" 以从 JSON 密钥中取出单个字段。
+// (GCPProvider reads secrets from Google Cloud Secret Manager.)
+// (Keys can be a full resource name "projects/p/secrets/s/versions/v" or a short name "s" or "s@version";)
+// (either form accepts a "#field" suffix to select a single entry of a JSON secret.)
+type GCPProvider struct {
+	project     string
+	endpoint    string
+	tokenSource TokenSource
+	client      *http.Client
+	interval    time.Duration
+}
+
+// NewGCPProvider 创建 GCP Secret Manager 提供者。
+// (NewGCPProvider creates a GCP Secret Manager provider.)
+func NewGCPProvider(opts ...GCPOption) *GCPProvider {
+	p := &GCPProvider{
+		project:  os.Getenv("GOOGLE_CLOUD_PROJECT"),
+		endpoint: "https://secretmanager.googleapis.com",
+		client:   newDefaultHTTPClient(),
+		interval: DefaultWatchInterval,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.tokenSource == nil {
+		p.tokenSource = newMetadataTokenSource(p.client)
+	}
+	return p
+}
+
+// Name 返回提供者名称。(Name returns the provider name.)
+func (p *GCPProvider) Name() string {
+	return "gcp"
+}
+
+// resourceName 将键名解析为密钥版本的完整资源名。
+// (resourceName resolves a key into the full resource name of a secret version.)
+func (p *GCPProvider) resourceName(name string) (string, error) {
+	if strings.HasPrefix(name, "projects/") {
+		if !strings.Contains(name, "/versions/") {
+			name += "/versions/latest"
+		}
+		return name, nil
+	}
+	if p.project == "" {
+		return "", lmccerrors.ErrorfWithCode(lmccerrors.ErrSecretInvalidKey, "gcp project is required for short secret name '%s'", name)
+	}
+	version := "latest"
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name, version = name[:i], name[i+1:]
+	}
+	return "projects/" + p.project + "/secrets/" + name + "/versions/" + version, nil
+}
+
+// gcpAccessResponse 是 AccessSecretVersion 的响应体。
+// (gcpAccessResponse is the response body of AccessSecretVersion.)
+type gcpAccessResponse struct {
+	Payload struct {
+		Data string `json:"data"`
+	} `json:"payload"`
+}
+
+// Get 读取 GCP Secret Manager 中的密钥。(Get reads the secret from GCP Secret Manager.)
+func (p *GCPProvider) Get(ctx context.Context, key string) (string, error) {
+	name, field := splitField(key)
+	if name == "" {
+		return "", lmccerrors.NewWithCode(lmccerrors.ErrSecretInvalidKey, "gcp secret name must not be empty")
+	}
+	resource, err := p.resourceName(name)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := p.tokenSource(ctx)
+	if err != nil {
+		return "", providerError(err, p.Name(), key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/v1/"+resource+":access", nil)
+	if err != nil {
+		return "", providerError(err, p.Name(), key)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := doRequest(p.client, req)
+	if err != nil {
+		var se *statusError
+		if errors.As(err, &se) && se.StatusCode == http.StatusNotFound {
+			return "", notFound(p.Name(), key)
+		}
+		return "", providerError(err, p.Name(), key)
+	}
+
+	var resp gcpAccessResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", providerError(err, p.Name(), key)
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", providerError(err, p.Name(), key)
+	}
+	return extractField(p.Name(), key, string(data), field)
+}
+
+// Watch 轮询 GCP Secret Manager 中密钥的变化。(Watch polls GCP Secret Manager for changes of the secret.)
+func (p *GCPProvider) Watch(ctx context.Context, key string, fn WatchFunc) error {
+	return pollWatch(ctx, p.interval, func(ctx context.Context) (string, error) {
+		return p.Get(ctx, key)
+	}, fn)
+}
+
+// newMetadataTokenSource 创建从元数据服务器获取并缓存令牌的 TokenSource。
+// (newMetadataTokenSource creates a TokenSource that fetches and caches tokens from the metadata server.)
+func newMetadataTokenSource(client *http.Client) TokenSource {
+	var (
+		mu      sync.Mutex
+		token   string
+		expires time.Time
+	)
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// 提前一分钟刷新，避免令牌在请求途中过期 (Refresh a minute early so the token does not expire mid-request)
+		if token != "" && time.Now().Add(time.Minute).Before(expires) {
+			return token, nil
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Metadata-Flavor", "Google")
+		body, err := doRequest(client, req)
+		if err != nil {
+			return "", lmccerrors.Wrap(err, "failed to fetch access token from metadata server")
+		}
+
+		var resp struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int    `json:"expires_in"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return "", lmccerrors.Wrap(err, "failed to decode metadata server token response")
+		}
+		token = resp.AccessToken
+		expires = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+		return token, nil
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// defaultHTTPTimeout 是远程提供者默认 HTTP 客户端的超时时间。
+// (defaultHTTPTimeout is the timeout of the default HTTP client of remote providers.)
+const defaultHTTPTimeout = 10 * time.Second
+
+// maxResponseSize 限制远程提供者响应体的大小。
+// (maxResponseSize limits the size of response bodies from remote providers.)
+const maxResponseSize = 1 << 20
+
+// newDefaultHTTPClient 创建远程提供者使用的默认 HTTP 客户端。
+// (newDefaultHTTPClient creates the default HTTP client used by remote providers.)
+func newDefaultHTTPClient() *http.Client {
+	return &http.Client{Timeout: defaultHTTPTimeout}
+}
+
+// doRequest 发送请求并读取响应体，非 2xx 状态码以 statusError 返回。
+// (doRequest sends the request and reads the body; non-2xx status codes are returned as statusError.)
+func doRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &statusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+	return body, nil
+}
+
+// statusError 表示远程提供者返回了非成功的 HTTP 状态码。
+// (statusError represents a non-successful HTTP status code returned by a remote provider.)
+type statusError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error 实现 error 接口。(Error implements the error interface.)
+func (e *statusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// splitField 将 "name#field" 形式的键拆分为名称和字段。
+// (splitField splits a key of the form "name#field" into name and field.)
+func splitField(key string) (string, string) {
+	if i := strings.LastIndex(key, "#"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return key, ""
+}
+
+// extractField 从 JSON 对象中提取字段；field 为空时原样返回 raw。
+// (extractField extracts a field from a JSON object; raw is returned unchanged when field is empty.)
+func extractField(provider, key, raw, field string) (string, error) {
+	if field == "" {
+		return raw, nil
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil {
+		return "", lmccerrors.WithCode(
+			lmccerrors.Wrapf(err, "secret '%s' from %s provider is not a JSON object", key, provider),
+			lmccerrors.ErrSecretInvalidKey,
+		)
+	}
+	return fieldValue(provider, key, obj, field)
+}
+
+// fieldValue 从已解码的对象中读取字段并转换为字符串。
+// (fieldValue reads a field from a decoded object and converts it to a string.)
+func fieldValue(provider, key string, obj map[string]any, field string) (string, error) {
+	v, ok := obj[field]
+	if !ok {
+		return "", notFound(provider, key)
+	}
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	case nil:
+		return "", nil
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return "", providerError(err, provider, key)
+		}
+		return string(data), nil
+	}
+}
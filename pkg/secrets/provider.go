@@ -0,0 +1,147 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package secrets
+
+import (
+	"context"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// DefaultWatchInterval 是基于轮询的 Watch 默认的检查间隔。
+// (DefaultWatchInterval is the default check interval of polling-based Watch implementations.)
+const DefaultWatchInterval = 30 * time.Second
+
+// WatchFunc 是密钥值变化时调用的回调。
+// 值成功读取且与上次不同时 err 为 nil；读取失败时 value 为上次已知的值。
+// (WatchFunc is the callback invoked when a secret value changes.)
+// (err is nil when a value different from the previous one was read; on read failure value holds the last known value.)
+type WatchFunc func(value string, err error)
+
+// Provider 是密钥提供者的抽象。
+// (Provider is the abstraction of a secret provider.)
+type Provider interface {
+	// Name 返回提供者的名称，例如 "env"、"file"、"vault"。
+	// (Name returns the name of the provider, e.g. "env", "file", "vault".)
+	Name() string
+
+	// Get 读取密钥的当前值。密钥不存在时返回带 ErrSecretNotFound 的错误。
+	// (Get reads the current value of a secret. It returns an error coded ErrSecretNotFound when the secret does not exist.)
+	Get(ctx context.Context, key string) (string, error)
+
+	// Watch 在密钥值轮换时调用 fn，直到 ctx 被取消。
+	// 初次读取是同步的，失败时直接返回错误；之后的变化在后台 goroutine 中通知。
+	// (Watch invokes fn whenever the secret value rotates, until ctx is cancelled.)
+	// (The initial read is synchronous and its error is returned; later changes are reported from a background goroutine.)
+	Watch(ctx context.Context, key string, fn WatchFunc) error
+}
+
+// pollWatch 通过定期调用 get 实现 Watch 语义。
+// (pollWatch implements Watch semantics by calling get periodically.)
+func pollWatch(ctx context.Context, interval time.Duration, get func(ctx context.Context) (string, error), fn WatchFunc) error {
+	if fn == nil {
+		return lmccerrors.NewWithCode(lmccerrors.ErrSecretInvalidKey, "watch callback must not be nil")
+	}
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	last, err := get(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		failing := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			value, err := get(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// 只在首次失败时通知，避免每个周期重复报告 (Report only the first failure to avoid repeating every tick)
+				if !failing {
+					failing = true
+					fn(last, err)
+				}
+				continue
+			}
+			failing = false
+			if value != last {
+				last = value
+				fn(value, nil)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// notFound 构造一个带 ErrSecretNotFound 的错误。
+// (notFound builds an error coded ErrSecretNotFound.)
+func notFound(provider, key string) error {
+	return lmccerrors.ErrorfWithCode(lmccerrors.ErrSecretNotFound, "secret '%s' not found in %s provider", key, provider)
+}
+
+// providerError 将后端错误包装为带 ErrSecretProvider 的错误。
+// (providerError wraps a backend error as an error coded ErrSecretProvider.)
+func providerError(err error, provider, key string) error {
+	return lmccerrors.WithCode(
+		lmccerrors.Wrapf(err, "failed to read secret '%s' from %s provider", key, provider),
+		lmccerrors.ErrSecretProvider,
+	)
+}
+
+// chain 按顺序查询多个提供者。
+// (chain queries multiple providers in order.)
+type chain struct {
+	providers []Provider
+}
+
+// Chain 返回一个按顺序查询 providers 的提供者，返回第一个找到的值。
+// 仅当密钥不存在时才会继续查询下一个提供者，其他错误会立即返回。
+// (Chain returns a provider that queries providers in order and returns the first value found.)
+// (Only a not-found result falls through to the next provider; other errors are returned immediately.)
+func Chain(providers ...Provider) Provider {
+	return &chain{providers: providers}
+}
+
+// Name 返回提供者名称。(Name returns the provider name.)
+func (c *chain) Name() string {
+	return "chain"
+}
+
+// Get 依次从各提供者读取密钥。(Get reads the secret from each provider in turn.)
+func (c *chain) Get(ctx context.Context, key string) (string, error) {
+	for _, p := range c.providers {
+		value, err := p.Get(ctx, key)
+		if err == nil {
+			return value, nil
+		}
+		if !lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound) {
+			return "", err
+		}
+	}
+	return "", notFound(c.Name(), key)
+}
+
+// Watch 轮询整条链以检测变化。(Watch polls the whole chain to detect changes.)
+func (c *chain) Watch(ctx context.Context, key string, fn WatchFunc) error {
+	return pollWatch(ctx, DefaultWatchInterval, func(ctx context.Context) (string, error) {
+		return c.Get(ctx, key)
+	}, fn)
+}
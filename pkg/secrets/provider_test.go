@@ -0,0 +1,136 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the env and file providers, Chain and polling Watch.
+ */
+
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEnvProvider tests reading secrets from environment variables.
+// (TestEnvProvider 测试从环境变量读取密钥。)
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("APP_DB_PASSWORD", "s3cret")
+
+	p := NewEnvProvider(WithEnvPrefix("app"))
+	assert.Equal(t, "env", p.Name())
+
+	value, err := p.Get(context.Background(), "db.password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	_, err = p.Get(context.Background(), "missing")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+
+	_, err = p.Get(context.Background(), "")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretInvalidKey))
+}
+
+// TestFileProvider tests reading secrets from files.
+// (TestFileProvider 测试从文件读取密钥。)
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db_pass"), []byte("hunter2\n"), 0o600))
+
+	p := NewFileProvider(WithFileBaseDir(dir))
+
+	value, err := p.Get(context.Background(), "db_pass")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value, "trailing newline should be trimmed")
+
+	for _, key := range []string{filepath.Join(dir, "db_pass"), "../" + filepath.Base(dir) + "/db_pass", "nested/../../db_pass"} {
+		_, err = p.Get(context.Background(), key)
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretInvalidKey), "key %q escapes the base directory: %v", key, err)
+	}
+
+	value, err = NewFileProvider().Get(context.Background(), filepath.Join(dir, "db_pass"))
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value, "without a base directory keys are trusted paths")
+
+	_, err = p.Get(context.Background(), "missing")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+}
+
+// TestChain tests that Chain falls through only on not-found errors.
+// (TestChain 测试 Chain 仅在未找到时继续查询下一个提供者。)
+func TestChain(t *testing.T) {
+	t.Setenv("API_TOKEN", "from-env")
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file_only"), []byte("from-file"), 0o600))
+
+	c := Chain(NewEnvProvider(), NewFileProvider(WithFileBaseDir(dir)))
+
+	value, err := c.Get(context.Background(), "api_token")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", value)
+
+	value, err = c.Get(context.Background(), "file_only")
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", value)
+
+	_, err = c.Get(context.Background(), "nowhere")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+}
+
+// TestFileProviderWatch tests rotation callbacks when a secret file changes.
+// (TestFileProviderWatch 测试密钥文件变化时的轮换回调。)
+func TestFileProviderWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	p := NewFileProvider(WithFileBaseDir(dir), WithFileWatchInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string, 4)
+	failures := make(chan error, 4)
+	err := p.Watch(ctx, "token", func(value string, err error) {
+		if err != nil {
+			failures <- err
+			return
+		}
+		changes <- value
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	select {
+	case v := <-changes:
+		assert.Equal(t, "v2", v)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for rotation callback")
+	}
+
+	require.NoError(t, os.Remove(path))
+	select {
+	case err := <-failures:
+		assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for failure callback")
+	}
+}
+
+// TestWatchInitialError tests that Watch returns the initial read error synchronously.
+// (TestWatchInitialError 测试 Watch 同步返回初次读取的错误。)
+func TestWatchInitialError(t *testing.T) {
+	p := NewEnvProvider()
+	err := p.Watch(context.Background(), "definitely_not_set_secret", func(string, error) {})
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+
+	err = p.Watch(context.Background(), "path", nil)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretInvalidKey))
+}
@@ -0,0 +1,164 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the Vault, AWS and GCP providers against fake HTTP backends.
+ */
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVaultProvider tests reading KV v1 and KV v2 secrets.
+// (TestVaultProvider 测试读取 KV v1 和 KV v2 密钥。)
+func TestVaultProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "root-token", r.Header.Get("X-Vault-Token"))
+		switch r.URL.Path {
+		case "/v1/kv/data/db":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"p@ss","user":"app"},"metadata":{"version":3}}}`))
+		case "/v1/secret/api":
+			_, _ = w.Write([]byte(`{"data":{"token":"abc"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := NewVaultProvider(WithVaultAddress(srv.URL), WithVaultToken("root-token"))
+	ctx := context.Background()
+
+	value, err := p.Get(ctx, "kv/data/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "p@ss", value)
+
+	value, err = p.Get(ctx, "secret/api")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", value, "single-field secrets return the field value")
+
+	value, err = p.Get(ctx, "kv/data/db")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"password":"p@ss","user":"app"}`, value)
+
+	_, err = p.Get(ctx, "kv/data/db#missing")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+
+	_, err = p.Get(ctx, "kv/data/other#password")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+}
+
+// TestAWSProvider tests reading secrets from a fake Secrets Manager endpoint.
+// (TestAWSProvider 测试从模拟的 Secrets Manager 端点读取密钥。)
+func TestAWSProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+
+		body, _ := io.ReadAll(r.Body)
+		var in map[string]string
+		require.NoError(t, json.Unmarshal(body, &in))
+
+		switch in["SecretId"] {
+		case "prod/db":
+			_, _ = w.Write([]byte(`{"SecretString":"{\"password\":\"pw\",\"port\":5432}"}`))
+		case "prod/cert":
+			_, _ = w.Write([]byte(`{"SecretBinary":"` + base64.StdEncoding.EncodeToString([]byte("binary")) + `"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := NewAWSProvider(
+		WithAWSRegion("us-east-1"),
+		WithAWSCredentials("AKID", "SECRET", "session"),
+		WithAWSEndpoint(srv.URL),
+	)
+	ctx := context.Background()
+
+	value, err := p.Get(ctx, "prod/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "pw", value)
+
+	value, err = p.Get(ctx, "prod/db#port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", value)
+
+	value, err = p.Get(ctx, "prod/cert")
+	require.NoError(t, err)
+	assert.Equal(t, "binary", value)
+
+	_, err = p.Get(ctx, "prod/none")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+}
+
+// TestSignV4 tests the signer against the "get-vanilla" case of the AWS SigV4 test suite.
+// (TestSignV4 使用 AWS SigV4 测试套件中的 "get-vanilla" 用例测试签名。)
+func TestSignV4(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.amazonaws.com/", nil)
+	ts := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	signV4(req, nil, "service", "us-east-1", "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "", ts)
+
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+			"SignedHeaders=host;x-amz-date, "+
+			"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+// TestGCPProvider tests reading secrets from a fake Secret Manager endpoint.
+// (TestGCPProvider 测试从模拟的 Secret Manager 端点读取密钥。)
+func TestGCPProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/v1/projects/demo/secrets/db/versions/latest:access":
+			data := base64.StdEncoding.EncodeToString([]byte(`{"password":"gcp-pw"}`))
+			_, _ = w.Write([]byte(`{"payload":{"data":"` + data + `"}}`))
+		case "/v1/projects/other/secrets/api/versions/2:access":
+			_, _ = w.Write([]byte(`{"payload":{"data":"` + base64.StdEncoding.EncodeToString([]byte("v2")) + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p := NewGCPProvider(
+		WithGCPProject("demo"),
+		WithGCPEndpoint(srv.URL),
+		WithGCPTokenSource(func(context.Context) (string, error) { return "test-token", nil }),
+	)
+	ctx := context.Background()
+
+	value, err := p.Get(ctx, "db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "gcp-pw", value)
+
+	value, err = p.Get(ctx, "projects/other/secrets/api/versions/2")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", value)
+
+	_, err = p.Get(ctx, "db@7")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretNotFound))
+
+	_, err = NewGCPProvider(WithGCPProject(""), WithGCPTokenSource(func(context.Context) (string, error) { return "", nil })).Get(ctx, "db")
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrSecretInvalidKey))
+}
@@ -0,0 +1,173 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+)
+
+// VaultOption 是配置 Vault 提供者的函数类型。
+// (VaultOption is a function type for configuring the Vault provider.)
+type VaultOption func(*VaultProvider)
+
+// WithVaultAddress 设置 Vault 服务器地址，默认读取 VAULT_ADDR。
+// (WithVaultAddress sets the Vault server address; VAULT_ADDR is used by default.)
+func WithVaultAddress(addr string) VaultOption {
+	return func(p *VaultProvider) {
+		p.address = strings.TrimRight(addr, "/")
+	}
+}
+
+// WithVaultToken 设置访问令牌，默认读取 VAULT_TOKEN。
+// (WithVaultToken sets the access token; VAULT_TOKEN is used by default.)
+func WithVaultToken(token string) VaultOption {
+	return func(p *VaultProvider) {
+		p.token = token
+	}
+}
+
+// WithVaultNamespace 设置 Vault Enterprise 命名空间，默认读取 VAULT_NAMESPACE。
+// (WithVaultNamespace sets the Vault Enterprise namespace; VAULT_NAMESPACE is used by default.)
+func WithVaultNamespace(namespace string) VaultOption {
+	return func(p *VaultProvider) {
+		p.namespace = namespace
+	}
+}
+
+// WithVaultHTTPClient 设置使用的 HTTP 客户端。
+// (WithVaultHTTPClient sets the HTTP client to use.)
+func WithVaultHTTPClient(client *http.Client) VaultOption {
+	return func(p *VaultProvider) {
+		p.client = client
+	}
+}
+
+// WithVaultWatchInterval 设置 Watch 的轮询间隔。
+// (WithVaultWatchInterval sets the polling interval of Watch.)
+func WithVaultWatchInterval(interval time.Duration) VaultOption {
+	return func(p *VaultProvider) {
+		p.interval = interval
+	}
+}
+
+// VaultProvider 通过 HTTP API 从 HashiCorp Vault 的 KV 引擎读取密钥。
+// 键的格式为 "path#field"，例如 "kv/data/db#password"（KV v2）或 "secret/db#password"（KV v1）。
+// 省略 field 时，若密钥只有一个字段则返回该字段，否则返回整个数据对象的 JSON。
+// (VaultProvider reads secrets from HashiCorp Vault KV engines over the HTTP API.)
+// (Keys have the form "path#field", e.g. "kv/data/db#password" (KV v2) or "secret/db#password" (KV v1).)
+// (Without a field, the only field is returned for single-field secrets, otherwise the whole data object as JSON.)
+type VaultProvider struct {
+	address   string
+	token     string
+	namespace string
+	client    *http.Client
+	interval  time.Duration
+}
+
+// NewVaultProvider 创建 Vault 提供者。
+// (NewVaultProvider creates a Vault provider.)
+func NewVaultProvider(opts ...VaultOption) *VaultProvider {
+	p := &VaultProvider{
+		address:   strings.TrimRight(os.Getenv("VAULT_ADDR"), "/"),
+		token:     os.Getenv("VAULT_TOKEN"),
+		namespace: os.Getenv("VAULT_NAMESPACE"),
+		client:    newDefaultHTTPClient(),
+		interval:  DefaultWatchInterval,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name 返回提供者名称。(Name returns the provider name.)
+func (p *VaultProvider) Name() string {
+	return "vault"
+}
+
+// vaultResponse 是 Vault 读取接口的响应体。
+// (vaultResponse is the response body of the Vault read API.)
+type vaultResponse struct {
+	Data map[string]any `json:"data"`
+}
+
+// Get 读取 Vault 中的密钥。(Get reads the secret from Vault.)
+func (p *VaultProvider) Get(ctx context.Context, key string) (string, error) {
+	path, field := splitField(key)
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "", lmccerrors.NewWithCode(lmccerrors.ErrSecretInvalidKey, "vault secret path must not be empty")
+	}
+	if p.address == "" {
+		return "", lmccerrors.NewWithCode(lmccerrors.ErrSecretProvider, "vault address is not configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+"/v1/"+path, nil)
+	if err != nil {
+		return "", providerError(err, p.Name(), key)
+	}
+	if p.token != "" {
+		req.Header.Set("X-Vault-Token", p.token)
+	}
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	body, err := doRequest(p.client, req)
+	if err != nil {
+		var se *statusError
+		if errors.As(err, &se) && se.StatusCode == http.StatusNotFound {
+			return "", notFound(p.Name(), key)
+		}
+		return "", providerError(err, p.Name(), key)
+	}
+
+	var resp vaultResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", providerError(err, p.Name(), key)
+	}
+
+	data := resp.Data
+	// KV v2 将数据嵌套在 data.data 中，并附带 metadata (KV v2 nests data under data.data alongside metadata)
+	if inner, ok := data["data"].(map[string]any); ok {
+		if _, hasMeta := data["metadata"]; hasMeta {
+			data = inner
+		}
+	}
+	if data == nil {
+		return "", notFound(p.Name(), key)
+	}
+
+	if field != "" {
+		return fieldValue(p.Name(), key, data, field)
+	}
+	if len(data) == 1 {
+		for k := range data {
+			return fieldValue(p.Name(), key, data, k)
+		}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", providerError(err, p.Name(), key)
+	}
+	return string(raw), nil
+}
+
+// Watch 轮询 Vault 中密钥的变化。(Watch polls Vault for changes of the secret.)
+func (p *VaultProvider) Watch(ctx context.Context, key string, fn WatchFunc) error {
+	return pollWatch(ctx, p.interval, func(ctx context.Context) (string, error) {
+		return p.Get(ctx, key)
+	}, fn)
+}
@@ -113,6 +113,13 @@ type LoggerMiddlewareConfig struct {
 	
 	// MaxBodySize 最大请求体大小 (Maximum request body size)
 	MaxBodySize int `yaml:"max-body-size" mapstructure:"max-body-size" json:"max_body_size"`
+	
+	// SampleRate 成功（2xx）请求的采样比例，取值 (0, 1]，0 表示未设置并按 1 处理 (Fraction of successful 2xx requests logged, in (0, 1]; 0 means unset and is treated as 1)
+	// 非 2xx 响应、出错和慢请求始终记录 (Non-2xx responses, errors and slow requests are always logged)
+	SampleRate float64 `yaml:"sample-rate" mapstructure:"sample-rate" json:"sample_rate"`
+	
+	// SlowThreshold 慢请求阈值，超过时始终以警告级别记录，0 表示不启用 (Slow request threshold; slower requests are always logged at warn level, 0 disables it)
+	SlowThreshold time.Duration `yaml:"slow-threshold" mapstructure:"slow-threshold" json:"slow_threshold"`
 }
 
 // RecoveryMiddlewareConfig 恢复中间件配置 (Recovery middleware configuration)
@@ -142,7 +149,7 @@ type RateLimitMiddlewareConfig struct {
 	Burst int `yaml:"burst" mapstructure:"burst" json:"burst"`
 	
 	// KeyFunc 键函数类型 (Key function type)
-	// 支持的值: ip, user, custom (Supported values: ip, user, custom)
+	// 支持的值: ip, user, custom；ip 按客户端 IP 限流，其余值共享一个令牌桶 (Supported values: ip, user, custom; ip limits per client IP, other values share one token bucket)
 	KeyFunc string `yaml:"key-func" mapstructure:"key-func" json:"key_func"`
 }
 
@@ -208,6 +215,9 @@ type GracefulShutdownConfig struct {
 	
 	// WaitTime 等待时间 (Wait time)
 	WaitTime time.Duration `yaml:"wait-time" mapstructure:"wait-time" json:"wait_time"`
+
+	// RetryAfter 排空期间拒绝新请求时 Retry-After 响应头建议的重试间隔 (Retry interval suggested in the Retry-After header when rejecting requests while draining)
+	RetryAfter time.Duration `yaml:"retry-after" mapstructure:"retry-after" json:"retry_after"`
 }
 
 // DefaultServerConfig 返回默认服务器配置 (Return default server configuration)
@@ -231,10 +241,13 @@ func DefaultServerConfig() *ServerConfig {
 		},
 		Middleware: MiddlewareConfig{
 			Logger: LoggerMiddlewareConfig{
-				Enabled:     true,
-				Format:      "json",
-				IncludeBody: false,
-				MaxBodySize: 1024,
+				Enabled:       true,
+				SkipPaths:     []string{"/health", "/metrics"},
+				Format:        "json",
+				IncludeBody:   false,
+				MaxBodySize:   1024,
+				SampleRate:    1,
+				SlowThreshold: time.Second,
 			},
 			Recovery: RecoveryMiddlewareConfig{
 				Enabled:             true,
@@ -263,8 +276,9 @@ func DefaultServerConfig() *ServerConfig {
 		},
 		GracefulShutdown: GracefulShutdownConfig{
 			Enabled:  true,
-			Timeout:  30 * time.Second,
-			WaitTime: 5 * time.Second,
+			Timeout:    30 * time.Second,
+			WaitTime:   5 * time.Second,
+			RetryAfter: 5 * time.Second,
 		},
 		Plugins: make(map[string]interface{}),
 	}
@@ -305,6 +319,22 @@ func (c *ServerConfig) Validate() error {
 	if c.MaxHeaderBytes <= 0 {
 		c.MaxHeaderBytes = 1 << 20 // 1MB
 	}
+
+	if c.GracefulShutdown.RetryAfter <= 0 {
+		c.GracefulShutdown.RetryAfter = 5 * time.Second
+	}
+	
+	if c.Middleware.Logger.SampleRate < 0 || c.Middleware.Logger.SampleRate > 1 {
+		return fmt.Errorf("logger sample rate must be between 0 and 1, got %v", c.Middleware.Logger.SampleRate)
+	}
+	
+	if c.Middleware.Logger.SlowThreshold < 0 {
+		return fmt.Errorf("logger slow threshold must not be negative, got %s", c.Middleware.Logger.SlowThreshold)
+	}
+
+	if rl := c.Middleware.RateLimit; rl.Enabled && (rl.Rate <= 0 || rl.Burst <= 0) {
+		return fmt.Errorf("rate limit requires a positive rate and burst, got rate %v and burst %d", rl.Rate, rl.Burst)
+	}
 	
 	return nil
 }
@@ -0,0 +1,155 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 请求跟踪与优雅排空 (In-flight request tracking and graceful drain)
+ */
+
+package server
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultRetryAfter 排空期间默认建议的重试间隔 (Default retry interval suggested while draining)
+const defaultRetryAfter = 5 * time.Second
+
+// Drainable 由支持优雅排空的框架实现 (Implemented by frameworks that support graceful drain)
+type Drainable interface {
+	// DrainTracker 返回框架的请求跟踪器 (Return the framework's request tracker)
+	DrainTracker() *DrainTracker
+}
+
+// DrainTracker 跟踪进行中的请求，并在关闭期间以 503 和 Retry-After 拒绝新请求 (Tracks in-flight requests and rejects new ones with 503 and Retry-After during shutdown)
+//
+// 关闭时先调用 StartDrain 停止接收新请求，再用 Wait 等待进行中的请求完成，避免 Kubernetes 滚动更新时丢失请求
+// (On shutdown call StartDrain to stop accepting new requests, then Wait for in-flight requests to finish so Kubernetes rolling updates don't drop requests)
+type DrainTracker struct {
+	inFlight   atomic.Int64
+	rejected   atomic.Int64
+	draining   atomic.Bool
+	retryAfter string
+}
+
+// NewDrainTracker 创建请求跟踪器，retryAfter 为拒绝新请求时建议的重试间隔 (Create a request tracker; retryAfter is the retry interval suggested to rejected requests)
+func NewDrainTracker(retryAfter time.Duration) *DrainTracker {
+	if retryAfter <= 0 {
+		retryAfter = defaultRetryAfter
+	}
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	return &DrainTracker{retryAfter: strconv.FormatInt(seconds, 10)}
+}
+
+// Acquire 登记一个新请求，排空期间返回 false；返回 true 时调用方必须调用 Release (Register a new request; returns false while draining. Callers must call Release when it returns true)
+func (d *DrainTracker) Acquire() bool {
+	if d.draining.Load() {
+		d.rejected.Add(1)
+		return false
+	}
+	d.inFlight.Add(1)
+	// 再次检查，保证 StartDrain 之后 Wait 不会漏掉请求 (Check again so Wait never misses a request admitted concurrently with StartDrain)
+	if d.draining.Load() {
+		d.inFlight.Add(-1)
+		d.rejected.Add(1)
+		return false
+	}
+	return true
+}
+
+// Release 标记请求完成 (Mark a request as finished)
+func (d *DrainTracker) Release() {
+	d.inFlight.Add(-1)
+}
+
+// StartDrain 开始排空，之后的新请求都会被拒绝 (Start draining; all later requests are rejected)
+func (d *DrainTracker) StartDrain() {
+	d.draining.Store(true)
+}
+
+// Draining 是否正在排空，可用于就绪检查 (Whether the server is draining; useful for readiness checks)
+func (d *DrainTracker) Draining() bool {
+	return d.draining.Load()
+}
+
+// InFlight 返回进行中的请求数 (Return the number of in-flight requests)
+func (d *DrainTracker) InFlight() int64 {
+	return d.inFlight.Load()
+}
+
+// Rejected 返回排空期间被拒绝的请求数 (Return the number of requests rejected while draining)
+func (d *DrainTracker) Rejected() int64 {
+	return d.rejected.Load()
+}
+
+// RetryAfter 返回 Retry-After 响应头的值（秒） (Return the Retry-After header value in seconds)
+func (d *DrainTracker) RetryAfter() string {
+	return d.retryAfter
+}
+
+// Wait 等待进行中的请求全部完成或 ctx 结束，期间每隔 interval 调用一次 progress 报告剩余请求数
+// (Wait until all in-flight requests finish or ctx is done, calling progress with the remaining count every interval)
+func (d *DrainTracker) Wait(ctx context.Context, interval time.Duration, progress func(inFlight int64)) error {
+	poll := time.NewTicker(10 * time.Millisecond)
+	defer poll.Stop()
+	report := time.NewTicker(interval)
+	defer report.Stop()
+
+	for {
+		if d.inFlight.Load() <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-report.C:
+			if progress != nil {
+				progress(d.inFlight.Load())
+			}
+		case <-poll.C:
+		}
+	}
+}
+
+// Handler 包装 http.Handler，跟踪请求并在排空期间返回 503 (Wrap an http.Handler, tracking requests and returning 503 while draining)
+func (d *DrainTracker) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !d.Acquire() {
+			w.Header().Set("Retry-After", d.retryAfter)
+			w.Header().Set("Connection", "close")
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		defer d.Release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Collectors 返回报告排空进度的 Prometheus 指标：in_flight_requests、draining 和 drain_rejected_requests_total
+// (Collectors returns Prometheus metrics reporting drain progress: in_flight_requests, draining and drain_rejected_requests_total)
+func (d *DrainTracker) Collectors(namespace string) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "server", Name: "in_flight_requests",
+			Help: "Number of HTTP requests currently being served.",
+		}, func() float64 { return float64(d.InFlight()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "server", Name: "draining",
+			Help: "1 while the server is draining before shutdown, 0 otherwise.",
+		}, func() float64 {
+			if d.Draining() {
+				return 1
+			}
+			return 0
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "server", Name: "drain_rejected_requests_total",
+			Help: "Number of HTTP requests rejected with 503 while draining.",
+		}, func() float64 { return float64(d.Rejected()) }),
+	}
+}
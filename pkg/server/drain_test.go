@@ -0,0 +1,162 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 请求跟踪与优雅排空测试 (In-flight request tracking and graceful drain tests)
+ */
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// drainableFramework 支持排空的模拟框架 (Mock framework supporting drain)
+type drainableFramework struct {
+	MockWebFramework
+	tracker *DrainTracker
+}
+
+func (f *drainableFramework) DrainTracker() *DrainTracker {
+	return f.tracker
+}
+
+// TestDrainTracker_Handler 测试排空期间以 503 拒绝新请求 (Test that new requests are rejected with 503 while draining)
+func TestDrainTracker_Handler(t *testing.T) {
+	tracker := NewDrainTracker(1500 * time.Millisecond)
+	release := make(chan struct{})
+	entered := make(chan struct{})
+	handler := tracker.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	inFlight := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(inFlight, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		close(done)
+	}()
+	<-entered
+	assert.Equal(t, int64(1), tracker.InFlight())
+
+	tracker.StartDrain()
+	assert.True(t, tracker.Draining())
+	rejected := httptest.NewRecorder()
+	handler.ServeHTTP(rejected, httptest.NewRequest(http.MethodGet, "/new", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rejected.Code)
+	assert.Equal(t, "2", rejected.Header().Get("Retry-After"))
+	assert.Equal(t, int64(1), tracker.Rejected())
+
+	close(release)
+	<-done
+	assert.Equal(t, http.StatusOK, inFlight.Code, "in-flight requests complete normally")
+	assert.Equal(t, int64(0), tracker.InFlight())
+}
+
+// TestDrainTracker_Wait 测试等待进行中的请求并报告进度 (Test waiting for in-flight requests with progress reports)
+func TestDrainTracker_Wait(t *testing.T) {
+	tracker := NewDrainTracker(0)
+	assert.Equal(t, "5", tracker.RetryAfter())
+	require.True(t, tracker.Acquire())
+	tracker.StartDrain()
+	assert.False(t, tracker.Acquire())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var reports []int64
+	err := tracker.Wait(ctx, 10*time.Millisecond, func(inFlight int64) { reports = append(reports, inFlight) })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotEmpty(t, reports)
+	assert.Equal(t, int64(1), reports[0])
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		tracker.Release()
+	}()
+	assert.NoError(t, tracker.Wait(context.Background(), time.Second, nil))
+}
+
+// TestDrainTracker_Collectors 测试排空指标 (Test drain metrics)
+func TestDrainTracker_Collectors(t *testing.T) {
+	tracker := NewDrainTracker(time.Second)
+	reg := prometheus.NewRegistry()
+	for _, c := range tracker.Collectors("app") {
+		require.NoError(t, reg.Register(c))
+	}
+
+	require.True(t, tracker.Acquire())
+	tracker.StartDrain()
+	tracker.Acquire()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	values := map[string]float64{}
+	for _, mf := range families {
+		m := mf.GetMetric()[0]
+		if m.GetGauge() != nil {
+			values[mf.GetName()] = m.GetGauge().GetValue()
+		} else {
+			values[mf.GetName()] = m.GetCounter().GetValue()
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"app_server_in_flight_requests":            1,
+		"app_server_draining":                      1,
+		"app_server_drain_rejected_requests_total": 1,
+	}, values)
+}
+
+// TestServerManager_StopDrains 测试停止前先排空 (Test that Stop drains before stopping the framework)
+func TestServerManager_StopDrains(t *testing.T) {
+	framework := &drainableFramework{tracker: NewDrainTracker(time.Second)}
+	framework.On("Stop", mock.Anything).Return(nil)
+	manager := NewServerManager(framework, DefaultServerConfig())
+	manager.running = true
+
+	require.True(t, framework.tracker.Acquire())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		framework.tracker.Release()
+	}()
+
+	require.NoError(t, manager.Stop(context.Background()))
+	assert.True(t, framework.tracker.Draining())
+	assert.Equal(t, int64(0), framework.tracker.InFlight())
+	framework.AssertExpectations(t)
+}
+
+// TestServerManager_RegisterDrainMetrics 测试注册排空指标并通过日志报告排空进度 (Test registering drain metrics and logging drain progress)
+func TestServerManager_RegisterDrainMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	logOpts := log.NewOptions()
+	logOpts.Format = "json"
+	original := log.Std()
+	log.SetGlobalLogger(log.NewLoggerWithWriter(logOpts, &buf))
+	t.Cleanup(func() { log.SetGlobalLogger(original) })
+
+	framework := &drainableFramework{tracker: NewDrainTracker(time.Second)}
+	manager := NewServerManager(framework, DefaultServerConfig())
+	reg := prometheus.NewRegistry()
+	require.NoError(t, manager.RegisterDrainMetrics(reg, "app"))
+	assert.Error(t, manager.RegisterDrainMetrics(reg, "app"), "metrics are registered once")
+	require.NoError(t, NewServerManager(&MockWebFramework{}, DefaultServerConfig()).RegisterDrainMetrics(reg, "app"))
+
+	require.NoError(t, manager.Drain(context.Background()))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	assert.Len(t, families, 3)
+	assert.Contains(t, buf.String(), `"M":"Server drained"`)
+	assert.Contains(t, buf.String(), `"rejected":0`)
+}
@@ -0,0 +1,333 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 基于 net/http 的优雅关闭服务器 (Graceful net/http server)
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"go.uber.org/zap"
+)
+
+// ServerOptions 是 New 创建的 HTTP 服务器的配置，带有 mapstructure 和 default 标签，可直接嵌入应用配置由 pkg/config 加载
+// (ServerOptions configures the HTTP server created by New. It carries mapstructure and default tags, so it can be
+// embedded in the application config and loaded by pkg/config)
+type ServerOptions struct {
+	// Addr 监听地址 (Address to listen on)
+	Addr string `yaml:"addr" mapstructure:"addr" json:"addr" default:":8080"`
+
+	// ReadTimeout 读取整个请求的超时时间 (Timeout for reading the entire request)
+	ReadTimeout time.Duration `yaml:"read-timeout" mapstructure:"read-timeout" json:"read_timeout" default:"30s"`
+
+	// ReadHeaderTimeout 读取请求头的超时时间 (Timeout for reading the request headers)
+	ReadHeaderTimeout time.Duration `yaml:"read-header-timeout" mapstructure:"read-header-timeout" json:"read_header_timeout" default:"10s"`
+
+	// WriteTimeout 写入响应的超时时间 (Timeout for writing the response)
+	WriteTimeout time.Duration `yaml:"write-timeout" mapstructure:"write-timeout" json:"write_timeout" default:"30s"`
+
+	// IdleTimeout keep-alive 连接的空闲超时时间 (Idle timeout of keep-alive connections)
+	IdleTimeout time.Duration `yaml:"idle-timeout" mapstructure:"idle-timeout" json:"idle_timeout" default:"120s"`
+
+	// MaxHeaderBytes 最大请求头字节数 (Maximum request header bytes)
+	MaxHeaderBytes int `yaml:"max-header-bytes" mapstructure:"max-header-bytes" json:"max_header_bytes" default:"1048576"`
+
+	// ShutdownDelay 收到关闭信号后就绪检查先返回 503，等待该时长让负载均衡摘除实例，再停止接收连接
+	// (After the shutdown signal the readiness check returns 503 for this long, so load balancers remove the instance,
+	// before the server stops accepting connections)
+	ShutdownDelay time.Duration `yaml:"shutdown-delay" mapstructure:"shutdown-delay" json:"shutdown_delay"`
+
+	// ShutdownTimeout 等待进行中的请求完成的最长时间 (Maximum time to wait for in-flight requests to finish)
+	ShutdownTimeout time.Duration `yaml:"shutdown-timeout" mapstructure:"shutdown-timeout" json:"shutdown_timeout" default:"30s"`
+
+	// HealthPath 存活检查路径，为空时不注册 (Liveness endpoint path; not registered when empty)
+	HealthPath string `yaml:"health-path" mapstructure:"health-path" json:"health_path" default:"/healthz"`
+
+	// ReadyPath 就绪检查路径，为空时不注册 (Readiness endpoint path; not registered when empty)
+	ReadyPath string `yaml:"ready-path" mapstructure:"ready-path" json:"ready_path" default:"/readyz"`
+
+	// TLS TLS配置，启用时需要 CertFile 和 KeyFile (TLS configuration; CertFile and KeyFile are required when enabled)
+	TLS TLSConfig `yaml:"tls" mapstructure:"tls" json:"tls"`
+
+	// Logger 记录生命周期事件和 http.Server 内部错误的日志器，为 nil 时使用全局日志器
+	// (Logger records lifecycle events and internal http.Server errors; the global logger is used when nil)
+	Logger log.Logger `yaml:"-" mapstructure:"-" json:"-"`
+}
+
+// DefaultServerOptions 返回默认的 HTTP 服务器配置 (Return the default HTTP server options)
+func DefaultServerOptions() ServerOptions {
+	return ServerOptions{
+		Addr:              ":8080",
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 << 20, // 1MB
+		ShutdownTimeout:   30 * time.Second,
+		HealthPath:        "/healthz",
+		ReadyPath:         "/readyz",
+	}
+}
+
+// ServerOptionsFromConfig 将 ServerConfig 中的地址、超时、TLS 和优雅关闭设置转换为 ServerOptions
+// (Convert the address, timeouts, TLS and graceful shutdown settings of a ServerConfig into ServerOptions)
+func ServerOptionsFromConfig(c *ServerConfig) ServerOptions {
+	opts := DefaultServerOptions()
+	opts.Addr = c.GetAddress()
+	opts.ReadTimeout = c.ReadTimeout
+	opts.WriteTimeout = c.WriteTimeout
+	opts.IdleTimeout = c.IdleTimeout
+	opts.MaxHeaderBytes = c.MaxHeaderBytes
+	opts.TLS = c.TLS
+	if c.GracefulShutdown.Enabled {
+		opts.ShutdownDelay = c.GracefulShutdown.WaitTime
+		opts.ShutdownTimeout = c.GracefulShutdown.Timeout
+	}
+	return opts
+}
+
+// Validate 验证配置的有效性，未设置的超时和大小使用默认值 (Validate the options; unset timeouts and sizes fall back to the defaults)
+func (o *ServerOptions) Validate() error {
+	defaults := DefaultServerOptions()
+	if o.Addr == "" {
+		o.Addr = defaults.Addr
+	}
+	if _, port, err := net.SplitHostPort(o.Addr); err != nil {
+		return fmt.Errorf("invalid address %q: %w", o.Addr, err)
+	} else if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return fmt.Errorf("invalid port in address %q", o.Addr)
+	}
+
+	if o.ReadTimeout <= 0 {
+		o.ReadTimeout = defaults.ReadTimeout
+	}
+	if o.ReadHeaderTimeout <= 0 {
+		o.ReadHeaderTimeout = defaults.ReadHeaderTimeout
+	}
+	if o.WriteTimeout <= 0 {
+		o.WriteTimeout = defaults.WriteTimeout
+	}
+	if o.IdleTimeout <= 0 {
+		o.IdleTimeout = defaults.IdleTimeout
+	}
+	if o.MaxHeaderBytes <= 0 {
+		o.MaxHeaderBytes = defaults.MaxHeaderBytes
+	}
+	if o.ShutdownTimeout <= 0 {
+		o.ShutdownTimeout = defaults.ShutdownTimeout
+	}
+	if o.ShutdownDelay < 0 {
+		return fmt.Errorf("shutdown delay must not be negative, got %s", o.ShutdownDelay)
+	}
+
+	if o.TLS.AutoTLS {
+		return fmt.Errorf("auto TLS is not supported, provide a certificate and key instead")
+	}
+	if o.TLS.Enabled && (o.TLS.CertFile == "" || o.TLS.KeyFile == "") {
+		return fmt.Errorf("TLS requires both a certificate file and a key file")
+	}
+	return nil
+}
+
+// readinessCheck 命名的就绪检查 (A named readiness check)
+type readinessCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// HTTPServer 是带健康检查、信号处理和优雅关闭的 net/http 服务器 (A net/http server with health checks, signal handling and graceful shutdown)
+//
+// 应用只需构建自己的路由，其余由 Run 完成 (Applications only build their routes; Run takes care of the rest):
+//
+//	srv := server.New(server.DefaultServerOptions())
+//	srv.AddReadinessCheck("database", db.PingContext)
+//	if err := srv.Run(ctx, mux); err != nil { ... }
+type HTTPServer struct {
+	opts     ServerOptions
+	checksMu sync.RWMutex
+	checks   []readinessCheck
+	mu       sync.Mutex
+	listener net.Listener
+	running  atomic.Bool
+	ready    atomic.Bool
+	stopping atomic.Bool
+}
+
+// New 创建 HTTP 服务器，但不会开始监听 (Create an HTTP server without starting to listen)
+func New(opts ServerOptions) *HTTPServer {
+	return &HTTPServer{opts: opts}
+}
+
+// AddReadinessCheck 添加就绪检查，所有检查通过时就绪端点才返回 200 (Add a readiness check; the readiness endpoint returns 200 only when every check passes)
+func (s *HTTPServer) AddReadinessCheck(name string, check func(ctx context.Context) error) {
+	s.checksMu.Lock()
+	defer s.checksMu.Unlock()
+	s.checks = append(s.checks, readinessCheck{name: name, check: check})
+}
+
+// Addr 返回实际监听的地址；在 Run 开始监听之前返回空字符串 (Return the actual listen address; empty before Run starts listening)
+func (s *HTTPServer) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Handler 返回在 handler 之前挂载存活和就绪端点的处理器 (Return a handler serving the liveness and readiness endpoints in front of handler)
+func (s *HTTPServer) Handler(handler http.Handler) http.Handler {
+	if handler == nil {
+		handler = http.NotFoundHandler()
+	}
+	if s.opts.HealthPath == "" && s.opts.ReadyPath == "" {
+		return handler
+	}
+
+	mux := http.NewServeMux()
+	if s.opts.HealthPath != "" {
+		mux.HandleFunc(s.opts.HealthPath, s.handleHealth)
+	}
+	if s.opts.ReadyPath != "" {
+		mux.HandleFunc(s.opts.ReadyPath, s.handleReady)
+	}
+	mux.Handle("/", handler)
+	return mux
+}
+
+// Run 监听配置的地址并提供 handler，直到 ctx 被取消或收到 SIGINT/SIGTERM，然后优雅关闭
+// 关闭时就绪端点先返回 503，等待 ShutdownDelay 后停止接收新连接，并在 ShutdownTimeout 内等待进行中的请求完成。
+// 正常关闭时返回 nil
+// (Run listens on the configured address and serves handler until ctx is cancelled or SIGINT/SIGTERM arrives, then
+// shuts down gracefully. On shutdown the readiness endpoint returns 503 first; after ShutdownDelay the server stops
+// accepting connections and waits up to ShutdownTimeout for in-flight requests. It returns nil on a clean shutdown)
+func (s *HTTPServer) Run(ctx context.Context, handler http.Handler) error {
+	if err := s.opts.Validate(); err != nil {
+		return fmt.Errorf("invalid server options: %w", err)
+	}
+	if !s.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("server is already running")
+	}
+	defer s.running.Store(false)
+	s.stopping.Store(false)
+
+	logger := s.opts.Logger
+	if logger == nil {
+		logger = log.Std()
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ln, err := net.Listen("tcp", s.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.opts.Addr, err)
+	}
+	s.mu.Lock()
+	s.listener = ln
+	s.mu.Unlock()
+
+	srv := &http.Server{
+		Handler:           s.Handler(handler),
+		ReadTimeout:       s.opts.ReadTimeout,
+		ReadHeaderTimeout: s.opts.ReadHeaderTimeout,
+		WriteTimeout:      s.opts.WriteTimeout,
+		IdleTimeout:       s.opts.IdleTimeout,
+		MaxHeaderBytes:    s.opts.MaxHeaderBytes,
+		ErrorLog:          zap.NewStdLog(logger.GetZapLogger()),
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if s.opts.TLS.Enabled {
+			serveErr <- srv.ServeTLS(ln, s.opts.TLS.CertFile, s.opts.TLS.KeyFile)
+		} else {
+			serveErr <- srv.Serve(ln)
+		}
+	}()
+	s.ready.Store(true)
+	logger.Infow("HTTP server started", "addr", ln.Addr().String(), "tls", s.opts.TLS.Enabled)
+
+	select {
+	case err := <-serveErr:
+		s.ready.Store(false)
+		return fmt.Errorf("HTTP server stopped unexpectedly: %w", err)
+	case <-ctx.Done():
+	}
+
+	// 先让就绪检查失败，给负载均衡留出摘除实例的时间 (Fail readiness first to give load balancers time to remove the instance)
+	s.ready.Store(false)
+	s.stopping.Store(true)
+	logger.Infow("Shutting down HTTP server", "delay", s.opts.ShutdownDelay, "timeout", s.opts.ShutdownTimeout)
+	if s.opts.ShutdownDelay > 0 {
+		time.Sleep(s.opts.ShutdownDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.opts.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+		return fmt.Errorf("failed to shut down HTTP server gracefully: %w", err)
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("HTTP server stopped unexpectedly: %w", err)
+	}
+	logger.Infow("HTTP server stopped")
+	return nil
+}
+
+// handleHealth 进程存活即返回 200 (Return 200 as long as the process is alive)
+func (s *HTTPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+// handleReady 服务中且所有就绪检查通过时返回 200，否则返回 503 和失败原因
+// (Return 200 while serving and every readiness check passes, otherwise 503 with the reasons)
+func (s *HTTPServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	if s.stopping.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "shutting down"})
+		return
+	}
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "starting"})
+		return
+	}
+
+	s.checksMu.RLock()
+	checks := append([]readinessCheck(nil), s.checks...)
+	s.checksMu.RUnlock()
+
+	failed := make(map[string]string)
+	for _, c := range checks {
+		if err := c.check(r.Context()); err != nil {
+			failed[c.name] = err.Error()
+		}
+	}
+	if len(failed) > 0 {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "not ready", "checks": failed})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ready"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
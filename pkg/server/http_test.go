@@ -0,0 +1,166 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 优雅关闭 HTTP 服务器测试 (Graceful HTTP server tests)
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startHTTPServer 在随机端口上运行服务器并等待其开始监听 (Run the server on a random port and wait until it listens)
+func startHTTPServer(t *testing.T, srv *HTTPServer, handler http.Handler) (context.CancelFunc, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(ctx, handler) }()
+	require.Eventually(t, func() bool { return srv.Addr() != "" }, 5*time.Second, 10*time.Millisecond)
+	t.Cleanup(cancel)
+	return cancel, done
+}
+
+// TestHTTPServer_Run 测试服务、健康端点和等待进行中请求的优雅关闭 (Test serving, the health endpoints and a graceful shutdown that waits for in-flight requests)
+func TestHTTPServer_Run(t *testing.T) {
+	opts := DefaultServerOptions()
+	opts.Addr = "127.0.0.1:0"
+	srv := New(opts)
+
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, "hello") })
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = io.WriteString(w, "done")
+	})
+	cancel, done := startHTTPServer(t, srv, mux)
+	base := "http://" + srv.Addr()
+	// 不复用连接，避免空闲的新连接拖慢关闭 (Don't reuse connections so no idle new connection delays the shutdown)
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	for path, want := range map[string]int{"/hello": http.StatusOK, "/healthz": http.StatusOK, "/readyz": http.StatusOK} {
+		resp, err := client.Get(base + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode, path)
+	}
+
+	// 关闭时等待进行中的请求完成 (Shutdown waits for in-flight requests)
+	slow := make(chan string, 1)
+	go func() {
+		resp, err := client.Get(base + "/slow")
+		if err != nil {
+			slow <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slow <- string(body)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	assert.Equal(t, "done", <-slow)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
+
+// TestHTTPServer_Readiness 测试就绪检查以及关闭延迟期间的 503 (Test readiness checks and the 503 during the shutdown delay)
+func TestHTTPServer_Readiness(t *testing.T) {
+	opts := DefaultServerOptions()
+	opts.Addr = "127.0.0.1:0"
+	opts.ShutdownDelay = 200 * time.Millisecond
+	srv := New(opts)
+
+	rec := httptest.NewRecorder()
+	srv.Handler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "not ready before Run")
+
+	dbErr := errors.New("connection refused")
+	var failing atomic.Bool
+	srv.AddReadinessCheck("database", func(context.Context) error {
+		if failing.Load() {
+			return dbErr
+		}
+		return nil
+	})
+	cancel, done := startHTTPServer(t, srv, http.NotFoundHandler())
+	handler := srv.Handler(nil)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	failing.Store(true)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"not ready","checks":{"database":"connection refused"}}`, rec.Body.String())
+	failing.Store(false)
+
+	// 关闭延迟期间仍在服务，但就绪检查失败 (Still serving during the shutdown delay, but readiness fails)
+	cancel()
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	require.Eventually(t, func() bool {
+		resp, err := client.Get("http://" + srv.Addr() + "/readyz")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusServiceUnavailable
+	}, time.Second, 10*time.Millisecond)
+	assert.NoError(t, <-done)
+}
+
+// TestServerOptions_Validate 测试默认值填充、无效配置和 ServerConfig 转换 (Test default filling, invalid options and the ServerConfig conversion)
+func TestServerOptions_Validate(t *testing.T) {
+	opts := ServerOptions{Addr: "localhost:8443"}
+	require.NoError(t, opts.Validate())
+	assert.Equal(t, 30*time.Second, opts.ReadTimeout)
+	assert.Equal(t, 1<<20, opts.MaxHeaderBytes)
+
+	for name, opts := range map[string]ServerOptions{
+		"bad address":       {Addr: "localhost"},
+		"bad port":          {Addr: ":99999"},
+		"negative delay":    {ShutdownDelay: -time.Second},
+		"auto tls":          {TLS: TLSConfig{Enabled: true, AutoTLS: true}},
+		"missing tls files": {TLS: TLSConfig{Enabled: true, CertFile: "cert.pem"}},
+	} {
+		assert.Error(t, opts.Validate(), name)
+	}
+
+	cfg := DefaultServerConfig()
+	cfg.Port = 9090
+	cfg.GracefulShutdown.WaitTime = 3 * time.Second
+	fromConfig := ServerOptionsFromConfig(cfg)
+	assert.Equal(t, "0.0.0.0:9090", fromConfig.Addr)
+	assert.Equal(t, 3*time.Second, fromConfig.ShutdownDelay)
+	assert.Equal(t, cfg.GracefulShutdown.Timeout, fromConfig.ShutdownTimeout)
+}
+
+// TestHTTPServer_ListenError 测试监听失败时 Run 返回错误 (Test that Run returns an error when listening fails)
+func TestHTTPServer_ListenError(t *testing.T) {
+	first := New(ServerOptions{Addr: "127.0.0.1:0"})
+	startHTTPServer(t, first, nil)
+
+	err := New(ServerOptions{Addr: first.Addr()}).Run(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to listen")
+}
@@ -0,0 +1,185 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 可热更新的请求超时、请求头与限流限制 (Hot-reloadable request timeouts, header and rate limits)
+ */
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// clientLimiterIdle 客户端令牌桶闲置多久后被回收 (How long a client token bucket may stay idle before it is reclaimed)
+const clientLimiterIdle = 3 * time.Minute
+
+// RuntimeLimits 可在运行时通过 Update 热更新的请求限制 (Request limits that can be hot-reloaded at runtime via Update)
+//
+// http.Server 开始监听后不能安全修改其字段，因此这些限制改为逐请求应用
+// (http.Server fields cannot be changed safely once it is serving, so these limits are applied per request instead):
+//   - ReadTimeout/WriteTimeout 通过 http.ResponseController 设置连接截止时间，对更新后开始处理的请求生效 (ReadTimeout/WriteTimeout set connection deadlines via http.ResponseController and apply to requests handled after the update)
+//   - 请求头超过 MaxHeaderBytes 时返回 431；启动时的 http.Server.MaxHeaderBytes 仍是硬上限，调大超过它需要重启 (Requests whose headers exceed MaxHeaderBytes get 431; the startup http.Server.MaxHeaderBytes remains a hard ceiling, raising beyond it needs a restart)
+//   - RateLimit 使用令牌桶，KeyFunc 为 ip 时按客户端 IP 限流，否则所有请求共享一个桶；更新速率时保留桶内令牌 (RateLimit uses token buckets, per client IP when KeyFunc is ip and one shared bucket otherwise; updates keep the tokens already in the buckets)
+type RuntimeLimits struct {
+	snapshot atomic.Pointer[limitsSnapshot]
+
+	mu        sync.Mutex // 保护令牌桶，now 在锁内调用 (Guards the token buckets; now is called under the lock)
+	global    *rate.Limiter
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// limitsSnapshot 限制配置快照 (Snapshot of the limits configuration)
+type limitsSnapshot struct {
+	readTimeout    time.Duration
+	writeTimeout   time.Duration
+	maxHeaderBytes int
+	rateLimit      RateLimitMiddlewareConfig
+}
+
+// clientLimiter 单个客户端的令牌桶 (Token bucket of a single client)
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRuntimeLimits 根据服务器配置创建运行时限制 (Create runtime limits from the server configuration)
+func NewRuntimeLimits(config *ServerConfig) *RuntimeLimits {
+	l := &RuntimeLimits{
+		clients: make(map[string]*clientLimiter),
+		now:     time.Now,
+	}
+	l.Update(config)
+	return l
+}
+
+// Update 原子地应用新的超时、请求头和限流配置，进行中的请求不受影响 (Atomically apply new timeout, header and rate limit settings; in-flight requests are unaffected)
+func (l *RuntimeLimits) Update(config *ServerConfig) {
+	s := &limitsSnapshot{
+		readTimeout:    config.ReadTimeout,
+		writeTimeout:   config.WriteTimeout,
+		maxHeaderBytes: config.MaxHeaderBytes,
+		rateLimit:      config.Middleware.RateLimit,
+	}
+	limit, burst := rate.Limit(s.rateLimit.Rate), s.rateLimit.Burst
+
+	l.mu.Lock()
+	now := l.now()
+	if l.global == nil {
+		l.global = rate.NewLimiter(limit, burst)
+	} else {
+		l.global.SetLimitAt(now, limit)
+		l.global.SetBurstAt(now, burst)
+	}
+	if s.rateLimit.Enabled {
+		for _, c := range l.clients {
+			c.limiter.SetLimitAt(now, limit)
+			c.limiter.SetBurstAt(now, burst)
+		}
+	} else {
+		clear(l.clients)
+	}
+	l.mu.Unlock()
+
+	l.snapshot.Store(s)
+}
+
+// Allow 判断来自 clientIP 的请求是否在限流范围内，未启用限流时始终返回 true (Report whether a request from clientIP is within the rate limit; always true when rate limiting is disabled)
+func (l *RuntimeLimits) Allow(clientIP string) bool {
+	s := l.snapshot.Load()
+	if !s.rateLimit.Enabled {
+		return true
+	}
+	if s.rateLimit.KeyFunc != "" && s.rateLimit.KeyFunc != "ip" {
+		return l.global.AllowN(l.now(), 1)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	if now.Sub(l.lastSweep) > clientLimiterIdle {
+		for key, c := range l.clients {
+			if now.Sub(c.lastSeen) > clientLimiterIdle {
+				delete(l.clients, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	c, ok := l.clients[clientIP]
+	if !ok {
+		c = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(s.rateLimit.Rate), s.rateLimit.Burst)}
+		l.clients[clientIP] = c
+	}
+	c.lastSeen = now
+	return c.limiter.AllowN(now, 1)
+}
+
+// HeaderTooLarge 判断请求头大小是否超过当前 MaxHeaderBytes (Report whether the header size exceeds the current MaxHeaderBytes)
+func (l *RuntimeLimits) HeaderTooLarge(size int) bool {
+	s := l.snapshot.Load()
+	return s.maxHeaderBytes > 0 && size > s.maxHeaderBytes
+}
+
+// Timeouts 返回当前的读写超时 (Return the current read and write timeouts)
+func (l *RuntimeLimits) Timeouts() (read, write time.Duration) {
+	s := l.snapshot.Load()
+	return s.readTimeout, s.writeTimeout
+}
+
+// Handler 包装 http.Handler，逐请求应用当前限制 (Wrap an http.Handler and apply the current limits to every request)
+func (l *RuntimeLimits) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.HeaderTooLarge(RequestHeaderSize(r)) {
+			http.Error(w, http.StatusText(http.StatusRequestHeaderFieldsTooLarge), http.StatusRequestHeaderFieldsTooLarge)
+			return
+		}
+		if !l.Allow(remoteIP(r)) {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+
+		// 不支持设置截止时间的 ResponseWriter 保持 http.Server 的默认值 (Writers that cannot set deadlines keep the http.Server defaults)
+		read, write := l.Timeouts()
+		rc := http.NewResponseController(w)
+		now := time.Now()
+		if read > 0 {
+			_ = rc.SetReadDeadline(now.Add(read))
+		}
+		if write > 0 {
+			_ = rc.SetWriteDeadline(now.Add(write))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestHeaderSize 估算请求行与请求头的字节数，与 http.Server.MaxHeaderBytes 的计算方式一致 (Estimate the bytes of the request line and headers, as counted by http.Server.MaxHeaderBytes)
+func RequestHeaderSize(r *http.Request) int {
+	// "METHOD URI PROTO\r\n" 与 "Host: host\r\n" ("METHOD URI PROTO\r\n" and "Host: host\r\n")
+	size := len(r.Method) + len(r.RequestURI) + len(r.Proto) + 4
+	size += len("Host: \r\n") + len(r.Host)
+	for key, values := range r.Header {
+		for _, value := range values {
+			size += len(key) + len(value) + 4 // ": " 与 "\r\n" (": " and "\r\n")
+		}
+	}
+	return size
+}
+
+// remoteIP 返回连接的对端 IP，不信任转发头 (Return the peer IP of the connection; forwarding headers are not trusted)
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
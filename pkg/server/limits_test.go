@@ -0,0 +1,114 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 运行时请求限制测试 (Runtime request limits tests)
+ */
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveLimited 通过 RuntimeLimits 发送一个请求并返回状态码 (Send one request through RuntimeLimits and return the status code)
+func serveLimited(limits *RuntimeLimits, remoteAddr string, header http.Header) int {
+	handler := limits.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.RemoteAddr = remoteAddr
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// TestRuntimeLimits_HeaderLimit 测试请求头上限可热更新 (Test that the header limit can be hot-reloaded)
+func TestRuntimeLimits_HeaderLimit(t *testing.T) {
+	config := DefaultServerConfig()
+	config.MaxHeaderBytes = 4096
+	limits := NewRuntimeLimits(config)
+
+	header := http.Header{"X-Large": []string{strings.Repeat("a", 1024)}}
+	assert.Equal(t, http.StatusOK, serveLimited(limits, "192.0.2.1:1234", header))
+
+	config.MaxHeaderBytes = 512
+	limits.Update(config)
+	assert.Equal(t, http.StatusRequestHeaderFieldsTooLarge, serveLimited(limits, "192.0.2.1:1234", header))
+	assert.Equal(t, http.StatusOK, serveLimited(limits, "192.0.2.1:1234", nil))
+}
+
+// TestRuntimeLimits_RateLimit 测试按客户端 IP 限流及热更新 (Test per-client-IP rate limiting and hot reload)
+func TestRuntimeLimits_RateLimit(t *testing.T) {
+	config := DefaultServerConfig()
+	limits := NewRuntimeLimits(config)
+	now := time.Unix(1700000000, 0)
+	limits.now = func() time.Time { return now }
+
+	// 默认未启用 (Disabled by default)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, serveLimited(limits, "192.0.2.1:1234", nil))
+	}
+
+	config.Middleware.RateLimit = RateLimitMiddlewareConfig{Enabled: true, Rate: 1, Burst: 2, KeyFunc: "ip"}
+	limits.Update(config)
+	assert.Equal(t, http.StatusOK, serveLimited(limits, "192.0.2.1:1234", nil))
+	assert.Equal(t, http.StatusOK, serveLimited(limits, "192.0.2.1:1234", nil))
+	assert.Equal(t, http.StatusTooManyRequests, serveLimited(limits, "192.0.2.1:1234", nil))
+	assert.Equal(t, http.StatusOK, serveLimited(limits, "192.0.2.2:1234", nil), "other clients have their own bucket")
+
+	// 提高突发量对已有的桶生效，桶内令牌保留 (Raising the burst applies to existing buckets and keeps their tokens)
+	config.Middleware.RateLimit.Burst = 4
+	limits.Update(config)
+	assert.Equal(t, http.StatusTooManyRequests, serveLimited(limits, "192.0.2.1:1234", nil))
+	now = now.Add(4 * time.Second)
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, http.StatusOK, serveLimited(limits, "192.0.2.1:1234", nil))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, serveLimited(limits, "192.0.2.1:1234", nil))
+
+	// 非 ip 键共享一个桶 (Non-ip keys share one bucket)
+	config.Middleware.RateLimit = RateLimitMiddlewareConfig{Enabled: true, Rate: 1, Burst: 1, KeyFunc: "user"}
+	limits.Update(config)
+	assert.True(t, limits.Allow("192.0.2.3"))
+	assert.False(t, limits.Allow("192.0.2.4"))
+
+	config.Middleware.RateLimit.Enabled = false
+	limits.Update(config)
+	assert.True(t, limits.Allow("192.0.2.4"))
+}
+
+// TestRuntimeLimits_Timeouts 测试读写超时可热更新 (Test that read and write timeouts can be hot-reloaded)
+func TestRuntimeLimits_Timeouts(t *testing.T) {
+	config := DefaultServerConfig()
+	limits := NewRuntimeLimits(config)
+	read, write := limits.Timeouts()
+	assert.Equal(t, 30*time.Second, read)
+	assert.Equal(t, 30*time.Second, write)
+
+	config.ReadTimeout = 5 * time.Second
+	config.WriteTimeout = 10 * time.Second
+	limits.Update(config)
+	read, write = limits.Timeouts()
+	assert.Equal(t, 5*time.Second, read)
+	assert.Equal(t, 10*time.Second, write)
+
+	// 真实连接上设置截止时间 (Deadlines are set on a real connection)
+	srv := httptest.NewServer(limits.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 访问日志采样与路径排除 (Access log sampling and path exclusion)
+ */
+
+package middleware
+
+import (
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+)
+
+// AccessLogDecision 访问日志记录决策 (Access log decision)
+type AccessLogDecision int
+
+const (
+	// AccessLogDrop 不记录 (Do not log)
+	AccessLogDrop AccessLogDecision = iota
+	// AccessLogNormal 按正常级别记录 (Log at the normal level)
+	AccessLogNormal
+	// AccessLogSlow 慢请求，以警告级别记录 (Slow request, log at warn level)
+	AccessLogSlow
+	// AccessLogError 出错或 5xx 响应，以错误级别记录 (Error or 5xx response, log at error level)
+	AccessLogError
+)
+
+// accessLogPolicy 访问日志策略快照 (Snapshot of the access log policy)
+type accessLogPolicy struct {
+	exact         map[string]struct{}
+	prefixes      []string
+	sampleRate    float64
+	slowThreshold time.Duration
+}
+
+// AccessLogSampler 决定哪些请求写入访问日志，可在运行时通过 Update 热更新
+// (AccessLogSampler decides which requests are written to the access log; it can be hot-reloaded at runtime via Update)
+//
+// 规则 (Rules):
+//   - SkipPaths 中的路径从不记录，以 "*" 结尾的条目按前缀匹配 (Paths in SkipPaths are never logged; entries ending in "*" match by prefix)
+//   - 出错、非 2xx 响应和超过 SlowThreshold 的请求始终记录 (Errors, non-2xx responses and requests slower than SlowThreshold are always logged)
+//   - 其余成功请求按 SampleRate 采样 (Remaining successful requests are sampled at SampleRate)
+type AccessLogSampler struct {
+	policy atomic.Pointer[accessLogPolicy]
+	random func() float64
+}
+
+// NewAccessLogSampler 根据日志中间件配置创建采样器 (Create a sampler from the logger middleware configuration)
+func NewAccessLogSampler(config server.LoggerMiddlewareConfig) *AccessLogSampler {
+	s := &AccessLogSampler{random: rand.Float64}
+	s.Update(config)
+	return s
+}
+
+// Update 原子地替换采样策略，并发请求立即使用新策略 (Atomically replace the policy; concurrent requests pick it up immediately)
+func (s *AccessLogSampler) Update(config server.LoggerMiddlewareConfig) {
+	p := &accessLogPolicy{
+		exact:         make(map[string]struct{}, len(config.SkipPaths)),
+		sampleRate:    config.SampleRate,
+		slowThreshold: config.SlowThreshold,
+	}
+	for _, path := range config.SkipPaths {
+		if prefix, ok := strings.CutSuffix(path, "*"); ok {
+			p.prefixes = append(p.prefixes, prefix)
+		} else {
+			p.exact[path] = struct{}{}
+		}
+	}
+	if p.sampleRate <= 0 || p.sampleRate > 1 {
+		p.sampleRate = 1
+	}
+	s.policy.Store(p)
+}
+
+// Skip 判断路径是否被排除，可在处理请求前调用 (Report whether the path is excluded; may be called before handling the request)
+func (s *AccessLogSampler) Skip(path string) bool {
+	p := s.policy.Load()
+	if _, ok := p.exact[path]; ok {
+		return true
+	}
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Decide 在请求完成后决定是否记录以及使用的级别 (Decide after the request completes whether and at which level to log it)
+func (s *AccessLogSampler) Decide(path string, status int, latency time.Duration, err error) AccessLogDecision {
+	if s.Skip(path) {
+		return AccessLogDrop
+	}
+	p := s.policy.Load()
+	switch {
+	case err != nil || status >= 500:
+		return AccessLogError
+	case p.slowThreshold > 0 && latency >= p.slowThreshold:
+		return AccessLogSlow
+	case status < 200 || status >= 300:
+		return AccessLogNormal
+	case p.sampleRate >= 1 || s.random() < p.sampleRate:
+		return AccessLogNormal
+	default:
+		return AccessLogDrop
+	}
+}
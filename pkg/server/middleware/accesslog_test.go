@@ -0,0 +1,53 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 访问日志采样器测试 (Tests for the access log sampler)
+ */
+
+package middleware
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAccessLogSampler_Decide 测试采样决策 (Test sampling decisions)
+func TestAccessLogSampler_Decide(t *testing.T) {
+	s := NewAccessLogSampler(server.LoggerMiddlewareConfig{
+		SkipPaths:     []string{"/health", "/debug/*"},
+		SampleRate:    0.1,
+		SlowThreshold: time.Second,
+	})
+	rolls := []float64{0.05, 0.5}
+	s.random = func() float64 {
+		r := rolls[0]
+		rolls = rolls[1:]
+		return r
+	}
+
+	assert.Equal(t, AccessLogDrop, s.Decide("/health", 500, 0, nil), "excluded paths are never logged")
+	assert.Equal(t, AccessLogDrop, s.Decide("/debug/pprof", 200, 0, nil))
+	assert.Equal(t, AccessLogError, s.Decide("/api", 503, 0, nil))
+	assert.Equal(t, AccessLogError, s.Decide("/api", 200, 0, errors.New("boom")))
+	assert.Equal(t, AccessLogSlow, s.Decide("/api", 200, 2*time.Second, nil))
+	assert.Equal(t, AccessLogNormal, s.Decide("/api", 404, 0, nil), "non-2xx responses are not sampled")
+	assert.Equal(t, AccessLogNormal, s.Decide("/api", 200, 0, nil), "sampled in")
+	assert.Equal(t, AccessLogDrop, s.Decide("/api", 200, 0, nil), "sampled out")
+	assert.Empty(t, rolls)
+}
+
+// TestAccessLogSampler_Update 测试热更新策略 (Test hot-reloading the policy)
+func TestAccessLogSampler_Update(t *testing.T) {
+	s := NewAccessLogSampler(server.LoggerMiddlewareConfig{SkipPaths: []string{"/health"}})
+	assert.True(t, s.Skip("/health"))
+	assert.Equal(t, AccessLogNormal, s.Decide("/api", 200, 0, nil), "zero sample rate logs everything")
+
+	s.Update(server.LoggerMiddlewareConfig{SkipPaths: []string{"/ready"}, SlowThreshold: time.Millisecond})
+	assert.False(t, s.Skip("/health"))
+	assert.True(t, s.Skip("/ready"))
+	assert.Equal(t, AccessLogSlow, s.Decide("/api", 200, 5*time.Millisecond, nil))
+}
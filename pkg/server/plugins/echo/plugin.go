@@ -65,8 +65,9 @@ func (p *Plugin) DefaultConfig() interface{} {
 		
 		Middleware: server.MiddlewareConfig{
 			Logger: server.LoggerMiddlewareConfig{
-				Enabled: true,
-				Format:  "json",
+				Enabled:   true,
+				Format:    "json",
+				SkipPaths: []string{"/health", "/metrics"},
 			},
 			Recovery: server.RecoveryMiddlewareConfig{
 				Enabled: true,
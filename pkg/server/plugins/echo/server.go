@@ -22,11 +22,14 @@ import (
 // EchoServer Echo服务器适配器 (Echo server adapter)
 // 实现server.WebFramework接口 (Implements server.WebFramework interface)
 type EchoServer struct {
-	config           *server.ServerConfig         // 服务器配置 (Server configuration)
-	serviceContainer services.ServiceContainer    // 服务容器 (Service container)
-	echo             *echo.Echo                   // Echo实例 (Echo instance)
-	httpServer       *http.Server                 // HTTP服务器 (HTTP server)
-	logger           services.Logger              // 日志服务 (Logger service)
+	config           *server.ServerConfig             // 服务器配置 (Server configuration)
+	serviceContainer services.ServiceContainer        // 服务容器 (Service container)
+	echo             *echo.Echo                       // Echo实例 (Echo instance)
+	httpServer       *http.Server                     // HTTP服务器 (HTTP server)
+	logger           services.Logger                  // 日志服务 (Logger service)
+	limits           *server.RuntimeLimits            // 可热更新的请求限制 (Hot-reloadable request limits)
+	drain            *server.DrainTracker             // 请求跟踪与优雅排空 (Request tracking and graceful drain)
+	accessLogger     *echoMiddleware.LoggerMiddleware // 访问日志中间件 (Access log middleware)
 }
 
 // NewEchoServer 创建Echo服务器适配器 (Create Echo server adapter)
@@ -49,6 +52,8 @@ func NewEchoServer(config *server.ServerConfig, serviceContainer services.Servic
 		serviceContainer: serviceContainer,
 		echo:             e,
 		logger:           serviceContainer.GetLogger(),
+		limits:           server.NewRuntimeLimits(config),
+		drain:            server.NewDrainTracker(config.GracefulShutdown.RetryAfter),
 	}
 
 	// 设置基本中间件 (Set basic middleware)
@@ -69,10 +74,11 @@ func NewEchoServer(config *server.ServerConfig, serviceContainer services.Servic
 
 // Start 启动服务器 (Start the server)
 func (s *EchoServer) Start(ctx context.Context) error {
-	// 创建HTTP服务器 (Create HTTP server)
+	// 创建HTTP服务器，DrainTracker 跟踪请求，RuntimeLimits 逐请求应用可热更新的限制
+	// (Create HTTP server; DrainTracker tracks requests and RuntimeLimits applies hot-reloadable limits per request)
 	s.httpServer = &http.Server{
 		Addr:           fmt.Sprintf("%s:%d", s.config.Host, s.config.Port),
-		Handler:        s.echo,
+		Handler:        s.drain.Handler(s.limits.Handler(s.echo)),
 		ReadTimeout:    s.config.ReadTimeout,
 		WriteTimeout:   s.config.WriteTimeout,
 		IdleTimeout:    s.config.IdleTimeout,
@@ -178,6 +184,20 @@ func (s *EchoServer) GetEchoEngine() *echo.Echo {
 	return s.echo
 }
 
+// DrainTracker 返回请求跟踪器 (Return the request tracker)
+func (s *EchoServer) DrainTracker() *server.DrainTracker {
+	return s.drain
+}
+
+// ApplyRuntimeConfig 应用可热更新的配置 (Apply hot-reloadable configuration)
+func (s *EchoServer) ApplyRuntimeConfig(config *server.ServerConfig) []string {
+	s.limits.Update(config)
+	if s.accessLogger != nil {
+		s.accessLogger.UpdateSampling(config.Middleware.Logger)
+	}
+	return nil
+}
+
 // setupMiddleware 设置基本中间件 (Set up basic middleware)
 func (s *EchoServer) setupMiddleware() error {
 	// 导入统一中间件包 (Import unified middleware package)
@@ -200,14 +220,16 @@ func (s *EchoServer) setupMiddleware() error {
 	// 日志中间件 (Logger middleware) - 使用统一实现
 	if s.config.Middleware.Logger.Enabled {
 		loggerConfig := &echoMiddleware.LoggerConfig{
-			Enabled:      s.config.Middleware.Logger.Enabled,
-			Format:       "${time_rfc3339} ${status} ${method} ${uri} ${latency_human} ${bytes_in}/${bytes_out}\n",
-			SkipPaths:    []string{"/health", "/metrics"},
-			EnableColors: false,
-			TimeFormat:   "2006-01-02T15:04:05Z07:00",
+			Enabled:       s.config.Middleware.Logger.Enabled,
+			Format:        "${time_rfc3339} ${status} ${method} ${uri} ${latency_human} ${bytes_in}/${bytes_out}\n",
+			SkipPaths:     s.config.Middleware.Logger.SkipPaths,
+			EnableColors:  false,
+			TimeFormat:    "2006-01-02T15:04:05Z07:00",
+			SampleRate:    s.config.Middleware.Logger.SampleRate,
+			SlowThreshold: s.config.Middleware.Logger.SlowThreshold,
 		}
-		loggerMiddleware := echoMiddleware.NewLoggerMiddleware(loggerConfig, s.serviceContainer)
-		s.echo.Use(loggerMiddleware.Handler())
+		s.accessLogger = echoMiddleware.NewLoggerMiddleware(loggerConfig, s.serviceContainer)
+		s.echo.Use(s.accessLogger.Handler())
 	}
 
 	// 请求ID中间件 (Request ID middleware) - 保持原生实现
@@ -7,12 +7,14 @@
 package middleware
 
 import (
+	"errors"
+	"net/http"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+	sdkmiddleware "github.com/lmcc-dev/lmcc-go-sdk/pkg/server/middleware"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server/services"
 )
 
@@ -21,7 +23,8 @@ type LoggerConfig struct {
 	// Enabled 是否启用日志中间件 (Whether to enable logger middleware)
 	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
 
-	// Format 日志格式 (Log format)
+	// Format 日志格式，访问日志经由 SDK 日志服务以结构化字段输出，该字段仅为兼容保留
+	// (Log format; access logs are written as structured fields through the SDK logger service, so this field is kept for compatibility only)
 	Format string `yaml:"format" mapstructure:"format"`
 
 	// SkipPaths 跳过记录的路径 (Paths to skip logging)
@@ -30,6 +33,12 @@ type LoggerConfig struct {
 	// EnableColors 是否启用颜色输出 (Whether to enable colored output)
 	EnableColors bool `yaml:"enable-colors" mapstructure:"enable-colors"`
 
+	// SampleRate 成功（2xx）请求的采样比例，0 表示全部记录 (Fraction of successful 2xx requests logged; 0 logs all)
+	SampleRate float64 `yaml:"sample-rate" mapstructure:"sample-rate"`
+
+	// SlowThreshold 慢请求阈值，超过时始终记录 (Slow request threshold; slower requests are always logged)
+	SlowThreshold time.Duration `yaml:"slow-threshold" mapstructure:"slow-threshold"`
+
 	// TimeFormat 时间格式 (Time format)
 	TimeFormat string `yaml:"time-format" mapstructure:"time-format"`
 
@@ -51,9 +60,10 @@ func DefaultLoggerConfig() *LoggerConfig {
 
 // LoggerMiddleware Fiber Logger中间件 (Fiber Logger middleware)
 type LoggerMiddleware struct {
-	config           *LoggerConfig            // Logger配置 (Logger configuration)
-	serviceContainer services.ServiceContainer // 服务容器 (Service container)
-	logger           services.Logger          // 日志服务 (Logger service)
+	config           *LoggerConfig                   // Logger配置 (Logger configuration)
+	serviceContainer services.ServiceContainer       // 服务容器 (Service container)
+	logger           services.Logger                 // 日志服务 (Logger service)
+	sampler          *sdkmiddleware.AccessLogSampler // 访问日志采样器 (Access log sampler)
 }
 
 // NewLoggerMiddleware 创建Logger中间件 (Create Logger middleware)
@@ -66,6 +76,16 @@ func NewLoggerMiddleware(config *LoggerConfig, serviceContainer services.Service
 		config:           config,
 		serviceContainer: serviceContainer,
 		logger:           serviceContainer.GetLogger(),
+		sampler:          sdkmiddleware.NewAccessLogSampler(samplerConfig(config)),
+	}
+}
+
+// samplerConfig 转换为采样器配置 (Convert to sampler configuration)
+func samplerConfig(config *LoggerConfig) server.LoggerMiddlewareConfig {
+	return server.LoggerMiddlewareConfig{
+		SkipPaths:     config.SkipPaths,
+		SampleRate:    config.SampleRate,
+		SlowThreshold: config.SlowThreshold,
 	}
 }
 
@@ -77,35 +97,38 @@ func (m *LoggerMiddleware) Handler() fiber.Handler {
 		}
 	}
 
-	// 创建Fiber Logger配置 (Create Fiber Logger configuration)
-	loggerConfig := logger.Config{
-		Format:     m.config.Format,
-		TimeFormat: m.config.TimeFormat,
-		TimeZone:   m.config.TimeZone,
-		Next: func(c *fiber.Ctx) bool {
-			// 检查是否跳过此路径 (Check if this path should be skipped)
-			path := c.Path()
-			for _, skipPath := range m.config.SkipPaths {
-				if path == skipPath {
-					return true
-				}
-			}
-			return false
-		},
-	}
-
 	// 记录Logger配置 (Log Logger configuration)
 	if m.logger != nil {
 		m.logger.Debugw("Fiber Logger middleware configured",
 			"enabled", m.config.Enabled,
-			"format", m.config.Format,
 			"skip_paths", m.config.SkipPaths,
-			"enable_colors", m.config.EnableColors,
-			"time_format", m.config.TimeFormat,
+			"sample_rate", m.config.SampleRate,
+			"slow_threshold", m.config.SlowThreshold,
 		)
 	}
 
-	return logger.New(loggerConfig)
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		if m.sampler.Skip(path) {
+			return c.Next()
+		}
+
+		start := time.Now()
+		err := c.Next()
+		latency := time.Since(start)
+
+		status := c.Response().StatusCode()
+		var fiberErr *fiber.Error
+		switch {
+		case errors.As(err, &fiberErr):
+			status = fiberErr.Code
+		case err != nil:
+			status = fiber.StatusInternalServerError
+		}
+
+		m.logRequest(c.Method(), path, c.OriginalURL(), status, latency, c.IP(), string(c.Request().Header.UserAgent()), err)
+		return err
+	}
 }
 
 // Process 实现统一中间件接口 (Implement unified middleware interface)
@@ -115,11 +138,9 @@ func (m *LoggerMiddleware) Process(ctx server.Context, next func() error) error
 	}
 
 	// 检查是否跳过此路径 (Check if this path should be skipped)
-	path := ctx.Request().URL.Path
-	for _, skipPath := range m.config.SkipPaths {
-		if path == skipPath {
-			return next()
-		}
+	req := ctx.Request()
+	if m.sampler.Skip(req.URL.Path) {
+		return next()
 	}
 
 	// 记录请求开始时间 (Record request start time)
@@ -128,36 +149,51 @@ func (m *LoggerMiddleware) Process(ctx server.Context, next func() error) error
 	// 执行下一个处理器 (Execute next handler)
 	err := next()
 
-	// 计算请求处理时间 (Calculate request processing time)
-	latency := time.Since(start)
+	// 获取响应状态码 (Get response status code)
+	status := http.StatusOK
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &fiberErr):
+		status = fiberErr.Code
+	case err != nil:
+		status = http.StatusInternalServerError
+	}
 
-	// 获取请求信息 (Get request information)
-	req := ctx.Request()
-	method := req.Method
-	uri := req.RequestURI
-	userAgent := req.UserAgent()
-	clientIP := ctx.ClientIP()
+	m.logRequest(req.Method, req.URL.Path, req.RequestURI, status, time.Since(start), ctx.ClientIP(), req.UserAgent(), err)
+	return err
+}
 
-	// 获取响应状态码 (Get response status code)
-	status := 200 // 默认状态码
-	if err != nil {
-		status = 500 // 错误状态码
+// logRequest 按采样决策记录请求 (Log the request according to the sampling decision)
+func (m *LoggerMiddleware) logRequest(method, path, uri string, status int, latency time.Duration, clientIP, userAgent string, err error) {
+	if m.logger == nil {
+		return
+	}
+	decision := m.sampler.Decide(path, status, latency, err)
+	if decision == sdkmiddleware.AccessLogDrop {
+		return
 	}
 
-	// 记录请求日志 (Log request)
-	if m.logger != nil {
-		m.logger.Infow("HTTP Request",
-			"method", method,
-			"uri", uri,
-			"status", status,
-			"latency", latency.String(),
-			"client_ip", clientIP,
-			"user_agent", userAgent,
-			"framework", "fiber",
-		)
+	fields := []interface{}{
+		"method", method,
+		"uri", uri,
+		"status", status,
+		"latency", latency.String(),
+		"client_ip", clientIP,
+		"user_agent", userAgent,
+		"framework", "fiber",
+	}
+	if err != nil {
+		fields = append(fields, "error", err.Error())
 	}
 
-	return err
+	switch decision {
+	case sdkmiddleware.AccessLogError:
+		m.logger.Errorw("HTTP Request", fields...)
+	case sdkmiddleware.AccessLogSlow:
+		m.logger.Warnw("Slow HTTP Request", fields...)
+	default:
+		m.logger.Infow("HTTP Request", fields...)
+	}
 }
 
 // GetConfig 获取Logger配置 (Get Logger configuration)
@@ -165,9 +201,15 @@ func (m *LoggerMiddleware) GetConfig() *LoggerConfig {
 	return m.config
 }
 
-// SetConfig 设置Logger配置 (Set Logger configuration)
+// SetConfig 设置Logger配置，采样策略立即生效 (Set Logger configuration; the sampling policy takes effect immediately)
 func (m *LoggerMiddleware) SetConfig(config *LoggerConfig) {
 	if config != nil {
 		m.config = config
+		m.sampler.Update(samplerConfig(config))
 	}
-} 
\ No newline at end of file
+}
+
+// UpdateSampling 热更新排除路径、采样比例和慢请求阈值，其余配置保持不变 (Hot-reload skip paths, sample rate and slow threshold; other settings are unchanged)
+func (m *LoggerMiddleware) UpdateSampling(config server.LoggerMiddlewareConfig) {
+	m.sampler.Update(config)
+}
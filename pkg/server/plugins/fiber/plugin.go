@@ -62,7 +62,8 @@ func (p *Plugin) DefaultConfig() interface{} {
 		},
 		Middleware: server.MiddlewareConfig{
 			Logger: server.LoggerMiddlewareConfig{
-				Enabled: true,
+				Enabled:   true,
+				SkipPaths: []string{"/health", "/metrics"},
 			},
 			Recovery: server.RecoveryMiddlewareConfig{
 				Enabled:    true,
@@ -24,6 +24,9 @@ type FiberServer struct {
 	serviceContainer services.ServiceContainer    // 服务容器 (Service container)
 	fiber            *fiber.App                   // Fiber实例 (Fiber instance)
 	logger           services.Logger              // 日志服务 (Logger service)
+	limits           *server.RuntimeLimits        // 可热更新的请求限制 (Hot-reloadable request limits)
+	drain            *server.DrainTracker         // 请求跟踪与优雅排空 (Request tracking and graceful drain)
+	accessLogger     *middleware.LoggerMiddleware // 访问日志中间件 (Access log middleware)
 }
 
 // NewFiberServer 创建Fiber服务器适配器 (Create Fiber server adapter)
@@ -50,6 +53,8 @@ func NewFiberServer(config *server.ServerConfig, serviceContainer services.Servi
 		serviceContainer: serviceContainer,
 		fiber:            app,
 		logger:           serviceContainer.GetLogger(),
+		limits:           server.NewRuntimeLimits(config),
+		drain:            server.NewDrainTracker(config.GracefulShutdown.RetryAfter),
 	}
 
 	// 设置中间件 (Setup middleware)
@@ -190,8 +195,64 @@ func (s *FiberServer) wrapMiddleware(middleware server.Middleware) fiber.Handler
 	}
 }
 
+// ApplyRuntimeConfig 应用可热更新的配置 (Apply hot-reloadable configuration)
+// fasthttp 的读写超时在创建时固定，修改后需要重启 (fasthttp read/write timeouts are fixed at creation; changing them needs a restart)
+func (s *FiberServer) ApplyRuntimeConfig(config *server.ServerConfig) []string {
+	s.limits.Update(config)
+	if s.accessLogger != nil {
+		s.accessLogger.UpdateSampling(config.Middleware.Logger)
+	}
+
+	var restartRequired []string
+	if config.ReadTimeout != s.config.ReadTimeout {
+		restartRequired = append(restartRequired, "read-timeout")
+	}
+	if config.WriteTimeout != s.config.WriteTimeout {
+		restartRequired = append(restartRequired, "write-timeout")
+	}
+	return restartRequired
+}
+
+// DrainTracker 返回请求跟踪器 (Return the request tracker)
+func (s *FiberServer) DrainTracker() *server.DrainTracker {
+	return s.drain
+}
+
+// drainHandler 跟踪请求并在排空期间返回 503 的Fiber处理器 (Fiber handler tracking requests and returning 503 while draining)
+func (s *FiberServer) drainHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !s.drain.Acquire() {
+			c.Set(fiber.HeaderRetryAfter, s.drain.RetryAfter())
+			c.Set(fiber.HeaderConnection, "close")
+			return c.SendStatus(fiber.StatusServiceUnavailable)
+		}
+		defer s.drain.Release()
+		return c.Next()
+	}
+}
+
+// limitsHandler 应用请求头上限和限流的Fiber处理器 (Fiber handler applying the header limit and rate limiting)
+func (s *FiberServer) limitsHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if s.limits.HeaderTooLarge(len(c.Request().Header.Header())) {
+			return c.SendStatus(fiber.StatusRequestHeaderFieldsTooLarge)
+		}
+		if !s.limits.Allow(c.IP()) {
+			c.Set(fiber.HeaderRetryAfter, "1")
+			return c.SendStatus(fiber.StatusTooManyRequests)
+		}
+		return c.Next()
+	}
+}
+
 // setupMiddleware 设置中间件 (Setup middleware)
 func (s *FiberServer) setupMiddleware() {
+	// 跟踪请求，排空期间拒绝新请求 (Track requests and reject new ones while draining)
+	s.fiber.Use(s.drainHandler())
+
+	// 请求头上限和限流可热更新 (Header limit and rate limiting are hot-reloadable)
+	s.fiber.Use(s.limitsHandler())
+
 	// 设置恢复中间件 (Setup recovery middleware)
 	if s.config.Middleware.Recovery.Enabled {
 		recoveryMiddleware := middleware.NewRecoveryMiddleware(&middleware.RecoveryConfig{
@@ -202,12 +263,16 @@ func (s *FiberServer) setupMiddleware() {
 
 	// 设置日志中间件 (Setup logger middleware)
 	if s.config.Middleware.Logger.Enabled {
-		loggerMiddleware := middleware.NewLoggerMiddleware(&middleware.LoggerConfig{
-			Format:    "[${time}] ${status} - ${method} ${path} ${latency}\n",
-			TimeZone:  "Local",
-			TimeFormat: "15:04:05",
+		s.accessLogger = middleware.NewLoggerMiddleware(&middleware.LoggerConfig{
+			Enabled:       true,
+			Format:        "[${time}] ${status} - ${method} ${path} ${latency}\n",
+			SkipPaths:     s.config.Middleware.Logger.SkipPaths,
+			TimeZone:      "Local",
+			TimeFormat:    "15:04:05",
+			SampleRate:    s.config.Middleware.Logger.SampleRate,
+			SlowThreshold: s.config.Middleware.Logger.SlowThreshold,
 		}, s.serviceContainer)
-		s.fiber.Use(loggerMiddleware.Handler())
+		s.fiber.Use(s.accessLogger.Handler())
 	}
 
 	// 设置CORS中间件 (Setup CORS middleware)
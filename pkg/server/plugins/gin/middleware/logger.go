@@ -0,0 +1,190 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Gin Logger中间件实现 (Gin Logger middleware implementation)
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+	sdkmiddleware "github.com/lmcc-dev/lmcc-go-sdk/pkg/server/middleware"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server/services"
+)
+
+// LoggerConfig Logger中间件配置 (Logger middleware configuration)
+type LoggerConfig struct {
+	// Enabled 是否启用日志中间件 (Whether to enable logger middleware)
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+
+	// SkipPaths 跳过记录的路径，以 "*" 结尾时按前缀匹配 (Paths to skip logging; a trailing "*" matches by prefix)
+	SkipPaths []string `yaml:"skip-paths" mapstructure:"skip-paths"`
+
+	// SampleRate 成功（2xx）请求的采样比例，0 表示全部记录 (Fraction of successful 2xx requests logged; 0 logs all)
+	SampleRate float64 `yaml:"sample-rate" mapstructure:"sample-rate"`
+
+	// SlowThreshold 慢请求阈值，超过时始终记录 (Slow request threshold; slower requests are always logged)
+	SlowThreshold time.Duration `yaml:"slow-threshold" mapstructure:"slow-threshold"`
+}
+
+// DefaultLoggerConfig 默认Logger配置 (Default Logger configuration)
+func DefaultLoggerConfig() *LoggerConfig {
+	return &LoggerConfig{
+		Enabled:   true,
+		SkipPaths: []string{"/health", "/metrics"},
+	}
+}
+
+// LoggerMiddleware Gin Logger中间件，经由 SDK 日志服务输出结构化访问日志
+// (Gin Logger middleware writing structured access logs through the SDK logger service)
+type LoggerMiddleware struct {
+	config  *LoggerConfig                   // Logger配置 (Logger configuration)
+	logger  services.Logger                 // 日志服务 (Logger service)
+	sampler *sdkmiddleware.AccessLogSampler // 访问日志采样器 (Access log sampler)
+}
+
+// NewLoggerMiddleware 创建Logger中间件 (Create Logger middleware)
+func NewLoggerMiddleware(config *LoggerConfig, serviceContainer services.ServiceContainer) *LoggerMiddleware {
+	if config == nil {
+		config = DefaultLoggerConfig()
+	}
+	if serviceContainer == nil {
+		serviceContainer = services.NewServiceContainerWithDefaults()
+	}
+
+	return &LoggerMiddleware{
+		config:  config,
+		logger:  serviceContainer.GetLogger(),
+		sampler: sdkmiddleware.NewAccessLogSampler(samplerConfig(config)),
+	}
+}
+
+// samplerConfig 转换为采样器配置 (Convert to sampler configuration)
+func samplerConfig(config *LoggerConfig) server.LoggerMiddlewareConfig {
+	return server.LoggerMiddlewareConfig{
+		SkipPaths:     config.SkipPaths,
+		SampleRate:    config.SampleRate,
+		SlowThreshold: config.SlowThreshold,
+	}
+}
+
+// GetGinHandler 返回Gin兼容的处理器 (Return Gin compatible handler)
+func (m *LoggerMiddleware) GetGinHandler() gin.HandlerFunc {
+	if !m.config.Enabled {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	// 记录Logger配置 (Log Logger configuration)
+	if m.logger != nil {
+		m.logger.Debugw("Gin Logger middleware configured",
+			"enabled", m.config.Enabled,
+			"skip_paths", m.config.SkipPaths,
+			"sample_rate", m.config.SampleRate,
+			"slow_threshold", m.config.SlowThreshold,
+		)
+	}
+
+	return func(c *gin.Context) {
+		if m.sampler.Skip(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		var err error
+		if last := c.Errors.Last(); last != nil {
+			err = last.Err
+		}
+		m.logRequest(c.Request, c.Writer.Status(), time.Since(start), c.ClientIP(), err)
+	}
+}
+
+// Process 实现统一中间件接口 (Implement unified middleware interface)
+func (m *LoggerMiddleware) Process(ctx server.Context, next func() error) error {
+	if !m.config.Enabled {
+		return next()
+	}
+
+	// 检查是否跳过此路径 (Check if this path should be skipped)
+	req := ctx.Request()
+	if m.sampler.Skip(req.URL.Path) {
+		return next()
+	}
+
+	// 记录请求开始时间 (Record request start time)
+	start := time.Now()
+
+	// 执行下一个处理器 (Execute next handler)
+	err := next()
+
+	// 获取响应状态码 (Get response status code)
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusInternalServerError
+	} else if resp, ok := ctx.Response().(gin.ResponseWriter); ok {
+		status = resp.Status()
+	}
+
+	m.logRequest(req, status, time.Since(start), ctx.ClientIP(), err)
+	return err
+}
+
+// logRequest 按采样决策记录请求 (Log the request according to the sampling decision)
+func (m *LoggerMiddleware) logRequest(req *http.Request, status int, latency time.Duration, clientIP string, err error) {
+	if m.logger == nil {
+		return
+	}
+	decision := m.sampler.Decide(req.URL.Path, status, latency, err)
+	if decision == sdkmiddleware.AccessLogDrop {
+		return
+	}
+
+	fields := []interface{}{
+		"method", req.Method,
+		"uri", req.RequestURI,
+		"status", status,
+		"latency", latency.String(),
+		"client_ip", clientIP,
+		"user_agent", req.UserAgent(),
+		"framework", "gin",
+	}
+	if err != nil {
+		fields = append(fields, "error", err.Error())
+	}
+
+	switch decision {
+	case sdkmiddleware.AccessLogError:
+		m.logger.Errorw("HTTP Request", fields...)
+	case sdkmiddleware.AccessLogSlow:
+		m.logger.Warnw("Slow HTTP Request", fields...)
+	default:
+		m.logger.Infow("HTTP Request", fields...)
+	}
+}
+
+// GetConfig 获取Logger配置 (Get Logger configuration)
+func (m *LoggerMiddleware) GetConfig() *LoggerConfig {
+	return m.config
+}
+
+// SetConfig 设置Logger配置，采样策略立即生效 (Set Logger configuration; the sampling policy takes effect immediately)
+func (m *LoggerMiddleware) SetConfig(config *LoggerConfig) {
+	if config != nil {
+		m.config = config
+		m.sampler.Update(samplerConfig(config))
+	}
+}
+
+// UpdateSampling 热更新排除路径、采样比例和慢请求阈值，其余配置保持不变 (Hot-reload skip paths, sample rate and slow threshold; other settings are unchanged)
+func (m *LoggerMiddleware) UpdateSampling(config server.LoggerMiddlewareConfig) {
+	m.sampler.Update(config)
+}
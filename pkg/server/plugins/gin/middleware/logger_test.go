@@ -0,0 +1,62 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: Tests for Gin Logger middleware / Gin Logger中间件测试
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log/logtest"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoggerMiddleware_SetConfig 测试设置Logger配置 (Test setting Logger configuration)
+func TestLoggerMiddleware_SetConfig(t *testing.T) {
+	middleware := NewLoggerMiddleware(nil, nil)
+	assert.Equal(t, DefaultLoggerConfig(), middleware.GetConfig())
+
+	newConfig := &LoggerConfig{Enabled: true, SkipPaths: []string{"/api/*"}}
+	middleware.SetConfig(newConfig)
+	assert.Equal(t, newConfig, middleware.GetConfig())
+	assert.True(t, middleware.sampler.Skip("/api/orders"))
+
+	// SetConfig(nil) 不会改变配置 (SetConfig(nil) keeps the configuration)
+	middleware.SetConfig(nil)
+	assert.Equal(t, newConfig, middleware.GetConfig())
+}
+
+// TestLoggerMiddleware_SlowRequest 测试采样时慢请求仍以警告级别记录 (Test that slow requests are still logged at warn level when sampling)
+func TestLoggerMiddleware_SlowRequest(t *testing.T) {
+	logger := logtest.NewTestLogger(t)
+	serviceContainer := services.NewServiceContainer()
+	serviceContainer.SetLogger(services.NewLoggerImpl(logger))
+
+	middleware := NewLoggerMiddleware(&LoggerConfig{
+		Enabled:       true,
+		SampleRate:    1e-9,
+		SlowThreshold: 10 * time.Millisecond,
+	}, serviceContainer)
+
+	engine := setupTestEngine()
+	engine.Use(middleware.GetGinHandler())
+	engine.GET("/fast", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/slow", func(c *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/fast", "/slow"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	logger.NotContainsEntry("", "HTTP Request", "uri", "/fast")
+	logger.ContainsEntry("warn", "Slow HTTP Request", "uri", "/slow", "status", 200, "framework", "gin")
+}
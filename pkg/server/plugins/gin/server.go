@@ -20,11 +20,14 @@ import (
 // GinServer Gin服务器适配器 (Gin server adapter)
 // 将Gin引擎适配到统一的WebFramework接口 (Adapts Gin engine to unified WebFramework interface)
 type GinServer struct {
-	engine     *gin.Engine
-	config     *server.ServerConfig
-	httpServer *http.Server
-	routes     map[string]*GinRouteGroup
-	services   services.ServiceContainer
+	engine       *gin.Engine
+	config       *server.ServerConfig
+	httpServer   *http.Server
+	routes       map[string]*GinRouteGroup
+	services     services.ServiceContainer
+	limits       *server.RuntimeLimits           // 可热更新的请求限制 (Hot-reloadable request limits)
+	drain        *server.DrainTracker            // 请求跟踪与优雅排空 (Request tracking and graceful drain)
+	accessLogger *ginMiddleware.LoggerMiddleware // 访问日志中间件 (Access log middleware)
 }
 
 // NewGinServer 创建Gin服务器适配器 (Create Gin server adapter)
@@ -58,10 +61,13 @@ func NewGinServerWithServices(config *server.ServerConfig, serviceContainer serv
 		applyGinConfig(engine, ginConfig)
 	}
 	
-	// 创建HTTP服务器 (Create HTTP server)
+	// 创建HTTP服务器，DrainTracker 跟踪请求，RuntimeLimits 逐请求应用可热更新的限制
+	// (Create HTTP server; DrainTracker tracks requests and RuntimeLimits applies hot-reloadable limits per request)
+	limits := server.NewRuntimeLimits(config)
+	drain := server.NewDrainTracker(config.GracefulShutdown.RetryAfter)
 	httpServer := &http.Server{
 		Addr:           config.GetAddress(),
-		Handler:        engine,
+		Handler:        drain.Handler(limits.Handler(engine)),
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
 		IdleTimeout:    config.IdleTimeout,
@@ -74,6 +80,8 @@ func NewGinServerWithServices(config *server.ServerConfig, serviceContainer serv
 		httpServer: httpServer,
 		routes:     make(map[string]*GinRouteGroup),
 		services:   serviceContainer,
+		limits:     limits,
+		drain:      drain,
 	}
 	
 	// 设置中间件 (Setup middleware)
@@ -238,6 +246,20 @@ func (s *GinServer) adaptMiddleware(middleware server.Middleware) gin.HandlerFun
 	}
 }
 
+// DrainTracker 返回请求跟踪器 (Return the request tracker)
+func (s *GinServer) DrainTracker() *server.DrainTracker {
+	return s.drain
+}
+
+// ApplyRuntimeConfig 应用可热更新的配置 (Apply hot-reloadable configuration)
+func (s *GinServer) ApplyRuntimeConfig(config *server.ServerConfig) []string {
+	s.limits.Update(config)
+	if s.accessLogger != nil {
+		s.accessLogger.UpdateSampling(config.Middleware.Logger)
+	}
+	return nil
+}
+
 // setupMiddleware 设置中间件 (Setup middleware)
 func (s *GinServer) setupMiddleware() {
 	// 设置恢复中间件 (Setup recovery middleware) - 使用原生实现
@@ -249,9 +271,16 @@ func (s *GinServer) setupMiddleware() {
 		}
 	}
 
-	// 设置日志中间件 (Setup logger middleware) - 使用原生实现
+	// 设置日志中间件 (Setup logger middleware) - 使用统一实现
 	if s.config.Middleware.Logger.Enabled {
-		s.engine.Use(gin.Logger())
+		loggerConfig := &ginMiddleware.LoggerConfig{
+			Enabled:       s.config.Middleware.Logger.Enabled,
+			SkipPaths:     s.config.Middleware.Logger.SkipPaths,
+			SampleRate:    s.config.Middleware.Logger.SampleRate,
+			SlowThreshold: s.config.Middleware.Logger.SlowThreshold,
+		}
+		s.accessLogger = ginMiddleware.NewLoggerMiddleware(loggerConfig, s.services)
+		s.engine.Use(s.accessLogger.GetGinHandler())
 	}
 
 	// 设置CORS中间件 (Setup CORS middleware) - 使用统一实现
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log/logtest"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
 	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server/services"
 )
@@ -406,4 +407,49 @@ func BenchmarkGinServerHandleRequest(b *testing.B) {
 		w := httptest.NewRecorder()
 		ginServer.GetGinEngine().ServeHTTP(w, req)
 	}
-} 
\ No newline at end of file
+} 
+// TestGinServerAccessLogSampling 测试访问日志的排除路径、采样和热更新 (Test access log skip paths, sampling and hot reload)
+func TestGinServerAccessLogSampling(t *testing.T) {
+	config := server.DefaultServerConfig()
+	config.Mode = "test"
+	config.Middleware.Logger.SkipPaths = []string{"/health"}
+	config.Middleware.Logger.SampleRate = 1e-9
+
+	logger := logtest.NewTestLogger(t)
+	serviceContainer := services.NewServiceContainerWithDefaults()
+	serviceContainer.SetLogger(services.NewLoggerImpl(logger))
+
+	ginServer := NewGinServerWithServices(config, serviceContainer)
+	engine := ginServer.GetGinEngine()
+	engine.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	serve := func(path string) {
+		rec := httptest.NewRecorder()
+		ginServer.GetHTTPServer().Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	serve("/health")
+	serve("/orders")
+	serve("/missing")
+	serve("/fail")
+
+	logger.NotContainsEntry("", "HTTP Request", "uri", "/health")
+	logger.NotContainsEntry("", "HTTP Request", "uri", "/orders")
+	logger.ContainsEntry("info", "HTTP Request", "uri", "/missing", "status", 404, "framework", "gin")
+	logger.ContainsEntry("error", "HTTP Request", "uri", "/fail", "status", 500)
+
+	// 采样配置热更新后立即生效，无需重启 (Sampling changes apply at once without a restart)
+	next := *config
+	next.Middleware.Logger.SampleRate = 0
+	if restart := ginServer.ApplyRuntimeConfig(&next); len(restart) != 0 {
+		t.Errorf("ApplyRuntimeConfig() requires a restart for %v", restart)
+	}
+
+	logger.Reset()
+	serve("/orders")
+	serve("/health")
+	logger.ContainsEntry("info", "HTTP Request", "uri", "/orders", "status", 200)
+	logger.NotContainsEntry("", "HTTP Request", "uri", "/health")
+}
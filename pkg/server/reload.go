@@ -0,0 +1,126 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 服务器配置热重载 (Server configuration hot reload)
+ */
+
+package server
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	"github.com/spf13/viper"
+)
+
+// RuntimeConfigurable 由可以在运行时应用配置变更的框架实现 (Implemented by frameworks that can apply configuration changes at runtime)
+type RuntimeConfigurable interface {
+	// ApplyRuntimeConfig 应用可热更新的配置子集，返回该框架无法热更新、仍需重启才能生效的字段
+	// (Apply the hot-reloadable subset of the configuration and return the fields this framework cannot reload and that still need a restart)
+	ApplyRuntimeConfig(config *ServerConfig) (restartRequired []string)
+}
+
+// reloadableField 可热更新的配置字段 (A hot-reloadable configuration field)
+type reloadableField struct {
+	name  string
+	apply func(dst, src *ServerConfig)
+}
+
+// reloadableFields 可在运行时安全应用的配置字段 (Configuration fields that can be applied safely at runtime)
+var reloadableFields = []reloadableField{
+	{"read-timeout", func(d, s *ServerConfig) { d.ReadTimeout = s.ReadTimeout }},
+	{"write-timeout", func(d, s *ServerConfig) { d.WriteTimeout = s.WriteTimeout }},
+	{"max-header-bytes", func(d, s *ServerConfig) { d.MaxHeaderBytes = s.MaxHeaderBytes }},
+	{"middleware.rate-limit", func(d, s *ServerConfig) { d.Middleware.RateLimit = s.Middleware.RateLimit }},
+	{"middleware.logger.skip-paths", func(d, s *ServerConfig) { d.Middleware.Logger.SkipPaths = s.Middleware.Logger.SkipPaths }},
+	{"middleware.logger.sample-rate", func(d, s *ServerConfig) { d.Middleware.Logger.SampleRate = s.Middleware.Logger.SampleRate }},
+	{"middleware.logger.slow-threshold", func(d, s *ServerConfig) { d.Middleware.Logger.SlowThreshold = s.Middleware.Logger.SlowThreshold }},
+}
+
+// restartRequiredChanges 返回只有重启才能生效的已变更字段 (Return the changed fields that only take effect after a restart)
+func restartRequiredChanges(current, next *ServerConfig) []string {
+	fields := []struct {
+		name     string
+		old, new any
+	}{
+		{"framework", current.Framework, next.Framework},
+		{"host", current.Host, next.Host},
+		{"port", current.Port, next.Port},
+		{"mode", current.Mode, next.Mode},
+		{"idle-timeout", current.IdleTimeout, next.IdleTimeout},
+		{"cors", current.CORS, next.CORS},
+		{"tls", current.TLS, next.TLS},
+		{"graceful-shutdown", current.GracefulShutdown, next.GracefulShutdown},
+		{"plugins", current.Plugins, next.Plugins},
+		{"middleware.recovery", current.Middleware.Recovery, next.Middleware.Recovery},
+		{"middleware.auth", current.Middleware.Auth, next.Middleware.Auth},
+		{"middleware.logger.enabled", current.Middleware.Logger.Enabled, next.Middleware.Logger.Enabled},
+		{"middleware.logger.format", current.Middleware.Logger.Format, next.Middleware.Logger.Format},
+		{"middleware.logger.include-body", current.Middleware.Logger.IncludeBody, next.Middleware.Logger.IncludeBody},
+		{"middleware.logger.max-body-size", current.Middleware.Logger.MaxBodySize, next.Middleware.Logger.MaxBodySize},
+	}
+
+	var changed []string
+	for _, f := range fields {
+		if !reflect.DeepEqual(f.old, f.new) {
+			changed = append(changed, f.name)
+		}
+	}
+	return changed
+}
+
+// ApplyConfig 在不重启的情况下应用配置中可安全热更新的部分：新请求的读写超时、请求头上限、限流和访问日志采样
+// 其他字段的变更被忽略并在返回值中列出，重启后才会生效
+// (ApplyConfig applies the safely hot-reloadable part of the configuration without a restart: read/write timeouts for new requests,
+// the header size limit, rate limiting and access log sampling. Changes to other fields are ignored and listed in the return value;
+// they take effect after a restart.)
+func (sm *ServerManager) ApplyConfig(config *ServerConfig) (restartRequired []string, err error) {
+	next := *config
+	if err := next.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	reloader, ok := sm.framework.(RuntimeConfigurable)
+	if !ok {
+		return nil, fmt.Errorf("framework %s does not support runtime configuration changes", sm.config.Framework)
+	}
+
+	sm.reloadMu.Lock()
+	defer sm.reloadMu.Unlock()
+
+	restartRequired = restartRequiredChanges(sm.config, &next)
+	unapplied := reloader.ApplyRuntimeConfig(&next)
+	for _, field := range reloadableFields {
+		if slices.Contains(unapplied, field.name) {
+			continue
+		}
+		field.apply(sm.config, &next)
+	}
+	return append(restartRequired, unapplied...), nil
+}
+
+// RegisterConfigHotReload 在配置管理器上注册 sectionKey 配置节的热重载回调，配置变更时调用 ApplyConfig
+// 应在加载配置、创建服务器管理器之后调用
+// (RegisterConfigHotReload registers a hot-reload callback for the sectionKey configuration section on the configuration manager;
+// ApplyConfig is called whenever the configuration changes. Call it after loading the configuration and creating the server manager.)
+func (sm *ServerManager) RegisterConfigHotReload(cfgManager config.Manager, sectionKey string) {
+	cfgManager.RegisterSectionChangeCallback(sectionKey, func(v *viper.Viper) error {
+		cfg := DefaultServerConfig()
+		if err := v.UnmarshalKey(sectionKey, cfg); err != nil {
+			return fmt.Errorf("failed to unmarshal server configuration: %w", err)
+		}
+
+		restartRequired, err := sm.ApplyConfig(cfg)
+		if err != nil {
+			return err
+		}
+		if len(restartRequired) > 0 {
+			log.Printf("Warn: changes to server configuration [%s] require a restart to take effect.", strings.Join(restartRequired, ", ")) // 与 pkg/config 一致使用标准 log (Use standard log, as pkg/config does)
+		}
+		return nil
+	})
+}
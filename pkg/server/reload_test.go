@@ -0,0 +1,124 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: 服务器配置热重载测试 (Server configuration hot reload tests)
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reloadableFramework 支持运行时配置的模拟框架 (Mock framework supporting runtime configuration)
+type reloadableFramework struct {
+	MockWebFramework
+	applied   []*ServerConfig
+	unapplied []string
+}
+
+func (f *reloadableFramework) ApplyRuntimeConfig(config *ServerConfig) []string {
+	f.applied = append(f.applied, config)
+	return f.unapplied
+}
+
+// sectionManager 只记录节回调的配置管理器 (Configuration manager that only records section callbacks)
+type sectionManager struct {
+	config.Manager
+	callbacks map[string]config.SectionChangeCallback
+}
+
+func (m *sectionManager) RegisterSectionChangeCallback(sectionKey string, callback config.SectionChangeCallback) {
+	m.callbacks[sectionKey] = callback
+}
+
+// TestServerManager_ApplyConfig 测试只应用可热更新的字段 (Test that only hot-reloadable fields are applied)
+func TestServerManager_ApplyConfig(t *testing.T) {
+	current := DefaultServerConfig()
+	framework := &reloadableFramework{}
+	manager := NewServerManager(framework, current)
+
+	next := DefaultServerConfig()
+	next.Port = 9090
+	next.ReadTimeout = 5 * time.Second
+	next.MaxHeaderBytes = 8192
+	next.Middleware.RateLimit.Enabled = true
+	next.Middleware.Logger.SampleRate = 0.1
+
+	restartRequired, err := manager.ApplyConfig(next)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"port"}, restartRequired)
+	require.Len(t, framework.applied, 1)
+	assert.Equal(t, 5*time.Second, framework.applied[0].ReadTimeout)
+
+	assert.Equal(t, 8080, current.Port, "port is not reloadable")
+	assert.Equal(t, 5*time.Second, current.ReadTimeout)
+	assert.Equal(t, 8192, current.MaxHeaderBytes)
+	assert.True(t, current.Middleware.RateLimit.Enabled)
+	assert.Equal(t, 0.1, current.Middleware.Logger.SampleRate)
+
+	// 框架无法应用的字段保持不变 (Fields the framework cannot apply stay unchanged)
+	framework.unapplied = []string{"write-timeout"}
+	next = DefaultServerConfig()
+	next.WriteTimeout = time.Minute
+	restartRequired, err = manager.ApplyConfig(next)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"write-timeout"}, restartRequired)
+	assert.Equal(t, 30*time.Second, current.WriteTimeout)
+
+	// 无效配置被拒绝 (Invalid configuration is rejected)
+	next = DefaultServerConfig()
+	next.Middleware.RateLimit = RateLimitMiddlewareConfig{Enabled: true}
+	_, err = manager.ApplyConfig(next)
+	assert.Error(t, err)
+	assert.Len(t, framework.applied, 2)
+}
+
+// TestServerManager_ApplyConfigUnsupported 测试不支持运行时配置的框架 (Test frameworks without runtime configuration support)
+func TestServerManager_ApplyConfigUnsupported(t *testing.T) {
+	manager := NewServerManager(&MockWebFramework{}, DefaultServerConfig())
+	_, err := manager.ApplyConfig(DefaultServerConfig())
+	assert.ErrorContains(t, err, "does not support runtime configuration changes")
+}
+
+// TestServerManager_RegisterConfigHotReload 测试配置节变更回调 (Test the configuration section change callback)
+func TestServerManager_RegisterConfigHotReload(t *testing.T) {
+	current := DefaultServerConfig()
+	framework := &reloadableFramework{}
+	manager := NewServerManager(framework, current)
+	cfgManager := &sectionManager{callbacks: make(map[string]config.SectionChangeCallback)}
+	manager.RegisterConfigHotReload(cfgManager, "server")
+	callback := cfgManager.callbacks["server"]
+	require.NotNil(t, callback)
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(`
+server:
+  write-timeout: 45s
+  middleware:
+    rate-limit:
+      enabled: true
+      rate: 50
+      burst: 100
+`)))
+	require.NoError(t, callback(v))
+	assert.Equal(t, 45*time.Second, current.WriteTimeout)
+	assert.Equal(t, RateLimitMiddlewareConfig{Enabled: true, Rate: 50, Burst: 100, KeyFunc: "ip"}, current.Middleware.RateLimit)
+
+	require.NoError(t, v.ReadConfig(strings.NewReader(`
+server:
+  middleware:
+    rate-limit:
+      enabled: true
+      rate: 0
+`)))
+	assert.Error(t, callback(v))
+}
@@ -11,8 +11,12 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // ServerManager 服务器管理器 (Server manager)
@@ -21,6 +25,7 @@ type ServerManager struct {
 	framework WebFramework
 	config    *ServerConfig
 	running   bool
+	reloadMu  sync.Mutex // 串行化 ApplyConfig (Serializes ApplyConfig)
 }
 
 // NewServerManager 创建服务器管理器 (Create server manager)
@@ -70,6 +75,11 @@ func (sm *ServerManager) Stop(ctx context.Context) error {
 		return fmt.Errorf("server is not running")
 	}
 	
+	// 先排空进行中的请求，超时后仍继续停止 (Drain in-flight requests first; stopping continues even if the drain times out)
+	if err := sm.Drain(ctx); err != nil {
+		log.Std().Warnw("Drain incomplete", "error", err)
+	}
+	
 	// 停止框架 (Stop framework)
 	if err := sm.framework.Stop(ctx); err != nil {
 		return fmt.Errorf("failed to stop framework: %w", err)
@@ -79,6 +89,50 @@ func (sm *ServerManager) Stop(ctx context.Context) error {
 	return nil
 }
 
+// Drain 以 503 拒绝新请求并等待进行中的请求完成，每秒通过全局 logger 记录一次进度；框架不支持排空时直接返回。
+// 排空指标需要调用方通过 RegisterDrainMetrics 注册
+// (Drain rejects new requests with 503 and waits for in-flight requests to finish, logging progress every second through
+// the global logger; it returns immediately when the framework does not support draining.
+// Drain metrics are only reported once the caller registers them with RegisterDrainMetrics)
+func (sm *ServerManager) Drain(ctx context.Context) error {
+	drainable, ok := sm.framework.(Drainable)
+	if !ok {
+		return nil
+	}
+
+	tracker := drainable.DrainTracker()
+	tracker.StartDrain()
+	logger := log.Std()
+	logger.Infow("Draining server", "in_flight", tracker.InFlight())
+	err := tracker.Wait(ctx, time.Second, func(inFlight int64) {
+		logger.Infow("Draining server", "in_flight", inFlight, "rejected", tracker.Rejected())
+	})
+	if err != nil {
+		return fmt.Errorf("%d request(s) still in flight: %w", tracker.InFlight(), err)
+	}
+	logger.Infow("Server drained", "rejected", tracker.Rejected())
+	return nil
+}
+
+// RegisterDrainMetrics 将框架的排空指标（<namespace>_server_in_flight_requests、<namespace>_server_draining、
+// <namespace>_server_drain_rejected_requests_total）注册到 reg，例如 metrics.Default()。ServerManager 不会自动注册它们；
+// 框架不支持排空时不做任何操作
+// (RegisterDrainMetrics registers the framework's drain metrics, <namespace>_server_in_flight_requests,
+// <namespace>_server_draining and <namespace>_server_drain_rejected_requests_total, with reg, e.g. metrics.Default().
+// ServerManager never registers them on its own; it is a no-op when the framework does not support draining)
+func (sm *ServerManager) RegisterDrainMetrics(reg prometheus.Registerer, namespace string) error {
+	drainable, ok := sm.framework.(Drainable)
+	if !ok {
+		return nil
+	}
+	for _, c := range drainable.DrainTracker().Collectors(namespace) {
+		if err := reg.Register(c); err != nil {
+			return fmt.Errorf("failed to register drain metrics: %w", err)
+		}
+	}
+	return nil
+}
+
 // IsRunning 检查服务器是否正在运行 (Check if server is running)
 func (sm *ServerManager) IsRunning() bool {
 	return sm.running
@@ -109,8 +163,8 @@ func (sm *ServerManager) handleGracefulShutdown() {
 	ctx, cancel := context.WithTimeout(context.Background(), sm.config.GracefulShutdown.Timeout)
 	defer cancel()
 	
-	// 等待一段时间让正在处理的请求完成 (Wait for ongoing requests to complete)
-	if sm.config.GracefulShutdown.WaitTime > 0 {
+	// 等待一段时间让正在处理的请求完成，支持排空的框架在 Stop 中按实际请求数等待 (Wait for ongoing requests to complete; drainable frameworks wait on the actual in-flight count in Stop)
+	if _, drainable := sm.framework.(Drainable); !drainable && sm.config.GracefulShutdown.WaitTime > 0 {
 		fmt.Printf("Waiting %v for ongoing requests to complete...\n", sm.config.GracefulShutdown.WaitTime)
 		time.Sleep(sm.config.GracefulShutdown.WaitTime)
 	}
@@ -365,6 +365,7 @@ func BenchmarkErrorHandlerImpl_New(b *testing.B) {
 
 // mockConfigManager 模拟配置管理器 (Mock config manager)
 type mockConfigManager struct {
+	config.Values
 	viper *viper.Viper
 }
 
@@ -378,4 +379,24 @@ func (m *mockConfigManager) RegisterCallback(callback func(v *viper.Viper, cfg a
 
 func (m *mockConfigManager) RegisterSectionChangeCallback(sectionKey string, callback config.SectionChangeCallback) {
 	// 空实现 (Empty implementation for interface compliance)
-} 
\ No newline at end of file
+} 
+
+func (m *mockConfigManager) LastChangeSet() config.ChangeSet {
+	return nil
+}
+
+func (m *mockConfigManager) Set(key string, value any) error {
+	return nil
+}
+
+func (m *mockConfigManager) Save() error {
+	return nil
+}
+
+func (m *mockConfigManager) Snapshot() (any, uint64) {
+	return nil, 0
+}
+
+func (m *mockConfigManager) Version() uint64 {
+	return 0
+}
\ No newline at end of file
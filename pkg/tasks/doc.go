@@ -0,0 +1,35 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package tasks 监管后台 goroutine，取代裸的 `go func()`：任务的日志和 trace 与发起它的请求关联，panic 不会让进程崩溃，
+关闭时可以等待任务结束。
+(Package tasks supervises background goroutines in place of a bare `go func()`: task logs and traces correlate with the request
+that started them, panics do not crash the process and shutdown can wait for tasks to finish.)
+
+Go 启动的任务保留调用方上下文的值（logger、OpenTelemetry span、trace/request 标识），但不随调用方取消，因此请求返回后
+任务仍可继续；任务在 Shutdown 时被取消。每个任务有一个 "task <name>" span，logger 附带 task、trace_id 和 request_id 字段。
+panic 被恢复为带 errors.ErrPanic 的错误，失败的任务以 Error 级别记录。
+(A task started with Go keeps the values of the caller's context (logger, OpenTelemetry span, trace/request identifiers) but is
+not cancelled with the caller, so it can outlive the request; tasks are cancelled on Shutdown. Every task gets a "task <name>" span
+and its logger carries the task, trace_id and request_id fields. Panics are recovered into errors coded errors.ErrPanic and failed
+tasks are logged at Error level.)
+
+	if err := tasks.Go(ctx, "send-welcome-email", func(ctx context.Context) error {
+		return mailer.Send(ctx, user.Email)
+	}); err != nil {
+		return err // errors.ErrTasksClosed after shutdown began
+	}
+
+	// 关闭时 (On shutdown)
+	if err := tasks.Shutdown(shutdownCtx); err != nil {
+		log.Warnw("Background tasks did not finish", "error", err) // errors.ErrTasksShutdown
+	}
+
+Manager.Collectors 返回 <namespace>_tasks_running、_tasks_started_total 和 _tasks_failed_total 指标。
+(Manager.Collectors returns the <namespace>_tasks_running, _tasks_started_total and _tasks_failed_total metrics.)
+*/
+package tasks
@@ -0,0 +1,285 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName 是任务 span 的 instrumentation scope。(tracerName is the instrumentation scope of task spans.)
+const tracerName = "github.com/lmcc-dev/lmcc-go-sdk/pkg/tasks"
+
+// Func 是后台任务的函数体。ctx 在管理器关闭时被取消。
+// (Func is the body of a background task. ctx is cancelled when the manager shuts down.)
+type Func func(ctx context.Context) error
+
+// Info 描述一个正在运行的任务。(Info describes a running task.)
+type Info struct {
+	ID      uint64    `json:"id"`
+	Name    string    `json:"name"`
+	Started time.Time `json:"started"`
+}
+
+// Option 是配置 Manager 的函数类型。(Option is a function type for configuring a Manager.)
+type Option func(*Manager)
+
+// WithLogger 设置任务使用的基础 logger。未设置时使用调用方上下文中的 logger（log.FromContext）。
+// (WithLogger sets the base logger of tasks. When unset the logger of the caller's context is used, see log.FromContext.)
+func WithLogger(logger log.Logger) Option {
+	return func(m *Manager) {
+		m.logger = logger
+	}
+}
+
+// WithName 设置管理器名称，作为任务名称的前缀出现在日志、span 和错误中。
+// (WithName sets the manager name, which prefixes task names in logs, spans and errors.)
+func WithName(name string) Option {
+	return func(m *Manager) {
+		m.name = name
+	}
+}
+
+// WithTracerProvider 设置创建任务 span 的 TracerProvider，默认使用 otel 全局提供者。
+// (WithTracerProvider sets the TracerProvider used for task spans; the otel global provider is used otherwise.)
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(m *Manager) {
+		m.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// Manager 监管后台任务：任务继承调用方上下文中的 logger、trace 和请求标识，但不会随调用方取消；
+// panic 被恢复为带 ErrPanic 的错误；Shutdown 取消所有任务并等待它们结束。
+// (Manager supervises background tasks: a task inherits the logger, trace and request identifiers of the caller's context but is not
+// cancelled with it; panics are recovered into errors coded ErrPanic; Shutdown cancels every task and waits for them to finish.)
+type Manager struct {
+	name   string
+	logger log.Logger
+	tracer trace.Tracer
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	mu      sync.Mutex
+	closed  bool
+	nextID  uint64
+	running map[uint64]Info
+	wg      sync.WaitGroup
+
+	started atomic.Int64
+	failed  atomic.Int64
+}
+
+// NewManager 创建任务管理器。(NewManager creates a task manager.)
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		tracer:  otel.Tracer(tracerName),
+		running: make(map[uint64]Info),
+	}
+	m.ctx, m.cancel = context.WithCancelCause(context.Background())
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Go 在新的 goroutine 中运行 fn。任务上下文保留 ctx 的值（logger、trace span、请求标识等），取消则跟随管理器；
+// 任务在 ctx 的 span 下开始名为 "task <name>" 的子 span，logger 附加 "task" 字段。失败和 panic 会被记录日志。
+// 管理器关闭后返回带 ErrTasksClosed 的错误。
+// (Go runs fn in a new goroutine. The task context keeps the values of ctx (logger, trace span, request identifiers and so on)
+// while cancellation follows the manager; the task starts a child span "task <name>" under the span in ctx and its logger gets
+// a "task" field. Failures and panics are logged. After shutdown begins it returns an error coded ErrTasksClosed.)
+func (m *Manager) Go(ctx context.Context, name string, fn Func) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return lmccerrors.ErrorfWithCode(lmccerrors.ErrTasksClosed, "cannot start task '%s': task manager is shutting down", name)
+	}
+	m.nextID++
+	info := Info{ID: m.nextID, Name: name, Started: time.Now()}
+	m.running[info.ID] = info
+	m.wg.Add(1)
+	m.mu.Unlock()
+	m.started.Add(1)
+
+	taskCtx, cancel := m.taskContext(ctx)
+	taskCtx, span := m.tracer.Start(taskCtx, "task "+m.label(name), trace.WithAttributes(attribute.String("task.name", name)))
+	logger := m.taskLogger(taskCtx, name)
+	taskCtx = log.NewContextWithLogger(taskCtx, logger)
+
+	go func() {
+		defer func() {
+			cancel()
+			span.End()
+			m.mu.Lock()
+			delete(m.running, info.ID)
+			m.mu.Unlock()
+			m.wg.Done()
+		}()
+
+		err := m.run(taskCtx, name, fn)
+		elapsed := time.Since(info.Started)
+		switch {
+		case err == nil:
+			logger.Debugw("Background task finished", "duration", elapsed)
+		case errors.Is(err, context.Canceled) && m.ctx.Err() != nil:
+			logger.Debugw("Background task cancelled by shutdown", "duration", elapsed, "error", err)
+		default:
+			m.failed.Add(1)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			logger.Errorw("Background task failed", "duration", elapsed, "error", err)
+		}
+	}()
+	return nil
+}
+
+// taskContext 返回保留 ctx 的值、但随管理器取消的上下文。
+// (taskContext returns a context keeping the values of ctx but cancelled with the manager.)
+func (m *Manager) taskContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	taskCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(m.ctx, func() { cancel(context.Cause(m.ctx)) })
+	return taskCtx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+// taskLogger 返回带 "task" 字段以及上下文中 trace/request 标识的 logger，使后台日志能与发起请求关联。
+// (taskLogger returns a logger carrying the "task" field and the trace/request identifiers of ctx, so background logs correlate with the originating request.)
+func (m *Manager) taskLogger(ctx context.Context, name string) log.Logger {
+	logger := m.logger
+	if logger == nil {
+		logger = log.FromContext(ctx)
+	}
+	kv := []any{"task", m.label(name)}
+	if traceID, ok := log.TraceIDFromContext(ctx); ok {
+		kv = append(kv, "trace_id", traceID)
+	} else if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		kv = append(kv, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+	if requestID, ok := log.RequestIDFromContext(ctx); ok {
+		kv = append(kv, "request_id", requestID)
+	}
+	return logger.WithValues(kv...)
+}
+
+// run 执行任务并将 panic 恢复为带 ErrPanic 的错误，错误的堆栈从发生 panic 的帧开始。
+// (run executes the task and recovers a panic into an error coded ErrPanic, whose stack starts at the frame that panicked.)
+func (m *Manager) run(ctx context.Context, name string, fn Func) (err error) {
+	defer lmccerrors.HandlePanic(func(p any) {
+		err = lmccerrors.Wrapf(lmccerrors.WrapPanic(p), "panic in task '%s'", m.label(name))
+	})
+	return fn(ctx)
+}
+
+// label 组合管理器名称和任务名称。(label combines the manager name with the task name.)
+func (m *Manager) label(name string) string {
+	if m.name == "" {
+		return name
+	}
+	return m.name + "/" + name
+}
+
+// Count 返回正在运行的任务数。(Count returns the number of running tasks.)
+func (m *Manager) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.running)
+}
+
+// Running 返回正在运行的任务，按启动顺序排列。(Running returns the running tasks in start order.)
+func (m *Manager) Running() []Info {
+	m.mu.Lock()
+	out := make([]Info, 0, len(m.running))
+	for _, info := range m.running {
+		out = append(out, info)
+	}
+	m.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Shutdown 拒绝新任务，取消所有任务的上下文并等待它们结束。ctx 先结束时返回带 ErrTasksShutdown 的错误，列出仍在运行的任务。
+// (Shutdown rejects new tasks, cancels every task's context and waits for them to finish. When ctx ends first it returns an error
+// coded ErrTasksShutdown listing the tasks still running.)
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+	m.cancel(lmccerrors.NewWithCode(lmccerrors.ErrTasksClosed, "task manager is shutting down"))
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		running := m.Running()
+		names := make([]string, len(running))
+		for i, info := range running {
+			names[i] = fmt.Sprintf("%s (running %s)", m.label(info.Name), time.Since(info.Started).Round(time.Millisecond))
+		}
+		return lmccerrors.WithCode(
+			lmccerrors.Wrapf(ctx.Err(), "%d tasks still running: %s", len(running), strings.Join(names, ", ")),
+			lmccerrors.ErrTasksShutdown,
+		)
+	}
+}
+
+// Collectors 返回任务指标：tasks_running、tasks_started_total 和 tasks_failed_total。
+// (Collectors returns the task metrics: tasks_running, tasks_started_total and tasks_failed_total.)
+func (m *Manager) Collectors(namespace string) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "tasks", Name: "running",
+			Help: "Number of background tasks currently running.",
+		}, func() float64 { return float64(m.Count()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "tasks", Name: "started_total",
+			Help: "Number of background tasks started.",
+		}, func() float64 { return float64(m.started.Load()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "tasks", Name: "failed_total",
+			Help: "Number of background tasks that returned an error or panicked.",
+		}, func() float64 { return float64(m.failed.Load()) }),
+	}
+}
+
+var defaultManager = NewManager()
+
+// Default 返回包级默认管理器。(Default returns the package-level default manager.)
+func Default() *Manager {
+	return defaultManager
+}
+
+// Go 在默认管理器上运行任务。(Go runs a task on the default manager.)
+func Go(ctx context.Context, name string, fn Func) error {
+	return defaultManager.Go(ctx, name, fn)
+}
+
+// Shutdown 关闭默认管理器。(Shutdown shuts down the default manager.)
+func Shutdown(ctx context.Context) error {
+	return defaultManager.Shutdown(ctx)
+}
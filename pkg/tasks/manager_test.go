@@ -0,0 +1,187 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for the background task manager.
+ */
+
+package tasks_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/tasks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// syncBuffer 是并发安全的 bytes.Buffer。(syncBuffer is a concurrency-safe bytes.Buffer.)
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newJSONLogger(w *syncBuffer) log.Logger {
+	opts := log.NewOptions()
+	opts.Format = "json"
+	opts.Level = "debug"
+	return log.NewLoggerWithWriter(opts, w)
+}
+
+// TestGoOutlivesCallerAndCorrelates tests that a task survives the caller's cancellation and keeps its logger, trace and request ID.
+// (TestGoOutlivesCallerAndCorrelates 测试任务在调用方取消后继续运行，并保留其 logger、trace 和请求标识。)
+func TestGoOutlivesCallerAndCorrelates(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	var out syncBuffer
+	m := tasks.NewManager(tasks.WithName("mailer"), tasks.WithTracerProvider(tp))
+
+	parent, span := tp.Tracer("test").Start(context.Background(), "request")
+	parent = log.NewContextWithLogger(parent, newJSONLogger(&out))
+	parent = log.ContextWithRequestID(parent, "req-42")
+	parent, cancelParent := context.WithCancel(parent)
+
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	require.NoError(t, m.Go(parent, "welcome", func(ctx context.Context) error {
+		<-release
+		log.FromContext(ctx).Info("sending welcome email")
+		done <- ctx.Err()
+		return nil
+	}))
+	cancelParent()
+	span.End()
+	assert.Equal(t, 1, m.Count())
+	require.Len(t, m.Running(), 1)
+	assert.Equal(t, "welcome", m.Running()[0].Name)
+
+	close(release)
+	assert.NoError(t, <-done, "the caller's cancellation must not reach the task")
+	require.NoError(t, m.Shutdown(context.Background()))
+	assert.Equal(t, 0, m.Count())
+
+	logs := out.String()
+	assert.Contains(t, logs, `"task":"mailer/welcome"`)
+	assert.Contains(t, logs, `"request_id":"req-42"`)
+	assert.Contains(t, logs, `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+
+	var taskSpan sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "task mailer/welcome" {
+			taskSpan = s
+		}
+	}
+	require.NotNil(t, taskSpan)
+	assert.Equal(t, span.SpanContext().SpanID(), taskSpan.Parent().SpanID())
+}
+
+// TestGoRecoversPanic tests that a panicking task is logged with an ErrPanic error and marks its span as failed.
+// (TestGoRecoversPanic 测试 panic 的任务以 ErrPanic 错误记录日志，并将 span 标记为失败。)
+func TestGoRecoversPanic(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	var out syncBuffer
+	m := tasks.NewManager(tasks.WithLogger(newJSONLogger(&out)), tasks.WithTracerProvider(tp))
+
+	require.NoError(t, m.Go(context.Background(), "explode", func(context.Context) error { panic("kaboom") }))
+	require.NoError(t, m.Go(context.Background(), "fail", func(context.Context) error { return errors.New("boom") }))
+	require.NoError(t, m.Shutdown(context.Background()))
+
+	logs := out.String()
+	assert.Equal(t, 2, strings.Count(logs, "Background task failed"))
+	assert.Contains(t, logs, "panic in task 'explode'")
+	assert.Contains(t, logs, "kaboom")
+	assert.Contains(t, logs, "boom")
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	for _, s := range ended {
+		assert.Equal(t, codes.Error, s.Status().Code, s.Name())
+	}
+}
+
+// TestShutdown tests that shutdown cancels tasks, rejects new ones and reports tasks that ignore cancellation.
+// (TestShutdown 测试关闭会取消任务、拒绝新任务，并报告忽略取消的任务。)
+func TestShutdown(t *testing.T) {
+	var out syncBuffer
+	m := tasks.NewManager(tasks.WithLogger(newJSONLogger(&out)))
+
+	require.NoError(t, m.Go(context.Background(), "poller", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	stuck := make(chan struct{})
+	require.NoError(t, m.Go(context.Background(), "stuck", func(context.Context) error {
+		<-stuck
+		return nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := m.Shutdown(ctx)
+	require.Error(t, err)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrTasksShutdown))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "1 tasks still running: stuck")
+	assert.NotContains(t, out.String(), "Background task failed", "cancellation by shutdown is not a failure")
+
+	err = m.Go(context.Background(), "late", func(context.Context) error { return nil })
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrTasksClosed))
+
+	close(stuck)
+	require.NoError(t, m.Shutdown(context.Background()))
+}
+
+// TestCollectors tests the running gauge and the started/failed counters.
+// (TestCollectors 测试运行中任务数 gauge 以及启动/失败计数器。)
+func TestCollectors(t *testing.T) {
+	var out syncBuffer
+	m := tasks.NewManager(tasks.WithLogger(newJSONLogger(&out)))
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(m.Collectors("app")...)
+
+	release := make(chan struct{})
+	require.NoError(t, m.Go(context.Background(), "wait", func(context.Context) error { <-release; return nil }))
+	require.NoError(t, m.Go(context.Background(), "fail", func(context.Context) error { return errors.New("boom") }))
+	require.Eventually(t, func() bool { return m.Count() == 1 }, time.Second, time.Millisecond)
+
+	expected := `
+# HELP app_tasks_failed_total Number of background tasks that returned an error or panicked.
+# TYPE app_tasks_failed_total counter
+app_tasks_failed_total 1
+# HELP app_tasks_running Number of background tasks currently running.
+# TYPE app_tasks_running gauge
+app_tasks_running 1
+# HELP app_tasks_started_total Number of background tasks started.
+# TYPE app_tasks_started_total counter
+app_tasks_started_total 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+	close(release)
+	require.NoError(t, m.Shutdown(context.Background()))
+}
@@ -0,0 +1,36 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package tenant carries tenant identity through request contexts for multi-tenant services.
+(tenant 包在请求 context 中传递租户身份，用于多租户服务。)
+
+A Resolver extracts the tenant from HTTP requests (the X-Tenant-ID header by default, or a
+subdomain or token claim via WithExtractor) and from gRPC metadata, validates it and stores it
+in the context. Downstream code reads it with FromContext; the request-scoped Logger from
+log.FromContext gains a tenant_id field, errors returned by handlers carry the tenant (see
+ErrorTenant), and gRPC client interceptors forward it to other services.
+(Resolver 从 HTTP 请求（默认读取 X-Tenant-ID 头，也可通过 WithExtractor 使用子域名或令牌声明）和 gRPC 元数据中
+提取租户，校验后写入 context。下游代码通过 FromContext 读取；log.FromContext 返回的请求级 Logger 会带上
+tenant_id 字段，处理器返回的错误会携带租户（见 ErrorTenant），gRPC 客户端拦截器会将其转发给其他服务。)
+
+Usage:
+(用法：)
+
+	resolver, err := tenant.NewResolver(opts, tenant.WithValidator(tenants.Exists))
+	if err != nil {
+		// handle error (处理错误)
+	}
+	http.ListenAndServe(":8080", resolver.Middleware(mux))
+
+	// Per-tenant overrides of the "rate-limit" section (按租户覆盖 "rate-limit" 配置节)
+	limits := tenant.NewOverlay[RateLimitConfig](cfgManager, "rate-limit", "tenants")
+	cfg, err := limits.ForContext(r.Context())
+
+Errors carry the ErrTenantOptionInvalid, ErrTenantMissing, ErrTenantInvalid or ErrTenantConfig codes from pkg/errors.
+(错误携带 pkg/errors 中的 ErrTenantOptionInvalid、ErrTenantMissing、ErrTenantInvalid 或 ErrTenantConfig 错误码。)
+*/
+package tenant
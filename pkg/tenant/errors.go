@@ -0,0 +1,59 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// tenantError 为错误附加租户 ID，错误消息保持不变，%+v 格式会额外输出租户。
+// (tenantError attaches the tenant ID to an error; the message is unchanged and the %+v format also prints the tenant.)
+type tenantError struct {
+	err error
+	id  string
+}
+
+func (e *tenantError) Error() string { return e.err.Error() }
+
+func (e *tenantError) Unwrap() error { return e.err }
+
+// Format 实现 fmt.Formatter。(Format implements fmt.Formatter.)
+func (e *tenantError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprintf(s, "%+v\ntenant: %s", e.err, e.id)
+		return
+	}
+	fmt.Fprintf(s, fmt.FormatString(s, verb), e.err)
+}
+
+// WrapError 为 err 附加 ctx 中的租户 ID；err 为 nil、ctx 中没有租户或 err 已携带租户时原样返回。
+// (WrapError attaches the tenant ID in ctx to err; err is returned unchanged when it is nil, ctx has no tenant or err already carries one.)
+func WrapError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	id, ok := FromContext(ctx)
+	if !ok {
+		return err
+	}
+	if _, has := ErrorTenant(err); has {
+		return err
+	}
+	return &tenantError{err: err, id: id}
+}
+
+// ErrorTenant 返回 WrapError 附加到 err 上的租户 ID。
+// (ErrorTenant returns the tenant ID attached to err by WrapError.)
+func ErrorTenant(err error) (string, bool) {
+	var te *tenantError
+	if errors.As(err, &te) {
+		return te.id, true
+	}
+	return "", false
+}
@@ -0,0 +1,73 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package tenant
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/auth"
+)
+
+// Extractor 从 HTTP 请求中提取租户 ID，无法提取时返回空字符串。
+// (Extractor extracts the tenant ID from an HTTP request, returning an empty string when it cannot.)
+type Extractor func(r *http.Request) string
+
+// FromHeader 返回从请求头 name 读取租户 ID 的提取器。
+// (FromHeader returns an extractor reading the tenant ID from header name.)
+func FromHeader(name string) Extractor {
+	return func(r *http.Request) string {
+		return strings.TrimSpace(r.Header.Get(name))
+	}
+}
+
+// FromSubdomain 返回从 baseDomain 的子域名读取租户 ID 的提取器，例如 "acme.example.com" 得到 "acme"。
+// (FromSubdomain returns an extractor reading the tenant ID from a subdomain of baseDomain, e.g. "acme.example.com" yields "acme".)
+func FromSubdomain(baseDomain string) Extractor {
+	suffix := "." + strings.TrimPrefix(strings.ToLower(baseDomain), ".")
+	return func(r *http.Request) string {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.ToLower(host)
+		if !strings.HasSuffix(host, suffix) {
+			return ""
+		}
+		sub := strings.TrimSuffix(host, suffix)
+		if strings.Contains(sub, ".") {
+			return ""
+		}
+		return sub
+	}
+}
+
+// FromClaim 返回从已认证调用方的令牌声明读取租户 ID 的提取器，必须放在 auth 中间件之后。
+// (FromClaim returns an extractor reading the tenant ID from a claim of the authenticated principal's token; it must run after the auth middleware.)
+func FromClaim(claim string) Extractor {
+	return func(r *http.Request) string {
+		p, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			return ""
+		}
+		return p.Claim(claim)
+	}
+}
+
+// FirstOf 返回依次尝试 extractors 并使用第一个非空结果的提取器。
+// (FirstOf returns an extractor trying extractors in order and using the first non-empty result.)
+func FirstOf(extractors ...Extractor) Extractor {
+	return func(r *http.Request) string {
+		for _, extract := range extractors {
+			if id := extract(r); id != "" {
+				return id
+			}
+		}
+		return ""
+	}
+}
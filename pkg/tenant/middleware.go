@@ -0,0 +1,159 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server/middleware"
+)
+
+// ResolverOption 是配置 Resolver 的函数类型。
+// (ResolverOption is a function type for configuring a Resolver.)
+type ResolverOption func(*Resolver)
+
+// WithExtractor 设置从 HTTP 请求提取租户的方式，默认读取 Options.Header。
+// (WithExtractor sets how the tenant is extracted from HTTP requests; Options.Header is read by default.)
+func WithExtractor(extract Extractor) ResolverOption {
+	return func(r *Resolver) {
+		r.extract = extract
+	}
+}
+
+// WithValidator 设置租户校验函数，例如检查租户是否存在或调用方是否属于该租户。
+// 返回的错误没有 Coder 时按 ErrTenantInvalid 处理。
+// (WithValidator sets a tenant validation function, e.g. checking that the tenant exists or that the caller belongs to it.)
+// (Returned errors without a Coder are treated as ErrTenantInvalid.)
+func WithValidator(validate func(ctx context.Context, id string) error) ResolverOption {
+	return func(r *Resolver) {
+		r.validate = validate
+	}
+}
+
+// Resolver 从 HTTP 请求和 gRPC 元数据中解析租户，写入 context，并为请求级 Logger 添加租户字段。
+// (Resolver resolves the tenant from HTTP requests and gRPC metadata, stores it in the context and adds the tenant field to the request-scoped Logger.)
+type Resolver struct {
+	opts     *Options
+	extract  Extractor
+	validate func(ctx context.Context, id string) error
+}
+
+// NewResolver 创建租户解析器。opts 为 nil 时使用默认选项。
+// (NewResolver creates a tenant resolver. Default options are used when opts is nil.)
+func NewResolver(opts *Options, options ...ResolverOption) (*Resolver, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid tenant options"),
+			lmccerrors.ErrTenantOptionInvalid,
+		)
+	}
+	r := &Resolver{opts: opts, extract: FromHeader(opts.Header)}
+	for _, opt := range options {
+		opt(r)
+	}
+	return r, nil
+}
+
+// Resolve 校验租户 ID。id 为空且 Options.Required 为 false 时返回空字符串和 nil。
+// (Resolve validates a tenant ID. An empty id yields an empty string and nil when Options.Required is false.)
+func (r *Resolver) Resolve(ctx context.Context, id string) (string, error) {
+	if id == "" {
+		if r.opts.Required {
+			return "", lmccerrors.NewWithCode(lmccerrors.ErrTenantMissing, "tenant is required")
+		}
+		return "", nil
+	}
+	if !ValidID(id) {
+		return "", lmccerrors.ErrorfWithCode(lmccerrors.ErrTenantInvalid, "invalid tenant id '%s'", id)
+	}
+	if r.validate != nil {
+		if err := r.validate(ctx, id); err != nil {
+			if lmccerrors.GetCoder(err) == nil {
+				err = lmccerrors.WithCode(err, lmccerrors.ErrTenantInvalid)
+			}
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+// Middleware 返回 net/http 租户中间件，解析失败时以标准错误信封返回 400 或 403。
+// (Middleware returns net/http tenant middleware, responding 400 or 403 with the standard error envelope when resolution fails.)
+func (r *Resolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if slices.Contains(r.opts.SkipPaths, req.URL.Path) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		id, err := r.Resolve(req.Context(), r.extract(req))
+		if err != nil {
+			log.FromContext(req.Context()).Debugw("Tenant resolution failed", "path", req.URL.Path, "error", err)
+			writeError(w, req, err)
+			return
+		}
+		if id != "" {
+			req = req.WithContext(withTenant(req.Context(), id))
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// Process 实现 server.Middleware，租户 ID 保存在 server.Context 的 ContextKey 下（用 FromServerContext 读取），后续处理返回的错误会附加租户。
+// (Process implements server.Middleware; the tenant ID is stored under ContextKey of the server.Context (read it with FromServerContext) and errors returned downstream carry the tenant.)
+func (r *Resolver) Process(ctx server.Context, next func() error) error {
+	req := ctx.Request()
+	if slices.Contains(r.opts.SkipPaths, req.URL.Path) {
+		return next()
+	}
+	id, err := r.Resolve(req.Context(), r.extract(req))
+	if err != nil {
+		log.FromContext(req.Context()).Debugw("Tenant resolution failed", "path", req.URL.Path, "error", err)
+		writeError(ctx.Response(), req, err)
+		return nil
+	}
+	if id == "" {
+		return next()
+	}
+	ctx.Set(ContextKey, id)
+	return WrapError(IntoContext(req.Context(), id), next())
+}
+
+// writeError 以标准错误信封写入错误响应。(writeError writes an error response using the standard error envelope.)
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	coder := lmccerrors.GetCoder(err)
+	if coder == nil {
+		coder = lmccerrors.ErrInternalServer
+	}
+	status := coder.HTTPStatus()
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		// 不向客户端暴露内部错误细节 (Do not expose internal error details to clients)
+		message = coder.String()
+	}
+
+	resp := middleware.ErrorResponse{
+		Error:     http.StatusText(status),
+		Message:   message,
+		Code:      coder.Code(),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		RequestID: r.Header.Get("X-Request-ID"),
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
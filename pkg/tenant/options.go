@@ -0,0 +1,69 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// idPattern 是合法的租户 ID：字母、数字、"-" 和 "_"，可安全用作日志字段、指标标签和配置键。
+// (idPattern matches valid tenant IDs: letters, digits, "-" and "_", safe as log fields, metric labels and config keys.)
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Options 定义了租户解析的配置选项，通常作为应用配置中的 "tenant" 节加载。
+// (Options defines tenant resolution configuration, typically loaded as the "tenant" section of the application configuration.)
+type Options struct {
+	// Header 是携带租户 ID 的 HTTP 请求头。
+	// (Header is the HTTP header carrying the tenant ID.)
+	Header string `json:"header" mapstructure:"header"`
+
+	// MetadataKey 是携带租户 ID 的 gRPC 元数据键，必须为小写。
+	// (MetadataKey is the gRPC metadata key carrying the tenant ID; it must be lowercase.)
+	MetadataKey string `json:"metadata-key" mapstructure:"metadata-key"`
+
+	// Required 为 true 时拒绝没有租户的请求。
+	// (Required rejects requests without a tenant when true.)
+	Required bool `json:"required" mapstructure:"required"`
+
+	// SkipPaths 是不解析租户的 HTTP 路径，例如健康检查。
+	// (SkipPaths are HTTP paths that skip tenant resolution, e.g. health checks.)
+	SkipPaths []string `json:"skip-paths" mapstructure:"skip-paths"`
+}
+
+// NewOptions 创建具有默认值的租户选项 (creates tenant options with default values)
+func NewOptions() *Options {
+	return &Options{
+		Header:      "X-Tenant-ID", // 默认请求头 (Default header)
+		MetadataKey: "x-tenant-id", // 默认元数据键 (Default metadata key)
+		Required:    true,          // 默认必须提供租户 (Tenant required by default)
+		SkipPaths:   []string{},    // 默认不跳过 (No skipped paths by default)
+	}
+}
+
+// Validate 验证租户选项是否有效。
+// (Validate validates if the tenant options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if o.Header == "" {
+		errs = append(errs, fmt.Errorf("tenant header must not be empty"))
+	}
+
+	if o.MetadataKey == "" || o.MetadataKey != strings.ToLower(o.MetadataKey) {
+		errs = append(errs, fmt.Errorf("invalid metadata key '%s', must be non-empty and lowercase", o.MetadataKey))
+	}
+
+	return errs
+}
+
+// ValidID 判断 id 是否是合法的租户 ID。
+// (ValidID reports whether id is a valid tenant ID.)
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
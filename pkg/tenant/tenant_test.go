@@ -0,0 +1,212 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for tenant resolution, propagation and config overlays.
+ */
+
+package tenant_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/auth"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/config"
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/server/middleware"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/tenant"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TestResolver_Middleware tests HTTP tenant extraction, validation and error responses.
+// (TestResolver_Middleware 测试 HTTP 租户提取、校验和错误响应。)
+func TestResolver_Middleware(t *testing.T) {
+	opts := tenant.NewOptions()
+	opts.SkipPaths = []string{"/healthz"}
+	r, err := tenant.NewResolver(opts, tenant.WithValidator(func(_ context.Context, id string) error {
+		if id != "acme" {
+			return errors.New("unknown tenant")
+		}
+		return nil
+	}))
+	require.NoError(t, err)
+
+	var got string
+	handler := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got, _ = tenant.FromContext(req.Context())
+	}))
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		status int
+		code   int
+	}{
+		{"valid tenant", "/orders", "acme", http.StatusOK, 0},
+		{"missing tenant", "/orders", "", http.StatusBadRequest, lmccerrors.ErrTenantMissing.Code()},
+		{"unknown tenant", "/orders", "globex", http.StatusForbidden, lmccerrors.ErrTenantInvalid.Code()},
+		{"malformed tenant", "/orders", "../etc", http.StatusForbidden, lmccerrors.ErrTenantInvalid.Code()},
+		{"skipped path", "/healthz", "", http.StatusOK, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.code != 0 {
+				var resp middleware.ErrorResponse
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, tt.code, resp.Code)
+				return
+			}
+			assert.Equal(t, tt.header, got)
+		})
+	}
+}
+
+// TestExtractors tests the subdomain, claim and fallback extractors.
+// (TestExtractors 测试子域名、令牌声明和回退提取器。)
+func TestExtractors(t *testing.T) {
+	sub := tenant.FromSubdomain("example.com")
+	req := httptest.NewRequest(http.MethodGet, "http://acme.example.com:8080/", nil)
+	assert.Equal(t, "acme", sub(req))
+	req.Host = "a.b.example.com"
+	assert.Equal(t, "", sub(req))
+
+	extract := tenant.FirstOf(tenant.FromHeader("X-Tenant-ID"), tenant.FromClaim("tenant"))
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(auth.ContextWithPrincipal(req.Context(), &auth.Principal{Claims: map[string]any{"tenant": "globex"}}))
+	assert.Equal(t, "globex", extract(req))
+	req.Header.Set("X-Tenant-ID", "acme")
+	assert.Equal(t, "acme", extract(req))
+}
+
+// TestWrapError tests attaching the tenant to errors without changing their message or code.
+// (TestWrapError 测试为错误附加租户且不改变其消息和错误码。)
+func TestWrapError(t *testing.T) {
+	ctx := tenant.IntoContext(context.Background(), "acme")
+	base := lmccerrors.NewWithCode(lmccerrors.ErrNotFound, "order not found")
+	err := tenant.WrapError(ctx, base)
+
+	assert.Equal(t, base.Error(), err.Error())
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrNotFound))
+	id, ok := tenant.ErrorTenant(err)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", id)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "tenant: acme")
+	assert.Same(t, err, tenant.WrapError(ctx, err), "errors are wrapped once")
+	assert.NoError(t, tenant.WrapError(ctx, nil))
+	assert.Same(t, base, tenant.WrapError(context.Background(), base))
+}
+
+// TestResolver_GRPC tests tenant resolution from metadata and propagation to outgoing calls.
+// (TestResolver_GRPC 测试从元数据解析租户并传播到出站调用。)
+func TestResolver_GRPC(t *testing.T) {
+	r, err := tenant.NewResolver(nil)
+	require.NoError(t, err)
+	info := &grpc.UnaryServerInfo{FullMethod: "/orders.v1.Orders/Get"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant-id", "acme"))
+	_, err = r.UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		id, _ := tenant.FromContext(ctx)
+		assert.Equal(t, "acme", id)
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err), "handler status codes are preserved")
+	id, _ := tenant.ErrorTenant(err)
+	assert.Equal(t, "acme", id)
+
+	_, err = r.UnaryServerInterceptor()(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		t.Fatal("handler must not run without a tenant")
+		return nil, nil
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = r.UnaryClientInterceptor()(tenant.IntoContext(context.Background(), "acme"), "/orders.v1.Orders/Get", nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Equal(t, []string{"acme"}, md.Get("x-tenant-id"))
+			return nil
+		})
+	assert.NoError(t, err)
+}
+
+// fakeManager is a config.Manager over a plain viper instance.
+// (fakeManager 是基于普通 viper 实例的 config.Manager。)
+type fakeManager struct {
+	config.Values
+	v         *viper.Viper
+	callbacks []func(*viper.Viper, any) error
+}
+
+func (m *fakeManager) GetViperInstance() *viper.Viper { return m.v }
+
+func (m *fakeManager) RegisterCallback(cb func(*viper.Viper, any) error) {
+	m.callbacks = append(m.callbacks, cb)
+}
+
+func (m *fakeManager) RegisterSectionChangeCallback(string, config.SectionChangeCallback) {}
+
+func (m *fakeManager) LastChangeSet() config.ChangeSet { return nil }
+
+func (m *fakeManager) Set(string, any) error { return nil }
+
+func (m *fakeManager) Save() error { return nil }
+
+func (m *fakeManager) Snapshot() (any, uint64) { return nil, 0 }
+
+func (m *fakeManager) Version() uint64 { return 0 }
+
+// TestOverlay tests per-tenant config overlays and cache invalidation.
+// (TestOverlay 测试租户级配置覆盖和缓存失效。)
+func TestOverlay(t *testing.T) {
+	type limits struct {
+		RPS     int           `mapstructure:"rps"`
+		Burst   int           `mapstructure:"burst"`
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+	v := viper.New()
+	v.Set("rate-limit", map[string]any{"rps": 10, "burst": 20, "timeout": "1s"})
+	v.Set("tenants", map[string]any{"acme": map[string]any{"rate-limit": map[string]any{"rps": 100}}})
+	m := &fakeManager{v: v}
+	overlay := tenant.NewOverlay[limits](m, "rate-limit", "")
+
+	base, err := overlay.Lookup("")
+	require.NoError(t, err)
+	assert.Equal(t, limits{RPS: 10, Burst: 20, Timeout: time.Second}, *base)
+
+	acme, err := overlay.ForContext(tenant.IntoContext(context.Background(), "ACME"))
+	require.NoError(t, err)
+	assert.Equal(t, limits{RPS: 100, Burst: 20, Timeout: time.Second}, *acme)
+
+	other, err := overlay.Lookup("globex")
+	require.NoError(t, err)
+	assert.Equal(t, *base, *other, "tenants without overrides get the base section")
+
+	v.Set("rate-limit", map[string]any{"rps": 5, "burst": 20, "timeout": "1s"})
+	for _, cb := range m.callbacks {
+		require.NoError(t, cb(v, nil))
+	}
+	other, err = overlay.Lookup("globex")
+	require.NoError(t, err)
+	assert.Equal(t, 5, other.RPS, "changes invalidate the cache")
+}
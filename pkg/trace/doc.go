@@ -0,0 +1,97 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+/*
+Package trace integrates OpenTelemetry tracing with the SDK's logging.
+(trace 包将 OpenTelemetry 追踪与 SDK 的日志集成。)
+
+When tracing and log correlation are enabled, every log written through the log.Ctx*
+methods at or above LogEventLevel (error by default) whose context holds a recording span
+is added to that span as a "log" event with the log's timestamp, severity and message.
+The log entry itself receives trace_id and span_id, so a trace shows its related error
+logs and each error log links back to its trace.
+(启用追踪和日志关联后，经由 log.Ctx* 方法写出、级别不低于 LogEventLevel（默认 error）且 context 中
+有正在记录的 span 的日志，会以日志的时间戳、级别和消息作为 "log" 事件添加到该 span；日志本身会写入
+trace_id 和 span_id，从而可以在追踪中看到相关的错误日志，也能从错误日志跳转到追踪。)
+
+Usage:
+(用法：)
+
+	shutdown, err := trace.Setup(&trace.Options{
+		Enabled:        true,
+		LogCorrelation: true,
+		LogEventLevel:  "error",
+	})
+	if err != nil {
+		// handle error (处理错误)
+	}
+	defer shutdown(context.Background())
+
+	ctx, span := tracer.Start(ctx, "charge")
+	defer span.End()
+	log.Std().CtxErrorf(ctx, "payment failed: %v", err) // also recorded on the span (同时记录到 span)
+
+NewResource builds an OpenTelemetry resource from log.Options.Service, so exporters for traces
+and metrics report the same service.name, service.version, deployment.environment.name and
+host.name that appear on every log entry.
+(NewResource 基于 log.Options.Service 构建 OpenTelemetry 资源，使追踪和指标导出器上报的 service.name、
+service.version、deployment.environment.name 和 host.name 与每条日志中的字段一致。)
+
+	res, err := trace.NewResource(ctx, logOpts.Service)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithResource(res))
+
+Spans and Exporters:
+(Span 与导出器：)
+
+NewTracerProvider builds a TracerProvider with that resource, sampling SampleRatio of the root
+spans (child spans follow their parent) and exporting to the Exporter named in the options:
+"stdout" writes spans as JSON for local debugging, "otlp" sends them over OTLP/gRPC to Endpoint.
+Any sdktrace.SpanExporter can be added with sdktrace.WithBatcher. pkg/sdk's Bootstrap does this
+for you when Trace.Enabled is set.
+(NewTracerProvider 使用该资源构建 TracerProvider，按 SampleRatio 采样根 span（子 span 遵循父 span 的决定），
+并导出到选项中 Exporter 指定的导出器："stdout" 以 JSON 输出 span 便于本地调试，"otlp" 通过 OTLP/gRPC 发送到 Endpoint。
+任意 sdktrace.SpanExporter 都可以通过 sdktrace.WithBatcher 添加。设置 Trace.Enabled 时 pkg/sdk 的 Bootstrap 会自动完成这些步骤。)
+
+	opts := trace.NewOptions()
+	opts.Exporter = trace.ExporterOTLP
+	opts.Endpoint = "otel-collector:4317"
+	tp, err := trace.NewTracerProvider(ctx, opts, logOpts.Service)
+	if err != nil {
+		// handle error (处理错误)
+	}
+	otel.SetTracerProvider(tp)
+	defer tp.Shutdown(context.Background())
+
+Start creates a span as a child of the span in the context and End finishes it, recording a
+non-nil error on it. With log.Options.EnableOTelTraceContext, logs written through log.Ctx* with
+the returned context carry its trace_id and span_id.
+(Start 以 context 中的 span 为父创建 span，End 结束 span 并记录非 nil 的错误。启用 log.Options.EnableOTelTraceContext 后，
+使用返回的 context 经 log.Ctx* 写出的日志会带有其 trace_id 和 span_id。)
+
+	func (s *Service) GetUser(ctx context.Context, id string) (user *User, err error) {
+		ctx, span := trace.Start(ctx, "GetUser")
+		defer func() { trace.End(span, err) }()
+		...
+	}
+
+Propagation:
+(传播：)
+
+Inject writes the W3C traceparent, tracestate and baggage headers of the current span to an
+outgoing request and Extract reads them from an incoming one, so spans of all services join the
+same trace.
+(Inject 将当前 span 的 W3C traceparent、tracestate 和 baggage 请求头写入出站请求，Extract 从入站请求中读取它们，
+使各个服务的 span 加入同一条追踪。)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	trace.Inject(ctx, req.Header)
+
+	func handler(w http.ResponseWriter, r *http.Request) {
+		ctx, span := trace.Start(trace.Extract(r.Context(), r.Header), "handle")
+		defer span.End()
+	}
+*/
+package trace
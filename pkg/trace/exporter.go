@@ -0,0 +1,94 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Exporter 是 span 导出器接口，OTLP、标准输出和测试用的内存导出器均实现了它。
+// (Exporter is the span exporter interface, implemented by the OTLP, stdout and in-memory test exporters.)
+type Exporter = sdktrace.SpanExporter
+
+// NewExporter 根据 opts.Exporter 创建 span 导出器，未配置导出器时返回 nil。
+// (NewExporter creates the span exporter selected by opts.Exporter, returning nil when none is configured.)
+func NewExporter(ctx context.Context, opts *Options) (Exporter, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid trace options"),
+			lmccerrors.ErrTraceOptionInvalid,
+		)
+	}
+
+	var (
+		exporter Exporter
+		err      error
+	)
+	switch opts.Exporter {
+	case ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		var grpcOpts []otlptracegrpc.Option
+		if opts.Endpoint != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(opts.Endpoint))
+		}
+		if opts.Insecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		}
+		if len(opts.Headers) > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithHeaders(opts.Headers))
+		}
+		exporter, err = otlptracegrpc.New(ctx, grpcOpts...)
+	}
+	if err != nil {
+		return nil, lmccerrors.WithCode(lmccerrors.Wrapf(err, "failed to create %s span exporter", opts.Exporter), lmccerrors.ErrTraceExporter)
+	}
+	return exporter, nil
+}
+
+// NewTracerProvider 创建以服务标识为资源的 TracerProvider：按 opts.SampleRatio 采样根 span、子 span 遵循父 span 的决定，
+// 并批量导出到 opts.Exporter 选择的导出器。extra 在最后应用，可以添加导出器（sdktrace.WithBatcher）或替换采样器。
+// 调用方负责通过 otel.SetTracerProvider 安装它，并在退出时调用 Shutdown 以导出剩余的 span。
+// (NewTracerProvider creates a TracerProvider whose resource is the service identity: root spans are sampled by
+// opts.SampleRatio, child spans follow their parent's decision, and spans are exported in batches to the exporter selected by
+// opts.Exporter. extra is applied last and may add exporters (sdktrace.WithBatcher) or replace the sampler. The caller
+// installs it with otel.SetTracerProvider and calls Shutdown on exit to flush the remaining spans.)
+func NewTracerProvider(ctx context.Context, opts *Options, svc log.ServiceOptions, extra ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	exporter, err := NewExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	res, err := NewResource(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	}
+	if exporter != nil {
+		tpOpts = append(tpOpts, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(append(tpOpts, extra...)...), nil
+}
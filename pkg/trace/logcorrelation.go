@@ -0,0 +1,66 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package trace
+
+import (
+	"context"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogEventName 是由日志生成的 span 事件名称。
+// (LogEventName is the name of span events generated from logs.)
+const LogEventName = "log"
+
+// 日志事件属性名，遵循 OpenTelemetry 日志语义约定。
+// (Log event attribute keys, following the OpenTelemetry log semantic conventions.)
+const (
+	// AttrLogSeverity 是日志级别属性。(AttrLogSeverity is the log severity attribute.)
+	AttrLogSeverity = attribute.Key("log.severity")
+	// AttrLogMessage 是日志消息属性。(AttrLogMessage is the log message attribute.)
+	AttrLogMessage = attribute.Key("log.message")
+)
+
+// CorrelateLogs 注册日志钩子：通过 log.Ctx* 方法写出且级别不低于 minLevel 的日志，
+// 若 context 中有正在记录的 span，会以日志时间戳添加一个 span 事件，同时在日志中写入 trace_id 和 span_id，
+// 从而可以在追踪中看到相关的错误日志，也可以从日志跳转到追踪。返回的函数用于取消关联。
+// (CorrelateLogs registers a log hook: for logs written through the log.Ctx* methods at or above minLevel whose
+// context holds a recording span, a span event is added with the log timestamp and trace_id and span_id are written
+// to the log, so a trace shows its related error logs and a log links back to its trace. The returned function
+// removes the correlation.)
+func CorrelateLogs(minLevel zapcore.Level) (remove func()) {
+	return log.AddContextHook(func(ctx context.Context, entry log.Entry) []any {
+		if entry.Level < minLevel {
+			return nil
+		}
+		span := oteltrace.SpanFromContext(ctx)
+		sc := span.SpanContext()
+		if !sc.IsValid() {
+			return nil
+		}
+
+		if span.IsRecording() {
+			span.AddEvent(LogEventName,
+				oteltrace.WithTimestamp(entry.Time),
+				oteltrace.WithAttributes(
+					AttrLogSeverity.String(entry.Level.CapitalString()),
+					AttrLogMessage.String(entry.Message),
+				),
+			)
+		}
+
+		fields := []any{"span_id", sc.SpanID().String()}
+		// 已通过 log.ContextWithTraceID 设置时不重复写入 (Not repeated when already set via log.ContextWithTraceID)
+		if _, ok := log.TraceIDFromContext(ctx); !ok {
+			fields = append(fields, "trace_id", sc.TraceID().String())
+		}
+		return fields
+	})
+}
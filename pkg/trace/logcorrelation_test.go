@@ -0,0 +1,82 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for correlating logs with spans.
+ */
+
+package trace_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestLogCorrelation tests that error logs become span events and carry the trace linkage.
+// (TestLogCorrelation 测试错误日志被记录为 span 事件并带有追踪关联信息。)
+func TestLogCorrelation(t *testing.T) {
+	logFilePath := filepath.Join(t.TempDir(), "trace.log")
+	logOpts := log.NewOptions()
+	logOpts.Format = "json"
+	logOpts.OutputPaths = []string{logFilePath}
+	log.Init(logOpts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	opts := trace.NewOptions()
+	opts.Enabled = true
+	shutdown, err := trace.Setup(opts)
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+
+	log.Std().CtxInfof(ctx, "request accepted")
+	log.Std().CtxErrorf(ctx, "payment failed: %s", "card declined")
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	log.Std().CtxErrorf(ctx, "after shutdown")
+	require.NoError(t, log.Sync())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	events := spans[0].Events()
+	require.Len(t, events, 1, "only error logs become span events")
+	assert.Equal(t, trace.LogEventName, events[0].Name)
+	attrs := map[string]string{}
+	for _, kv := range events[0].Attributes {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+	assert.Equal(t, "ERROR", attrs[string(trace.AttrLogSeverity)])
+	assert.Equal(t, "payment failed: card declined", attrs[string(trace.AttrLogMessage)])
+
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	lines := string(content)
+	assert.Contains(t, lines, `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+	assert.Contains(t, lines, `"span_id":"`+span.SpanContext().SpanID().String()+`"`)
+}
+
+// TestSetupValidation tests option validation in Setup.
+// (TestSetupValidation 测试 Setup 中的选项校验。)
+func TestSetupValidation(t *testing.T) {
+	opts := trace.NewOptions()
+	opts.LogEventLevel = "loud"
+	_, err := trace.Setup(opts)
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrTraceOptionInvalid))
+
+	shutdown, err := trace.Setup(nil)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
@@ -0,0 +1,95 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package trace
+
+import (
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Options 定义了追踪的配置选项。
+// (Options defines configuration options for tracing.)
+// 通常作为应用配置中的 "trace" 节加载。
+// (It is typically loaded as the "trace" section of the application configuration.)
+type Options struct {
+	// Enabled 控制是否启用追踪集成。
+	// (Enabled controls whether the tracing integration is enabled.)
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// LogCorrelation 控制是否将日志与当前 span 关联：达到 LogEventLevel 的日志会作为 span 事件记录，
+	// 并在日志中写入 trace_id 和 span_id。
+	// (LogCorrelation controls whether logs are correlated with the current span: logs at or above LogEventLevel
+	// are recorded as span events and carry trace_id and span_id.)
+	LogCorrelation bool `json:"log-correlation" mapstructure:"log-correlation"`
+
+	// LogEventLevel 是记录为 span 事件的最低日志级别。
+	// (LogEventLevel is the minimum log level recorded as a span event.)
+	LogEventLevel string `json:"log-event-level" mapstructure:"log-event-level"`
+
+	// Exporter 选择 span 导出器：""（不导出）、"stdout" 或 "otlp"。
+	// (Exporter selects the span exporter: "" (none), "stdout" or "otlp".)
+	Exporter string `json:"exporter" mapstructure:"exporter"`
+
+	// Endpoint 是 OTLP 收集器的 host:port，例如 "otel-collector:4317"；为空时使用 OTEL_EXPORTER_OTLP_ENDPOINT 或 "localhost:4317"。
+	// (Endpoint is the OTLP collector host:port, e.g. "otel-collector:4317"; when empty OTEL_EXPORTER_OTLP_ENDPOINT or "localhost:4317" is used.)
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+
+	// Headers 是随每次 OTLP 导出发送的请求头，例如认证信息。
+	// (Headers are sent with every OTLP export, e.g. for authentication.)
+	Headers map[string]string `json:"headers" mapstructure:"headers"`
+
+	// Insecure 让 OTLP 使用明文连接。(Insecure makes OTLP use a plaintext connection.)
+	Insecure bool `json:"insecure" mapstructure:"insecure"`
+
+	// SampleRatio 是被采样的根 span 比例，取值 0 到 1；子 span 遵循父 span 的采样决定。
+	// (SampleRatio is the fraction of root spans sampled, from 0 to 1; child spans follow their parent's decision.)
+	SampleRatio float64 `json:"sample-ratio" mapstructure:"sample-ratio"`
+}
+
+// 导出器名称。(Exporter names.)
+const (
+	// ExporterNone 不导出 span。(ExporterNone exports no spans.)
+	ExporterNone = ""
+	// ExporterStdout 将 span 以 JSON 写入标准输出，用于本地调试。(ExporterStdout writes spans as JSON to stdout, for local debugging.)
+	ExporterStdout = "stdout"
+	// ExporterOTLP 通过 OTLP/gRPC 导出 span。(ExporterOTLP exports spans over OTLP/gRPC.)
+	ExporterOTLP = "otlp"
+)
+
+// NewOptions 创建具有默认值的追踪选项 (creates tracing options with default values)
+func NewOptions() *Options {
+	return &Options{
+		Enabled:        false,
+		LogCorrelation: true,
+		LogEventLevel:  "error",      // 仅错误日志，避免 span 事件过多 (Errors only, to keep span events small)
+		Exporter:       ExporterNone, // 默认不导出 (No exporting by default)
+		SampleRatio:    1,            // 采样所有根 span (Every root span is sampled)
+	}
+}
+
+// Validate 验证追踪选项是否有效。
+// (Validate validates if the tracing options are valid.)
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if _, err := zapcore.ParseLevel(o.LogEventLevel); err != nil {
+		errs = append(errs, fmt.Errorf("invalid trace log event level '%s': %w", o.LogEventLevel, err))
+	}
+
+	switch o.Exporter {
+	case ExporterNone, ExporterStdout, ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("invalid trace exporter '%s', must be '%s' or '%s'", o.Exporter, ExporterStdout, ExporterOTLP))
+	}
+
+	if o.SampleRatio < 0 || o.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("invalid trace sample ratio %v, must be between 0 and 1", o.SampleRatio))
+	}
+
+	return errs
+}
@@ -0,0 +1,35 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package trace
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator 传播 W3C Trace Context（traceparent、tracestate）和 W3C Baggage 请求头。
+// (Propagator propagates the W3C Trace Context (traceparent, tracestate) and W3C Baggage headers.)
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// Inject 将 ctx 中的 span 上下文和 baggage 写入出站请求头，使下游服务的 span 成为当前 span 的子 span。
+// (Inject writes the span context and baggage in ctx to outgoing request headers, so spans of the downstream service
+// become children of the current span.)
+func Inject(ctx context.Context, header http.Header) {
+	Propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract 从入站请求头读取远程 span 上下文和 baggage，返回携带它们的 context；请求头缺失或无效时返回原 ctx。
+// (Extract reads the remote span context and baggage from incoming request headers and returns a context carrying them;
+// ctx is returned unchanged when the headers are missing or invalid.)
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return Propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,55 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package trace
+
+import (
+	"context"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// NewResource 根据日志配置中的服务标识（log.Options.Service）构建 OpenTelemetry 资源，
+// 使追踪和指标与日志的 service.name、service.version、deployment.environment.name、host.name 完全一致。
+// OTEL_RESOURCE_ATTRIBUTES 等环境变量中的属性会被保留，但服务标识以配置为准；extra 中的属性最后应用。
+// (NewResource builds an OpenTelemetry resource from the service identity in the logging configuration
+// (log.Options.Service), so traces and metrics carry exactly the same service.name, service.version,
+// deployment.environment.name and host.name as logs. Attributes from environment variables such as
+// OTEL_RESOURCE_ATTRIBUTES are kept, but the configured identity wins; extra attributes are applied last.)
+func NewResource(ctx context.Context, svc log.ServiceOptions, extra ...attribute.KeyValue) (*resource.Resource, error) {
+	svc = svc.Resolved()
+
+	var attrs []attribute.KeyValue
+	for _, kv := range []struct {
+		attr  func(string) attribute.KeyValue
+		value string
+	}{
+		{semconv.ServiceName, svc.Name},
+		{semconv.ServiceVersion, svc.Version},
+		{semconv.DeploymentEnvironmentName, svc.Environment},
+		{semconv.HostName, svc.Host},
+	} {
+		if kv.value != "" {
+			attrs = append(attrs, kv.attr(kv.value))
+		}
+	}
+	attrs = append(attrs, extra...)
+
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+		resource.WithAttributes(attrs...),
+	)
+	if err != nil {
+		return nil, lmccerrors.WithCode(lmccerrors.Wrap(err, "failed to build trace resource"), lmccerrors.ErrTraceOptionInvalid)
+	}
+	return res, nil
+}
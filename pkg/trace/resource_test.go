@@ -0,0 +1,59 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for building OpenTelemetry resources.
+ */
+
+package trace_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TestNewResource tests that the resource and the log entries share identical identity attributes.
+// (TestNewResource 测试资源与日志条目具有完全相同的标识属性。)
+func TestNewResource(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.name=from-env,team=payments")
+
+	svc := log.ServiceOptions{Name: "checkout", Version: "1.2.3", Environment: "staging"}
+	res, err := trace.NewResource(context.Background(), svc, attribute.String("region", "eu"))
+	require.NoError(t, err)
+
+	attrs := map[string]string{}
+	for _, kv := range res.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "checkout", attrs[log.ServiceNameKey], "configured identity wins over env")
+	assert.Equal(t, "payments", attrs["team"])
+	assert.Equal(t, "eu", attrs["region"])
+
+	logFilePath := filepath.Join(t.TempDir(), "service.log")
+	logOpts := log.NewOptions()
+	logOpts.OutputPaths = []string{logFilePath}
+	logOpts.Service = svc
+	log.Init(logOpts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+	log.Info("started")
+	require.NoError(t, log.Sync())
+
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(string(content))), &entry))
+	for _, key := range []string{log.ServiceNameKey, log.ServiceVersionKey, log.DeploymentEnvironmentKey, log.HostNameKey} {
+		assert.NotEmpty(t, attrs[key], key)
+		assert.Equal(t, attrs[key], entry[key], key)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ * Contains tests for starting spans, propagating them and creating exporters.
+ */
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/log"
+	"github.com/lmcc-dev/lmcc-go-sdk/pkg/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// useTracerProvider 安装一个记录 span 的全局 TracerProvider，并在测试结束时恢复。
+// (useTracerProvider installs a global TracerProvider recording spans and restores the previous one after the test.)
+func useTracerProvider(t *testing.T, opts ...sdktrace.TracerProviderOption) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(append([]sdktrace.TracerProviderOption{sdktrace.WithSpanProcessor(recorder)}, opts...)...)
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+// TestStartEnd tests that Start creates child spans and End records errors.
+// (TestStartEnd 测试 Start 创建子 span，End 记录错误。)
+func TestStartEnd(t *testing.T) {
+	recorder := useTracerProvider(t)
+
+	assert.Empty(t, trace.TraceID(context.Background()))
+	ctx, parent := trace.Start(context.Background(), "handle")
+	childCtx, child := trace.Start(ctx, "query")
+	assert.Equal(t, parent.SpanContext().TraceID().String(), trace.TraceID(childCtx))
+	trace.End(child, errors.New("connection reset"))
+	trace.End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "query", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection reset", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, trace.ScopeName, spans[1].InstrumentationScope().Name)
+}
+
+// TestInjectExtract tests round-tripping the traceparent and baggage headers between services.
+// (TestInjectExtract 测试 traceparent 和 baggage 请求头在服务之间往返传递。)
+func TestInjectExtract(t *testing.T) {
+	useTracerProvider(t)
+
+	tests := []struct {
+		name        string
+		header      http.Header
+		wantTraceID string
+		wantSampled bool
+	}{
+		{
+			name:        "sampled traceparent",
+			header:      http.Header{"Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}},
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSampled: true,
+		},
+		{
+			name:        "unsampled traceparent",
+			header:      http.Header{"Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"}},
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{name: "malformed traceparent", header: http.Header{"Traceparent": {"00-zz-00f067aa0ba902b7-01"}}},
+		{name: "missing traceparent", header: http.Header{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := trace.Extract(context.Background(), tt.header)
+			assert.Equal(t, tt.wantTraceID, trace.TraceID(ctx))
+			if tt.wantTraceID == "" {
+				return
+			}
+
+			sc := oteltrace.SpanContextFromContext(ctx)
+			assert.True(t, sc.IsRemote())
+			assert.Equal(t, tt.wantSampled, sc.IsSampled())
+
+			// 子 span 继承 trace ID 并以自身为下游的父 span (The child span keeps the trace ID and becomes the downstream parent)
+			ctx, span := trace.Start(ctx, "forward")
+			defer span.End()
+			out := http.Header{}
+			trace.Inject(ctx, out)
+			flags := "00"
+			if tt.wantSampled {
+				flags = "01"
+			}
+			assert.Equal(t, "00-"+tt.wantTraceID+"-"+span.SpanContext().SpanID().String()+"-"+flags, out.Get("traceparent"))
+		})
+	}
+
+	member, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+	out := http.Header{}
+	trace.Inject(baggage.ContextWithBaggage(context.Background(), bag), out)
+	assert.Equal(t, "tenant=acme", out.Get("baggage"))
+	assert.Equal(t, "acme", baggage.FromContext(trace.Extract(context.Background(), out)).Member("tenant").Value())
+}
+
+// TestNewTracerProvider tests exporter selection, sampling and option validation.
+// (TestNewTracerProvider 测试导出器选择、采样和选项校验。)
+func TestNewTracerProvider(t *testing.T) {
+	ctx := context.Background()
+
+	exporter, err := trace.NewExporter(ctx, trace.NewOptions())
+	require.NoError(t, err)
+	assert.Nil(t, exporter, "no exporter is configured by default")
+
+	opts := trace.NewOptions()
+	opts.Exporter = trace.ExporterOTLP
+	opts.Endpoint = "127.0.0.1:4317"
+	opts.Insecure = true
+	exporter, err = trace.NewExporter(ctx, opts)
+	require.NoError(t, err, "the OTLP exporter connects lazily")
+	require.NoError(t, exporter.Shutdown(ctx))
+
+	opts = trace.NewOptions()
+	opts.Exporter = "jaeger"
+	opts.SampleRatio = 2
+	_, err = trace.NewTracerProvider(ctx, opts, log.ServiceOptions{Name: "checkout"})
+	assert.True(t, lmccerrors.IsCode(err, lmccerrors.ErrTraceOptionInvalid))
+	assert.Len(t, opts.Validate(), 2)
+
+	memory := tracetest.NewInMemoryExporter()
+	opts = trace.NewOptions()
+	opts.SampleRatio = 0
+	tp, err := trace.NewTracerProvider(ctx, opts, log.ServiceOptions{Name: "checkout"}, sdktrace.WithSyncer(memory))
+	require.NoError(t, err)
+	_, span := tp.Tracer("test").Start(ctx, "dropped")
+	span.End()
+	remote := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: oteltrace.FlagsSampled,
+		Remote:     true,
+	})
+	_, span = tp.Tracer("test").Start(oteltrace.ContextWithRemoteSpanContext(ctx, remote), "kept")
+	span.End()
+	require.NoError(t, tp.ForceFlush(ctx))
+	t.Cleanup(func() { _ = tp.Shutdown(ctx) })
+
+	spans := memory.GetSpans()
+	require.Len(t, spans, 1, "root spans are dropped at ratio 0 but sampled parents are honoured")
+	assert.Equal(t, "kept", spans[0].Name)
+	name, ok := spans[0].Resource.Set().Value("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", name.AsString())
+}
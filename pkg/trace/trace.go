@@ -0,0 +1,49 @@
+/*
+ * Author: Martin <lmccc.dev@gmail.com>
+ * Co-Author: AI Assistant
+ * Description: This code was collaboratively developed by Martin and AI Assistant.
+ */
+
+package trace
+
+import (
+	"context"
+	"errors"
+
+	lmccerrors "github.com/lmcc-dev/lmcc-go-sdk/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// Setup 根据选项启用追踪集成，返回用于撤销集成的关闭函数。
+// 追踪关闭时返回空操作的关闭函数。
+// (Setup enables the tracing integration from options and returns a shutdown function that undoes it.)
+// (A no-op shutdown function is returned when tracing is disabled.)
+func Setup(opts *Options) (shutdown func(context.Context) error, err error) {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, lmccerrors.WithCode(
+			lmccerrors.Wrap(errors.Join(errs...), "invalid trace options"),
+			lmccerrors.ErrTraceOptionInvalid,
+		)
+	}
+
+	noop := func(context.Context) error { return nil }
+	if !opts.Enabled {
+		return noop, nil
+	}
+
+	var cleanups []func()
+	if opts.LogCorrelation {
+		level, _ := zapcore.ParseLevel(opts.LogEventLevel)
+		cleanups = append(cleanups, CorrelateLogs(level))
+	}
+
+	return func(context.Context) error {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+		return nil
+	}, nil
+}